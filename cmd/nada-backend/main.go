@@ -29,7 +29,14 @@ import (
 	"github.com/navikt/nada-backend/pkg/service/core/routes"
 	"github.com/navikt/nada-backend/pkg/service/core/storage"
 	"github.com/navikt/nada-backend/pkg/syncers/access_ensurer"
+	"github.com/navikt/nada-backend/pkg/syncers/access_recertification"
+	"github.com/navikt/nada-backend/pkg/syncers/dataset_profiler"
+	"github.com/navikt/nada-backend/pkg/syncers/dataset_sunset"
 	"github.com/navikt/nada-backend/pkg/syncers/metabase"
+	"github.com/navikt/nada-backend/pkg/syncers/notifications"
+	"github.com/navikt/nada-backend/pkg/syncers/polly_revalidation"
+	"github.com/navikt/nada-backend/pkg/syncers/quality_checks"
+	"github.com/navikt/nada-backend/pkg/syncers/recycle_bin"
 	"github.com/navikt/nada-backend/pkg/syncers/teamkatalogen"
 	"github.com/navikt/nada-backend/pkg/syncers/teamprojectsupdater"
 	"github.com/navikt/nada-backend/pkg/tk"
@@ -57,6 +64,14 @@ const (
 	MetabaseUpdateFrequency      = 1 * time.Hour
 	MetabaseCollectionsFrequency = 3600
 	TeamKatalogenFrequency       = 1 * time.Hour
+	DatasetSunsetFrequency       = 1 * time.Hour
+	RecycleBinPurgeFrequency     = 1 * time.Hour
+	NotificationsFrequency       = 1 * time.Hour
+	NotificationSendFrequency    = 15 * time.Second
+	RecertificationFrequency     = 1 * time.Hour
+	PollyRevalidationFrequency   = 24 * time.Hour
+	DatasetProfilerFrequency     = 24 * time.Hour
+	QualityChecksFrequency       = 24 * time.Hour
 )
 
 func main() {
@@ -130,20 +145,11 @@ func main() {
 		ncFetcher,
 		bqClient,
 		csClient,
+		csClient.Buckets(),
 		saClient,
 		cfg,
 		zlog.With().Str("subsystem", "api_clients").Logger(),
 	)
-	services, err := core.NewServices(cfg, stores, apiClients, zlog.With().Str("subsystem", "services").Logger())
-	if err != nil {
-		zlog.Fatal().Err(err).Msg("setting up services")
-	}
-
-	teamProjectsUpdater := teamprojectsupdater.New(
-		services.NaisConsoleService,
-		zlog.With().Str("subsystem", "teamprojectsupdater").Logger(),
-	)
-	go teamProjectsUpdater.Run(ctx, time.Duration(cfg.TeamProjectsUpdateDelaySeconds)*time.Second, TeamProjectsUpdateFrequency)
 
 	googleGroups, err := auth.NewGoogleGroups(
 		ctx,
@@ -154,6 +160,17 @@ func main() {
 		zlog.Fatal().Err(err).Msg("setting up google groups")
 	}
 
+	services, err := core.NewServices(cfg, stores, apiClients, googleGroups, zlog.With().Str("subsystem", "services").Logger())
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("setting up services")
+	}
+
+	teamProjectsUpdater := teamprojectsupdater.New(
+		services.NaisConsoleService,
+		zlog.With().Str("subsystem", "teamprojectsupdater").Logger(),
+	)
+	go teamProjectsUpdater.Run(ctx, time.Duration(cfg.TeamProjectsUpdateDelaySeconds)*time.Second, TeamProjectsUpdateFrequency)
+
 	metabaseSynchronizer := metabase.New(services.MetaBaseService)
 	go metabaseSynchronizer.Run(
 		ctx,
@@ -177,6 +194,54 @@ func main() {
 	)
 	go teamcatalogue.Run(ctx, TeamKatalogenFrequency)
 
+	datasetSunset := dataset_sunset.New(
+		services.LifecycleService,
+		zlog.With().Str("subsystem", "dataset_sunset").Logger(),
+	)
+	go datasetSunset.Run(ctx, DatasetSunsetFrequency)
+
+	recertification := access_recertification.New(
+		services.RecertificationService,
+		zlog.With().Str("subsystem", "access_recertification").Logger(),
+	)
+	go recertification.Run(ctx, RecertificationFrequency)
+
+	pollyRevalidation := polly_revalidation.New(
+		services.PollyService,
+		zlog.With().Str("subsystem", "polly_revalidation").Logger(),
+	)
+	go pollyRevalidation.Run(ctx, PollyRevalidationFrequency)
+
+	datasetProfiler := dataset_profiler.New(
+		services.ProfilingService,
+		zlog.With().Str("subsystem", "dataset_profiler").Logger(),
+	)
+	go datasetProfiler.Run(ctx, DatasetProfilerFrequency)
+
+	qualityChecks := quality_checks.New(
+		services.QualityCheckService,
+		zlog.With().Str("subsystem", "quality_checks").Logger(),
+	)
+	go qualityChecks.Run(ctx, QualityChecksFrequency)
+
+	recycleBin := recycle_bin.New(
+		services.RecycleBinService,
+		zlog.With().Str("subsystem", "recycle_bin_purger").Logger(),
+	)
+	go recycleBin.Run(ctx, RecycleBinPurgeFrequency)
+
+	notifier := notifications.New(
+		services.NotificationService,
+		zlog.With().Str("subsystem", "notifications").Logger(),
+	)
+	go notifier.Run(ctx, NotificationsFrequency)
+
+	deliverer := notifications.NewDeliverer(
+		services.NotificationService,
+		zlog.With().Str("subsystem", "notification_deliverer").Logger(),
+	)
+	go deliverer.Run(ctx, NotificationSendFrequency)
+
 	azureGroups := auth.NewAzureGroups(
 		http.DefaultClient,
 		cfg.Oauth.ClientID,
@@ -234,13 +299,27 @@ func main() {
 	routes.Add(router,
 		routes.NewInsightProductRoutes(routes.NewInsightProductEndpoints(zlog, h.InsightProductHandler), authenticatorMiddleware),
 		routes.NewAccessRoutes(routes.NewAccessEndpoints(zlog, h.AccessHandler), authenticatorMiddleware),
+		routes.NewAccessApprovalRulesRoutes(routes.NewAccessApprovalRulesEndpoints(zlog, h.AccessApprovalRulesHandler), authenticatorMiddleware),
 		routes.NewBigQueryRoutes(routes.NewBigQueryEndpoints(zlog, h.BigQueryHandler)),
 		routes.NewDataProductsRoutes(routes.NewDataProductsEndpoints(zlog, h.DataProductsHandler), authenticatorMiddleware),
+		routes.NewDataproductTransferRoutes(routes.NewDataproductTransferEndpoints(zlog, h.DataproductTransferHandler), authenticatorMiddleware),
+		routes.NewDatasetPreviewRoutes(routes.NewDatasetPreviewEndpoints(zlog, h.DatasetPreviewHandler), authenticatorMiddleware),
+		routes.NewLifecycleRoutes(routes.NewLifecycleEndpoints(zlog, h.LifecycleHandler), authenticatorMiddleware),
+		routes.NewNotificationsRoutes(routes.NewNotificationsEndpoints(zlog, h.NotificationsHandler), authenticatorMiddleware),
+		routes.NewRecycleBinRoutes(routes.NewRecycleBinEndpoints(zlog, h.RecycleBinHandler), authenticatorMiddleware),
+		routes.NewCatalogueApplyRoutes(routes.NewCatalogueApplyEndpoints(zlog, h.CatalogueApplyHandler), h.StoryHandler.NadaTokenMiddleware),
+		routes.NewCatalogueExportRoutes(routes.NewCatalogueExportEndpoints(zlog, h.CatalogueExportHandler)),
+		routes.NewComplianceRoutes(routes.NewComplianceEndpoints(zlog, h.ComplianceHandler), authenticatorMiddleware),
+		routes.NewDbtRoutes(routes.NewDbtEndpoints(zlog, h.DbtHandler), h.StoryHandler.NadaTokenMiddleware),
 		routes.NewJoinableViewsRoutes(routes.NewJoinableViewsEndpoints(zlog, h.JoinableViewsHandler), authenticatorMiddleware),
 		routes.NewKeywordRoutes(routes.NewKeywordEndpoints(zlog, h.KeywordsHandler), authenticatorMiddleware),
 		routes.NewMetabaseRoutes(routes.NewMetabaseEndpoints(zlog, h.MetabaseHandler), authenticatorMiddleware),
-		routes.NewPollyRoutes(routes.NewPollyEndpoints(zlog, h.PollyHandler)),
+		routes.NewPoliciesRoutes(routes.NewPoliciesEndpoints(zlog, h.PoliciesHandler), authenticatorMiddleware),
+		routes.NewRecertificationRoutes(routes.NewRecertificationEndpoints(zlog, h.RecertificationHandler), authenticatorMiddleware),
+		routes.NewPollyRoutes(routes.NewPollyEndpoints(zlog, h.PollyHandler), authenticatorMiddleware),
+		routes.NewProfilingRoutes(routes.NewProfilingEndpoints(zlog, h.ProfilingHandler), authenticatorMiddleware),
 		routes.NewProductAreaRoutes(routes.NewProductAreaEndpoints(zlog, h.ProductAreasHandler)),
+		routes.NewQualityCheckRoutes(routes.NewQualityCheckEndpoints(zlog, h.QualityCheckHandler), authenticatorMiddleware),
 		routes.NewSearchRoutes(routes.NewSearchEndpoints(zlog, h.SearchHandler)),
 		routes.NewSlackRoutes(routes.NewSlackEndpoints(zlog, h.SlackHandler)),
 		routes.NewStoryRoutes(routes.NewStoryEndpoints(zlog, h.StoryHandler), authenticatorMiddleware, h.StoryHandler.NadaTokenMiddleware),
@@ -272,6 +351,7 @@ func main() {
 	go access_ensurer.NewEnsurer(
 		googleGroups,
 		cfg.BigQuery.CentralGCPProject,
+		cfg.CrossTeamPseudonymization.JoinableViewsCleanup,
 		promErrs,
 		stores.AccessStorage,
 		services.MetaBaseService,
@@ -280,6 +360,8 @@ func main() {
 		apiClients.BigQueryAPI,
 		services.BigQueryService,
 		services.JoinableViewService,
+		stores.DatasourceStorage,
+		apiClients.DatasourceProviders,
 		zlog.With().Str("subsystem", "accessensurer").Logger(),
 	).Run(ctx, AccessEnsurerFrequency)
 
@@ -27,6 +27,7 @@ type Operations interface {
 	GetDatasets(ctx context.Context, projectID string) ([]*Dataset, error)
 	GetTable(ctx context.Context, projectID, datasetID, tableID string) (*Table, error)
 	GetTables(ctx context.Context, projectID, datasetID string) ([]*Table, error)
+	ListTableRows(ctx context.Context, projectID, datasetID, tableID string, maxRows int) (*TableRows, error)
 	CreateDataset(ctx context.Context, projectID, datasetID, region string) error
 	CreateDatasetIfNotExists(ctx context.Context, projectID, datasetID, region string) error
 	CreateTable(ctx context.Context, input *Table) error
@@ -34,7 +35,10 @@ type Operations interface {
 	DeleteTable(ctx context.Context, projectID, datasetID, tableID string) error
 	DeleteDataset(ctx context.Context, projectID, datasetID string, deleteContents bool) error
 	QueryAndWait(ctx context.Context, projectID, query string) (*JobStatistics, error)
+	QueryAndRead(ctx context.Context, projectID, query string, maxRows int, maxBytesBilled int64) (*QueryResult, error)
+	DryRunQuery(ctx context.Context, projectID, query string) (*JobStatistics, error)
 	AddDatasetRoleAccessEntry(ctx context.Context, projectID, datasetID string, input *AccessEntry) error
+	RemoveDatasetRoleAccessEntry(ctx context.Context, projectID, datasetID string, input *AccessEntry) error
 	AddDatasetViewAccessEntry(ctx context.Context, projectID, datasetID string, view *View) error
 	AddAndSetTablePolicy(ctx context.Context, projectID, datasetID, tableID, role, member string) error
 	RemoveAndSetTablePolicy(ctx context.Context, projectID, datasetID, tableID, role, member string) error
@@ -280,11 +284,29 @@ func (c Column) Validate() error {
 	)
 }
 
+// TableRows is a sample of the rows in a table, the values of each row are
+// in the same order as the columns in the schema
+type TableRows struct {
+	Schema []*Column
+	Rows   [][]any
+}
+
+// QueryResult is the result of a query, the values of each row are in the
+// same order as the columns in the schema
+type QueryResult struct {
+	Statistics *JobStatistics
+	Schema     []*Column
+	Rows       [][]any
+}
+
 type JobStatistics struct {
 	CreationTime        time.Time
 	StartTime           time.Time
 	EndTime             time.Time
 	TotalBytesProcessed int64
+	// ReferencedTables are the tables read by a query, on the form
+	// project.dataset.table
+	ReferencedTables []string
 }
 
 func (c *Client) GetDataset(ctx context.Context, projectID, datasetID string) (*Dataset, error) {
@@ -394,6 +416,11 @@ func (c *Client) GetTables(ctx context.Context, projectID, datasetID string) ([]
 				break
 			}
 
+			var gerr *googleapi.Error
+			if errors.As(err, &gerr) && gerr.Code == http.StatusNotFound {
+				return nil, ErrNotExist
+			}
+
 			return nil, fmt.Errorf("iterating tables: %w", err)
 		}
 
@@ -422,6 +449,59 @@ func (c *Client) GetTable(ctx context.Context, projectID, datasetID, tableID str
 	return table, nil
 }
 
+// ListTableRows reads at most maxRows rows directly from the table storage,
+// using the tabledata.list API, which does not run a query
+func (c *Client) ListTableRows(ctx context.Context, projectID, datasetID, tableID string, maxRows int) (*TableRows, error) {
+	client, err := c.clientFromProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("table rows: %w", err)
+	}
+
+	it := client.Dataset(datasetID).Table(tableID).Read(ctx)
+	it.PageInfo().MaxSize = maxRows
+
+	rows := [][]any{}
+	for len(rows) < maxRows {
+		var row []bigquery.Value
+
+		err := it.Next(&row)
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+
+			var gerr *googleapi.Error
+			if errors.As(err, &gerr) && gerr.Code == http.StatusNotFound {
+				return nil, ErrNotExist
+			}
+
+			return nil, fmt.Errorf("reading rows %s.%s.%s: %w", projectID, datasetID, tableID, err)
+		}
+
+		values := make([]any, len(row))
+		for i, v := range row {
+			values[i] = v
+		}
+
+		rows = append(rows, values)
+	}
+
+	schema := fieldSchemaToSchema(it.Schema)
+	if schema == nil {
+		table, err := c.getTableWithMetadata(ctx, client, datasetID, tableID)
+		if err != nil {
+			return nil, err
+		}
+
+		schema = table.Schema
+	}
+
+	return &TableRows{
+		Schema: schema,
+		Rows:   rows,
+	}, nil
+}
+
 func schemaToFieldSchema(schema []*Column) []*bigquery.FieldSchema {
 	if len(schema) == 0 {
 		return nil
@@ -693,37 +773,121 @@ func DatasetNameWithRandomPostfix(name string) string {
 }
 
 func (c *Client) QueryAndWait(ctx context.Context, projectID, query string) (*JobStatistics, error) {
+	_, status, err := c.runAndWait(ctx, projectID, query, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobStatistics(status), nil
+}
+
+// QueryAndRead runs the query, waits for it to complete, and reads at most
+// maxRows rows of the result. The query fails without being billed if it
+// would bill more than maxBytesBilled bytes, zero means no limit
+func (c *Client) QueryAndRead(ctx context.Context, projectID, query string, maxRows int, maxBytesBilled int64) (*QueryResult, error) {
+	job, status, err := c.runAndWait(ctx, projectID, query, maxBytesBilled)
+	if err != nil {
+		return nil, err
+	}
+
+	it, err := job.Read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading query result: %w", err)
+	}
+
+	rows := [][]any{}
+	for len(rows) < maxRows {
+		var row []bigquery.Value
+
+		err := it.Next(&row)
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+
+			return nil, fmt.Errorf("reading query result: %w", err)
+		}
+
+		values := make([]any, len(row))
+		for i, v := range row {
+			values[i] = v
+		}
+
+		rows = append(rows, values)
+	}
+
+	return &QueryResult{
+		Statistics: jobStatistics(status),
+		Schema:     fieldSchemaToSchema(it.Schema),
+		Rows:       rows,
+	}, nil
+}
+
+// DryRunQuery validates the query without running it, the statistics
+// contain the number of bytes the query would process
+func (c *Client) DryRunQuery(ctx context.Context, projectID, query string) (*JobStatistics, error) {
+	client, err := c.clientFromProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("dry run query: %w", err)
+	}
+
+	q := client.Query(query)
+	q.DryRun = true
+
+	job, err := q.Run(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("dry running query: %w", err)
+	}
+
+	return jobStatistics(job.LastStatus()), nil
+}
+
+func (c *Client) runAndWait(ctx context.Context, projectID, query string, maxBytesBilled int64) (*bigquery.Job, *bigquery.JobStatus, error) {
 	client, err := c.clientFromProject(context.Background(), projectID)
 	if err != nil {
-		return nil, fmt.Errorf("query and wait: %w", err)
+		return nil, nil, fmt.Errorf("query and wait: %w", err)
 	}
 
-	job, err := client.Query(query).Run(context.Background())
+	q := client.Query(query)
+	q.MaxBytesBilled = maxBytesBilled
+
+	job, err := q.Run(context.Background())
 	if err != nil {
-		return nil, fmt.Errorf("running query: %w", err)
+		return nil, nil, fmt.Errorf("running query: %w", err)
 	}
 
 	status, err := job.Wait(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("waiting for query: %w", err)
+		return nil, nil, fmt.Errorf("waiting for query: %w", err)
 	}
 
 	err = status.Err()
 	if err != nil {
-		return nil, fmt.Errorf("query failed: %w", err)
+		return nil, nil, fmt.Errorf("query failed: %w", err)
 	}
 
-	var stats *JobStatistics
-	if status.Statistics != nil {
-		stats = &JobStatistics{
-			CreationTime:        status.Statistics.CreationTime,
-			StartTime:           status.Statistics.StartTime,
-			EndTime:             status.Statistics.EndTime,
-			TotalBytesProcessed: status.Statistics.TotalBytesProcessed,
+	return job, status, nil
+}
+
+func jobStatistics(status *bigquery.JobStatus) *JobStatistics {
+	if status == nil || status.Statistics == nil {
+		return nil
+	}
+
+	stats := &JobStatistics{
+		CreationTime:        status.Statistics.CreationTime,
+		StartTime:           status.Statistics.StartTime,
+		EndTime:             status.Statistics.EndTime,
+		TotalBytesProcessed: status.Statistics.TotalBytesProcessed,
+	}
+
+	if details, ok := status.Statistics.Details.(*bigquery.QueryStatistics); ok {
+		for _, t := range details.ReferencedTables {
+			stats.ReferencedTables = append(stats.ReferencedTables, fmt.Sprintf("%s.%s.%s", t.ProjectID, t.DatasetID, t.TableID))
 		}
 	}
 
-	return stats, nil
+	return stats
 }
 
 func (c *Client) AddDatasetRoleAccessEntry(ctx context.Context, projectID, datasetID string, input *AccessEntry) error {
@@ -798,6 +962,68 @@ func (c *Client) AddDatasetRoleAccessEntry(ctx context.Context, projectID, datas
 	return nil
 }
 
+func (c *Client) RemoveDatasetRoleAccessEntry(ctx context.Context, projectID, datasetID string, input *AccessEntry) error {
+	err := input.Validate()
+	if err != nil {
+		return fmt.Errorf("validating role access entry: %w", err)
+	}
+
+	client, err := c.clientFromProject(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("removing access entry: %w", err)
+	}
+
+	var entityType bigquery.EntityType
+	switch input.EntityType {
+	case UserEmailEntity:
+		entityType = bigquery.UserEmailEntity
+	case GroupEmailEntity:
+		entityType = bigquery.GroupEmailEntity
+	case ViewEntity:
+		return fmt.Errorf("view entity should be removed via own function")
+	default:
+		return fmt.Errorf("unknown entity type %v", entityType)
+	}
+
+	c.log.Info().Fields(map[string]interface{}{
+		"project":     projectID,
+		"dataset":     datasetID,
+		"entity_type": entityType,
+		"entity":      input.Entity,
+		"role":        string(input.Role),
+	}).Msg("removing_access")
+
+	ds := client.Dataset(datasetID)
+
+	meta, err := ds.Metadata(ctx)
+	if err != nil {
+		return fmt.Errorf("getting dataset metadata %s.%s: %w", projectID, datasetID, err)
+	}
+
+	var access []*bigquery.AccessEntry
+
+	for _, a := range meta.Access {
+		if a.EntityType == entityType && a.Entity == input.Entity && a.Role == bigquery.AccessRole(input.Role) {
+			continue
+		}
+
+		access = append(access, a)
+	}
+
+	if len(access) == len(meta.Access) {
+		return nil
+	}
+
+	_, err = ds.Update(ctx, bigquery.DatasetMetadataToUpdate{
+		Access: access,
+	}, meta.ETag)
+	if err != nil {
+		return fmt.Errorf("updating dataset metadata %s.%s: %w", projectID, datasetID, err)
+	}
+
+	return nil
+}
+
 func (c *Client) AddDatasetViewAccessEntry(ctx context.Context, projectID, datasetID string, input *View) error {
 	err := input.Validate()
 	if err != nil {
@@ -854,6 +1080,10 @@ func (c *Client) AddAndSetTablePolicy(ctx context.Context, projectID, datasetID,
 		return fmt.Errorf("getting table policy: %w", err)
 	}
 
+	if policy.HasRole(member, iam.RoleName(role)) {
+		return nil
+	}
+
 	policy.Add(member, iam.RoleName(role))
 
 	err = client.Dataset(datasetID).Table(tableID).IAM().SetPolicy(ctx, policy)
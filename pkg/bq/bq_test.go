@@ -327,6 +327,87 @@ func TestClient_GetTable(t *testing.T) {
 	}
 }
 
+func TestClient_ListTableRows(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		projectID string
+		datasetID string
+		tableID   string
+		maxRows   int
+		schema    *emulator.Dataset
+		expect    any
+		expectErr bool
+	}{
+		{
+			name:      "success",
+			projectID: "test-project",
+			datasetID: "test-dataset",
+			tableID:   "test-table",
+			maxRows:   2,
+			schema: &emulator.Dataset{
+				DatasetID: "test-dataset",
+				TableID:   "test-table",
+				Columns: []*types.Column{
+					emulator.ColumnNullable("name"),
+				},
+				Rows: types.Data{
+					{"name": "first"},
+					{"name": "second"},
+					{"name": "third"},
+				},
+			},
+			expect: &bq.TableRows{
+				Schema: []*bq.Column{
+					{
+						Name: "name",
+						Type: bq.StringFieldType,
+						Mode: bq.NullableMode,
+					},
+				},
+				Rows: [][]any{
+					{"first"},
+					{"second"},
+				},
+			},
+		},
+		{
+			name:      "not found",
+			projectID: "test-project",
+			datasetID: "test-dataset",
+			tableID:   "test-table",
+			maxRows:   2,
+			expect:    bq.ErrNotExist,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := emulator.New(zerolog.New(os.Stdout))
+			defer s.Cleanup()
+
+			s.WithProject(tc.projectID, tc.schema)
+			s.TestServer()
+
+			c := bq.NewClient(s.Endpoint(), false, zerolog.Nop())
+
+			got, err := c.ListTableRows(context.Background(), tc.projectID, tc.datasetID, tc.tableID, tc.maxRows)
+			if tc.expectErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+				assert.Equal(t, tc.expect, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expect, got)
+			}
+		})
+	}
+}
+
 func TestClient_CreateDataset(t *testing.T) {
 	t.Parallel()
 
@@ -1046,6 +1127,91 @@ func TestClient_QueryAndWait(t *testing.T) {
 	}
 }
 
+func TestClient_QueryAndRead(t *testing.T) {
+	t.Parallel()
+
+	fileFromYAML := func(t *testing.T, data string) string {
+		dir := t.TempDir()
+
+		testFilePath := filepath.Join(dir, "test.yaml")
+
+		err := os.WriteFile(testFilePath, []byte(data), 0o644)
+		assert.NoError(t, err)
+
+		return testFilePath
+	}
+
+	testCases := []struct {
+		name      string
+		projectID string
+		query     string
+		maxRows   int
+		expect    any
+		expectErr bool
+	}{
+		{
+			name:      "should work",
+			projectID: "test-project",
+			query:     "SELECT COUNT(*) AS row_count, MAX(name) AS max_name FROM `test-dataset.test-table`",
+			maxRows:   10,
+			expect: &bq.QueryResult{
+				Schema: []*bq.Column{
+					{Name: "row_count", Type: bq.IntegerFieldType, Mode: bq.NullableMode},
+					{Name: "max_name", Type: bq.StringFieldType, Mode: bq.NullableMode},
+				},
+				Rows: [][]any{
+					{int64(2), "bob"},
+				},
+			},
+		},
+		{
+			name:      "should limit rows",
+			projectID: "test-project",
+			query:     "SELECT name FROM `test-dataset.test-table` ORDER BY id",
+			maxRows:   1,
+			expect: &bq.QueryResult{
+				Schema: []*bq.Column{
+					{Name: "name", Type: bq.StringFieldType, Mode: bq.NullableMode},
+				},
+				Rows: [][]any{
+					{"alice"},
+				},
+			},
+		},
+		{
+			name:      "should fail",
+			projectID: "test-project",
+			query:     "SELECT * FROM `test-dataset.test-table-nope`",
+			maxRows:   10,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := emulator.New(zerolog.New(os.Stdout))
+			defer s.Cleanup()
+
+			s.WithSource(tc.projectID, server.YAMLSource(fileFromYAML(t, testData)))
+			s.TestServer()
+
+			c := bq.NewClient(s.Endpoint(), false, zerolog.Nop())
+
+			got, err := c.QueryAndRead(context.Background(), tc.projectID, tc.query, tc.maxRows, 0)
+			if tc.expectErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				diff := cmp.Diff(tc.expect, got, cmpopts.IgnoreFields(bq.QueryResult{}, "Statistics"))
+				assert.Empty(t, diff)
+			}
+		})
+	}
+}
+
 // A little bit unsure if this actually does anything behind the scenes
 func TestClient_AddDatasetRoleAccessEntry(t *testing.T) {
 	t.Parallel()
@@ -1096,6 +1262,82 @@ func TestClient_AddDatasetRoleAccessEntry(t *testing.T) {
 	}
 }
 
+func TestClient_RemoveDatasetRoleAccessEntry(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		projectID string
+		datasetID string
+		schema    *emulator.Dataset
+		input     *bq.AccessEntry
+		expectErr bool
+	}{
+		{
+			name:      "success",
+			projectID: "test-project",
+			datasetID: "test-dataset",
+			input: &bq.AccessEntry{
+				Role:       bq.ReaderRole,
+				Entity:     "bob@example.com",
+				EntityType: bq.UserEmailEntity,
+			},
+			schema: &emulator.Dataset{
+				DatasetID: "test-dataset",
+			},
+		},
+		{
+			name:      "view entity",
+			projectID: "test-project",
+			datasetID: "test-dataset",
+			input: &bq.AccessEntry{
+				Role:       bq.ReaderRole,
+				Entity:     "bob@example.com",
+				EntityType: bq.ViewEntity,
+			},
+			schema: &emulator.Dataset{
+				DatasetID: "test-dataset",
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := emulator.New(zerolog.New(os.Stdout))
+			defer s.Cleanup()
+
+			s.WithProject(tc.projectID, tc.schema)
+			s.TestServer()
+
+			c := bq.NewClient(s.Endpoint(), false, zerolog.Nop())
+
+			err := c.AddDatasetRoleAccessEntry(context.Background(), tc.projectID, tc.datasetID, &bq.AccessEntry{
+				Role:       tc.input.Role,
+				Entity:     tc.input.Entity,
+				EntityType: bq.UserEmailEntity,
+			})
+			assert.NoError(t, err)
+
+			err = c.RemoveDatasetRoleAccessEntry(context.Background(), tc.projectID, tc.datasetID, tc.input)
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+
+				ds, err := c.GetDataset(context.Background(), tc.projectID, tc.datasetID)
+				assert.NoError(t, err)
+
+				for _, a := range ds.Access {
+					assert.NotEqual(t, tc.input.Entity, a.Entity)
+				}
+			}
+		})
+	}
+}
+
 // A little bit unsure if this actually does anything behind the scenes
 func TestClient_AddDatasetViewAccessEntry(t *testing.T) {
 	t.Parallel()
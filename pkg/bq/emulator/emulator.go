@@ -32,6 +32,7 @@ type Dataset struct {
 	DatasetID string
 	TableID   string
 	Columns   []*types.Column
+	Rows      types.Data
 }
 
 func ColumnNullable(name string) *types.Column {
@@ -131,7 +132,8 @@ func (e *Emulator) WithProject(projectID string, datasets ...*Dataset) {
 
 		if ds.TableID != "" {
 			t := &types.Table{
-				ID: ds.TableID,
+				ID:   ds.TableID,
+				Data: ds.Rows,
 			}
 
 			t.Columns = append(t.Columns, ds.Columns...)
@@ -47,10 +47,14 @@ type Config struct {
 	NaisConsole               NaisConsole               `yaml:"nais_console"`
 	API                       API                       `yaml:"api"`
 	ServiceAccount            ServiceAccount            `yaml:"service_account"`
+	DCAT                      DCAT                      `yaml:"dcat"`
+	SMTP                      SMTP                      `yaml:"smtp"`
 
 	EmailSuffix                    string `yaml:"email_suffix"`
 	NaisClusterName                string `yaml:"nais_cluster_name"`
 	KeywordsAdminGroup             string `yaml:"keywords_admin_group"`
+	PolicyAdminGroup               string `yaml:"policy_admin_group"`
+	ComplianceAdminGroup           string `yaml:"compliance_admin_group"`
 	AllUsersGroup                  string `yaml:"all_users_group"`
 	LoginPage                      string `yaml:"login_page"`
 	AmplitudeAPIKey                string `yaml:"amplitude_api_key"`
@@ -81,7 +85,11 @@ func (c Config) Validate() error {
 		validation.Field(&c.CrossTeamPseudonymization, validation.Required),
 		validation.Field(&c.GCS, validation.Required),
 		validation.Field(&c.BigQuery, validation.Required),
+		validation.Field(&c.DCAT),
+		validation.Field(&c.SMTP),
 		validation.Field(&c.KeywordsAdminGroup, validation.Required),
+		validation.Field(&c.PolicyAdminGroup, validation.Required),
+		validation.Field(&c.ComplianceAdminGroup, validation.Required),
 		validation.Field(&c.NaisClusterName, validation.Required),
 		validation.Field(&c.EmailSuffix, validation.Required),
 		validation.Field(&c.CacheDurationSeconds, validation.Required),
@@ -89,6 +97,39 @@ func (c Config) Validate() error {
 	)
 }
 
+// DCAT describes the catalogue in the DCAT-AP export, which is harvested
+// by the national data catalogue
+type DCAT struct {
+	Title         string `yaml:"title"`
+	PublisherName string `yaml:"publisher_name"`
+	PublisherURI  string `yaml:"publisher_uri"`
+	LicenseURI    string `yaml:"license_uri"`
+}
+
+func (d DCAT) Validate() error {
+	return validation.ValidateStruct(&d,
+		validation.Field(&d.PublisherURI, is.URL),
+		validation.Field(&d.LicenseURI, is.URL),
+	)
+}
+
+// SMTP is the mail server used for email notifications, emails are only
+// logged when no host is set
+type SMTP struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	From     string `yaml:"from"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+func (s SMTP) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Port, validation.When(s.Host != "", validation.Required)),
+		validation.Field(&s.From, validation.When(s.Host != "", validation.Required, is.EmailFormat)),
+	)
+}
+
 type ServiceAccount struct {
 	EndpointOverride string `yaml:"endpoint"`
 	DisableAuth      bool   `yaml:"disable_auth"`
@@ -153,6 +194,9 @@ func (t TeamsCatalogue) Validate() error {
 type Slack struct {
 	Token      string `yaml:"token"`
 	WebhookURL string `yaml:"webhook_url"`
+	// SigningSecret verifies that interactions are sent by Slack, they are
+	// rejected when it is not set
+	SigningSecret string `yaml:"signing_secret"`
 }
 
 func (s Slack) Validate() error {
@@ -307,6 +351,9 @@ func (o Oauth) Validate() error {
 type CrossTeamPseudonymization struct {
 	GCPProjectID string `yaml:"gcp_project_id"`
 	GCPRegion    string `yaml:"gcp_region"`
+	// JoinableViewsCleanup enables deleting expired and orphaned joinable views,
+	// and revoking grants that are no longer valid, in the access ensurer
+	JoinableViewsCleanup bool `yaml:"joinable_views_cleanup"`
 }
 
 func (p *CrossTeamPseudonymization) Validate() error {
@@ -337,6 +384,9 @@ type BigQuery struct {
 	TeamProjectPseudoViewsDatasetName string `yaml:"team_project_pseudo_views_dataset_name"`
 	GCPRegion                         string `yaml:"gcp_region"`
 	CentralGCPProject                 string `yaml:"central_gcp_project"`
+	// ProfilingBytesBudget is the number of bytes the profiling of the
+	// datasources can process in each run, a default is used if not set
+	ProfilingBytesBudget int64 `yaml:"profiling_bytes_budget"`
 }
 
 func (b BigQuery) Validate() error {
@@ -38,8 +38,9 @@ func newFakeConfig() config.Config {
 			MappingFrequencySec: 600,
 		},
 		CrossTeamPseudonymization: config.CrossTeamPseudonymization{
-			GCPProjectID: "some-project",
-			GCPRegion:    "eu-north1",
+			GCPProjectID:         "some-project",
+			GCPRegion:            "eu-north1",
+			JoinableViewsCleanup: true,
 		},
 		GCS: config.GCS{
 			Endpoint:          "http://localhost:9090",
@@ -51,10 +52,12 @@ func newFakeConfig() config.Config {
 			TeamProjectPseudoViewsDatasetName: "some-dataset",
 			GCPRegion:                         "eu-north1",
 			CentralGCPProject:                 "central-project",
+			ProfilingBytesBudget:              1073741824,
 		},
 		Slack: config.Slack{
-			Token:      "fake_token",
-			WebhookURL: "http://localhost:8080/webhook",
+			Token:         "fake_token",
+			WebhookURL:    "http://localhost:8080/webhook",
+			SigningSecret: "fake_signing_secret",
 		},
 		Server: config.Server{
 			Hostname: "localhost",
@@ -124,9 +127,22 @@ func newFakeConfig() config.Config {
 			EndpointOverride: "http://localhost:8086",
 			DisableAuth:      true,
 		},
+		DCAT: config.DCAT{
+			Title:         "Datamarkedsplassen",
+			PublisherName: "Some Organisation",
+			PublisherURI:  "http://localhost:8080/organisation",
+			LicenseURI:    "http://localhost:8080/license",
+		},
+		SMTP: config.SMTP{
+			Host: "localhost",
+			Port: 1025,
+			From: "nada@nav.no",
+		},
 		EmailSuffix:                    "@nav.no",
 		NaisClusterName:                "dev-gcp",
 		KeywordsAdminGroup:             "nada@nav.no",
+		PolicyAdminGroup:               "nada@nav.no",
+		ComplianceAdminGroup:           "nada@nav.no",
 		AllUsersGroup:                  "group:all-users@nav.no",
 		LoginPage:                      "http://localhost:8080/",
 		AmplitudeAPIKey:                "fake_key",
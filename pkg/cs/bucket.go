@@ -0,0 +1,211 @@
+package cs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/iam"
+	"cloud.google.com/go/iam/apiv1/iampb"
+	"cloud.google.com/go/storage"
+
+	"google.golang.org/api/iterator"
+	"google.golang.org/genproto/googleapis/type/expr"
+)
+
+const (
+	// ObjectViewerRole allows reading objects and their metadata in a bucket.
+	ObjectViewerRole = "roles/storage.objectViewer"
+)
+
+// BucketOperations works on arbitrary buckets, as opposed to Operations,
+// which is bound to the bucket the Client was created with.
+type BucketOperations interface {
+	GetBucket(ctx context.Context, bucket string) (*Bucket, error)
+	GetBucketObjects(ctx context.Context, bucket string, q *Query) ([]*Object, error)
+	AddBucketMember(ctx context.Context, bucket, prefix, role, member string) error
+	RemoveBucketMember(ctx context.Context, bucket, prefix, role, member string) error
+}
+
+type BucketClient struct {
+	client *storage.Client
+}
+
+type Bucket struct {
+	Name     string
+	Location string
+	Created  time.Time
+	Updated  time.Time
+	Labels   map[string]string
+}
+
+func (c *BucketClient) GetBucket(ctx context.Context, bucket string) (*Bucket, error) {
+	attrs, err := c.client.Bucket(bucket).Attrs(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrBucketNotExist) {
+			return nil, ErrBucketNotExist
+		}
+
+		return nil, fmt.Errorf("getting bucket attributes: %w", err)
+	}
+
+	return &Bucket{
+		Name:     attrs.Name,
+		Location: attrs.Location,
+		Created:  attrs.Created,
+		Updated:  attrs.Updated,
+		Labels:   attrs.Labels,
+	}, nil
+}
+
+func (c *BucketClient) GetBucketObjects(ctx context.Context, bucket string, q *Query) ([]*Object, error) {
+	var objects []*Object
+
+	var query *storage.Query
+	if q != nil {
+		query = &storage.Query{
+			Prefix: q.Prefix,
+		}
+	}
+
+	it := c.client.Bucket(bucket).Objects(ctx, query)
+	if q != nil && q.Limit > 0 {
+		it.PageInfo().MaxSize = q.Limit
+	}
+
+	for {
+		if q != nil && q.Limit > 0 && len(objects) >= q.Limit {
+			break
+		}
+
+		obj, err := it.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+
+			if errors.Is(err, storage.ErrBucketNotExist) {
+				return nil, ErrBucketNotExist
+			}
+
+			return nil, fmt.Errorf("iterating objects: %w", err)
+		}
+
+		objects = append(objects, &Object{
+			Name:   obj.Name,
+			Bucket: obj.Bucket,
+			Attrs: Attributes{
+				ContentType:     obj.ContentType,
+				ContentEncoding: obj.ContentEncoding,
+				Size:            obj.Size,
+				SizeStr:         strconv.FormatInt(obj.Size, 10),
+			},
+		})
+	}
+
+	return objects, nil
+}
+
+// AddBucketMember grants the role on the objects below the prefix, with an
+// IAM condition on the object name when the prefix is not empty. Conditions
+// require uniform bucket-level access to be enabled on the bucket.
+func (c *BucketClient) AddBucketMember(ctx context.Context, bucket, prefix, role, member string) error {
+	condition := PrefixCondition(bucket, prefix)
+
+	return c.updateBucketPolicy(ctx, bucket, func(policy *iam.Policy3) {
+		b := findBinding(policy.Bindings, role, condition)
+		if b == nil {
+			b = &iampb.Binding{
+				Role:      role,
+				Condition: condition,
+			}
+			policy.Bindings = append(policy.Bindings, b)
+		}
+
+		if !slices.Contains(b.Members, member) {
+			b.Members = append(b.Members, member)
+		}
+	})
+}
+
+func (c *BucketClient) RemoveBucketMember(ctx context.Context, bucket, prefix, role, member string) error {
+	condition := PrefixCondition(bucket, prefix)
+
+	return c.updateBucketPolicy(ctx, bucket, func(policy *iam.Policy3) {
+		b := findBinding(policy.Bindings, role, condition)
+		if b == nil {
+			return
+		}
+
+		b.Members = slices.DeleteFunc(b.Members, func(m string) bool {
+			return m == member
+		})
+
+		policy.Bindings = slices.DeleteFunc(policy.Bindings, func(b *iampb.Binding) bool {
+			return len(b.Members) == 0
+		})
+	})
+}
+
+// PrefixCondition returns the IAM condition limiting a binding to the objects
+// below the prefix, or nil when the binding covers the whole bucket.
+func PrefixCondition(bucket, prefix string) *expr.Expr {
+	if prefix == "" {
+		return nil
+	}
+
+	return &expr.Expr{
+		Title:      "nada-prefix",
+		Expression: fmt.Sprintf("resource.name.startsWith(%q)", fmt.Sprintf("projects/_/buckets/%s/objects/%s", bucket, prefix)),
+	}
+}
+
+func findBinding(bindings []*iampb.Binding, role string, condition *expr.Expr) *iampb.Binding {
+	for _, b := range bindings {
+		if b.Role != role {
+			continue
+		}
+
+		if b.Condition.GetExpression() == condition.GetExpression() {
+			return b
+		}
+	}
+
+	return nil
+}
+
+func (c *BucketClient) updateBucketPolicy(ctx context.Context, bucket string, update func(policy *iam.Policy3)) error {
+	handle := c.client.Bucket(bucket).IAM().V3()
+
+	policy, err := handle.Policy(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrBucketNotExist) {
+			return ErrBucketNotExist
+		}
+
+		return fmt.Errorf("getting bucket policy: %w", err)
+	}
+
+	update(policy)
+
+	err = handle.SetPolicy(ctx, policy)
+	if err != nil {
+		return fmt.Errorf("setting bucket policy: %w", err)
+	}
+
+	return nil
+}
+
+// Buckets returns a BucketClient sharing the underlying storage client.
+func (c *Client) Buckets() *BucketClient {
+	return NewBucketClient(c.client)
+}
+
+func NewBucketClient(client *storage.Client) *BucketClient {
+	return &BucketClient{
+		client: client,
+	}
+}
@@ -0,0 +1,94 @@
+package cs_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fsouza/fake-gcs-server/fakestorage"
+	"github.com/navikt/nada-backend/pkg/cs"
+	"github.com/navikt/nada-backend/pkg/cs/emulator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketClient_GetBucket(t *testing.T) {
+	testCases := []struct {
+		name         string
+		bucket       string
+		createBucket bool
+		expectErr    bool
+		expect       any
+	}{
+		{
+			name:         "bucket exists",
+			bucket:       "some-bucket",
+			createBucket: true,
+			expect:       "some-bucket",
+		},
+		{
+			name:      "no such bucket",
+			bucket:    "some-bucket",
+			expectErr: true,
+			expect:    cs.ErrBucketNotExist,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := emulator.New(t, nil)
+			defer e.Cleanup()
+
+			if tc.createBucket {
+				e.CreateBucket(tc.bucket)
+			}
+
+			client := cs.NewBucketClient(e.Client())
+
+			got, err := client.GetBucket(context.Background(), tc.bucket)
+			if tc.expectErr {
+				assert.ErrorIs(t, err, tc.expect.(error))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expect, got.Name)
+			}
+		})
+	}
+}
+
+func TestBucketClient_GetBucketObjects(t *testing.T) {
+	e := emulator.New(t, []fakestorage.Object{
+		{
+			ObjectAttrs: fakestorage.ObjectAttrs{
+				BucketName: "some-bucket",
+				Name:       "exports/2024/data.parquet",
+			},
+			Content: []byte("data"),
+		},
+		{
+			ObjectAttrs: fakestorage.ObjectAttrs{
+				BucketName: "some-bucket",
+				Name:       "other/file.txt",
+			},
+		},
+	})
+	defer e.Cleanup()
+
+	client := cs.NewBucketClient(e.Client())
+
+	objects, err := client.GetBucketObjects(context.Background(), "some-bucket", &cs.Query{Prefix: "exports/"})
+	assert.NoError(t, err)
+	assert.Len(t, objects, 1)
+	assert.Equal(t, "exports/2024/data.parquet", objects[0].Name)
+	assert.Equal(t, int64(4), objects[0].Attrs.Size)
+
+	objects, err = client.GetBucketObjects(context.Background(), "some-bucket", &cs.Query{Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, objects, 1)
+}
+
+func TestPrefixCondition(t *testing.T) {
+	assert.Nil(t, cs.PrefixCondition("some-bucket", ""))
+
+	condition := cs.PrefixCondition("some-bucket", "exports/")
+	assert.Equal(t, `resource.name.startsWith("projects/_/buckets/some-bucket/objects/exports/")`, condition.Expression)
+	assert.NotEmpty(t, condition.Title)
+}
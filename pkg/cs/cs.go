@@ -50,6 +50,8 @@ type Attributes struct {
 
 type Query struct {
 	Prefix string
+	// Limit stops listing objects after this many, zero means no limit.
+	Limit int
 }
 
 func (c *Client) DeleteObjects(ctx context.Context, q *Query) (int, error) {
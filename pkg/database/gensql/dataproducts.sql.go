@@ -8,6 +8,7 @@ package gensql
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -28,7 +29,7 @@ VALUES ($1,
         $5,
         $6,
         $7)
-RETURNING id, name, description, "group", created, last_modified, tsv_document, slug, teamkatalogen_url, team_contact, team_id
+RETURNING id, name, description, "group", created, last_modified, tsv_document, slug, teamkatalogen_url, team_contact, team_id, lifecycle_status, replaced_by, sunset, deprecation_reason, deleted, deleted_by
 `
 
 type CreateDataproductParams struct {
@@ -64,6 +65,12 @@ func (q *Queries) CreateDataproduct(ctx context.Context, arg CreateDataproductPa
 		&i.TeamkatalogenUrl,
 		&i.TeamContact,
 		&i.TeamID,
+		&i.LifecycleStatus,
+		&i.ReplacedBy,
+		&i.Sunset,
+		&i.DeprecationReason,
+		&i.Deleted,
+		&i.DeletedBy,
 	)
 	return i, err
 }
@@ -72,6 +79,7 @@ const dataproductGroupStats = `-- name: DataproductGroupStats :many
 SELECT "group",
        count(1) as "count"
 FROM "dataproducts"
+WHERE "deleted" IS NULL
 GROUP BY "group"
 ORDER BY "count" DESC
 LIMIT $2 OFFSET $1
@@ -116,6 +124,7 @@ FROM (
 	SELECT unnest(ds.keywords) as keyword
 	FROM dataproducts dp
     INNER JOIN datasets ds ON ds.dataproduct_id = dp.id
+	WHERE dp.deleted IS NULL
 ) keywords
 WHERE true
 AND CASE WHEN coalesce(TRIM($1), '') = '' THEN true ELSE keyword ILIKE $1::text || '%' END
@@ -164,9 +173,9 @@ func (q *Queries) DeleteDataproduct(ctx context.Context, id uuid.UUID) error {
 }
 
 const getDataproduct = `-- name: GetDataproduct :one
-SELECT id, name, description, "group", created, last_modified, tsv_document, slug, teamkatalogen_url, team_contact, team_id
+SELECT id, name, description, "group", created, last_modified, tsv_document, slug, teamkatalogen_url, team_contact, team_id, lifecycle_status, replaced_by, sunset, deprecation_reason, deleted, deleted_by
 FROM dataproducts
-WHERE id = $1
+WHERE id = $1 AND deleted IS NULL
 `
 
 func (q *Queries) GetDataproduct(ctx context.Context, id uuid.UUID) (Dataproduct, error) {
@@ -184,13 +193,20 @@ func (q *Queries) GetDataproduct(ctx context.Context, id uuid.UUID) (Dataproduct
 		&i.TeamkatalogenUrl,
 		&i.TeamContact,
 		&i.TeamID,
+		&i.LifecycleStatus,
+		&i.ReplacedBy,
+		&i.Sunset,
+		&i.DeprecationReason,
+		&i.Deleted,
+		&i.DeletedBy,
 	)
 	return i, err
 }
 
 const getDataproducts = `-- name: GetDataproducts :many
-SELECT id, name, description, "group", created, last_modified, tsv_document, slug, teamkatalogen_url, team_contact, team_id
+SELECT id, name, description, "group", created, last_modified, tsv_document, slug, teamkatalogen_url, team_contact, team_id, lifecycle_status, replaced_by, sunset, deprecation_reason, deleted, deleted_by
 FROM dataproducts
+WHERE deleted IS NULL
 ORDER BY last_modified DESC
 LIMIT $2 OFFSET $1
 `
@@ -221,6 +237,12 @@ func (q *Queries) GetDataproducts(ctx context.Context, arg GetDataproductsParams
 			&i.TeamkatalogenUrl,
 			&i.TeamContact,
 			&i.TeamID,
+			&i.LifecycleStatus,
+			&i.ReplacedBy,
+			&i.Sunset,
+			&i.DeprecationReason,
+			&i.Deleted,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -236,9 +258,9 @@ func (q *Queries) GetDataproducts(ctx context.Context, arg GetDataproductsParams
 }
 
 const getDataproductsByGroups = `-- name: GetDataproductsByGroups :many
-SELECT id, name, description, "group", created, last_modified, tsv_document, slug, teamkatalogen_url, team_contact, team_id
+SELECT id, name, description, "group", created, last_modified, tsv_document, slug, teamkatalogen_url, team_contact, team_id, lifecycle_status, replaced_by, sunset, deprecation_reason, deleted, deleted_by
 FROM dataproducts
-WHERE "group" = ANY ($1::text[])
+WHERE "group" = ANY ($1::text[]) AND deleted IS NULL
 ORDER BY last_modified DESC
 `
 
@@ -263,6 +285,120 @@ func (q *Queries) GetDataproductsByGroups(ctx context.Context, groups []string)
 			&i.TeamkatalogenUrl,
 			&i.TeamContact,
 			&i.TeamID,
+			&i.LifecycleStatus,
+			&i.ReplacedBy,
+			&i.Sunset,
+			&i.DeprecationReason,
+			&i.Deleted,
+			&i.DeletedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDataproductsByGroupsPage = `-- name: GetDataproductsByGroupsPage :many
+(
+    SELECT dp.id, dp.name, dp.description, dp."group", dp.created, dp.last_modified, dp.tsv_document, dp.slug, dp.teamkatalogen_url, dp.team_contact, dp.team_id, dp.lifecycle_status, dp.replaced_by, dp.sunset, dp.deprecation_reason, dp.deleted, dp.deleted_by, dp.team_name, dp.pa_name, dp.pa_id
+    FROM dataproduct_with_teamkatalogen_view dp
+    WHERE $1::text = 'name' AND NOT $2::bool
+    AND dp."group" = ANY($3::text[])
+    AND ($4::uuid IS NULL OR (dp.name, dp.id) > ($5::text, $4::uuid))
+    ORDER BY dp.name ASC, dp.id ASC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT dp.id, dp.name, dp.description, dp."group", dp.created, dp.last_modified, dp.tsv_document, dp.slug, dp.teamkatalogen_url, dp.team_contact, dp.team_id, dp.lifecycle_status, dp.replaced_by, dp.sunset, dp.deprecation_reason, dp.deleted, dp.deleted_by, dp.team_name, dp.pa_name, dp.pa_id
+    FROM dataproduct_with_teamkatalogen_view dp
+    WHERE $1::text = 'name' AND $2::bool
+    AND dp."group" = ANY($3::text[])
+    AND ($4::uuid IS NULL OR (dp.name, dp.id) < ($5::text, $4::uuid))
+    ORDER BY dp.name DESC, dp.id DESC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT dp.id, dp.name, dp.description, dp."group", dp.created, dp.last_modified, dp.tsv_document, dp.slug, dp.teamkatalogen_url, dp.team_contact, dp.team_id, dp.lifecycle_status, dp.replaced_by, dp.sunset, dp.deprecation_reason, dp.deleted, dp.deleted_by, dp.team_name, dp.pa_name, dp.pa_id
+    FROM dataproduct_with_teamkatalogen_view dp
+    WHERE $1::text = 'created' AND NOT $2::bool
+    AND dp."group" = ANY($3::text[])
+    AND ($4::uuid IS NULL OR (dp.created, dp.id) > ($7::timestamptz, $4::uuid))
+    ORDER BY dp.created ASC, dp.id ASC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT dp.id, dp.name, dp.description, dp."group", dp.created, dp.last_modified, dp.tsv_document, dp.slug, dp.teamkatalogen_url, dp.team_contact, dp.team_id, dp.lifecycle_status, dp.replaced_by, dp.sunset, dp.deprecation_reason, dp.deleted, dp.deleted_by, dp.team_name, dp.pa_name, dp.pa_id
+    FROM dataproduct_with_teamkatalogen_view dp
+    WHERE $1::text = 'created' AND $2::bool
+    AND dp."group" = ANY($3::text[])
+    AND ($4::uuid IS NULL OR (dp.created, dp.id) < ($7::timestamptz, $4::uuid))
+    ORDER BY dp.created DESC, dp.id DESC
+    LIMIT $6::int
+)
+`
+
+type GetDataproductsByGroupsPageParams struct {
+	Sort         string
+	Descending   bool
+	Groups       []string
+	AfterID      uuid.NullUUID
+	AfterName    string
+	Lim          int32
+	AfterCreated time.Time
+}
+
+type GetDataproductsByGroupsPageRow struct {
+	DataproductWithTeamkatalogenView DataproductWithTeamkatalogenView
+}
+
+func (q *Queries) GetDataproductsByGroupsPage(ctx context.Context, arg GetDataproductsByGroupsPageParams) ([]GetDataproductsByGroupsPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDataproductsByGroupsPage,
+		arg.Sort,
+		arg.Descending,
+		pq.Array(arg.Groups),
+		arg.AfterID,
+		arg.AfterName,
+		arg.Lim,
+		arg.AfterCreated,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDataproductsByGroupsPageRow{}
+	for rows.Next() {
+		var i GetDataproductsByGroupsPageRow
+		if err := rows.Scan(
+			&i.DataproductWithTeamkatalogenView.ID,
+			&i.DataproductWithTeamkatalogenView.Name,
+			&i.DataproductWithTeamkatalogenView.Description,
+			&i.DataproductWithTeamkatalogenView.Group,
+			&i.DataproductWithTeamkatalogenView.Created,
+			&i.DataproductWithTeamkatalogenView.LastModified,
+			&i.DataproductWithTeamkatalogenView.TsvDocument,
+			&i.DataproductWithTeamkatalogenView.Slug,
+			&i.DataproductWithTeamkatalogenView.TeamkatalogenUrl,
+			&i.DataproductWithTeamkatalogenView.TeamContact,
+			&i.DataproductWithTeamkatalogenView.TeamID,
+			&i.DataproductWithTeamkatalogenView.LifecycleStatus,
+			&i.DataproductWithTeamkatalogenView.ReplacedBy,
+			&i.DataproductWithTeamkatalogenView.Sunset,
+			&i.DataproductWithTeamkatalogenView.DeprecationReason,
+			&i.DataproductWithTeamkatalogenView.Deleted,
+			&i.DataproductWithTeamkatalogenView.DeletedBy,
+			&i.DataproductWithTeamkatalogenView.TeamName,
+			&i.DataproductWithTeamkatalogenView.PaName,
+			&i.DataproductWithTeamkatalogenView.PaID,
 		); err != nil {
 			return nil, err
 		}
@@ -278,9 +414,9 @@ func (q *Queries) GetDataproductsByGroups(ctx context.Context, groups []string)
 }
 
 const getDataproductsByIDs = `-- name: GetDataproductsByIDs :many
-SELECT id, name, description, "group", created, last_modified, tsv_document, slug, teamkatalogen_url, team_contact, team_id
+SELECT id, name, description, "group", created, last_modified, tsv_document, slug, teamkatalogen_url, team_contact, team_id, lifecycle_status, replaced_by, sunset, deprecation_reason, deleted, deleted_by
 FROM dataproducts
-WHERE id = ANY ($1::uuid[])
+WHERE id = ANY ($1::uuid[]) AND deleted IS NULL
 ORDER BY last_modified DESC
 `
 
@@ -305,6 +441,12 @@ func (q *Queries) GetDataproductsByIDs(ctx context.Context, ids []uuid.UUID) ([]
 			&i.TeamkatalogenUrl,
 			&i.TeamContact,
 			&i.TeamID,
+			&i.LifecycleStatus,
+			&i.ReplacedBy,
+			&i.Sunset,
+			&i.DeprecationReason,
+			&i.Deleted,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -320,7 +462,7 @@ func (q *Queries) GetDataproductsByIDs(ctx context.Context, ids []uuid.UUID) ([]
 }
 
 const getDataproductsByProductArea = `-- name: GetDataproductsByProductArea :many
-SELECT id, name, description, "group", created, last_modified, tsv_document, slug, teamkatalogen_url, team_contact, team_id, team_name, pa_name, pa_id
+SELECT id, name, description, "group", created, last_modified, tsv_document, slug, teamkatalogen_url, team_contact, team_id, lifecycle_status, replaced_by, sunset, deprecation_reason, deleted, deleted_by, team_name, pa_name, pa_id
 FROM dataproduct_with_teamkatalogen_view
 WHERE team_id = ANY($1::uuid[])
 ORDER BY created DESC
@@ -347,6 +489,12 @@ func (q *Queries) GetDataproductsByProductArea(ctx context.Context, teamID []uui
 			&i.TeamkatalogenUrl,
 			&i.TeamContact,
 			&i.TeamID,
+			&i.LifecycleStatus,
+			&i.ReplacedBy,
+			&i.Sunset,
+			&i.DeprecationReason,
+			&i.Deleted,
+			&i.DeletedBy,
 			&i.TeamName,
 			&i.PaName,
 			&i.PaID,
@@ -364,10 +512,118 @@ func (q *Queries) GetDataproductsByProductArea(ctx context.Context, teamID []uui
 	return items, nil
 }
 
+const getDataproductsByProductAreaPage = `-- name: GetDataproductsByProductAreaPage :many
+(
+    SELECT dp.id, dp.name, dp.description, dp."group", dp.created, dp.last_modified, dp.tsv_document, dp.slug, dp.teamkatalogen_url, dp.team_contact, dp.team_id, dp.lifecycle_status, dp.replaced_by, dp.sunset, dp.deprecation_reason, dp.deleted, dp.deleted_by, dp.team_name, dp.pa_name, dp.pa_id
+    FROM dataproduct_with_teamkatalogen_view dp
+    WHERE $1::text = 'name' AND NOT $2::bool
+    AND dp.team_id = ANY($3::uuid[])
+    AND ($4::uuid IS NULL OR (dp.name, dp.id) > ($5::text, $4::uuid))
+    ORDER BY dp.name ASC, dp.id ASC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT dp.id, dp.name, dp.description, dp."group", dp.created, dp.last_modified, dp.tsv_document, dp.slug, dp.teamkatalogen_url, dp.team_contact, dp.team_id, dp.lifecycle_status, dp.replaced_by, dp.sunset, dp.deprecation_reason, dp.deleted, dp.deleted_by, dp.team_name, dp.pa_name, dp.pa_id
+    FROM dataproduct_with_teamkatalogen_view dp
+    WHERE $1::text = 'name' AND $2::bool
+    AND dp.team_id = ANY($3::uuid[])
+    AND ($4::uuid IS NULL OR (dp.name, dp.id) < ($5::text, $4::uuid))
+    ORDER BY dp.name DESC, dp.id DESC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT dp.id, dp.name, dp.description, dp."group", dp.created, dp.last_modified, dp.tsv_document, dp.slug, dp.teamkatalogen_url, dp.team_contact, dp.team_id, dp.lifecycle_status, dp.replaced_by, dp.sunset, dp.deprecation_reason, dp.deleted, dp.deleted_by, dp.team_name, dp.pa_name, dp.pa_id
+    FROM dataproduct_with_teamkatalogen_view dp
+    WHERE $1::text = 'created' AND NOT $2::bool
+    AND dp.team_id = ANY($3::uuid[])
+    AND ($4::uuid IS NULL OR (dp.created, dp.id) > ($7::timestamptz, $4::uuid))
+    ORDER BY dp.created ASC, dp.id ASC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT dp.id, dp.name, dp.description, dp."group", dp.created, dp.last_modified, dp.tsv_document, dp.slug, dp.teamkatalogen_url, dp.team_contact, dp.team_id, dp.lifecycle_status, dp.replaced_by, dp.sunset, dp.deprecation_reason, dp.deleted, dp.deleted_by, dp.team_name, dp.pa_name, dp.pa_id
+    FROM dataproduct_with_teamkatalogen_view dp
+    WHERE $1::text = 'created' AND $2::bool
+    AND dp.team_id = ANY($3::uuid[])
+    AND ($4::uuid IS NULL OR (dp.created, dp.id) < ($7::timestamptz, $4::uuid))
+    ORDER BY dp.created DESC, dp.id DESC
+    LIMIT $6::int
+)
+`
+
+type GetDataproductsByProductAreaPageParams struct {
+	Sort         string
+	Descending   bool
+	TeamID       []uuid.UUID
+	AfterID      uuid.NullUUID
+	AfterName    string
+	Lim          int32
+	AfterCreated time.Time
+}
+
+type GetDataproductsByProductAreaPageRow struct {
+	DataproductWithTeamkatalogenView DataproductWithTeamkatalogenView
+}
+
+func (q *Queries) GetDataproductsByProductAreaPage(ctx context.Context, arg GetDataproductsByProductAreaPageParams) ([]GetDataproductsByProductAreaPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDataproductsByProductAreaPage,
+		arg.Sort,
+		arg.Descending,
+		pq.Array(arg.TeamID),
+		arg.AfterID,
+		arg.AfterName,
+		arg.Lim,
+		arg.AfterCreated,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDataproductsByProductAreaPageRow{}
+	for rows.Next() {
+		var i GetDataproductsByProductAreaPageRow
+		if err := rows.Scan(
+			&i.DataproductWithTeamkatalogenView.ID,
+			&i.DataproductWithTeamkatalogenView.Name,
+			&i.DataproductWithTeamkatalogenView.Description,
+			&i.DataproductWithTeamkatalogenView.Group,
+			&i.DataproductWithTeamkatalogenView.Created,
+			&i.DataproductWithTeamkatalogenView.LastModified,
+			&i.DataproductWithTeamkatalogenView.TsvDocument,
+			&i.DataproductWithTeamkatalogenView.Slug,
+			&i.DataproductWithTeamkatalogenView.TeamkatalogenUrl,
+			&i.DataproductWithTeamkatalogenView.TeamContact,
+			&i.DataproductWithTeamkatalogenView.TeamID,
+			&i.DataproductWithTeamkatalogenView.LifecycleStatus,
+			&i.DataproductWithTeamkatalogenView.ReplacedBy,
+			&i.DataproductWithTeamkatalogenView.Sunset,
+			&i.DataproductWithTeamkatalogenView.DeprecationReason,
+			&i.DataproductWithTeamkatalogenView.Deleted,
+			&i.DataproductWithTeamkatalogenView.DeletedBy,
+			&i.DataproductWithTeamkatalogenView.TeamName,
+			&i.DataproductWithTeamkatalogenView.PaName,
+			&i.DataproductWithTeamkatalogenView.PaID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getDataproductsByTeam = `-- name: GetDataproductsByTeam :many
-SELECT id, name, description, "group", created, last_modified, tsv_document, slug, teamkatalogen_url, team_contact, team_id
+SELECT id, name, description, "group", created, last_modified, tsv_document, slug, teamkatalogen_url, team_contact, team_id, lifecycle_status, replaced_by, sunset, deprecation_reason, deleted, deleted_by
 FROM dataproducts
-WHERE team_id = $1
+WHERE team_id = $1 AND deleted IS NULL
 ORDER BY created DESC
 `
 
@@ -392,6 +648,12 @@ func (q *Queries) GetDataproductsByTeam(ctx context.Context, teamID uuid.NullUUI
 			&i.TeamkatalogenUrl,
 			&i.TeamContact,
 			&i.TeamID,
+			&i.LifecycleStatus,
+			&i.ReplacedBy,
+			&i.Sunset,
+			&i.DeprecationReason,
+			&i.Deleted,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -415,7 +677,8 @@ SET "name"              = $1,
     "team_contact"      = $5,
     "team_id"           = $6
 WHERE id = $7
-RETURNING id, name, description, "group", created, last_modified, tsv_document, slug, teamkatalogen_url, team_contact, team_id
+AND ($8::timestamptz IS NULL OR last_modified = $8)
+RETURNING id, name, description, "group", created, last_modified, tsv_document, slug, teamkatalogen_url, team_contact, team_id, lifecycle_status, replaced_by, sunset, deprecation_reason, deleted, deleted_by
 `
 
 type UpdateDataproductParams struct {
@@ -426,6 +689,7 @@ type UpdateDataproductParams struct {
 	TeamContact           sql.NullString
 	TeamID                uuid.NullUUID
 	ID                    uuid.UUID
+	IfMatch               sql.NullTime
 }
 
 func (q *Queries) UpdateDataproduct(ctx context.Context, arg UpdateDataproductParams) (Dataproduct, error) {
@@ -437,6 +701,7 @@ func (q *Queries) UpdateDataproduct(ctx context.Context, arg UpdateDataproductPa
 		arg.TeamContact,
 		arg.TeamID,
 		arg.ID,
+		arg.IfMatch,
 	)
 	var i Dataproduct
 	err := row.Scan(
@@ -451,6 +716,12 @@ func (q *Queries) UpdateDataproduct(ctx context.Context, arg UpdateDataproductPa
 		&i.TeamkatalogenUrl,
 		&i.TeamContact,
 		&i.TeamID,
+		&i.LifecycleStatus,
+		&i.ReplacedBy,
+		&i.Sunset,
+		&i.DeprecationReason,
+		&i.Deleted,
+		&i.DeletedBy,
 	)
 	return i, err
 }
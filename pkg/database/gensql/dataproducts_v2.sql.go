@@ -42,7 +42,7 @@ func (q *Queries) GetDataproductKeywords(ctx context.Context, dpid uuid.UUID) ([
 }
 
 const getDataproductWithDatasetsBasic = `-- name: GetDataproductWithDatasetsBasic :many
-SELECT dp.id, dp.name, dp.description, "group", dp.created, dp.last_modified, dp.tsv_document, dp.slug, teamkatalogen_url, team_contact, team_id, team_name, pa_name, pa_id, ds.id, ds.name, ds.description, pii, ds.created, ds.last_modified, type, ds.tsv_document, ds.slug, repo, keywords, dataproduct_id, anonymisation_description, target_user
+SELECT dp.id, dp.name, dp.description, "group", dp.created, dp.last_modified, dp.tsv_document, dp.slug, teamkatalogen_url, team_contact, team_id, dp.lifecycle_status, dp.replaced_by, dp.sunset, dp.deprecation_reason, deleted, deleted_by, team_name, pa_name, pa_id, ds.id, ds.name, ds.description, pii, ds.created, ds.last_modified, type, ds.tsv_document, ds.slug, repo, keywords, dataproduct_id, anonymisation_description, target_user, ds.lifecycle_status, ds.replaced_by, ds.sunset, ds.deprecation_reason
 FROM dataproduct_with_teamkatalogen_view dp LEFT JOIN datasets ds ON ds.dataproduct_id = dp.id
 WHERE dp.id = $1
 `
@@ -59,6 +59,12 @@ type GetDataproductWithDatasetsBasicRow struct {
 	TeamkatalogenUrl         sql.NullString
 	TeamContact              sql.NullString
 	TeamID                   uuid.NullUUID
+	LifecycleStatus          LifecycleStatus
+	ReplacedBy               uuid.NullUUID
+	Sunset                   sql.NullTime
+	DeprecationReason        sql.NullString
+	Deleted                  sql.NullTime
+	DeletedBy                sql.NullString
 	TeamName                 sql.NullString
 	PaName                   sql.NullString
 	PaID                     uuid.NullUUID
@@ -76,6 +82,10 @@ type GetDataproductWithDatasetsBasicRow struct {
 	DataproductID            uuid.NullUUID
 	AnonymisationDescription sql.NullString
 	TargetUser               sql.NullString
+	LifecycleStatus_2        NullLifecycleStatus
+	ReplacedBy_2             uuid.NullUUID
+	Sunset_2                 sql.NullTime
+	DeprecationReason_2      sql.NullString
 }
 
 func (q *Queries) GetDataproductWithDatasetsBasic(ctx context.Context, id uuid.UUID) ([]GetDataproductWithDatasetsBasicRow, error) {
@@ -99,6 +109,12 @@ func (q *Queries) GetDataproductWithDatasetsBasic(ctx context.Context, id uuid.U
 			&i.TeamkatalogenUrl,
 			&i.TeamContact,
 			&i.TeamID,
+			&i.LifecycleStatus,
+			&i.ReplacedBy,
+			&i.Sunset,
+			&i.DeprecationReason,
+			&i.Deleted,
+			&i.DeletedBy,
 			&i.TeamName,
 			&i.PaName,
 			&i.PaID,
@@ -116,6 +132,10 @@ func (q *Queries) GetDataproductWithDatasetsBasic(ctx context.Context, id uuid.U
 			&i.DataproductID,
 			&i.AnonymisationDescription,
 			&i.TargetUser,
+			&i.LifecycleStatus_2,
+			&i.ReplacedBy_2,
+			&i.Sunset_2,
+			&i.DeprecationReason_2,
 		); err != nil {
 			return nil, err
 		}
@@ -133,7 +153,7 @@ func (q *Queries) GetDataproductWithDatasetsBasic(ctx context.Context, id uuid.U
 const getDataproductsNumberByTeam = `-- name: GetDataproductsNumberByTeam :one
 SELECT COUNT(*) as "count"
 FROM dataproducts
-WHERE team_id = $1
+WHERE team_id = $1 AND deleted IS NULL
 `
 
 func (q *Queries) GetDataproductsNumberByTeam(ctx context.Context, teamID uuid.NullUUID) (int64, error) {
@@ -144,7 +164,7 @@ func (q *Queries) GetDataproductsNumberByTeam(ctx context.Context, teamID uuid.N
 }
 
 const getDataproductsWithDatasets = `-- name: GetDataproductsWithDatasets :many
-SELECT dp.dp_id, dp.dp_name, dp.dp_description, dp.dp_group, dp.dp_created, dp.dp_last_modified, dp.dp_slug, dp.teamkatalogen_url, dp.team_contact, dp.team_id, dp.team_name, dp.pa_name, dp.pa_id, dp.ds_dp_id, dp.ds_id, dp.ds_name, dp.ds_description, dp.ds_created, dp.ds_last_modified, dp.ds_slug, dp.ds_keywords, dsrc.last_modified as "dsrc_last_modified"
+SELECT dp.dp_id, dp.dp_name, dp.dp_description, dp.dp_group, dp.dp_created, dp.dp_last_modified, dp.dp_slug, dp.teamkatalogen_url, dp.team_contact, dp.team_id, dp.team_name, dp.pa_name, dp.pa_id, dp.dp_lifecycle_status, dp.dp_replaced_by, dp.dp_sunset, dp.dp_deprecation_reason, dp.ds_dp_id, dp.ds_id, dp.ds_name, dp.ds_description, dp.ds_created, dp.ds_last_modified, dp.ds_slug, dp.ds_keywords, dp.ds_lifecycle_status, dp.ds_replaced_by, dp.ds_sunset, dp.ds_deprecation_reason, dsrc.last_modified as "dsrc_last_modified"
 FROM dataproduct_view dp
 LEFT JOIN datasource_bigquery dsrc ON dsrc.dataset_id = dp.ds_id
 WHERE (array_length($1::uuid[], 1) IS NULL OR dp_id = ANY ($1))
@@ -158,28 +178,36 @@ type GetDataproductsWithDatasetsParams struct {
 }
 
 type GetDataproductsWithDatasetsRow struct {
-	DpID             uuid.UUID
-	DpName           string
-	DpDescription    sql.NullString
-	DpGroup          string
-	DpCreated        time.Time
-	DpLastModified   time.Time
-	DpSlug           string
-	TeamkatalogenUrl sql.NullString
-	TeamContact      sql.NullString
-	TeamID           uuid.NullUUID
-	TeamName         sql.NullString
-	PaName           sql.NullString
-	PaID             uuid.NullUUID
-	DsDpID           uuid.NullUUID
-	DsID             uuid.NullUUID
-	DsName           sql.NullString
-	DsDescription    sql.NullString
-	DsCreated        sql.NullTime
-	DsLastModified   sql.NullTime
-	DsSlug           sql.NullString
-	DsKeywords       []string
-	DsrcLastModified sql.NullTime
+	DpID                uuid.UUID
+	DpName              string
+	DpDescription       sql.NullString
+	DpGroup             string
+	DpCreated           time.Time
+	DpLastModified      time.Time
+	DpSlug              string
+	TeamkatalogenUrl    sql.NullString
+	TeamContact         sql.NullString
+	TeamID              uuid.NullUUID
+	TeamName            sql.NullString
+	PaName              sql.NullString
+	PaID                uuid.NullUUID
+	DpLifecycleStatus   LifecycleStatus
+	DpReplacedBy        uuid.NullUUID
+	DpSunset            sql.NullTime
+	DpDeprecationReason sql.NullString
+	DsDpID              uuid.NullUUID
+	DsID                uuid.NullUUID
+	DsName              sql.NullString
+	DsDescription       sql.NullString
+	DsCreated           sql.NullTime
+	DsLastModified      sql.NullTime
+	DsSlug              sql.NullString
+	DsKeywords          []string
+	DsLifecycleStatus   NullLifecycleStatus
+	DsReplacedBy        uuid.NullUUID
+	DsSunset            sql.NullTime
+	DsDeprecationReason sql.NullString
+	DsrcLastModified    sql.NullTime
 }
 
 func (q *Queries) GetDataproductsWithDatasets(ctx context.Context, arg GetDataproductsWithDatasetsParams) ([]GetDataproductsWithDatasetsRow, error) {
@@ -205,6 +233,10 @@ func (q *Queries) GetDataproductsWithDatasets(ctx context.Context, arg GetDatapr
 			&i.TeamName,
 			&i.PaName,
 			&i.PaID,
+			&i.DpLifecycleStatus,
+			&i.DpReplacedBy,
+			&i.DpSunset,
+			&i.DpDeprecationReason,
 			&i.DsDpID,
 			&i.DsID,
 			&i.DsName,
@@ -213,6 +245,10 @@ func (q *Queries) GetDataproductsWithDatasets(ctx context.Context, arg GetDatapr
 			&i.DsLastModified,
 			&i.DsSlug,
 			pq.Array(&i.DsKeywords),
+			&i.DsLifecycleStatus,
+			&i.DsReplacedBy,
+			&i.DsSunset,
+			&i.DsDeprecationReason,
 			&i.DsrcLastModified,
 		); err != nil {
 			return nil, err
@@ -229,7 +265,7 @@ func (q *Queries) GetDataproductsWithDatasets(ctx context.Context, arg GetDatapr
 }
 
 const getDataproductsWithDatasetsAndAccessRequests = `-- name: GetDataproductsWithDatasetsAndAccessRequests :many
-SELECT dp.dp_id, dp.dp_name, dp.dp_description, dp.dp_group, dp.dp_created, dp.dp_last_modified, dp.dp_slug, dp.teamkatalogen_url, dp.team_contact, dp.team_id, dp.team_name, dp.pa_name, dp.pa_id, dp.ds_dp_id, dp.ds_id, dp.ds_name, dp.ds_description, dp.ds_created, dp.ds_last_modified, dp.ds_slug, dp.ds_keywords, dsrc.last_modified as "dsrc_last_modified",
+SELECT dp.dp_id, dp.dp_name, dp.dp_description, dp.dp_group, dp.dp_created, dp.dp_last_modified, dp.dp_slug, dp.teamkatalogen_url, dp.team_contact, dp.team_id, dp.team_name, dp.pa_name, dp.pa_id, dp.dp_lifecycle_status, dp.dp_replaced_by, dp.dp_sunset, dp.dp_deprecation_reason, dp.ds_dp_id, dp.ds_id, dp.ds_name, dp.ds_description, dp.ds_created, dp.ds_last_modified, dp.ds_slug, dp.ds_keywords, dp.ds_lifecycle_status, dp.ds_replaced_by, dp.ds_sunset, dp.ds_deprecation_reason, dsrc.last_modified as "dsrc_last_modified",
  dar.id as "dar_id", dar.dataset_id as "dar_dataset_id", dar.subject as "dar_subject", dar.owner as "dar_owner",
   dar.expires as "dar_expires", dar.status as "dar_status", dar.granter as "dar_granter", dar.reason as "dar_reason", 
   dar.closed as "dar_closed", dar.polly_documentation_id as "dar_polly_documentation_id", dar.created as "dar_created"
@@ -260,6 +296,10 @@ type GetDataproductsWithDatasetsAndAccessRequestsRow struct {
 	TeamName                sql.NullString
 	PaName                  sql.NullString
 	PaID                    uuid.NullUUID
+	DpLifecycleStatus       LifecycleStatus
+	DpReplacedBy            uuid.NullUUID
+	DpSunset                sql.NullTime
+	DpDeprecationReason     sql.NullString
 	DsDpID                  uuid.NullUUID
 	DsID                    uuid.NullUUID
 	DsName                  sql.NullString
@@ -268,6 +308,10 @@ type GetDataproductsWithDatasetsAndAccessRequestsRow struct {
 	DsLastModified          sql.NullTime
 	DsSlug                  sql.NullString
 	DsKeywords              []string
+	DsLifecycleStatus       NullLifecycleStatus
+	DsReplacedBy            uuid.NullUUID
+	DsSunset                sql.NullTime
+	DsDeprecationReason     sql.NullString
 	DsrcLastModified        sql.NullTime
 	DarID                   uuid.NullUUID
 	DarDatasetID            uuid.NullUUID
@@ -305,6 +349,10 @@ func (q *Queries) GetDataproductsWithDatasetsAndAccessRequests(ctx context.Conte
 			&i.TeamName,
 			&i.PaName,
 			&i.PaID,
+			&i.DpLifecycleStatus,
+			&i.DpReplacedBy,
+			&i.DpSunset,
+			&i.DpDeprecationReason,
 			&i.DsDpID,
 			&i.DsID,
 			&i.DsName,
@@ -313,6 +361,10 @@ func (q *Queries) GetDataproductsWithDatasetsAndAccessRequests(ctx context.Conte
 			&i.DsLastModified,
 			&i.DsSlug,
 			pq.Array(&i.DsKeywords),
+			&i.DsLifecycleStatus,
+			&i.DsReplacedBy,
+			&i.DsSunset,
+			&i.DsDeprecationReason,
 			&i.DsrcLastModified,
 			&i.DarID,
 			&i.DarDatasetID,
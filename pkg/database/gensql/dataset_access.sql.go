@@ -8,8 +8,10 @@ package gensql
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const getAccessToDataset = `-- name: GetAccessToDataset :one
@@ -196,6 +198,128 @@ func (q *Queries) ListActiveAccessToDataset(ctx context.Context, datasetID uuid.
 	return items, nil
 }
 
+const listActiveAccessToTablePatternDatasources = `-- name: ListActiveAccessToTablePatternDatasources :many
+SELECT da.id, da.dataset_id, da.subject, da.granter, da.expires, da.created, da.revoked, da.access_request_id, da.owner
+FROM dataset_access da
+JOIN datasource_bigquery dsb ON dsb.dataset_id = da.dataset_id AND dsb.is_reference = FALSE
+JOIN datasets ds ON ds.id = da.dataset_id
+JOIN dataproducts dp ON dp.id = ds.dataproduct_id
+WHERE dsb.scope = 'dataset'
+AND dsb.table_pattern != ''
+AND dsb.deleted IS NULL
+AND ds.lifecycle_status != 'retired'
+AND dp.deleted IS NULL
+AND da.revoked IS NULL
+AND (da.expires IS NULL OR da.expires >= NOW())
+ORDER BY da.dataset_id
+`
+
+func (q *Queries) ListActiveAccessToTablePatternDatasources(ctx context.Context) ([]DatasetAccess, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveAccessToTablePatternDatasources)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []DatasetAccess{}
+	for rows.Next() {
+		var i DatasetAccess
+		if err := rows.Scan(
+			&i.ID,
+			&i.DatasetID,
+			&i.Subject,
+			&i.Granter,
+			&i.Expires,
+			&i.Created,
+			&i.Revoked,
+			&i.AccessRequestID,
+			&i.Owner,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExpiringAccessForGroups = `-- name: ListExpiringAccessForGroups :many
+SELECT
+    da.id AS access_id,
+    da.subject,
+    da.owner,
+    da.expires::timestamptz AS expires,
+    ds.id AS dataset_id,
+    ds.name AS dataset_name,
+    dp.id AS dataproduct_id,
+    dp.name AS dataproduct_name,
+    dar.id AS renewal_request_id
+FROM dataset_access da
+JOIN datasets ds ON da.dataset_id = ds.id
+JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+LEFT JOIN dataset_access_requests dar ON dar.renews_access_id = da.id AND dar.status = 'pending'
+WHERE dp."group" = ANY ($1::text[])
+AND dp.deleted IS NULL
+AND da.revoked IS NULL
+AND da.expires > NOW()
+AND da.expires <= NOW() + make_interval(days => $2::int)
+ORDER BY da.expires
+`
+
+type ListExpiringAccessForGroupsParams struct {
+	Groups []string
+	Days   int32
+}
+
+type ListExpiringAccessForGroupsRow struct {
+	AccessID         uuid.UUID
+	Subject          string
+	Owner            string
+	Expires          time.Time
+	DatasetID        uuid.UUID
+	DatasetName      string
+	DataproductID    uuid.UUID
+	DataproductName  string
+	RenewalRequestID uuid.NullUUID
+}
+
+func (q *Queries) ListExpiringAccessForGroups(ctx context.Context, arg ListExpiringAccessForGroupsParams) ([]ListExpiringAccessForGroupsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listExpiringAccessForGroups, pq.Array(arg.Groups), arg.Days)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListExpiringAccessForGroupsRow{}
+	for rows.Next() {
+		var i ListExpiringAccessForGroupsRow
+		if err := rows.Scan(
+			&i.AccessID,
+			&i.Subject,
+			&i.Owner,
+			&i.Expires,
+			&i.DatasetID,
+			&i.DatasetName,
+			&i.DataproductID,
+			&i.DataproductName,
+			&i.RenewalRequestID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUnrevokedExpiredAccessEntries = `-- name: ListUnrevokedExpiredAccessEntries :many
 SELECT id, dataset_id, subject, granter, expires, created, revoked, access_request_id, owner
 FROM dataset_access
@@ -246,3 +370,19 @@ func (q *Queries) RevokeAccessToDataset(ctx context.Context, id uuid.UUID) error
 	_, err := q.db.ExecContext(ctx, revokeAccessToDataset, id)
 	return err
 }
+
+const updateAccessExpires = `-- name: UpdateAccessExpires :exec
+UPDATE dataset_access
+SET expires = $1
+WHERE id = $2
+`
+
+type UpdateAccessExpiresParams struct {
+	Expires sql.NullTime
+	ID      uuid.UUID
+}
+
+func (q *Queries) UpdateAccessExpires(ctx context.Context, arg UpdateAccessExpiresParams) error {
+	_, err := q.db.ExecContext(ctx, updateAccessExpires, arg.Expires, arg.ID)
+	return err
+}
@@ -8,6 +8,7 @@ package gensql
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -42,7 +43,7 @@ VALUES ($1,
         LOWER($3),
         $4,
         $5)
-RETURNING id, dataset_id, subject, owner, polly_documentation_id, last_modified, created, expires, status, closed, granter, reason
+RETURNING id, dataset_id, subject, owner, polly_documentation_id, last_modified, created, expires, status, closed, granter, reason, approval_rule_id, renews_access_id
 `
 
 type CreateAccessRequestForDatasetParams struct {
@@ -75,6 +76,62 @@ func (q *Queries) CreateAccessRequestForDataset(ctx context.Context, arg CreateA
 		&i.Closed,
 		&i.Granter,
 		&i.Reason,
+		&i.ApprovalRuleID,
+		&i.RenewsAccessID,
+	)
+	return i, err
+}
+
+const createRenewalAccessRequest = `-- name: CreateRenewalAccessRequest :one
+INSERT INTO dataset_access_requests (dataset_id,
+                                        "subject",
+                                        "owner",
+                                        "expires",
+                                        polly_documentation_id,
+                                        renews_access_id)
+VALUES ($1,
+        $2,
+        LOWER($3),
+        $4,
+        $5,
+        $6)
+RETURNING id, dataset_id, subject, owner, polly_documentation_id, last_modified, created, expires, status, closed, granter, reason, approval_rule_id, renews_access_id
+`
+
+type CreateRenewalAccessRequestParams struct {
+	DatasetID            uuid.UUID
+	Subject              string
+	Owner                string
+	Expires              sql.NullTime
+	PollyDocumentationID uuid.NullUUID
+	RenewsAccessID       uuid.NullUUID
+}
+
+func (q *Queries) CreateRenewalAccessRequest(ctx context.Context, arg CreateRenewalAccessRequestParams) (DatasetAccessRequest, error) {
+	row := q.db.QueryRowContext(ctx, createRenewalAccessRequest,
+		arg.DatasetID,
+		arg.Subject,
+		arg.Owner,
+		arg.Expires,
+		arg.PollyDocumentationID,
+		arg.RenewsAccessID,
+	)
+	var i DatasetAccessRequest
+	err := row.Scan(
+		&i.ID,
+		&i.DatasetID,
+		&i.Subject,
+		&i.Owner,
+		&i.PollyDocumentationID,
+		&i.LastModified,
+		&i.Created,
+		&i.Expires,
+		&i.Status,
+		&i.Closed,
+		&i.Granter,
+		&i.Reason,
+		&i.ApprovalRuleID,
+		&i.RenewsAccessID,
 	)
 	return i, err
 }
@@ -110,7 +167,7 @@ func (q *Queries) DenyAccessRequest(ctx context.Context, arg DenyAccessRequestPa
 }
 
 const getAccessRequest = `-- name: GetAccessRequest :one
-SELECT id, dataset_id, subject, owner, polly_documentation_id, last_modified, created, expires, status, closed, granter, reason
+SELECT id, dataset_id, subject, owner, polly_documentation_id, last_modified, created, expires, status, closed, granter, reason, approval_rule_id, renews_access_id
 FROM dataset_access_requests
 WHERE id = $1
 `
@@ -131,39 +188,104 @@ func (q *Queries) GetAccessRequest(ctx context.Context, id uuid.UUID) (DatasetAc
 		&i.Closed,
 		&i.Granter,
 		&i.Reason,
+		&i.ApprovalRuleID,
+		&i.RenewsAccessID,
 	)
 	return i, err
 }
 
-const listAccessRequestsForDataset = `-- name: ListAccessRequestsForDataset :many
-SELECT id, dataset_id, subject, owner, polly_documentation_id, last_modified, created, expires, status, closed, granter, reason
+const getPendingRenewalAccessRequest = `-- name: GetPendingRenewalAccessRequest :one
+SELECT id, dataset_id, subject, owner, polly_documentation_id, last_modified, created, expires, status, closed, granter, reason, approval_rule_id, renews_access_id
 FROM dataset_access_requests
-WHERE dataset_id = $1 AND status = 'pending'
-ORDER BY created DESC
+WHERE renews_access_id = $1 AND status = 'pending'
+`
+
+func (q *Queries) GetPendingRenewalAccessRequest(ctx context.Context, renewsAccessID uuid.NullUUID) (DatasetAccessRequest, error) {
+	row := q.db.QueryRowContext(ctx, getPendingRenewalAccessRequest, renewsAccessID)
+	var i DatasetAccessRequest
+	err := row.Scan(
+		&i.ID,
+		&i.DatasetID,
+		&i.Subject,
+		&i.Owner,
+		&i.PollyDocumentationID,
+		&i.LastModified,
+		&i.Created,
+		&i.Expires,
+		&i.Status,
+		&i.Closed,
+		&i.Granter,
+		&i.Reason,
+		&i.ApprovalRuleID,
+		&i.RenewsAccessID,
+	)
+	return i, err
+}
+
+const listAccessRequestsForDatasetPage = `-- name: ListAccessRequestsForDatasetPage :many
+(
+    SELECT dar.id, dar.dataset_id, dar.subject, dar.owner, dar.polly_documentation_id, dar.last_modified, dar.created, dar.expires, dar.status, dar.closed, dar.granter, dar.reason, dar.approval_rule_id, dar.renews_access_id
+    FROM dataset_access_requests dar
+    WHERE NOT $1::bool
+    AND dar.dataset_id = $2 AND dar.status = 'pending'
+    AND ($3::uuid IS NULL OR (dar.created, dar.id) > ($4::timestamptz, $3::uuid))
+    ORDER BY dar.created ASC, dar.id ASC
+    LIMIT $5::int
+)
+UNION ALL
+(
+    SELECT dar.id, dar.dataset_id, dar.subject, dar.owner, dar.polly_documentation_id, dar.last_modified, dar.created, dar.expires, dar.status, dar.closed, dar.granter, dar.reason, dar.approval_rule_id, dar.renews_access_id
+    FROM dataset_access_requests dar
+    WHERE $1::bool
+    AND dar.dataset_id = $2 AND dar.status = 'pending'
+    AND ($3::uuid IS NULL OR (dar.created, dar.id) < ($4::timestamptz, $3::uuid))
+    ORDER BY dar.created DESC, dar.id DESC
+    LIMIT $5::int
+)
 `
 
-func (q *Queries) ListAccessRequestsForDataset(ctx context.Context, datasetID uuid.UUID) ([]DatasetAccessRequest, error) {
-	rows, err := q.db.QueryContext(ctx, listAccessRequestsForDataset, datasetID)
+type ListAccessRequestsForDatasetPageParams struct {
+	Descending   bool
+	DatasetID    uuid.UUID
+	AfterID      uuid.NullUUID
+	AfterCreated time.Time
+	Lim          int32
+}
+
+type ListAccessRequestsForDatasetPageRow struct {
+	DatasetAccessRequest DatasetAccessRequest
+}
+
+func (q *Queries) ListAccessRequestsForDatasetPage(ctx context.Context, arg ListAccessRequestsForDatasetPageParams) ([]ListAccessRequestsForDatasetPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAccessRequestsForDatasetPage,
+		arg.Descending,
+		arg.DatasetID,
+		arg.AfterID,
+		arg.AfterCreated,
+		arg.Lim,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []DatasetAccessRequest{}
+	items := []ListAccessRequestsForDatasetPageRow{}
 	for rows.Next() {
-		var i DatasetAccessRequest
+		var i ListAccessRequestsForDatasetPageRow
 		if err := rows.Scan(
-			&i.ID,
-			&i.DatasetID,
-			&i.Subject,
-			&i.Owner,
-			&i.PollyDocumentationID,
-			&i.LastModified,
-			&i.Created,
-			&i.Expires,
-			&i.Status,
-			&i.Closed,
-			&i.Granter,
-			&i.Reason,
+			&i.DatasetAccessRequest.ID,
+			&i.DatasetAccessRequest.DatasetID,
+			&i.DatasetAccessRequest.Subject,
+			&i.DatasetAccessRequest.Owner,
+			&i.DatasetAccessRequest.PollyDocumentationID,
+			&i.DatasetAccessRequest.LastModified,
+			&i.DatasetAccessRequest.Created,
+			&i.DatasetAccessRequest.Expires,
+			&i.DatasetAccessRequest.Status,
+			&i.DatasetAccessRequest.Closed,
+			&i.DatasetAccessRequest.Granter,
+			&i.DatasetAccessRequest.Reason,
+			&i.DatasetAccessRequest.ApprovalRuleID,
+			&i.DatasetAccessRequest.RenewsAccessID,
 		); err != nil {
 			return nil, err
 		}
@@ -178,35 +300,160 @@ func (q *Queries) ListAccessRequestsForDataset(ctx context.Context, datasetID uu
 	return items, nil
 }
 
-const listAccessRequestsForOwner = `-- name: ListAccessRequestsForOwner :many
-SELECT id, dataset_id, subject, owner, polly_documentation_id, last_modified, created, expires, status, closed, granter, reason
-FROM dataset_access_requests
-WHERE "owner" = ANY ($1::text[])
-ORDER BY created DESC
+const listAccessRequestsForGranterPage = `-- name: ListAccessRequestsForGranterPage :many
+(
+    SELECT dar.id, dar.dataset_id, dar.subject, dar.owner, dar.polly_documentation_id, dar.last_modified, dar.created, dar.expires, dar.status, dar.closed, dar.granter, dar.reason, dar.approval_rule_id, dar.renews_access_id, ds.name AS dataset_name, dp.id AS dataproduct_id, dp.slug AS dataproduct_slug, dp.name AS dataproduct_name
+    FROM dataset_access_requests dar
+    JOIN datasets ds ON dar.dataset_id = ds.id
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    WHERE NOT $1::bool
+    AND dp."group" = ANY ($2::text[]) AND dar.status = 'pending' AND dp.deleted IS NULL
+    AND ($3::uuid IS NULL OR (dar.created, dar.id) > ($4::timestamptz, $3::uuid))
+    ORDER BY dar.created ASC, dar.id ASC
+    LIMIT $5::int
+)
+UNION ALL
+(
+    SELECT dar.id, dar.dataset_id, dar.subject, dar.owner, dar.polly_documentation_id, dar.last_modified, dar.created, dar.expires, dar.status, dar.closed, dar.granter, dar.reason, dar.approval_rule_id, dar.renews_access_id, ds.name AS dataset_name, dp.id AS dataproduct_id, dp.slug AS dataproduct_slug, dp.name AS dataproduct_name
+    FROM dataset_access_requests dar
+    JOIN datasets ds ON dar.dataset_id = ds.id
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    WHERE $1::bool
+    AND dp."group" = ANY ($2::text[]) AND dar.status = 'pending' AND dp.deleted IS NULL
+    AND ($3::uuid IS NULL OR (dar.created, dar.id) < ($4::timestamptz, $3::uuid))
+    ORDER BY dar.created DESC, dar.id DESC
+    LIMIT $5::int
+)
 `
 
-func (q *Queries) ListAccessRequestsForOwner(ctx context.Context, owner []string) ([]DatasetAccessRequest, error) {
-	rows, err := q.db.QueryContext(ctx, listAccessRequestsForOwner, pq.Array(owner))
+type ListAccessRequestsForGranterPageParams struct {
+	Descending   bool
+	Groups       []string
+	AfterID      uuid.NullUUID
+	AfterCreated time.Time
+	Lim          int32
+}
+
+type ListAccessRequestsForGranterPageRow struct {
+	DatasetAccessRequest DatasetAccessRequest
+	DatasetName          string
+	DataproductID        uuid.UUID
+	DataproductSlug      string
+	DataproductName      string
+}
+
+func (q *Queries) ListAccessRequestsForGranterPage(ctx context.Context, arg ListAccessRequestsForGranterPageParams) ([]ListAccessRequestsForGranterPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAccessRequestsForGranterPage,
+		arg.Descending,
+		pq.Array(arg.Groups),
+		arg.AfterID,
+		arg.AfterCreated,
+		arg.Lim,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAccessRequestsForGranterPageRow{}
+	for rows.Next() {
+		var i ListAccessRequestsForGranterPageRow
+		if err := rows.Scan(
+			&i.DatasetAccessRequest.ID,
+			&i.DatasetAccessRequest.DatasetID,
+			&i.DatasetAccessRequest.Subject,
+			&i.DatasetAccessRequest.Owner,
+			&i.DatasetAccessRequest.PollyDocumentationID,
+			&i.DatasetAccessRequest.LastModified,
+			&i.DatasetAccessRequest.Created,
+			&i.DatasetAccessRequest.Expires,
+			&i.DatasetAccessRequest.Status,
+			&i.DatasetAccessRequest.Closed,
+			&i.DatasetAccessRequest.Granter,
+			&i.DatasetAccessRequest.Reason,
+			&i.DatasetAccessRequest.ApprovalRuleID,
+			&i.DatasetAccessRequest.RenewsAccessID,
+			&i.DatasetName,
+			&i.DataproductID,
+			&i.DataproductSlug,
+			&i.DataproductName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccessRequestsForOwnerPage = `-- name: ListAccessRequestsForOwnerPage :many
+(
+    SELECT dar.id, dar.dataset_id, dar.subject, dar.owner, dar.polly_documentation_id, dar.last_modified, dar.created, dar.expires, dar.status, dar.closed, dar.granter, dar.reason, dar.approval_rule_id, dar.renews_access_id
+    FROM dataset_access_requests dar
+    WHERE NOT $1::bool
+    AND dar."owner" = ANY ($2::text[])
+    AND ($3::uuid IS NULL OR (dar.created, dar.id) > ($4::timestamptz, $3::uuid))
+    ORDER BY dar.created ASC, dar.id ASC
+    LIMIT $5::int
+)
+UNION ALL
+(
+    SELECT dar.id, dar.dataset_id, dar.subject, dar.owner, dar.polly_documentation_id, dar.last_modified, dar.created, dar.expires, dar.status, dar.closed, dar.granter, dar.reason, dar.approval_rule_id, dar.renews_access_id
+    FROM dataset_access_requests dar
+    WHERE $1::bool
+    AND dar."owner" = ANY ($2::text[])
+    AND ($3::uuid IS NULL OR (dar.created, dar.id) < ($4::timestamptz, $3::uuid))
+    ORDER BY dar.created DESC, dar.id DESC
+    LIMIT $5::int
+)
+`
+
+type ListAccessRequestsForOwnerPageParams struct {
+	Descending   bool
+	Owner        []string
+	AfterID      uuid.NullUUID
+	AfterCreated time.Time
+	Lim          int32
+}
+
+type ListAccessRequestsForOwnerPageRow struct {
+	DatasetAccessRequest DatasetAccessRequest
+}
+
+func (q *Queries) ListAccessRequestsForOwnerPage(ctx context.Context, arg ListAccessRequestsForOwnerPageParams) ([]ListAccessRequestsForOwnerPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAccessRequestsForOwnerPage,
+		arg.Descending,
+		pq.Array(arg.Owner),
+		arg.AfterID,
+		arg.AfterCreated,
+		arg.Lim,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []DatasetAccessRequest{}
+	items := []ListAccessRequestsForOwnerPageRow{}
 	for rows.Next() {
-		var i DatasetAccessRequest
+		var i ListAccessRequestsForOwnerPageRow
 		if err := rows.Scan(
-			&i.ID,
-			&i.DatasetID,
-			&i.Subject,
-			&i.Owner,
-			&i.PollyDocumentationID,
-			&i.LastModified,
-			&i.Created,
-			&i.Expires,
-			&i.Status,
-			&i.Closed,
-			&i.Granter,
-			&i.Reason,
+			&i.DatasetAccessRequest.ID,
+			&i.DatasetAccessRequest.DatasetID,
+			&i.DatasetAccessRequest.Subject,
+			&i.DatasetAccessRequest.Owner,
+			&i.DatasetAccessRequest.PollyDocumentationID,
+			&i.DatasetAccessRequest.LastModified,
+			&i.DatasetAccessRequest.Created,
+			&i.DatasetAccessRequest.Expires,
+			&i.DatasetAccessRequest.Status,
+			&i.DatasetAccessRequest.Closed,
+			&i.DatasetAccessRequest.Granter,
+			&i.DatasetAccessRequest.Reason,
+			&i.DatasetAccessRequest.ApprovalRuleID,
+			&i.DatasetAccessRequest.RenewsAccessID,
 		); err != nil {
 			return nil, err
 		}
@@ -227,7 +474,7 @@ SET owner                  = $1,
     polly_documentation_id = $2,
     expires = $3
 WHERE id = $4
-RETURNING id, dataset_id, subject, owner, polly_documentation_id, last_modified, created, expires, status, closed, granter, reason
+RETURNING id, dataset_id, subject, owner, polly_documentation_id, last_modified, created, expires, status, closed, granter, reason, approval_rule_id, renews_access_id
 `
 
 type UpdateAccessRequestParams struct {
@@ -258,6 +505,8 @@ func (q *Queries) UpdateAccessRequest(ctx context.Context, arg UpdateAccessReque
 		&i.Closed,
 		&i.Granter,
 		&i.Reason,
+		&i.ApprovalRuleID,
+		&i.RenewsAccessID,
 	)
 	return i, err
 }
@@ -8,6 +8,7 @@ package gensql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -29,7 +30,10 @@ INSERT INTO
     "table_type",
     "pii_tags",
     "pseudo_columns",
-    "is_reference"
+    "is_reference",
+    "scope",
+    "table_pattern",
+    "tables"
   )
 VALUES
   (
@@ -44,8 +48,11 @@ VALUES
     $9,
     $10,
     $11,
-    $12
-  ) RETURNING dataset_id, project_id, dataset, table_name, schema, last_modified, created, expires, table_type, description, pii_tags, missing_since, id, is_reference, pseudo_columns, deleted
+    $12,
+    $13,
+    $14,
+    $15
+  ) RETURNING dataset_id, project_id, dataset, table_name, schema, last_modified, created, expires, table_type, description, pii_tags, missing_since, id, is_reference, deleted, pseudo_columns, scope, table_pattern, tables
 `
 
 type CreateBigqueryDatasourceParams struct {
@@ -59,8 +66,11 @@ type CreateBigqueryDatasourceParams struct {
 	Expires       sql.NullTime
 	TableType     string
 	PiiTags       pqtype.NullRawMessage
-	PseudoColumns []string
+	PseudoColumns json.RawMessage
 	IsReference   bool
+	Scope         string
+	TablePattern  string
+	Tables        pqtype.NullRawMessage
 }
 
 func (q *Queries) CreateBigqueryDatasource(ctx context.Context, arg CreateBigqueryDatasourceParams) (DatasourceBigquery, error) {
@@ -75,8 +85,11 @@ func (q *Queries) CreateBigqueryDatasource(ctx context.Context, arg CreateBigque
 		arg.Expires,
 		arg.TableType,
 		arg.PiiTags,
-		pq.Array(arg.PseudoColumns),
+		arg.PseudoColumns,
 		arg.IsReference,
+		arg.Scope,
+		arg.TablePattern,
+		arg.Tables,
 	)
 	var i DatasourceBigquery
 	err := row.Scan(
@@ -94,8 +107,11 @@ func (q *Queries) CreateBigqueryDatasource(ctx context.Context, arg CreateBigque
 		&i.MissingSince,
 		&i.ID,
 		&i.IsReference,
-		pq.Array(&i.PseudoColumns),
 		&i.Deleted,
+		&i.PseudoColumns,
+		&i.Scope,
+		&i.TablePattern,
+		&i.Tables,
 	)
 	return i, err
 }
@@ -126,7 +142,7 @@ VALUES
     $8,
     $9,
     $10
-  ) RETURNING id, name, description, pii, created, last_modified, type, tsv_document, slug, repo, keywords, dataproduct_id, anonymisation_description, target_user
+  ) RETURNING id, name, description, pii, created, last_modified, type, tsv_document, slug, repo, keywords, dataproduct_id, anonymisation_description, target_user, lifecycle_status, replaced_by, sunset, deprecation_reason
 `
 
 type CreateDatasetParams struct {
@@ -171,13 +187,17 @@ func (q *Queries) CreateDataset(ctx context.Context, arg CreateDatasetParams) (D
 		&i.DataproductID,
 		&i.AnonymisationDescription,
 		&i.TargetUser,
+		&i.LifecycleStatus,
+		&i.ReplacedBy,
+		&i.Sunset,
+		&i.DeprecationReason,
 	)
 	return i, err
 }
 
 const datasetsByMetabase = `-- name: DatasetsByMetabase :many
 SELECT
-  id, name, description, pii, created, last_modified, type, tsv_document, slug, repo, keywords, dataproduct_id, anonymisation_description, target_user
+  id, name, description, pii, created, last_modified, type, tsv_document, slug, repo, keywords, dataproduct_id, anonymisation_description, target_user, lifecycle_status, replaced_by, sunset, deprecation_reason
 FROM
   datasets
 WHERE
@@ -224,6 +244,10 @@ func (q *Queries) DatasetsByMetabase(ctx context.Context, arg DatasetsByMetabase
 			&i.DataproductID,
 			&i.AnonymisationDescription,
 			&i.TargetUser,
+			&i.LifecycleStatus,
+			&i.ReplacedBy,
+			&i.Sunset,
+			&i.DeprecationReason,
 		); err != nil {
 			return nil, err
 		}
@@ -349,7 +373,7 @@ func (q *Queries) GetAccessiblePseudoDatasetsByUser(ctx context.Context, arg Get
 
 const getBigqueryDatasource = `-- name: GetBigqueryDatasource :one
 SELECT
-  dataset_id, project_id, dataset, table_name, schema, last_modified, created, expires, table_type, description, pii_tags, missing_since, id, is_reference, pseudo_columns, deleted
+  dataset_id, project_id, dataset, table_name, schema, last_modified, created, expires, table_type, description, pii_tags, missing_since, id, is_reference, deleted, pseudo_columns, scope, table_pattern, tables
 FROM
   datasource_bigquery
 WHERE
@@ -380,15 +404,18 @@ func (q *Queries) GetBigqueryDatasource(ctx context.Context, arg GetBigqueryData
 		&i.MissingSince,
 		&i.ID,
 		&i.IsReference,
-		pq.Array(&i.PseudoColumns),
 		&i.Deleted,
+		&i.PseudoColumns,
+		&i.Scope,
+		&i.TablePattern,
+		&i.Tables,
 	)
 	return i, err
 }
 
 const getBigqueryDatasources = `-- name: GetBigqueryDatasources :many
 SELECT
-  dataset_id, project_id, dataset, table_name, schema, last_modified, created, expires, table_type, description, pii_tags, missing_since, id, is_reference, pseudo_columns, deleted
+  dataset_id, project_id, dataset, table_name, schema, last_modified, created, expires, table_type, description, pii_tags, missing_since, id, is_reference, deleted, pseudo_columns, scope, table_pattern, tables
 FROM
   datasource_bigquery
 `
@@ -417,8 +444,11 @@ func (q *Queries) GetBigqueryDatasources(ctx context.Context) ([]DatasourceBigqu
 			&i.MissingSince,
 			&i.ID,
 			&i.IsReference,
-			pq.Array(&i.PseudoColumns),
 			&i.Deleted,
+			&i.PseudoColumns,
+			&i.Scope,
+			&i.TablePattern,
+			&i.Tables,
 		); err != nil {
 			return nil, err
 		}
@@ -435,7 +465,7 @@ func (q *Queries) GetBigqueryDatasources(ctx context.Context) ([]DatasourceBigqu
 
 const getDataset = `-- name: GetDataset :one
 SELECT
-  id, name, description, pii, created, last_modified, type, tsv_document, slug, repo, keywords, dataproduct_id, anonymisation_description, target_user
+  id, name, description, pii, created, last_modified, type, tsv_document, slug, repo, keywords, dataproduct_id, anonymisation_description, target_user, lifecycle_status, replaced_by, sunset, deprecation_reason
 FROM
   datasets
 WHERE
@@ -460,13 +490,17 @@ func (q *Queries) GetDataset(ctx context.Context, id uuid.UUID) (Dataset, error)
 		&i.DataproductID,
 		&i.AnonymisationDescription,
 		&i.TargetUser,
+		&i.LifecycleStatus,
+		&i.ReplacedBy,
+		&i.Sunset,
+		&i.DeprecationReason,
 	)
 	return i, err
 }
 
 const getDatasets = `-- name: GetDatasets :many
 SELECT
-  id, name, description, pii, created, last_modified, type, tsv_document, slug, repo, keywords, dataproduct_id, anonymisation_description, target_user
+  id, name, description, pii, created, last_modified, type, tsv_document, slug, repo, keywords, dataproduct_id, anonymisation_description, target_user, lifecycle_status, replaced_by, sunset, deprecation_reason
 FROM
   datasets
 ORDER BY
@@ -504,6 +538,10 @@ func (q *Queries) GetDatasets(ctx context.Context, arg GetDatasetsParams) ([]Dat
 			&i.DataproductID,
 			&i.AnonymisationDescription,
 			&i.TargetUser,
+			&i.LifecycleStatus,
+			&i.ReplacedBy,
+			&i.Sunset,
+			&i.DeprecationReason,
 		); err != nil {
 			return nil, err
 		}
@@ -520,7 +558,7 @@ func (q *Queries) GetDatasets(ctx context.Context, arg GetDatasetsParams) ([]Dat
 
 const getDatasetsByGroups = `-- name: GetDatasetsByGroups :many
 SELECT
-  id, name, description, pii, created, last_modified, type, tsv_document, slug, repo, keywords, dataproduct_id, anonymisation_description, target_user
+  id, name, description, pii, created, last_modified, type, tsv_document, slug, repo, keywords, dataproduct_id, anonymisation_description, target_user, lifecycle_status, replaced_by, sunset, deprecation_reason
 FROM
   datasets
 WHERE
@@ -553,6 +591,10 @@ func (q *Queries) GetDatasetsByGroups(ctx context.Context, groups []string) ([]D
 			&i.DataproductID,
 			&i.AnonymisationDescription,
 			&i.TargetUser,
+			&i.LifecycleStatus,
+			&i.ReplacedBy,
+			&i.Sunset,
+			&i.DeprecationReason,
 		); err != nil {
 			return nil, err
 		}
@@ -569,7 +611,7 @@ func (q *Queries) GetDatasetsByGroups(ctx context.Context, groups []string) ([]D
 
 const getDatasetsByIDs = `-- name: GetDatasetsByIDs :many
 SELECT
-  id, name, description, pii, created, last_modified, type, tsv_document, slug, repo, keywords, dataproduct_id, anonymisation_description, target_user
+  id, name, description, pii, created, last_modified, type, tsv_document, slug, repo, keywords, dataproduct_id, anonymisation_description, target_user, lifecycle_status, replaced_by, sunset, deprecation_reason
 FROM
   datasets
 WHERE
@@ -602,6 +644,10 @@ func (q *Queries) GetDatasetsByIDs(ctx context.Context, ids []uuid.UUID) ([]Data
 			&i.DataproductID,
 			&i.AnonymisationDescription,
 			&i.TargetUser,
+			&i.LifecycleStatus,
+			&i.ReplacedBy,
+			&i.Sunset,
+			&i.DeprecationReason,
 		); err != nil {
 			return nil, err
 		}
@@ -618,7 +664,7 @@ func (q *Queries) GetDatasetsByIDs(ctx context.Context, ids []uuid.UUID) ([]Data
 
 const getDatasetsByUserAccess = `-- name: GetDatasetsByUserAccess :many
 SELECT
-  id, name, description, pii, created, last_modified, type, tsv_document, slug, repo, keywords, dataproduct_id, anonymisation_description, target_user
+  id, name, description, pii, created, last_modified, type, tsv_document, slug, repo, keywords, dataproduct_id, anonymisation_description, target_user, lifecycle_status, replaced_by, sunset, deprecation_reason
 FROM
   datasets
 WHERE
@@ -663,6 +709,10 @@ func (q *Queries) GetDatasetsByUserAccess(ctx context.Context, id string) ([]Dat
 			&i.DataproductID,
 			&i.AnonymisationDescription,
 			&i.TargetUser,
+			&i.LifecycleStatus,
+			&i.ReplacedBy,
+			&i.Sunset,
+			&i.DeprecationReason,
 		); err != nil {
 			return nil, err
 		}
@@ -679,7 +729,7 @@ func (q *Queries) GetDatasetsByUserAccess(ctx context.Context, id string) ([]Dat
 
 const getDatasetsForOwner = `-- name: GetDatasetsForOwner :many
 SELECT
-  ds.id, ds.name, ds.description, ds.pii, ds.created, ds.last_modified, ds.type, ds.tsv_document, ds.slug, ds.repo, ds.keywords, ds.dataproduct_id, ds.anonymisation_description, ds.target_user
+  ds.id, ds.name, ds.description, ds.pii, ds.created, ds.last_modified, ds.type, ds.tsv_document, ds.slug, ds.repo, ds.keywords, ds.dataproduct_id, ds.anonymisation_description, ds.target_user, ds.lifecycle_status, ds.replaced_by, ds.sunset, ds.deprecation_reason
 FROM
   datasets ds
 WHERE
@@ -717,6 +767,10 @@ func (q *Queries) GetDatasetsForOwner(ctx context.Context, groups []string) ([]D
 			&i.DataproductID,
 			&i.AnonymisationDescription,
 			&i.TargetUser,
+			&i.LifecycleStatus,
+			&i.ReplacedBy,
+			&i.Sunset,
+			&i.DeprecationReason,
 		); err != nil {
 			return nil, err
 		}
@@ -733,7 +787,7 @@ func (q *Queries) GetDatasetsForOwner(ctx context.Context, groups []string) ([]D
 
 const getDatasetsInDataproduct = `-- name: GetDatasetsInDataproduct :many
 SELECT
-  id, name, description, pii, created, last_modified, type, tsv_document, slug, repo, keywords, dataproduct_id, anonymisation_description, target_user
+  id, name, description, pii, created, last_modified, type, tsv_document, slug, repo, keywords, dataproduct_id, anonymisation_description, target_user, lifecycle_status, replaced_by, sunset, deprecation_reason
 FROM
   datasets
 WHERE
@@ -764,6 +818,10 @@ func (q *Queries) GetDatasetsInDataproduct(ctx context.Context, dataproductID uu
 			&i.DataproductID,
 			&i.AnonymisationDescription,
 			&i.TargetUser,
+			&i.LifecycleStatus,
+			&i.ReplacedBy,
+			&i.Sunset,
+			&i.DeprecationReason,
 		); err != nil {
 			return nil, err
 		}
@@ -803,14 +861,14 @@ func (q *Queries) GetOwnerGroupOfDataset(ctx context.Context, datasetID uuid.UUI
 
 const getPseudoDatasourcesToDelete = `-- name: GetPseudoDatasourcesToDelete :many
 SELECT
-  bq.dataset_id, bq.project_id, bq.dataset, bq.table_name, bq.schema, bq.last_modified, bq.created, bq.expires, bq.table_type, bq.description, bq.pii_tags, bq.missing_since, bq.id, bq.is_reference, bq.pseudo_columns, bq.deleted
+  bq.dataset_id, bq.project_id, bq.dataset, bq.table_name, bq.schema, bq.last_modified, bq.created, bq.expires, bq.table_type, bq.description, bq.pii_tags, bq.missing_since, bq.id, bq.is_reference, bq.deleted, bq.pseudo_columns, bq.scope, bq.table_pattern, bq.tables
 FROM
   datasource_bigquery bq
   LEFT JOIN datasets ds ON bq.dataset_id = ds.id
 WHERE
   ds.id IS NULL
   AND bq.deleted is NULL
-  AND ARRAY_LENGTH(bq.pseudo_columns, 1) > 0
+  AND jsonb_array_length(bq.pseudo_columns) > 0
 `
 
 func (q *Queries) GetPseudoDatasourcesToDelete(ctx context.Context) ([]DatasourceBigquery, error) {
@@ -837,8 +895,11 @@ func (q *Queries) GetPseudoDatasourcesToDelete(ctx context.Context) ([]Datasourc
 			&i.MissingSince,
 			&i.ID,
 			&i.IsReference,
-			pq.Array(&i.PseudoColumns),
 			&i.Deleted,
+			&i.PseudoColumns,
+			&i.Scope,
+			&i.TablePattern,
+			&i.Tables,
 		); err != nil {
 			return nil, err
 		}
@@ -896,12 +957,12 @@ WHERE
 
 type UpdateBigqueryDatasourceParams struct {
 	PiiTags       pqtype.NullRawMessage
-	PseudoColumns []string
+	PseudoColumns json.RawMessage
 	DatasetID     uuid.UUID
 }
 
 func (q *Queries) UpdateBigqueryDatasource(ctx context.Context, arg UpdateBigqueryDatasourceParams) error {
-	_, err := q.db.ExecContext(ctx, updateBigqueryDatasource, arg.PiiTags, pq.Array(arg.PseudoColumns), arg.DatasetID)
+	_, err := q.db.ExecContext(ctx, updateBigqueryDatasource, arg.PiiTags, arg.PseudoColumns, arg.DatasetID)
 	return err
 }
 
@@ -927,22 +988,19 @@ SET
   "last_modified" = $2,
   "expires" = $3,
   "description" = $4,
-  "missing_since" = null,
-  "pseudo_columns" = CASE
-    WHEN $5::text[] IS NOT NULL THEN $5::text[]
-    ELSE "pseudo_columns"
-  END
+  "tables" = $5,
+  "missing_since" = null
 WHERE
   dataset_id = $6
 `
 
 type UpdateBigqueryDatasourceSchemaParams struct {
-	Schema        pqtype.NullRawMessage
-	LastModified  time.Time
-	Expires       sql.NullTime
-	Description   sql.NullString
-	PseudoColumns []string
-	DatasetID     uuid.UUID
+	Schema       pqtype.NullRawMessage
+	LastModified time.Time
+	Expires      sql.NullTime
+	Description  sql.NullString
+	Tables       pqtype.NullRawMessage
+	DatasetID    uuid.UUID
 }
 
 func (q *Queries) UpdateBigqueryDatasourceSchema(ctx context.Context, arg UpdateBigqueryDatasourceSchemaParams) error {
@@ -951,7 +1009,7 @@ func (q *Queries) UpdateBigqueryDatasourceSchema(ctx context.Context, arg Update
 		arg.LastModified,
 		arg.Expires,
 		arg.Description,
-		pq.Array(arg.PseudoColumns),
+		arg.Tables,
 		arg.DatasetID,
 	)
 	return err
@@ -971,7 +1029,9 @@ SET
   "anonymisation_description" = $8,
   "target_user" = $9
 WHERE
-  id = $10 RETURNING id, name, description, pii, created, last_modified, type, tsv_document, slug, repo, keywords, dataproduct_id, anonymisation_description, target_user
+  id = $10
+  AND ($11::timestamptz IS NULL OR last_modified = $11)
+RETURNING id, name, description, pii, created, last_modified, type, tsv_document, slug, repo, keywords, dataproduct_id, anonymisation_description, target_user, lifecycle_status, replaced_by, sunset, deprecation_reason
 `
 
 type UpdateDatasetParams struct {
@@ -985,6 +1045,7 @@ type UpdateDatasetParams struct {
 	AnonymisationDescription sql.NullString
 	TargetUser               sql.NullString
 	ID                       uuid.UUID
+	IfMatch                  sql.NullTime
 }
 
 func (q *Queries) UpdateDataset(ctx context.Context, arg UpdateDatasetParams) (Dataset, error) {
@@ -999,6 +1060,7 @@ func (q *Queries) UpdateDataset(ctx context.Context, arg UpdateDatasetParams) (D
 		arg.AnonymisationDescription,
 		arg.TargetUser,
 		arg.ID,
+		arg.IfMatch,
 	)
 	var i Dataset
 	err := row.Scan(
@@ -1016,6 +1078,10 @@ func (q *Queries) UpdateDataset(ctx context.Context, arg UpdateDatasetParams) (D
 		&i.DataproductID,
 		&i.AnonymisationDescription,
 		&i.TargetUser,
+		&i.LifecycleStatus,
+		&i.ReplacedBy,
+		&i.Sunset,
+		&i.DeprecationReason,
 	)
 	return i, err
 }
@@ -7,103 +7,44 @@ package gensql
 
 import (
 	"context"
-	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 )
 
-const getAccessibleDatasets = `-- name: GetAccessibleDatasets :many
-SELECT
-  DISTINCT ON (ds.id)
-  ds.id, ds.name, ds.description, ds.pii, ds.created, ds.last_modified, ds.type, ds.tsv_document, ds.slug, ds.repo, ds.keywords, ds.dataproduct_id, ds.anonymisation_description, ds.target_user,
-  dsa.subject AS "subject",
-  dsa.owner AS "access_owner",
-  dp.slug AS dp_slug,
-  dp.name AS dp_name,
-  dp.group
-FROM
-  datasets ds
-  LEFT JOIN dataproducts dp ON ds.dataproduct_id = dp.id
-  LEFT JOIN dataset_access dsa ON dsa.dataset_id = ds.id
-WHERE
-  array_length($1::TEXT[], 1) IS NOT NULL AND array_length($1::TEXT[], 1)!=0
-  AND dp.group = ANY($1 :: TEXT [])
-  OR (
-    SPLIT_PART(dsa.subject, ':', 1) != 'serviceAccount'
-    AND (
-        $2::TEXT IS NOT NULL
-        AND dsa.subject = LOWER($2)
-        OR SPLIT_PART(dsa.subject, ':', 2) = ANY($1::TEXT[])
-    )
-  )
-  AND revoked IS NULL
-  AND (
-    expires > NOW()
-    OR expires IS NULL
-  )
-ORDER BY
-  ds.id,
-  ds.last_modified DESC
+const getAllDatasetsMinimal = `-- name: GetAllDatasetsMinimal :many
+SELECT ds.id, ds.created, name, project_id, dataset, table_name 
+FROM datasets ds 
+JOIN datasource_bigquery dsb 
+ON ds.id = dsb.dataset_id
 `
 
-type GetAccessibleDatasetsParams struct {
-	Groups    []string
-	Requester string
-}
-
-type GetAccessibleDatasetsRow struct {
-	ID                       uuid.UUID
-	Name                     string
-	Description              sql.NullString
-	Pii                      PiiLevel
-	Created                  time.Time
-	LastModified             time.Time
-	Type                     DatasourceType
-	TsvDocument              interface{}
-	Slug                     string
-	Repo                     sql.NullString
-	Keywords                 []string
-	DataproductID            uuid.UUID
-	AnonymisationDescription sql.NullString
-	TargetUser               sql.NullString
-	Subject                  sql.NullString
-	AccessOwner              sql.NullString
-	DpSlug                   sql.NullString
-	DpName                   sql.NullString
-	Group                    sql.NullString
+type GetAllDatasetsMinimalRow struct {
+	ID        uuid.UUID
+	Created   time.Time
+	Name      string
+	ProjectID string
+	Dataset   string
+	TableName string
 }
 
-func (q *Queries) GetAccessibleDatasets(ctx context.Context, arg GetAccessibleDatasetsParams) ([]GetAccessibleDatasetsRow, error) {
-	rows, err := q.db.QueryContext(ctx, getAccessibleDatasets, pq.Array(arg.Groups), arg.Requester)
+func (q *Queries) GetAllDatasetsMinimal(ctx context.Context) ([]GetAllDatasetsMinimalRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAllDatasetsMinimal)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetAccessibleDatasetsRow{}
+	items := []GetAllDatasetsMinimalRow{}
 	for rows.Next() {
-		var i GetAccessibleDatasetsRow
+		var i GetAllDatasetsMinimalRow
 		if err := rows.Scan(
 			&i.ID,
-			&i.Name,
-			&i.Description,
-			&i.Pii,
 			&i.Created,
-			&i.LastModified,
-			&i.Type,
-			&i.TsvDocument,
-			&i.Slug,
-			&i.Repo,
-			pq.Array(&i.Keywords),
-			&i.DataproductID,
-			&i.AnonymisationDescription,
-			&i.TargetUser,
-			&i.Subject,
-			&i.AccessOwner,
-			&i.DpSlug,
-			&i.DpName,
-			&i.Group,
+			&i.Name,
+			&i.ProjectID,
+			&i.Dataset,
+			&i.TableName,
 		); err != nil {
 			return nil, err
 		}
@@ -118,89 +59,66 @@ func (q *Queries) GetAccessibleDatasets(ctx context.Context, arg GetAccessibleDa
 	return items, nil
 }
 
-const getAccessibleDatasetsByOwnedServiceAccounts = `-- name: GetAccessibleDatasetsByOwnedServiceAccounts :many
+const getDatasetComplete = `-- name: GetDatasetComplete :many
 SELECT
-  ds.id, ds.name, ds.description, ds.pii, ds.created, ds.last_modified, ds.type, ds.tsv_document, ds.slug, ds.repo, ds.keywords, ds.dataproduct_id, ds.anonymisation_description, ds.target_user,
-  dsa.subject AS "subject",
-  dsa.owner AS "access_owner",
-  dp.slug AS dp_slug,
-  dp.name AS dp_name,
-  dp.group
+  ds_id, ds_name, ds_description, ds_created, ds_last_modified, ds_slug, pii, ds_keywords, ds_repo, ds_lifecycle_status, ds_replaced_by, ds_sunset, ds_deprecation_reason, bq_id, bq_created, bq_last_modified, bq_expires, bq_description, bq_missing_since, pii_tags, bq_project, bq_dataset, bq_table_name, bq_table_type, pseudo_columns, bq_schema, ds_dp_id, mapping_services, access_id, access_subject, access_owner, access_granter, access_expires, access_created, access_revoked, access_request_id, mb_database_id, mb_deleted_at, bq_scope, bq_table_pattern, bq_tables
 FROM
-  datasets ds
-  LEFT JOIN dataproducts dp ON ds.dataproduct_id = dp.id
-  LEFT JOIN dataset_access dsa ON dsa.dataset_id = ds.id
+  dataset_view
 WHERE
-  SPLIT_PART("subject", ':', 1) = 'serviceAccount'
-  AND (
-    dsa.owner = $1
-    OR dsa.owner = ANY($2::TEXT[])
-  )  
-  AND revoked IS NULL
-  AND (
-    expires > NOW()
-    OR expires IS NULL
-  )
-ORDER BY
-  ds.last_modified DESC
+  ds_id = $1
 `
 
-type GetAccessibleDatasetsByOwnedServiceAccountsParams struct {
-	Requester string
-	Groups    []string
-}
-
-type GetAccessibleDatasetsByOwnedServiceAccountsRow struct {
-	ID                       uuid.UUID
-	Name                     string
-	Description              sql.NullString
-	Pii                      PiiLevel
-	Created                  time.Time
-	LastModified             time.Time
-	Type                     DatasourceType
-	TsvDocument              interface{}
-	Slug                     string
-	Repo                     sql.NullString
-	Keywords                 []string
-	DataproductID            uuid.UUID
-	AnonymisationDescription sql.NullString
-	TargetUser               sql.NullString
-	Subject                  sql.NullString
-	AccessOwner              sql.NullString
-	DpSlug                   sql.NullString
-	DpName                   sql.NullString
-	Group                    sql.NullString
-}
-
-func (q *Queries) GetAccessibleDatasetsByOwnedServiceAccounts(ctx context.Context, arg GetAccessibleDatasetsByOwnedServiceAccountsParams) ([]GetAccessibleDatasetsByOwnedServiceAccountsRow, error) {
-	rows, err := q.db.QueryContext(ctx, getAccessibleDatasetsByOwnedServiceAccounts, arg.Requester, pq.Array(arg.Groups))
+func (q *Queries) GetDatasetComplete(ctx context.Context, id uuid.UUID) ([]DatasetView, error) {
+	rows, err := q.db.QueryContext(ctx, getDatasetComplete, id)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetAccessibleDatasetsByOwnedServiceAccountsRow{}
+	items := []DatasetView{}
 	for rows.Next() {
-		var i GetAccessibleDatasetsByOwnedServiceAccountsRow
+		var i DatasetView
 		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Description,
+			&i.DsID,
+			&i.DsName,
+			&i.DsDescription,
+			&i.DsCreated,
+			&i.DsLastModified,
+			&i.DsSlug,
 			&i.Pii,
-			&i.Created,
-			&i.LastModified,
-			&i.Type,
-			&i.TsvDocument,
-			&i.Slug,
-			&i.Repo,
-			pq.Array(&i.Keywords),
-			&i.DataproductID,
-			&i.AnonymisationDescription,
-			&i.TargetUser,
-			&i.Subject,
+			pq.Array(&i.DsKeywords),
+			&i.DsRepo,
+			&i.DsLifecycleStatus,
+			&i.DsReplacedBy,
+			&i.DsSunset,
+			&i.DsDeprecationReason,
+			&i.BqID,
+			&i.BqCreated,
+			&i.BqLastModified,
+			&i.BqExpires,
+			&i.BqDescription,
+			&i.BqMissingSince,
+			&i.PiiTags,
+			&i.BqProject,
+			&i.BqDataset,
+			&i.BqTableName,
+			&i.BqTableType,
+			&i.PseudoColumns,
+			&i.BqSchema,
+			&i.DsDpID,
+			pq.Array(&i.MappingServices),
+			&i.AccessID,
+			&i.AccessSubject,
 			&i.AccessOwner,
-			&i.DpSlug,
-			&i.DpName,
-			&i.Group,
+			&i.AccessGranter,
+			&i.AccessExpires,
+			&i.AccessCreated,
+			&i.AccessRevoked,
+			&i.AccessRequestID,
+			&i.MbDatabaseID,
+			&i.MbDeletedAt,
+			&i.BqScope,
+			&i.BqTablePattern,
+			&i.BqTables,
 		); err != nil {
 			return nil, err
 		}
@@ -215,14 +133,58 @@ func (q *Queries) GetAccessibleDatasetsByOwnedServiceAccounts(ctx context.Contex
 	return items, nil
 }
 
-const getAllDatasetsMinimal = `-- name: GetAllDatasetsMinimal :many
-SELECT ds.id, ds.created, name, project_id, dataset, table_name 
-FROM datasets ds 
-JOIN datasource_bigquery dsb 
-ON ds.id = dsb.dataset_id
+const getDatasetsMinimalPage = `-- name: GetDatasetsMinimalPage :many
+(
+    SELECT ds.id, ds.created, ds.name, dsb.project_id, dsb.dataset, dsb.table_name
+    FROM datasets ds
+    JOIN datasource_bigquery dsb ON ds.id = dsb.dataset_id AND NOT dsb.is_reference
+    WHERE $1::text = 'name' AND NOT $2::bool
+    AND ($3::uuid IS NULL OR (ds.name, ds.id) > ($4::text, $3::uuid))
+    ORDER BY ds.name ASC, ds.id ASC
+    LIMIT $5::int
+)
+UNION ALL
+(
+    SELECT ds.id, ds.created, ds.name, dsb.project_id, dsb.dataset, dsb.table_name
+    FROM datasets ds
+    JOIN datasource_bigquery dsb ON ds.id = dsb.dataset_id AND NOT dsb.is_reference
+    WHERE $1::text = 'name' AND $2::bool
+    AND ($3::uuid IS NULL OR (ds.name, ds.id) < ($4::text, $3::uuid))
+    ORDER BY ds.name DESC, ds.id DESC
+    LIMIT $5::int
+)
+UNION ALL
+(
+    SELECT ds.id, ds.created, ds.name, dsb.project_id, dsb.dataset, dsb.table_name
+    FROM datasets ds
+    JOIN datasource_bigquery dsb ON ds.id = dsb.dataset_id AND NOT dsb.is_reference
+    WHERE $1::text = 'created' AND NOT $2::bool
+    AND ($3::uuid IS NULL OR (ds.created, ds.id) > ($6::timestamptz, $3::uuid))
+    ORDER BY ds.created ASC, ds.id ASC
+    LIMIT $5::int
+)
+UNION ALL
+(
+    SELECT ds.id, ds.created, ds.name, dsb.project_id, dsb.dataset, dsb.table_name
+    FROM datasets ds
+    JOIN datasource_bigquery dsb ON ds.id = dsb.dataset_id AND NOT dsb.is_reference
+    WHERE $1::text = 'created' AND $2::bool
+    AND ($3::uuid IS NULL OR (ds.created, ds.id) < ($6::timestamptz, $3::uuid))
+    ORDER BY ds.created DESC, ds.id DESC
+    LIMIT $5::int
+)
 `
 
-type GetAllDatasetsMinimalRow struct {
+type GetDatasetsMinimalPageParams struct {
+	Sort         string
+	Descending   bool
+	AfterID      uuid.NullUUID
+	AfterName    string
+	Lim          int32
+	AfterCreated time.Time
+}
+
+type GetDatasetsMinimalPageRow struct {
 	ID        uuid.UUID
 	Created   time.Time
 	Name      string
@@ -231,15 +193,22 @@ type GetAllDatasetsMinimalRow struct {
 	TableName string
 }
 
-func (q *Queries) GetAllDatasetsMinimal(ctx context.Context) ([]GetAllDatasetsMinimalRow, error) {
-	rows, err := q.db.QueryContext(ctx, getAllDatasetsMinimal)
+func (q *Queries) GetDatasetsMinimalPage(ctx context.Context, arg GetDatasetsMinimalPageParams) ([]GetDatasetsMinimalPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDatasetsMinimalPage,
+		arg.Sort,
+		arg.Descending,
+		arg.AfterID,
+		arg.AfterName,
+		arg.Lim,
+		arg.AfterCreated,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetAllDatasetsMinimalRow{}
+	items := []GetDatasetsMinimalPageRow{}
 	for rows.Next() {
-		var i GetAllDatasetsMinimalRow
+		var i GetDatasetsMinimalPageRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Created,
@@ -261,59 +230,414 @@ func (q *Queries) GetAllDatasetsMinimal(ctx context.Context) ([]GetAllDatasetsMi
 	return items, nil
 }
 
-const getDatasetComplete = `-- name: GetDatasetComplete :many
-SELECT
-  ds_id, ds_name, ds_description, ds_created, ds_last_modified, ds_slug, pii, ds_keywords, ds_repo, bq_id, bq_created, bq_last_modified, bq_expires, bq_description, bq_missing_since, pii_tags, bq_project, bq_dataset, bq_table_name, bq_table_type, pseudo_columns, bq_schema, ds_dp_id, mapping_services, access_id, access_subject, access_owner, access_granter, access_expires, access_created, access_revoked, access_request_id, mb_database_id, mb_deleted_at
-FROM
-  dataset_view
-WHERE
-  ds_id = $1
+const getGrantedDatasetsPage = `-- name: GetGrantedDatasetsPage :many
+(
+    SELECT ds.id, ds.name, ds.description, ds.pii, ds.created, ds.last_modified, ds.type, ds.tsv_document, ds.slug, ds.repo, ds.keywords, ds.dataproduct_id, ds.anonymisation_description, ds.target_user, ds.lifecycle_status, ds.replaced_by, ds.sunset, ds.deprecation_reason, dsa.subject, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    CROSS JOIN LATERAL (
+        SELECT a.subject
+        FROM dataset_access a
+        WHERE a.dataset_id = ds.id
+        AND SPLIT_PART(a.subject, ':', 1) != 'serviceAccount'
+        AND (a.subject = LOWER($1::text) OR SPLIT_PART(a.subject, ':', 2) = ANY($2::text[]))
+        AND a.revoked IS NULL
+        AND (a.expires > NOW() OR a.expires IS NULL)
+        ORDER BY a.created
+        LIMIT 1
+    ) dsa
+    WHERE $3::text = 'name' AND NOT $4::bool
+    AND NOT dp.group = ANY($2::text[]) AND dp.deleted IS NULL
+    AND ($5::uuid IS NULL OR (ds.name, ds.id) > ($6::text, $5::uuid))
+    ORDER BY ds.name ASC, ds.id ASC
+    LIMIT $7::int
+)
+UNION ALL
+(
+    SELECT ds.id, ds.name, ds.description, ds.pii, ds.created, ds.last_modified, ds.type, ds.tsv_document, ds.slug, ds.repo, ds.keywords, ds.dataproduct_id, ds.anonymisation_description, ds.target_user, ds.lifecycle_status, ds.replaced_by, ds.sunset, ds.deprecation_reason, dsa.subject, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    CROSS JOIN LATERAL (
+        SELECT a.subject
+        FROM dataset_access a
+        WHERE a.dataset_id = ds.id
+        AND SPLIT_PART(a.subject, ':', 1) != 'serviceAccount'
+        AND (a.subject = LOWER($1::text) OR SPLIT_PART(a.subject, ':', 2) = ANY($2::text[]))
+        AND a.revoked IS NULL
+        AND (a.expires > NOW() OR a.expires IS NULL)
+        ORDER BY a.created
+        LIMIT 1
+    ) dsa
+    WHERE $3::text = 'name' AND $4::bool
+    AND NOT dp.group = ANY($2::text[]) AND dp.deleted IS NULL
+    AND ($5::uuid IS NULL OR (ds.name, ds.id) < ($6::text, $5::uuid))
+    ORDER BY ds.name DESC, ds.id DESC
+    LIMIT $7::int
+)
+UNION ALL
+(
+    SELECT ds.id, ds.name, ds.description, ds.pii, ds.created, ds.last_modified, ds.type, ds.tsv_document, ds.slug, ds.repo, ds.keywords, ds.dataproduct_id, ds.anonymisation_description, ds.target_user, ds.lifecycle_status, ds.replaced_by, ds.sunset, ds.deprecation_reason, dsa.subject, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    CROSS JOIN LATERAL (
+        SELECT a.subject
+        FROM dataset_access a
+        WHERE a.dataset_id = ds.id
+        AND SPLIT_PART(a.subject, ':', 1) != 'serviceAccount'
+        AND (a.subject = LOWER($1::text) OR SPLIT_PART(a.subject, ':', 2) = ANY($2::text[]))
+        AND a.revoked IS NULL
+        AND (a.expires > NOW() OR a.expires IS NULL)
+        ORDER BY a.created
+        LIMIT 1
+    ) dsa
+    WHERE $3::text = 'created' AND NOT $4::bool
+    AND NOT dp.group = ANY($2::text[]) AND dp.deleted IS NULL
+    AND ($5::uuid IS NULL OR (ds.created, ds.id) > ($8::timestamptz, $5::uuid))
+    ORDER BY ds.created ASC, ds.id ASC
+    LIMIT $7::int
+)
+UNION ALL
+(
+    SELECT ds.id, ds.name, ds.description, ds.pii, ds.created, ds.last_modified, ds.type, ds.tsv_document, ds.slug, ds.repo, ds.keywords, ds.dataproduct_id, ds.anonymisation_description, ds.target_user, ds.lifecycle_status, ds.replaced_by, ds.sunset, ds.deprecation_reason, dsa.subject, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    CROSS JOIN LATERAL (
+        SELECT a.subject
+        FROM dataset_access a
+        WHERE a.dataset_id = ds.id
+        AND SPLIT_PART(a.subject, ':', 1) != 'serviceAccount'
+        AND (a.subject = LOWER($1::text) OR SPLIT_PART(a.subject, ':', 2) = ANY($2::text[]))
+        AND a.revoked IS NULL
+        AND (a.expires > NOW() OR a.expires IS NULL)
+        ORDER BY a.created
+        LIMIT 1
+    ) dsa
+    WHERE $3::text = 'created' AND $4::bool
+    AND NOT dp.group = ANY($2::text[]) AND dp.deleted IS NULL
+    AND ($5::uuid IS NULL OR (ds.created, ds.id) < ($8::timestamptz, $5::uuid))
+    ORDER BY ds.created DESC, ds.id DESC
+    LIMIT $7::int
+)
 `
 
-func (q *Queries) GetDatasetComplete(ctx context.Context, id uuid.UUID) ([]DatasetView, error) {
-	rows, err := q.db.QueryContext(ctx, getDatasetComplete, id)
+type GetGrantedDatasetsPageParams struct {
+	Requester    string
+	Groups       []string
+	Sort         string
+	Descending   bool
+	AfterID      uuid.NullUUID
+	AfterName    string
+	Lim          int32
+	AfterCreated time.Time
+}
+
+type GetGrantedDatasetsPageRow struct {
+	Dataset Dataset
+	Subject string
+	DpSlug  string
+	DpName  string
+	Group   string
+}
+
+func (q *Queries) GetGrantedDatasetsPage(ctx context.Context, arg GetGrantedDatasetsPageParams) ([]GetGrantedDatasetsPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, getGrantedDatasetsPage,
+		arg.Requester,
+		pq.Array(arg.Groups),
+		arg.Sort,
+		arg.Descending,
+		arg.AfterID,
+		arg.AfterName,
+		arg.Lim,
+		arg.AfterCreated,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []DatasetView{}
+	items := []GetGrantedDatasetsPageRow{}
 	for rows.Next() {
-		var i DatasetView
+		var i GetGrantedDatasetsPageRow
 		if err := rows.Scan(
-			&i.DsID,
-			&i.DsName,
-			&i.DsDescription,
-			&i.DsCreated,
-			&i.DsLastModified,
-			&i.DsSlug,
-			&i.Pii,
-			pq.Array(&i.DsKeywords),
-			&i.DsRepo,
-			&i.BqID,
-			&i.BqCreated,
-			&i.BqLastModified,
-			&i.BqExpires,
-			&i.BqDescription,
-			&i.BqMissingSince,
-			&i.PiiTags,
-			&i.BqProject,
-			&i.BqDataset,
-			&i.BqTableName,
-			&i.BqTableType,
-			pq.Array(&i.PseudoColumns),
-			&i.BqSchema,
-			&i.DsDpID,
-			pq.Array(&i.MappingServices),
+			&i.Dataset.ID,
+			&i.Dataset.Name,
+			&i.Dataset.Description,
+			&i.Dataset.Pii,
+			&i.Dataset.Created,
+			&i.Dataset.LastModified,
+			&i.Dataset.Type,
+			&i.Dataset.TsvDocument,
+			&i.Dataset.Slug,
+			&i.Dataset.Repo,
+			pq.Array(&i.Dataset.Keywords),
+			&i.Dataset.DataproductID,
+			&i.Dataset.AnonymisationDescription,
+			&i.Dataset.TargetUser,
+			&i.Dataset.LifecycleStatus,
+			&i.Dataset.ReplacedBy,
+			&i.Dataset.Sunset,
+			&i.Dataset.DeprecationReason,
+			&i.Subject,
+			&i.DpSlug,
+			&i.DpName,
+			&i.Group,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOwnedDatasetsPage = `-- name: GetOwnedDatasetsPage :many
+(
+    SELECT ds.id, ds.name, ds.description, ds.pii, ds.created, ds.last_modified, ds.type, ds.tsv_document, ds.slug, ds.repo, ds.keywords, ds.dataproduct_id, ds.anonymisation_description, ds.target_user, ds.lifecycle_status, ds.replaced_by, ds.sunset, ds.deprecation_reason, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    WHERE $1::text = 'name' AND NOT $2::bool
+    AND dp.group = ANY($3::text[]) AND dp.deleted IS NULL
+    AND ($4::uuid IS NULL OR (ds.name, ds.id) > ($5::text, $4::uuid))
+    ORDER BY ds.name ASC, ds.id ASC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT ds.id, ds.name, ds.description, ds.pii, ds.created, ds.last_modified, ds.type, ds.tsv_document, ds.slug, ds.repo, ds.keywords, ds.dataproduct_id, ds.anonymisation_description, ds.target_user, ds.lifecycle_status, ds.replaced_by, ds.sunset, ds.deprecation_reason, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    WHERE $1::text = 'name' AND $2::bool
+    AND dp.group = ANY($3::text[]) AND dp.deleted IS NULL
+    AND ($4::uuid IS NULL OR (ds.name, ds.id) < ($5::text, $4::uuid))
+    ORDER BY ds.name DESC, ds.id DESC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT ds.id, ds.name, ds.description, ds.pii, ds.created, ds.last_modified, ds.type, ds.tsv_document, ds.slug, ds.repo, ds.keywords, ds.dataproduct_id, ds.anonymisation_description, ds.target_user, ds.lifecycle_status, ds.replaced_by, ds.sunset, ds.deprecation_reason, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    WHERE $1::text = 'created' AND NOT $2::bool
+    AND dp.group = ANY($3::text[]) AND dp.deleted IS NULL
+    AND ($4::uuid IS NULL OR (ds.created, ds.id) > ($7::timestamptz, $4::uuid))
+    ORDER BY ds.created ASC, ds.id ASC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT ds.id, ds.name, ds.description, ds.pii, ds.created, ds.last_modified, ds.type, ds.tsv_document, ds.slug, ds.repo, ds.keywords, ds.dataproduct_id, ds.anonymisation_description, ds.target_user, ds.lifecycle_status, ds.replaced_by, ds.sunset, ds.deprecation_reason, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    WHERE $1::text = 'created' AND $2::bool
+    AND dp.group = ANY($3::text[]) AND dp.deleted IS NULL
+    AND ($4::uuid IS NULL OR (ds.created, ds.id) < ($7::timestamptz, $4::uuid))
+    ORDER BY ds.created DESC, ds.id DESC
+    LIMIT $6::int
+)
+`
+
+type GetOwnedDatasetsPageParams struct {
+	Sort         string
+	Descending   bool
+	Groups       []string
+	AfterID      uuid.NullUUID
+	AfterName    string
+	Lim          int32
+	AfterCreated time.Time
+}
+
+type GetOwnedDatasetsPageRow struct {
+	Dataset Dataset
+	DpSlug  string
+	DpName  string
+	Group   string
+}
+
+func (q *Queries) GetOwnedDatasetsPage(ctx context.Context, arg GetOwnedDatasetsPageParams) ([]GetOwnedDatasetsPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, getOwnedDatasetsPage,
+		arg.Sort,
+		arg.Descending,
+		pq.Array(arg.Groups),
+		arg.AfterID,
+		arg.AfterName,
+		arg.Lim,
+		arg.AfterCreated,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetOwnedDatasetsPageRow{}
+	for rows.Next() {
+		var i GetOwnedDatasetsPageRow
+		if err := rows.Scan(
+			&i.Dataset.ID,
+			&i.Dataset.Name,
+			&i.Dataset.Description,
+			&i.Dataset.Pii,
+			&i.Dataset.Created,
+			&i.Dataset.LastModified,
+			&i.Dataset.Type,
+			&i.Dataset.TsvDocument,
+			&i.Dataset.Slug,
+			&i.Dataset.Repo,
+			pq.Array(&i.Dataset.Keywords),
+			&i.Dataset.DataproductID,
+			&i.Dataset.AnonymisationDescription,
+			&i.Dataset.TargetUser,
+			&i.Dataset.LifecycleStatus,
+			&i.Dataset.ReplacedBy,
+			&i.Dataset.Sunset,
+			&i.Dataset.DeprecationReason,
+			&i.DpSlug,
+			&i.DpName,
+			&i.Group,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getServiceAccountGrantedDatasetsPage = `-- name: GetServiceAccountGrantedDatasetsPage :many
+(
+    SELECT ds.id, ds.name, ds.description, ds.pii, ds.created, ds.last_modified, ds.type, ds.tsv_document, ds.slug, ds.repo, ds.keywords, ds.dataproduct_id, ds.anonymisation_description, ds.target_user, ds.lifecycle_status, ds.replaced_by, ds.sunset, ds.deprecation_reason, dsa.id AS access_id, dsa.subject, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    JOIN dataset_access dsa ON dsa.dataset_id = ds.id
+    WHERE $1::text = 'name' AND NOT $2::bool
+    AND SPLIT_PART(dsa.subject, ':', 1) = 'serviceAccount'
+    AND (dsa.owner = $3::text OR dsa.owner = ANY($4::text[]))
+    AND dsa.revoked IS NULL
+    AND (dsa.expires > NOW() OR dsa.expires IS NULL)
+    AND dp.deleted IS NULL
+    AND ($5::uuid IS NULL OR (ds.name, dsa.id) > ($6::text, $5::uuid))
+    ORDER BY ds.name ASC, dsa.id ASC
+    LIMIT $7::int
+)
+UNION ALL
+(
+    SELECT ds.id, ds.name, ds.description, ds.pii, ds.created, ds.last_modified, ds.type, ds.tsv_document, ds.slug, ds.repo, ds.keywords, ds.dataproduct_id, ds.anonymisation_description, ds.target_user, ds.lifecycle_status, ds.replaced_by, ds.sunset, ds.deprecation_reason, dsa.id AS access_id, dsa.subject, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    JOIN dataset_access dsa ON dsa.dataset_id = ds.id
+    WHERE $1::text = 'name' AND $2::bool
+    AND SPLIT_PART(dsa.subject, ':', 1) = 'serviceAccount'
+    AND (dsa.owner = $3::text OR dsa.owner = ANY($4::text[]))
+    AND dsa.revoked IS NULL
+    AND (dsa.expires > NOW() OR dsa.expires IS NULL)
+    AND dp.deleted IS NULL
+    AND ($5::uuid IS NULL OR (ds.name, dsa.id) < ($6::text, $5::uuid))
+    ORDER BY ds.name DESC, dsa.id DESC
+    LIMIT $7::int
+)
+UNION ALL
+(
+    SELECT ds.id, ds.name, ds.description, ds.pii, ds.created, ds.last_modified, ds.type, ds.tsv_document, ds.slug, ds.repo, ds.keywords, ds.dataproduct_id, ds.anonymisation_description, ds.target_user, ds.lifecycle_status, ds.replaced_by, ds.sunset, ds.deprecation_reason, dsa.id AS access_id, dsa.subject, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    JOIN dataset_access dsa ON dsa.dataset_id = ds.id
+    WHERE $1::text = 'created' AND NOT $2::bool
+    AND SPLIT_PART(dsa.subject, ':', 1) = 'serviceAccount'
+    AND (dsa.owner = $3::text OR dsa.owner = ANY($4::text[]))
+    AND dsa.revoked IS NULL
+    AND (dsa.expires > NOW() OR dsa.expires IS NULL)
+    AND dp.deleted IS NULL
+    AND ($5::uuid IS NULL OR (ds.created, dsa.id) > ($8::timestamptz, $5::uuid))
+    ORDER BY ds.created ASC, dsa.id ASC
+    LIMIT $7::int
+)
+UNION ALL
+(
+    SELECT ds.id, ds.name, ds.description, ds.pii, ds.created, ds.last_modified, ds.type, ds.tsv_document, ds.slug, ds.repo, ds.keywords, ds.dataproduct_id, ds.anonymisation_description, ds.target_user, ds.lifecycle_status, ds.replaced_by, ds.sunset, ds.deprecation_reason, dsa.id AS access_id, dsa.subject, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    JOIN dataset_access dsa ON dsa.dataset_id = ds.id
+    WHERE $1::text = 'created' AND $2::bool
+    AND SPLIT_PART(dsa.subject, ':', 1) = 'serviceAccount'
+    AND (dsa.owner = $3::text OR dsa.owner = ANY($4::text[]))
+    AND dsa.revoked IS NULL
+    AND (dsa.expires > NOW() OR dsa.expires IS NULL)
+    AND dp.deleted IS NULL
+    AND ($5::uuid IS NULL OR (ds.created, dsa.id) < ($8::timestamptz, $5::uuid))
+    ORDER BY ds.created DESC, dsa.id DESC
+    LIMIT $7::int
+)
+`
+
+type GetServiceAccountGrantedDatasetsPageParams struct {
+	Sort         string
+	Descending   bool
+	Requester    string
+	Groups       []string
+	AfterID      uuid.NullUUID
+	AfterName    string
+	Lim          int32
+	AfterCreated time.Time
+}
+
+type GetServiceAccountGrantedDatasetsPageRow struct {
+	Dataset  Dataset
+	AccessID uuid.UUID
+	Subject  string
+	DpSlug   string
+	DpName   string
+	Group    string
+}
+
+func (q *Queries) GetServiceAccountGrantedDatasetsPage(ctx context.Context, arg GetServiceAccountGrantedDatasetsPageParams) ([]GetServiceAccountGrantedDatasetsPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, getServiceAccountGrantedDatasetsPage,
+		arg.Sort,
+		arg.Descending,
+		arg.Requester,
+		pq.Array(arg.Groups),
+		arg.AfterID,
+		arg.AfterName,
+		arg.Lim,
+		arg.AfterCreated,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetServiceAccountGrantedDatasetsPageRow{}
+	for rows.Next() {
+		var i GetServiceAccountGrantedDatasetsPageRow
+		if err := rows.Scan(
+			&i.Dataset.ID,
+			&i.Dataset.Name,
+			&i.Dataset.Description,
+			&i.Dataset.Pii,
+			&i.Dataset.Created,
+			&i.Dataset.LastModified,
+			&i.Dataset.Type,
+			&i.Dataset.TsvDocument,
+			&i.Dataset.Slug,
+			&i.Dataset.Repo,
+			pq.Array(&i.Dataset.Keywords),
+			&i.Dataset.DataproductID,
+			&i.Dataset.AnonymisationDescription,
+			&i.Dataset.TargetUser,
+			&i.Dataset.LifecycleStatus,
+			&i.Dataset.ReplacedBy,
+			&i.Dataset.Sunset,
+			&i.Dataset.DeprecationReason,
 			&i.AccessID,
-			&i.AccessSubject,
-			&i.AccessOwner,
-			&i.AccessGranter,
-			&i.AccessExpires,
-			&i.AccessCreated,
-			&i.AccessRevoked,
-			&i.AccessRequestID,
-			&i.MbDatabaseID,
-			&i.MbDeletedAt,
+			&i.Subject,
+			&i.DpSlug,
+			&i.DpName,
+			&i.Group,
 		); err != nil {
 			return nil, err
 		}
@@ -37,7 +37,7 @@ VALUES
         $7,
         $8,
         $9
-    ) RETURNING id, name, description, creator, created, last_modified, type, tsv_document, link, keywords, "group", teamkatalogen_url, team_id
+    ) RETURNING id, name, description, creator, created, last_modified, type, tsv_document, link, keywords, "group", teamkatalogen_url, team_id, deleted, deleted_by
 `
 
 type CreateInsightProductParams struct {
@@ -79,6 +79,8 @@ func (q *Queries) CreateInsightProduct(ctx context.Context, arg CreateInsightPro
 		&i.Group,
 		&i.TeamkatalogenUrl,
 		&i.TeamID,
+		&i.Deleted,
+		&i.DeletedBy,
 	)
 	return i, err
 }
@@ -97,11 +99,12 @@ func (q *Queries) DeleteInsightProduct(ctx context.Context, id uuid.UUID) error
 
 const getInsightProduct = `-- name: GetInsightProduct :one
 SELECT
-    id, name, description, creator, created, last_modified, type, tsv_document, link, keywords, "group", teamkatalogen_url, team_id
+    id, name, description, creator, created, last_modified, type, tsv_document, link, keywords, "group", teamkatalogen_url, team_id, deleted, deleted_by
 FROM
     insight_product
 WHERE
     id = $1
+    AND deleted IS NULL
 `
 
 func (q *Queries) GetInsightProduct(ctx context.Context, id uuid.UUID) (InsightProduct, error) {
@@ -121,17 +124,20 @@ func (q *Queries) GetInsightProduct(ctx context.Context, id uuid.UUID) (InsightP
 		&i.Group,
 		&i.TeamkatalogenUrl,
 		&i.TeamID,
+		&i.Deleted,
+		&i.DeletedBy,
 	)
 	return i, err
 }
 
 const getInsightProductByGroups_ = `-- name: GetInsightProductByGroups_ :many
 SELECT
-    id, name, description, creator, created, last_modified, type, tsv_document, link, keywords, "group", teamkatalogen_url, team_id
+    id, name, description, creator, created, last_modified, type, tsv_document, link, keywords, "group", teamkatalogen_url, team_id, deleted, deleted_by
 FROM
     insight_product
 WHERE
     "group" = ANY ($1 :: text [])
+    AND deleted IS NULL
 ORDER BY
     last_modified DESC
 `
@@ -159,6 +165,8 @@ func (q *Queries) GetInsightProductByGroups_(ctx context.Context, groups []strin
 			&i.Group,
 			&i.TeamkatalogenUrl,
 			&i.TeamID,
+			&i.Deleted,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -175,9 +183,11 @@ func (q *Queries) GetInsightProductByGroups_(ctx context.Context, groups []strin
 
 const getInsightProducts = `-- name: GetInsightProducts :many
 SELECT
-    id, name, description, creator, created, last_modified, type, tsv_document, link, keywords, "group", teamkatalogen_url, team_id
+    id, name, description, creator, created, last_modified, type, tsv_document, link, keywords, "group", teamkatalogen_url, team_id, deleted, deleted_by
 FROM
     insight_product
+WHERE
+    deleted IS NULL
 ORDER BY
     last_modified DESC
 `
@@ -205,6 +215,8 @@ func (q *Queries) GetInsightProducts(ctx context.Context) ([]InsightProduct, err
 			&i.Group,
 			&i.TeamkatalogenUrl,
 			&i.TeamID,
+			&i.Deleted,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -221,11 +233,12 @@ func (q *Queries) GetInsightProducts(ctx context.Context) ([]InsightProduct, err
 
 const getInsightProductsByIDs = `-- name: GetInsightProductsByIDs :many
 SELECT
-    id, name, description, creator, created, last_modified, type, tsv_document, link, keywords, "group", teamkatalogen_url, team_id
+    id, name, description, creator, created, last_modified, type, tsv_document, link, keywords, "group", teamkatalogen_url, team_id, deleted, deleted_by
 FROM
     insight_product
 WHERE
     id = ANY ($1 :: uuid [])
+    AND deleted IS NULL
 ORDER BY
     last_modified DESC
 `
@@ -253,6 +266,8 @@ func (q *Queries) GetInsightProductsByIDs(ctx context.Context, id []uuid.UUID) (
 			&i.Group,
 			&i.TeamkatalogenUrl,
 			&i.TeamID,
+			&i.Deleted,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -269,11 +284,12 @@ func (q *Queries) GetInsightProductsByIDs(ctx context.Context, id []uuid.UUID) (
 
 const getInsightProductsByTeam = `-- name: GetInsightProductsByTeam :many
 SELECT
-    id, name, description, creator, created, last_modified, type, tsv_document, link, keywords, "group", teamkatalogen_url, team_id
+    id, name, description, creator, created, last_modified, type, tsv_document, link, keywords, "group", teamkatalogen_url, team_id, deleted, deleted_by
 FROM
     insight_product
 WHERE
     team_id = $1
+    AND deleted IS NULL
 ORDER BY
     last_modified DESC
 `
@@ -301,6 +317,8 @@ func (q *Queries) GetInsightProductsByTeam(ctx context.Context, teamID uuid.Null
 			&i.Group,
 			&i.TeamkatalogenUrl,
 			&i.TeamID,
+			&i.Deleted,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -322,6 +340,7 @@ FROM
     insight_product
 WHERE
     team_id = $1
+    AND deleted IS NULL
 `
 
 func (q *Queries) GetInsightProductsNumberByTeam(ctx context.Context, teamID uuid.NullUUID) (int64, error) {
@@ -344,7 +363,9 @@ SET
     "teamkatalogen_url" = $7,
     "team_id" = $8
 WHERE
-    id = $9 RETURNING id, name, description, creator, created, last_modified, type, tsv_document, link, keywords, "group", teamkatalogen_url, team_id
+    id = $9
+    AND ($10::timestamptz IS NULL OR last_modified = $10)
+RETURNING id, name, description, creator, created, last_modified, type, tsv_document, link, keywords, "group", teamkatalogen_url, team_id, deleted, deleted_by
 `
 
 type UpdateInsightProductParams struct {
@@ -357,6 +378,7 @@ type UpdateInsightProductParams struct {
 	TeamkatalogenUrl sql.NullString
 	TeamID           uuid.NullUUID
 	ID               uuid.UUID
+	IfMatch          sql.NullTime
 }
 
 func (q *Queries) UpdateInsightProduct(ctx context.Context, arg UpdateInsightProductParams) (InsightProduct, error) {
@@ -370,6 +392,7 @@ func (q *Queries) UpdateInsightProduct(ctx context.Context, arg UpdateInsightPro
 		arg.TeamkatalogenUrl,
 		arg.TeamID,
 		arg.ID,
+		arg.IfMatch,
 	)
 	var i InsightProduct
 	err := row.Scan(
@@ -386,6 +409,8 @@ func (q *Queries) UpdateInsightProduct(ctx context.Context, arg UpdateInsightPro
 		&i.Group,
 		&i.TeamkatalogenUrl,
 		&i.TeamID,
+		&i.Deleted,
+		&i.DeletedBy,
 	)
 	return i, err
 }
@@ -7,6 +7,7 @@ package gensql
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -14,7 +15,7 @@ import (
 
 const getInsightProductWithTeamkatalogen = `-- name: GetInsightProductWithTeamkatalogen :one
 SELECT
-    id, name, description, creator, created, last_modified, type, tsv_document, link, keywords, "group", teamkatalogen_url, team_id, team_name, pa_name
+    id, name, description, creator, created, last_modified, type, tsv_document, link, keywords, "group", teamkatalogen_url, team_id, deleted, deleted_by, team_name, pa_name
 FROM
     insight_product_with_teamkatalogen_view
 WHERE
@@ -40,48 +41,105 @@ func (q *Queries) GetInsightProductWithTeamkatalogen(ctx context.Context, id uui
 		&i.Group,
 		&i.TeamkatalogenUrl,
 		&i.TeamID,
+		&i.Deleted,
+		&i.DeletedBy,
 		&i.TeamName,
 		&i.PaName,
 	)
 	return i, err
 }
 
-const getInsightProductsByGroups = `-- name: GetInsightProductsByGroups :many
-SELECT
-    id, name, description, creator, created, last_modified, type, tsv_document, link, keywords, "group", teamkatalogen_url, team_id, team_name, pa_name
-FROM
-    insight_product_with_teamkatalogen_view ipwtv
-WHERE
-    "group" = ANY($1::text[])
-ORDER BY
-    ipwtv."group", ipwtv.name ASC
+const getInsightProductsByGroupsPage = `-- name: GetInsightProductsByGroupsPage :many
+(
+    SELECT ipwtv.id, ipwtv.name, ipwtv.description, ipwtv.creator, ipwtv.created, ipwtv.last_modified, ipwtv.type, ipwtv.tsv_document, ipwtv.link, ipwtv.keywords, ipwtv."group", ipwtv.teamkatalogen_url, ipwtv.team_id, ipwtv.deleted, ipwtv.deleted_by, ipwtv.team_name, ipwtv.pa_name
+    FROM insight_product_with_teamkatalogen_view ipwtv
+    WHERE $1::text = 'name' AND NOT $2::bool
+    AND ipwtv."group" = ANY($3::text[])
+    AND ($4::uuid IS NULL OR (ipwtv.name, ipwtv.id) > ($5::text, $4::uuid))
+    ORDER BY ipwtv.name ASC, ipwtv.id ASC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT ipwtv.id, ipwtv.name, ipwtv.description, ipwtv.creator, ipwtv.created, ipwtv.last_modified, ipwtv.type, ipwtv.tsv_document, ipwtv.link, ipwtv.keywords, ipwtv."group", ipwtv.teamkatalogen_url, ipwtv.team_id, ipwtv.deleted, ipwtv.deleted_by, ipwtv.team_name, ipwtv.pa_name
+    FROM insight_product_with_teamkatalogen_view ipwtv
+    WHERE $1::text = 'name' AND $2::bool
+    AND ipwtv."group" = ANY($3::text[])
+    AND ($4::uuid IS NULL OR (ipwtv.name, ipwtv.id) < ($5::text, $4::uuid))
+    ORDER BY ipwtv.name DESC, ipwtv.id DESC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT ipwtv.id, ipwtv.name, ipwtv.description, ipwtv.creator, ipwtv.created, ipwtv.last_modified, ipwtv.type, ipwtv.tsv_document, ipwtv.link, ipwtv.keywords, ipwtv."group", ipwtv.teamkatalogen_url, ipwtv.team_id, ipwtv.deleted, ipwtv.deleted_by, ipwtv.team_name, ipwtv.pa_name
+    FROM insight_product_with_teamkatalogen_view ipwtv
+    WHERE $1::text = 'created' AND NOT $2::bool
+    AND ipwtv."group" = ANY($3::text[])
+    AND ($4::uuid IS NULL OR (ipwtv.created, ipwtv.id) > ($7::timestamptz, $4::uuid))
+    ORDER BY ipwtv.created ASC, ipwtv.id ASC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT ipwtv.id, ipwtv.name, ipwtv.description, ipwtv.creator, ipwtv.created, ipwtv.last_modified, ipwtv.type, ipwtv.tsv_document, ipwtv.link, ipwtv.keywords, ipwtv."group", ipwtv.teamkatalogen_url, ipwtv.team_id, ipwtv.deleted, ipwtv.deleted_by, ipwtv.team_name, ipwtv.pa_name
+    FROM insight_product_with_teamkatalogen_view ipwtv
+    WHERE $1::text = 'created' AND $2::bool
+    AND ipwtv."group" = ANY($3::text[])
+    AND ($4::uuid IS NULL OR (ipwtv.created, ipwtv.id) < ($7::timestamptz, $4::uuid))
+    ORDER BY ipwtv.created DESC, ipwtv.id DESC
+    LIMIT $6::int
+)
 `
 
-func (q *Queries) GetInsightProductsByGroups(ctx context.Context, groups []string) ([]InsightProductWithTeamkatalogenView, error) {
-	rows, err := q.db.QueryContext(ctx, getInsightProductsByGroups, pq.Array(groups))
+type GetInsightProductsByGroupsPageParams struct {
+	Sort         string
+	Descending   bool
+	Groups       []string
+	AfterID      uuid.NullUUID
+	AfterName    string
+	Lim          int32
+	AfterCreated time.Time
+}
+
+type GetInsightProductsByGroupsPageRow struct {
+	InsightProductWithTeamkatalogenView InsightProductWithTeamkatalogenView
+}
+
+func (q *Queries) GetInsightProductsByGroupsPage(ctx context.Context, arg GetInsightProductsByGroupsPageParams) ([]GetInsightProductsByGroupsPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, getInsightProductsByGroupsPage,
+		arg.Sort,
+		arg.Descending,
+		pq.Array(arg.Groups),
+		arg.AfterID,
+		arg.AfterName,
+		arg.Lim,
+		arg.AfterCreated,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []InsightProductWithTeamkatalogenView{}
+	items := []GetInsightProductsByGroupsPageRow{}
 	for rows.Next() {
-		var i InsightProductWithTeamkatalogenView
+		var i GetInsightProductsByGroupsPageRow
 		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Description,
-			&i.Creator,
-			&i.Created,
-			&i.LastModified,
-			&i.Type,
-			&i.TsvDocument,
-			&i.Link,
-			pq.Array(&i.Keywords),
-			&i.Group,
-			&i.TeamkatalogenUrl,
-			&i.TeamID,
-			&i.TeamName,
-			&i.PaName,
+			&i.InsightProductWithTeamkatalogenView.ID,
+			&i.InsightProductWithTeamkatalogenView.Name,
+			&i.InsightProductWithTeamkatalogenView.Description,
+			&i.InsightProductWithTeamkatalogenView.Creator,
+			&i.InsightProductWithTeamkatalogenView.Created,
+			&i.InsightProductWithTeamkatalogenView.LastModified,
+			&i.InsightProductWithTeamkatalogenView.Type,
+			&i.InsightProductWithTeamkatalogenView.TsvDocument,
+			&i.InsightProductWithTeamkatalogenView.Link,
+			pq.Array(&i.InsightProductWithTeamkatalogenView.Keywords),
+			&i.InsightProductWithTeamkatalogenView.Group,
+			&i.InsightProductWithTeamkatalogenView.TeamkatalogenUrl,
+			&i.InsightProductWithTeamkatalogenView.TeamID,
+			&i.InsightProductWithTeamkatalogenView.Deleted,
+			&i.InsightProductWithTeamkatalogenView.DeletedBy,
+			&i.InsightProductWithTeamkatalogenView.TeamName,
+			&i.InsightProductWithTeamkatalogenView.PaName,
 		); err != nil {
 			return nil, err
 		}
@@ -98,7 +156,7 @@ func (q *Queries) GetInsightProductsByGroups(ctx context.Context, groups []strin
 
 const getInsightProductsByProductArea = `-- name: GetInsightProductsByProductArea :many
 SELECT
-    id, name, description, creator, created, last_modified, type, tsv_document, link, keywords, "group", teamkatalogen_url, team_id, team_name, pa_name
+    id, name, description, creator, created, last_modified, type, tsv_document, link, keywords, "group", teamkatalogen_url, team_id, deleted, deleted_by, team_name, pa_name
 FROM
     insight_product_with_teamkatalogen_view
 WHERE
@@ -130,6 +188,8 @@ func (q *Queries) GetInsightProductsByProductArea(ctx context.Context, teamID []
 			&i.Group,
 			&i.TeamkatalogenUrl,
 			&i.TeamID,
+			&i.Deleted,
+			&i.DeletedBy,
 			&i.TeamName,
 			&i.PaName,
 		); err != nil {
@@ -145,3 +205,108 @@ func (q *Queries) GetInsightProductsByProductArea(ctx context.Context, teamID []
 	}
 	return items, nil
 }
+
+const getInsightProductsByProductAreaPage = `-- name: GetInsightProductsByProductAreaPage :many
+(
+    SELECT ipwtv.id, ipwtv.name, ipwtv.description, ipwtv.creator, ipwtv.created, ipwtv.last_modified, ipwtv.type, ipwtv.tsv_document, ipwtv.link, ipwtv.keywords, ipwtv."group", ipwtv.teamkatalogen_url, ipwtv.team_id, ipwtv.deleted, ipwtv.deleted_by, ipwtv.team_name, ipwtv.pa_name
+    FROM insight_product_with_teamkatalogen_view ipwtv
+    WHERE $1::text = 'name' AND NOT $2::bool
+    AND ipwtv.team_id = ANY($3::uuid[])
+    AND ($4::uuid IS NULL OR (ipwtv.name, ipwtv.id) > ($5::text, $4::uuid))
+    ORDER BY ipwtv.name ASC, ipwtv.id ASC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT ipwtv.id, ipwtv.name, ipwtv.description, ipwtv.creator, ipwtv.created, ipwtv.last_modified, ipwtv.type, ipwtv.tsv_document, ipwtv.link, ipwtv.keywords, ipwtv."group", ipwtv.teamkatalogen_url, ipwtv.team_id, ipwtv.deleted, ipwtv.deleted_by, ipwtv.team_name, ipwtv.pa_name
+    FROM insight_product_with_teamkatalogen_view ipwtv
+    WHERE $1::text = 'name' AND $2::bool
+    AND ipwtv.team_id = ANY($3::uuid[])
+    AND ($4::uuid IS NULL OR (ipwtv.name, ipwtv.id) < ($5::text, $4::uuid))
+    ORDER BY ipwtv.name DESC, ipwtv.id DESC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT ipwtv.id, ipwtv.name, ipwtv.description, ipwtv.creator, ipwtv.created, ipwtv.last_modified, ipwtv.type, ipwtv.tsv_document, ipwtv.link, ipwtv.keywords, ipwtv."group", ipwtv.teamkatalogen_url, ipwtv.team_id, ipwtv.deleted, ipwtv.deleted_by, ipwtv.team_name, ipwtv.pa_name
+    FROM insight_product_with_teamkatalogen_view ipwtv
+    WHERE $1::text = 'created' AND NOT $2::bool
+    AND ipwtv.team_id = ANY($3::uuid[])
+    AND ($4::uuid IS NULL OR (ipwtv.created, ipwtv.id) > ($7::timestamptz, $4::uuid))
+    ORDER BY ipwtv.created ASC, ipwtv.id ASC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT ipwtv.id, ipwtv.name, ipwtv.description, ipwtv.creator, ipwtv.created, ipwtv.last_modified, ipwtv.type, ipwtv.tsv_document, ipwtv.link, ipwtv.keywords, ipwtv."group", ipwtv.teamkatalogen_url, ipwtv.team_id, ipwtv.deleted, ipwtv.deleted_by, ipwtv.team_name, ipwtv.pa_name
+    FROM insight_product_with_teamkatalogen_view ipwtv
+    WHERE $1::text = 'created' AND $2::bool
+    AND ipwtv.team_id = ANY($3::uuid[])
+    AND ($4::uuid IS NULL OR (ipwtv.created, ipwtv.id) < ($7::timestamptz, $4::uuid))
+    ORDER BY ipwtv.created DESC, ipwtv.id DESC
+    LIMIT $6::int
+)
+`
+
+type GetInsightProductsByProductAreaPageParams struct {
+	Sort         string
+	Descending   bool
+	TeamID       []uuid.UUID
+	AfterID      uuid.NullUUID
+	AfterName    string
+	Lim          int32
+	AfterCreated time.Time
+}
+
+type GetInsightProductsByProductAreaPageRow struct {
+	InsightProductWithTeamkatalogenView InsightProductWithTeamkatalogenView
+}
+
+func (q *Queries) GetInsightProductsByProductAreaPage(ctx context.Context, arg GetInsightProductsByProductAreaPageParams) ([]GetInsightProductsByProductAreaPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, getInsightProductsByProductAreaPage,
+		arg.Sort,
+		arg.Descending,
+		pq.Array(arg.TeamID),
+		arg.AfterID,
+		arg.AfterName,
+		arg.Lim,
+		arg.AfterCreated,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetInsightProductsByProductAreaPageRow{}
+	for rows.Next() {
+		var i GetInsightProductsByProductAreaPageRow
+		if err := rows.Scan(
+			&i.InsightProductWithTeamkatalogenView.ID,
+			&i.InsightProductWithTeamkatalogenView.Name,
+			&i.InsightProductWithTeamkatalogenView.Description,
+			&i.InsightProductWithTeamkatalogenView.Creator,
+			&i.InsightProductWithTeamkatalogenView.Created,
+			&i.InsightProductWithTeamkatalogenView.LastModified,
+			&i.InsightProductWithTeamkatalogenView.Type,
+			&i.InsightProductWithTeamkatalogenView.TsvDocument,
+			&i.InsightProductWithTeamkatalogenView.Link,
+			pq.Array(&i.InsightProductWithTeamkatalogenView.Keywords),
+			&i.InsightProductWithTeamkatalogenView.Group,
+			&i.InsightProductWithTeamkatalogenView.TeamkatalogenUrl,
+			&i.InsightProductWithTeamkatalogenView.TeamID,
+			&i.InsightProductWithTeamkatalogenView.Deleted,
+			&i.InsightProductWithTeamkatalogenView.DeletedBy,
+			&i.InsightProductWithTeamkatalogenView.TeamName,
+			&i.InsightProductWithTeamkatalogenView.PaName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -13,6 +13,23 @@ import (
 	"github.com/google/uuid"
 )
 
+const createJoinableViewShare = `-- name: CreateJoinableViewShare :exec
+INSERT INTO
+    joinable_views_shares ("joinable_view_id", "group")
+VALUES
+    ($1, $2) ON CONFLICT DO NOTHING
+`
+
+type CreateJoinableViewShareParams struct {
+	JoinableViewID uuid.UUID
+	GroupEmail     string
+}
+
+func (q *Queries) CreateJoinableViewShare(ctx context.Context, arg CreateJoinableViewShareParams) error {
+	_, err := q.db.ExecContext(ctx, createJoinableViewShare, arg.JoinableViewID, arg.GroupEmail)
+	return err
+}
+
 const createJoinableViews = `-- name: CreateJoinableViews :one
 INSERT INTO
     joinable_views ("name", "owner", "created", "expires")
@@ -50,7 +67,7 @@ const createJoinableViewsDatasource = `-- name: CreateJoinableViewsDatasource :o
 INSERT INTO
     joinable_views_datasource ("joinable_view_id", "datasource_id")
 VALUES
-    ($1, $2) RETURNING id, joinable_view_id, datasource_id, deleted
+    ($1, $2) RETURNING id, joinable_view_id, datasource_id, deleted, view_deleted
 `
 
 type CreateJoinableViewsDatasourceParams struct {
@@ -66,10 +83,68 @@ func (q *Queries) CreateJoinableViewsDatasource(ctx context.Context, arg CreateJ
 		&i.JoinableViewID,
 		&i.DatasourceID,
 		&i.Deleted,
+		&i.ViewDeleted,
+	)
+	return i, err
+}
+
+const getJoinableView = `-- name: GetJoinableView :one
+SELECT
+    id, owner, name, created, expires, deleted
+FROM
+    joinable_views
+WHERE
+    id = $1
+`
+
+func (q *Queries) GetJoinableView(ctx context.Context, id uuid.UUID) (JoinableView, error) {
+	row := q.db.QueryRowContext(ctx, getJoinableView, id)
+	var i JoinableView
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Name,
+		&i.Created,
+		&i.Expires,
+		&i.Deleted,
 	)
 	return i, err
 }
 
+const getJoinableViewShares = `-- name: GetJoinableViewShares :many
+SELECT
+    "group"
+FROM
+    joinable_views_shares
+WHERE
+    joinable_view_id = $1
+ORDER BY
+    created
+`
+
+func (q *Queries) GetJoinableViewShares(ctx context.Context, joinableViewID uuid.UUID) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getJoinableViewShares, joinableViewID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var group string
+		if err := rows.Scan(&group); err != nil {
+			return nil, err
+		}
+		items = append(items, group)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getJoinableViewWithDataset = `-- name: GetJoinableViewWithDataset :many
 SELECT
     dsrc.project_id as bq_project,
@@ -167,6 +242,7 @@ FROM
     )
 WHERE
     jv.owner = $1
+    AND jv.deleted IS NULL
     AND (
         jv.expires IS NULL
         OR jv.expires > NOW()
@@ -262,10 +338,62 @@ func (q *Queries) GetJoinableViewsForReferenceAndUser(ctx context.Context, arg G
 	return items, nil
 }
 
+const getJoinableViewsToBeCleanedUp = `-- name: GetJoinableViewsToBeCleanedUp :many
+SELECT
+    id, owner, name, created, expires, deleted
+FROM
+    joinable_views jv
+WHERE
+    jv.deleted IS NULL
+    AND (
+        jv.expires < NOW()
+        OR NOT EXISTS (
+            SELECT
+                1
+            FROM
+                joinable_views_datasource jvds
+            WHERE
+                jvds.joinable_view_id = jv.id
+                AND jvds.deleted IS NULL
+        )
+    )
+`
+
+func (q *Queries) GetJoinableViewsToBeCleanedUp(ctx context.Context) ([]JoinableView, error) {
+	rows, err := q.db.QueryContext(ctx, getJoinableViewsToBeCleanedUp)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []JoinableView{}
+	for rows.Next() {
+		var i JoinableView
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Name,
+			&i.Created,
+			&i.Expires,
+			&i.Deleted,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getJoinableViewsToBeDeletedWithRefDatasource = `-- name: GetJoinableViewsToBeDeletedWithRefDatasource :many
 SELECT
     jv.id as joinable_view_id,
     jv.name as joinable_view_name,
+    bq.id as datasource_id,
     bq.project_id as bq_project_id,
     bq.dataset as bq_dataset_id,
     bq.table_name as bq_table_id
@@ -275,11 +403,14 @@ FROM
     JOIN datasource_bigquery bq ON bq.id = jvds.datasource_id
 WHERE
     jvds.deleted IS NOT NULL
+    AND jvds.view_deleted IS NULL
+    AND jv.deleted IS NULL
 `
 
 type GetJoinableViewsToBeDeletedWithRefDatasourceRow struct {
 	JoinableViewID   uuid.UUID
 	JoinableViewName string
+	DatasourceID     uuid.UUID
 	BqProjectID      string
 	BqDatasetID      string
 	BqTableID        string
@@ -297,6 +428,7 @@ func (q *Queries) GetJoinableViewsToBeDeletedWithRefDatasource(ctx context.Conte
 		if err := rows.Scan(
 			&i.JoinableViewID,
 			&i.JoinableViewName,
+			&i.DatasourceID,
 			&i.BqProjectID,
 			&i.BqDatasetID,
 			&i.BqTableID,
@@ -375,6 +507,26 @@ func (q *Queries) GetJoinableViewsWithReference(ctx context.Context) ([]GetJoina
 	return items, nil
 }
 
+const setJoinableViewDatasourceViewDeleted = `-- name: SetJoinableViewDatasourceViewDeleted :exec
+UPDATE
+    joinable_views_datasource
+SET
+    view_deleted = NOW()
+WHERE
+    joinable_view_id = $1
+    AND datasource_id = $2
+`
+
+type SetJoinableViewDatasourceViewDeletedParams struct {
+	JoinableViewID uuid.UUID
+	DatasourceID   uuid.UUID
+}
+
+func (q *Queries) SetJoinableViewDatasourceViewDeleted(ctx context.Context, arg SetJoinableViewDatasourceViewDeletedParams) error {
+	_, err := q.db.ExecContext(ctx, setJoinableViewDatasourceViewDeleted, arg.JoinableViewID, arg.DatasourceID)
+	return err
+}
+
 const setJoinableViewDeleted = `-- name: SetJoinableViewDeleted :exec
 UPDATE
     joinable_views
@@ -388,3 +540,22 @@ func (q *Queries) SetJoinableViewDeleted(ctx context.Context, id uuid.UUID) erro
 	_, err := q.db.ExecContext(ctx, setJoinableViewDeleted, id)
 	return err
 }
+
+const setJoinableViewExpires = `-- name: SetJoinableViewExpires :exec
+UPDATE
+    joinable_views
+SET
+    expires = $1
+WHERE
+    id = $2
+`
+
+type SetJoinableViewExpiresParams struct {
+	Expires sql.NullTime
+	ID      uuid.UUID
+}
+
+func (q *Queries) SetJoinableViewExpires(ctx context.Context, arg SetJoinableViewExpiresParams) error {
+	_, err := q.db.ExecContext(ctx, setJoinableViewExpires, arg.Expires, arg.ID)
+	return err
+}
@@ -116,14 +116,19 @@ func (q *Queries) GetMetabaseMetadataWithDeleted(ctx context.Context, datasetID
 
 const getOpenMetabaseTablesInSameBigQueryDataset = `-- name: GetOpenMetabaseTablesInSameBigQueryDataset :many
 WITH sources_in_same_dataset AS (
-  SELECT dataset_id, project_id, dataset, table_name, schema, last_modified, created, expires, table_type, description, pii_tags, missing_since, id, is_reference, pseudo_columns, deleted FROM datasource_bigquery 
+  SELECT dataset_id, project_id, dataset, table_name, schema, last_modified, created, expires, table_type, description, pii_tags, missing_since, id, is_reference, deleted, pseudo_columns, scope, table_pattern, tables FROM datasource_bigquery 
   WHERE project_id = $1 AND dataset = $2
 )
 
-SELECT table_name FROM sources_in_same_dataset sds
+SELECT sds.table_name::TEXT AS table_name FROM sources_in_same_dataset sds
 JOIN metabase_metadata mbm
 ON mbm.dataset_id = sds.dataset_id
-WHERE mbm.collection_id IS null
+WHERE mbm.collection_id IS null AND sds.scope = 'table'
+UNION
+SELECT (jsonb_array_elements(sds.tables) ->> 'name')::TEXT AS table_name FROM sources_in_same_dataset sds
+JOIN metabase_metadata mbm
+ON mbm.dataset_id = sds.dataset_id
+WHERE mbm.collection_id IS null AND sds.scope = 'dataset' AND sds.tables IS NOT NULL
 `
 
 type GetOpenMetabaseTablesInSameBigQueryDatasetParams struct {
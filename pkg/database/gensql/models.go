@@ -7,6 +7,7 @@ package gensql
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -57,10 +58,55 @@ func (ns NullAccessRequestStatusType) Value() (driver.Value, error) {
 	return string(ns.AccessRequestStatusType), nil
 }
 
+type DataproductTransferStatus string
+
+const (
+	DataproductTransferStatusPending   DataproductTransferStatus = "pending"
+	DataproductTransferStatusAccepted  DataproductTransferStatus = "accepted"
+	DataproductTransferStatusRejected  DataproductTransferStatus = "rejected"
+	DataproductTransferStatusCancelled DataproductTransferStatus = "cancelled"
+)
+
+func (e *DataproductTransferStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DataproductTransferStatus(s)
+	case string:
+		*e = DataproductTransferStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DataproductTransferStatus: %T", src)
+	}
+	return nil
+}
+
+type NullDataproductTransferStatus struct {
+	DataproductTransferStatus DataproductTransferStatus
+	Valid                     bool // Valid is true if DataproductTransferStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDataproductTransferStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.DataproductTransferStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DataproductTransferStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDataproductTransferStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DataproductTransferStatus), nil
+}
+
 type DatasourceType string
 
 const (
 	DatasourceTypeBigquery DatasourceType = "bigquery"
+	DatasourceTypeGcs      DatasourceType = "gcs"
 )
 
 func (e *DatasourceType) Scan(src interface{}) error {
@@ -98,6 +144,93 @@ func (ns NullDatasourceType) Value() (driver.Value, error) {
 	return string(ns.DatasourceType), nil
 }
 
+type LifecycleStatus string
+
+const (
+	LifecycleStatusActive          LifecycleStatus = "active"
+	LifecycleStatusDeprecated      LifecycleStatus = "deprecated"
+	LifecycleStatusSunsetScheduled LifecycleStatus = "sunset_scheduled"
+	LifecycleStatusRetired         LifecycleStatus = "retired"
+)
+
+func (e *LifecycleStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LifecycleStatus(s)
+	case string:
+		*e = LifecycleStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LifecycleStatus: %T", src)
+	}
+	return nil
+}
+
+type NullLifecycleStatus struct {
+	LifecycleStatus LifecycleStatus
+	Valid           bool // Valid is true if LifecycleStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLifecycleStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.LifecycleStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LifecycleStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLifecycleStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LifecycleStatus), nil
+}
+
+type NotificationChannel string
+
+const (
+	NotificationChannelSlack NotificationChannel = "slack"
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelInApp NotificationChannel = "inApp"
+)
+
+func (e *NotificationChannel) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = NotificationChannel(s)
+	case string:
+		*e = NotificationChannel(s)
+	default:
+		return fmt.Errorf("unsupported scan type for NotificationChannel: %T", src)
+	}
+	return nil
+}
+
+type NullNotificationChannel struct {
+	NotificationChannel NotificationChannel
+	Valid               bool // Valid is true if NotificationChannel is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullNotificationChannel) Scan(value interface{}) error {
+	if value == nil {
+		ns.NotificationChannel, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.NotificationChannel.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullNotificationChannel) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.NotificationChannel), nil
+}
+
 type PiiLevel string
 
 const (
@@ -141,64 +274,288 @@ func (ns NullPiiLevel) Value() (driver.Value, error) {
 	return string(ns.PiiLevel), nil
 }
 
+type PollyPurposeStatus string
+
+const (
+	PollyPurposeStatusValid     PollyPurposeStatus = "valid"
+	PollyPurposeStatusExpired   PollyPurposeStatus = "expired"
+	PollyPurposeStatusWithdrawn PollyPurposeStatus = "withdrawn"
+)
+
+func (e *PollyPurposeStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PollyPurposeStatus(s)
+	case string:
+		*e = PollyPurposeStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PollyPurposeStatus: %T", src)
+	}
+	return nil
+}
+
+type NullPollyPurposeStatus struct {
+	PollyPurposeStatus PollyPurposeStatus
+	Valid              bool // Valid is true if PollyPurposeStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPollyPurposeStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.PollyPurposeStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PollyPurposeStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPollyPurposeStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PollyPurposeStatus), nil
+}
+
+type QualityCheckKind string
+
+const (
+	QualityCheckKindSql      QualityCheckKind = "sql"
+	QualityCheckKindNotNull  QualityCheckKind = "not_null"
+	QualityCheckKindUnique   QualityCheckKind = "unique"
+	QualityCheckKindRowCount QualityCheckKind = "row_count"
+)
+
+func (e *QualityCheckKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = QualityCheckKind(s)
+	case string:
+		*e = QualityCheckKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for QualityCheckKind: %T", src)
+	}
+	return nil
+}
+
+type NullQualityCheckKind struct {
+	QualityCheckKind QualityCheckKind
+	Valid            bool // Valid is true if QualityCheckKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullQualityCheckKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.QualityCheckKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.QualityCheckKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullQualityCheckKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.QualityCheckKind), nil
+}
+
+type QualityCheckStatus string
+
+const (
+	QualityCheckStatusPassed QualityCheckStatus = "passed"
+	QualityCheckStatusFailed QualityCheckStatus = "failed"
+	QualityCheckStatusError  QualityCheckStatus = "error"
+)
+
+func (e *QualityCheckStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = QualityCheckStatus(s)
+	case string:
+		*e = QualityCheckStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for QualityCheckStatus: %T", src)
+	}
+	return nil
+}
+
+type NullQualityCheckStatus struct {
+	QualityCheckStatus QualityCheckStatus
+	Valid              bool // Valid is true if QualityCheckStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullQualityCheckStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.QualityCheckStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.QualityCheckStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullQualityCheckStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.QualityCheckStatus), nil
+}
+
+type RecertificationDecision string
+
+const (
+	RecertificationDecisionConfirmed         RecertificationDecision = "confirmed"
+	RecertificationDecisionShortened         RecertificationDecision = "shortened"
+	RecertificationDecisionRevoked           RecertificationDecision = "revoked"
+	RecertificationDecisionRevokedAtDeadline RecertificationDecision = "revoked_at_deadline"
+	RecertificationDecisionUnconfirmed       RecertificationDecision = "unconfirmed"
+)
+
+func (e *RecertificationDecision) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = RecertificationDecision(s)
+	case string:
+		*e = RecertificationDecision(s)
+	default:
+		return fmt.Errorf("unsupported scan type for RecertificationDecision: %T", src)
+	}
+	return nil
+}
+
+type NullRecertificationDecision struct {
+	RecertificationDecision RecertificationDecision
+	Valid                   bool // Valid is true if RecertificationDecision is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullRecertificationDecision) Scan(value interface{}) error {
+	if value == nil {
+		ns.RecertificationDecision, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.RecertificationDecision.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullRecertificationDecision) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.RecertificationDecision), nil
+}
+
+type AccessPolicy struct {
+	ID        uuid.UUID
+	DatasetID uuid.NullUUID
+	Name      string
+	Condition string
+	Reason    string
+	CreatedBy string
+	Created   time.Time
+	Deleted   sql.NullTime
+}
+
 type Dashboard struct {
 	ID  uuid.UUID
 	Url string
 }
 
 type Dataproduct struct {
-	ID               uuid.UUID
-	Name             string
-	Description      sql.NullString
-	Group            string
-	Created          time.Time
-	LastModified     time.Time
-	TsvDocument      interface{}
-	Slug             string
-	TeamkatalogenUrl sql.NullString
-	TeamContact      sql.NullString
-	TeamID           uuid.NullUUID
+	ID                uuid.UUID
+	Name              string
+	Description       sql.NullString
+	Group             string
+	Created           time.Time
+	LastModified      time.Time
+	TsvDocument       interface{}
+	Slug              string
+	TeamkatalogenUrl  sql.NullString
+	TeamContact       sql.NullString
+	TeamID            uuid.NullUUID
+	LifecycleStatus   LifecycleStatus
+	ReplacedBy        uuid.NullUUID
+	Sunset            sql.NullTime
+	DeprecationReason sql.NullString
+	Deleted           sql.NullTime
+	DeletedBy         sql.NullString
+}
+
+type DataproductTransfer struct {
+	ID                 uuid.UUID
+	DataproductID      uuid.UUID
+	FromGroup          string
+	FromTeamID         uuid.NullUUID
+	ToGroup            string
+	ToTeamID           uuid.NullUUID
+	ToTeamkatalogenUrl sql.NullString
+	ToTeamContact      sql.NullString
+	StoryIds           []uuid.UUID
+	Status             DataproductTransferStatus
+	RequestedBy        string
+	Created            time.Time
+	ResolvedBy         sql.NullString
+	Resolved           sql.NullTime
+	Reason             sql.NullString
 }
 
 type DataproductView struct {
-	DpID             uuid.UUID
-	DpName           string
-	DpDescription    sql.NullString
-	DpGroup          string
-	DpCreated        time.Time
-	DpLastModified   time.Time
-	DpSlug           string
-	TeamkatalogenUrl sql.NullString
-	TeamContact      sql.NullString
-	TeamID           uuid.NullUUID
-	TeamName         sql.NullString
-	PaName           sql.NullString
-	PaID             uuid.NullUUID
-	DsDpID           uuid.NullUUID
-	DsID             uuid.NullUUID
-	DsName           sql.NullString
-	DsDescription    sql.NullString
-	DsCreated        sql.NullTime
-	DsLastModified   sql.NullTime
-	DsSlug           sql.NullString
-	DsKeywords       []string
+	DpID                uuid.UUID
+	DpName              string
+	DpDescription       sql.NullString
+	DpGroup             string
+	DpCreated           time.Time
+	DpLastModified      time.Time
+	DpSlug              string
+	TeamkatalogenUrl    sql.NullString
+	TeamContact         sql.NullString
+	TeamID              uuid.NullUUID
+	TeamName            sql.NullString
+	PaName              sql.NullString
+	PaID                uuid.NullUUID
+	DpLifecycleStatus   LifecycleStatus
+	DpReplacedBy        uuid.NullUUID
+	DpSunset            sql.NullTime
+	DpDeprecationReason sql.NullString
+	DsDpID              uuid.NullUUID
+	DsID                uuid.NullUUID
+	DsName              sql.NullString
+	DsDescription       sql.NullString
+	DsCreated           sql.NullTime
+	DsLastModified      sql.NullTime
+	DsSlug              sql.NullString
+	DsKeywords          []string
+	DsLifecycleStatus   NullLifecycleStatus
+	DsReplacedBy        uuid.NullUUID
+	DsSunset            sql.NullTime
+	DsDeprecationReason sql.NullString
 }
 
 type DataproductWithTeamkatalogenView struct {
-	ID               uuid.UUID
-	Name             string
-	Description      sql.NullString
-	Group            string
-	Created          time.Time
-	LastModified     time.Time
-	TsvDocument      interface{}
-	Slug             string
-	TeamkatalogenUrl sql.NullString
-	TeamContact      sql.NullString
-	TeamID           uuid.NullUUID
-	TeamName         sql.NullString
-	PaName           sql.NullString
-	PaID             uuid.NullUUID
+	ID                uuid.UUID
+	Name              string
+	Description       sql.NullString
+	Group             string
+	Created           time.Time
+	LastModified      time.Time
+	TsvDocument       interface{}
+	Slug              string
+	TeamkatalogenUrl  sql.NullString
+	TeamContact       sql.NullString
+	TeamID            uuid.NullUUID
+	LifecycleStatus   LifecycleStatus
+	ReplacedBy        uuid.NullUUID
+	Sunset            sql.NullTime
+	DeprecationReason sql.NullString
+	Deleted           sql.NullTime
+	DeletedBy         sql.NullString
+	TeamName          sql.NullString
+	PaName            sql.NullString
+	PaID              uuid.NullUUID
 }
 
 type Dataset struct {
@@ -216,6 +573,10 @@ type Dataset struct {
 	DataproductID            uuid.UUID
 	AnonymisationDescription sql.NullString
 	TargetUser               sql.NullString
+	LifecycleStatus          LifecycleStatus
+	ReplacedBy               uuid.NullUUID
+	Sunset                   sql.NullTime
+	DeprecationReason        sql.NullString
 }
 
 type DatasetAccess struct {
@@ -230,6 +591,26 @@ type DatasetAccess struct {
 	Owner           string
 }
 
+type DatasetAccessApprovalRule struct {
+	ID            uuid.UUID
+	DatasetID     uuid.UUID
+	SubjectType   sql.NullString
+	SubjectGroup  sql.NullString
+	Pii           NullPiiLevel
+	MaxExpiryDays sql.NullInt32
+	RequirePolly  bool
+	CreatedBy     string
+	Created       time.Time
+	Deleted       sql.NullTime
+}
+
+type DatasetAccessExpiryReminder struct {
+	AccessID   uuid.UUID
+	Expires    time.Time
+	DaysBefore int32
+	Sent       time.Time
+}
+
 type DatasetAccessRequest struct {
 	ID                   uuid.UUID
 	DatasetID            uuid.UUID
@@ -243,43 +624,86 @@ type DatasetAccessRequest struct {
 	Closed               sql.NullTime
 	Granter              sql.NullString
 	Reason               sql.NullString
+	ApprovalRuleID       uuid.NullUUID
+	RenewsAccessID       uuid.NullUUID
+}
+
+type DatasetColumnDescription struct {
+	DatasetID   uuid.UUID
+	ColumnName  string
+	Description string
+	Source      string
+	UpdatedBy   string
+	Updated     time.Time
+}
+
+type DatasetPreviewSetting struct {
+	DatasetID uuid.UUID
+	Enabled   bool
+	UpdatedBy string
+	Updated   time.Time
+}
+
+type DatasetProfile struct {
+	ID             uuid.UUID
+	DatasetID      uuid.UUID
+	ProjectID      string
+	Dataset        string
+	TableName      string
+	RowCount       int64
+	BytesProcessed int64
+	Columns        json.RawMessage
+	Created        time.Time
+}
+
+type DatasetQualityStatus struct {
+	DatasetID uuid.UUID
+	Status    QualityCheckStatus
+	LastRun   time.Time
 }
 
 type DatasetView struct {
-	DsID            uuid.UUID
-	DsName          string
-	DsDescription   sql.NullString
-	DsCreated       time.Time
-	DsLastModified  time.Time
-	DsSlug          string
-	Pii             PiiLevel
-	DsKeywords      []string
-	DsRepo          sql.NullString
-	BqID            uuid.UUID
-	BqCreated       time.Time
-	BqLastModified  time.Time
-	BqExpires       sql.NullTime
-	BqDescription   sql.NullString
-	BqMissingSince  sql.NullTime
-	PiiTags         pqtype.NullRawMessage
-	BqProject       string
-	BqDataset       string
-	BqTableName     string
-	BqTableType     string
-	PseudoColumns   []string
-	BqSchema        pqtype.NullRawMessage
-	DsDpID          uuid.UUID
-	MappingServices []string
-	AccessID        uuid.NullUUID
-	AccessSubject   sql.NullString
-	AccessOwner     sql.NullString
-	AccessGranter   sql.NullString
-	AccessExpires   sql.NullTime
-	AccessCreated   sql.NullTime
-	AccessRevoked   sql.NullTime
-	AccessRequestID uuid.NullUUID
-	MbDatabaseID    sql.NullInt32
-	MbDeletedAt     sql.NullTime
+	DsID                uuid.UUID
+	DsName              string
+	DsDescription       sql.NullString
+	DsCreated           time.Time
+	DsLastModified      time.Time
+	DsSlug              string
+	Pii                 PiiLevel
+	DsKeywords          []string
+	DsRepo              sql.NullString
+	DsLifecycleStatus   LifecycleStatus
+	DsReplacedBy        uuid.NullUUID
+	DsSunset            sql.NullTime
+	DsDeprecationReason sql.NullString
+	BqID                uuid.UUID
+	BqCreated           time.Time
+	BqLastModified      time.Time
+	BqExpires           sql.NullTime
+	BqDescription       sql.NullString
+	BqMissingSince      sql.NullTime
+	PiiTags             pqtype.NullRawMessage
+	BqProject           string
+	BqDataset           string
+	BqTableName         string
+	BqTableType         string
+	PseudoColumns       json.RawMessage
+	BqSchema            pqtype.NullRawMessage
+	DsDpID              uuid.UUID
+	MappingServices     []string
+	AccessID            uuid.NullUUID
+	AccessSubject       sql.NullString
+	AccessOwner         sql.NullString
+	AccessGranter       sql.NullString
+	AccessExpires       sql.NullTime
+	AccessCreated       sql.NullTime
+	AccessRevoked       sql.NullTime
+	AccessRequestID     uuid.NullUUID
+	MbDatabaseID        sql.NullInt32
+	MbDeletedAt         sql.NullTime
+	BqScope             string
+	BqTablePattern      string
+	BqTables            pqtype.NullRawMessage
 }
 
 type DatasourceBigquery struct {
@@ -297,8 +721,24 @@ type DatasourceBigquery struct {
 	MissingSince  sql.NullTime
 	ID            uuid.UUID
 	IsReference   bool
-	PseudoColumns []string
 	Deleted       sql.NullTime
+	PseudoColumns json.RawMessage
+	Scope         string
+	TablePattern  string
+	Tables        pqtype.NullRawMessage
+}
+
+type DatasourceGc struct {
+	ID           uuid.UUID
+	DatasetID    uuid.UUID
+	ProjectID    string
+	Bucket       string
+	Prefix       string
+	Description  string
+	Objects      pqtype.NullRawMessage
+	Created      time.Time
+	LastModified time.Time
+	MissingSince sql.NullTime
 }
 
 type HttpCache struct {
@@ -323,6 +763,8 @@ type InsightProduct struct {
 	Group            string
 	TeamkatalogenUrl sql.NullString
 	TeamID           uuid.NullUUID
+	Deleted          sql.NullTime
+	DeletedBy        sql.NullString
 }
 
 type InsightProductWithTeamkatalogenView struct {
@@ -339,6 +781,8 @@ type InsightProductWithTeamkatalogenView struct {
 	Group            string
 	TeamkatalogenUrl sql.NullString
 	TeamID           uuid.NullUUID
+	Deleted          sql.NullTime
+	DeletedBy        sql.NullString
 	TeamName         sql.NullString
 	PaName           sql.NullString
 }
@@ -357,6 +801,13 @@ type JoinableViewsDatasource struct {
 	JoinableViewID uuid.UUID
 	DatasourceID   uuid.UUID
 	Deleted        sql.NullTime
+	ViewDeleted    sql.NullTime
+}
+
+type JoinableViewsShare struct {
+	JoinableViewID uuid.UUID
+	Group          string
+	Created        time.Time
 }
 
 type MetabaseMetadatum struct {
@@ -374,6 +825,46 @@ type NadaToken struct {
 	Token uuid.UUID
 }
 
+type Notification struct {
+	ID            uuid.UUID
+	Recipient     string
+	EventType     string
+	Title         string
+	Message       string
+	Link          sql.NullString
+	ReferenceID   uuid.NullUUID
+	Created       time.Time
+	DigestChannel NullNotificationChannel
+	DigestSent    sql.NullTime
+}
+
+type NotificationDelivery struct {
+	ID          uuid.UUID
+	Kind        string
+	Recipient   string
+	Title       string
+	Text        string
+	ReferenceID uuid.NullUUID
+	Created     time.Time
+	Attempts    int32
+	LastAttempt sql.NullTime
+	LastError   sql.NullString
+	Delivered   sql.NullTime
+}
+
+type NotificationPreference struct {
+	Email     string
+	EventType string
+	Channel   NotificationChannel
+	Digest    bool
+}
+
+type NotificationRead struct {
+	NotificationID uuid.UUID
+	Email          string
+	ReadAt         time.Time
+}
+
 type PollyDocumentation struct {
 	ID         uuid.UUID
 	ExternalID string
@@ -381,17 +872,100 @@ type PollyDocumentation struct {
 	Url        string
 }
 
+type PollyPurpose struct {
+	ExternalID  string
+	Name        string
+	Status      PollyPurposeStatus
+	ValidFrom   sql.NullTime
+	ValidTo     sql.NullTime
+	LastChecked time.Time
+}
+
+type PollyPurposeHistory struct {
+	ID         uuid.UUID
+	ExternalID string
+	Name       string
+	Status     PollyPurposeStatus
+	ValidFrom  sql.NullTime
+	ValidTo    sql.NullTime
+	Recorded   time.Time
+}
+
+type QualityCheck struct {
+	ID          uuid.UUID
+	DatasetID   uuid.UUID
+	Name        string
+	Description sql.NullString
+	Kind        QualityCheckKind
+	ColumnName  sql.NullString
+	Condition   sql.NullString
+	SqlQuery    sql.NullString
+	Threshold   sql.NullInt64
+	CreatedBy   string
+	Created     time.Time
+}
+
+type QualityCheckLatestRun struct {
+	ID             uuid.UUID
+	CheckID        uuid.UUID
+	Status         QualityCheckStatus
+	Measured       sql.NullInt64
+	Error          sql.NullString
+	BytesProcessed int64
+	Started        time.Time
+}
+
+type QualityCheckRun struct {
+	ID             uuid.UUID
+	CheckID        uuid.UUID
+	Status         QualityCheckStatus
+	Measured       sql.NullInt64
+	Error          sql.NullString
+	BytesProcessed int64
+	Started        time.Time
+}
+
+type RecertificationCampaign struct {
+	ID                uuid.UUID
+	Name              string
+	Deadline          time.Time
+	RevokeUnconfirmed bool
+	CreatedBy         string
+	Created           time.Time
+	LastReminded      sql.NullTime
+	Completed         sql.NullTime
+}
+
+type RecertificationReview struct {
+	ID         uuid.UUID
+	CampaignID uuid.UUID
+	DatasetID  uuid.UUID
+	AccessID   uuid.UUID
+	Subject    string
+	Owner      string
+	Granter    string
+	Expires    sql.NullTime
+	Decision   NullRecertificationDecision
+	NewExpires sql.NullTime
+	ReviewedBy sql.NullString
+	Reviewed   sql.NullTime
+}
+
 type Search struct {
-	ElementID    uuid.UUID
-	ElementType  string
-	Description  string
-	Keywords     interface{}
-	Group        string
-	TeamID       uuid.NullUUID
-	Created      time.Time
-	LastModified time.Time
-	TsvDocument  interface{}
-	Services     string
+	ElementID     uuid.UUID
+	ElementType   string
+	Name          string
+	Description   string
+	Keywords      interface{}
+	Group         string
+	TeamID        uuid.NullUUID
+	Created       time.Time
+	LastModified  time.Time
+	TsvDocument   interface{}
+	Services      string
+	Pii           sql.NullString
+	ProductAreaID uuid.NullUUID
+	QualityStatus sql.NullString
 }
 
 type Session struct {
@@ -414,6 +988,8 @@ type Story struct {
 	TeamkatalogenUrl sql.NullString
 	TeamID           uuid.NullUUID
 	Group            string
+	Deleted          sql.NullTime
+	DeletedBy        sql.NullString
 }
 
 type StoryWithTeamkatalogenView struct {
@@ -427,6 +1003,8 @@ type StoryWithTeamkatalogenView struct {
 	TeamkatalogenUrl sql.NullString
 	TeamID           uuid.NullUUID
 	Group            string
+	Deleted          sql.NullTime
+	DeletedBy        sql.NullString
 	TeamName         sql.NullString
 	PaName           sql.NullString
 }
@@ -7,8 +7,10 @@ package gensql
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const createPollyDocumentation = `-- name: CreatePollyDocumentation :one
@@ -39,20 +41,370 @@ func (q *Queries) CreatePollyDocumentation(ctx context.Context, arg CreatePollyD
 	return i, err
 }
 
+const createPollyPurposeHistory = `-- name: CreatePollyPurposeHistory :exec
+INSERT INTO polly_purpose_history ("external_id",
+                                   "name",
+                                   "status",
+                                   "valid_from",
+                                   "valid_to")
+VALUES ($1,
+        $2,
+        $3,
+        $4,
+        $5)
+`
+
+type CreatePollyPurposeHistoryParams struct {
+	ExternalID string
+	Name       string
+	Status     PollyPurposeStatus
+	ValidFrom  sql.NullTime
+	ValidTo    sql.NullTime
+}
+
+func (q *Queries) CreatePollyPurposeHistory(ctx context.Context, arg CreatePollyPurposeHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, createPollyPurposeHistory,
+		arg.ExternalID,
+		arg.Name,
+		arg.Status,
+		arg.ValidFrom,
+		arg.ValidTo,
+	)
+	return err
+}
+
 const getPollyDocumentation = `-- name: GetPollyDocumentation :one
-SELECT id, external_id, name, url
-FROM polly_documentation
-WHERE id = $1
+SELECT pd.id, pd.external_id, pd.name, pd.url, pp.status, pp.valid_to
+FROM polly_documentation pd
+LEFT JOIN polly_purposes pp ON pp.external_id = pd.external_id
+WHERE pd.id = $1
 `
 
-func (q *Queries) GetPollyDocumentation(ctx context.Context, id uuid.UUID) (PollyDocumentation, error) {
+type GetPollyDocumentationRow struct {
+	ID         uuid.UUID
+	ExternalID string
+	Name       string
+	Url        string
+	Status     NullPollyPurposeStatus
+	ValidTo    sql.NullTime
+}
+
+func (q *Queries) GetPollyDocumentation(ctx context.Context, id uuid.UUID) (GetPollyDocumentationRow, error) {
 	row := q.db.QueryRowContext(ctx, getPollyDocumentation, id)
-	var i PollyDocumentation
+	var i GetPollyDocumentationRow
 	err := row.Scan(
 		&i.ID,
 		&i.ExternalID,
 		&i.Name,
 		&i.Url,
+		&i.Status,
+		&i.ValidTo,
 	)
 	return i, err
 }
+
+const getPollyPurpose = `-- name: GetPollyPurpose :one
+SELECT external_id, name, status, valid_from, valid_to, last_checked
+FROM polly_purposes
+WHERE external_id = $1
+`
+
+func (q *Queries) GetPollyPurpose(ctx context.Context, externalID string) (PollyPurpose, error) {
+	row := q.db.QueryRowContext(ctx, getPollyPurpose, externalID)
+	var i PollyPurpose
+	err := row.Scan(
+		&i.ExternalID,
+		&i.Name,
+		&i.Status,
+		&i.ValidFrom,
+		&i.ValidTo,
+		&i.LastChecked,
+	)
+	return i, err
+}
+
+const getPollyPurposeHistory = `-- name: GetPollyPurposeHistory :many
+SELECT id, external_id, name, status, valid_from, valid_to, recorded
+FROM polly_purpose_history
+WHERE external_id = $1
+ORDER BY recorded DESC
+`
+
+func (q *Queries) GetPollyPurposeHistory(ctx context.Context, externalID string) ([]PollyPurposeHistory, error) {
+	rows, err := q.db.QueryContext(ctx, getPollyPurposeHistory, externalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PollyPurposeHistory{}
+	for rows.Next() {
+		var i PollyPurposeHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.ExternalID,
+			&i.Name,
+			&i.Status,
+			&i.ValidFrom,
+			&i.ValidTo,
+			&i.Recorded,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReferencedPollyPurposes = `-- name: GetReferencedPollyPurposes :many
+SELECT DISTINCT ON (pd.external_id) pd.external_id, pd.name, pd.url
+FROM polly_documentation pd
+JOIN dataset_access_requests dar ON dar.polly_documentation_id = pd.id
+LEFT JOIN dataset_access da ON da.access_request_id = dar.id
+WHERE dar.status = 'pending'
+OR (da.id IS NOT NULL AND da.revoked IS NULL AND (da.expires IS NULL OR da.expires > NOW()))
+ORDER BY pd.external_id, dar.created DESC
+`
+
+type GetReferencedPollyPurposesRow struct {
+	ExternalID string
+	Name       string
+	Url        string
+}
+
+func (q *Queries) GetReferencedPollyPurposes(ctx context.Context) ([]GetReferencedPollyPurposesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getReferencedPollyPurposes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetReferencedPollyPurposesRow{}
+	for rows.Next() {
+		var i GetReferencedPollyPurposesRow
+		if err := rows.Scan(&i.ExternalID, &i.Name, &i.Url); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccessWithInvalidPollyPurposeForGroups = `-- name: ListAccessWithInvalidPollyPurposeForGroups :many
+SELECT
+    da.id AS access_id,
+    da.subject,
+    da.owner,
+    da.expires,
+    ds.id AS dataset_id,
+    ds.name AS dataset_name,
+    dp.id AS dataproduct_id,
+    dp.name AS dataproduct_name,
+    dp."group" AS owner_group,
+    dp.team_contact,
+    pd.external_id,
+    pd.name AS purpose_name,
+    pd.url AS purpose_url,
+    pp.status AS purpose_status
+FROM dataset_access da
+JOIN dataset_access_requests dar ON da.access_request_id = dar.id
+JOIN polly_documentation pd ON dar.polly_documentation_id = pd.id
+JOIN polly_purposes pp ON pp.external_id = pd.external_id
+JOIN datasets ds ON da.dataset_id = ds.id
+JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+WHERE dp."group" = ANY ($1::text[])
+AND pp.status != 'valid'
+AND dp.deleted IS NULL
+AND da.revoked IS NULL
+AND (da.expires IS NULL OR da.expires > NOW())
+ORDER BY ds.name, da.subject
+`
+
+type ListAccessWithInvalidPollyPurposeForGroupsRow struct {
+	AccessID        uuid.UUID
+	Subject         string
+	Owner           string
+	Expires         sql.NullTime
+	DatasetID       uuid.UUID
+	DatasetName     string
+	DataproductID   uuid.UUID
+	DataproductName string
+	OwnerGroup      string
+	TeamContact     sql.NullString
+	ExternalID      string
+	PurposeName     string
+	PurposeUrl      string
+	PurposeStatus   PollyPurposeStatus
+}
+
+func (q *Queries) ListAccessWithInvalidPollyPurposeForGroups(ctx context.Context, groups []string) ([]ListAccessWithInvalidPollyPurposeForGroupsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAccessWithInvalidPollyPurposeForGroups, pq.Array(groups))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAccessWithInvalidPollyPurposeForGroupsRow{}
+	for rows.Next() {
+		var i ListAccessWithInvalidPollyPurposeForGroupsRow
+		if err := rows.Scan(
+			&i.AccessID,
+			&i.Subject,
+			&i.Owner,
+			&i.Expires,
+			&i.DatasetID,
+			&i.DatasetName,
+			&i.DataproductID,
+			&i.DataproductName,
+			&i.OwnerGroup,
+			&i.TeamContact,
+			&i.ExternalID,
+			&i.PurposeName,
+			&i.PurposeUrl,
+			&i.PurposeStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listActiveAccessForPollyPurpose = `-- name: ListActiveAccessForPollyPurpose :many
+SELECT
+    da.id AS access_id,
+    da.subject,
+    da.owner,
+    da.expires,
+    ds.id AS dataset_id,
+    ds.name AS dataset_name,
+    dp.id AS dataproduct_id,
+    dp.name AS dataproduct_name,
+    dp."group" AS owner_group,
+    dp.team_contact,
+    pd.external_id,
+    pd.name AS purpose_name,
+    pd.url AS purpose_url,
+    pp.status AS purpose_status
+FROM dataset_access da
+JOIN dataset_access_requests dar ON da.access_request_id = dar.id
+JOIN polly_documentation pd ON dar.polly_documentation_id = pd.id
+JOIN polly_purposes pp ON pp.external_id = pd.external_id
+JOIN datasets ds ON da.dataset_id = ds.id
+JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+WHERE pd.external_id = $1
+AND dp.deleted IS NULL
+AND da.revoked IS NULL
+AND (da.expires IS NULL OR da.expires > NOW())
+ORDER BY dp."group", ds.name, da.subject
+`
+
+type ListActiveAccessForPollyPurposeRow struct {
+	AccessID        uuid.UUID
+	Subject         string
+	Owner           string
+	Expires         sql.NullTime
+	DatasetID       uuid.UUID
+	DatasetName     string
+	DataproductID   uuid.UUID
+	DataproductName string
+	OwnerGroup      string
+	TeamContact     sql.NullString
+	ExternalID      string
+	PurposeName     string
+	PurposeUrl      string
+	PurposeStatus   PollyPurposeStatus
+}
+
+func (q *Queries) ListActiveAccessForPollyPurpose(ctx context.Context, externalID string) ([]ListActiveAccessForPollyPurposeRow, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveAccessForPollyPurpose, externalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListActiveAccessForPollyPurposeRow{}
+	for rows.Next() {
+		var i ListActiveAccessForPollyPurposeRow
+		if err := rows.Scan(
+			&i.AccessID,
+			&i.Subject,
+			&i.Owner,
+			&i.Expires,
+			&i.DatasetID,
+			&i.DatasetName,
+			&i.DataproductID,
+			&i.DataproductName,
+			&i.OwnerGroup,
+			&i.TeamContact,
+			&i.ExternalID,
+			&i.PurposeName,
+			&i.PurposeUrl,
+			&i.PurposeStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertPollyPurpose = `-- name: UpsertPollyPurpose :exec
+INSERT INTO polly_purposes ("external_id",
+                            "name",
+                            "status",
+                            "valid_from",
+                            "valid_to",
+                            "last_checked")
+VALUES ($1,
+        $2,
+        $3,
+        $4,
+        $5,
+        NOW())
+ON CONFLICT (external_id) DO UPDATE
+SET name         = EXCLUDED.name,
+    status       = EXCLUDED.status,
+    valid_from   = EXCLUDED.valid_from,
+    valid_to     = EXCLUDED.valid_to,
+    last_checked = EXCLUDED.last_checked
+`
+
+type UpsertPollyPurposeParams struct {
+	ExternalID string
+	Name       string
+	Status     PollyPurposeStatus
+	ValidFrom  sql.NullTime
+	ValidTo    sql.NullTime
+}
+
+func (q *Queries) UpsertPollyPurpose(ctx context.Context, arg UpsertPollyPurposeParams) error {
+	_, err := q.db.ExecContext(ctx, upsertPollyPurpose,
+		arg.ExternalID,
+		arg.Name,
+		arg.Status,
+		arg.ValidFrom,
+		arg.ValidTo,
+	)
+	return err
+}
@@ -6,6 +6,8 @@ package gensql
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -14,15 +16,30 @@ type Querier interface {
 	AddTeamProject(ctx context.Context, arg AddTeamProjectParams) (TeamProject, error)
 	ApproveAccessRequest(ctx context.Context, arg ApproveAccessRequestParams) error
 	ClearTeamProjectsCache(ctx context.Context) error
+	CompleteRecertificationCampaign(ctx context.Context, id uuid.UUID) error
+	CreateAccessApprovalRule(ctx context.Context, arg CreateAccessApprovalRuleParams) (DatasetAccessApprovalRule, error)
+	CreateAccessPolicy(ctx context.Context, arg CreateAccessPolicyParams) (AccessPolicy, error)
 	CreateAccessRequestForDataset(ctx context.Context, arg CreateAccessRequestForDatasetParams) (DatasetAccessRequest, error)
 	CreateBigqueryDatasource(ctx context.Context, arg CreateBigqueryDatasourceParams) (DatasourceBigquery, error)
 	CreateDataproduct(ctx context.Context, arg CreateDataproductParams) (Dataproduct, error)
+	CreateDataproductTransfer(ctx context.Context, arg CreateDataproductTransferParams) (DataproductTransfer, error)
 	CreateDataset(ctx context.Context, arg CreateDatasetParams) (Dataset, error)
+	CreateDatasetProfile(ctx context.Context, arg CreateDatasetProfileParams) error
+	CreateGCSDatasource(ctx context.Context, arg CreateGCSDatasourceParams) (DatasourceGc, error)
 	CreateInsightProduct(ctx context.Context, arg CreateInsightProductParams) (InsightProduct, error)
+	CreateJoinableViewShare(ctx context.Context, arg CreateJoinableViewShareParams) error
 	CreateJoinableViews(ctx context.Context, arg CreateJoinableViewsParams) (JoinableView, error)
 	CreateJoinableViewsDatasource(ctx context.Context, arg CreateJoinableViewsDatasourceParams) (JoinableViewsDatasource, error)
 	CreateMetabaseMetadata(ctx context.Context, datasetID uuid.UUID) error
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
+	CreateNotificationDelivery(ctx context.Context, arg CreateNotificationDeliveryParams) error
 	CreatePollyDocumentation(ctx context.Context, arg CreatePollyDocumentationParams) (PollyDocumentation, error)
+	CreatePollyPurposeHistory(ctx context.Context, arg CreatePollyPurposeHistoryParams) error
+	CreateQualityCheck(ctx context.Context, arg CreateQualityCheckParams) (QualityCheck, error)
+	CreateQualityCheckRun(ctx context.Context, arg CreateQualityCheckRunParams) (QualityCheckRun, error)
+	CreateRecertificationCampaign(ctx context.Context, arg CreateRecertificationCampaignParams) (RecertificationCampaign, error)
+	CreateRecertificationReview(ctx context.Context, arg CreateRecertificationReviewParams) error
+	CreateRenewalAccessRequest(ctx context.Context, arg CreateRenewalAccessRequestParams) (DatasetAccessRequest, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) error
 	CreateStory(ctx context.Context, arg CreateStoryParams) (Story, error)
 	CreateStoryWithID(ctx context.Context, arg CreateStoryWithIDParams) (Story, error)
@@ -30,42 +47,60 @@ type Querier interface {
 	DataproductGroupStats(ctx context.Context, arg DataproductGroupStatsParams) ([]DataproductGroupStatsRow, error)
 	DataproductKeywords(ctx context.Context, keyword string) ([]DataproductKeywordsRow, error)
 	DatasetsByMetabase(ctx context.Context, arg DatasetsByMetabaseParams) ([]Dataset, error)
+	DeleteAccessApprovalRule(ctx context.Context, id uuid.UUID) error
+	DeleteAccessPolicy(ctx context.Context, id uuid.UUID) error
 	DeleteAccessRequest(ctx context.Context, id uuid.UUID) error
 	DeleteDataproduct(ctx context.Context, id uuid.UUID) error
 	DeleteDataset(ctx context.Context, id uuid.UUID) error
 	DeleteInsightProduct(ctx context.Context, id uuid.UUID) error
 	DeleteMetabaseMetadata(ctx context.Context, datasetID uuid.UUID) error
 	DeleteNadaToken(ctx context.Context, team string) error
+	DeleteQualityCheck(ctx context.Context, id uuid.UUID) error
 	DeleteSession(ctx context.Context, token string) error
 	DeleteStory(ctx context.Context, id uuid.UUID) error
 	DenyAccessRequest(ctx context.Context, arg DenyAccessRequestParams) error
+	GetAccessApprovalRule(ctx context.Context, id uuid.UUID) (DatasetAccessApprovalRule, error)
+	GetAccessApprovalRulesForDataset(ctx context.Context, datasetID uuid.UUID) ([]DatasetAccessApprovalRule, error)
+	GetAccessPoliciesForDataset(ctx context.Context, datasetID uuid.NullUUID) ([]AccessPolicy, error)
+	GetAccessPolicy(ctx context.Context, id uuid.UUID) (AccessPolicy, error)
 	GetAccessRequest(ctx context.Context, id uuid.UUID) (DatasetAccessRequest, error)
 	GetAccessToDataset(ctx context.Context, id uuid.UUID) (DatasetAccess, error)
-	GetAccessibleDatasets(ctx context.Context, arg GetAccessibleDatasetsParams) ([]GetAccessibleDatasetsRow, error)
-	GetAccessibleDatasetsByOwnedServiceAccounts(ctx context.Context, arg GetAccessibleDatasetsByOwnedServiceAccountsParams) ([]GetAccessibleDatasetsByOwnedServiceAccountsRow, error)
 	GetAccessiblePseudoDatasetsByUser(ctx context.Context, arg GetAccessiblePseudoDatasetsByUserParams) ([]GetAccessiblePseudoDatasetsByUserRow, error)
 	GetActiveAccessToDatasetForSubject(ctx context.Context, arg GetActiveAccessToDatasetForSubjectParams) (DatasetAccess, error)
+	GetActiveDatasetAccessForDataproductSubject(ctx context.Context, arg GetActiveDatasetAccessForDataproductSubjectParams) ([]DatasetAccess, error)
 	GetAddMetabaseDatasetMappings(ctx context.Context) ([]uuid.UUID, error)
 	GetAllDatasetsMinimal(ctx context.Context) ([]GetAllDatasetsMinimalRow, error)
 	GetAllMetabaseMetadata(ctx context.Context) ([]MetabaseMetadatum, error)
 	GetAllTeams(ctx context.Context) ([]TkTeam, error)
 	GetBigqueryDatasource(ctx context.Context, arg GetBigqueryDatasourceParams) (DatasourceBigquery, error)
 	GetBigqueryDatasources(ctx context.Context) ([]DatasourceBigquery, error)
+	GetCatalogueExportRows(ctx context.Context, modifiedSince sql.NullTime) ([]GetCatalogueExportRowsRow, error)
 	GetDashboard(ctx context.Context, id uuid.UUID) (Dashboard, error)
 	GetDataproduct(ctx context.Context, id uuid.UUID) (Dataproduct, error)
 	GetDataproductKeywords(ctx context.Context, dpid uuid.UUID) ([]string, error)
+	GetDataproductTransfer(ctx context.Context, id uuid.UUID) (DataproductTransfer, error)
+	GetDataproductTransfersForDataproduct(ctx context.Context, dataproductID uuid.UUID) ([]DataproductTransfer, error)
+	GetDataproductTransfersForGroups(ctx context.Context, groups []string) ([]DataproductTransfer, error)
 	GetDataproductWithDatasetsBasic(ctx context.Context, id uuid.UUID) ([]GetDataproductWithDatasetsBasicRow, error)
 	GetDataproducts(ctx context.Context, arg GetDataproductsParams) ([]Dataproduct, error)
 	GetDataproductsByGroups(ctx context.Context, groups []string) ([]Dataproduct, error)
+	GetDataproductsByGroupsPage(ctx context.Context, arg GetDataproductsByGroupsPageParams) ([]GetDataproductsByGroupsPageRow, error)
 	GetDataproductsByIDs(ctx context.Context, ids []uuid.UUID) ([]Dataproduct, error)
 	GetDataproductsByProductArea(ctx context.Context, teamID []uuid.UUID) ([]DataproductWithTeamkatalogenView, error)
+	GetDataproductsByProductAreaPage(ctx context.Context, arg GetDataproductsByProductAreaPageParams) ([]GetDataproductsByProductAreaPageRow, error)
 	GetDataproductsByTeam(ctx context.Context, teamID uuid.NullUUID) ([]Dataproduct, error)
 	GetDataproductsNumberByTeam(ctx context.Context, teamID uuid.NullUUID) (int64, error)
+	GetDataproductsToRetire(ctx context.Context) ([]uuid.UUID, error)
 	GetDataproductsWithDatasets(ctx context.Context, arg GetDataproductsWithDatasetsParams) ([]GetDataproductsWithDatasetsRow, error)
 	GetDataproductsWithDatasetsAndAccessRequests(ctx context.Context, arg GetDataproductsWithDatasetsAndAccessRequestsParams) ([]GetDataproductsWithDatasetsAndAccessRequestsRow, error)
 	GetDataset(ctx context.Context, id uuid.UUID) (Dataset, error)
+	GetDatasetColumnDescriptions(ctx context.Context, datasetID uuid.UUID) ([]DatasetColumnDescription, error)
 	GetDatasetComplete(ctx context.Context, id uuid.UUID) ([]DatasetView, error)
 	GetDatasetMappings(ctx context.Context, datasetID uuid.UUID) (ThirdPartyMapping, error)
+	GetDatasetPreviewSettings(ctx context.Context, datasetID uuid.UUID) (DatasetPreviewSetting, error)
+	GetDatasetProfiles(ctx context.Context, arg GetDatasetProfilesParams) ([]DatasetProfile, error)
+	GetDatasetQualityStatus(ctx context.Context, datasetID uuid.UUID) (DatasetQualityStatus, error)
+	GetDatasetType(ctx context.Context, id uuid.UUID) (DatasourceType, error)
 	GetDatasets(ctx context.Context, arg GetDatasetsParams) ([]Dataset, error)
 	GetDatasetsByGroups(ctx context.Context, groups []string) ([]Dataset, error)
 	GetDatasetsByIDs(ctx context.Context, ids []uuid.UUID) ([]Dataset, error)
@@ -73,41 +108,79 @@ type Querier interface {
 	GetDatasetsByUserAccess(ctx context.Context, id string) ([]Dataset, error)
 	GetDatasetsForOwner(ctx context.Context, groups []string) ([]Dataset, error)
 	GetDatasetsInDataproduct(ctx context.Context, dataproductID uuid.UUID) ([]Dataset, error)
+	GetDatasetsMinimalPage(ctx context.Context, arg GetDatasetsMinimalPageParams) ([]GetDatasetsMinimalPageRow, error)
+	GetDatasetsToRetire(ctx context.Context) ([]uuid.UUID, error)
+	GetDatasourcesToProfile(ctx context.Context) ([]GetDatasourcesToProfileRow, error)
+	GetDbtImportCandidates(ctx context.Context, projectIds []string) ([]GetDbtImportCandidatesRow, error)
+	GetDeletedItems(ctx context.Context, arg GetDeletedItemsParams) ([]GetDeletedItemsRow, error)
+	GetDigestRecipients(ctx context.Context, pendingSince time.Time) ([]GetDigestRecipientsRow, error)
+	GetExpiringAccessToNotify(ctx context.Context, days int32) ([]GetExpiringAccessToNotifyRow, error)
+	GetGCSDatasource(ctx context.Context, datasetID uuid.UUID) (DatasourceGc, error)
+	GetGCSDatasources(ctx context.Context) ([]DatasourceGc, error)
+	GetGrantedDatasetsPage(ctx context.Context, arg GetGrantedDatasetsPageParams) ([]GetGrantedDatasetsPageRow, error)
 	GetInsightProduct(ctx context.Context, id uuid.UUID) (InsightProduct, error)
 	GetInsightProductByGroups_(ctx context.Context, groups []string) ([]InsightProduct, error)
 	GetInsightProductWithTeamkatalogen(ctx context.Context, id uuid.UUID) (InsightProductWithTeamkatalogenView, error)
 	GetInsightProducts(ctx context.Context) ([]InsightProduct, error)
-	GetInsightProductsByGroups(ctx context.Context, groups []string) ([]InsightProductWithTeamkatalogenView, error)
+	GetInsightProductsByGroupsPage(ctx context.Context, arg GetInsightProductsByGroupsPageParams) ([]GetInsightProductsByGroupsPageRow, error)
 	GetInsightProductsByIDs(ctx context.Context, id []uuid.UUID) ([]InsightProduct, error)
 	GetInsightProductsByProductArea(ctx context.Context, teamID []uuid.UUID) ([]InsightProductWithTeamkatalogenView, error)
+	GetInsightProductsByProductAreaPage(ctx context.Context, arg GetInsightProductsByProductAreaPageParams) ([]GetInsightProductsByProductAreaPageRow, error)
 	GetInsightProductsByTeam(ctx context.Context, teamID uuid.NullUUID) ([]InsightProduct, error)
 	GetInsightProductsNumberByTeam(ctx context.Context, teamID uuid.NullUUID) (int64, error)
+	GetJoinableView(ctx context.Context, id uuid.UUID) (JoinableView, error)
+	GetJoinableViewShares(ctx context.Context, joinableViewID uuid.UUID) ([]string, error)
 	GetJoinableViewWithDataset(ctx context.Context, id uuid.UUID) ([]GetJoinableViewWithDatasetRow, error)
 	GetJoinableViewsForOwner(ctx context.Context, owner string) ([]GetJoinableViewsForOwnerRow, error)
 	GetJoinableViewsForReferenceAndUser(ctx context.Context, arg GetJoinableViewsForReferenceAndUserParams) ([]GetJoinableViewsForReferenceAndUserRow, error)
+	GetJoinableViewsToBeCleanedUp(ctx context.Context) ([]JoinableView, error)
 	GetJoinableViewsToBeDeletedWithRefDatasource(ctx context.Context) ([]GetJoinableViewsToBeDeletedWithRefDatasourceRow, error)
 	GetJoinableViewsWithReference(ctx context.Context) ([]GetJoinableViewsWithReferenceRow, error)
 	GetKeywords(ctx context.Context) ([]GetKeywordsRow, error)
+	GetLatestTableProfile(ctx context.Context, arg GetLatestTableProfileParams) (DatasetProfile, error)
 	GetMetabaseMetadata(ctx context.Context, datasetID uuid.UUID) (MetabaseMetadatum, error)
 	GetMetabaseMetadataWithDeleted(ctx context.Context, datasetID uuid.UUID) (MetabaseMetadatum, error)
 	GetNadaToken(ctx context.Context, team string) (uuid.UUID, error)
 	GetNadaTokens(ctx context.Context) ([]NadaToken, error)
 	GetNadaTokensForTeams(ctx context.Context, teams []string) ([]NadaToken, error)
+	GetNotificationPreferences(ctx context.Context, email string) ([]NotificationPreference, error)
+	GetNotificationsPage(ctx context.Context, arg GetNotificationsPageParams) ([]GetNotificationsPageRow, error)
 	GetOpenMetabaseTablesInSameBigQueryDataset(ctx context.Context, arg GetOpenMetabaseTablesInSameBigQueryDatasetParams) ([]string, error)
+	GetOpenRecertificationCampaigns(ctx context.Context) ([]RecertificationCampaign, error)
+	GetOrganisationAccessPolicies(ctx context.Context) ([]AccessPolicy, error)
+	GetOwnedDatasetsPage(ctx context.Context, arg GetOwnedDatasetsPageParams) ([]GetOwnedDatasetsPageRow, error)
 	GetOwnerGroupOfDataset(ctx context.Context, datasetID uuid.UUID) (string, error)
-	GetPollyDocumentation(ctx context.Context, id uuid.UUID) (PollyDocumentation, error)
+	GetPendingDigestNotifications(ctx context.Context, arg GetPendingDigestNotificationsParams) ([]Notification, error)
+	GetPendingNotificationDeliveries(ctx context.Context, arg GetPendingNotificationDeliveriesParams) ([]NotificationDelivery, error)
+	GetPendingRenewalAccessRequest(ctx context.Context, renewsAccessID uuid.NullUUID) (DatasetAccessRequest, error)
+	GetPersonalDataInventory(ctx context.Context, arg GetPersonalDataInventoryParams) ([]GetPersonalDataInventoryRow, error)
+	GetPollyDocumentation(ctx context.Context, id uuid.UUID) (GetPollyDocumentationRow, error)
+	GetPollyPurpose(ctx context.Context, externalID string) (PollyPurpose, error)
+	GetPollyPurposeHistory(ctx context.Context, externalID string) ([]PollyPurposeHistory, error)
 	GetProductArea(ctx context.Context, id uuid.UUID) (TkProductArea, error)
 	GetProductAreas(ctx context.Context) ([]TkProductArea, error)
 	GetPseudoDatasourcesToDelete(ctx context.Context) ([]DatasourceBigquery, error)
+	GetQualityCheck(ctx context.Context, id uuid.UUID) (QualityCheck, error)
+	GetQualityCheckRuns(ctx context.Context, arg GetQualityCheckRunsParams) ([]QualityCheckRun, error)
+	GetQualityChecks(ctx context.Context, datasetID uuid.UUID) ([]GetQualityChecksRow, error)
+	GetQualityChecksToRun(ctx context.Context, id uuid.NullUUID) ([]GetQualityChecksToRunRow, error)
+	GetRecertificationCampaign(ctx context.Context, id uuid.UUID) (RecertificationCampaign, error)
+	GetRecertificationCampaigns(ctx context.Context) ([]RecertificationCampaign, error)
+	GetRecertificationCampaignsForGroups(ctx context.Context, arg GetRecertificationCampaignsForGroupsParams) ([]RecertificationCampaign, error)
+	GetRecertificationReview(ctx context.Context, id uuid.UUID) (GetRecertificationReviewRow, error)
+	GetRecertificationReviews(ctx context.Context, campaignID uuid.UUID) ([]GetRecertificationReviewsRow, error)
+	GetReferencedPollyPurposes(ctx context.Context) ([]GetReferencedPollyPurposesRow, error)
 	GetRemoveMetabaseDatasetMappings(ctx context.Context) ([]uuid.UUID, error)
+	GetServiceAccountGrantedDatasetsPage(ctx context.Context, arg GetServiceAccountGrantedDatasetsPageParams) ([]GetServiceAccountGrantedDatasetsPageRow, error)
 	GetSession(ctx context.Context, token string) (Session, error)
 	GetStories(ctx context.Context) ([]Story, error)
 	GetStoriesByGroups(ctx context.Context, groups []string) ([]Story, error)
 	GetStoriesByIDs(ctx context.Context, ids []uuid.UUID) ([]Story, error)
 	GetStoriesByProductArea(ctx context.Context, teamID []uuid.UUID) ([]StoryWithTeamkatalogenView, error)
+	GetStoriesByProductAreaPage(ctx context.Context, arg GetStoriesByProductAreaPageParams) ([]GetStoriesByProductAreaPageRow, error)
 	GetStoriesByTeam(ctx context.Context, teamID uuid.NullUUID) ([]Story, error)
 	GetStoriesNumberByTeam(ctx context.Context, teamID uuid.NullUUID) (int64, error)
-	GetStoriesWithTeamkatalogenByGroups(ctx context.Context, groups []string) ([]StoryWithTeamkatalogenView, error)
+	GetStoriesWithTeamkatalogenByGroupsPage(ctx context.Context, arg GetStoriesWithTeamkatalogenByGroupsPageParams) ([]GetStoriesWithTeamkatalogenByGroupsPageRow, error)
 	GetStoriesWithTeamkatalogenByIDs(ctx context.Context, ids []uuid.UUID) ([]StoryWithTeamkatalogenView, error)
 	GetStory(ctx context.Context, id uuid.UUID) (Story, error)
 	GetTag(ctx context.Context) (Tag, error)
@@ -117,39 +190,82 @@ type Querier interface {
 	GetTeamProjects(ctx context.Context) ([]TeamProject, error)
 	GetTeamsInProductArea(ctx context.Context, productAreaID uuid.NullUUID) ([]TkTeam, error)
 	GrantAccessToDataset(ctx context.Context, arg GrantAccessToDatasetParams) (DatasetAccess, error)
-	ListAccessRequestsForDataset(ctx context.Context, datasetID uuid.UUID) ([]DatasetAccessRequest, error)
-	ListAccessRequestsForOwner(ctx context.Context, owner []string) ([]DatasetAccessRequest, error)
+	ListAccessRequestsForDatasetPage(ctx context.Context, arg ListAccessRequestsForDatasetPageParams) ([]ListAccessRequestsForDatasetPageRow, error)
+	ListAccessRequestsForGranterPage(ctx context.Context, arg ListAccessRequestsForGranterPageParams) ([]ListAccessRequestsForGranterPageRow, error)
+	ListAccessRequestsForOwnerPage(ctx context.Context, arg ListAccessRequestsForOwnerPageParams) ([]ListAccessRequestsForOwnerPageRow, error)
 	ListAccessToDataset(ctx context.Context, datasetID uuid.UUID) ([]DatasetAccess, error)
+	ListAccessWithInvalidPollyPurposeForGroups(ctx context.Context, groups []string) ([]ListAccessWithInvalidPollyPurposeForGroupsRow, error)
+	ListActiveAccessForPollyPurpose(ctx context.Context, externalID string) ([]ListActiveAccessForPollyPurposeRow, error)
 	ListActiveAccessToDataset(ctx context.Context, datasetID uuid.UUID) ([]DatasetAccess, error)
+	ListActiveAccessToTablePatternDatasources(ctx context.Context) ([]DatasetAccess, error)
+	ListActiveAccessWithPurposeForDatasets(ctx context.Context, datasetIds []uuid.UUID) ([]ListActiveAccessWithPurposeForDatasetsRow, error)
+	ListExpiringAccessForGroups(ctx context.Context, arg ListExpiringAccessForGroupsParams) ([]ListExpiringAccessForGroupsRow, error)
 	ListUnrevokedExpiredAccessEntries(ctx context.Context) ([]DatasetAccess, error)
 	MapDataset(ctx context.Context, arg MapDatasetParams) error
+	MarkAccessExpiryNotified(ctx context.Context, arg MarkAccessExpiryNotifiedParams) error
+	MarkAllNotificationsRead(ctx context.Context, arg MarkAllNotificationsReadParams) error
+	MarkNotificationDelivered(ctx context.Context, id uuid.UUID) error
+	MarkNotificationDeliveryFailed(ctx context.Context, arg MarkNotificationDeliveryFailedParams) error
+	MarkNotificationDigestSent(ctx context.Context, ids []uuid.UUID) error
+	MarkNotificationsRead(ctx context.Context, arg MarkNotificationsReadParams) error
 	RemoveKeywordInDatasets(ctx context.Context, keywordToRemove interface{}) error
 	RemoveKeywordInStories(ctx context.Context, keywordToRemove interface{}) error
 	ReplaceDatasetsTag(ctx context.Context, arg ReplaceDatasetsTagParams) error
 	ReplaceKeywordInDatasets(ctx context.Context, arg ReplaceKeywordInDatasetsParams) error
 	ReplaceKeywordInStories(ctx context.Context, arg ReplaceKeywordInStoriesParams) error
 	ReplaceStoriesTag(ctx context.Context, arg ReplaceStoriesTagParams) error
+	ResolveDataproductTransfer(ctx context.Context, arg ResolveDataproductTransferParams) (DataproductTransfer, error)
+	RestoreDataproduct(ctx context.Context, id uuid.UUID) error
+	RestoreInsightProduct(ctx context.Context, id uuid.UUID) error
 	RestoreMetabaseMetadata(ctx context.Context, datasetID uuid.UUID) error
+	RestoreStory(ctx context.Context, id uuid.UUID) error
+	ReviewRecertification(ctx context.Context, arg ReviewRecertificationParams) error
 	RevokeAccessToDataset(ctx context.Context, id uuid.UUID) error
 	RotateNadaToken(ctx context.Context, team string) error
 	Search(ctx context.Context, arg SearchParams) ([]SearchRow, error)
+	SearchCount(ctx context.Context, arg SearchCountParams) (int64, error)
+	SearchFacets(ctx context.Context, arg SearchFacetsParams) ([]SearchFacetsRow, error)
+	SearchSuggestKeywords(ctx context.Context, arg SearchSuggestKeywordsParams) ([]SearchSuggestKeywordsRow, error)
+	SearchSuggestNames(ctx context.Context, arg SearchSuggestNamesParams) ([]SearchSuggestNamesRow, error)
+	SetAccessRequestApprovalRule(ctx context.Context, arg SetAccessRequestApprovalRuleParams) error
 	SetCollectionMetabaseMetadata(ctx context.Context, arg SetCollectionMetabaseMetadataParams) (MetabaseMetadatum, error)
 	SetDatabaseMetabaseMetadata(ctx context.Context, arg SetDatabaseMetabaseMetadataParams) (MetabaseMetadatum, error)
+	SetDataproductLifecycle(ctx context.Context, arg SetDataproductLifecycleParams) error
+	SetDatasetLifecycle(ctx context.Context, arg SetDatasetLifecycleParams) error
 	SetDatasourceDeleted(ctx context.Context, id uuid.UUID) error
+	SetJoinableViewDatasourceViewDeleted(ctx context.Context, arg SetJoinableViewDatasourceViewDeletedParams) error
 	SetJoinableViewDeleted(ctx context.Context, id uuid.UUID) error
+	SetJoinableViewExpires(ctx context.Context, arg SetJoinableViewExpiresParams) error
 	SetPermissionGroupMetabaseMetadata(ctx context.Context, arg SetPermissionGroupMetabaseMetadataParams) (MetabaseMetadatum, error)
+	SetRecertificationCampaignReminded(ctx context.Context, id uuid.UUID) error
 	SetServiceAccountMetabaseMetadata(ctx context.Context, arg SetServiceAccountMetabaseMetadataParams) (MetabaseMetadatum, error)
 	SetSyncCompletedMetabaseMetadata(ctx context.Context, datasetID uuid.UUID) error
+	SoftDeleteDataproduct(ctx context.Context, arg SoftDeleteDataproductParams) error
+	SoftDeleteInsightProduct(ctx context.Context, arg SoftDeleteInsightProductParams) error
 	SoftDeleteMetabaseMetadata(ctx context.Context, datasetID uuid.UUID) error
+	SoftDeleteStory(ctx context.Context, arg SoftDeleteStoryParams) error
+	TransferDataproductOwner(ctx context.Context, arg TransferDataproductOwnerParams) error
+	TransferDatasetAccessOwner(ctx context.Context, arg TransferDatasetAccessOwnerParams) error
+	TransferDatasetAccessRequestsOwner(ctx context.Context, arg TransferDatasetAccessRequestsOwnerParams) error
+	TransferStoriesOwner(ctx context.Context, arg TransferStoriesOwnerParams) error
+	UpdateAccessExpires(ctx context.Context, arg UpdateAccessExpiresParams) error
 	UpdateAccessRequest(ctx context.Context, arg UpdateAccessRequestParams) (DatasetAccessRequest, error)
 	UpdateBigqueryDatasource(ctx context.Context, arg UpdateBigqueryDatasourceParams) error
 	UpdateBigqueryDatasourceMissing(ctx context.Context, datasetID uuid.UUID) error
 	UpdateBigqueryDatasourceSchema(ctx context.Context, arg UpdateBigqueryDatasourceSchemaParams) error
+	UpdateBigqueryDatasourceSchemaColumns(ctx context.Context, arg UpdateBigqueryDatasourceSchemaColumnsParams) error
 	UpdateDataproduct(ctx context.Context, arg UpdateDataproductParams) (Dataproduct, error)
 	UpdateDataset(ctx context.Context, arg UpdateDatasetParams) (Dataset, error)
+	UpdateDatasetDescriptionAndKeywords(ctx context.Context, arg UpdateDatasetDescriptionAndKeywordsParams) error
+	UpdateGCSDatasourceMetadata(ctx context.Context, arg UpdateGCSDatasourceMetadataParams) error
+	UpdateGCSDatasourceMissing(ctx context.Context, datasetID uuid.UUID) error
 	UpdateInsightProduct(ctx context.Context, arg UpdateInsightProductParams) (InsightProduct, error)
 	UpdateStory(ctx context.Context, arg UpdateStoryParams) (Story, error)
 	UpdateTag(ctx context.Context, arg UpdateTagParams) error
+	UpsertDatasetColumnDescription(ctx context.Context, arg UpsertDatasetColumnDescriptionParams) error
+	UpsertDatasetPreviewSettings(ctx context.Context, arg UpsertDatasetPreviewSettingsParams) (DatasetPreviewSetting, error)
+	UpsertNotificationPreferences(ctx context.Context, arg UpsertNotificationPreferencesParams) error
+	UpsertPollyPurpose(ctx context.Context, arg UpsertPollyPurposeParams) error
 	UpsertProductArea(ctx context.Context, arg UpsertProductAreaParams) error
 	UpsertTeam(ctx context.Context, arg UpsertTeamParams) error
 }
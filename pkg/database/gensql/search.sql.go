@@ -16,61 +16,44 @@ const search = `-- name: Search :many
 SELECT
 	element_id::uuid,
 	element_type::text,
-	ts_rank_cd(tsv_document, query) AS rank,
-	ts_headline('norwegian', "description", query, 'MinWords=10, MaxWords=20, MaxFragments=2 FragmentDelimiter=" … " StartSel="((START))" StopSel="((STOP))"')::text AS excerpt
-FROM
-	search,
-	websearch_to_tsquery('norwegian', $1) query
-WHERE
 	(
 		CASE
-			WHEN array_length($2::text[], 1) > 0 THEN "element_type" = ANY($2)
-			ELSE TRUE
-		END
-	)
-	AND (
-		CASE
-			WHEN array_length($3::text[], 1) > 0 THEN "keywords" && $3
-			ELSE TRUE
+			WHEN $1 :: boolean THEN word_similarity($2, "name")
+			ELSE ts_rank_cd(tsv_document, query)
 		END
-	)
-	AND (
-		CASE
-			WHEN $1 :: text != '' THEN "tsv_document" @@ query
-			ELSE TRUE
-		END
-	)
-	AND (
-		CASE
-			WHEN array_length($4::text[], 1) > 0 THEN "group" = ANY($4)
-			ELSE TRUE
-		END
-	)
-	AND (
-		CASE
-			WHEN array_length($5::uuid[], 1) > 0 THEN "team_id" = ANY($5)
-			ELSE TRUE
-		END
-	)
-	AND (
-		CASE
-			WHEN array_length($6::text[], 1) > 0 THEN "services" && $6
-			ELSE TRUE
-		END
-	)
+	)::real AS rank,
+	ts_headline('norwegian', "description", query, 'MinWords=10, MaxWords=20, MaxFragments=2 FragmentDelimiter=" … " StartSel="((START))" StopSel="((STOP))"')::text AS excerpt
+FROM
+	search_hits(
+		$2::text,
+		$1::boolean,
+		$3::text[],
+		$4::text[],
+		$5::text[],
+		$6::uuid[],
+		$7::uuid[],
+		$8::text[],
+		$9::text[],
+		$10::text[]
+	) hits,
+	websearch_to_tsquery('norwegian', $2) query
 ORDER BY rank DESC, created ASC
-LIMIT $8 OFFSET $7
+LIMIT $11 OFFSET $12
 `
 
 type SearchParams struct {
-	Query   string
-	Types   []string
-	Keyword []string
-	Grp     []string
-	TeamID  []uuid.UUID
-	Service []string
-	Offs    int32
-	Lim     int32
+	Fuzzy         bool
+	Query         string
+	Types         []string
+	Keyword       []string
+	Grp           []string
+	TeamID        []uuid.UUID
+	ProductAreaID []uuid.UUID
+	Pii           []string
+	Service       []string
+	QualityStatus []string
+	Lim           int32
+	Offs          int32
 }
 
 type SearchRow struct {
@@ -82,14 +65,18 @@ type SearchRow struct {
 
 func (q *Queries) Search(ctx context.Context, arg SearchParams) ([]SearchRow, error) {
 	rows, err := q.db.QueryContext(ctx, search,
+		arg.Fuzzy,
 		arg.Query,
 		pq.Array(arg.Types),
 		pq.Array(arg.Keyword),
 		pq.Array(arg.Grp),
 		pq.Array(arg.TeamID),
+		pq.Array(arg.ProductAreaID),
+		pq.Array(arg.Pii),
 		pq.Array(arg.Service),
-		arg.Offs,
+		pq.Array(arg.QualityStatus),
 		arg.Lim,
+		arg.Offs,
 	)
 	if err != nil {
 		return nil, err
@@ -116,3 +103,349 @@ func (q *Queries) Search(ctx context.Context, arg SearchParams) ([]SearchRow, er
 	}
 	return items, nil
 }
+
+const searchCount = `-- name: SearchCount :one
+SELECT
+	count(*)::bigint AS total
+FROM
+	search_hits(
+		$1::text,
+		$2::boolean,
+		$3::text[],
+		$4::text[],
+		$5::text[],
+		$6::uuid[],
+		$7::uuid[],
+		$8::text[],
+		$9::text[],
+		$10::text[]
+	)
+`
+
+type SearchCountParams struct {
+	Query         string
+	Fuzzy         bool
+	Types         []string
+	Keyword       []string
+	Grp           []string
+	TeamID        []uuid.UUID
+	ProductAreaID []uuid.UUID
+	Pii           []string
+	Service       []string
+	QualityStatus []string
+}
+
+func (q *Queries) SearchCount(ctx context.Context, arg SearchCountParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, searchCount,
+		arg.Query,
+		arg.Fuzzy,
+		pq.Array(arg.Types),
+		pq.Array(arg.Keyword),
+		pq.Array(arg.Grp),
+		pq.Array(arg.TeamID),
+		pq.Array(arg.ProductAreaID),
+		pq.Array(arg.Pii),
+		pq.Array(arg.Service),
+		pq.Array(arg.QualityStatus),
+	)
+	var total int64
+	err := row.Scan(&total)
+	return total, err
+}
+
+const searchFacets = `-- name: SearchFacets :many
+WITH hits AS (
+	SELECT
+		"element_id",
+		"element_type",
+		"keywords",
+		"team_id",
+		"product_area_id",
+		"pii",
+		"services",
+		"quality_status"
+	FROM
+		search_hits(
+			$1::text,
+			$2::boolean,
+			$3::text[],
+			$4::text[],
+			$5::text[],
+			$6::uuid[],
+			$7::uuid[],
+			$8::text[],
+			$9::text[],
+			$10::text[]
+		)
+)
+SELECT
+	'keywords'::text AS facet,
+	"keyword"::text AS value,
+	''::text AS label,
+	count(DISTINCT "element_id")::bigint AS count
+FROM
+	hits,
+	unnest("keywords") AS "keyword"
+GROUP BY
+	"keyword"
+UNION ALL
+SELECT
+	'teams'::text AS facet,
+	"hits"."team_id"::text AS value,
+	coalesce("tkt"."name", '')::text AS label,
+	count(*)::bigint AS count
+FROM
+	hits
+	LEFT JOIN tk_teams "tkt" ON "hits"."team_id" = "tkt"."id"
+WHERE
+	"hits"."team_id" IS NOT NULL
+GROUP BY
+	"hits"."team_id",
+	"tkt"."name"
+UNION ALL
+SELECT
+	'productAreas'::text AS facet,
+	"hits"."product_area_id"::text AS value,
+	coalesce("tkpa"."name", '')::text AS label,
+	count(*)::bigint AS count
+FROM
+	hits
+	LEFT JOIN tk_product_areas "tkpa" ON "hits"."product_area_id" = "tkpa"."id"
+WHERE
+	"hits"."product_area_id" IS NOT NULL
+GROUP BY
+	"hits"."product_area_id",
+	"tkpa"."name"
+UNION ALL
+SELECT
+	'piiLevels'::text AS facet,
+	"pii"::text AS value,
+	''::text AS label,
+	count(*)::bigint AS count
+FROM
+	hits
+WHERE
+	"pii" IS NOT NULL
+GROUP BY
+	"pii"
+UNION ALL
+SELECT
+	'qualityStatuses'::text AS facet,
+	"quality_status"::text AS value,
+	''::text AS label,
+	count(*)::bigint AS count
+FROM
+	hits
+WHERE
+	"quality_status" IS NOT NULL
+GROUP BY
+	"quality_status"
+UNION ALL
+SELECT
+	'types'::text AS facet,
+	"element_type"::text AS value,
+	''::text AS label,
+	count(*)::bigint AS count
+FROM
+	hits
+GROUP BY
+	"element_type"
+UNION ALL
+SELECT
+	'services'::text AS facet,
+	"service"::text AS value,
+	''::text AS label,
+	count(DISTINCT "element_id")::bigint AS count
+FROM
+	hits,
+	unnest("services") AS "service"
+GROUP BY
+	"service"
+ORDER BY
+	facet,
+	count DESC,
+	value
+`
+
+type SearchFacetsParams struct {
+	Query         string
+	Fuzzy         bool
+	Types         []string
+	Keyword       []string
+	Grp           []string
+	TeamID        []uuid.UUID
+	ProductAreaID []uuid.UUID
+	Pii           []string
+	Service       []string
+	QualityStatus []string
+}
+
+type SearchFacetsRow struct {
+	Facet string
+	Value string
+	Label string
+	Count int64
+}
+
+func (q *Queries) SearchFacets(ctx context.Context, arg SearchFacetsParams) ([]SearchFacetsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchFacets,
+		arg.Query,
+		arg.Fuzzy,
+		pq.Array(arg.Types),
+		pq.Array(arg.Keyword),
+		pq.Array(arg.Grp),
+		pq.Array(arg.TeamID),
+		pq.Array(arg.ProductAreaID),
+		pq.Array(arg.Pii),
+		pq.Array(arg.Service),
+		pq.Array(arg.QualityStatus),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchFacetsRow{}
+	for rows.Next() {
+		var i SearchFacetsRow
+		if err := rows.Scan(
+			&i.Facet,
+			&i.Value,
+			&i.Label,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchSuggestKeywords = `-- name: SearchSuggestKeywords :many
+SELECT
+	"keyword"::text,
+	count(1) AS "count"
+FROM
+	(
+		SELECT unnest("ds"."keywords") AS "keyword" FROM datasets "ds"
+			JOIN dataproducts "dp" ON "ds"."dataproduct_id" = "dp"."id"
+		WHERE "dp"."deleted" IS NULL
+		UNION ALL
+		SELECT unnest("keywords") AS "keyword" FROM stories
+		WHERE "deleted" IS NULL
+	) AS "keywords"
+WHERE
+	"keyword" ILIKE $1::text || '%'
+GROUP BY
+	"keyword"
+ORDER BY
+	"count" DESC,
+	"keyword" ASC
+LIMIT $2
+`
+
+type SearchSuggestKeywordsParams struct {
+	Prefix string
+	Lim    int32
+}
+
+type SearchSuggestKeywordsRow struct {
+	Keyword string
+	Count   int64
+}
+
+func (q *Queries) SearchSuggestKeywords(ctx context.Context, arg SearchSuggestKeywordsParams) ([]SearchSuggestKeywordsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchSuggestKeywords, arg.Prefix, arg.Lim)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchSuggestKeywordsRow{}
+	for rows.Next() {
+		var i SearchSuggestKeywordsRow
+		if err := rows.Scan(&i.Keyword, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchSuggestNames = `-- name: SearchSuggestNames :many
+WITH "names" AS (
+	SELECT "id", "name", 'dataproduct' AS "element_type" FROM dataproducts
+	WHERE "deleted" IS NULL
+		AND "lifecycle_status" != 'retired'
+		AND ("name" ILIKE $1::text || '%' OR "name" ILIKE '% ' || $1::text || '%')
+	UNION ALL
+	SELECT "ds"."id", "ds"."name", 'dataset' AS "element_type" FROM datasets "ds"
+		JOIN dataproducts "dp" ON "ds"."dataproduct_id" = "dp"."id"
+	WHERE "dp"."deleted" IS NULL
+		AND "ds"."lifecycle_status" != 'retired'
+		AND ("ds"."name" ILIKE $1::text || '%' OR "ds"."name" ILIKE '% ' || $1::text || '%')
+	UNION ALL
+	SELECT "id", "name", 'story' AS "element_type" FROM stories
+	WHERE "deleted" IS NULL
+		AND ("name" ILIKE $1::text || '%' OR "name" ILIKE '% ' || $1::text || '%')
+	UNION ALL
+	SELECT "id", "name", 'team' AS "element_type" FROM tk_teams
+	WHERE "name" ILIKE $1::text || '%' OR "name" ILIKE '% ' || $1::text || '%'
+)
+SELECT
+	"id",
+	"name"::text,
+	"element_type"::text
+FROM
+	"names"
+ORDER BY
+	"name" ILIKE $1::text || '%' DESC,
+	similarity("name", $2::text) DESC,
+	"name" ASC
+LIMIT $3
+`
+
+type SearchSuggestNamesParams struct {
+	Prefix string
+	Term   string
+	Lim    int32
+}
+
+type SearchSuggestNamesRow struct {
+	ID          uuid.UUID
+	Name        string
+	ElementType string
+}
+
+func (q *Queries) SearchSuggestNames(ctx context.Context, arg SearchSuggestNamesParams) ([]SearchSuggestNamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchSuggestNames, arg.Prefix, arg.Term, arg.Lim)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchSuggestNamesRow{}
+	for rows.Next() {
+		var i SearchSuggestNamesRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.ElementType); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -7,40 +7,202 @@ package gensql
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 )
 
-const getStoriesWithTeamkatalogenByGroups = `-- name: GetStoriesWithTeamkatalogenByGroups :many
-SELECT id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group", team_name, pa_name
-FROM story_with_teamkatalogen_view swtv
-WHERE "group" = ANY ($1::text[])
-ORDER BY swtv."group", swtv.name ASC
+const getStoriesByProductAreaPage = `-- name: GetStoriesByProductAreaPage :many
+(
+    SELECT swtv.id, swtv.name, swtv.creator, swtv.created, swtv.last_modified, swtv.description, swtv.keywords, swtv.teamkatalogen_url, swtv.team_id, swtv."group", swtv.deleted, swtv.deleted_by, swtv.team_name, swtv.pa_name
+    FROM story_with_teamkatalogen_view swtv
+    WHERE $1::text = 'name' AND NOT $2::bool
+    AND swtv.team_id = ANY($3::uuid[])
+    AND ($4::uuid IS NULL OR (swtv.name, swtv.id) > ($5::text, $4::uuid))
+    ORDER BY swtv.name ASC, swtv.id ASC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT swtv.id, swtv.name, swtv.creator, swtv.created, swtv.last_modified, swtv.description, swtv.keywords, swtv.teamkatalogen_url, swtv.team_id, swtv."group", swtv.deleted, swtv.deleted_by, swtv.team_name, swtv.pa_name
+    FROM story_with_teamkatalogen_view swtv
+    WHERE $1::text = 'name' AND $2::bool
+    AND swtv.team_id = ANY($3::uuid[])
+    AND ($4::uuid IS NULL OR (swtv.name, swtv.id) < ($5::text, $4::uuid))
+    ORDER BY swtv.name DESC, swtv.id DESC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT swtv.id, swtv.name, swtv.creator, swtv.created, swtv.last_modified, swtv.description, swtv.keywords, swtv.teamkatalogen_url, swtv.team_id, swtv."group", swtv.deleted, swtv.deleted_by, swtv.team_name, swtv.pa_name
+    FROM story_with_teamkatalogen_view swtv
+    WHERE $1::text = 'created' AND NOT $2::bool
+    AND swtv.team_id = ANY($3::uuid[])
+    AND ($4::uuid IS NULL OR (swtv.created, swtv.id) > ($7::timestamptz, $4::uuid))
+    ORDER BY swtv.created ASC, swtv.id ASC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT swtv.id, swtv.name, swtv.creator, swtv.created, swtv.last_modified, swtv.description, swtv.keywords, swtv.teamkatalogen_url, swtv.team_id, swtv."group", swtv.deleted, swtv.deleted_by, swtv.team_name, swtv.pa_name
+    FROM story_with_teamkatalogen_view swtv
+    WHERE $1::text = 'created' AND $2::bool
+    AND swtv.team_id = ANY($3::uuid[])
+    AND ($4::uuid IS NULL OR (swtv.created, swtv.id) < ($7::timestamptz, $4::uuid))
+    ORDER BY swtv.created DESC, swtv.id DESC
+    LIMIT $6::int
+)
 `
 
-func (q *Queries) GetStoriesWithTeamkatalogenByGroups(ctx context.Context, groups []string) ([]StoryWithTeamkatalogenView, error) {
-	rows, err := q.db.QueryContext(ctx, getStoriesWithTeamkatalogenByGroups, pq.Array(groups))
+type GetStoriesByProductAreaPageParams struct {
+	Sort         string
+	Descending   bool
+	TeamID       []uuid.UUID
+	AfterID      uuid.NullUUID
+	AfterName    string
+	Lim          int32
+	AfterCreated time.Time
+}
+
+type GetStoriesByProductAreaPageRow struct {
+	StoryWithTeamkatalogenView StoryWithTeamkatalogenView
+}
+
+func (q *Queries) GetStoriesByProductAreaPage(ctx context.Context, arg GetStoriesByProductAreaPageParams) ([]GetStoriesByProductAreaPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStoriesByProductAreaPage,
+		arg.Sort,
+		arg.Descending,
+		pq.Array(arg.TeamID),
+		arg.AfterID,
+		arg.AfterName,
+		arg.Lim,
+		arg.AfterCreated,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []StoryWithTeamkatalogenView{}
+	items := []GetStoriesByProductAreaPageRow{}
 	for rows.Next() {
-		var i StoryWithTeamkatalogenView
+		var i GetStoriesByProductAreaPageRow
 		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Creator,
-			&i.Created,
-			&i.LastModified,
-			&i.Description,
-			pq.Array(&i.Keywords),
-			&i.TeamkatalogenUrl,
-			&i.TeamID,
-			&i.Group,
-			&i.TeamName,
-			&i.PaName,
+			&i.StoryWithTeamkatalogenView.ID,
+			&i.StoryWithTeamkatalogenView.Name,
+			&i.StoryWithTeamkatalogenView.Creator,
+			&i.StoryWithTeamkatalogenView.Created,
+			&i.StoryWithTeamkatalogenView.LastModified,
+			&i.StoryWithTeamkatalogenView.Description,
+			pq.Array(&i.StoryWithTeamkatalogenView.Keywords),
+			&i.StoryWithTeamkatalogenView.TeamkatalogenUrl,
+			&i.StoryWithTeamkatalogenView.TeamID,
+			&i.StoryWithTeamkatalogenView.Group,
+			&i.StoryWithTeamkatalogenView.Deleted,
+			&i.StoryWithTeamkatalogenView.DeletedBy,
+			&i.StoryWithTeamkatalogenView.TeamName,
+			&i.StoryWithTeamkatalogenView.PaName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStoriesWithTeamkatalogenByGroupsPage = `-- name: GetStoriesWithTeamkatalogenByGroupsPage :many
+(
+    SELECT swtv.id, swtv.name, swtv.creator, swtv.created, swtv.last_modified, swtv.description, swtv.keywords, swtv.teamkatalogen_url, swtv.team_id, swtv."group", swtv.deleted, swtv.deleted_by, swtv.team_name, swtv.pa_name
+    FROM story_with_teamkatalogen_view swtv
+    WHERE $1::text = 'name' AND NOT $2::bool
+    AND swtv."group" = ANY($3::text[])
+    AND ($4::uuid IS NULL OR (swtv.name, swtv.id) > ($5::text, $4::uuid))
+    ORDER BY swtv.name ASC, swtv.id ASC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT swtv.id, swtv.name, swtv.creator, swtv.created, swtv.last_modified, swtv.description, swtv.keywords, swtv.teamkatalogen_url, swtv.team_id, swtv."group", swtv.deleted, swtv.deleted_by, swtv.team_name, swtv.pa_name
+    FROM story_with_teamkatalogen_view swtv
+    WHERE $1::text = 'name' AND $2::bool
+    AND swtv."group" = ANY($3::text[])
+    AND ($4::uuid IS NULL OR (swtv.name, swtv.id) < ($5::text, $4::uuid))
+    ORDER BY swtv.name DESC, swtv.id DESC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT swtv.id, swtv.name, swtv.creator, swtv.created, swtv.last_modified, swtv.description, swtv.keywords, swtv.teamkatalogen_url, swtv.team_id, swtv."group", swtv.deleted, swtv.deleted_by, swtv.team_name, swtv.pa_name
+    FROM story_with_teamkatalogen_view swtv
+    WHERE $1::text = 'created' AND NOT $2::bool
+    AND swtv."group" = ANY($3::text[])
+    AND ($4::uuid IS NULL OR (swtv.created, swtv.id) > ($7::timestamptz, $4::uuid))
+    ORDER BY swtv.created ASC, swtv.id ASC
+    LIMIT $6::int
+)
+UNION ALL
+(
+    SELECT swtv.id, swtv.name, swtv.creator, swtv.created, swtv.last_modified, swtv.description, swtv.keywords, swtv.teamkatalogen_url, swtv.team_id, swtv."group", swtv.deleted, swtv.deleted_by, swtv.team_name, swtv.pa_name
+    FROM story_with_teamkatalogen_view swtv
+    WHERE $1::text = 'created' AND $2::bool
+    AND swtv."group" = ANY($3::text[])
+    AND ($4::uuid IS NULL OR (swtv.created, swtv.id) < ($7::timestamptz, $4::uuid))
+    ORDER BY swtv.created DESC, swtv.id DESC
+    LIMIT $6::int
+)
+`
+
+type GetStoriesWithTeamkatalogenByGroupsPageParams struct {
+	Sort         string
+	Descending   bool
+	Groups       []string
+	AfterID      uuid.NullUUID
+	AfterName    string
+	Lim          int32
+	AfterCreated time.Time
+}
+
+type GetStoriesWithTeamkatalogenByGroupsPageRow struct {
+	StoryWithTeamkatalogenView StoryWithTeamkatalogenView
+}
+
+func (q *Queries) GetStoriesWithTeamkatalogenByGroupsPage(ctx context.Context, arg GetStoriesWithTeamkatalogenByGroupsPageParams) ([]GetStoriesWithTeamkatalogenByGroupsPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStoriesWithTeamkatalogenByGroupsPage,
+		arg.Sort,
+		arg.Descending,
+		pq.Array(arg.Groups),
+		arg.AfterID,
+		arg.AfterName,
+		arg.Lim,
+		arg.AfterCreated,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetStoriesWithTeamkatalogenByGroupsPageRow{}
+	for rows.Next() {
+		var i GetStoriesWithTeamkatalogenByGroupsPageRow
+		if err := rows.Scan(
+			&i.StoryWithTeamkatalogenView.ID,
+			&i.StoryWithTeamkatalogenView.Name,
+			&i.StoryWithTeamkatalogenView.Creator,
+			&i.StoryWithTeamkatalogenView.Created,
+			&i.StoryWithTeamkatalogenView.LastModified,
+			&i.StoryWithTeamkatalogenView.Description,
+			pq.Array(&i.StoryWithTeamkatalogenView.Keywords),
+			&i.StoryWithTeamkatalogenView.TeamkatalogenUrl,
+			&i.StoryWithTeamkatalogenView.TeamID,
+			&i.StoryWithTeamkatalogenView.Group,
+			&i.StoryWithTeamkatalogenView.Deleted,
+			&i.StoryWithTeamkatalogenView.DeletedBy,
+			&i.StoryWithTeamkatalogenView.TeamName,
+			&i.StoryWithTeamkatalogenView.PaName,
 		); err != nil {
 			return nil, err
 		}
@@ -56,7 +218,7 @@ func (q *Queries) GetStoriesWithTeamkatalogenByGroups(ctx context.Context, group
 }
 
 const getStoriesWithTeamkatalogenByIDs = `-- name: GetStoriesWithTeamkatalogenByIDs :many
-SELECT id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group", team_name, pa_name
+SELECT id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group", deleted, deleted_by, team_name, pa_name
 FROM story_with_teamkatalogen_view
 WHERE id = ANY ($1::uuid[])
 ORDER BY last_modified DESC
@@ -82,6 +244,8 @@ func (q *Queries) GetStoriesWithTeamkatalogenByIDs(ctx context.Context, ids []uu
 			&i.TeamkatalogenUrl,
 			&i.TeamID,
 			&i.Group,
+			&i.Deleted,
+			&i.DeletedBy,
 			&i.TeamName,
 			&i.PaName,
 		); err != nil {
@@ -31,7 +31,7 @@ INSERT INTO stories (
     $6,
     $7
 )
-RETURNING id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group"
+RETURNING id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group", deleted, deleted_by
 `
 
 type CreateStoryParams struct {
@@ -66,6 +66,8 @@ func (q *Queries) CreateStory(ctx context.Context, arg CreateStoryParams) (Story
 		&i.TeamkatalogenUrl,
 		&i.TeamID,
 		&i.Group,
+		&i.Deleted,
+		&i.DeletedBy,
 	)
 	return i, err
 }
@@ -90,7 +92,7 @@ INSERT INTO stories (
     $7,
     $8
 )
-RETURNING id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group"
+RETURNING id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group", deleted, deleted_by
 `
 
 type CreateStoryWithIDParams struct {
@@ -127,6 +129,8 @@ func (q *Queries) CreateStoryWithID(ctx context.Context, arg CreateStoryWithIDPa
 		&i.TeamkatalogenUrl,
 		&i.TeamID,
 		&i.Group,
+		&i.Deleted,
+		&i.DeletedBy,
 	)
 	return i, err
 }
@@ -142,8 +146,9 @@ func (q *Queries) DeleteStory(ctx context.Context, id uuid.UUID) error {
 }
 
 const getStories = `-- name: GetStories :many
-SELECT id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group"
+SELECT id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group", deleted, deleted_by
 FROM stories
+WHERE deleted IS NULL
 ORDER BY last_modified DESC
 `
 
@@ -167,6 +172,8 @@ func (q *Queries) GetStories(ctx context.Context) ([]Story, error) {
 			&i.TeamkatalogenUrl,
 			&i.TeamID,
 			&i.Group,
+			&i.Deleted,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -182,9 +189,9 @@ func (q *Queries) GetStories(ctx context.Context) ([]Story, error) {
 }
 
 const getStoriesByGroups = `-- name: GetStoriesByGroups :many
-SELECT id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group"
+SELECT id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group", deleted, deleted_by
 FROM stories
-WHERE "group" = ANY ($1::text[])
+WHERE "group" = ANY ($1::text[]) AND deleted IS NULL
 ORDER BY last_modified DESC
 `
 
@@ -208,6 +215,8 @@ func (q *Queries) GetStoriesByGroups(ctx context.Context, groups []string) ([]St
 			&i.TeamkatalogenUrl,
 			&i.TeamID,
 			&i.Group,
+			&i.Deleted,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -223,9 +232,9 @@ func (q *Queries) GetStoriesByGroups(ctx context.Context, groups []string) ([]St
 }
 
 const getStoriesByIDs = `-- name: GetStoriesByIDs :many
-SELECT id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group"
+SELECT id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group", deleted, deleted_by
 FROM stories
-WHERE id = ANY ($1::uuid[])
+WHERE id = ANY ($1::uuid[]) AND deleted IS NULL
 ORDER BY last_modified DESC
 `
 
@@ -249,6 +258,8 @@ func (q *Queries) GetStoriesByIDs(ctx context.Context, ids []uuid.UUID) ([]Story
 			&i.TeamkatalogenUrl,
 			&i.TeamID,
 			&i.Group,
+			&i.Deleted,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -264,7 +275,7 @@ func (q *Queries) GetStoriesByIDs(ctx context.Context, ids []uuid.UUID) ([]Story
 }
 
 const getStoriesByProductArea = `-- name: GetStoriesByProductArea :many
-SELECT id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group", team_name, pa_name
+SELECT id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group", deleted, deleted_by, team_name, pa_name
 FROM story_with_teamkatalogen_view
 WHERE team_id = ANY($1::uuid[])
 ORDER BY last_modified DESC
@@ -290,6 +301,8 @@ func (q *Queries) GetStoriesByProductArea(ctx context.Context, teamID []uuid.UUI
 			&i.TeamkatalogenUrl,
 			&i.TeamID,
 			&i.Group,
+			&i.Deleted,
+			&i.DeletedBy,
 			&i.TeamName,
 			&i.PaName,
 		); err != nil {
@@ -307,9 +320,9 @@ func (q *Queries) GetStoriesByProductArea(ctx context.Context, teamID []uuid.UUI
 }
 
 const getStoriesByTeam = `-- name: GetStoriesByTeam :many
-SELECT id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group"
+SELECT id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group", deleted, deleted_by
 FROM stories
-WHERE team_id = $1
+WHERE team_id = $1 AND deleted IS NULL
 ORDER BY last_modified DESC
 `
 
@@ -333,6 +346,8 @@ func (q *Queries) GetStoriesByTeam(ctx context.Context, teamID uuid.NullUUID) ([
 			&i.TeamkatalogenUrl,
 			&i.TeamID,
 			&i.Group,
+			&i.Deleted,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -350,7 +365,7 @@ func (q *Queries) GetStoriesByTeam(ctx context.Context, teamID uuid.NullUUID) ([
 const getStoriesNumberByTeam = `-- name: GetStoriesNumberByTeam :one
 SELECT COUNT(*) as "count"
 FROM stories
-WHERE team_id = $1
+WHERE team_id = $1 AND deleted IS NULL
 `
 
 func (q *Queries) GetStoriesNumberByTeam(ctx context.Context, teamID uuid.NullUUID) (int64, error) {
@@ -361,9 +376,9 @@ func (q *Queries) GetStoriesNumberByTeam(ctx context.Context, teamID uuid.NullUU
 }
 
 const getStory = `-- name: GetStory :one
-SELECT id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group"
+SELECT id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group", deleted, deleted_by
 FROM stories
-WHERE id = $1
+WHERE id = $1 AND deleted IS NULL
 `
 
 func (q *Queries) GetStory(ctx context.Context, id uuid.UUID) (Story, error) {
@@ -380,6 +395,8 @@ func (q *Queries) GetStory(ctx context.Context, id uuid.UUID) (Story, error) {
 		&i.TeamkatalogenUrl,
 		&i.TeamID,
 		&i.Group,
+		&i.Deleted,
+		&i.DeletedBy,
 	)
 	return i, err
 }
@@ -409,7 +426,8 @@ SET
     "team_id" = $5,
     "group" = $6
 WHERE id = $7
-RETURNING id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group"
+AND ($8::timestamptz IS NULL OR last_modified = $8)
+RETURNING id, name, creator, created, last_modified, description, keywords, teamkatalogen_url, team_id, "group", deleted, deleted_by
 `
 
 type UpdateStoryParams struct {
@@ -420,6 +438,7 @@ type UpdateStoryParams struct {
 	TeamID           uuid.NullUUID
 	OwnerGroup       string
 	ID               uuid.UUID
+	IfMatch          sql.NullTime
 }
 
 func (q *Queries) UpdateStory(ctx context.Context, arg UpdateStoryParams) (Story, error) {
@@ -431,6 +450,7 @@ func (q *Queries) UpdateStory(ctx context.Context, arg UpdateStoryParams) (Story
 		arg.TeamID,
 		arg.OwnerGroup,
 		arg.ID,
+		arg.IfMatch,
 	)
 	var i Story
 	err := row.Scan(
@@ -444,6 +464,8 @@ func (q *Queries) UpdateStory(ctx context.Context, arg UpdateStoryParams) (Story
 		&i.TeamkatalogenUrl,
 		&i.TeamID,
 		&i.Group,
+		&i.Deleted,
+		&i.DeletedBy,
 	)
 	return i, err
 }
@@ -19,6 +19,11 @@ WHERE "dataset_id" NOT IN (
     SELECT dataset_id FROM metabase_metadata
     WHERE "sync_completed" IS NOT NULL
 )
+AND "dataset_id" NOT IN (
+    SELECT ds.id FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    WHERE dp.deleted IS NOT NULL
+)
 AND 'metabase' = ANY(services)
 `
 
@@ -59,7 +64,7 @@ func (q *Queries) GetDatasetMappings(ctx context.Context, datasetID uuid.UUID) (
 }
 
 const getDatasetsByMapping = `-- name: GetDatasetsByMapping :many
-SELECT datasets.id, datasets.name, datasets.description, datasets.pii, datasets.created, datasets.last_modified, datasets.type, datasets.tsv_document, datasets.slug, datasets.repo, datasets.keywords, datasets.dataproduct_id, datasets.anonymisation_description, datasets.target_user FROM third_party_mappings
+SELECT datasets.id, datasets.name, datasets.description, datasets.pii, datasets.created, datasets.last_modified, datasets.type, datasets.tsv_document, datasets.slug, datasets.repo, datasets.keywords, datasets.dataproduct_id, datasets.anonymisation_description, datasets.target_user, datasets.lifecycle_status, datasets.replaced_by, datasets.sunset, datasets.deprecation_reason FROM third_party_mappings
 INNER JOIN datasets ON datasets.id = third_party_mappings.dataset_id
 WHERE $1::TEXT = ANY("services")
 LIMIT $3 OFFSET $2
@@ -95,6 +100,10 @@ func (q *Queries) GetDatasetsByMapping(ctx context.Context, arg GetDatasetsByMap
 			&i.DataproductID,
 			&i.AnonymisationDescription,
 			&i.TargetUser,
+			&i.LifecycleStatus,
+			&i.ReplacedBy,
+			&i.Sunset,
+			&i.DeprecationReason,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,276 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX dataproducts_name_trgm_idx ON dataproducts USING gin ("name" gin_trgm_ops);
+CREATE INDEX datasets_name_trgm_idx ON datasets USING gin ("name" gin_trgm_ops);
+CREATE INDEX stories_name_trgm_idx ON stories USING gin ("name" gin_trgm_ops);
+CREATE INDEX tk_teams_name_trgm_idx ON tk_teams USING gin ("name" gin_trgm_ops);
+
+DROP VIEW search;
+
+CREATE VIEW search AS (
+    SELECT
+        "dp"."id" AS "element_id",
+        'dataproduct' AS "element_type",
+        "dp"."name",
+        coalesce("dp"."description", '') AS "description",
+        "dpk"."aggregated_keywords" AS "keywords",
+        "dp"."group",
+        "dp"."team_id",
+        "dp"."created",
+        "dp"."last_modified",
+        (
+            setweight(to_tsvector('norwegian', "dp"."name"), 'A') || setweight(
+                to_tsvector('norwegian', coalesce("dp"."description", '')),
+                'B'
+            ) || setweight(
+                to_tsvector(
+                    'norwegian',
+                    split_part(coalesce("dp"."group", ''), '@', 1)
+                ),
+                'D'
+            )
+        ) AS tsv_document,
+        '{}' AS "services",
+        NULL::text AS "pii",
+        "tkt"."product_area_id"
+    FROM
+        "dataproducts" "dp"
+        LEFT JOIN "tk_teams" "tkt" ON "dp"."team_id" = "tkt"."id"
+        LEFT JOIN (
+            SELECT
+                "dk"."dataproduct_id",
+                coalesce(array_agg("flatterned_keywords_array"), '{}') as "aggregated_keywords"
+            FROM
+                (
+                    SELECT
+                        "dataproduct_id",
+                        unnest("keywords") as "flatterned_keywords_array"
+                    FROM
+                        "datasets"
+                ) as "dk"
+            GROUP BY
+                "dk"."dataproduct_id"
+        ) AS "dpk" on "dp"."id" = "dataproduct_id"
+    UNION
+    SELECT
+        "ds"."id" AS "element_id",
+        'dataset' AS "element_type",
+        "ds"."name",
+        coalesce("ds"."description", '') AS "description",
+        "ds"."keywords",
+        "dp"."group",
+        "dp"."team_id",
+        "ds"."created",
+        "ds"."last_modified",
+        (
+            setweight(to_tsvector('norwegian', "ds"."name"), 'A') || setweight(
+                to_tsvector('norwegian', coalesce("ds"."description", '')),
+                'B'
+            ) || setweight(
+                to_tsvector(
+                    'norwegian',
+                    coalesce(f_arr2text("ds"."keywords"), '')
+                ),
+                'C'
+            ) || setweight(
+                to_tsvector('norwegian', coalesce("ds"."repo", '')),
+                'D'
+            ) || setweight(
+                to_tsvector('norwegian', "ds"."type" :: text),
+                'D'
+            ) || setweight(
+                to_tsvector(
+                    'norwegian',
+                    split_part(coalesce("dp"."group", ''), '@', 1)
+                ),
+                'D'
+            )
+        ) AS tsv_document,
+        "tpm"."services",
+        "ds"."pii"::text AS "pii",
+        "tkt"."product_area_id"
+    FROM
+        "datasets" "ds"
+        JOIN "dataproducts" "dp" ON "ds"."dataproduct_id" = "dp"."id"
+        LEFT JOIN "third_party_mappings" "tpm" ON "tpm"."dataset_id" = "ds"."id"
+        LEFT JOIN "tk_teams" "tkt" ON "dp"."team_id" = "tkt"."id"
+)
+UNION
+SELECT
+    "ss"."id" AS "element_id",
+    'story' AS "element_type",
+    "ss"."name",
+    "ss"."description" AS "description",
+    "ss"."keywords" AS "keywords",
+    "ss"."group" AS "group",
+    "ss"."team_id",
+    "ss"."created",
+    "ss"."last_modified",
+    (
+        setweight(to_tsvector('norwegian', "ss"."name"), 'A') || setweight(
+            to_tsvector('norwegian', "ss"."description"),
+            'B'
+        ) || setweight(
+            to_tsvector(
+                'norwegian',
+                coalesce(f_arr2text("ss"."keywords"), '')
+            ),
+            'C'
+        ) || setweight(
+            to_tsvector(
+                'norwegian',
+                split_part(coalesce("ss"."creator", ''), '@', 1)
+            ),
+            'D'
+        ) || setweight(
+            to_tsvector(
+                'norwegian',
+                split_part(coalesce("ss"."group", ''), '@', 1)
+            ),
+            'D'
+        )
+    ) AS tsv_document,
+    '{}' AS "services",
+    NULL::text AS "pii",
+    "tkt"."product_area_id"
+FROM
+    "stories" ss
+    LEFT JOIN "tk_teams" "tkt" ON "ss"."team_id" = "tkt"."id";
+
+-- +goose Down
+DROP VIEW search;
+
+CREATE VIEW search AS (
+    SELECT
+        "dp"."id" AS "element_id",
+        'dataproduct' AS "element_type",
+        coalesce("dp"."description", '') AS "description",
+        "dpk"."aggregated_keywords" AS "keywords",
+        "dp"."group",
+        "dp"."team_id",
+        "dp"."created",
+        "dp"."last_modified",
+        (
+            setweight(to_tsvector('norwegian', "dp"."name"), 'A') || setweight(
+                to_tsvector('norwegian', coalesce("dp"."description", '')),
+                'B'
+            ) || setweight(
+                to_tsvector(
+                    'norwegian',
+                    split_part(coalesce("dp"."group", ''), '@', 1)
+                ),
+                'D'
+            )
+        ) AS tsv_document,
+        '{}' AS "services",
+        NULL::text AS "pii",
+        "tkt"."product_area_id"
+    FROM
+        "dataproducts" "dp"
+        LEFT JOIN "tk_teams" "tkt" ON "dp"."team_id" = "tkt"."id"
+        LEFT JOIN (
+            SELECT
+                "dk"."dataproduct_id",
+                coalesce(array_agg("flatterned_keywords_array"), '{}') as "aggregated_keywords"
+            FROM
+                (
+                    SELECT
+                        "dataproduct_id",
+                        unnest("keywords") as "flatterned_keywords_array"
+                    FROM
+                        "datasets"
+                ) as "dk"
+            GROUP BY
+                "dk"."dataproduct_id"
+        ) AS "dpk" on "dp"."id" = "dataproduct_id"
+    UNION
+    SELECT
+        "ds"."id" AS "element_id",
+        'dataset' AS "element_type",
+        coalesce("ds"."description", '') AS "description",
+        "ds"."keywords",
+        "dp"."group",
+        "dp"."team_id",
+        "ds"."created",
+        "ds"."last_modified",
+        (
+            setweight(to_tsvector('norwegian', "ds"."name"), 'A') || setweight(
+                to_tsvector('norwegian', coalesce("ds"."description", '')),
+                'B'
+            ) || setweight(
+                to_tsvector(
+                    'norwegian',
+                    coalesce(f_arr2text("ds"."keywords"), '')
+                ),
+                'C'
+            ) || setweight(
+                to_tsvector('norwegian', coalesce("ds"."repo", '')),
+                'D'
+            ) || setweight(
+                to_tsvector('norwegian', "ds"."type" :: text),
+                'D'
+            ) || setweight(
+                to_tsvector(
+                    'norwegian',
+                    split_part(coalesce("dp"."group", ''), '@', 1)
+                ),
+                'D'
+            )
+        ) AS tsv_document,
+        "tpm"."services",
+        "ds"."pii"::text AS "pii",
+        "tkt"."product_area_id"
+    FROM
+        "datasets" "ds"
+        JOIN "dataproducts" "dp" ON "ds"."dataproduct_id" = "dp"."id"
+        LEFT JOIN "third_party_mappings" "tpm" ON "tpm"."dataset_id" = "ds"."id"
+        LEFT JOIN "tk_teams" "tkt" ON "dp"."team_id" = "tkt"."id"
+)
+UNION
+SELECT
+    "ss"."id" AS "element_id",
+    'story' AS "element_type",
+    "ss"."description" AS "description",
+    "ss"."keywords" AS "keywords",
+    "ss"."group" AS "group",
+    "ss"."team_id",
+    "ss"."created",
+    "ss"."last_modified",
+    (
+        setweight(to_tsvector('norwegian', "ss"."name"), 'A') || setweight(
+            to_tsvector('norwegian', "ss"."description"),
+            'B'
+        ) || setweight(
+            to_tsvector(
+                'norwegian',
+                coalesce(f_arr2text("ss"."keywords"), '')
+            ),
+            'C'
+        ) || setweight(
+            to_tsvector(
+                'norwegian',
+                split_part(coalesce("ss"."creator", ''), '@', 1)
+            ),
+            'D'
+        ) || setweight(
+            to_tsvector(
+                'norwegian',
+                split_part(coalesce("ss"."group", ''), '@', 1)
+            ),
+            'D'
+        )
+    ) AS tsv_document,
+    '{}' AS "services",
+    NULL::text AS "pii",
+    "tkt"."product_area_id"
+FROM
+    "stories" ss
+    LEFT JOIN "tk_teams" "tkt" ON "ss"."team_id" = "tkt"."id";
+
+DROP INDEX tk_teams_name_trgm_idx;
+DROP INDEX stories_name_trgm_idx;
+DROP INDEX datasets_name_trgm_idx;
+DROP INDEX dataproducts_name_trgm_idx;
+
+DROP EXTENSION pg_trgm;
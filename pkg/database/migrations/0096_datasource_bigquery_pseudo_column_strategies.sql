@@ -0,0 +1,129 @@
+-- +goose Up
+DROP VIEW dataset_view;
+
+ALTER TABLE datasource_bigquery ADD COLUMN "pseudo_column_strategies" JSONB NOT NULL DEFAULT '[]';
+
+UPDATE datasource_bigquery SET "pseudo_column_strategies" = (
+    SELECT coalesce(jsonb_agg(jsonb_build_object('name', "column_name", 'strategy', 'salted_hash')), '[]')
+    FROM unnest("pseudo_columns") AS "column_name"
+)
+WHERE "pseudo_columns" IS NOT NULL;
+
+ALTER TABLE datasource_bigquery DROP COLUMN "pseudo_columns";
+ALTER TABLE datasource_bigquery RENAME COLUMN "pseudo_column_strategies" TO "pseudo_columns";
+
+CREATE VIEW dataset_view AS(
+    SELECT
+        ds.id as ds_id,
+        ds.name as ds_name,
+        ds.description as ds_description,
+        ds.created as ds_created,
+        ds.last_modified as ds_last_modified,
+        ds.slug as ds_slug,
+        ds.pii as pii,
+        ds.keywords as ds_keywords,
+        ds.repo as ds_repo,
+        dsrc.id AS bq_id,
+        dsrc.created as bq_created,
+        dsrc.last_modified as bq_last_modified,
+        dsrc.expires as bq_expires,
+        dsrc.description as bq_description,
+        dsrc.missing_since as bq_missing_since,
+        dsrc.pii_tags as pii_tags,
+        dsrc.project_id as bq_project,
+        dsrc.dataset as bq_dataset,
+        dsrc.table_name as bq_table_name,
+        dsrc.table_type as bq_table_type,
+        dsrc.pseudo_columns as pseudo_columns,
+        dsrc.schema as bq_schema,
+        ds.dataproduct_id as ds_dp_id,
+        dm.services as mapping_services,
+        da.id as access_id,
+        da.subject as access_subject,
+        da.owner as access_owner,
+        da.granter as access_granter,
+        da.expires as access_expires,
+        da.created as access_created,
+        da.revoked as access_revoked,
+        da.access_request_id as access_request_id,
+        mm.database_id as mb_database_id,
+        mm.deleted_at as mb_deleted_at
+    FROM
+        datasets ds
+        LEFT JOIN (
+            SELECT
+                *
+            FROM
+                datasource_bigquery
+            WHERE
+                is_reference = false
+        ) dsrc ON ds.id = dsrc.dataset_id
+        LEFT JOIN third_party_mappings dm ON ds.id = dm.dataset_id
+        LEFT JOIN dataset_access da ON ds.id = da.dataset_id
+        LEFT JOIN metabase_metadata mm ON ds.id = mm.dataset_id
+);
+
+-- +goose Down
+DROP VIEW dataset_view;
+
+ALTER TABLE datasource_bigquery ADD COLUMN "pseudo_column_names" TEXT[];
+
+UPDATE datasource_bigquery SET "pseudo_column_names" = ARRAY(
+    SELECT "column"->>'name'
+    FROM jsonb_array_elements("pseudo_columns") AS "column"
+)
+WHERE jsonb_array_length("pseudo_columns") > 0;
+
+ALTER TABLE datasource_bigquery DROP COLUMN "pseudo_columns";
+ALTER TABLE datasource_bigquery RENAME COLUMN "pseudo_column_names" TO "pseudo_columns";
+
+CREATE VIEW dataset_view AS(
+    SELECT
+        ds.id as ds_id,
+        ds.name as ds_name,
+        ds.description as ds_description,
+        ds.created as ds_created,
+        ds.last_modified as ds_last_modified,
+        ds.slug as ds_slug,
+        ds.pii as pii,
+        ds.keywords as ds_keywords,
+        ds.repo as ds_repo,
+        dsrc.id AS bq_id,
+        dsrc.created as bq_created,
+        dsrc.last_modified as bq_last_modified,
+        dsrc.expires as bq_expires,
+        dsrc.description as bq_description,
+        dsrc.missing_since as bq_missing_since,
+        dsrc.pii_tags as pii_tags,
+        dsrc.project_id as bq_project,
+        dsrc.dataset as bq_dataset,
+        dsrc.table_name as bq_table_name,
+        dsrc.table_type as bq_table_type,
+        dsrc.pseudo_columns as pseudo_columns,
+        dsrc.schema as bq_schema,
+        ds.dataproduct_id as ds_dp_id,
+        dm.services as mapping_services,
+        da.id as access_id,
+        da.subject as access_subject,
+        da.owner as access_owner,
+        da.granter as access_granter,
+        da.expires as access_expires,
+        da.created as access_created,
+        da.revoked as access_revoked,
+        da.access_request_id as access_request_id,
+        mm.database_id as mb_database_id,
+        mm.deleted_at as mb_deleted_at
+    FROM
+        datasets ds
+        LEFT JOIN (
+            SELECT
+                *
+            FROM
+                datasource_bigquery
+            WHERE
+                is_reference = false
+        ) dsrc ON ds.id = dsrc.dataset_id
+        LEFT JOIN third_party_mappings dm ON ds.id = dm.dataset_id
+        LEFT JOIN dataset_access da ON ds.id = da.dataset_id
+        LEFT JOIN metabase_metadata mm ON ds.id = mm.dataset_id
+);
@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE joinable_views_datasource ADD COLUMN "view_deleted" TIMESTAMPTZ;
+
+CREATE TABLE joinable_views_shares (
+    "joinable_view_id" uuid NOT NULL,
+    "group" TEXT NOT NULL,
+    "created" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("joinable_view_id", "group"),
+    CONSTRAINT fk_joinable_views_shares FOREIGN KEY (joinable_view_id) REFERENCES joinable_views (id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE joinable_views_shares;
+
+ALTER TABLE joinable_views_datasource DROP COLUMN "view_deleted";
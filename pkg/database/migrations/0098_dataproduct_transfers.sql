@@ -0,0 +1,29 @@
+-- +goose Up
+CREATE TYPE dataproduct_transfer_status AS ENUM ('pending', 'accepted', 'rejected', 'cancelled');
+
+CREATE TABLE dataproduct_transfers (
+    "id" uuid DEFAULT uuid_generate_v4() NOT NULL,
+    "dataproduct_id" uuid NOT NULL,
+    "from_group" TEXT NOT NULL,
+    "from_team_id" uuid,
+    "to_group" TEXT NOT NULL,
+    "to_team_id" uuid,
+    "to_teamkatalogen_url" TEXT,
+    "to_team_contact" TEXT,
+    "story_ids" uuid[] NOT NULL DEFAULT '{}',
+    "status" dataproduct_transfer_status NOT NULL DEFAULT 'pending',
+    "requested_by" TEXT NOT NULL,
+    "created" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "resolved_by" TEXT,
+    "resolved" TIMESTAMPTZ,
+    "reason" TEXT,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_dataproduct_transfers FOREIGN KEY (dataproduct_id) REFERENCES dataproducts (id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX dataproduct_transfers_pending_idx ON dataproduct_transfers (dataproduct_id) WHERE status = 'pending';
+
+-- +goose Down
+DROP TABLE dataproduct_transfers;
+
+DROP TYPE dataproduct_transfer_status;
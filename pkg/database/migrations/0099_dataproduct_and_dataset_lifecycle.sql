@@ -0,0 +1,221 @@
+-- +goose Up
+CREATE TYPE lifecycle_status AS ENUM ('active', 'deprecated', 'sunset_scheduled', 'retired');
+
+ALTER TABLE dataproducts
+    ADD COLUMN "lifecycle_status" lifecycle_status NOT NULL DEFAULT 'active',
+    ADD COLUMN "replaced_by" uuid REFERENCES dataproducts (id) ON DELETE SET NULL,
+    ADD COLUMN "sunset" TIMESTAMPTZ,
+    ADD COLUMN "deprecation_reason" TEXT;
+
+ALTER TABLE datasets
+    ADD COLUMN "lifecycle_status" lifecycle_status NOT NULL DEFAULT 'active',
+    ADD COLUMN "replaced_by" uuid REFERENCES datasets (id) ON DELETE SET NULL,
+    ADD COLUMN "sunset" TIMESTAMPTZ,
+    ADD COLUMN "deprecation_reason" TEXT;
+
+DROP VIEW dataset_view;
+DROP VIEW dataproduct_view;
+DROP VIEW dataproduct_with_teamkatalogen_view;
+
+CREATE VIEW dataset_view AS(
+    SELECT
+        ds.id as ds_id,
+        ds.name as ds_name,
+        ds.description as ds_description,
+        ds.created as ds_created,
+        ds.last_modified as ds_last_modified,
+        ds.slug as ds_slug,
+        ds.pii as pii,
+        ds.keywords as ds_keywords,
+        ds.repo as ds_repo,
+        ds.lifecycle_status as ds_lifecycle_status,
+        ds.replaced_by as ds_replaced_by,
+        ds.sunset as ds_sunset,
+        ds.deprecation_reason as ds_deprecation_reason,
+        dsrc.id AS bq_id,
+        dsrc.created as bq_created,
+        dsrc.last_modified as bq_last_modified,
+        dsrc.expires as bq_expires,
+        dsrc.description as bq_description,
+        dsrc.missing_since as bq_missing_since,
+        dsrc.pii_tags as pii_tags,
+        dsrc.project_id as bq_project,
+        dsrc.dataset as bq_dataset,
+        dsrc.table_name as bq_table_name,
+        dsrc.table_type as bq_table_type,
+        dsrc.pseudo_columns as pseudo_columns,
+        dsrc.schema as bq_schema,
+        ds.dataproduct_id as ds_dp_id,
+        dm.services as mapping_services,
+        da.id as access_id,
+        da.subject as access_subject,
+        da.owner as access_owner,
+        da.granter as access_granter,
+        da.expires as access_expires,
+        da.created as access_created,
+        da.revoked as access_revoked,
+        da.access_request_id as access_request_id,
+        mm.database_id as mb_database_id,
+        mm.deleted_at as mb_deleted_at
+    FROM
+        datasets ds
+        LEFT JOIN (
+            SELECT
+                *
+            FROM
+                datasource_bigquery
+            WHERE
+                is_reference = false
+        ) dsrc ON ds.id = dsrc.dataset_id
+        LEFT JOIN third_party_mappings dm ON ds.id = dm.dataset_id
+        LEFT JOIN dataset_access da ON ds.id = da.dataset_id
+        LEFT JOIN metabase_metadata mm ON ds.id = mm.dataset_id
+);
+
+CREATE VIEW dataproduct_with_teamkatalogen_view AS(
+SELECT dp.*, tkt.name as team_name, tkpa.name as pa_name, tkt.product_area_id as pa_id FROM dataproducts dp LEFT JOIN 
+	(tk_teams tkt LEFT JOIN tk_product_areas tkpa
+	ON tkt.product_area_id = tkpa.id)
+	ON dp.team_id = tkt.id
+);
+
+CREATE VIEW dataproduct_view AS(
+    SELECT
+        dp.id as dp_id,
+        dp.name as dp_name,
+        dp.description as dp_description,
+        dp.group as dp_group,
+        dp.created as dp_created,
+        dp.last_modified as dp_last_modified,
+        dp.slug as dp_slug,
+        dp.teamkatalogen_url as teamkatalogen_url,
+        dp.team_contact as team_contact,
+        dp.team_id as team_id,
+		dp.team_name as team_name,
+		dp.pa_name as pa_name,
+        dp.pa_id as pa_id,
+        dp.lifecycle_status as dp_lifecycle_status,
+        dp.replaced_by as dp_replaced_by,
+        dp.sunset as dp_sunset,
+        dp.deprecation_reason as dp_deprecation_reason,
+        ds.dataproduct_id as ds_dp_id,
+        ds.id as ds_id,
+        ds.name as ds_name,
+        ds.description as ds_description,
+        ds.created as ds_created,
+        ds.last_modified as ds_last_modified,
+        ds.slug as ds_slug,
+        ds.keywords as ds_keywords,
+        ds.lifecycle_status as ds_lifecycle_status,
+        ds.replaced_by as ds_replaced_by,
+        ds.sunset as ds_sunset,
+        ds.deprecation_reason as ds_deprecation_reason
+    FROM
+        dataproduct_with_teamkatalogen_view dp
+        LEFT JOIN datasets ds ON dp.id = ds.dataproduct_id
+);
+
+-- +goose Down
+DROP VIEW dataset_view;
+DROP VIEW dataproduct_view;
+DROP VIEW dataproduct_with_teamkatalogen_view;
+
+ALTER TABLE datasets
+    DROP COLUMN "lifecycle_status",
+    DROP COLUMN "replaced_by",
+    DROP COLUMN "sunset",
+    DROP COLUMN "deprecation_reason";
+
+ALTER TABLE dataproducts
+    DROP COLUMN "lifecycle_status",
+    DROP COLUMN "replaced_by",
+    DROP COLUMN "sunset",
+    DROP COLUMN "deprecation_reason";
+
+DROP TYPE lifecycle_status;
+
+CREATE VIEW dataset_view AS(
+    SELECT
+        ds.id as ds_id,
+        ds.name as ds_name,
+        ds.description as ds_description,
+        ds.created as ds_created,
+        ds.last_modified as ds_last_modified,
+        ds.slug as ds_slug,
+        ds.pii as pii,
+        ds.keywords as ds_keywords,
+        ds.repo as ds_repo,
+        dsrc.id AS bq_id,
+        dsrc.created as bq_created,
+        dsrc.last_modified as bq_last_modified,
+        dsrc.expires as bq_expires,
+        dsrc.description as bq_description,
+        dsrc.missing_since as bq_missing_since,
+        dsrc.pii_tags as pii_tags,
+        dsrc.project_id as bq_project,
+        dsrc.dataset as bq_dataset,
+        dsrc.table_name as bq_table_name,
+        dsrc.table_type as bq_table_type,
+        dsrc.pseudo_columns as pseudo_columns,
+        dsrc.schema as bq_schema,
+        ds.dataproduct_id as ds_dp_id,
+        dm.services as mapping_services,
+        da.id as access_id,
+        da.subject as access_subject,
+        da.owner as access_owner,
+        da.granter as access_granter,
+        da.expires as access_expires,
+        da.created as access_created,
+        da.revoked as access_revoked,
+        da.access_request_id as access_request_id,
+        mm.database_id as mb_database_id,
+        mm.deleted_at as mb_deleted_at
+    FROM
+        datasets ds
+        LEFT JOIN (
+            SELECT
+                *
+            FROM
+                datasource_bigquery
+            WHERE
+                is_reference = false
+        ) dsrc ON ds.id = dsrc.dataset_id
+        LEFT JOIN third_party_mappings dm ON ds.id = dm.dataset_id
+        LEFT JOIN dataset_access da ON ds.id = da.dataset_id
+        LEFT JOIN metabase_metadata mm ON ds.id = mm.dataset_id
+);
+
+CREATE VIEW dataproduct_with_teamkatalogen_view AS(
+SELECT dp.*, tkt.name as team_name, tkpa.name as pa_name, tkt.product_area_id as pa_id FROM dataproducts dp LEFT JOIN 
+	(tk_teams tkt LEFT JOIN tk_product_areas tkpa
+	ON tkt.product_area_id = tkpa.id)
+	ON dp.team_id = tkt.id
+);
+
+CREATE VIEW dataproduct_view AS(
+    SELECT
+        dp.id as dp_id,
+        dp.name as dp_name,
+        dp.description as dp_description,
+        dp.group as dp_group,
+        dp.created as dp_created,
+        dp.last_modified as dp_last_modified,
+        dp.slug as dp_slug,
+        dp.teamkatalogen_url as teamkatalogen_url,
+        dp.team_contact as team_contact,
+        dp.team_id as team_id,
+		dp.team_name as team_name,
+		dp.pa_name as pa_name,
+        dp.pa_id as pa_id,
+        ds.dataproduct_id as ds_dp_id,
+        ds.id as ds_id,
+        ds.name as ds_name,
+        ds.description as ds_description,
+        ds.created as ds_created,
+        ds.last_modified as ds_last_modified,
+        ds.slug as ds_slug,
+        ds.keywords as ds_keywords
+    FROM
+        dataproduct_with_teamkatalogen_view dp
+        LEFT JOIN datasets ds ON dp.id = ds.dataproduct_id
+);
@@ -0,0 +1,531 @@
+-- +goose Up
+ALTER TABLE dataproducts
+    ADD COLUMN "deleted" TIMESTAMPTZ,
+    ADD COLUMN "deleted_by" TEXT;
+
+ALTER TABLE stories
+    ADD COLUMN "deleted" TIMESTAMPTZ,
+    ADD COLUMN "deleted_by" TEXT;
+
+ALTER TABLE insight_product
+    ADD COLUMN "deleted" TIMESTAMPTZ,
+    ADD COLUMN "deleted_by" TEXT;
+
+DROP VIEW dataproduct_view;
+DROP VIEW dataproduct_with_teamkatalogen_view;
+DROP VIEW story_with_teamkatalogen_view;
+DROP VIEW insight_product_with_teamkatalogen_view;
+
+CREATE VIEW dataproduct_with_teamkatalogen_view AS(
+SELECT dp.*, tkt.name as team_name, tkpa.name as pa_name, tkt.product_area_id as pa_id FROM dataproducts dp LEFT JOIN 
+	(tk_teams tkt LEFT JOIN tk_product_areas tkpa
+	ON tkt.product_area_id = tkpa.id)
+	ON dp.team_id = tkt.id
+WHERE dp.deleted IS NULL
+);
+
+CREATE VIEW dataproduct_view AS(
+    SELECT
+        dp.id as dp_id,
+        dp.name as dp_name,
+        dp.description as dp_description,
+        dp.group as dp_group,
+        dp.created as dp_created,
+        dp.last_modified as dp_last_modified,
+        dp.slug as dp_slug,
+        dp.teamkatalogen_url as teamkatalogen_url,
+        dp.team_contact as team_contact,
+        dp.team_id as team_id,
+		dp.team_name as team_name,
+		dp.pa_name as pa_name,
+        dp.pa_id as pa_id,
+        dp.lifecycle_status as dp_lifecycle_status,
+        dp.replaced_by as dp_replaced_by,
+        dp.sunset as dp_sunset,
+        dp.deprecation_reason as dp_deprecation_reason,
+        ds.dataproduct_id as ds_dp_id,
+        ds.id as ds_id,
+        ds.name as ds_name,
+        ds.description as ds_description,
+        ds.created as ds_created,
+        ds.last_modified as ds_last_modified,
+        ds.slug as ds_slug,
+        ds.keywords as ds_keywords,
+        ds.lifecycle_status as ds_lifecycle_status,
+        ds.replaced_by as ds_replaced_by,
+        ds.sunset as ds_sunset,
+        ds.deprecation_reason as ds_deprecation_reason
+    FROM
+        dataproduct_with_teamkatalogen_view dp
+        LEFT JOIN datasets ds ON dp.id = ds.dataproduct_id
+);
+
+CREATE VIEW story_with_teamkatalogen_view AS(
+SELECT s.*, tkt.name as team_name, tkpa.name as pa_name FROM stories s LEFT JOIN 
+	(tk_teams tkt LEFT JOIN tk_product_areas tkpa
+	ON tkt.product_area_id = tkpa.id)
+	ON s.team_id = tkt.id
+WHERE s.deleted IS NULL
+);
+
+CREATE VIEW insight_product_with_teamkatalogen_view AS(
+SELECT isp.*, tkt.name as team_name, tkpa.name as pa_name FROM insight_product isp LEFT JOIN 
+	(tk_teams tkt LEFT JOIN tk_product_areas tkpa
+	ON tkt.product_area_id = tkpa.id)
+	ON isp.team_id = tkt.id
+WHERE isp.deleted IS NULL
+);
+
+CREATE OR REPLACE VIEW dataset_view AS(
+    SELECT
+        ds.id as ds_id,
+        ds.name as ds_name,
+        ds.description as ds_description,
+        ds.created as ds_created,
+        ds.last_modified as ds_last_modified,
+        ds.slug as ds_slug,
+        ds.pii as pii,
+        ds.keywords as ds_keywords,
+        ds.repo as ds_repo,
+        ds.lifecycle_status as ds_lifecycle_status,
+        ds.replaced_by as ds_replaced_by,
+        ds.sunset as ds_sunset,
+        ds.deprecation_reason as ds_deprecation_reason,
+        dsrc.id AS bq_id,
+        dsrc.created as bq_created,
+        dsrc.last_modified as bq_last_modified,
+        dsrc.expires as bq_expires,
+        dsrc.description as bq_description,
+        dsrc.missing_since as bq_missing_since,
+        dsrc.pii_tags as pii_tags,
+        dsrc.project_id as bq_project,
+        dsrc.dataset as bq_dataset,
+        dsrc.table_name as bq_table_name,
+        dsrc.table_type as bq_table_type,
+        dsrc.pseudo_columns as pseudo_columns,
+        dsrc.schema as bq_schema,
+        ds.dataproduct_id as ds_dp_id,
+        dm.services as mapping_services,
+        da.id as access_id,
+        da.subject as access_subject,
+        da.owner as access_owner,
+        da.granter as access_granter,
+        da.expires as access_expires,
+        da.created as access_created,
+        da.revoked as access_revoked,
+        da.access_request_id as access_request_id,
+        mm.database_id as mb_database_id,
+        mm.deleted_at as mb_deleted_at
+    FROM
+        datasets ds
+        LEFT JOIN (
+            SELECT
+                *
+            FROM
+                datasource_bigquery
+            WHERE
+                is_reference = false
+        ) dsrc ON ds.id = dsrc.dataset_id
+        LEFT JOIN third_party_mappings dm ON ds.id = dm.dataset_id
+        LEFT JOIN dataset_access da ON ds.id = da.dataset_id
+        LEFT JOIN metabase_metadata mm ON ds.id = mm.dataset_id
+
+    WHERE
+        ds.dataproduct_id NOT IN (SELECT id FROM dataproducts WHERE deleted IS NOT NULL)
+);
+
+CREATE OR REPLACE VIEW search AS (
+    SELECT
+        "dp"."id" AS "element_id",
+        'dataproduct' AS "element_type",
+        "dp"."name",
+        coalesce("dp"."description", '') AS "description",
+        "dpk"."aggregated_keywords" AS "keywords",
+        "dp"."group",
+        "dp"."team_id",
+        "dp"."created",
+        "dp"."last_modified",
+        (
+            setweight(to_tsvector('norwegian', "dp"."name"), 'A') || setweight(
+                to_tsvector('norwegian', coalesce("dp"."description", '')),
+                'B'
+            ) || setweight(
+                to_tsvector(
+                    'norwegian',
+                    split_part(coalesce("dp"."group", ''), '@', 1)
+                ),
+                'D'
+            )
+        ) AS tsv_document,
+        '{}' AS "services",
+        NULL::text AS "pii",
+        "tkt"."product_area_id"
+    FROM
+        "dataproducts" "dp"
+        LEFT JOIN "tk_teams" "tkt" ON "dp"."team_id" = "tkt"."id"
+        LEFT JOIN (
+            SELECT
+                "dk"."dataproduct_id",
+                coalesce(array_agg("flatterned_keywords_array"), '{}') as "aggregated_keywords"
+            FROM
+                (
+                    SELECT
+                        "dataproduct_id",
+                        unnest("keywords") as "flatterned_keywords_array"
+                    FROM
+                        "datasets"
+                ) as "dk"
+            GROUP BY
+                "dk"."dataproduct_id"
+        ) AS "dpk" on "dp"."id" = "dataproduct_id"
+    WHERE
+        "dp"."deleted" IS NULL
+    UNION
+    SELECT
+        "ds"."id" AS "element_id",
+        'dataset' AS "element_type",
+        "ds"."name",
+        coalesce("ds"."description", '') AS "description",
+        "ds"."keywords",
+        "dp"."group",
+        "dp"."team_id",
+        "ds"."created",
+        "ds"."last_modified",
+        (
+            setweight(to_tsvector('norwegian', "ds"."name"), 'A') || setweight(
+                to_tsvector('norwegian', coalesce("ds"."description", '')),
+                'B'
+            ) || setweight(
+                to_tsvector(
+                    'norwegian',
+                    coalesce(f_arr2text("ds"."keywords"), '')
+                ),
+                'C'
+            ) || setweight(
+                to_tsvector('norwegian', coalesce("ds"."repo", '')),
+                'D'
+            ) || setweight(
+                to_tsvector('norwegian', "ds"."type" :: text),
+                'D'
+            ) || setweight(
+                to_tsvector(
+                    'norwegian',
+                    split_part(coalesce("dp"."group", ''), '@', 1)
+                ),
+                'D'
+            )
+        ) AS tsv_document,
+        "tpm"."services",
+        "ds"."pii"::text AS "pii",
+        "tkt"."product_area_id"
+    FROM
+        "datasets" "ds"
+        JOIN "dataproducts" "dp" ON "ds"."dataproduct_id" = "dp"."id"
+        LEFT JOIN "third_party_mappings" "tpm" ON "tpm"."dataset_id" = "ds"."id"
+        LEFT JOIN "tk_teams" "tkt" ON "dp"."team_id" = "tkt"."id"
+    WHERE
+        "dp"."deleted" IS NULL
+)
+UNION
+SELECT
+    "ss"."id" AS "element_id",
+    'story' AS "element_type",
+    "ss"."name",
+    "ss"."description" AS "description",
+    "ss"."keywords" AS "keywords",
+    "ss"."group" AS "group",
+    "ss"."team_id",
+    "ss"."created",
+    "ss"."last_modified",
+    (
+        setweight(to_tsvector('norwegian', "ss"."name"), 'A') || setweight(
+            to_tsvector('norwegian', "ss"."description"),
+            'B'
+        ) || setweight(
+            to_tsvector(
+                'norwegian',
+                coalesce(f_arr2text("ss"."keywords"), '')
+            ),
+            'C'
+        ) || setweight(
+            to_tsvector(
+                'norwegian',
+                split_part(coalesce("ss"."creator", ''), '@', 1)
+            ),
+            'D'
+        ) || setweight(
+            to_tsvector(
+                'norwegian',
+                split_part(coalesce("ss"."group", ''), '@', 1)
+            ),
+            'D'
+        )
+    ) AS tsv_document,
+    '{}' AS "services",
+    NULL::text AS "pii",
+    "tkt"."product_area_id"
+FROM
+    "stories" ss
+    LEFT JOIN "tk_teams" "tkt" ON "ss"."team_id" = "tkt"."id"
+WHERE
+    "ss"."deleted" IS NULL;
+
+-- +goose Down
+DROP VIEW dataproduct_view;
+DROP VIEW dataproduct_with_teamkatalogen_view;
+DROP VIEW story_with_teamkatalogen_view;
+DROP VIEW insight_product_with_teamkatalogen_view;
+
+CREATE OR REPLACE VIEW search AS (
+    SELECT
+        "dp"."id" AS "element_id",
+        'dataproduct' AS "element_type",
+        "dp"."name",
+        coalesce("dp"."description", '') AS "description",
+        "dpk"."aggregated_keywords" AS "keywords",
+        "dp"."group",
+        "dp"."team_id",
+        "dp"."created",
+        "dp"."last_modified",
+        (
+            setweight(to_tsvector('norwegian', "dp"."name"), 'A') || setweight(
+                to_tsvector('norwegian', coalesce("dp"."description", '')),
+                'B'
+            ) || setweight(
+                to_tsvector(
+                    'norwegian',
+                    split_part(coalesce("dp"."group", ''), '@', 1)
+                ),
+                'D'
+            )
+        ) AS tsv_document,
+        '{}' AS "services",
+        NULL::text AS "pii",
+        "tkt"."product_area_id"
+    FROM
+        "dataproducts" "dp"
+        LEFT JOIN "tk_teams" "tkt" ON "dp"."team_id" = "tkt"."id"
+        LEFT JOIN (
+            SELECT
+                "dk"."dataproduct_id",
+                coalesce(array_agg("flatterned_keywords_array"), '{}') as "aggregated_keywords"
+            FROM
+                (
+                    SELECT
+                        "dataproduct_id",
+                        unnest("keywords") as "flatterned_keywords_array"
+                    FROM
+                        "datasets"
+                ) as "dk"
+            GROUP BY
+                "dk"."dataproduct_id"
+        ) AS "dpk" on "dp"."id" = "dataproduct_id"
+    UNION
+    SELECT
+        "ds"."id" AS "element_id",
+        'dataset' AS "element_type",
+        "ds"."name",
+        coalesce("ds"."description", '') AS "description",
+        "ds"."keywords",
+        "dp"."group",
+        "dp"."team_id",
+        "ds"."created",
+        "ds"."last_modified",
+        (
+            setweight(to_tsvector('norwegian', "ds"."name"), 'A') || setweight(
+                to_tsvector('norwegian', coalesce("ds"."description", '')),
+                'B'
+            ) || setweight(
+                to_tsvector(
+                    'norwegian',
+                    coalesce(f_arr2text("ds"."keywords"), '')
+                ),
+                'C'
+            ) || setweight(
+                to_tsvector('norwegian', coalesce("ds"."repo", '')),
+                'D'
+            ) || setweight(
+                to_tsvector('norwegian', "ds"."type" :: text),
+                'D'
+            ) || setweight(
+                to_tsvector(
+                    'norwegian',
+                    split_part(coalesce("dp"."group", ''), '@', 1)
+                ),
+                'D'
+            )
+        ) AS tsv_document,
+        "tpm"."services",
+        "ds"."pii"::text AS "pii",
+        "tkt"."product_area_id"
+    FROM
+        "datasets" "ds"
+        JOIN "dataproducts" "dp" ON "ds"."dataproduct_id" = "dp"."id"
+        LEFT JOIN "third_party_mappings" "tpm" ON "tpm"."dataset_id" = "ds"."id"
+        LEFT JOIN "tk_teams" "tkt" ON "dp"."team_id" = "tkt"."id"
+)
+UNION
+SELECT
+    "ss"."id" AS "element_id",
+    'story' AS "element_type",
+    "ss"."name",
+    "ss"."description" AS "description",
+    "ss"."keywords" AS "keywords",
+    "ss"."group" AS "group",
+    "ss"."team_id",
+    "ss"."created",
+    "ss"."last_modified",
+    (
+        setweight(to_tsvector('norwegian', "ss"."name"), 'A') || setweight(
+            to_tsvector('norwegian', "ss"."description"),
+            'B'
+        ) || setweight(
+            to_tsvector(
+                'norwegian',
+                coalesce(f_arr2text("ss"."keywords"), '')
+            ),
+            'C'
+        ) || setweight(
+            to_tsvector(
+                'norwegian',
+                split_part(coalesce("ss"."creator", ''), '@', 1)
+            ),
+            'D'
+        ) || setweight(
+            to_tsvector(
+                'norwegian',
+                split_part(coalesce("ss"."group", ''), '@', 1)
+            ),
+            'D'
+        )
+    ) AS tsv_document,
+    '{}' AS "services",
+    NULL::text AS "pii",
+    "tkt"."product_area_id"
+FROM
+    "stories" ss
+    LEFT JOIN "tk_teams" "tkt" ON "ss"."team_id" = "tkt"."id";
+
+CREATE OR REPLACE VIEW dataset_view AS(
+    SELECT
+        ds.id as ds_id,
+        ds.name as ds_name,
+        ds.description as ds_description,
+        ds.created as ds_created,
+        ds.last_modified as ds_last_modified,
+        ds.slug as ds_slug,
+        ds.pii as pii,
+        ds.keywords as ds_keywords,
+        ds.repo as ds_repo,
+        ds.lifecycle_status as ds_lifecycle_status,
+        ds.replaced_by as ds_replaced_by,
+        ds.sunset as ds_sunset,
+        ds.deprecation_reason as ds_deprecation_reason,
+        dsrc.id AS bq_id,
+        dsrc.created as bq_created,
+        dsrc.last_modified as bq_last_modified,
+        dsrc.expires as bq_expires,
+        dsrc.description as bq_description,
+        dsrc.missing_since as bq_missing_since,
+        dsrc.pii_tags as pii_tags,
+        dsrc.project_id as bq_project,
+        dsrc.dataset as bq_dataset,
+        dsrc.table_name as bq_table_name,
+        dsrc.table_type as bq_table_type,
+        dsrc.pseudo_columns as pseudo_columns,
+        dsrc.schema as bq_schema,
+        ds.dataproduct_id as ds_dp_id,
+        dm.services as mapping_services,
+        da.id as access_id,
+        da.subject as access_subject,
+        da.owner as access_owner,
+        da.granter as access_granter,
+        da.expires as access_expires,
+        da.created as access_created,
+        da.revoked as access_revoked,
+        da.access_request_id as access_request_id,
+        mm.database_id as mb_database_id,
+        mm.deleted_at as mb_deleted_at
+    FROM
+        datasets ds
+        LEFT JOIN (
+            SELECT
+                *
+            FROM
+                datasource_bigquery
+            WHERE
+                is_reference = false
+        ) dsrc ON ds.id = dsrc.dataset_id
+        LEFT JOIN third_party_mappings dm ON ds.id = dm.dataset_id
+        LEFT JOIN dataset_access da ON ds.id = da.dataset_id
+        LEFT JOIN metabase_metadata mm ON ds.id = mm.dataset_id
+);
+
+ALTER TABLE dataproducts
+    DROP COLUMN "deleted",
+    DROP COLUMN "deleted_by";
+
+ALTER TABLE stories
+    DROP COLUMN "deleted",
+    DROP COLUMN "deleted_by";
+
+ALTER TABLE insight_product
+    DROP COLUMN "deleted",
+    DROP COLUMN "deleted_by";
+
+CREATE VIEW dataproduct_with_teamkatalogen_view AS(
+SELECT dp.*, tkt.name as team_name, tkpa.name as pa_name, tkt.product_area_id as pa_id FROM dataproducts dp LEFT JOIN 
+	(tk_teams tkt LEFT JOIN tk_product_areas tkpa
+	ON tkt.product_area_id = tkpa.id)
+	ON dp.team_id = tkt.id
+);
+
+CREATE VIEW dataproduct_view AS(
+    SELECT
+        dp.id as dp_id,
+        dp.name as dp_name,
+        dp.description as dp_description,
+        dp.group as dp_group,
+        dp.created as dp_created,
+        dp.last_modified as dp_last_modified,
+        dp.slug as dp_slug,
+        dp.teamkatalogen_url as teamkatalogen_url,
+        dp.team_contact as team_contact,
+        dp.team_id as team_id,
+		dp.team_name as team_name,
+		dp.pa_name as pa_name,
+        dp.pa_id as pa_id,
+        dp.lifecycle_status as dp_lifecycle_status,
+        dp.replaced_by as dp_replaced_by,
+        dp.sunset as dp_sunset,
+        dp.deprecation_reason as dp_deprecation_reason,
+        ds.dataproduct_id as ds_dp_id,
+        ds.id as ds_id,
+        ds.name as ds_name,
+        ds.description as ds_description,
+        ds.created as ds_created,
+        ds.last_modified as ds_last_modified,
+        ds.slug as ds_slug,
+        ds.keywords as ds_keywords,
+        ds.lifecycle_status as ds_lifecycle_status,
+        ds.replaced_by as ds_replaced_by,
+        ds.sunset as ds_sunset,
+        ds.deprecation_reason as ds_deprecation_reason
+    FROM
+        dataproduct_with_teamkatalogen_view dp
+        LEFT JOIN datasets ds ON dp.id = ds.dataproduct_id
+);
+
+CREATE VIEW story_with_teamkatalogen_view AS(
+SELECT s.*, tkt.name as team_name, tkpa.name as pa_name FROM stories s LEFT JOIN 
+	(tk_teams tkt LEFT JOIN tk_product_areas tkpa
+	ON tkt.product_area_id = tkpa.id)
+	ON s.team_id = tkt.id
+);
+
+CREATE VIEW insight_product_with_teamkatalogen_view AS(
+SELECT isp.*, tkt.name as team_name, tkpa.name as pa_name FROM insight_product isp LEFT JOIN 
+	(tk_teams tkt LEFT JOIN tk_product_areas tkpa
+	ON tkt.product_area_id = tkpa.id)
+	ON isp.team_id = tkt.id
+);
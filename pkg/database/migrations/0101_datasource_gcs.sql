@@ -0,0 +1,22 @@
+-- +goose Up
+ALTER TYPE datasource_type ADD VALUE 'gcs';
+
+CREATE TABLE datasource_gcs (
+    "id"            UUID        NOT NULL DEFAULT uuid_generate_v4(),
+    "dataset_id"    UUID        NOT NULL,
+    "project_id"    TEXT        NOT NULL,
+    "bucket"        TEXT        NOT NULL,
+    "prefix"        TEXT        NOT NULL DEFAULT '',
+    "description"   TEXT        NOT NULL DEFAULT '',
+    "schema"        JSONB,
+    "created"       TIMESTAMPTZ NOT NULL,
+    "last_modified" TIMESTAMPTZ NOT NULL,
+    "missing_since" TIMESTAMPTZ,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_gcs_dataset FOREIGN KEY (dataset_id)
+        REFERENCES datasets (id) ON DELETE CASCADE,
+    UNIQUE (dataset_id)
+);
+
+-- +goose Down
+DROP TABLE datasource_gcs;
@@ -0,0 +1,136 @@
+-- +goose Up
+ALTER TABLE datasource_bigquery
+    ADD COLUMN "scope" TEXT NOT NULL DEFAULT 'table',
+    ADD COLUMN "table_pattern" TEXT NOT NULL DEFAULT '',
+    ADD COLUMN "tables" JSONB;
+
+-- The datasource columns are coalesced, so datasets backed by other
+-- datasource types than BigQuery can be read through the view.
+DROP VIEW dataset_view;
+
+CREATE VIEW dataset_view AS(
+    SELECT
+        ds.id as ds_id,
+        ds.name as ds_name,
+        ds.description as ds_description,
+        ds.created as ds_created,
+        ds.last_modified as ds_last_modified,
+        ds.slug as ds_slug,
+        ds.pii as pii,
+        ds.keywords as ds_keywords,
+        ds.repo as ds_repo,
+        ds.lifecycle_status as ds_lifecycle_status,
+        ds.replaced_by as ds_replaced_by,
+        ds.sunset as ds_sunset,
+        ds.deprecation_reason as ds_deprecation_reason,
+        dsrc.id AS bq_id,
+        COALESCE(dsrc.created, ds.created) as bq_created,
+        COALESCE(dsrc.last_modified, ds.last_modified) as bq_last_modified,
+        dsrc.expires as bq_expires,
+        dsrc.description as bq_description,
+        dsrc.missing_since as bq_missing_since,
+        dsrc.pii_tags as pii_tags,
+        COALESCE(dsrc.project_id, '') as bq_project,
+        COALESCE(dsrc.dataset, '') as bq_dataset,
+        COALESCE(dsrc.table_name, '') as bq_table_name,
+        COALESCE(dsrc.table_type, '') as bq_table_type,
+        dsrc.pseudo_columns as pseudo_columns,
+        dsrc.schema as bq_schema,
+        ds.dataproduct_id as ds_dp_id,
+        dm.services as mapping_services,
+        da.id as access_id,
+        da.subject as access_subject,
+        da.owner as access_owner,
+        da.granter as access_granter,
+        da.expires as access_expires,
+        da.created as access_created,
+        da.revoked as access_revoked,
+        da.access_request_id as access_request_id,
+        mm.database_id as mb_database_id,
+        mm.deleted_at as mb_deleted_at,
+        COALESCE(dsrc.scope, '') as bq_scope,
+        COALESCE(dsrc.table_pattern, '') as bq_table_pattern,
+        dsrc.tables as bq_tables
+    FROM
+        datasets ds
+        LEFT JOIN (
+            SELECT
+                *
+            FROM
+                datasource_bigquery
+            WHERE
+                is_reference = false
+        ) dsrc ON ds.id = dsrc.dataset_id
+        LEFT JOIN third_party_mappings dm ON ds.id = dm.dataset_id
+        LEFT JOIN dataset_access da ON ds.id = da.dataset_id
+        LEFT JOIN metabase_metadata mm ON ds.id = mm.dataset_id
+
+    WHERE
+        ds.dataproduct_id NOT IN (SELECT id FROM dataproducts WHERE deleted IS NOT NULL)
+);
+
+-- +goose Down
+DROP VIEW dataset_view;
+
+CREATE VIEW dataset_view AS(
+    SELECT
+        ds.id as ds_id,
+        ds.name as ds_name,
+        ds.description as ds_description,
+        ds.created as ds_created,
+        ds.last_modified as ds_last_modified,
+        ds.slug as ds_slug,
+        ds.pii as pii,
+        ds.keywords as ds_keywords,
+        ds.repo as ds_repo,
+        ds.lifecycle_status as ds_lifecycle_status,
+        ds.replaced_by as ds_replaced_by,
+        ds.sunset as ds_sunset,
+        ds.deprecation_reason as ds_deprecation_reason,
+        dsrc.id AS bq_id,
+        dsrc.created as bq_created,
+        dsrc.last_modified as bq_last_modified,
+        dsrc.expires as bq_expires,
+        dsrc.description as bq_description,
+        dsrc.missing_since as bq_missing_since,
+        dsrc.pii_tags as pii_tags,
+        dsrc.project_id as bq_project,
+        dsrc.dataset as bq_dataset,
+        dsrc.table_name as bq_table_name,
+        dsrc.table_type as bq_table_type,
+        dsrc.pseudo_columns as pseudo_columns,
+        dsrc.schema as bq_schema,
+        ds.dataproduct_id as ds_dp_id,
+        dm.services as mapping_services,
+        da.id as access_id,
+        da.subject as access_subject,
+        da.owner as access_owner,
+        da.granter as access_granter,
+        da.expires as access_expires,
+        da.created as access_created,
+        da.revoked as access_revoked,
+        da.access_request_id as access_request_id,
+        mm.database_id as mb_database_id,
+        mm.deleted_at as mb_deleted_at
+    FROM
+        datasets ds
+        LEFT JOIN (
+            SELECT
+                *
+            FROM
+                datasource_bigquery
+            WHERE
+                is_reference = false
+        ) dsrc ON ds.id = dsrc.dataset_id
+        LEFT JOIN third_party_mappings dm ON ds.id = dm.dataset_id
+        LEFT JOIN dataset_access da ON ds.id = da.dataset_id
+        LEFT JOIN metabase_metadata mm ON ds.id = mm.dataset_id
+
+    WHERE
+        ds.dataproduct_id NOT IN (SELECT id FROM dataproducts WHERE deleted IS NOT NULL)
+);
+
+ALTER TABLE datasource_bigquery
+    DROP COLUMN "scope",
+    DROP COLUMN "table_pattern",
+    DROP COLUMN "tables";
@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE dataset_column_descriptions (
+    "dataset_id" UUID NOT NULL REFERENCES datasets(id) ON DELETE CASCADE,
+    "column_name" TEXT NOT NULL,
+    "description" TEXT NOT NULL,
+    "source" TEXT NOT NULL,
+    "updated_by" TEXT NOT NULL,
+    "updated" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("dataset_id", "column_name")
+);
+
+-- +goose Down
+DROP TABLE dataset_column_descriptions;
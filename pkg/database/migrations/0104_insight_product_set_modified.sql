@@ -0,0 +1,9 @@
+-- +goose Up
+CREATE TRIGGER insight_product_set_modified
+    BEFORE UPDATE
+    ON insight_product
+    FOR EACH ROW
+EXECUTE PROCEDURE update_modified_timestamp();
+
+-- +goose Down
+DROP TRIGGER insight_product_set_modified ON insight_product;
@@ -0,0 +1,43 @@
+-- +goose Up
+CREATE TYPE notification_channel AS ENUM ('slack', 'email', 'inApp');
+
+CREATE TABLE notifications (
+    "id" uuid DEFAULT uuid_generate_v4() NOT NULL,
+    "recipient" TEXT NOT NULL,
+    "event_type" TEXT NOT NULL,
+    "title" TEXT NOT NULL,
+    "message" TEXT NOT NULL,
+    "link" TEXT,
+    "reference_id" uuid,
+    "created" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "digest_channel" notification_channel,
+    "digest_sent" TIMESTAMPTZ,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX notifications_recipient_idx ON notifications (recipient, created);
+CREATE INDEX notifications_digest_pending_idx ON notifications (recipient) WHERE digest_channel IS NOT NULL AND digest_sent IS NULL;
+CREATE INDEX notifications_reference_idx ON notifications (event_type, reference_id);
+
+CREATE TABLE notification_reads (
+    "notification_id" uuid NOT NULL,
+    "email" TEXT NOT NULL,
+    "read_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (notification_id, email),
+    CONSTRAINT fk_notification_reads FOREIGN KEY (notification_id) REFERENCES notifications (id) ON DELETE CASCADE
+);
+
+CREATE TABLE notification_preferences (
+    "email" TEXT NOT NULL,
+    "event_type" TEXT NOT NULL,
+    "channel" notification_channel NOT NULL,
+    "digest" BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (email, event_type)
+);
+
+-- +goose Down
+DROP TABLE notification_preferences;
+DROP TABLE notification_reads;
+DROP TABLE notifications;
+
+DROP TYPE notification_channel;
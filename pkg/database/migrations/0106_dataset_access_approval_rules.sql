@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE dataset_access_approval_rules (
+    "id" uuid DEFAULT uuid_generate_v4() NOT NULL,
+    "dataset_id" uuid NOT NULL,
+    "subject_type" TEXT,
+    "subject_group" TEXT,
+    "pii" pii_level,
+    "max_expiry_days" INTEGER,
+    "require_polly" BOOLEAN NOT NULL DEFAULT FALSE,
+    "created_by" TEXT NOT NULL,
+    "created" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "deleted" TIMESTAMPTZ,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_dataset_access_approval_rules_dataset FOREIGN KEY (dataset_id) REFERENCES datasets (id) ON DELETE CASCADE
+);
+
+CREATE INDEX dataset_access_approval_rules_dataset_idx ON dataset_access_approval_rules (dataset_id) WHERE deleted IS NULL;
+
+ALTER TABLE dataset_access_requests ADD COLUMN "approval_rule_id" uuid;
+ALTER TABLE dataset_access_requests ADD CONSTRAINT fk_dataset_access_requests_approval_rule FOREIGN KEY (approval_rule_id) REFERENCES dataset_access_approval_rules (id);
+
+-- +goose Down
+ALTER TABLE dataset_access_requests DROP COLUMN "approval_rule_id";
+DROP TABLE dataset_access_approval_rules;
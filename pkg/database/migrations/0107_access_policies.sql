@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE access_policies (
+    "id" uuid DEFAULT uuid_generate_v4() NOT NULL,
+    "dataset_id" uuid,
+    "name" TEXT NOT NULL,
+    "condition" TEXT NOT NULL,
+    "reason" TEXT NOT NULL,
+    "created_by" TEXT NOT NULL,
+    "created" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "deleted" TIMESTAMPTZ,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_access_policies_dataset FOREIGN KEY (dataset_id) REFERENCES datasets (id) ON DELETE CASCADE
+);
+
+CREATE INDEX access_policies_dataset_idx ON access_policies (dataset_id) WHERE deleted IS NULL;
+
+-- +goose Down
+DROP TABLE access_policies;
@@ -0,0 +1,41 @@
+-- +goose Up
+CREATE TYPE recertification_decision AS ENUM ('confirmed', 'shortened', 'revoked', 'revoked_at_deadline', 'unconfirmed');
+
+CREATE TABLE recertification_campaigns (
+    "id" uuid DEFAULT uuid_generate_v4() NOT NULL,
+    "name" TEXT NOT NULL,
+    "deadline" TIMESTAMPTZ NOT NULL,
+    "revoke_unconfirmed" BOOLEAN NOT NULL DEFAULT FALSE,
+    "created_by" TEXT NOT NULL,
+    "created" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "last_reminded" TIMESTAMPTZ,
+    "completed" TIMESTAMPTZ,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE recertification_reviews (
+    "id" uuid DEFAULT uuid_generate_v4() NOT NULL,
+    "campaign_id" uuid NOT NULL,
+    "dataset_id" uuid NOT NULL,
+    "access_id" uuid NOT NULL,
+    "subject" TEXT NOT NULL,
+    "owner" TEXT NOT NULL,
+    "granter" TEXT NOT NULL,
+    "expires" TIMESTAMPTZ,
+    "decision" recertification_decision,
+    "new_expires" TIMESTAMPTZ,
+    "reviewed_by" TEXT,
+    "reviewed" TIMESTAMPTZ,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_recertification_reviews_campaign FOREIGN KEY (campaign_id) REFERENCES recertification_campaigns (id) ON DELETE CASCADE,
+    CONSTRAINT fk_recertification_reviews_dataset FOREIGN KEY (dataset_id) REFERENCES datasets (id) ON DELETE CASCADE,
+    CONSTRAINT fk_recertification_reviews_access FOREIGN KEY (access_id) REFERENCES dataset_access (id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX recertification_reviews_campaign_access_idx ON recertification_reviews (campaign_id, access_id);
+
+-- +goose Down
+DROP TABLE recertification_reviews;
+DROP TABLE recertification_campaigns;
+
+DROP TYPE recertification_decision;
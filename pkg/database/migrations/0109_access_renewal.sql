@@ -0,0 +1,26 @@
+-- +goose Up
+ALTER TABLE dataset_access_requests ADD COLUMN "renews_access_id" uuid;
+ALTER TABLE dataset_access_requests ADD CONSTRAINT fk_dataset_access_requests_renews_access FOREIGN KEY (renews_access_id) REFERENCES dataset_access (id) ON DELETE SET NULL;
+
+CREATE INDEX dataset_access_requests_renews_access_idx ON dataset_access_requests (renews_access_id) WHERE status = 'pending';
+
+CREATE TABLE dataset_access_expiry_reminders (
+    "access_id" uuid NOT NULL,
+    "expires" TIMESTAMPTZ NOT NULL,
+    "days_before" INTEGER NOT NULL,
+    "sent" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (access_id, expires, days_before),
+    CONSTRAINT fk_dataset_access_expiry_reminders_access FOREIGN KEY (access_id) REFERENCES dataset_access (id) ON DELETE CASCADE
+);
+
+-- Accesses that have been notified of their expiry before count as reminded
+-- a week ahead
+INSERT INTO dataset_access_expiry_reminders (access_id, expires, days_before)
+SELECT DISTINCT da.id, da.expires, 7
+FROM notifications n
+JOIN dataset_access da ON da.id = n.reference_id
+WHERE n.event_type = 'access_expiring' AND da.expires IS NOT NULL;
+
+-- +goose Down
+DROP TABLE dataset_access_expiry_reminders;
+ALTER TABLE dataset_access_requests DROP COLUMN "renews_access_id";
@@ -0,0 +1,33 @@
+-- +goose Up
+CREATE TYPE polly_purpose_status AS ENUM ('valid', 'expired', 'withdrawn');
+
+CREATE TABLE polly_purposes
+(
+    "external_id"  TEXT                 NOT NULL,
+    "name"         TEXT                 NOT NULL,
+    "status"       polly_purpose_status NOT NULL,
+    "valid_from"   TIMESTAMPTZ,
+    "valid_to"     TIMESTAMPTZ,
+    "last_checked" TIMESTAMPTZ          NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (external_id)
+);
+
+CREATE TABLE polly_purpose_history
+(
+    "id"          uuid                          DEFAULT uuid_generate_v4(),
+    "external_id" TEXT                 NOT NULL,
+    "name"        TEXT                 NOT NULL,
+    "status"      polly_purpose_status NOT NULL,
+    "valid_from"  TIMESTAMPTZ,
+    "valid_to"    TIMESTAMPTZ,
+    "recorded"    TIMESTAMPTZ          NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX polly_purpose_history_external_id_idx ON polly_purpose_history (external_id, recorded DESC);
+
+-- +goose Down
+DROP INDEX polly_purpose_history_external_id_idx;
+DROP TABLE polly_purpose_history;
+DROP TABLE polly_purposes;
+DROP TYPE polly_purpose_status;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE dataset_preview_settings
+(
+    "dataset_id" uuid        NOT NULL,
+    "enabled"    BOOLEAN     NOT NULL DEFAULT FALSE,
+    "updated_by" TEXT        NOT NULL,
+    "updated"    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (dataset_id),
+    CONSTRAINT fk_dataset_preview_settings_dataset
+        FOREIGN KEY (dataset_id)
+            REFERENCES datasets (id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE dataset_preview_settings;
@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE dataset_profiles
+(
+    "id"              uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    "dataset_id"      uuid        NOT NULL,
+    "project_id"      TEXT        NOT NULL,
+    "dataset"         TEXT        NOT NULL,
+    "table_name"      TEXT        NOT NULL,
+    "row_count"       BIGINT      NOT NULL,
+    "bytes_processed" BIGINT      NOT NULL,
+    "columns"         JSONB       NOT NULL,
+    "created"         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id),
+    CONSTRAINT fk_dataset_profiles_dataset
+        FOREIGN KEY (dataset_id)
+            REFERENCES datasets (id) ON DELETE CASCADE
+);
+
+CREATE INDEX dataset_profiles_dataset_id_idx ON dataset_profiles (dataset_id, created DESC);
+CREATE INDEX dataset_profiles_table_idx ON dataset_profiles (project_id, dataset, table_name, created DESC);
+
+-- +goose Down
+DROP INDEX dataset_profiles_table_idx;
+DROP INDEX dataset_profiles_dataset_id_idx;
+DROP TABLE dataset_profiles;
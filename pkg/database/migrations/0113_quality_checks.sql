@@ -0,0 +1,355 @@
+-- +goose Up
+CREATE TYPE quality_check_kind AS ENUM ('sql', 'not_null', 'unique', 'row_count');
+CREATE TYPE quality_check_status AS ENUM ('passed', 'failed', 'error');
+
+CREATE TABLE quality_checks
+(
+    "id"          uuid               NOT NULL DEFAULT uuid_generate_v4(),
+    "dataset_id"  uuid               NOT NULL,
+    "name"        TEXT               NOT NULL,
+    "description" TEXT,
+    "kind"        quality_check_kind NOT NULL,
+    "column_name" TEXT,
+    "condition"   TEXT,
+    "sql_query"   TEXT,
+    "threshold"   BIGINT,
+    "created_by"  TEXT               NOT NULL,
+    "created"     TIMESTAMPTZ        NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id),
+    UNIQUE (dataset_id, name),
+    CONSTRAINT fk_quality_checks_dataset
+        FOREIGN KEY (dataset_id)
+            REFERENCES datasets (id) ON DELETE CASCADE
+);
+
+CREATE TABLE quality_check_runs
+(
+    "id"              uuid                 NOT NULL DEFAULT uuid_generate_v4(),
+    "check_id"        uuid                 NOT NULL,
+    "status"          quality_check_status NOT NULL,
+    "measured"        BIGINT,
+    "error"           TEXT,
+    "bytes_processed" BIGINT               NOT NULL DEFAULT 0,
+    "started"         TIMESTAMPTZ          NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id),
+    CONSTRAINT fk_quality_check_runs_check
+        FOREIGN KEY (check_id)
+            REFERENCES quality_checks (id) ON DELETE CASCADE
+);
+
+CREATE INDEX quality_check_runs_check_id_idx ON quality_check_runs (check_id, started DESC);
+
+CREATE VIEW quality_check_latest_runs AS (
+    SELECT DISTINCT ON ("check_id")
+        *
+    FROM
+        "quality_check_runs"
+    ORDER BY
+        "check_id",
+        "started" DESC
+);
+
+CREATE VIEW dataset_quality_status AS (
+    SELECT
+        "qc"."dataset_id",
+        (
+            CASE
+                WHEN bool_or("lr"."status" = 'failed') THEN 'failed'
+                WHEN bool_or("lr"."status" = 'error') THEN 'error'
+                ELSE 'passed'
+            END
+        )::quality_check_status AS "status",
+        max("lr"."started")::timestamptz AS "last_run"
+    FROM
+        "quality_checks" "qc"
+        JOIN "quality_check_latest_runs" "lr" ON "lr"."check_id" = "qc"."id"
+    GROUP BY
+        "qc"."dataset_id"
+);
+
+CREATE OR REPLACE VIEW search AS (
+    SELECT
+        "dp"."id" AS "element_id",
+        'dataproduct' AS "element_type",
+        "dp"."name",
+        coalesce("dp"."description", '') AS "description",
+        "dpk"."aggregated_keywords" AS "keywords",
+        "dp"."group",
+        "dp"."team_id",
+        "dp"."created",
+        "dp"."last_modified",
+        (
+            setweight(to_tsvector('norwegian', "dp"."name"), 'A') || setweight(
+                to_tsvector('norwegian', coalesce("dp"."description", '')),
+                'B'
+            ) || setweight(
+                to_tsvector(
+                    'norwegian',
+                    split_part(coalesce("dp"."group", ''), '@', 1)
+                ),
+                'D'
+            )
+        ) AS tsv_document,
+        '{}' AS "services",
+        NULL::text AS "pii",
+        "tkt"."product_area_id",
+        NULL::text AS "quality_status"
+    FROM
+        "dataproducts" "dp"
+        LEFT JOIN "tk_teams" "tkt" ON "dp"."team_id" = "tkt"."id"
+        LEFT JOIN (
+            SELECT
+                "dk"."dataproduct_id",
+                coalesce(array_agg("flatterned_keywords_array"), '{}') as "aggregated_keywords"
+            FROM
+                (
+                    SELECT
+                        "dataproduct_id",
+                        unnest("keywords") as "flatterned_keywords_array"
+                    FROM
+                        "datasets"
+                ) as "dk"
+            GROUP BY
+                "dk"."dataproduct_id"
+        ) AS "dpk" on "dp"."id" = "dataproduct_id"
+    WHERE
+        "dp"."deleted" IS NULL
+    UNION
+    SELECT
+        "ds"."id" AS "element_id",
+        'dataset' AS "element_type",
+        "ds"."name",
+        coalesce("ds"."description", '') AS "description",
+        "ds"."keywords",
+        "dp"."group",
+        "dp"."team_id",
+        "ds"."created",
+        "ds"."last_modified",
+        (
+            setweight(to_tsvector('norwegian', "ds"."name"), 'A') || setweight(
+                to_tsvector('norwegian', coalesce("ds"."description", '')),
+                'B'
+            ) || setweight(
+                to_tsvector(
+                    'norwegian',
+                    coalesce(f_arr2text("ds"."keywords"), '')
+                ),
+                'C'
+            ) || setweight(
+                to_tsvector('norwegian', coalesce("ds"."repo", '')),
+                'D'
+            ) || setweight(
+                to_tsvector('norwegian', "ds"."type" :: text),
+                'D'
+            ) || setweight(
+                to_tsvector(
+                    'norwegian',
+                    split_part(coalesce("dp"."group", ''), '@', 1)
+                ),
+                'D'
+            )
+        ) AS tsv_document,
+        "tpm"."services",
+        "ds"."pii"::text AS "pii",
+        "tkt"."product_area_id",
+        "dqs"."status"::text AS "quality_status"
+    FROM
+        "datasets" "ds"
+        JOIN "dataproducts" "dp" ON "ds"."dataproduct_id" = "dp"."id"
+        LEFT JOIN "third_party_mappings" "tpm" ON "tpm"."dataset_id" = "ds"."id"
+        LEFT JOIN "tk_teams" "tkt" ON "dp"."team_id" = "tkt"."id"
+        LEFT JOIN "dataset_quality_status" "dqs" ON "dqs"."dataset_id" = "ds"."id"
+    WHERE
+        "dp"."deleted" IS NULL
+)
+UNION
+SELECT
+    "ss"."id" AS "element_id",
+    'story' AS "element_type",
+    "ss"."name",
+    "ss"."description" AS "description",
+    "ss"."keywords" AS "keywords",
+    "ss"."group" AS "group",
+    "ss"."team_id",
+    "ss"."created",
+    "ss"."last_modified",
+    (
+        setweight(to_tsvector('norwegian', "ss"."name"), 'A') || setweight(
+            to_tsvector('norwegian', "ss"."description"),
+            'B'
+        ) || setweight(
+            to_tsvector(
+                'norwegian',
+                coalesce(f_arr2text("ss"."keywords"), '')
+            ),
+            'C'
+        ) || setweight(
+            to_tsvector(
+                'norwegian',
+                split_part(coalesce("ss"."creator", ''), '@', 1)
+            ),
+            'D'
+        ) || setweight(
+            to_tsvector(
+                'norwegian',
+                split_part(coalesce("ss"."group", ''), '@', 1)
+            ),
+            'D'
+        )
+    ) AS tsv_document,
+    '{}' AS "services",
+    NULL::text AS "pii",
+    "tkt"."product_area_id",
+    NULL::text AS "quality_status"
+FROM
+    "stories" ss
+    LEFT JOIN "tk_teams" "tkt" ON "ss"."team_id" = "tkt"."id"
+WHERE
+    "ss"."deleted" IS NULL;
+
+-- +goose Down
+DROP VIEW search;
+
+CREATE VIEW search AS (
+    SELECT
+        "dp"."id" AS "element_id",
+        'dataproduct' AS "element_type",
+        "dp"."name",
+        coalesce("dp"."description", '') AS "description",
+        "dpk"."aggregated_keywords" AS "keywords",
+        "dp"."group",
+        "dp"."team_id",
+        "dp"."created",
+        "dp"."last_modified",
+        (
+            setweight(to_tsvector('norwegian', "dp"."name"), 'A') || setweight(
+                to_tsvector('norwegian', coalesce("dp"."description", '')),
+                'B'
+            ) || setweight(
+                to_tsvector(
+                    'norwegian',
+                    split_part(coalesce("dp"."group", ''), '@', 1)
+                ),
+                'D'
+            )
+        ) AS tsv_document,
+        '{}' AS "services",
+        NULL::text AS "pii",
+        "tkt"."product_area_id"
+    FROM
+        "dataproducts" "dp"
+        LEFT JOIN "tk_teams" "tkt" ON "dp"."team_id" = "tkt"."id"
+        LEFT JOIN (
+            SELECT
+                "dk"."dataproduct_id",
+                coalesce(array_agg("flatterned_keywords_array"), '{}') as "aggregated_keywords"
+            FROM
+                (
+                    SELECT
+                        "dataproduct_id",
+                        unnest("keywords") as "flatterned_keywords_array"
+                    FROM
+                        "datasets"
+                ) as "dk"
+            GROUP BY
+                "dk"."dataproduct_id"
+        ) AS "dpk" on "dp"."id" = "dataproduct_id"
+    WHERE
+        "dp"."deleted" IS NULL
+    UNION
+    SELECT
+        "ds"."id" AS "element_id",
+        'dataset' AS "element_type",
+        "ds"."name",
+        coalesce("ds"."description", '') AS "description",
+        "ds"."keywords",
+        "dp"."group",
+        "dp"."team_id",
+        "ds"."created",
+        "ds"."last_modified",
+        (
+            setweight(to_tsvector('norwegian', "ds"."name"), 'A') || setweight(
+                to_tsvector('norwegian', coalesce("ds"."description", '')),
+                'B'
+            ) || setweight(
+                to_tsvector(
+                    'norwegian',
+                    coalesce(f_arr2text("ds"."keywords"), '')
+                ),
+                'C'
+            ) || setweight(
+                to_tsvector('norwegian', coalesce("ds"."repo", '')),
+                'D'
+            ) || setweight(
+                to_tsvector('norwegian', "ds"."type" :: text),
+                'D'
+            ) || setweight(
+                to_tsvector(
+                    'norwegian',
+                    split_part(coalesce("dp"."group", ''), '@', 1)
+                ),
+                'D'
+            )
+        ) AS tsv_document,
+        "tpm"."services",
+        "ds"."pii"::text AS "pii",
+        "tkt"."product_area_id"
+    FROM
+        "datasets" "ds"
+        JOIN "dataproducts" "dp" ON "ds"."dataproduct_id" = "dp"."id"
+        LEFT JOIN "third_party_mappings" "tpm" ON "tpm"."dataset_id" = "ds"."id"
+        LEFT JOIN "tk_teams" "tkt" ON "dp"."team_id" = "tkt"."id"
+    WHERE
+        "dp"."deleted" IS NULL
+)
+UNION
+SELECT
+    "ss"."id" AS "element_id",
+    'story' AS "element_type",
+    "ss"."name",
+    "ss"."description" AS "description",
+    "ss"."keywords" AS "keywords",
+    "ss"."group" AS "group",
+    "ss"."team_id",
+    "ss"."created",
+    "ss"."last_modified",
+    (
+        setweight(to_tsvector('norwegian', "ss"."name"), 'A') || setweight(
+            to_tsvector('norwegian', "ss"."description"),
+            'B'
+        ) || setweight(
+            to_tsvector(
+                'norwegian',
+                coalesce(f_arr2text("ss"."keywords"), '')
+            ),
+            'C'
+        ) || setweight(
+            to_tsvector(
+                'norwegian',
+                split_part(coalesce("ss"."creator", ''), '@', 1)
+            ),
+            'D'
+        ) || setweight(
+            to_tsvector(
+                'norwegian',
+                split_part(coalesce("ss"."group", ''), '@', 1)
+            ),
+            'D'
+        )
+    ) AS tsv_document,
+    '{}' AS "services",
+    NULL::text AS "pii",
+    "tkt"."product_area_id"
+FROM
+    "stories" ss
+    LEFT JOIN "tk_teams" "tkt" ON "ss"."team_id" = "tkt"."id"
+WHERE
+    "ss"."deleted" IS NULL;
+
+DROP VIEW dataset_quality_status;
+DROP VIEW quality_check_latest_runs;
+DROP INDEX quality_check_runs_check_id_idx;
+DROP TABLE quality_check_runs;
+DROP TABLE quality_checks;
+DROP TYPE quality_check_status;
+DROP TYPE quality_check_kind;
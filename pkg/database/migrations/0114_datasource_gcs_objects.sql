@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE datasource_gcs RENAME COLUMN "schema" TO "objects";
+
+-- +goose Down
+ALTER TABLE datasource_gcs RENAME COLUMN "objects" TO "schema";
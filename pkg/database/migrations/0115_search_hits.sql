@@ -0,0 +1,83 @@
+-- +goose Up
+-- The filtering of the search is shared by the queries for the hits, the
+-- total count and the facets. The parameters are prefixed, since the
+-- columns of the view take precedence over parameters with the same name.
+-- +goose StatementBegin
+CREATE FUNCTION search_hits(
+    p_query text,
+    p_fuzzy boolean,
+    p_types text[],
+    p_keyword text[],
+    p_grp text[],
+    p_team_id uuid[],
+    p_product_area_id uuid[],
+    p_pii text[],
+    p_service text[],
+    p_quality_status text[]
+) RETURNS SETOF search LANGUAGE sql STABLE AS $$
+    SELECT
+        "search".*
+    FROM
+        search,
+        websearch_to_tsquery('norwegian', p_query) query
+    WHERE
+        (
+            CASE
+                WHEN array_length(p_types, 1) > 0 THEN "element_type" = ANY(p_types)
+                ELSE TRUE
+            END
+        )
+        AND (
+            CASE
+                WHEN array_length(p_keyword, 1) > 0 THEN "keywords" && p_keyword
+                ELSE TRUE
+            END
+        )
+        AND (
+            CASE
+                WHEN p_query = '' THEN TRUE
+                WHEN p_fuzzy THEN p_query <% "name" OR p_query <% f_arr2text("keywords")
+                ELSE "tsv_document" @@ query
+            END
+        )
+        AND (
+            CASE
+                WHEN array_length(p_grp, 1) > 0 THEN "group" = ANY(p_grp)
+                ELSE TRUE
+            END
+        )
+        AND (
+            CASE
+                WHEN array_length(p_team_id, 1) > 0 THEN "team_id" = ANY(p_team_id)
+                ELSE TRUE
+            END
+        )
+        AND (
+            CASE
+                WHEN array_length(p_product_area_id, 1) > 0 THEN "product_area_id" = ANY(p_product_area_id)
+                ELSE TRUE
+            END
+        )
+        AND (
+            CASE
+                WHEN array_length(p_pii, 1) > 0 THEN "pii" = ANY(p_pii)
+                ELSE TRUE
+            END
+        )
+        AND (
+            CASE
+                WHEN array_length(p_service, 1) > 0 THEN "services" && p_service
+                ELSE TRUE
+            END
+        )
+        AND (
+            CASE
+                WHEN array_length(p_quality_status, 1) > 0 THEN "quality_status" = ANY(p_quality_status)
+                ELSE TRUE
+            END
+        )
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP FUNCTION search_hits;
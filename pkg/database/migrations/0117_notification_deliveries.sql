@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE notification_deliveries (
+    "id" uuid DEFAULT uuid_generate_v4() NOT NULL,
+    "kind" TEXT NOT NULL,
+    "recipient" TEXT NOT NULL,
+    "title" TEXT NOT NULL,
+    "text" TEXT NOT NULL,
+    "reference_id" uuid,
+    "created" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "attempts" INT NOT NULL DEFAULT 0,
+    "last_attempt" TIMESTAMPTZ,
+    "last_error" TEXT,
+    "delivered" TIMESTAMPTZ,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX notification_deliveries_pending_idx ON notification_deliveries (created) WHERE delivered IS NULL;
+
+-- +goose Down
+DROP TABLE notification_deliveries;
@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE INDEX dataproducts_name_id_idx ON dataproducts ("name", id) WHERE deleted IS NULL;
+CREATE INDEX dataproducts_created_id_idx ON dataproducts (created, id) WHERE deleted IS NULL;
+CREATE INDEX datasets_name_id_idx ON datasets ("name", id);
+CREATE INDEX datasets_created_id_idx ON datasets (created, id);
+CREATE INDEX stories_name_id_idx ON stories ("name", id) WHERE deleted IS NULL;
+CREATE INDEX stories_created_id_idx ON stories (created, id) WHERE deleted IS NULL;
+CREATE INDEX insight_product_name_id_idx ON insight_product ("name", id) WHERE deleted IS NULL;
+CREATE INDEX insight_product_created_id_idx ON insight_product (created, id) WHERE deleted IS NULL;
+CREATE INDEX dataset_access_requests_created_id_idx ON dataset_access_requests (created, id);
+CREATE INDEX notifications_created_id_idx ON notifications (created, id);
+
+-- +goose Down
+DROP INDEX notifications_created_id_idx;
+DROP INDEX dataset_access_requests_created_id_idx;
+DROP INDEX insight_product_created_id_idx;
+DROP INDEX insight_product_name_id_idx;
+DROP INDEX stories_created_id_idx;
+DROP INDEX stories_name_id_idx;
+DROP INDEX datasets_created_id_idx;
+DROP INDEX datasets_name_id_idx;
+DROP INDEX dataproducts_created_id_idx;
+DROP INDEX dataproducts_name_id_idx;
@@ -0,0 +1,36 @@
+-- name: CreateAccessPolicy :one
+INSERT INTO access_policies (
+    dataset_id,
+    "name",
+    "condition",
+    reason,
+    created_by
+) VALUES (
+    @dataset_id,
+    @name,
+    @condition,
+    @reason,
+    @created_by
+) RETURNING *;
+
+-- name: GetAccessPolicy :one
+SELECT *
+FROM access_policies
+WHERE id = @id AND deleted IS NULL;
+
+-- name: GetOrganisationAccessPolicies :many
+SELECT *
+FROM access_policies
+WHERE dataset_id IS NULL AND deleted IS NULL
+ORDER BY created;
+
+-- name: GetAccessPoliciesForDataset :many
+SELECT *
+FROM access_policies
+WHERE dataset_id = @dataset_id AND deleted IS NULL
+ORDER BY created;
+
+-- name: DeleteAccessPolicy :exec
+UPDATE access_policies
+SET deleted = NOW()
+WHERE id = @id;
@@ -0,0 +1,40 @@
+-- name: GetCatalogueExportRows :many
+SELECT
+    dp.id AS dp_id,
+    dp.name AS dp_name,
+    dp.description AS dp_description,
+    dp.group AS dp_group,
+    dp.created AS dp_created,
+    dp.last_modified AS dp_last_modified,
+    dp.slug AS dp_slug,
+    dp.teamkatalogen_url,
+    dp.team_contact,
+    dp.team_name,
+    ds.id AS ds_id,
+    ds.name AS ds_name,
+    ds.description AS ds_description,
+    ds.created AS ds_created,
+    ds.last_modified AS ds_last_modified,
+    ds.slug AS ds_slug,
+    ds.keywords AS ds_keywords,
+    ds.pii AS ds_pii,
+    ds.type AS ds_type,
+    ds.lifecycle_status AS ds_lifecycle_status
+FROM
+    dataproduct_with_teamkatalogen_view dp
+    LEFT JOIN datasets ds ON ds.dataproduct_id = dp.id
+WHERE
+    sqlc.narg('modified_since')::timestamptz IS NULL
+    OR dp.id IN (
+        SELECT
+            mdp.id
+        FROM
+            dataproducts mdp
+            LEFT JOIN datasets mds ON mds.dataproduct_id = mdp.id
+        WHERE
+            mdp.last_modified >= sqlc.narg('modified_since')
+            OR mds.last_modified >= sqlc.narg('modified_since')
+    )
+ORDER BY
+    dp.name,
+    ds.name;
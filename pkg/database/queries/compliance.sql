@@ -0,0 +1,59 @@
+-- name: GetPersonalDataInventory :many
+SELECT
+    ds.id AS dataset_id,
+    ds.name AS dataset_name,
+    ds.pii,
+    ds.anonymisation_description,
+    dp.id AS dataproduct_id,
+    dp.name AS dataproduct_name,
+    dp."group" AS owner_group,
+    dp.team_contact,
+    dp.team_id,
+    tkt.name AS team_name,
+    tkt.product_area_id,
+    tkpa.name AS product_area_name,
+    bq.pii_tags,
+    bq.pseudo_columns,
+    mbm.permission_group_id AS metabase_permission_group_id,
+    (mbm.dataset_id IS NOT NULL AND mbm.deleted_at IS NULL)::bool AS in_metabase
+FROM datasets ds
+JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+LEFT JOIN tk_teams tkt ON dp.team_id = tkt.id
+LEFT JOIN tk_product_areas tkpa ON tkt.product_area_id = tkpa.id
+LEFT JOIN datasource_bigquery bq ON bq.dataset_id = ds.id AND NOT bq.is_reference
+LEFT JOIN metabase_metadata mbm ON mbm.dataset_id = ds.id
+WHERE dp.deleted IS NULL
+AND (
+    ds.pii != 'none'
+    OR jsonb_array_length(COALESCE(bq.pseudo_columns, '[]'::jsonb)) > 0
+    -- Datasets marked as without personal data, but with columns tagged as personal data
+    OR EXISTS (
+        SELECT 1 FROM jsonb_each_text(CASE WHEN jsonb_typeof(bq.pii_tags) = 'object' THEN bq.pii_tags ELSE '{}'::jsonb END) tags
+        WHERE tags.value != 'PII_IngenPersonopplysning'
+    )
+)
+AND (sqlc.narg('product_area_id')::uuid IS NULL OR tkt.product_area_id = sqlc.narg('product_area_id'))
+AND (sqlc.narg('team_id')::uuid IS NULL OR dp.team_id = sqlc.narg('team_id'))
+ORDER BY tkpa.name, tkt.name, dp.name, ds.name;
+
+-- name: ListActiveAccessWithPurposeForDatasets :many
+SELECT
+    da.id AS access_id,
+    da.dataset_id,
+    da.subject,
+    da.owner,
+    da.granter,
+    da.created,
+    da.expires,
+    pd.external_id AS purpose_external_id,
+    pd.name AS purpose_name,
+    pd.url AS purpose_url,
+    pp.status AS purpose_status
+FROM dataset_access da
+LEFT JOIN dataset_access_requests dar ON da.access_request_id = dar.id
+LEFT JOIN polly_documentation pd ON dar.polly_documentation_id = pd.id
+LEFT JOIN polly_purposes pp ON pp.external_id = pd.external_id
+WHERE da.dataset_id = ANY (@dataset_ids::uuid[])
+AND da.revoked IS NULL
+AND (da.expires IS NULL OR da.expires > NOW())
+ORDER BY da.dataset_id, da.subject;
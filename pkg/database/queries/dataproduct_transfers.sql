@@ -0,0 +1,126 @@
+-- name: CreateDataproductTransfer :one
+INSERT INTO dataproduct_transfers (
+    "dataproduct_id",
+    "from_group",
+    "from_team_id",
+    "to_group",
+    "to_team_id",
+    "to_teamkatalogen_url",
+    "to_team_contact",
+    "story_ids",
+    "requested_by"
+) VALUES (
+    @dataproduct_id,
+    @from_group,
+    @from_team_id,
+    @to_group,
+    @to_team_id,
+    @to_teamkatalogen_url,
+    @to_team_contact,
+    @story_ids::uuid[],
+    @requested_by
+)
+RETURNING *;
+
+-- name: GetDataproductTransfer :one
+SELECT
+    *
+FROM
+    dataproduct_transfers
+WHERE
+    id = @id;
+
+-- name: GetDataproductTransfersForGroups :many
+SELECT
+    *
+FROM
+    dataproduct_transfers
+WHERE
+    from_group = ANY(@groups::text[])
+    OR to_group = ANY(@groups::text[])
+ORDER BY
+    created DESC;
+
+-- name: GetDataproductTransfersForDataproduct :many
+SELECT
+    *
+FROM
+    dataproduct_transfers
+WHERE
+    dataproduct_id = @dataproduct_id
+ORDER BY
+    created DESC;
+
+-- name: ResolveDataproductTransfer :one
+UPDATE
+    dataproduct_transfers
+SET
+    status = @status,
+    resolved_by = @resolved_by,
+    resolved = NOW(),
+    reason = @reason
+WHERE
+    id = @id
+    AND status = 'pending'
+RETURNING *;
+
+-- name: TransferDataproductOwner :exec
+UPDATE
+    dataproducts
+SET
+    "group" = @to_group,
+    team_id = @to_team_id,
+    teamkatalogen_url = @to_teamkatalogen_url,
+    team_contact = @to_team_contact
+WHERE
+    id = @id;
+
+-- name: TransferStoriesOwner :exec
+UPDATE
+    stories
+SET
+    "group" = @to_group,
+    team_id = @to_team_id,
+    teamkatalogen_url = @to_teamkatalogen_url
+WHERE
+    id = ANY(@ids::uuid[])
+    AND "group" = @from_group;
+
+-- name: TransferDatasetAccessOwner :exec
+UPDATE
+    dataset_access
+SET
+    "owner" = CASE
+        WHEN "owner" = 'group:' || @from_group::text THEN 'group:' || @to_group::text
+        ELSE @to_group::text
+    END
+WHERE
+    dataset_id IN (SELECT id FROM datasets WHERE dataproduct_id = @dataproduct_id)
+    AND "owner" IN (@from_group::text, 'group:' || @from_group::text)
+    AND revoked IS NULL;
+
+-- name: GetActiveDatasetAccessForDataproductSubject :many
+SELECT
+    *
+FROM
+    dataset_access
+WHERE
+    dataset_id IN (SELECT id FROM datasets WHERE dataproduct_id = @dataproduct_id)
+    AND LOWER("subject") = LOWER(@subject::text)
+    AND revoked IS NULL
+    AND (expires IS NULL OR expires >= NOW())
+ORDER BY
+    created;
+
+-- name: TransferDatasetAccessRequestsOwner :exec
+UPDATE
+    dataset_access_requests
+SET
+    "owner" = CASE
+        WHEN "owner" = 'group:' || @from_group::text THEN 'group:' || @to_group::text
+        ELSE @to_group::text
+    END
+WHERE
+    dataset_id IN (SELECT id FROM datasets WHERE dataproduct_id = @dataproduct_id)
+    AND "owner" IN (@from_group::text, 'group:' || @from_group::text)
+    AND status = 'pending';
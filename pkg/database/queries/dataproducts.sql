@@ -1,24 +1,25 @@
 -- name: GetDataproduct :one
 SELECT *
 FROM dataproducts
-WHERE id = @id;
+WHERE id = @id AND deleted IS NULL;
 
 -- name: GetDataproducts :many
 SELECT *
 FROM dataproducts
+WHERE deleted IS NULL
 ORDER BY last_modified DESC
 LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: GetDataproductsByIDs :many
 SELECT *
 FROM dataproducts
-WHERE id = ANY (@ids::uuid[])
+WHERE id = ANY (@ids::uuid[]) AND deleted IS NULL
 ORDER BY last_modified DESC;
 
 -- name: GetDataproductsByGroups :many
 SELECT *
 FROM dataproducts
-WHERE "group" = ANY (@groups::text[])
+WHERE "group" = ANY (@groups::text[]) AND deleted IS NULL
 ORDER BY last_modified DESC;
 
 -- name: GetDataproductsByProductArea :many
@@ -30,7 +31,7 @@ ORDER BY created DESC;
 -- name: GetDataproductsByTeam :many
 SELECT *
 FROM dataproducts
-WHERE team_id = @team_id
+WHERE team_id = @team_id AND deleted IS NULL
 ORDER BY created DESC;
 
 -- name: DeleteDataproduct :exec
@@ -64,6 +65,7 @@ SET "name"              = @name,
     "team_contact"      = @team_contact,
     "team_id"           = @team_id
 WHERE id = @id
+AND (sqlc.narg('if_match')::timestamptz IS NULL OR last_modified = sqlc.narg('if_match'))
 RETURNING *;
 
 
@@ -73,6 +75,7 @@ FROM (
 	SELECT unnest(ds.keywords) as keyword
 	FROM dataproducts dp
     INNER JOIN datasets ds ON ds.dataproduct_id = dp.id
+	WHERE dp.deleted IS NULL
 ) keywords
 WHERE true
 AND CASE WHEN coalesce(TRIM(@keyword), '') = '' THEN true ELSE keyword ILIKE @keyword::text || '%' END
@@ -84,6 +87,89 @@ LIMIT 15;
 SELECT "group",
        count(1) as "count"
 FROM "dataproducts"
+WHERE "deleted" IS NULL
 GROUP BY "group"
 ORDER BY "count" DESC
 LIMIT @lim OFFSET @offs;
+
+-- name: GetDataproductsByGroupsPage :many
+(
+    SELECT sqlc.embed(dp)
+    FROM dataproduct_with_teamkatalogen_view dp
+    WHERE @sort::text = 'name' AND NOT @descending::bool
+    AND dp."group" = ANY(@groups::text[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (dp.name, dp.id) > (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY dp.name ASC, dp.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(dp)
+    FROM dataproduct_with_teamkatalogen_view dp
+    WHERE @sort::text = 'name' AND @descending::bool
+    AND dp."group" = ANY(@groups::text[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (dp.name, dp.id) < (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY dp.name DESC, dp.id DESC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(dp)
+    FROM dataproduct_with_teamkatalogen_view dp
+    WHERE @sort::text = 'created' AND NOT @descending::bool
+    AND dp."group" = ANY(@groups::text[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (dp.created, dp.id) > (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY dp.created ASC, dp.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(dp)
+    FROM dataproduct_with_teamkatalogen_view dp
+    WHERE @sort::text = 'created' AND @descending::bool
+    AND dp."group" = ANY(@groups::text[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (dp.created, dp.id) < (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY dp.created DESC, dp.id DESC
+    LIMIT @lim::int
+);
+
+-- name: GetDataproductsByProductAreaPage :many
+(
+    SELECT sqlc.embed(dp)
+    FROM dataproduct_with_teamkatalogen_view dp
+    WHERE @sort::text = 'name' AND NOT @descending::bool
+    AND dp.team_id = ANY(@team_id::uuid[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (dp.name, dp.id) > (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY dp.name ASC, dp.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(dp)
+    FROM dataproduct_with_teamkatalogen_view dp
+    WHERE @sort::text = 'name' AND @descending::bool
+    AND dp.team_id = ANY(@team_id::uuid[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (dp.name, dp.id) < (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY dp.name DESC, dp.id DESC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(dp)
+    FROM dataproduct_with_teamkatalogen_view dp
+    WHERE @sort::text = 'created' AND NOT @descending::bool
+    AND dp.team_id = ANY(@team_id::uuid[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (dp.created, dp.id) > (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY dp.created ASC, dp.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(dp)
+    FROM dataproduct_with_teamkatalogen_view dp
+    WHERE @sort::text = 'created' AND @descending::bool
+    AND dp.team_id = ANY(@team_id::uuid[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (dp.created, dp.id) < (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY dp.created DESC, dp.id DESC
+    LIMIT @lim::int
+);
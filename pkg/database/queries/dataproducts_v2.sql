@@ -29,4 +29,4 @@ SELECT DISTINCT unnest(keywords)::text FROM datasets ds WHERE ds.dataproduct_id
 -- name: GetDataproductsNumberByTeam :one
 SELECT COUNT(*) as "count"
 FROM dataproducts
-WHERE team_id = @team_id;
\ No newline at end of file
+WHERE team_id = @team_id AND deleted IS NULL;
\ No newline at end of file
@@ -18,6 +18,11 @@ UPDATE dataset_access
 SET revoked = NOW()
 WHERE id = @id;
 
+-- name: UpdateAccessExpires :exec
+UPDATE dataset_access
+SET expires = @expires
+WHERE id = @id;
+
 -- name: ListUnrevokedExpiredAccessEntries :many
 SELECT *
 FROM dataset_access
@@ -39,6 +44,21 @@ SELECT *
 FROM dataset_access
 WHERE dataset_id = @dataset_id AND revoked IS NULL AND (expires IS NULL OR expires >= NOW());
 
+-- name: ListActiveAccessToTablePatternDatasources :many
+SELECT da.*
+FROM dataset_access da
+JOIN datasource_bigquery dsb ON dsb.dataset_id = da.dataset_id AND dsb.is_reference = FALSE
+JOIN datasets ds ON ds.id = da.dataset_id
+JOIN dataproducts dp ON dp.id = ds.dataproduct_id
+WHERE dsb.scope = 'dataset'
+AND dsb.table_pattern != ''
+AND dsb.deleted IS NULL
+AND ds.lifecycle_status != 'retired'
+AND dp.deleted IS NULL
+AND da.revoked IS NULL
+AND (da.expires IS NULL OR da.expires >= NOW())
+ORDER BY da.dataset_id;
+
 -- name: GetActiveAccessToDatasetForSubject :one
 SELECT *
 FROM dataset_access
@@ -49,3 +69,25 @@ AND (
   expires IS NULL 
   OR expires >= NOW()
 );
+
+-- name: ListExpiringAccessForGroups :many
+SELECT
+    da.id AS access_id,
+    da.subject,
+    da.owner,
+    da.expires::timestamptz AS expires,
+    ds.id AS dataset_id,
+    ds.name AS dataset_name,
+    dp.id AS dataproduct_id,
+    dp.name AS dataproduct_name,
+    dar.id AS renewal_request_id
+FROM dataset_access da
+JOIN datasets ds ON da.dataset_id = ds.id
+JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+LEFT JOIN dataset_access_requests dar ON dar.renews_access_id = da.id AND dar.status = 'pending'
+WHERE dp."group" = ANY (@groups::text[])
+AND dp.deleted IS NULL
+AND da.revoked IS NULL
+AND da.expires > NOW()
+AND da.expires <= NOW() + make_interval(days => @days::int)
+ORDER BY da.expires;
@@ -0,0 +1,39 @@
+-- name: CreateAccessApprovalRule :one
+INSERT INTO dataset_access_approval_rules (
+    dataset_id,
+    subject_type,
+    subject_group,
+    pii,
+    max_expiry_days,
+    require_polly,
+    created_by
+) VALUES (
+    @dataset_id,
+    @subject_type,
+    @subject_group,
+    @pii,
+    @max_expiry_days,
+    @require_polly,
+    @created_by
+) RETURNING *;
+
+-- name: GetAccessApprovalRule :one
+SELECT *
+FROM dataset_access_approval_rules
+WHERE id = @id AND deleted IS NULL;
+
+-- name: GetAccessApprovalRulesForDataset :many
+SELECT *
+FROM dataset_access_approval_rules
+WHERE dataset_id = @dataset_id AND deleted IS NULL
+ORDER BY created;
+
+-- name: DeleteAccessApprovalRule :exec
+UPDATE dataset_access_approval_rules
+SET deleted = NOW()
+WHERE id = @id;
+
+-- name: SetAccessRequestApprovalRule :exec
+UPDATE dataset_access_requests
+SET approval_rule_id = @approval_rule_id
+WHERE id = @id;
@@ -11,17 +11,25 @@ VALUES (@dataset_id,
         @polly_documentation_id)
 RETURNING *;
 
--- name: ListAccessRequestsForDataset :many
-SELECT *
-FROM dataset_access_requests
-WHERE dataset_id = @dataset_id AND status = 'pending'
-ORDER BY created DESC;
+-- name: CreateRenewalAccessRequest :one
+INSERT INTO dataset_access_requests (dataset_id,
+                                        "subject",
+                                        "owner",
+                                        "expires",
+                                        polly_documentation_id,
+                                        renews_access_id)
+VALUES (@dataset_id,
+        @subject,
+        LOWER(@owner),
+        @expires,
+        @polly_documentation_id,
+        @renews_access_id)
+RETURNING *;
 
--- name: ListAccessRequestsForOwner :many
+-- name: GetPendingRenewalAccessRequest :one
 SELECT *
 FROM dataset_access_requests
-WHERE "owner" = ANY (@owner::text[])
-ORDER BY created DESC;
+WHERE renews_access_id = @renews_access_id AND status = 'pending';
 
 -- name: GetAccessRequest :one
 SELECT *
@@ -54,3 +62,70 @@ SET status = 'approved',
     granter = @granter,
     closed = NOW()
 WHERE id = @id;
+
+-- name: ListAccessRequestsForDatasetPage :many
+(
+    SELECT sqlc.embed(dar)
+    FROM dataset_access_requests dar
+    WHERE NOT @descending::bool
+    AND dar.dataset_id = @dataset_id AND dar.status = 'pending'
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (dar.created, dar.id) > (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY dar.created ASC, dar.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(dar)
+    FROM dataset_access_requests dar
+    WHERE @descending::bool
+    AND dar.dataset_id = @dataset_id AND dar.status = 'pending'
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (dar.created, dar.id) < (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY dar.created DESC, dar.id DESC
+    LIMIT @lim::int
+);
+
+-- name: ListAccessRequestsForOwnerPage :many
+(
+    SELECT sqlc.embed(dar)
+    FROM dataset_access_requests dar
+    WHERE NOT @descending::bool
+    AND dar."owner" = ANY (@owner::text[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (dar.created, dar.id) > (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY dar.created ASC, dar.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(dar)
+    FROM dataset_access_requests dar
+    WHERE @descending::bool
+    AND dar."owner" = ANY (@owner::text[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (dar.created, dar.id) < (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY dar.created DESC, dar.id DESC
+    LIMIT @lim::int
+);
+
+-- name: ListAccessRequestsForGranterPage :many
+(
+    SELECT sqlc.embed(dar), ds.name AS dataset_name, dp.id AS dataproduct_id, dp.slug AS dataproduct_slug, dp.name AS dataproduct_name
+    FROM dataset_access_requests dar
+    JOIN datasets ds ON dar.dataset_id = ds.id
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    WHERE NOT @descending::bool
+    AND dp."group" = ANY (@groups::text[]) AND dar.status = 'pending' AND dp.deleted IS NULL
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (dar.created, dar.id) > (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY dar.created ASC, dar.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(dar), ds.name AS dataset_name, dp.id AS dataproduct_id, dp.slug AS dataproduct_slug, dp.name AS dataproduct_name
+    FROM dataset_access_requests dar
+    JOIN datasets ds ON dar.dataset_id = ds.id
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    WHERE @descending::bool
+    AND dp."group" = ANY (@groups::text[]) AND dar.status = 'pending' AND dp.deleted IS NULL
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (dar.created, dar.id) < (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY dar.created DESC, dar.id DESC
+    LIMIT @lim::int
+);
@@ -0,0 +1,19 @@
+-- name: GetDatasetPreviewSettings :one
+SELECT *
+FROM dataset_preview_settings
+WHERE dataset_id = @dataset_id;
+
+-- name: UpsertDatasetPreviewSettings :one
+INSERT INTO dataset_preview_settings (
+    "dataset_id",
+    "enabled",
+    "updated_by"
+) VALUES (
+    @dataset_id,
+    @enabled,
+    @updated_by
+) ON CONFLICT (dataset_id) DO UPDATE SET
+    "enabled" = EXCLUDED.enabled,
+    "updated_by" = EXCLUDED.updated_by,
+    "updated" = NOW()
+RETURNING *;
@@ -114,7 +114,9 @@ SET
   "anonymisation_description" = @anonymisation_description,
   "target_user" = @target_user
 WHERE
-  id = @id RETURNING *;
+  id = @id
+  AND (sqlc.narg('if_match')::timestamptz IS NULL OR last_modified = sqlc.narg('if_match'))
+RETURNING *;
 
 -- name: GetBigqueryDatasource :one
 SELECT
@@ -145,7 +147,10 @@ INSERT INTO
     "table_type",
     "pii_tags",
     "pseudo_columns",
-    "is_reference"
+    "is_reference",
+    "scope",
+    "table_pattern",
+    "tables"
   )
 VALUES
   (
@@ -160,7 +165,10 @@ VALUES
     @table_type,
     @pii_tags,
     @pseudo_columns,
-    @is_reference
+    @is_reference,
+    @scope,
+    @table_pattern,
+    @tables
   ) RETURNING *;
 
 -- name: UpdateBigqueryDatasourceSchema :exec
@@ -171,11 +179,8 @@ SET
   "last_modified" = @last_modified,
   "expires" = @expires,
   "description" = @description,
-  "missing_since" = null,
-  "pseudo_columns" = CASE
-    WHEN @pseudo_columns::text[] IS NOT NULL THEN @pseudo_columns::text[]
-    ELSE "pseudo_columns"
-  END
+  "tables" = @tables,
+  "missing_since" = null
 WHERE
   dataset_id = @dataset_id;
 
@@ -312,7 +317,7 @@ FROM
 WHERE
   ds.id IS NULL
   AND bq.deleted is NULL
-  AND ARRAY_LENGTH(bq.pseudo_columns, 1) > 0;
+  AND jsonb_array_length(bq.pseudo_columns) > 0;
 
 -- name: SetDatasourceDeleted :exec
 UPDATE
@@ -12,61 +12,242 @@ FROM
 WHERE
   ds_id = @id;
 
--- name: GetAccessibleDatasets :many
-SELECT
-  DISTINCT ON (ds.id)
-  ds.*,
-  dsa.subject AS "subject",
-  dsa.owner AS "access_owner",
-  dp.slug AS dp_slug,
-  dp.name AS dp_name,
-  dp.group
-FROM
-  datasets ds
-  LEFT JOIN dataproducts dp ON ds.dataproduct_id = dp.id
-  LEFT JOIN dataset_access dsa ON dsa.dataset_id = ds.id
-WHERE
-  array_length(@groups::TEXT[], 1) IS NOT NULL AND array_length(@groups::TEXT[], 1)!=0
-  AND dp.group = ANY(@groups :: TEXT [])
-  OR (
-    SPLIT_PART(dsa.subject, ':', 1) != 'serviceAccount'
-    AND (
-        @requester::TEXT IS NOT NULL
-        AND dsa.subject = LOWER(@requester)
-        OR SPLIT_PART(dsa.subject, ':', 2) = ANY(@groups::TEXT[])
-    )
-  )
-  AND revoked IS NULL
-  AND (
-    expires > NOW()
-    OR expires IS NULL
-  )
-ORDER BY
-  ds.id,
-  ds.last_modified DESC;
+-- name: GetDatasetsMinimalPage :many
+(
+    SELECT ds.id, ds.created, ds.name, dsb.project_id, dsb.dataset, dsb.table_name
+    FROM datasets ds
+    JOIN datasource_bigquery dsb ON ds.id = dsb.dataset_id AND NOT dsb.is_reference
+    WHERE @sort::text = 'name' AND NOT @descending::bool
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ds.name, ds.id) > (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY ds.name ASC, ds.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT ds.id, ds.created, ds.name, dsb.project_id, dsb.dataset, dsb.table_name
+    FROM datasets ds
+    JOIN datasource_bigquery dsb ON ds.id = dsb.dataset_id AND NOT dsb.is_reference
+    WHERE @sort::text = 'name' AND @descending::bool
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ds.name, ds.id) < (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY ds.name DESC, ds.id DESC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT ds.id, ds.created, ds.name, dsb.project_id, dsb.dataset, dsb.table_name
+    FROM datasets ds
+    JOIN datasource_bigquery dsb ON ds.id = dsb.dataset_id AND NOT dsb.is_reference
+    WHERE @sort::text = 'created' AND NOT @descending::bool
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ds.created, ds.id) > (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY ds.created ASC, ds.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT ds.id, ds.created, ds.name, dsb.project_id, dsb.dataset, dsb.table_name
+    FROM datasets ds
+    JOIN datasource_bigquery dsb ON ds.id = dsb.dataset_id AND NOT dsb.is_reference
+    WHERE @sort::text = 'created' AND @descending::bool
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ds.created, ds.id) < (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY ds.created DESC, ds.id DESC
+    LIMIT @lim::int
+);
 
--- name: GetAccessibleDatasetsByOwnedServiceAccounts :many
-SELECT
-  ds.*,
-  dsa.subject AS "subject",
-  dsa.owner AS "access_owner",
-  dp.slug AS dp_slug,
-  dp.name AS dp_name,
-  dp.group
-FROM
-  datasets ds
-  LEFT JOIN dataproducts dp ON ds.dataproduct_id = dp.id
-  LEFT JOIN dataset_access dsa ON dsa.dataset_id = ds.id
-WHERE
-  SPLIT_PART("subject", ':', 1) = 'serviceAccount'
-  AND (
-    dsa.owner = @requester
-    OR dsa.owner = ANY(@groups::TEXT[])
-  )  
-  AND revoked IS NULL
-  AND (
-    expires > NOW()
-    OR expires IS NULL
-  )
-ORDER BY
-  ds.last_modified DESC;
\ No newline at end of file
+-- name: GetOwnedDatasetsPage :many
+(
+    SELECT sqlc.embed(ds), dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    WHERE @sort::text = 'name' AND NOT @descending::bool
+    AND dp.group = ANY(@groups::text[]) AND dp.deleted IS NULL
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ds.name, ds.id) > (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY ds.name ASC, ds.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(ds), dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    WHERE @sort::text = 'name' AND @descending::bool
+    AND dp.group = ANY(@groups::text[]) AND dp.deleted IS NULL
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ds.name, ds.id) < (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY ds.name DESC, ds.id DESC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(ds), dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    WHERE @sort::text = 'created' AND NOT @descending::bool
+    AND dp.group = ANY(@groups::text[]) AND dp.deleted IS NULL
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ds.created, ds.id) > (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY ds.created ASC, ds.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(ds), dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    WHERE @sort::text = 'created' AND @descending::bool
+    AND dp.group = ANY(@groups::text[]) AND dp.deleted IS NULL
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ds.created, ds.id) < (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY ds.created DESC, ds.id DESC
+    LIMIT @lim::int
+);
+
+-- name: GetGrantedDatasetsPage :many
+(
+    SELECT sqlc.embed(ds), dsa.subject, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    CROSS JOIN LATERAL (
+        SELECT a.subject
+        FROM dataset_access a
+        WHERE a.dataset_id = ds.id
+        AND SPLIT_PART(a.subject, ':', 1) != 'serviceAccount'
+        AND (a.subject = LOWER(@requester::text) OR SPLIT_PART(a.subject, ':', 2) = ANY(@groups::text[]))
+        AND a.revoked IS NULL
+        AND (a.expires > NOW() OR a.expires IS NULL)
+        ORDER BY a.created
+        LIMIT 1
+    ) dsa
+    WHERE @sort::text = 'name' AND NOT @descending::bool
+    AND NOT dp.group = ANY(@groups::text[]) AND dp.deleted IS NULL
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ds.name, ds.id) > (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY ds.name ASC, ds.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(ds), dsa.subject, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    CROSS JOIN LATERAL (
+        SELECT a.subject
+        FROM dataset_access a
+        WHERE a.dataset_id = ds.id
+        AND SPLIT_PART(a.subject, ':', 1) != 'serviceAccount'
+        AND (a.subject = LOWER(@requester::text) OR SPLIT_PART(a.subject, ':', 2) = ANY(@groups::text[]))
+        AND a.revoked IS NULL
+        AND (a.expires > NOW() OR a.expires IS NULL)
+        ORDER BY a.created
+        LIMIT 1
+    ) dsa
+    WHERE @sort::text = 'name' AND @descending::bool
+    AND NOT dp.group = ANY(@groups::text[]) AND dp.deleted IS NULL
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ds.name, ds.id) < (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY ds.name DESC, ds.id DESC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(ds), dsa.subject, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    CROSS JOIN LATERAL (
+        SELECT a.subject
+        FROM dataset_access a
+        WHERE a.dataset_id = ds.id
+        AND SPLIT_PART(a.subject, ':', 1) != 'serviceAccount'
+        AND (a.subject = LOWER(@requester::text) OR SPLIT_PART(a.subject, ':', 2) = ANY(@groups::text[]))
+        AND a.revoked IS NULL
+        AND (a.expires > NOW() OR a.expires IS NULL)
+        ORDER BY a.created
+        LIMIT 1
+    ) dsa
+    WHERE @sort::text = 'created' AND NOT @descending::bool
+    AND NOT dp.group = ANY(@groups::text[]) AND dp.deleted IS NULL
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ds.created, ds.id) > (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY ds.created ASC, ds.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(ds), dsa.subject, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    CROSS JOIN LATERAL (
+        SELECT a.subject
+        FROM dataset_access a
+        WHERE a.dataset_id = ds.id
+        AND SPLIT_PART(a.subject, ':', 1) != 'serviceAccount'
+        AND (a.subject = LOWER(@requester::text) OR SPLIT_PART(a.subject, ':', 2) = ANY(@groups::text[]))
+        AND a.revoked IS NULL
+        AND (a.expires > NOW() OR a.expires IS NULL)
+        ORDER BY a.created
+        LIMIT 1
+    ) dsa
+    WHERE @sort::text = 'created' AND @descending::bool
+    AND NOT dp.group = ANY(@groups::text[]) AND dp.deleted IS NULL
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ds.created, ds.id) < (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY ds.created DESC, ds.id DESC
+    LIMIT @lim::int
+);
+
+-- name: GetServiceAccountGrantedDatasetsPage :many
+(
+    SELECT sqlc.embed(ds), dsa.id AS access_id, dsa.subject, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    JOIN dataset_access dsa ON dsa.dataset_id = ds.id
+    WHERE @sort::text = 'name' AND NOT @descending::bool
+    AND SPLIT_PART(dsa.subject, ':', 1) = 'serviceAccount'
+    AND (dsa.owner = @requester::text OR dsa.owner = ANY(@groups::text[]))
+    AND dsa.revoked IS NULL
+    AND (dsa.expires > NOW() OR dsa.expires IS NULL)
+    AND dp.deleted IS NULL
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ds.name, dsa.id) > (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY ds.name ASC, dsa.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(ds), dsa.id AS access_id, dsa.subject, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    JOIN dataset_access dsa ON dsa.dataset_id = ds.id
+    WHERE @sort::text = 'name' AND @descending::bool
+    AND SPLIT_PART(dsa.subject, ':', 1) = 'serviceAccount'
+    AND (dsa.owner = @requester::text OR dsa.owner = ANY(@groups::text[]))
+    AND dsa.revoked IS NULL
+    AND (dsa.expires > NOW() OR dsa.expires IS NULL)
+    AND dp.deleted IS NULL
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ds.name, dsa.id) < (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY ds.name DESC, dsa.id DESC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(ds), dsa.id AS access_id, dsa.subject, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    JOIN dataset_access dsa ON dsa.dataset_id = ds.id
+    WHERE @sort::text = 'created' AND NOT @descending::bool
+    AND SPLIT_PART(dsa.subject, ':', 1) = 'serviceAccount'
+    AND (dsa.owner = @requester::text OR dsa.owner = ANY(@groups::text[]))
+    AND dsa.revoked IS NULL
+    AND (dsa.expires > NOW() OR dsa.expires IS NULL)
+    AND dp.deleted IS NULL
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ds.created, dsa.id) > (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY ds.created ASC, dsa.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(ds), dsa.id AS access_id, dsa.subject, dp.slug AS dp_slug, dp.name AS dp_name, dp.group
+    FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    JOIN dataset_access dsa ON dsa.dataset_id = ds.id
+    WHERE @sort::text = 'created' AND @descending::bool
+    AND SPLIT_PART(dsa.subject, ':', 1) = 'serviceAccount'
+    AND (dsa.owner = @requester::text OR dsa.owner = ANY(@groups::text[]))
+    AND dsa.revoked IS NULL
+    AND (dsa.expires > NOW() OR dsa.expires IS NULL)
+    AND dp.deleted IS NULL
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ds.created, dsa.id) < (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY ds.created DESC, dsa.id DESC
+    LIMIT @lim::int
+);
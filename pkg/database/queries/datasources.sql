@@ -0,0 +1,64 @@
+-- name: GetDatasetType :one
+SELECT
+  "type"
+FROM
+  datasets
+WHERE
+  id = @id;
+
+-- name: CreateGCSDatasource :one
+INSERT INTO
+  datasource_gcs (
+    "dataset_id",
+    "project_id",
+    "bucket",
+    "prefix",
+    "description",
+    "objects",
+    "created",
+    "last_modified"
+  )
+VALUES
+  (
+    @dataset_id,
+    @project_id,
+    @bucket,
+    @prefix,
+    @description,
+    @objects,
+    @created,
+    @last_modified
+  ) RETURNING *;
+
+-- name: GetGCSDatasource :one
+SELECT
+  *
+FROM
+  datasource_gcs
+WHERE
+  dataset_id = @dataset_id;
+
+-- name: GetGCSDatasources :many
+SELECT
+  *
+FROM
+  datasource_gcs;
+
+-- name: UpdateGCSDatasourceMetadata :exec
+UPDATE
+  datasource_gcs
+SET
+  "objects" = @objects,
+  "last_modified" = @last_modified,
+  "description" = @description,
+  "missing_since" = null
+WHERE
+  dataset_id = @dataset_id;
+
+-- name: UpdateGCSDatasourceMissing :exec
+UPDATE
+  datasource_gcs
+SET
+  "missing_since" = NOW()
+WHERE
+  dataset_id = @dataset_id;
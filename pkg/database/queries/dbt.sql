@@ -0,0 +1,75 @@
+-- name: GetDbtImportCandidates :many
+SELECT
+  ds.id as ds_id,
+  ds.name as ds_name,
+  ds.description as ds_description,
+  ds.keywords as ds_keywords,
+  ds.dataproduct_id as ds_dp_id,
+  dp."group" as dp_group,
+  bq.project_id as bq_project,
+  bq.dataset as bq_dataset,
+  bq.table_name as bq_table_name,
+  bq.schema as bq_schema
+FROM
+  datasets ds
+  JOIN datasource_bigquery bq ON ds.id = bq.dataset_id
+  JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+WHERE
+  bq.is_reference = false
+  AND bq.scope = 'table'
+  AND bq.project_id = ANY(@project_ids::text[])
+  AND dp.deleted IS NULL
+ORDER BY
+  ds.name;
+
+-- name: UpdateDatasetDescriptionAndKeywords :exec
+UPDATE
+  datasets
+SET
+  "description" = @description,
+  "keywords" = @keywords
+WHERE
+  id = @id;
+
+-- name: UpsertDatasetColumnDescription :exec
+INSERT INTO
+  dataset_column_descriptions (
+    "dataset_id",
+    "column_name",
+    "description",
+    "source",
+    "updated_by"
+  )
+VALUES
+  (
+    @dataset_id,
+    @column_name,
+    @description,
+    @source,
+    @updated_by
+  ) ON CONFLICT ("dataset_id", "column_name") DO
+UPDATE
+SET
+  "description" = EXCLUDED.description,
+  "source" = EXCLUDED.source,
+  "updated_by" = EXCLUDED.updated_by,
+  "updated" = NOW();
+
+-- name: GetDatasetColumnDescriptions :many
+SELECT
+  *
+FROM
+  dataset_column_descriptions
+WHERE
+  dataset_id = @dataset_id
+ORDER BY
+  column_name;
+
+-- name: UpdateBigqueryDatasourceSchemaColumns :exec
+UPDATE
+  datasource_bigquery
+SET
+  "schema" = @schema
+WHERE
+  dataset_id = @dataset_id
+  AND is_reference = false;
@@ -30,13 +30,16 @@ SELECT
 FROM
     insight_product
 WHERE
-    id = @id;
+    id = @id
+    AND deleted IS NULL;
 
 -- name: GetInsightProducts :many
 SELECT
     *
 FROM
     insight_product
+WHERE
+    deleted IS NULL
 ORDER BY
     last_modified DESC;
 
@@ -47,6 +50,7 @@ FROM
     insight_product
 WHERE
     id = ANY (@id :: uuid [])
+    AND deleted IS NULL
 ORDER BY
     last_modified DESC;
 
@@ -57,6 +61,7 @@ FROM
     insight_product
 WHERE
     team_id = @team_id
+    AND deleted IS NULL
 ORDER BY
     last_modified DESC;
 
@@ -67,7 +72,8 @@ SELECT
 FROM
     insight_product
 WHERE
-    team_id = @team_id;
+    team_id = @team_id
+    AND deleted IS NULL;
 
 -- name: UpdateInsightProduct :one
 UPDATE
@@ -82,7 +88,9 @@ SET
     "teamkatalogen_url" = @teamkatalogen_url,
     "team_id" = @team_id
 WHERE
-    id = @id RETURNING *;
+    id = @id
+    AND (sqlc.narg('if_match')::timestamptz IS NULL OR last_modified = sqlc.narg('if_match'))
+RETURNING *;
 
 -- name: DeleteInsightProduct :exec
 DELETE FROM
@@ -97,5 +105,6 @@ FROM
     insight_product
 WHERE
     "group" = ANY (@groups :: text [])
+    AND deleted IS NULL
 ORDER BY
     last_modified DESC;
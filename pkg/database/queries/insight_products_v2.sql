@@ -8,16 +8,6 @@ WHERE
 ORDER BY
     last_modified DESC;
 
--- name: GetInsightProductsByGroups :many
-SELECT
-    *
-FROM
-    insight_product_with_teamkatalogen_view ipwtv
-WHERE
-    "group" = ANY(@groups::text[])
-ORDER BY
-    ipwtv."group", ipwtv.name ASC;
-
 -- name: GetInsightProductWithTeamkatalogen :one
 SELECT
     *
@@ -27,3 +17,85 @@ WHERE
     "id" = @id
 ORDER BY
     last_modified DESC;
+
+-- name: GetInsightProductsByGroupsPage :many
+(
+    SELECT sqlc.embed(ipwtv)
+    FROM insight_product_with_teamkatalogen_view ipwtv
+    WHERE @sort::text = 'name' AND NOT @descending::bool
+    AND ipwtv."group" = ANY(@groups::text[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ipwtv.name, ipwtv.id) > (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY ipwtv.name ASC, ipwtv.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(ipwtv)
+    FROM insight_product_with_teamkatalogen_view ipwtv
+    WHERE @sort::text = 'name' AND @descending::bool
+    AND ipwtv."group" = ANY(@groups::text[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ipwtv.name, ipwtv.id) < (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY ipwtv.name DESC, ipwtv.id DESC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(ipwtv)
+    FROM insight_product_with_teamkatalogen_view ipwtv
+    WHERE @sort::text = 'created' AND NOT @descending::bool
+    AND ipwtv."group" = ANY(@groups::text[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ipwtv.created, ipwtv.id) > (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY ipwtv.created ASC, ipwtv.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(ipwtv)
+    FROM insight_product_with_teamkatalogen_view ipwtv
+    WHERE @sort::text = 'created' AND @descending::bool
+    AND ipwtv."group" = ANY(@groups::text[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ipwtv.created, ipwtv.id) < (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY ipwtv.created DESC, ipwtv.id DESC
+    LIMIT @lim::int
+);
+
+-- name: GetInsightProductsByProductAreaPage :many
+(
+    SELECT sqlc.embed(ipwtv)
+    FROM insight_product_with_teamkatalogen_view ipwtv
+    WHERE @sort::text = 'name' AND NOT @descending::bool
+    AND ipwtv.team_id = ANY(@team_id::uuid[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ipwtv.name, ipwtv.id) > (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY ipwtv.name ASC, ipwtv.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(ipwtv)
+    FROM insight_product_with_teamkatalogen_view ipwtv
+    WHERE @sort::text = 'name' AND @descending::bool
+    AND ipwtv.team_id = ANY(@team_id::uuid[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ipwtv.name, ipwtv.id) < (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY ipwtv.name DESC, ipwtv.id DESC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(ipwtv)
+    FROM insight_product_with_teamkatalogen_view ipwtv
+    WHERE @sort::text = 'created' AND NOT @descending::bool
+    AND ipwtv.team_id = ANY(@team_id::uuid[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ipwtv.created, ipwtv.id) > (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY ipwtv.created ASC, ipwtv.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(ipwtv)
+    FROM insight_product_with_teamkatalogen_view ipwtv
+    WHERE @sort::text = 'created' AND @descending::bool
+    AND ipwtv.team_id = ANY(@team_id::uuid[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (ipwtv.created, ipwtv.id) < (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY ipwtv.created DESC, ipwtv.id DESC
+    LIMIT @lim::int
+);
@@ -18,6 +18,7 @@ FROM
     )
 WHERE
     jv.owner = @owner
+    AND jv.deleted IS NULL
     AND (
         jv.expires IS NULL
         OR jv.expires > NOW()
@@ -105,6 +106,7 @@ WHERE
 SELECT
     jv.id as joinable_view_id,
     jv.name as joinable_view_name,
+    bq.id as datasource_id,
     bq.project_id as bq_project_id,
     bq.dataset as bq_dataset_id,
     bq.table_name as bq_table_id
@@ -113,4 +115,67 @@ FROM
     JOIN joinable_views_datasource jvds ON jv.id = jvds.joinable_view_id
     JOIN datasource_bigquery bq ON bq.id = jvds.datasource_id
 WHERE
-    jvds.deleted IS NOT NULL;
\ No newline at end of file
+    jvds.deleted IS NOT NULL
+    AND jvds.view_deleted IS NULL
+    AND jv.deleted IS NULL;
+
+-- name: GetJoinableView :one
+SELECT
+    *
+FROM
+    joinable_views
+WHERE
+    id = @id;
+
+-- name: SetJoinableViewExpires :exec
+UPDATE
+    joinable_views
+SET
+    expires = @expires
+WHERE
+    id = @id;
+
+-- name: SetJoinableViewDatasourceViewDeleted :exec
+UPDATE
+    joinable_views_datasource
+SET
+    view_deleted = NOW()
+WHERE
+    joinable_view_id = @joinable_view_id
+    AND datasource_id = @datasource_id;
+
+-- name: CreateJoinableViewShare :exec
+INSERT INTO
+    joinable_views_shares ("joinable_view_id", "group")
+VALUES
+    (@joinable_view_id, @group_email) ON CONFLICT DO NOTHING;
+
+-- name: GetJoinableViewShares :many
+SELECT
+    "group"
+FROM
+    joinable_views_shares
+WHERE
+    joinable_view_id = @joinable_view_id
+ORDER BY
+    created;
+
+-- name: GetJoinableViewsToBeCleanedUp :many
+SELECT
+    *
+FROM
+    joinable_views jv
+WHERE
+    jv.deleted IS NULL
+    AND (
+        jv.expires < NOW()
+        OR NOT EXISTS (
+            SELECT
+                1
+            FROM
+                joinable_views_datasource jvds
+            WHERE
+                jvds.joinable_view_id = jv.id
+                AND jvds.deleted IS NULL
+        )
+    );
@@ -0,0 +1,41 @@
+-- name: SetDataproductLifecycle :exec
+UPDATE
+    dataproducts
+SET
+    lifecycle_status = @lifecycle_status,
+    replaced_by = @replaced_by,
+    sunset = @sunset,
+    deprecation_reason = @deprecation_reason
+WHERE
+    id = @id;
+
+-- name: SetDatasetLifecycle :exec
+UPDATE
+    datasets
+SET
+    lifecycle_status = @lifecycle_status,
+    replaced_by = @replaced_by,
+    sunset = @sunset,
+    deprecation_reason = @deprecation_reason
+WHERE
+    id = @id;
+
+-- name: GetDataproductsToRetire :many
+SELECT
+    id
+FROM
+    dataproducts
+WHERE
+    lifecycle_status IN ('deprecated', 'sunset_scheduled')
+    AND sunset <= NOW()
+    AND deleted IS NULL;
+
+-- name: GetDatasetsToRetire :many
+SELECT
+    id
+FROM
+    datasets
+WHERE
+    lifecycle_status IN ('deprecated', 'sunset_scheduled')
+    AND sunset <= NOW()
+    AND dataproduct_id NOT IN (SELECT id FROM dataproducts WHERE deleted IS NOT NULL);
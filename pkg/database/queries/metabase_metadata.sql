@@ -69,7 +69,12 @@ WITH sources_in_same_dataset AS (
   WHERE project_id = @project_id AND dataset = @dataset
 )
 
-SELECT table_name FROM sources_in_same_dataset sds
+SELECT sds.table_name::TEXT AS table_name FROM sources_in_same_dataset sds
 JOIN metabase_metadata mbm
 ON mbm.dataset_id = sds.dataset_id
-WHERE mbm.collection_id IS null;
+WHERE mbm.collection_id IS null AND sds.scope = 'table'
+UNION
+SELECT (jsonb_array_elements(sds.tables) ->> 'name')::TEXT AS table_name FROM sources_in_same_dataset sds
+JOIN metabase_metadata mbm
+ON mbm.dataset_id = sds.dataset_id
+WHERE mbm.collection_id IS null AND sds.scope = 'dataset' AND sds.tables IS NOT NULL;
@@ -0,0 +1,161 @@
+-- name: CreateNotification :one
+INSERT INTO notifications (
+    "recipient",
+    "event_type",
+    "title",
+    "message",
+    "link",
+    "reference_id",
+    "digest_channel"
+) VALUES (
+    @recipient,
+    @event_type,
+    @title,
+    @message,
+    @link,
+    @reference_id,
+    @digest_channel
+)
+RETURNING *;
+
+-- name: CreateNotificationDelivery :exec
+INSERT INTO notification_deliveries (
+    "kind",
+    "recipient",
+    "title",
+    "text",
+    "reference_id"
+) VALUES (
+    @kind,
+    @recipient,
+    @title,
+    @text,
+    @reference_id
+);
+
+-- name: GetPendingNotificationDeliveries :many
+SELECT *
+FROM notification_deliveries
+WHERE delivered IS NULL
+AND attempts < @max_attempts::int
+AND (last_attempt IS NULL OR last_attempt <= NOW() - make_interval(mins => attempts))
+ORDER BY created
+LIMIT @lim::int;
+
+-- name: MarkNotificationDelivered :exec
+UPDATE notification_deliveries
+SET delivered = NOW(),
+    attempts = attempts + 1,
+    last_attempt = NOW()
+WHERE id = @id;
+
+-- name: MarkNotificationDeliveryFailed :exec
+UPDATE notification_deliveries
+SET attempts = attempts + 1,
+    last_attempt = NOW(),
+    last_error = @last_error
+WHERE id = @id;
+
+-- name: GetNotificationsPage :many
+(
+    SELECT sqlc.embed(n), r.read_at
+    FROM notifications n
+    LEFT JOIN notification_reads r ON r.notification_id = n.id AND r.email = @email
+    WHERE NOT @descending::bool
+    AND n.recipient = ANY(@recipients::text[])
+    AND (NOT @unread_only::bool OR r.read_at IS NULL)
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (n.created, n.id) > (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY n.created ASC, n.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(n), r.read_at
+    FROM notifications n
+    LEFT JOIN notification_reads r ON r.notification_id = n.id AND r.email = @email
+    WHERE @descending::bool
+    AND n.recipient = ANY(@recipients::text[])
+    AND (NOT @unread_only::bool OR r.read_at IS NULL)
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (n.created, n.id) < (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY n.created DESC, n.id DESC
+    LIMIT @lim::int
+);
+
+-- name: MarkNotificationsRead :exec
+INSERT INTO notification_reads ("notification_id", "email")
+SELECT id, @email
+FROM notifications
+WHERE id = ANY(@ids::uuid[])
+AND recipient = ANY(@recipients::text[])
+ON CONFLICT DO NOTHING;
+
+-- name: MarkAllNotificationsRead :exec
+INSERT INTO notification_reads ("notification_id", "email")
+SELECT id, @email
+FROM notifications
+WHERE recipient = ANY(@recipients::text[])
+ON CONFLICT DO NOTHING;
+
+-- name: GetNotificationPreferences :many
+SELECT *
+FROM notification_preferences
+WHERE email = @email
+ORDER BY event_type;
+
+-- name: UpsertNotificationPreferences :exec
+INSERT INTO notification_preferences ("email", "event_type", "channel", "digest")
+SELECT @email, unnest(@event_types::text[]), unnest(@channels::text[])::notification_channel, unnest(@digests::bool[])
+ON CONFLICT (email, event_type) DO UPDATE
+SET channel = EXCLUDED.channel,
+    digest = EXCLUDED.digest;
+
+-- name: GetDigestRecipients :many
+SELECT recipient, digest_channel::notification_channel AS digest_channel
+FROM notifications
+WHERE digest_channel IS NOT NULL AND digest_sent IS NULL
+GROUP BY recipient, digest_channel
+HAVING MIN(created) <= @pending_since;
+
+-- name: GetPendingDigestNotifications :many
+SELECT *
+FROM notifications
+WHERE recipient = @recipient
+AND digest_channel = @digest_channel
+AND digest_sent IS NULL
+ORDER BY created;
+
+-- name: MarkNotificationDigestSent :exec
+UPDATE notifications
+SET digest_sent = NOW()
+WHERE id = ANY(@ids::uuid[]);
+
+-- name: GetExpiringAccessToNotify :many
+SELECT
+    da.id AS access_id,
+    da.subject,
+    da.owner,
+    da.expires::timestamptz AS expires,
+    ds.id AS dataset_id,
+    ds.name AS dataset_name,
+    dp.id AS dataproduct_id,
+    dp.name AS dataproduct_name
+FROM dataset_access da
+JOIN datasets ds ON ds.id = da.dataset_id
+JOIN dataproducts dp ON dp.id = ds.dataproduct_id
+WHERE da.revoked IS NULL
+AND da.expires > NOW()
+AND da.expires <= NOW() + make_interval(days => @days::int)
+AND dp.deleted IS NULL
+AND NOT EXISTS (
+    SELECT 1
+    FROM dataset_access_expiry_reminders r
+    WHERE r.access_id = da.id
+    AND r.expires = da.expires
+    AND r.days_before <= @days::int
+)
+ORDER BY da.expires;
+
+-- name: MarkAccessExpiryNotified :exec
+INSERT INTO dataset_access_expiry_reminders (access_id, expires, days_before)
+VALUES (@access_id, @expires, @days_before)
+ON CONFLICT DO NOTHING;
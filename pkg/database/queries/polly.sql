@@ -8,6 +8,116 @@ VALUES (@external_id,
 RETURNING *;
 
 -- name: GetPollyDocumentation :one
+SELECT pd.*, pp.status, pp.valid_to
+FROM polly_documentation pd
+LEFT JOIN polly_purposes pp ON pp.external_id = pd.external_id
+WHERE pd.id = @id;
+
+-- name: GetReferencedPollyPurposes :many
+SELECT DISTINCT ON (pd.external_id) pd.external_id, pd.name, pd.url
+FROM polly_documentation pd
+JOIN dataset_access_requests dar ON dar.polly_documentation_id = pd.id
+LEFT JOIN dataset_access da ON da.access_request_id = dar.id
+WHERE dar.status = 'pending'
+OR (da.id IS NOT NULL AND da.revoked IS NULL AND (da.expires IS NULL OR da.expires > NOW()))
+ORDER BY pd.external_id, dar.created DESC;
+
+-- name: GetPollyPurpose :one
+SELECT *
+FROM polly_purposes
+WHERE external_id = @external_id;
+
+-- name: UpsertPollyPurpose :exec
+INSERT INTO polly_purposes ("external_id",
+                            "name",
+                            "status",
+                            "valid_from",
+                            "valid_to",
+                            "last_checked")
+VALUES (@external_id,
+        @name,
+        @status,
+        @valid_from,
+        @valid_to,
+        NOW())
+ON CONFLICT (external_id) DO UPDATE
+SET name         = EXCLUDED.name,
+    status       = EXCLUDED.status,
+    valid_from   = EXCLUDED.valid_from,
+    valid_to     = EXCLUDED.valid_to,
+    last_checked = EXCLUDED.last_checked;
+
+-- name: CreatePollyPurposeHistory :exec
+INSERT INTO polly_purpose_history ("external_id",
+                                   "name",
+                                   "status",
+                                   "valid_from",
+                                   "valid_to")
+VALUES (@external_id,
+        @name,
+        @status,
+        @valid_from,
+        @valid_to);
+
+-- name: GetPollyPurposeHistory :many
 SELECT *
-FROM polly_documentation
-WHERE id = @id;
+FROM polly_purpose_history
+WHERE external_id = @external_id
+ORDER BY recorded DESC;
+
+-- name: ListActiveAccessForPollyPurpose :many
+SELECT
+    da.id AS access_id,
+    da.subject,
+    da.owner,
+    da.expires,
+    ds.id AS dataset_id,
+    ds.name AS dataset_name,
+    dp.id AS dataproduct_id,
+    dp.name AS dataproduct_name,
+    dp."group" AS owner_group,
+    dp.team_contact,
+    pd.external_id,
+    pd.name AS purpose_name,
+    pd.url AS purpose_url,
+    pp.status AS purpose_status
+FROM dataset_access da
+JOIN dataset_access_requests dar ON da.access_request_id = dar.id
+JOIN polly_documentation pd ON dar.polly_documentation_id = pd.id
+JOIN polly_purposes pp ON pp.external_id = pd.external_id
+JOIN datasets ds ON da.dataset_id = ds.id
+JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+WHERE pd.external_id = @external_id
+AND dp.deleted IS NULL
+AND da.revoked IS NULL
+AND (da.expires IS NULL OR da.expires > NOW())
+ORDER BY dp."group", ds.name, da.subject;
+
+-- name: ListAccessWithInvalidPollyPurposeForGroups :many
+SELECT
+    da.id AS access_id,
+    da.subject,
+    da.owner,
+    da.expires,
+    ds.id AS dataset_id,
+    ds.name AS dataset_name,
+    dp.id AS dataproduct_id,
+    dp.name AS dataproduct_name,
+    dp."group" AS owner_group,
+    dp.team_contact,
+    pd.external_id,
+    pd.name AS purpose_name,
+    pd.url AS purpose_url,
+    pp.status AS purpose_status
+FROM dataset_access da
+JOIN dataset_access_requests dar ON da.access_request_id = dar.id
+JOIN polly_documentation pd ON dar.polly_documentation_id = pd.id
+JOIN polly_purposes pp ON pp.external_id = pd.external_id
+JOIN datasets ds ON da.dataset_id = ds.id
+JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+WHERE dp."group" = ANY (@groups::text[])
+AND pp.status != 'valid'
+AND dp.deleted IS NULL
+AND da.revoked IS NULL
+AND (da.expires IS NULL OR da.expires > NOW())
+ORDER BY ds.name, da.subject;
@@ -0,0 +1,55 @@
+-- name: GetDatasourcesToProfile :many
+SELECT
+  ds.id AS dataset_id,
+  ds.pii,
+  dsb.project_id,
+  dsb.dataset,
+  dsb.table_name,
+  dsb.schema,
+  dsb.pii_tags,
+  dsb.pseudo_columns
+FROM
+  datasets ds
+  JOIN datasource_bigquery dsb ON dsb.dataset_id = ds.id
+  AND dsb.is_reference = false
+  AND dsb.deleted IS NULL
+WHERE
+  dsb.missing_since IS NULL
+  AND dsb.scope = 'table'
+ORDER BY
+  ds.id;
+
+-- name: CreateDatasetProfile :exec
+INSERT INTO dataset_profiles (
+    "dataset_id",
+    "project_id",
+    "dataset",
+    "table_name",
+    "row_count",
+    "bytes_processed",
+    "columns"
+) VALUES (
+    @dataset_id,
+    @project_id,
+    @dataset,
+    @table_name,
+    @row_count,
+    @bytes_processed,
+    @columns
+);
+
+-- name: GetDatasetProfiles :many
+SELECT *
+FROM dataset_profiles
+WHERE dataset_id = @dataset_id
+ORDER BY created DESC
+LIMIT @lim::int;
+
+-- name: GetLatestTableProfile :one
+SELECT *
+FROM dataset_profiles
+WHERE project_id = @project_id
+  AND dataset = @dataset
+  AND table_name = @table_name
+ORDER BY created DESC
+LIMIT 1;
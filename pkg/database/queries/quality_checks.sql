@@ -0,0 +1,108 @@
+-- name: CreateQualityCheck :one
+INSERT INTO quality_checks (
+    "dataset_id",
+    "name",
+    "description",
+    "kind",
+    "column_name",
+    "condition",
+    "sql_query",
+    "threshold",
+    "created_by"
+) VALUES (
+    @dataset_id,
+    @name,
+    @description,
+    @kind,
+    @column_name,
+    @condition,
+    @sql_query,
+    @threshold,
+    @created_by
+)
+RETURNING *;
+
+-- name: GetQualityCheck :one
+SELECT *
+FROM quality_checks
+WHERE id = @id;
+
+-- name: GetQualityChecks :many
+SELECT
+  sqlc.embed(qc),
+  lr.id AS run_id,
+  lr.status AS run_status,
+  lr.measured AS run_measured,
+  lr.error AS run_error,
+  lr.bytes_processed AS run_bytes_processed,
+  lr.started AS run_started
+FROM
+  quality_checks qc
+  LEFT JOIN quality_check_latest_runs lr ON lr.check_id = qc.id
+WHERE
+  qc.dataset_id = @dataset_id
+ORDER BY
+  qc.name;
+
+-- name: GetQualityChecksToRun :many
+SELECT
+  sqlc.embed(qc),
+  dp.id AS dataproduct_id,
+  dp.name AS dataproduct_name,
+  dp.group AS owner_group,
+  ds.name AS dataset_name,
+  dsb.project_id,
+  dsb.dataset,
+  dsb.table_name,
+  lr.status AS previous_status
+FROM
+  quality_checks qc
+  JOIN datasets ds ON ds.id = qc.dataset_id
+  JOIN dataproducts dp ON dp.id = ds.dataproduct_id
+  JOIN datasource_bigquery dsb ON dsb.dataset_id = ds.id
+  AND dsb.is_reference = false
+  AND dsb.deleted IS NULL
+  LEFT JOIN quality_check_latest_runs lr ON lr.check_id = qc.id
+WHERE
+  dp.deleted IS NULL
+  AND dsb.missing_since IS NULL
+  AND dsb.scope = 'table'
+  AND (
+    sqlc.narg('id')::uuid IS NULL
+    OR qc.id = sqlc.narg('id')::uuid
+  )
+ORDER BY
+  qc.dataset_id,
+  qc.name;
+
+-- name: DeleteQualityCheck :exec
+DELETE FROM quality_checks
+WHERE id = @id;
+
+-- name: CreateQualityCheckRun :one
+INSERT INTO quality_check_runs (
+    "check_id",
+    "status",
+    "measured",
+    "error",
+    "bytes_processed"
+) VALUES (
+    @check_id,
+    @status,
+    @measured,
+    @error,
+    @bytes_processed
+)
+RETURNING *;
+
+-- name: GetQualityCheckRuns :many
+SELECT *
+FROM quality_check_runs
+WHERE check_id = @check_id
+ORDER BY started DESC
+LIMIT @lim::int;
+
+-- name: GetDatasetQualityStatus :one
+SELECT *
+FROM dataset_quality_status
+WHERE dataset_id = @dataset_id;
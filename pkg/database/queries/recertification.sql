@@ -0,0 +1,105 @@
+-- name: CreateRecertificationCampaign :one
+INSERT INTO recertification_campaigns (
+    "name",
+    deadline,
+    revoke_unconfirmed,
+    created_by
+) VALUES (
+    @name,
+    @deadline,
+    @revoke_unconfirmed,
+    @created_by
+) RETURNING *;
+
+-- name: CreateRecertificationReview :exec
+INSERT INTO recertification_reviews (
+    campaign_id,
+    dataset_id,
+    access_id,
+    subject,
+    "owner",
+    granter,
+    expires
+) VALUES (
+    @campaign_id,
+    @dataset_id,
+    @access_id,
+    @subject,
+    @owner,
+    @granter,
+    @expires
+);
+
+-- name: GetRecertificationCampaign :one
+SELECT *
+FROM recertification_campaigns
+WHERE id = @id;
+
+-- name: GetRecertificationCampaigns :many
+SELECT *
+FROM recertification_campaigns
+ORDER BY created DESC;
+
+-- name: GetRecertificationCampaignsForGroups :many
+SELECT *
+FROM recertification_campaigns c
+WHERE c.created_by = @created_by
+    OR EXISTS (
+        SELECT 1
+        FROM recertification_reviews r
+        JOIN datasets ds ON ds.id = r.dataset_id
+        JOIN dataproducts dp ON dp.id = ds.dataproduct_id
+        WHERE r.campaign_id = c.id AND dp."group" = ANY(@groups::text[])
+    )
+ORDER BY c.created DESC;
+
+-- name: GetOpenRecertificationCampaigns :many
+SELECT *
+FROM recertification_campaigns
+WHERE completed IS NULL
+ORDER BY deadline;
+
+-- name: GetRecertificationReviews :many
+SELECT
+    r.*,
+    ds.name AS dataset_name,
+    dp.id AS dataproduct_id,
+    dp.name AS dataproduct_name,
+    dp."group" AS owner_group,
+    dp.team_contact
+FROM recertification_reviews r
+JOIN datasets ds ON ds.id = r.dataset_id
+JOIN dataproducts dp ON dp.id = ds.dataproduct_id
+WHERE r.campaign_id = @campaign_id
+ORDER BY ds.name, r.subject;
+
+-- name: GetRecertificationReview :one
+SELECT
+    r.*,
+    ds.name AS dataset_name,
+    dp.id AS dataproduct_id,
+    dp.name AS dataproduct_name,
+    dp."group" AS owner_group,
+    dp.team_contact
+FROM recertification_reviews r
+JOIN datasets ds ON ds.id = r.dataset_id
+JOIN dataproducts dp ON dp.id = ds.dataproduct_id
+WHERE r.id = @id;
+
+-- name: ReviewRecertification :exec
+UPDATE recertification_reviews
+SET decision = @decision,
+    new_expires = @new_expires,
+    reviewed_by = @reviewed_by,
+    reviewed = NOW()
+WHERE id = @id;
+
+-- name: SetRecertificationCampaignReminded :exec
+UPDATE recertification_campaigns
+SET last_reminded = NOW()
+WHERE id = @id;
+
+-- name: CompleteRecertificationCampaign :exec
+UPDATE recertification_campaigns
+SET completed = NOW()
+WHERE id = @id;
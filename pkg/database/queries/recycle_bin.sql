@@ -0,0 +1,104 @@
+-- name: SoftDeleteDataproduct :exec
+UPDATE
+    dataproducts
+SET
+    deleted = NOW(),
+    deleted_by = @deleted_by
+WHERE
+    id = @id
+    AND deleted IS NULL;
+
+-- name: SoftDeleteStory :exec
+UPDATE
+    stories
+SET
+    deleted = NOW(),
+    deleted_by = @deleted_by
+WHERE
+    id = @id
+    AND deleted IS NULL;
+
+-- name: SoftDeleteInsightProduct :exec
+UPDATE
+    insight_product
+SET
+    deleted = NOW(),
+    deleted_by = @deleted_by
+WHERE
+    id = @id
+    AND deleted IS NULL;
+
+-- name: RestoreDataproduct :exec
+UPDATE
+    dataproducts
+SET
+    deleted = NULL,
+    deleted_by = NULL
+WHERE
+    id = @id;
+
+-- name: RestoreStory :exec
+UPDATE
+    stories
+SET
+    deleted = NULL,
+    deleted_by = NULL
+WHERE
+    id = @id;
+
+-- name: RestoreInsightProduct :exec
+UPDATE
+    insight_product
+SET
+    deleted = NULL,
+    deleted_by = NULL
+WHERE
+    id = @id;
+
+-- name: GetDeletedItems :many
+SELECT
+    *
+FROM
+    (
+        SELECT
+            "id",
+            "name",
+            'dataproduct'::text AS "item_type",
+            "group",
+            "deleted"::timestamptz AS "deleted",
+            coalesce("deleted_by", '')::text AS "deleted_by"
+        FROM
+            dataproducts
+        WHERE
+            "deleted" IS NOT NULL
+        UNION ALL
+        SELECT
+            "id",
+            "name",
+            'story'::text AS "item_type",
+            "group",
+            "deleted"::timestamptz AS "deleted",
+            coalesce("deleted_by", '')::text AS "deleted_by"
+        FROM
+            stories
+        WHERE
+            "deleted" IS NOT NULL
+        UNION ALL
+        SELECT
+            "id",
+            "name",
+            'insight_product'::text AS "item_type",
+            "group",
+            "deleted"::timestamptz AS "deleted",
+            coalesce("deleted_by", '')::text AS "deleted_by"
+        FROM
+            insight_product
+        WHERE
+            "deleted" IS NOT NULL
+    ) AS "items"
+WHERE
+    (array_length(@groups::text[], 1) IS NULL OR "group" = ANY (@groups))
+    AND (sqlc.narg('id')::uuid IS NULL OR "id" = sqlc.narg('id'))
+    AND (sqlc.narg('deleted_before')::timestamptz IS NULL OR "deleted" < sqlc.narg('deleted_before'))
+ORDER BY
+    "deleted" DESC;
@@ -2,48 +2,210 @@
 SELECT
 	element_id::uuid,
 	element_type::text,
-	ts_rank_cd(tsv_document, query) AS rank,
-	ts_headline('norwegian', "description", query, 'MinWords=10, MaxWords=20, MaxFragments=2 FragmentDelimiter=" … " StartSel="((START))" StopSel="((STOP))"')::text AS excerpt
-FROM
-	search,
-	websearch_to_tsquery('norwegian', @query) query
-WHERE
 	(
 		CASE
-			WHEN array_length(@types::text[], 1) > 0 THEN "element_type" = ANY(@types)
-			ELSE TRUE
-		END
-	)
-	AND (
-		CASE
-			WHEN array_length(@keyword::text[], 1) > 0 THEN "keywords" && @keyword
-			ELSE TRUE
-		END
-	)
-	AND (
-		CASE
-			WHEN @query :: text != '' THEN "tsv_document" @@ query
-			ELSE TRUE
-		END
-	)
-	AND (
-		CASE
-			WHEN array_length(@grp::text[], 1) > 0 THEN "group" = ANY(@grp)
-			ELSE TRUE
-		END
-	)
-	AND (
-		CASE
-			WHEN array_length(@team_id::uuid[], 1) > 0 THEN "team_id" = ANY(@team_id)
-			ELSE TRUE
-		END
-	)
-	AND (
-		CASE
-			WHEN array_length(@service::text[], 1) > 0 THEN "services" && @service
-			ELSE TRUE
+			WHEN @fuzzy :: boolean THEN word_similarity(@query, "name")
+			ELSE ts_rank_cd(tsv_document, query)
 		END
-	)
+	)::real AS rank,
+	ts_headline('norwegian', "description", query, 'MinWords=10, MaxWords=20, MaxFragments=2 FragmentDelimiter=" … " StartSel="((START))" StopSel="((STOP))"')::text AS excerpt
+FROM
+	search_hits(
+		@query::text,
+		@fuzzy::boolean,
+		@types::text[],
+		@keyword::text[],
+		@grp::text[],
+		@team_id::uuid[],
+		@product_area_id::uuid[],
+		@pii::text[],
+		@service::text[],
+		@quality_status::text[]
+	) hits,
+	websearch_to_tsquery('norwegian', @query) query
 ORDER BY rank DESC, created ASC
 LIMIT @lim OFFSET @offs;
-;
+
+-- name: SearchCount :one
+SELECT
+	count(*)::bigint AS total
+FROM
+	search_hits(
+		@query::text,
+		@fuzzy::boolean,
+		@types::text[],
+		@keyword::text[],
+		@grp::text[],
+		@team_id::uuid[],
+		@product_area_id::uuid[],
+		@pii::text[],
+		@service::text[],
+		@quality_status::text[]
+	);
+
+-- name: SearchFacets :many
+WITH hits AS (
+	SELECT
+		"element_id",
+		"element_type",
+		"keywords",
+		"team_id",
+		"product_area_id",
+		"pii",
+		"services",
+		"quality_status"
+	FROM
+		search_hits(
+			@query::text,
+			@fuzzy::boolean,
+			@types::text[],
+			@keyword::text[],
+			@grp::text[],
+			@team_id::uuid[],
+			@product_area_id::uuid[],
+			@pii::text[],
+			@service::text[],
+			@quality_status::text[]
+		)
+)
+SELECT
+	'keywords'::text AS facet,
+	"keyword"::text AS value,
+	''::text AS label,
+	count(DISTINCT "element_id")::bigint AS count
+FROM
+	hits,
+	unnest("keywords") AS "keyword"
+GROUP BY
+	"keyword"
+UNION ALL
+SELECT
+	'teams'::text AS facet,
+	"hits"."team_id"::text AS value,
+	coalesce("tkt"."name", '')::text AS label,
+	count(*)::bigint AS count
+FROM
+	hits
+	LEFT JOIN tk_teams "tkt" ON "hits"."team_id" = "tkt"."id"
+WHERE
+	"hits"."team_id" IS NOT NULL
+GROUP BY
+	"hits"."team_id",
+	"tkt"."name"
+UNION ALL
+SELECT
+	'productAreas'::text AS facet,
+	"hits"."product_area_id"::text AS value,
+	coalesce("tkpa"."name", '')::text AS label,
+	count(*)::bigint AS count
+FROM
+	hits
+	LEFT JOIN tk_product_areas "tkpa" ON "hits"."product_area_id" = "tkpa"."id"
+WHERE
+	"hits"."product_area_id" IS NOT NULL
+GROUP BY
+	"hits"."product_area_id",
+	"tkpa"."name"
+UNION ALL
+SELECT
+	'piiLevels'::text AS facet,
+	"pii"::text AS value,
+	''::text AS label,
+	count(*)::bigint AS count
+FROM
+	hits
+WHERE
+	"pii" IS NOT NULL
+GROUP BY
+	"pii"
+UNION ALL
+SELECT
+	'qualityStatuses'::text AS facet,
+	"quality_status"::text AS value,
+	''::text AS label,
+	count(*)::bigint AS count
+FROM
+	hits
+WHERE
+	"quality_status" IS NOT NULL
+GROUP BY
+	"quality_status"
+UNION ALL
+SELECT
+	'types'::text AS facet,
+	"element_type"::text AS value,
+	''::text AS label,
+	count(*)::bigint AS count
+FROM
+	hits
+GROUP BY
+	"element_type"
+UNION ALL
+SELECT
+	'services'::text AS facet,
+	"service"::text AS value,
+	''::text AS label,
+	count(DISTINCT "element_id")::bigint AS count
+FROM
+	hits,
+	unnest("services") AS "service"
+GROUP BY
+	"service"
+ORDER BY
+	facet,
+	count DESC,
+	value;
+
+-- name: SearchSuggestNames :many
+WITH "names" AS (
+	SELECT "id", "name", 'dataproduct' AS "element_type" FROM dataproducts
+	WHERE "deleted" IS NULL
+		AND "lifecycle_status" != 'retired'
+		AND ("name" ILIKE @prefix::text || '%' OR "name" ILIKE '% ' || @prefix::text || '%')
+	UNION ALL
+	SELECT "ds"."id", "ds"."name", 'dataset' AS "element_type" FROM datasets "ds"
+		JOIN dataproducts "dp" ON "ds"."dataproduct_id" = "dp"."id"
+	WHERE "dp"."deleted" IS NULL
+		AND "ds"."lifecycle_status" != 'retired'
+		AND ("ds"."name" ILIKE @prefix::text || '%' OR "ds"."name" ILIKE '% ' || @prefix::text || '%')
+	UNION ALL
+	SELECT "id", "name", 'story' AS "element_type" FROM stories
+	WHERE "deleted" IS NULL
+		AND ("name" ILIKE @prefix::text || '%' OR "name" ILIKE '% ' || @prefix::text || '%')
+	UNION ALL
+	SELECT "id", "name", 'team' AS "element_type" FROM tk_teams
+	WHERE "name" ILIKE @prefix::text || '%' OR "name" ILIKE '% ' || @prefix::text || '%'
+)
+SELECT
+	"id",
+	"name"::text,
+	"element_type"::text
+FROM
+	"names"
+ORDER BY
+	"name" ILIKE @prefix::text || '%' DESC,
+	similarity("name", @term::text) DESC,
+	"name" ASC
+LIMIT @lim;
+
+-- name: SearchSuggestKeywords :many
+SELECT
+	"keyword"::text,
+	count(1) AS "count"
+FROM
+	(
+		SELECT unnest("ds"."keywords") AS "keyword" FROM datasets "ds"
+			JOIN dataproducts "dp" ON "ds"."dataproduct_id" = "dp"."id"
+		WHERE "dp"."deleted" IS NULL
+		UNION ALL
+		SELECT unnest("keywords") AS "keyword" FROM stories
+		WHERE "deleted" IS NULL
+	) AS "keywords"
+WHERE
+	"keyword" ILIKE @prefix::text || '%'
+GROUP BY
+	"keyword"
+ORDER BY
+	"count" DESC,
+	"keyword" ASC
+LIMIT @lim;
@@ -4,8 +4,84 @@ FROM story_with_teamkatalogen_view
 WHERE id = ANY (@ids::uuid[])
 ORDER BY last_modified DESC;
 
--- name: GetStoriesWithTeamkatalogenByGroups :many
-SELECT *
-FROM story_with_teamkatalogen_view swtv
-WHERE "group" = ANY (@groups::text[])
-ORDER BY swtv."group", swtv.name ASC;
\ No newline at end of file
+-- name: GetStoriesWithTeamkatalogenByGroupsPage :many
+(
+    SELECT sqlc.embed(swtv)
+    FROM story_with_teamkatalogen_view swtv
+    WHERE @sort::text = 'name' AND NOT @descending::bool
+    AND swtv."group" = ANY(@groups::text[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (swtv.name, swtv.id) > (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY swtv.name ASC, swtv.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(swtv)
+    FROM story_with_teamkatalogen_view swtv
+    WHERE @sort::text = 'name' AND @descending::bool
+    AND swtv."group" = ANY(@groups::text[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (swtv.name, swtv.id) < (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY swtv.name DESC, swtv.id DESC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(swtv)
+    FROM story_with_teamkatalogen_view swtv
+    WHERE @sort::text = 'created' AND NOT @descending::bool
+    AND swtv."group" = ANY(@groups::text[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (swtv.created, swtv.id) > (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY swtv.created ASC, swtv.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(swtv)
+    FROM story_with_teamkatalogen_view swtv
+    WHERE @sort::text = 'created' AND @descending::bool
+    AND swtv."group" = ANY(@groups::text[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (swtv.created, swtv.id) < (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY swtv.created DESC, swtv.id DESC
+    LIMIT @lim::int
+);
+
+-- name: GetStoriesByProductAreaPage :many
+(
+    SELECT sqlc.embed(swtv)
+    FROM story_with_teamkatalogen_view swtv
+    WHERE @sort::text = 'name' AND NOT @descending::bool
+    AND swtv.team_id = ANY(@team_id::uuid[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (swtv.name, swtv.id) > (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY swtv.name ASC, swtv.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(swtv)
+    FROM story_with_teamkatalogen_view swtv
+    WHERE @sort::text = 'name' AND @descending::bool
+    AND swtv.team_id = ANY(@team_id::uuid[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (swtv.name, swtv.id) < (@after_name::text, sqlc.narg('after_id')::uuid))
+    ORDER BY swtv.name DESC, swtv.id DESC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(swtv)
+    FROM story_with_teamkatalogen_view swtv
+    WHERE @sort::text = 'created' AND NOT @descending::bool
+    AND swtv.team_id = ANY(@team_id::uuid[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (swtv.created, swtv.id) > (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY swtv.created ASC, swtv.id ASC
+    LIMIT @lim::int
+)
+UNION ALL
+(
+    SELECT sqlc.embed(swtv)
+    FROM story_with_teamkatalogen_view swtv
+    WHERE @sort::text = 'created' AND @descending::bool
+    AND swtv.team_id = ANY(@team_id::uuid[])
+    AND (sqlc.narg('after_id')::uuid IS NULL OR (swtv.created, swtv.id) < (@after_created::timestamptz, sqlc.narg('after_id')::uuid))
+    ORDER BY swtv.created DESC, swtv.id DESC
+    LIMIT @lim::int
+);
@@ -43,17 +43,18 @@ RETURNING *;
 -- name: GetStory :one
 SELECT *
 FROM stories
-WHERE id = @id;
+WHERE id = @id AND deleted IS NULL;
 
 -- name: GetStories :many
 SELECT *
 FROM stories
+WHERE deleted IS NULL
 ORDER BY last_modified DESC;
 
 -- name: GetStoriesByIDs :many
 SELECT *
 FROM stories
-WHERE id = ANY (@ids::uuid[])
+WHERE id = ANY (@ids::uuid[]) AND deleted IS NULL
 ORDER BY last_modified DESC;
 
 -- name: GetStoriesByProductArea :many
@@ -65,13 +66,13 @@ ORDER BY last_modified DESC;
 -- name: GetStoriesByTeam :many
 SELECT *
 FROM stories
-WHERE team_id = @team_id
+WHERE team_id = @team_id AND deleted IS NULL
 ORDER BY last_modified DESC;
 
 -- name: GetStoriesNumberByTeam :one
 SELECT COUNT(*) as "count"
 FROM stories
-WHERE team_id = @team_id;
+WHERE team_id = @team_id AND deleted IS NULL;
 
 -- name: UpdateStory :one
 UPDATE stories
@@ -83,6 +84,7 @@ SET
     "team_id" = @team_id,
     "group" = @owner_group
 WHERE id = @id
+AND (sqlc.narg('if_match')::timestamptz IS NULL OR last_modified = sqlc.narg('if_match'))
 RETURNING *;
 
 -- name: DeleteStory :exec
@@ -92,7 +94,7 @@ WHERE id = @id;
 -- name: GetStoriesByGroups :many
 SELECT *
 FROM stories
-WHERE "group" = ANY (@groups::text[])
+WHERE "group" = ANY (@groups::text[]) AND deleted IS NULL
 ORDER BY last_modified DESC;
 
 -- name: ReplaceStoriesTag :exec
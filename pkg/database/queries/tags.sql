@@ -6,6 +6,7 @@ FROM (
          UNION ALL
          SELECT unnest(s.keywords) as keyword
             FROM stories s
+            WHERE s.deleted IS NULL
     ) keywords
 GROUP BY keyword
 ORDER BY keywords."count" DESC;
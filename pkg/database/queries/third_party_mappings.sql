@@ -26,6 +26,11 @@ WHERE "dataset_id" NOT IN (
     SELECT dataset_id FROM metabase_metadata
     WHERE "sync_completed" IS NOT NULL
 )
+AND "dataset_id" NOT IN (
+    SELECT ds.id FROM datasets ds
+    JOIN dataproducts dp ON ds.dataproduct_id = dp.id
+    WHERE dp.deleted IS NOT NULL
+)
 AND 'metabase' = ANY(services);
 
 -- name: GetRemoveMetabaseDatasetMappings :many
@@ -155,6 +155,8 @@ const (
 	// The error is logged and http.StatusForbidden (403) is sent.
 	Unauthorized
 	UnsupportedMediaType // Unsupported Media Type
+	PreconditionFailed   // The resource has been modified since the version the request is based on
+	PreconditionRequired // The request must be conditional, e.g. have an If-Match header
 )
 
 func (k Kind) String() string {
@@ -189,6 +191,10 @@ func (k Kind) String() string {
 		return "unauthorized request"
 	case UnsupportedMediaType:
 		return "unsupported media type"
+	case PreconditionFailed:
+		return "precondition failed"
+	case PreconditionRequired:
+		return "precondition required"
 	default:
 		return "unknown error kind"
 	}
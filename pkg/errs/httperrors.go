@@ -267,6 +267,10 @@ func httpErrorStatusCode(k Kind) int {
 		return http.StatusNotFound
 	case UnsupportedMediaType:
 		return http.StatusUnsupportedMediaType
+	case PreconditionFailed:
+		return http.StatusPreconditionFailed
+	case PreconditionRequired:
+		return http.StatusPreconditionRequired
 	// the zero value of Kind is Other, so if no Kind is present
 	// in the error, Other is used. Errors should always have a
 	// Kind set, otherwise, a 500 will be returned and no
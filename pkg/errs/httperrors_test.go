@@ -30,6 +30,8 @@ func Test_httpErrorStatusCode(t *testing.T) {
 		{"BrokenLink", args{k: BrokenLink}, http.StatusBadRequest},
 		{"Validation", args{k: Validation}, http.StatusBadRequest},
 		{"InvalidRequest", args{k: InvalidRequest}, http.StatusBadRequest},
+		{"PreconditionFailed", args{k: PreconditionFailed}, http.StatusPreconditionFailed},
+		{"PreconditionRequired", args{k: PreconditionRequired}, http.StatusPreconditionRequired},
 		{"Other", args{k: Other}, http.StatusInternalServerError},
 		{"IO", args{k: IO}, http.StatusInternalServerError},
 		{"Internal", args{k: Internal}, http.StatusInternalServerError},
@@ -4,27 +4,39 @@ import (
 	"context"
 	"time"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/google/uuid"
 )
 
 type AccessStorage interface {
 	CreateAccessRequestForDataset(ctx context.Context, datasetID uuid.UUID, pollyDocumentationID uuid.NullUUID, subject, owner string, expires *time.Time) (*AccessRequest, error)
+	// CreateRenewalAccessRequest creates an access request for the subject and
+	// owner of the access, which extends the access when approved
+	CreateRenewalAccessRequest(ctx context.Context, access *Access, pollyDocumentationID uuid.NullUUID, expires *time.Time) (*AccessRequest, error)
 	DeleteAccessRequest(ctx context.Context, accessRequestID uuid.UUID) error
 	DenyAccessRequest(ctx context.Context, user *User, accessRequestID uuid.UUID, reason *string) error
 	GetAccessRequest(ctx context.Context, accessRequestID uuid.UUID) (*AccessRequest, error)
 	GetAccessToDataset(ctx context.Context, id uuid.UUID) (*Access, error)
+	GetPendingRenewalAccessRequest(ctx context.Context, accessID uuid.UUID) (*AccessRequest, error)
 	GetUnrevokedExpiredAccess(ctx context.Context) ([]*Access, error)
 	GrantAccessToDatasetAndApproveRequest(ctx context.Context, user *User, datasetID uuid.UUID, subject, accessRequestOwner string, accessRequestID uuid.UUID, expires *time.Time) error
 	GrantAccessToDatasetAndRenew(ctx context.Context, datasetID uuid.UUID, expires *time.Time, subject, owner, granter string) error
-	ListAccessRequestsForDataset(ctx context.Context, datasetID uuid.UUID) ([]*AccessRequest, error)
-	ListAccessRequestsForOwner(ctx context.Context, owner []string) ([]*AccessRequest, error)
+	ListAccessRequestsForDataset(ctx context.Context, datasetID uuid.UUID, page PageRequest) (*Page[*AccessRequest], error)
+	ListAccessRequestsForGranter(ctx context.Context, groups []string, page PageRequest) (*Page[*AccessRequestForGranter], error)
+	ListAccessRequestsForOwner(ctx context.Context, owner []string, page PageRequest) (*Page[*AccessRequest], error)
 	ListActiveAccessToDataset(ctx context.Context, datasetID uuid.UUID) ([]*Access, error)
+	// ListActiveAccessToTablePatternDatasources returns the active accesses
+	// to datasets with a BigQuery datasource covering the tables of a dataset
+	// that match a table pattern
+	ListActiveAccessToTablePatternDatasources(ctx context.Context) ([]*Access, error)
+	ListExpiringAccessForGroups(ctx context.Context, groups []string, days int) ([]*ExpiringAccess, error)
+	RenewAccessToDatasetAndApproveRequest(ctx context.Context, user *User, accessID, accessRequestID uuid.UUID, expires *time.Time) error
 	RevokeAccessToDataset(ctx context.Context, id uuid.UUID) error
 	UpdateAccessRequest(ctx context.Context, input UpdateAccessRequestDTO) error
 }
 
 type AccessService interface {
-	GetAccessRequests(ctx context.Context, datasetID uuid.UUID) (*AccessRequestsWrapper, error)
+	GetAccessRequests(ctx context.Context, datasetID uuid.UUID, page PageRequest) (*Page[*AccessRequest], error)
 	CreateAccessRequest(ctx context.Context, user *User, input NewAccessRequestDTO) error
 	DeleteAccessRequest(ctx context.Context, user *User, accessRequestID uuid.UUID) error
 	UpdateAccessRequest(ctx context.Context, input UpdateAccessRequestDTO) error
@@ -32,6 +44,12 @@ type AccessService interface {
 	DenyAccessRequest(ctx context.Context, user *User, accessRequestID uuid.UUID, reason *string) error
 	RevokeAccessToDataset(ctx context.Context, user *User, id uuid.UUID, gcpProjectID string) error
 	GrantAccessToDataset(ctx context.Context, user *User, input GrantAccessData, gcpProjectID string) error
+	// RenewAccess requests a renewal of the access on behalf of its subject or
+	// owner, reusing the Polly purpose of the original access request
+	RenewAccess(ctx context.Context, user *User, accessID uuid.UUID, input RenewAccessDTO) (*AccessRequest, error)
+	// GetExpiringAccess returns the accesses to the datasets owned by the
+	// groups of the user that expire within the days
+	GetExpiringAccess(ctx context.Context, user *User, days int) (*ExpiringAccesses, error)
 }
 
 type Access struct {
@@ -46,6 +64,17 @@ type Access struct {
 	AccessRequestID *uuid.UUID `json:"accessRequestID"`
 }
 
+// HasSubject returns true if one of the accesses is granted to the subject
+func HasSubject(accesses []*Access, subject string) bool {
+	for _, a := range accesses {
+		if a.Subject == subject {
+			return true
+		}
+	}
+
+	return false
+}
+
 type NewAccessRequestDTO struct {
 	DatasetID   uuid.UUID   `json:"datasetID"`
 	Subject     *string     `json:"subject"`
@@ -75,6 +104,10 @@ type AccessRequest struct {
 	Owner       string              `json:"owner"`
 	Polly       *Polly              `json:"polly"`
 	Reason      *string             `json:"reason"`
+	// ApprovalRuleID is the automatic approval rule that approved the request
+	ApprovalRuleID *uuid.UUID `json:"approvalRuleID"`
+	// RenewsAccessID is the access that is extended when the request is approved
+	RenewsAccessID *uuid.UUID `json:"renewsAccessID"`
 }
 
 type AccessRequestForGranter struct {
@@ -85,16 +118,29 @@ type AccessRequestForGranter struct {
 	DataproductName string    `json:"dataproductName"`
 }
 
-type AccessRequestsWrapper struct {
-	AccessRequests []*AccessRequest `json:"accessRequests"`
-}
-
 const (
 	SubjectTypeUser           string = "user"
 	SubjectTypeGroup          string = "group"
 	SubjectTypeServiceAccount string = "serviceAccount"
 )
 
+type ExpiringAccesses struct {
+	Accesses []*ExpiringAccess `json:"accesses"`
+}
+
+type RenewAccessDTO struct {
+	Expires *time.Time `json:"expires"`
+	// Polly replaces the purpose of the access, the purpose of the original
+	// access request is kept when it is not given
+	Polly *PollyInput `json:"polly"`
+}
+
+func (r RenewAccessDTO) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Expires, validation.Min(time.Now()).Error("must be in the future")),
+	)
+}
+
 type GrantAccessData struct {
 	DatasetID   uuid.UUID  `json:"datasetID"`
 	Expires     *time.Time `json:"expires"`
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/google/uuid"
+)
+
+type AccessApprovalRuleStorage interface {
+	CreateAccessApprovalRule(ctx context.Context, rule NewAccessApprovalRule, createdBy string) (*AccessApprovalRule, error)
+	GetAccessApprovalRule(ctx context.Context, id uuid.UUID) (*AccessApprovalRule, error)
+	GetAccessApprovalRulesForDataset(ctx context.Context, datasetID uuid.UUID) ([]*AccessApprovalRule, error)
+	DeleteAccessApprovalRule(ctx context.Context, id uuid.UUID) error
+	SetAccessRequestApprovalRule(ctx context.Context, accessRequestID, ruleID uuid.UUID) error
+}
+
+type AccessApprovalRuleService interface {
+	GetAccessApprovalRules(ctx context.Context, user *User, datasetID uuid.UUID) (*AccessApprovalRules, error)
+	CreateAccessApprovalRule(ctx context.Context, user *User, input NewAccessApprovalRule) (*AccessApprovalRule, error)
+	DeleteAccessApprovalRule(ctx context.Context, user *User, id uuid.UUID) error
+}
+
+// AccessApprovalRule approves an access request to the dataset automatically
+// when all of its conditions match, a condition that is not set matches any
+// access request
+type AccessApprovalRule struct {
+	ID        uuid.UUID `json:"id"`
+	DatasetID uuid.UUID `json:"datasetID"`
+	// SubjectType is the type of subject the access is requested for
+	SubjectType *string `json:"subjectType"`
+	// SubjectGroup is the group the subject must belong to, a user must be a
+	// member, a group must be the group, and a service account must be owned
+	// by the group
+	SubjectGroup *string `json:"subjectGroup"`
+	// Pii is the pii level the dataset must have
+	Pii *PiiLevel `json:"pii"`
+	// MaxExpiryDays is how many days from now the requested access may last,
+	// an access without expiry never matches
+	MaxExpiryDays *int `json:"maxExpiryDays"`
+	// RequirePolly requires that a Polly purpose is attached to the request
+	RequirePolly bool      `json:"requirePolly"`
+	CreatedBy    string    `json:"createdBy"`
+	Created      time.Time `json:"created"`
+}
+
+type AccessApprovalRules struct {
+	Rules []*AccessApprovalRule `json:"rules"`
+}
+
+type NewAccessApprovalRule struct {
+	DatasetID     uuid.UUID `json:"datasetID"`
+	SubjectType   *string   `json:"subjectType"`
+	SubjectGroup  *string   `json:"subjectGroup"`
+	Pii           *PiiLevel `json:"pii"`
+	MaxExpiryDays *int      `json:"maxExpiryDays"`
+	RequirePolly  bool      `json:"requirePolly"`
+}
+
+func (r NewAccessApprovalRule) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.SubjectType, validation.NilOrNotEmpty, validation.In(SubjectTypeUser, SubjectTypeGroup, SubjectTypeServiceAccount)),
+		validation.Field(&r.SubjectGroup, validation.NilOrNotEmpty, is.EmailFormat),
+		validation.Field(&r.Pii, validation.NilOrNotEmpty, validation.In(PiiLevelSensitive, PiiLevelAnonymised, PiiLevelNone)),
+		validation.Field(&r.MaxExpiryDays, validation.NilOrNotEmpty, validation.Min(1)),
+	)
+}
@@ -2,8 +2,14 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
 	"time"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
 	"github.com/google/uuid"
 )
 
@@ -23,11 +29,33 @@ type BigQueryAPI interface {
 	MakeBigQueryUrlForJoinableViews(name, projectID, datasetID, tableID string) string
 	CreateJoinableViewsForUser(ctx context.Context, name string, datasources []JoinableViewDatasource) (string, string, map[string]string, error)
 	CreateJoinableView(ctx context.Context, joinableDatasetID string, datasource JoinableViewDatasource) (string, error)
-	ComposeJoinableViewQuery(plainTable DatasourceForJoinableView, joinableDatasetID string) string
+	ComposeJoinableViewQuery(plainTable DatasourceForJoinableView, joinableDatasetID string, columnTypes map[string]string) (string, error)
 	TableMetadata(ctx context.Context, projectID string, datasetID string, tableID string) (BigqueryMetadata, error)
+	// TableRows reads at most maxRows rows from a table without running a
+	// query, it does not work for views
+	TableRows(ctx context.Context, projectID, datasetID, tableID string, maxRows int) (*BigQueryTableRows, error)
+	// EstimateQueryBytes returns the number of bytes the query would process
+	EstimateQueryBytes(ctx context.Context, query string) (int64, error)
+	// QueryReferencedTables returns the tables the query would read, on the
+	// form project.dataset.table
+	QueryReferencedTables(ctx context.Context, query string) ([]string, error)
+	// Query runs the query and reads at most maxRows rows, the query fails
+	// if it would bill more than maxBytesBilled bytes, zero means no limit
+	Query(ctx context.Context, query string, maxRows int, maxBytesBilled int64) (*BigQueryQueryResult, error)
+	// ComposeProfileQuery returns a query with a single row, the row count
+	// followed by the null count, distinct count, min and max of each column
+	ComposeProfileQuery(projectID, datasetID, tableID string, columns []ProfileColumn) (string, error)
+	// ComposeTopValuesQuery returns a query with the column name, value and
+	// count of the most frequent values of each column
+	ComposeTopValuesQuery(projectID, datasetID, tableID string, columns []ProfileColumn, limit int) (string, error)
+	// ComposeQualityCheckQuery returns a query with a single row, the value
+	// measured by the check
+	ComposeQualityCheckQuery(projectID, datasetID, tableID string, check *QualityCheck) (string, error)
+	GrantDataset(ctx context.Context, projectID, datasetID, member string) error
+	RevokeDataset(ctx context.Context, projectID, datasetID, member string) error
 	GetTables(ctx context.Context, projectID, datasetID string) ([]*BigQueryTable, error)
 	GetDatasets(ctx context.Context, projectID string) ([]string, error)
-	CreatePseudonymisedView(ctx context.Context, projectID, datasetID, tableID string, piiColumns []string) (string, string, string, error)
+	CreatePseudonymisedView(ctx context.Context, projectID, datasetID, tableID string, pseudoColumns []PseudoColumn) (string, string, string, error)
 	DeleteJoinableView(ctx context.Context, joinableViewName, refProjectID, refDatasetID, refTableID string) error
 	DeletePseudoView(ctx context.Context, pseudoProjectID, pseudoDatasetID, pseudoTableID string) error
 	DeleteJoinableDataset(ctx context.Context, datasetID string) error
@@ -61,11 +89,40 @@ const (
 	Snapshot BigQueryTableType = "SNAPSHOT"
 )
 
+// BigQueryScope decides whether a BigQuery datasource is a single table, or
+// every table in a BigQuery dataset, optionally limited by a table pattern.
+type BigQueryScope string
+
+const (
+	BigQueryScopeTable   BigQueryScope = "table"
+	BigQueryScopeDataset BigQueryScope = "dataset"
+)
+
+// MatchesTablePattern reports whether the table is included by the pattern,
+// which uses the same syntax as path.Match. An empty pattern includes all
+// tables.
+func MatchesTablePattern(pattern, table string) bool {
+	if pattern == "" {
+		return true
+	}
+
+	ok, err := path.Match(pattern, table)
+
+	return err == nil && ok
+}
+
+type BigQueryMemberTable struct {
+	Name         string            `json:"name"`
+	TableType    BigQueryTableType `json:"tableType"`
+	LastModified time.Time         `json:"lastModified"`
+	Schema       []*BigqueryColumn `json:"schema"`
+}
+
 type DatasourceForJoinableView struct {
 	Project       string
 	Dataset       string
 	Table         string
-	PseudoColumns []string
+	PseudoColumns []PseudoColumn
 }
 
 type JoinableViewDatasource struct {
@@ -82,18 +139,36 @@ type GCPProject struct {
 type BigQuery struct {
 	ID            uuid.UUID
 	DatasetID     uuid.UUID
-	ProjectID     string            `json:"projectID"`
-	Dataset       string            `json:"dataset"`
-	Table         string            `json:"table"`
-	TableType     BigQueryTableType `json:"tableType"`
-	LastModified  time.Time         `json:"lastModified"`
-	Created       time.Time         `json:"created"`
-	Expires       *time.Time        `json:"expired"`
-	Description   string            `json:"description"`
-	PiiTags       *string           `json:"piiTags"`
-	MissingSince  *time.Time        `json:"missingSince"`
-	PseudoColumns []string          `json:"pseudoColumns"`
-	Schema        []*BigqueryColumn `json:"schema"`
+	ProjectID     string                 `json:"projectID"`
+	Dataset       string                 `json:"dataset"`
+	Table         string                 `json:"table"`
+	TableType     BigQueryTableType      `json:"tableType"`
+	LastModified  time.Time              `json:"lastModified"`
+	Created       time.Time              `json:"created"`
+	Expires       *time.Time             `json:"expired"`
+	Description   string                 `json:"description"`
+	PiiTags       *string                `json:"piiTags"`
+	MissingSince  *time.Time             `json:"missingSince"`
+	PseudoColumns []PseudoColumn         `json:"pseudoColumns"`
+	Schema        []*BigqueryColumn      `json:"schema"`
+	Scope         BigQueryScope          `json:"scope"`
+	TablePattern  string                 `json:"tablePattern"`
+	Tables        []*BigQueryMemberTable `json:"tables"`
+}
+
+// TableNames returns the tables covered by the datasource, which for a
+// dataset scoped datasource are the member tables found by the last sync.
+func (b *BigQuery) TableNames() []string {
+	if b.Scope != BigQueryScopeDataset {
+		return []string{b.Table}
+	}
+
+	names := make([]string, len(b.Tables))
+	for i, t := range b.Tables {
+		names[i] = t.Name
+	}
+
+	return names
 }
 
 type BQTables struct {
@@ -106,13 +181,18 @@ type BQDatasets struct {
 
 type BQColumns struct {
 	BQColumns []*BigqueryColumn `json:"bqColumns"`
+	// Profile is the latest profile of the table without the min, max and
+	// top values, it is not set if the table has not been profiled
+	Profile *DatasetProfile `json:"profile"`
 }
 
 type NewBigQuery struct {
-	ProjectID string  `json:"projectID"`
-	Dataset   string  `json:"dataset"`
-	Table     string  `json:"table"`
-	PiiTags   *string `json:"piiTags"`
+	ProjectID    string        `json:"projectID"`
+	Dataset      string        `json:"dataset"`
+	Table        string        `json:"table"`
+	PiiTags      *string       `json:"piiTags"`
+	Scope        BigQueryScope `json:"scope"`
+	TablePattern string        `json:"tablePattern"`
 }
 
 type BigquerySchema struct {
@@ -120,17 +200,33 @@ type BigquerySchema struct {
 }
 
 type BigqueryMetadata struct {
-	Schema       BigquerySchema    `json:"schema"`
-	TableType    BigQueryTableType `json:"tableType"`
-	LastModified time.Time         `json:"lastModified"`
-	Created      time.Time         `json:"created"`
-	Expires      time.Time         `json:"expires"`
-	Description  string            `json:"description"`
+	Schema       BigquerySchema         `json:"schema"`
+	TableType    BigQueryTableType      `json:"tableType"`
+	LastModified time.Time              `json:"lastModified"`
+	Created      time.Time              `json:"created"`
+	Expires      time.Time              `json:"expires"`
+	Description  string                 `json:"description"`
+	Tables       []*BigQueryMemberTable `json:"tables"`
+}
+
+// BigQueryTableRows are rows read from a table, the values of each row are
+// in the same order as the columns in the schema
+type BigQueryTableRows struct {
+	Schema []*BigqueryColumn
+	Rows   [][]any
+}
+
+// BigQueryQueryResult is the result of a query, the values of each row are
+// in the same order as the columns in the schema
+type BigQueryQueryResult struct {
+	BytesProcessed int64
+	Schema         []*BigqueryColumn
+	Rows           [][]any
 }
 
 type BigQueryDataSourceUpdate struct {
 	PiiTags       *string
-	PseudoColumns []string
+	PseudoColumns []PseudoColumn
 	DatasetID     uuid.UUID
 }
 
@@ -147,3 +243,140 @@ type BigQueryTable struct {
 	Name         string            `json:"name"`
 	Type         BigQueryTableType `json:"type"`
 }
+
+// PseudoStrategy describes how the values of a column are
+// pseudonymised in a pseudo view
+type PseudoStrategy string
+
+const (
+	// PseudoStrategySaltedHash replaces the value with a salted SHA256 hash,
+	// the column can be joined on in joinable views
+	PseudoStrategySaltedHash PseudoStrategy = "salted_hash"
+	// PseudoStrategyTruncateYear truncates a date or a timestamp to the first day of the year
+	PseudoStrategyTruncateYear PseudoStrategy = "truncate_year"
+	// PseudoStrategyBucket keeps the first Size characters of a string, e.g., of a postcode,
+	// or rounds a number down to the nearest multiple of Size
+	PseudoStrategyBucket PseudoStrategy = "bucket"
+	// PseudoStrategyNull replaces the value with NULL, e.g., for free text
+	PseudoStrategyNull PseudoStrategy = "null"
+)
+
+// pseudoStrategyColumnTypes contains the column types each strategy can be applied to
+var pseudoStrategyColumnTypes = map[PseudoStrategy][]string{
+	// The value is cast to a string before it is hashed
+	PseudoStrategySaltedHash: {
+		"STRING", "BYTES", "INTEGER", "FLOAT", "BOOLEAN", "TIMESTAMP", "DATE", "TIME",
+		"DATETIME", "NUMERIC", "BIGNUMERIC", "INTERVAL",
+	},
+	PseudoStrategyTruncateYear: {"DATE", "DATETIME", "TIMESTAMP"},
+	PseudoStrategyBucket:       {"STRING", "INTEGER", "FLOAT", "NUMERIC", "BIGNUMERIC"},
+	// Records and ranges can not be cast from NULL without their field types
+	PseudoStrategyNull: {
+		"STRING", "BYTES", "INTEGER", "FLOAT", "BOOLEAN", "TIMESTAMP", "DATE", "TIME",
+		"DATETIME", "NUMERIC", "BIGNUMERIC", "GEOGRAPHY", "INTERVAL", "JSON",
+	},
+}
+
+// PseudoColumn defines how a column is pseudonymised in a pseudo view
+type PseudoColumn struct {
+	Name     string         `json:"name"`
+	Strategy PseudoStrategy `json:"strategy"`
+	// Size of the buckets, only used by the bucket strategy
+	Size int `json:"size,omitempty"`
+}
+
+// UnmarshalJSON also accepts a plain column name, which is what
+// clients used to send, and pseudonymises it with a salted hash
+func (c *PseudoColumn) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*c = PseudoColumn{
+			Name:     name,
+			Strategy: PseudoStrategySaltedHash,
+		}
+
+		return nil
+	}
+
+	type pseudoColumn PseudoColumn
+
+	var column pseudoColumn
+	if err := json.Unmarshal(data, &column); err != nil {
+		return err
+	}
+
+	*c = PseudoColumn(column)
+
+	return nil
+}
+
+func (c PseudoColumn) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Name, validation.Required),
+		validation.Field(&c.Strategy, validation.Required, validation.In(
+			PseudoStrategySaltedHash,
+			PseudoStrategyTruncateYear,
+			PseudoStrategyBucket,
+			PseudoStrategyNull,
+		)),
+		validation.Field(&c.Size,
+			validation.When(c.Strategy == PseudoStrategyBucket, validation.Required, validation.Min(1)).
+				Else(validation.Empty),
+		),
+	)
+}
+
+// SupportsColumnType returns true if the strategy of the pseudo column
+// can be applied to a column of the given BigQuery type
+func (c PseudoColumn) SupportsColumnType(columnType string) bool {
+	types, ok := pseudoStrategyColumnTypes[c.Strategy]
+	if !ok {
+		return false
+	}
+
+	for _, t := range types {
+		if t == columnType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidatePseudoColumns validates the pseudo columns, and checks that they
+// exist in the schema and that their strategies support the column types.
+// None of the strategies support repeated columns, as they apply to a single value.
+func ValidatePseudoColumns(columns []PseudoColumn, schema []*BigqueryColumn) error {
+	schemaColumns := map[string]*BigqueryColumn{}
+	for _, c := range schema {
+		schemaColumns[c.Name] = c
+	}
+
+	seen := map[string]bool{}
+	for _, c := range columns {
+		if err := c.Validate(); err != nil {
+			return fmt.Errorf("pseudo column %s: %w", c.Name, err)
+		}
+
+		if seen[c.Name] {
+			return fmt.Errorf("pseudo column %s: defined more than once", c.Name)
+		}
+
+		seen[c.Name] = true
+
+		column, ok := schemaColumns[c.Name]
+		if !ok {
+			return fmt.Errorf("pseudo column %s: does not exist in the table schema", c.Name)
+		}
+
+		if strings.EqualFold(column.Mode, "REPEATED") {
+			return fmt.Errorf("pseudo column %s: repeated columns are not supported", c.Name)
+		}
+
+		if !c.SupportsColumnType(column.Type) {
+			return fmt.Errorf("pseudo column %s: strategy %s does not support column type %s", c.Name, c.Strategy, column.Type)
+		}
+	}
+
+	return nil
+}
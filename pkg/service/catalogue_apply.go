@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+type CatalogueApplyService interface {
+	Apply(ctx context.Context, teamEmail string, spec *CatalogueSpec, opts CatalogueApplyOptions) (*CataloguePlan, error)
+}
+
+// CatalogueSpec is the declarative description of the dataproducts,
+// datasets and standing access grants owned by a team
+type CatalogueSpec struct {
+	Dataproducts []*DataproductSpec `json:"dataproducts" yaml:"dataproducts"`
+}
+
+func (s CatalogueSpec) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Dataproducts, validation.By(uniqueNames(func(dp *DataproductSpec) string {
+			return dp.Name
+		}))),
+	)
+}
+
+type DataproductSpec struct {
+	Name             string         `json:"name" yaml:"name"`
+	Description      *string        `json:"description" yaml:"description"`
+	TeamkatalogenURL *string        `json:"teamkatalogenURL" yaml:"teamkatalogenURL"`
+	TeamContact      *string        `json:"teamContact" yaml:"teamContact"`
+	TeamID           *uuid.UUID     `json:"teamID" yaml:"teamID"`
+	Datasets         []*DatasetSpec `json:"datasets" yaml:"datasets"`
+}
+
+func (s DataproductSpec) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Name, validation.Required),
+		validation.Field(&s.Datasets, validation.By(uniqueNames(func(ds *DatasetSpec) string {
+			return ds.Name
+		}))),
+	)
+}
+
+type DatasetSpec struct {
+	Name                     string        `json:"name" yaml:"name"`
+	Description              *string       `json:"description" yaml:"description"`
+	Repo                     *string       `json:"repo" yaml:"repo"`
+	Pii                      PiiLevel      `json:"pii" yaml:"pii"`
+	Keywords                 []string      `json:"keywords" yaml:"keywords"`
+	AnonymisationDescription *string       `json:"anonymisationDescription" yaml:"anonymisationDescription"`
+	TargetUser               *string       `json:"targetUser" yaml:"targetUser"`
+	BigQuery                 BigQuerySpec  `json:"bigquery" yaml:"bigquery"`
+	Access                   []*AccessSpec `json:"access" yaml:"access"`
+}
+
+func (s DatasetSpec) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Name, validation.Required),
+		validation.Field(&s.Pii, validation.Required, validation.In(PiiLevelSensitive, PiiLevelAnonymised, PiiLevelNone)),
+		validation.Field(&s.BigQuery),
+		validation.Field(&s.Access, validation.By(uniqueNames(func(a *AccessSpec) string {
+			return a.Subject
+		}))),
+	)
+}
+
+type BigQuerySpec struct {
+	ProjectID    string        `json:"projectID" yaml:"projectID"`
+	Dataset      string        `json:"dataset" yaml:"dataset"`
+	Table        string        `json:"table" yaml:"table"`
+	Scope        BigQueryScope `json:"scope" yaml:"scope"`
+	TablePattern string        `json:"tablePattern" yaml:"tablePattern"`
+}
+
+func (s BigQuerySpec) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.ProjectID, validation.Required),
+		validation.Field(&s.Dataset, validation.Required),
+		validation.Field(&s.Table, validation.When(s.Scope != BigQueryScopeDataset, validation.Required)),
+		validation.Field(&s.Scope, validation.In(BigQueryScopeTable, BigQueryScopeDataset)),
+	)
+}
+
+// AccessSpec is a standing access grant, where the subject is prefixed
+// with its type, e.g., user:ola.nordmann@nav.no
+type AccessSpec struct {
+	Subject string     `json:"subject" yaml:"subject"`
+	Owner   *string    `json:"owner" yaml:"owner"`
+	Expires *time.Time `json:"expires" yaml:"expires"`
+}
+
+func (s AccessSpec) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Subject, validation.Required, validation.By(func(value interface{}) error {
+			subjectType, subject, _ := strings.Cut(value.(string), ":")
+			if subject == "" {
+				return fmt.Errorf("must be prefixed with the subject type")
+			}
+
+			return validation.In(SubjectTypeUser, SubjectTypeGroup, SubjectTypeServiceAccount).
+				Error("must be prefixed with user, group or serviceAccount").
+				Validate(subjectType)
+		})),
+	)
+}
+
+func uniqueNames[T any](name func(T) string) validation.RuleFunc {
+	return func(value interface{}) error {
+		seen := map[string]bool{}
+
+		for _, v := range value.([]T) {
+			n := name(v)
+			if seen[n] {
+				return fmt.Errorf("%s is specified more than once", n)
+			}
+
+			seen[n] = true
+		}
+
+		return nil
+	}
+}
+
+type CatalogueApplyOptions struct {
+	// PlanOnly computes the plan without executing it
+	PlanOnly bool
+	// Prune deletes the dataproducts and datasets, and revokes the access
+	// grants, that are not in the spec
+	Prune bool
+}
+
+type CatalogueActionType string
+
+const (
+	CatalogueActionCreate CatalogueActionType = "create"
+	CatalogueActionUpdate CatalogueActionType = "update"
+	CatalogueActionDelete CatalogueActionType = "delete"
+	CatalogueActionGrant  CatalogueActionType = "grant"
+	CatalogueActionRevoke CatalogueActionType = "revoke"
+)
+
+type CatalogueResourceKind string
+
+const (
+	CatalogueResourceDataproduct CatalogueResourceKind = "dataproduct"
+	CatalogueResourceDataset     CatalogueResourceKind = "dataset"
+	CatalogueResourceAccess      CatalogueResourceKind = "access"
+)
+
+type CatalogueActionStatus string
+
+const (
+	CatalogueActionStatusPlanned CatalogueActionStatus = "planned"
+	CatalogueActionStatusApplied CatalogueActionStatus = "applied"
+	CatalogueActionStatusFailed  CatalogueActionStatus = "failed"
+	// CatalogueActionStatusSkipped is an action that was not applied, since
+	// an action before it failed
+	CatalogueActionStatusSkipped CatalogueActionStatus = "skipped"
+)
+
+type CataloguePlan struct {
+	Applied bool                   `json:"applied"`
+	Actions []*CataloguePlanAction `json:"actions"`
+}
+
+// StatusCode signals that applying the plan failed part way, the status of
+// the actions tells what was applied before the failure
+func (p *CataloguePlan) StatusCode() int {
+	for _, a := range p.Actions {
+		if a.Status == CatalogueActionStatusFailed {
+			return http.StatusInternalServerError
+		}
+	}
+
+	return http.StatusOK
+}
+
+// CataloguePlanAction is a change needed to bring the catalogue in line
+// with the spec. The path names the resource, e.g., dataproduct/dataset/subject.
+type CataloguePlanAction struct {
+	Action  CatalogueActionType     `json:"action"`
+	Kind    CatalogueResourceKind   `json:"kind"`
+	Path    string                  `json:"path"`
+	ID      *uuid.UUID              `json:"id"`
+	Changes []*CatalogueFieldChange `json:"changes"`
+	Status  CatalogueActionStatus   `json:"status"`
+	Error   *string                 `json:"error"`
+}
+
+type CatalogueFieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
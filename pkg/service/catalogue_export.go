@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+type CatalogueExportStorage interface {
+	GetCatalogueEntries(ctx context.Context, modifiedSince *time.Time) ([]*CatalogueEntry, error)
+}
+
+type CatalogueExportService interface {
+	ExportDCAT(ctx context.Context, opts DCATExportOptions) (*DCATExport, error)
+}
+
+type DCATFormat string
+
+const (
+	DCATFormatJSONLD DCATFormat = "jsonld"
+	DCATFormatTurtle DCATFormat = "turtle"
+)
+
+// ContentType returns the media type the format is served with
+func (f DCATFormat) ContentType() string {
+	switch f {
+	case DCATFormatTurtle:
+		return "text/turtle; charset=utf-8"
+	default:
+		return "application/ld+json; charset=utf-8"
+	}
+}
+
+// DCATField is a field that can be left out of the export, e.g., because
+// it should not be published outside the organisation
+type DCATField string
+
+const (
+	DCATFieldDescription  DCATField = "description"
+	DCATFieldKeywords     DCATField = "keywords"
+	DCATFieldContactPoint DCATField = "contactPoint"
+	DCATFieldPublisher    DCATField = "publisher"
+	DCATFieldPii          DCATField = "pii"
+)
+
+type DCATExportOptions struct {
+	Format DCATFormat
+	// ModifiedSince limits the export to dataproducts where the dataproduct
+	// or one of its datasets has changed since the given time
+	ModifiedSince *time.Time
+	ExcludeFields []DCATField
+	// ExcludeSensitive leaves out datasets containing personal data
+	ExcludeSensitive bool
+}
+
+func (o DCATExportOptions) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.Format, validation.Required, validation.In(DCATFormatJSONLD, DCATFormatTurtle)),
+		validation.Field(&o.ExcludeFields, validation.Each(validation.In(
+			DCATFieldDescription,
+			DCATFieldKeywords,
+			DCATFieldContactPoint,
+			DCATFieldPublisher,
+			DCATFieldPii,
+		).Error(fmt.Sprintf("must be one of %s, %s, %s, %s or %s",
+			DCATFieldDescription,
+			DCATFieldKeywords,
+			DCATFieldContactPoint,
+			DCATFieldPublisher,
+			DCATFieldPii,
+		)))),
+	)
+}
+
+// Excludes returns true if the field should be left out of the export
+func (o DCATExportOptions) Excludes(field DCATField) bool {
+	for _, f := range o.ExcludeFields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DCATCatalogue describes the catalogue itself in the export
+type DCATCatalogue struct {
+	Title         string
+	PublisherName string
+	PublisherURI  string
+	LicenseURI    string
+}
+
+type DCATExport struct {
+	ContentType string
+	Data        []byte
+}
+
+// CatalogueEntry is a dataproduct together with its datasets, as it is
+// published in the catalogue export
+type CatalogueEntry struct {
+	ID               uuid.UUID
+	Name             string
+	Description      *string
+	Slug             string
+	Group            string
+	Created          time.Time
+	LastModified     time.Time
+	TeamName         *string
+	TeamkatalogenURL *string
+	TeamContact      *string
+	Datasets         []*CatalogueEntryDataset
+}
+
+type CatalogueEntryDataset struct {
+	ID              uuid.UUID
+	Name            string
+	Description     *string
+	Slug            string
+	Keywords        []string
+	Pii             PiiLevel
+	Type            DatasourceType
+	LifecycleStatus LifecycleStatus
+	Created         time.Time
+	LastModified    time.Time
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ComplianceStorage interface {
+	// GetPersonalDataInventory returns the datasets that contain personal
+	// data, or have pseudonymised columns, with their active accesses
+	GetPersonalDataInventory(ctx context.Context, filter ComplianceReportFilter) ([]*PersonalDataset, error)
+}
+
+type ComplianceService interface {
+	GetReport(ctx context.Context, user *User, filter ComplianceReportFilter) (*ComplianceReport, error)
+	ExportReport(ctx context.Context, user *User, filter ComplianceReportFilter, format ComplianceExportFormat) (*ComplianceExport, error)
+}
+
+type ComplianceReportFilter struct {
+	ProductAreaID *uuid.UUID
+	TeamID        *uuid.UUID
+}
+
+// ComplianceReport answers which datasets contain personal data, who owns
+// them, who has access, on which legal basis and until when
+type ComplianceReport struct {
+	Generated time.Time          `json:"generated"`
+	Datasets  []*PersonalDataset `json:"datasets"`
+}
+
+type MetabaseExposure string
+
+const (
+	// MetabaseExposureOpen is a dataset that all users can query in Metabase
+	MetabaseExposureOpen MetabaseExposure = "open"
+	// MetabaseExposureRestricted is a dataset that only the users with access
+	// can query in Metabase
+	MetabaseExposureRestricted MetabaseExposure = "restricted"
+)
+
+// PiiTagNone is the tag of columns without personal data
+const PiiTagNone = "PII_IngenPersonopplysning"
+
+type PersonalDataset struct {
+	DatasetID                uuid.UUID `json:"datasetID"`
+	DatasetName              string    `json:"datasetName"`
+	DataproductID            uuid.UUID `json:"dataproductID"`
+	DataproductName          string    `json:"dataproductName"`
+	Pii                      PiiLevel  `json:"pii"`
+	AnonymisationDescription *string   `json:"anonymisationDescription"`
+	// PiiTags maps the columns of the table to the kind of personal data
+	// they contain
+	PiiTags         map[string]string `json:"piiTags"`
+	PseudoColumns   []PseudoColumn    `json:"pseudoColumns"`
+	OwnerGroup      string            `json:"ownerGroup"`
+	TeamContact     *string           `json:"teamContact"`
+	TeamID          *uuid.UUID        `json:"teamID"`
+	TeamName        *string           `json:"teamName"`
+	ProductAreaID   *uuid.UUID        `json:"productAreaID"`
+	ProductAreaName *string           `json:"productAreaName"`
+	// MetabaseExposure is not set when the dataset is not in Metabase
+	MetabaseExposure *MetabaseExposure `json:"metabaseExposure"`
+	// PiiMismatch is set when the dataset is marked as without personal data,
+	// but has columns tagged as personal data
+	PiiMismatch bool                  `json:"piiMismatch"`
+	Accesses    []*PersonalDataAccess `json:"accesses"`
+}
+
+// PersonalDataAccess is an active access, and the purpose it was granted for
+// if it was granted through an access request with a purpose
+type PersonalDataAccess struct {
+	AccessID      uuid.UUID           `json:"accessID"`
+	Subject       string              `json:"subject"`
+	Owner         string              `json:"owner"`
+	Granter       string              `json:"granter"`
+	Created       time.Time           `json:"created"`
+	Expires       *time.Time          `json:"expires"`
+	Purpose       *QueryPolly         `json:"purpose"`
+	PurposeStatus *PollyPurposeStatus `json:"purposeStatus"`
+}
+
+type ComplianceExportFormat string
+
+const (
+	ComplianceExportFormatCSV  ComplianceExportFormat = "csv"
+	ComplianceExportFormatXLSX ComplianceExportFormat = "xlsx"
+)
+
+type ComplianceExport struct {
+	ContentType string
+	Data        []byte
+}
@@ -11,20 +11,24 @@ import (
 	"github.com/navikt/nada-backend/pkg/service/core/api/gcp"
 	httpapi "github.com/navikt/nada-backend/pkg/service/core/api/http"
 	slackapi "github.com/navikt/nada-backend/pkg/service/core/api/slack"
+	"github.com/navikt/nada-backend/pkg/service/core/api/smtp"
+	"github.com/navikt/nada-backend/pkg/service/core/api/static"
 	"github.com/navikt/nada-backend/pkg/service/core/cache/postgres"
 	"github.com/navikt/nada-backend/pkg/tk"
 	"github.com/rs/zerolog"
 )
 
 type Clients struct {
-	BigQueryAPI       service.BigQueryAPI
-	StoryAPI          service.StoryAPI
-	ServiceAccountAPI service.ServiceAccountAPI
-	MetaBaseAPI       service.MetabaseAPI
-	PollyAPI          service.PollyAPI
-	TeamKatalogenAPI  service.TeamKatalogenAPI
-	SlackAPI          service.SlackAPI
-	NaisConsoleAPI    service.NaisConsoleAPI
+	BigQueryAPI         service.BigQueryAPI
+	StoryAPI            service.StoryAPI
+	ServiceAccountAPI   service.ServiceAccountAPI
+	MetaBaseAPI         service.MetabaseAPI
+	PollyAPI            service.PollyAPI
+	TeamKatalogenAPI    service.TeamKatalogenAPI
+	SlackAPI            service.SlackAPI
+	EmailAPI            service.EmailAPI
+	NaisConsoleAPI      service.NaisConsoleAPI
+	DatasourceProviders service.DatasourceProviders
 }
 
 func NewClients(
@@ -33,6 +37,7 @@ func NewClients(
 	ncFetcher nc.Fetcher,
 	bqClient bq.Operations,
 	csClient cs.Operations,
+	csBuckets cs.BucketOperations,
 	saClient sa.Operations,
 	cfg config.Config,
 	log zerolog.Logger,
@@ -40,13 +45,26 @@ func NewClients(
 	tkAPI := httpapi.NewTeamKatalogenAPI(tkFetcher, log)
 	tkAPICacher := postgres.NewTeamKatalogenCache(tkAPI, cache)
 
+	bqAPI := gcp.NewBigQueryAPI(
+		cfg.BigQuery.CentralGCPProject,
+		cfg.BigQuery.GCPRegion,
+		cfg.BigQuery.TeamProjectPseudoViewsDatasetName,
+		bqClient,
+	)
+
+	var emailAPI service.EmailAPI = static.NewEmailAPI(log.With().Str("component", "email").Logger())
+	if cfg.SMTP.Host != "" {
+		emailAPI = smtp.NewEmailAPI(
+			cfg.SMTP.Host,
+			cfg.SMTP.Port,
+			cfg.SMTP.From,
+			cfg.SMTP.Username,
+			cfg.SMTP.Password,
+		)
+	}
+
 	return &Clients{
-		BigQueryAPI: gcp.NewBigQueryAPI(
-			cfg.BigQuery.CentralGCPProject,
-			cfg.BigQuery.GCPRegion,
-			cfg.BigQuery.TeamProjectPseudoViewsDatasetName,
-			bqClient,
-		),
+		BigQueryAPI: bqAPI,
 		StoryAPI: gcp.NewStoryAPI(
 			csClient,
 			log.With().Str("component", "story").Logger(),
@@ -70,8 +88,13 @@ func NewClients(
 			cfg.Slack.WebhookURL,
 			cfg.Slack.Token,
 		),
+		EmailAPI: emailAPI,
 		NaisConsoleAPI: httpapi.NewNaisConsoleAPI(
 			ncFetcher,
 		),
+		DatasourceProviders: service.NewDatasourceProviders(
+			gcp.NewBigQueryDatasourceProvider(bqAPI),
+			gcp.NewGCSDatasourceProvider(csBuckets),
+		),
 	}
 }
@@ -84,41 +84,148 @@ func (a *bigQueryAPI) GetDatasets(ctx context.Context, projectID string) ([]stri
 	return datasets, nil
 }
 
-func composePseudoViewQuery(projectID, datasetID, tableID string, targetColumns []string) string {
-	qGenSalt := `WITH gen_salt AS (
-		SELECT GENERATE_UUID() AS salt
-	)`
+// composePseudoColumn returns the expression that pseudonymises the column
+// according to its strategy, salt is only used by the salted hash strategy
+func composePseudoColumn(column service.PseudoColumn, columnType, salt string) (string, error) {
+	if !column.SupportsColumnType(columnType) {
+		return "", fmt.Errorf("strategy %v does not support column %v of type %v", column.Strategy, column.Name, columnType)
+	}
+
+	name, err := quoteColumnName(column.Name)
+	if err != nil {
+		return "", err
+	}
+
+	switch column.Strategy {
+	case service.PseudoStrategySaltedHash:
+		if columnType == string(bq.StringFieldType) {
+			return fmt.Sprintf("SHA256(%v || %v)", name, salt), nil
+		}
+
+		return fmt.Sprintf("SHA256(CAST(%v AS STRING) || %v)", name, salt), nil
+	case service.PseudoStrategyTruncateYear:
+		return fmt.Sprintf("%v_TRUNC(%v, YEAR)", columnType, name), nil
+	case service.PseudoStrategyBucket:
+		switch columnType {
+		case string(bq.StringFieldType):
+			return fmt.Sprintf("SUBSTR(%v, 1, %d)", name, column.Size), nil
+		case string(bq.IntegerFieldType):
+			return fmt.Sprintf("DIV(%v, %d) * %d", name, column.Size, column.Size), nil
+		default:
+			return fmt.Sprintf("FLOOR(%v / %d) * %d", name, column.Size, column.Size), nil
+		}
+	case service.PseudoStrategyNull:
+		return fmt.Sprintf("CAST(NULL AS %v)", standardSQLType(columnType)), nil
+	}
+
+	return "", fmt.Errorf("unknown pseudonymisation strategy %v for column %v", column.Strategy, column.Name)
+}
 
+// standardSQLType returns the name of the column type in standard SQL, the
+// schema of a table uses the legacy names of some types
+func standardSQLType(columnType string) string {
+	switch columnType {
+	case string(bq.IntegerFieldType):
+		return "INT64"
+	case string(bq.FloatFieldType):
+		return "FLOAT64"
+	case string(bq.BooleanFieldType):
+		return "BOOL"
+	}
+
+	return columnType
+}
+
+// quoteColumnName returns the column name quoted with backticks, so that
+// names which are reserved keywords can be used in a query
+func quoteColumnName(name string) (string, error) {
+	if strings.ContainsAny(name, "`\\") {
+		return "", fmt.Errorf("invalid column name %q", name)
+	}
+
+	return "`" + name + "`", nil
+}
+
+// composePseudoSelect pseudonymises the columns and selects the rest of the table as is,
+// salted hash columns are prefixed with joinablePrefix so they can be told apart in joinable views
+func composePseudoSelect(columns []service.PseudoColumn, columnTypes map[string]string, salt, joinablePrefix string) (string, error) {
 	qSelect := "SELECT "
-	for _, c := range targetColumns {
-		qSelect += fmt.Sprintf(" SHA256(%v || gen_salt.salt) AS %v", c, c)
+	for _, c := range columns {
+		expr, err := composePseudoColumn(c, columnTypes[c.Name], salt)
+		if err != nil {
+			return "", err
+		}
+
+		alias := c.Name
+		if c.Strategy == service.PseudoStrategySaltedHash {
+			alias = joinablePrefix + c.Name
+		}
+
+		qSelect += fmt.Sprintf(" %v AS `%v`", expr, alias)
 		qSelect += ","
 	}
 
 	qSelect += "I.* EXCEPT("
 
-	for i, c := range targetColumns {
-		qSelect += c
-		if i != len(targetColumns)-1 {
+	for i, c := range columns {
+		qSelect += "`" + c.Name + "`"
+		if i != len(columns)-1 {
 			qSelect += ","
 		} else {
 			qSelect += ")"
 		}
 	}
+
+	return qSelect, nil
+}
+
+func composePseudoViewQuery(projectID, datasetID, tableID string, columns []service.PseudoColumn, columnTypes map[string]string) (string, error) {
+	qGenSalt := `WITH gen_salt AS (
+		SELECT GENERATE_UUID() AS salt
+	)`
+
+	qSelect, err := composePseudoSelect(columns, columnTypes, "gen_salt.salt", "")
+	if err != nil {
+		return "", err
+	}
+
 	qFrom := fmt.Sprintf("FROM `%v.%v.%v` AS I, gen_salt", projectID, datasetID, tableID)
 
-	return qGenSalt + " " + qSelect + " " + qFrom
+	return qGenSalt + " " + qSelect + " " + qFrom, nil
+}
+
+func (a *bigQueryAPI) columnTypes(ctx context.Context, projectID, datasetID, tableID string) (map[string]string, error) {
+	table, err := a.client.GetTable(ctx, projectID, datasetID, tableID)
+	if err != nil {
+		return nil, err
+	}
+
+	columnTypes := map[string]string{}
+	for _, c := range table.Schema {
+		columnTypes[c.Name] = c.Type.String()
+	}
+
+	return columnTypes, nil
 }
 
-func (a *bigQueryAPI) CreatePseudonymisedView(ctx context.Context, projectID, datasetID, tableID string, piiColumns []string) (string, string, string, error) {
+func (a *bigQueryAPI) CreatePseudonymisedView(ctx context.Context, projectID, datasetID, tableID string, pseudoColumns []service.PseudoColumn) (string, string, string, error) {
 	const op errs.Op = "bigQueryAPI.CreatePseudonymisedView"
 
-	err := a.client.CreateDatasetIfNotExists(ctx, projectID, a.pseudoDataSet, a.gcpRegion)
+	columnTypes, err := a.columnTypes(ctx, projectID, datasetID, tableID)
+	if err != nil {
+		return "", "", "", errs.E(errs.IO, op, err)
+	}
+
+	viewQuery, err := composePseudoViewQuery(projectID, datasetID, tableID, pseudoColumns, columnTypes)
+	if err != nil {
+		return "", "", "", errs.E(errs.InvalidRequest, op, err)
+	}
+
+	err = a.client.CreateDatasetIfNotExists(ctx, projectID, a.pseudoDataSet, a.gcpRegion)
 	if err != nil {
 		return "", "", "", errs.E(errs.IO, op, err)
 	}
 
-	viewQuery := composePseudoViewQuery(projectID, datasetID, tableID, piiColumns)
 	pseudoViewID := fmt.Sprintf("%v_%v", datasetID, tableID)
 
 	_, err = a.client.CreateTableOrUpdate(ctx, &bq.Table{
@@ -214,43 +321,268 @@ func (a *bigQueryAPI) TableMetadata(ctx context.Context, projectID string, datas
 	return metadata, nil
 }
 
+func (a *bigQueryAPI) TableRows(ctx context.Context, projectID, datasetID, tableID string, maxRows int) (*service.BigQueryTableRows, error) {
+	const op errs.Op = "bigQueryAPI.TableRows"
+
+	rows, err := a.client.ListTableRows(ctx, projectID, datasetID, tableID, maxRows)
+	if err != nil {
+		if errors.Is(err, bq.ErrNotExist) {
+			return nil, errs.E(errs.NotExist, op, err)
+		}
+
+		return nil, errs.E(errs.IO, op, err)
+	}
+
+	return &service.BigQueryTableRows{
+		Schema: toBigqueryColumns(rows.Schema),
+		Rows:   rows.Rows,
+	}, nil
+}
+
+func (a *bigQueryAPI) EstimateQueryBytes(ctx context.Context, query string) (int64, error) {
+	const op errs.Op = "bigQueryAPI.EstimateQueryBytes"
+
+	stats, err := a.client.DryRunQuery(ctx, a.gcpProject, query)
+	if err != nil {
+		return 0, errs.E(errs.IO, op, err)
+	}
+
+	if stats == nil {
+		return 0, nil
+	}
+
+	return stats.TotalBytesProcessed, nil
+}
+
+func (a *bigQueryAPI) QueryReferencedTables(ctx context.Context, query string) ([]string, error) {
+	const op errs.Op = "bigQueryAPI.QueryReferencedTables"
+
+	stats, err := a.client.DryRunQuery(ctx, a.gcpProject, query)
+	if err != nil {
+		return nil, errs.E(errs.IO, op, err)
+	}
+
+	if stats == nil {
+		return nil, nil
+	}
+
+	return stats.ReferencedTables, nil
+}
+
+func (a *bigQueryAPI) Query(ctx context.Context, query string, maxRows int, maxBytesBilled int64) (*service.BigQueryQueryResult, error) {
+	const op errs.Op = "bigQueryAPI.Query"
+
+	res, err := a.client.QueryAndRead(ctx, a.gcpProject, query, maxRows, maxBytesBilled)
+	if err != nil {
+		return nil, errs.E(errs.IO, op, err)
+	}
+
+	result := &service.BigQueryQueryResult{
+		Schema: toBigqueryColumns(res.Schema),
+		Rows:   res.Rows,
+	}
+
+	if res.Statistics != nil {
+		result.BytesProcessed = res.Statistics.TotalBytesProcessed
+	}
+
+	return result, nil
+}
+
+func toBigqueryColumns(columns []*bq.Column) []*service.BigqueryColumn {
+	schema := make([]*service.BigqueryColumn, len(columns))
+	for i, c := range columns {
+		schema[i] = &service.BigqueryColumn{
+			Name:        c.Name,
+			Type:        c.Type.String(),
+			Mode:        c.Mode.String(),
+			Description: c.Description,
+		}
+	}
+
+	return schema
+}
+
+func (a *bigQueryAPI) ComposeProfileQuery(projectID, datasetID, tableID string, columns []service.ProfileColumn) (string, error) {
+	if len(columns) == 0 {
+		return "", fmt.Errorf("no columns to profile in %v.%v.%v", projectID, datasetID, tableID)
+	}
+
+	selects := []string{"COUNT(*) AS row_count"}
+
+	for i, c := range columns {
+		if strings.ContainsAny(c.Name, "`'\\") {
+			return "", fmt.Errorf("invalid column name %q", c.Name)
+		}
+
+		distinct := "CAST(NULL AS INT64)"
+		if c.Groupable() {
+			distinct = fmt.Sprintf("APPROX_COUNT_DISTINCT(`%v`)", c.Name)
+		}
+
+		minimum, maximum := "CAST(NULL AS STRING)", "CAST(NULL AS STRING)"
+		if !c.Masked && c.Orderable() {
+			minimum = fmt.Sprintf("CAST(MIN(`%v`) AS STRING)", c.Name)
+			maximum = fmt.Sprintf("CAST(MAX(`%v`) AS STRING)", c.Name)
+		}
+
+		selects = append(selects,
+			fmt.Sprintf("COUNTIF(`%v` IS NULL) AS c%d_nulls", c.Name, i),
+			fmt.Sprintf("%v AS c%d_distinct", distinct, i),
+			fmt.Sprintf("%v AS c%d_min", minimum, i),
+			fmt.Sprintf("%v AS c%d_max", maximum, i),
+		)
+	}
+
+	return fmt.Sprintf("SELECT %v FROM `%v.%v.%v`", strings.Join(selects, ", "), projectID, datasetID, tableID), nil
+}
+
+func (a *bigQueryAPI) ComposeTopValuesQuery(projectID, datasetID, tableID string, columns []service.ProfileColumn, limit int) (string, error) {
+	if len(columns) == 0 {
+		return "", fmt.Errorf("no columns to profile in %v.%v.%v", projectID, datasetID, tableID)
+	}
+
+	var selects []string
+
+	for _, c := range columns {
+		if strings.ContainsAny(c.Name, "`'\\") {
+			return "", fmt.Errorf("invalid column name %q", c.Name)
+		}
+
+		if c.Masked || !c.Groupable() {
+			return "", fmt.Errorf("top values of column %v are not supported", c.Name)
+		}
+
+		selects = append(selects, fmt.Sprintf(
+			"(SELECT '%v' AS column_name, CAST(`%v` AS STRING) AS value, COUNT(*) AS count FROM `%v.%v.%v` GROUP BY value ORDER BY count DESC LIMIT %d)",
+			c.Name, c.Name, projectID, datasetID, tableID, limit,
+		))
+	}
+
+	return strings.Join(selects, " UNION ALL "), nil
+}
+
+func (a *bigQueryAPI) ComposeQualityCheckQuery(projectID, datasetID, tableID string, check *service.QualityCheck) (string, error) {
+	table := fmt.Sprintf("`%v.%v.%v`", projectID, datasetID, tableID)
+
+	var conditions []string
+
+	if check.Column != nil {
+		if strings.ContainsAny(*check.Column, "`'\\") {
+			return "", fmt.Errorf("invalid column name %q", *check.Column)
+		}
+
+		if check.Kind == service.QualityCheckKindUnique {
+			conditions = append(conditions, fmt.Sprintf("`%v` IS NOT NULL", *check.Column))
+		}
+	}
+
+	if check.Condition != nil && strings.TrimSpace(*check.Condition) != "" {
+		if strings.Contains(*check.Condition, ";") {
+			return "", fmt.Errorf("condition of check %v must be a single expression", check.Name)
+		}
+
+		if readsSystemViews(*check.Condition) {
+			return "", fmt.Errorf("condition of check %v can not read from system views", check.Name)
+		}
+
+		conditions = append(conditions, fmt.Sprintf("(%v)", *check.Condition))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	switch check.Kind {
+	case service.QualityCheckKindNotNull, service.QualityCheckKindUnique:
+		if check.Column == nil {
+			return "", fmt.Errorf("check %v has no column", check.Name)
+		}
+
+		if check.Kind == service.QualityCheckKindNotNull {
+			return fmt.Sprintf("SELECT COUNTIF(`%v` IS NULL) AS measured FROM %v%v", *check.Column, table, where), nil
+		}
+
+		return fmt.Sprintf(
+			"SELECT COUNT(*) AS measured FROM (SELECT `%v` FROM %v%v GROUP BY `%v` HAVING COUNT(*) > 1)",
+			*check.Column, table, where, *check.Column,
+		), nil
+	case service.QualityCheckKindRowCount:
+		return fmt.Sprintf("SELECT COUNT(*) AS measured FROM %v%v", table, where), nil
+	case service.QualityCheckKindSQL:
+		if check.SQL == nil {
+			return "", fmt.Errorf("check %v has no query", check.Name)
+		}
+
+		query := strings.TrimSuffix(strings.TrimSpace(*check.SQL), ";")
+		if strings.Contains(query, ";") {
+			return "", fmt.Errorf("query of check %v must be a single statement", check.Name)
+		}
+
+		// The system views are not listed as referenced tables when dry
+		// running the query, so they are rejected here
+		if readsSystemViews(query) {
+			return "", fmt.Errorf("query of check %v can not read from system views", check.Name)
+		}
+
+		if !strings.Contains(query, service.QualityCheckTablePlaceholder) {
+			return "", fmt.Errorf("query of check %v must read from %v", check.Name, service.QualityCheckTablePlaceholder)
+		}
+
+		query = strings.ReplaceAll(query, service.QualityCheckTablePlaceholder, table)
+
+		return fmt.Sprintf("SELECT COUNT(*) AS measured FROM (%v)", query), nil
+	}
+
+	return "", fmt.Errorf("unsupported kind %v of check %v", check.Kind, check.Name)
+}
+
+// readsSystemViews returns true if the query refers to the INFORMATION_SCHEMA
+// views, either of a dataset or of a region
+func readsSystemViews(query string) bool {
+	q := strings.ToLower(query)
+
+	return strings.Contains(q, "information_schema") || strings.Contains(q, "region-")
+}
+
 // FIXME: duplicated
 func makeJoinableViewName(projectID, datasetID, tableID string) string {
 	// datasetID will always be same markedsplassen dataset id
 	return fmt.Sprintf("%v_%v", projectID, tableID)
 }
 
-func (a *bigQueryAPI) ComposeJoinableViewQuery(plainTable service.DatasourceForJoinableView, joinableDatasetID string) string {
+func (a *bigQueryAPI) ComposeJoinableViewQuery(plainTable service.DatasourceForJoinableView, joinableDatasetID string, columnTypes map[string]string) (string, error) {
 	qSalt := fmt.Sprintf("WITH unified_salt AS (SELECT value AS salt FROM `%v.%v.%v` ds WHERE ds.key='%v')", a.gcpProject, "secrets_vault", "secrets", joinableDatasetID)
 
-	qSelect := "SELECT "
-	for _, c := range plainTable.PseudoColumns {
-		qSelect += fmt.Sprintf(" SHA256(%v || unified_salt.salt) AS _x_%v", c, c)
-		qSelect += ","
+	qSelect, err := composePseudoSelect(plainTable.PseudoColumns, columnTypes, "unified_salt.salt", "_x_")
+	if err != nil {
+		return "", err
 	}
 
-	qSelect += "I.* EXCEPT("
-
-	for i, c := range plainTable.PseudoColumns {
-		qSelect += c
-		if i != len(plainTable.PseudoColumns)-1 {
-			qSelect += ","
-		} else {
-			qSelect += ")"
-		}
-	}
 	qFrom := fmt.Sprintf("FROM `%v.%v.%v` AS I, unified_salt", plainTable.Project, plainTable.Dataset, plainTable.Table)
 
-	return qSalt + " " + qSelect + " " + qFrom
+	return qSalt + " " + qSelect + " " + qFrom, nil
 }
 
 func (a *bigQueryAPI) CreateJoinableView(ctx context.Context, joinableDatasetID string, datasource service.JoinableViewDatasource) (string, error) {
 	const op errs.Op = "bigQueryAPI.CreateJoinableView"
 
-	query := a.ComposeJoinableViewQuery(*datasource.RefDatasource, joinableDatasetID)
+	ref := datasource.RefDatasource
+
+	columnTypes, err := a.columnTypes(ctx, ref.Project, ref.Dataset, ref.Table)
+	if err != nil {
+		return "", errs.E(errs.IO, op, err)
+	}
+
+	query, err := a.ComposeJoinableViewQuery(*ref, joinableDatasetID, columnTypes)
+	if err != nil {
+		return "", errs.E(errs.InvalidRequest, op, err)
+	}
+
 	tableID := makeJoinableViewName(datasource.PseudoDatasource.Project, datasource.PseudoDatasource.Dataset, datasource.PseudoDatasource.Table)
 
-	err := a.client.CreateTable(ctx, &bq.Table{
+	err = a.client.CreateTable(ctx, &bq.Table{
 		ProjectID: a.gcpProject,
 		DatasetID: joinableDatasetID,
 		TableID:   tableID,
@@ -411,6 +743,62 @@ func (a *bigQueryAPI) Revoke(ctx context.Context, projectID, datasetID, tableID,
 	return nil
 }
 
+// GrantDataset gives the member read access to every table in the dataset.
+func (a *bigQueryAPI) GrantDataset(ctx context.Context, projectID, datasetID, member string) error {
+	const op errs.Op = "bigQueryAPI.GrantDataset"
+
+	entry, err := datasetAccessEntry(member)
+	if err != nil {
+		return errs.E(errs.InvalidRequest, op, err)
+	}
+
+	err = a.client.AddDatasetRoleAccessEntry(ctx, projectID, datasetID, entry)
+	if err != nil {
+		return errs.E(errs.IO, op, fmt.Errorf("adding dataset role access entry: %w", err))
+	}
+
+	return nil
+}
+
+func (a *bigQueryAPI) RevokeDataset(ctx context.Context, projectID, datasetID, member string) error {
+	const op errs.Op = "bigQueryAPI.RevokeDataset"
+
+	entry, err := datasetAccessEntry(member)
+	if err != nil {
+		return errs.E(errs.InvalidRequest, op, err)
+	}
+
+	err = a.client.RemoveDatasetRoleAccessEntry(ctx, projectID, datasetID, entry)
+	if err != nil {
+		return errs.E(errs.IO, op, fmt.Errorf("removing dataset role access entry: %w", err))
+	}
+
+	return nil
+}
+
+func datasetAccessEntry(member string) (*bq.AccessEntry, error) {
+	entType, entity, found := strings.Cut(member, ":")
+	if !found {
+		return nil, fmt.Errorf("member %s is not on the form type:email", member)
+	}
+
+	var entityType bq.EntityType
+	switch entType {
+	case "user", "serviceAccount":
+		entityType = bq.UserEmailEntity
+	case "group":
+		entityType = bq.GroupEmailEntity
+	default:
+		return nil, fmt.Errorf("unsupported member type %s", entType)
+	}
+
+	return &bq.AccessEntry{
+		Role:       bq.BigQueryDataViewerRole,
+		Entity:     entity,
+		EntityType: entityType,
+	}, nil
+}
+
 func (a *bigQueryAPI) AddToAuthorizedViews(ctx context.Context, srcProjectID, srcDataset, sinkProjectID, sinkDataset, sinkTable string) error {
 	const op errs.Op = "bigQueryAPI.AddToAuthorizedViews"
 
@@ -0,0 +1,389 @@
+package gcp_test
+
+import (
+	"testing"
+
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core/api/gcp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposeJoinableViewQuery(t *testing.T) {
+	columnTypes := map[string]string{
+		"fnr":      "STRING",
+		"id":       "INTEGER",
+		"born":     "DATE",
+		"postcode": "STRING",
+		"income":   "NUMERIC",
+		"comment":  "STRING",
+		"score":    "FLOAT",
+		"address":  "RECORD",
+		"select":   "STRING",
+	}
+
+	testCases := []struct {
+		name      string
+		columns   []service.PseudoColumn
+		expect    string
+		expectErr bool
+	}{
+		{
+			name: "salted hash on string",
+			columns: []service.PseudoColumn{
+				{Name: "fnr", Strategy: service.PseudoStrategySaltedHash},
+			},
+			expect: "WITH unified_salt AS (SELECT value AS salt FROM `project.secrets_vault.secrets` ds WHERE ds.key='joinable') " +
+				"SELECT  SHA256(`fnr` || unified_salt.salt) AS `_x_fnr`,I.* EXCEPT(`fnr`) " +
+				"FROM `ref-project.ref_dataset.ref_table` AS I, unified_salt",
+		},
+		{
+			name: "salted hash on integer is cast to string",
+			columns: []service.PseudoColumn{
+				{Name: "id", Strategy: service.PseudoStrategySaltedHash},
+			},
+			expect: "WITH unified_salt AS (SELECT value AS salt FROM `project.secrets_vault.secrets` ds WHERE ds.key='joinable') " +
+				"SELECT  SHA256(CAST(`id` AS STRING) || unified_salt.salt) AS `_x_id`,I.* EXCEPT(`id`) " +
+				"FROM `ref-project.ref_dataset.ref_table` AS I, unified_salt",
+		},
+		{
+			name: "all strategies",
+			columns: []service.PseudoColumn{
+				{Name: "born", Strategy: service.PseudoStrategyTruncateYear},
+				{Name: "postcode", Strategy: service.PseudoStrategyBucket, Size: 2},
+				{Name: "income", Strategy: service.PseudoStrategyBucket, Size: 10000},
+				{Name: "comment", Strategy: service.PseudoStrategyNull},
+			},
+			expect: "WITH unified_salt AS (SELECT value AS salt FROM `project.secrets_vault.secrets` ds WHERE ds.key='joinable') " +
+				"SELECT  DATE_TRUNC(`born`, YEAR) AS `born`, SUBSTR(`postcode`, 1, 2) AS `postcode`, " +
+				"FLOOR(`income` / 10000) * 10000 AS `income`, CAST(NULL AS STRING) AS `comment`," +
+				"I.* EXCEPT(`born`,`postcode`,`income`,`comment`) " +
+				"FROM `ref-project.ref_dataset.ref_table` AS I, unified_salt",
+		},
+		{
+			name: "null on float uses the standard sql type",
+			columns: []service.PseudoColumn{
+				{Name: "score", Strategy: service.PseudoStrategyNull},
+			},
+			expect: "WITH unified_salt AS (SELECT value AS salt FROM `project.secrets_vault.secrets` ds WHERE ds.key='joinable') " +
+				"SELECT  CAST(NULL AS FLOAT64) AS `score`,I.* EXCEPT(`score`) " +
+				"FROM `ref-project.ref_dataset.ref_table` AS I, unified_salt",
+		},
+		{
+			name: "null on record is not supported",
+			columns: []service.PseudoColumn{
+				{Name: "address", Strategy: service.PseudoStrategyNull},
+			},
+			expectErr: true,
+		},
+		{
+			name: "column named as a reserved keyword",
+			columns: []service.PseudoColumn{
+				{Name: "select", Strategy: service.PseudoStrategySaltedHash},
+			},
+			expect: "WITH unified_salt AS (SELECT value AS salt FROM `project.secrets_vault.secrets` ds WHERE ds.key='joinable') " +
+				"SELECT  SHA256(`select` || unified_salt.salt) AS `_x_select`,I.* EXCEPT(`select`) " +
+				"FROM `ref-project.ref_dataset.ref_table` AS I, unified_salt",
+		},
+		{
+			name: "unsupported column type",
+			columns: []service.PseudoColumn{
+				{Name: "fnr", Strategy: service.PseudoStrategyTruncateYear},
+			},
+			expectErr: true,
+		},
+	}
+
+	api := gcp.NewBigQueryAPI("project", "europe-north1", "markedsplassen", nil)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := api.ComposeJoinableViewQuery(service.DatasourceForJoinableView{
+				Project:       "ref-project",
+				Dataset:       "ref_dataset",
+				Table:         "ref_table",
+				PseudoColumns: tc.columns,
+			}, "joinable", columnTypes)
+
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, got)
+		})
+	}
+}
+
+func TestComposeProfileQuery(t *testing.T) {
+	testCases := []struct {
+		name      string
+		columns   []service.ProfileColumn
+		expect    string
+		expectErr bool
+	}{
+		{
+			name: "string column",
+			columns: []service.ProfileColumn{
+				{Name: "fuel_type", Type: "STRING"},
+			},
+			expect: "SELECT COUNT(*) AS row_count, COUNTIF(`fuel_type` IS NULL) AS c0_nulls, " +
+				"APPROX_COUNT_DISTINCT(`fuel_type`) AS c0_distinct, CAST(MIN(`fuel_type`) AS STRING) AS c0_min, " +
+				"CAST(MAX(`fuel_type`) AS STRING) AS c0_max FROM `project.dataset.table`",
+		},
+		{
+			name: "masked and geography columns",
+			columns: []service.ProfileColumn{
+				{Name: "fnr", Type: "STRING", Masked: true},
+				{Name: "location", Type: "GEOGRAPHY"},
+			},
+			expect: "SELECT COUNT(*) AS row_count, COUNTIF(`fnr` IS NULL) AS c0_nulls, " +
+				"APPROX_COUNT_DISTINCT(`fnr`) AS c0_distinct, CAST(NULL AS STRING) AS c0_min, " +
+				"CAST(NULL AS STRING) AS c0_max, COUNTIF(`location` IS NULL) AS c1_nulls, " +
+				"CAST(NULL AS INT64) AS c1_distinct, CAST(NULL AS STRING) AS c1_min, " +
+				"CAST(NULL AS STRING) AS c1_max FROM `project.dataset.table`",
+		},
+		{
+			name:      "no columns",
+			expectErr: true,
+		},
+		{
+			name: "invalid column name",
+			columns: []service.ProfileColumn{
+				{Name: "fnr` FROM x --", Type: "STRING"},
+			},
+			expectErr: true,
+		},
+	}
+
+	api := gcp.NewBigQueryAPI("project", "europe-north1", "markedsplassen", nil)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := api.ComposeProfileQuery("project", "dataset", "table", tc.columns)
+
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, got)
+		})
+	}
+}
+
+func TestComposeTopValuesQuery(t *testing.T) {
+	testCases := []struct {
+		name      string
+		columns   []service.ProfileColumn
+		expect    string
+		expectErr bool
+	}{
+		{
+			name: "two columns",
+			columns: []service.ProfileColumn{
+				{Name: "fuel_type", Type: "STRING"},
+				{Name: "unit", Type: "STRING"},
+			},
+			expect: "(SELECT 'fuel_type' AS column_name, CAST(`fuel_type` AS STRING) AS value, COUNT(*) AS count " +
+				"FROM `project.dataset.table` GROUP BY value ORDER BY count DESC LIMIT 5) UNION ALL " +
+				"(SELECT 'unit' AS column_name, CAST(`unit` AS STRING) AS value, COUNT(*) AS count " +
+				"FROM `project.dataset.table` GROUP BY value ORDER BY count DESC LIMIT 5)",
+		},
+		{
+			name: "masked column",
+			columns: []service.ProfileColumn{
+				{Name: "fnr", Type: "STRING", Masked: true},
+			},
+			expectErr: true,
+		},
+	}
+
+	api := gcp.NewBigQueryAPI("project", "europe-north1", "markedsplassen", nil)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := api.ComposeTopValuesQuery("project", "dataset", "table", tc.columns, 5)
+
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, got)
+		})
+	}
+}
+
+func TestComposeQualityCheckQuery(t *testing.T) {
+	column := "fuel_type"
+	condition := "unit = 'l'"
+	query := "SELECT * FROM {{table}} WHERE consumption_rate IS NULL;"
+	otherTable := "SELECT * FROM `project.dataset.other`"
+	twoStatements := "SELECT * FROM {{table}}; DROP TABLE {{table}}"
+	invalidColumn := "fuel`type"
+	systemView := "SELECT * FROM {{table}} CROSS JOIN `project.dataset.INFORMATION_SCHEMA.TABLES`"
+	regionView := "SELECT * FROM {{table}} CROSS JOIN `region-europe-north1`.information_schema.jobs"
+	systemCondition := "EXISTS (SELECT 1 FROM dataset.INFORMATION_SCHEMA.COLUMNS)"
+
+	testCases := []struct {
+		name      string
+		check     *service.QualityCheck
+		expect    string
+		expectErr bool
+	}{
+		{
+			name:   "not null",
+			check:  &service.QualityCheck{Kind: service.QualityCheckKindNotNull, Column: &column},
+			expect: "SELECT COUNTIF(`fuel_type` IS NULL) AS measured FROM `project.dataset.table`",
+		},
+		{
+			name:  "unique with condition",
+			check: &service.QualityCheck{Kind: service.QualityCheckKindUnique, Column: &column, Condition: &condition},
+			expect: "SELECT COUNT(*) AS measured FROM (SELECT `fuel_type` FROM `project.dataset.table` " +
+				"WHERE `fuel_type` IS NOT NULL AND (unit = 'l') GROUP BY `fuel_type` HAVING COUNT(*) > 1)",
+		},
+		{
+			name:   "row count",
+			check:  &service.QualityCheck{Kind: service.QualityCheckKindRowCount},
+			expect: "SELECT COUNT(*) AS measured FROM `project.dataset.table`",
+		},
+		{
+			name:   "sql",
+			check:  &service.QualityCheck{Kind: service.QualityCheckKindSQL, SQL: &query},
+			expect: "SELECT COUNT(*) AS measured FROM (SELECT * FROM `project.dataset.table` WHERE consumption_rate IS NULL)",
+		},
+		{
+			name:      "sql without table placeholder",
+			check:     &service.QualityCheck{Kind: service.QualityCheckKindSQL, SQL: &otherTable},
+			expectErr: true,
+		},
+		{
+			name:      "sql with two statements",
+			check:     &service.QualityCheck{Kind: service.QualityCheckKindSQL, SQL: &twoStatements},
+			expectErr: true,
+		},
+		{
+			name:      "sql reading from information schema",
+			check:     &service.QualityCheck{Kind: service.QualityCheckKindSQL, SQL: &systemView},
+			expectErr: true,
+		},
+		{
+			name:      "sql reading from region information schema",
+			check:     &service.QualityCheck{Kind: service.QualityCheckKindSQL, SQL: &regionView},
+			expectErr: true,
+		},
+		{
+			name:      "condition reading from information schema",
+			check:     &service.QualityCheck{Kind: service.QualityCheckKindRowCount, Condition: &systemCondition},
+			expectErr: true,
+		},
+		{
+			name:      "invalid column name",
+			check:     &service.QualityCheck{Kind: service.QualityCheckKindNotNull, Column: &invalidColumn},
+			expectErr: true,
+		},
+	}
+
+	api := gcp.NewBigQueryAPI("project", "europe-north1", "markedsplassen", nil)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := api.ComposeQualityCheckQuery("project", "dataset", "table", tc.check)
+
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, got)
+		})
+	}
+}
+
+func TestValidatePseudoColumns(t *testing.T) {
+	schema := []*service.BigqueryColumn{
+		{Name: "fnr", Type: "STRING"},
+		{Name: "born", Type: "DATE"},
+		{Name: "address", Type: "RECORD"},
+		{Name: "location", Type: "GEOGRAPHY"},
+		{Name: "payload", Type: "JSON"},
+		{Name: "aliases", Type: "STRING", Mode: "REPEATED"},
+	}
+
+	testCases := []struct {
+		name      string
+		columns   []service.PseudoColumn
+		expectErr bool
+	}{
+		{
+			name: "valid",
+			columns: []service.PseudoColumn{
+				{Name: "fnr", Strategy: service.PseudoStrategySaltedHash},
+				{Name: "born", Strategy: service.PseudoStrategyTruncateYear},
+			},
+		},
+		{
+			name:      "missing column",
+			columns:   []service.PseudoColumn{{Name: "phone", Strategy: service.PseudoStrategyNull}},
+			expectErr: true,
+		},
+		{
+			name:      "salted hash of record",
+			columns:   []service.PseudoColumn{{Name: "address", Strategy: service.PseudoStrategySaltedHash}},
+			expectErr: true,
+		},
+		{
+			name:      "salted hash of geography",
+			columns:   []service.PseudoColumn{{Name: "location", Strategy: service.PseudoStrategySaltedHash}},
+			expectErr: true,
+		},
+		{
+			name:      "salted hash of json",
+			columns:   []service.PseudoColumn{{Name: "payload", Strategy: service.PseudoStrategySaltedHash}},
+			expectErr: true,
+		},
+		{
+			name:      "salted hash of repeated column",
+			columns:   []service.PseudoColumn{{Name: "aliases", Strategy: service.PseudoStrategySaltedHash}},
+			expectErr: true,
+		},
+		{
+			name:      "null of repeated column",
+			columns:   []service.PseudoColumn{{Name: "aliases", Strategy: service.PseudoStrategyNull}},
+			expectErr: true,
+		},
+		{
+			name:      "bucket without size",
+			columns:   []service.PseudoColumn{{Name: "fnr", Strategy: service.PseudoStrategyBucket}},
+			expectErr: true,
+		},
+		{
+			name:      "unknown strategy",
+			columns:   []service.PseudoColumn{{Name: "fnr", Strategy: "reverse"}},
+			expectErr: true,
+		},
+		{
+			name: "duplicate column",
+			columns: []service.PseudoColumn{
+				{Name: "fnr", Strategy: service.PseudoStrategySaltedHash},
+				{Name: "fnr", Strategy: service.PseudoStrategyNull},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := service.ValidatePseudoColumns(tc.columns, schema)
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
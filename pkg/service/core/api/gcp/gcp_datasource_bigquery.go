@@ -0,0 +1,246 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/navikt/nada-backend/pkg/bq"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+var _ service.DatasourceProvider = &bigQueryDatasourceProvider{}
+
+type bigQueryDatasourceProvider struct {
+	api service.BigQueryAPI
+}
+
+func (p *bigQueryDatasourceProvider) Type() service.DatasourceType {
+	return service.DatasourceTypeBigQuery
+}
+
+func (p *bigQueryDatasourceProvider) Exists(ctx context.Context, ref service.DatasourceRef) (bool, error) {
+	const op errs.Op = "bigQueryDatasourceProvider.Exists"
+
+	if ref.Scope == service.BigQueryScopeDataset {
+		_, err := p.api.GetTables(ctx, ref.ProjectID, ref.Dataset)
+		if err != nil {
+			if errors.Is(err, bq.ErrNotExist) {
+				return false, nil
+			}
+
+			return false, errs.E(op, err)
+		}
+
+		return true, nil
+	}
+
+	_, err := p.api.TableMetadata(ctx, ref.ProjectID, ref.Dataset, ref.Table)
+	if err != nil {
+		if errors.Is(err, bq.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, errs.E(op, err)
+	}
+
+	return true, nil
+}
+
+func (p *bigQueryDatasourceProvider) Metadata(ctx context.Context, ref service.DatasourceRef) (*service.DatasourceMetadata, error) {
+	const op errs.Op = "bigQueryDatasourceProvider.Metadata"
+
+	if ref.Scope == service.BigQueryScopeDataset {
+		meta, err := p.datasetMetadata(ctx, ref)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		return meta, nil
+	}
+
+	meta, err := p.api.TableMetadata(ctx, ref.ProjectID, ref.Dataset, ref.Table)
+	if err != nil {
+		if errors.Is(err, bq.ErrNotExist) {
+			return nil, errs.E(errs.NotExist, op, err)
+		}
+
+		return nil, errs.E(op, err)
+	}
+
+	return &service.DatasourceMetadata{
+		Description:  meta.Description,
+		Created:      meta.Created,
+		LastModified: meta.LastModified,
+		Expires:      meta.Expires,
+		TableType:    meta.TableType,
+		Schema:       meta.Schema.Columns,
+	}, nil
+}
+
+// datasetMetadata collects the metadata of every table in the dataset that
+// matches the table pattern. The combined schema prefixes each column with
+// the name of its table.
+func (p *bigQueryDatasourceProvider) datasetMetadata(ctx context.Context, ref service.DatasourceRef) (*service.DatasourceMetadata, error) {
+	const op errs.Op = "bigQueryDatasourceProvider.datasetMetadata"
+
+	tables, err := p.memberTables(ctx, ref)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	meta := &service.DatasourceMetadata{}
+
+	for _, t := range tables {
+		tableMeta, err := p.api.TableMetadata(ctx, ref.ProjectID, ref.Dataset, t)
+		if err != nil {
+			if errors.Is(err, bq.ErrNotExist) {
+				// The table was removed after we listed the dataset
+				continue
+			}
+
+			return nil, errs.E(op, err)
+		}
+
+		meta.Tables = append(meta.Tables, &service.BigQueryMemberTable{
+			Name:         t,
+			TableType:    tableMeta.TableType,
+			LastModified: tableMeta.LastModified,
+			Schema:       tableMeta.Schema.Columns,
+		})
+
+		for _, c := range tableMeta.Schema.Columns {
+			meta.Schema = append(meta.Schema, &service.BigqueryColumn{
+				Name:        t + "." + c.Name,
+				Type:        c.Type,
+				Mode:        c.Mode,
+				Description: c.Description,
+			})
+		}
+
+		if meta.Created.IsZero() || tableMeta.Created.Before(meta.Created) {
+			meta.Created = tableMeta.Created
+		}
+
+		if tableMeta.LastModified.After(meta.LastModified) {
+			meta.LastModified = tableMeta.LastModified
+		}
+	}
+
+	return meta, nil
+}
+
+func (p *bigQueryDatasourceProvider) memberTables(ctx context.Context, ref service.DatasourceRef) ([]string, error) {
+	const op errs.Op = "bigQueryDatasourceProvider.memberTables"
+
+	tables, err := p.api.GetTables(ctx, ref.ProjectID, ref.Dataset)
+	if err != nil {
+		if errors.Is(err, bq.ErrNotExist) {
+			return nil, errs.E(errs.NotExist, op, err)
+		}
+
+		return nil, errs.E(op, err)
+	}
+
+	var names []string
+	for _, t := range tables {
+		if service.MatchesTablePattern(ref.TablePattern, t.Name) {
+			names = append(names, t.Name)
+		}
+	}
+
+	return names, nil
+}
+
+func (p *bigQueryDatasourceProvider) Schema(ctx context.Context, ref service.DatasourceRef) ([]*service.BigqueryColumn, error) {
+	const op errs.Op = "bigQueryDatasourceProvider.Schema"
+
+	meta, err := p.Metadata(ctx, ref)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return meta.Schema, nil
+}
+
+func (p *bigQueryDatasourceProvider) Grant(ctx context.Context, ref service.DatasourceRef, member string) error {
+	const op errs.Op = "bigQueryDatasourceProvider.Grant"
+
+	if ref.Scope == service.BigQueryScopeDataset {
+		err := p.forDataset(ctx, ref, member, p.api.GrantDataset, p.api.Grant)
+		if err != nil {
+			return errs.E(op, err)
+		}
+
+		return nil
+	}
+
+	err := p.api.Grant(ctx, ref.ProjectID, ref.Dataset, ref.Table, member)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+func (p *bigQueryDatasourceProvider) Revoke(ctx context.Context, ref service.DatasourceRef, member string) error {
+	const op errs.Op = "bigQueryDatasourceProvider.Revoke"
+
+	if ref.Scope == service.BigQueryScopeDataset {
+		err := p.forDataset(ctx, ref, member, p.api.RevokeDataset, p.api.Revoke)
+		if err != nil {
+			return errs.E(op, err)
+		}
+
+		return nil
+	}
+
+	err := p.api.Revoke(ctx, ref.ProjectID, ref.Dataset, ref.Table, member)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+// forDataset changes access to a dataset scoped datasource. Without a table
+// pattern the whole dataset is covered, so access is given on the dataset
+// itself, otherwise on each of the matching tables.
+func (p *bigQueryDatasourceProvider) forDataset(
+	ctx context.Context,
+	ref service.DatasourceRef,
+	member string,
+	datasetFn func(ctx context.Context, projectID, datasetID, member string) error,
+	tableFn func(ctx context.Context, projectID, datasetID, tableID, member string) error,
+) error {
+	const op errs.Op = "bigQueryDatasourceProvider.forDataset"
+
+	if ref.TablePattern == "" {
+		err := datasetFn(ctx, ref.ProjectID, ref.Dataset, member)
+		if err != nil {
+			return errs.E(op, err)
+		}
+
+		return nil
+	}
+
+	tables, err := p.memberTables(ctx, ref)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	for _, t := range tables {
+		err := tableFn(ctx, ref.ProjectID, ref.Dataset, t, member)
+		if err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	return nil
+}
+
+func NewBigQueryDatasourceProvider(api service.BigQueryAPI) *bigQueryDatasourceProvider {
+	return &bigQueryDatasourceProvider{
+		api: api,
+	}
+}
@@ -0,0 +1,138 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/navikt/nada-backend/pkg/cs"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+const (
+	// maxListedObjects limits how many objects are listed for a datasource,
+	// large buckets would otherwise produce huge listings.
+	maxListedObjects = 1000
+	// descriptionLabel is the bucket label we read the description from.
+	descriptionLabel = "description"
+)
+
+var _ service.DatasourceProvider = &gcsDatasourceProvider{}
+
+type gcsDatasourceProvider struct {
+	ops cs.BucketOperations
+}
+
+func (p *gcsDatasourceProvider) Type() service.DatasourceType {
+	return service.DatasourceTypeGCS
+}
+
+func (p *gcsDatasourceProvider) Exists(ctx context.Context, ref service.DatasourceRef) (bool, error) {
+	const op errs.Op = "gcsDatasourceProvider.Exists"
+
+	_, err := p.ops.GetBucket(ctx, ref.Bucket)
+	if err != nil {
+		if errors.Is(err, cs.ErrBucketNotExist) {
+			return false, nil
+		}
+
+		return false, errs.E(errs.IO, op, err)
+	}
+
+	return true, nil
+}
+
+func (p *gcsDatasourceProvider) Metadata(ctx context.Context, ref service.DatasourceRef) (*service.DatasourceMetadata, error) {
+	const op errs.Op = "gcsDatasourceProvider.Metadata"
+
+	bucket, err := p.ops.GetBucket(ctx, ref.Bucket)
+	if err != nil {
+		if errors.Is(err, cs.ErrBucketNotExist) {
+			return nil, errs.E(errs.NotExist, op, fmt.Errorf("bucket %s does not exist", ref.Bucket))
+		}
+
+		return nil, errs.E(errs.IO, op, err)
+	}
+
+	objects, err := p.objects(ctx, ref)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return &service.DatasourceMetadata{
+		Description:  bucket.Labels[descriptionLabel],
+		Created:      bucket.Created,
+		LastModified: bucket.Updated,
+		Objects:      objects,
+	}, nil
+}
+
+// Schema returns nothing, since the objects of a bucket have no schema in the
+// tabular sense, the objects are listed in the metadata instead.
+func (p *gcsDatasourceProvider) Schema(_ context.Context, _ service.DatasourceRef) ([]*service.BigqueryColumn, error) {
+	return nil, nil
+}
+
+// objects lists the first objects below the prefix of the datasource.
+func (p *gcsDatasourceProvider) objects(ctx context.Context, ref service.DatasourceRef) ([]*service.GCSObject, error) {
+	const op errs.Op = "gcsDatasourceProvider.objects"
+
+	objects, err := p.ops.GetBucketObjects(ctx, ref.Bucket, &cs.Query{Prefix: ref.Prefix, Limit: maxListedObjects})
+	if err != nil {
+		if errors.Is(err, cs.ErrBucketNotExist) {
+			return nil, errs.E(errs.NotExist, op, fmt.Errorf("bucket %s does not exist", ref.Bucket))
+		}
+
+		return nil, errs.E(errs.IO, op, err)
+	}
+
+	listed := make([]*service.GCSObject, len(objects))
+	for i, obj := range objects {
+		listed[i] = &service.GCSObject{
+			Name:        obj.Name,
+			ContentType: obj.Attrs.ContentType,
+			Size:        obj.Attrs.Size,
+		}
+	}
+
+	return listed, nil
+}
+
+// Grant gives the member read access to the objects below the prefix of the
+// datasource, and not to the rest of the bucket
+func (p *gcsDatasourceProvider) Grant(ctx context.Context, ref service.DatasourceRef, member string) error {
+	const op errs.Op = "gcsDatasourceProvider.Grant"
+
+	err := p.ops.AddBucketMember(ctx, ref.Bucket, ref.Prefix, cs.ObjectViewerRole, member)
+	if err != nil {
+		if errors.Is(err, cs.ErrBucketNotExist) {
+			return errs.E(errs.NotExist, op, fmt.Errorf("bucket %s does not exist", ref.Bucket))
+		}
+
+		return errs.E(errs.IO, op, err)
+	}
+
+	return nil
+}
+
+func (p *gcsDatasourceProvider) Revoke(ctx context.Context, ref service.DatasourceRef, member string) error {
+	const op errs.Op = "gcsDatasourceProvider.Revoke"
+
+	err := p.ops.RemoveBucketMember(ctx, ref.Bucket, ref.Prefix, cs.ObjectViewerRole, member)
+	if err != nil {
+		if errors.Is(err, cs.ErrBucketNotExist) {
+			return errs.E(errs.NotExist, op, fmt.Errorf("bucket %s does not exist", ref.Bucket))
+		}
+
+		return errs.E(errs.IO, op, err)
+	}
+
+	return nil
+}
+
+func NewGCSDatasourceProvider(ops cs.BucketOperations) *gcsDatasourceProvider {
+	return &gcsDatasourceProvider{
+		ops: ops,
+	}
+}
@@ -0,0 +1,68 @@
+package gcp_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fsouza/fake-gcs-server/fakestorage"
+	"github.com/navikt/nada-backend/pkg/cs"
+	"github.com/navikt/nada-backend/pkg/cs/emulator"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core/api/gcp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGCSDatasourceProvider(t *testing.T) {
+	e := emulator.New(t, []fakestorage.Object{
+		{
+			ObjectAttrs: fakestorage.ObjectAttrs{
+				BucketName:  "some-bucket",
+				Name:        "exports/data.csv",
+				ContentType: "text/csv",
+			},
+			Content: []byte("id,name"),
+		},
+		{
+			ObjectAttrs: fakestorage.ObjectAttrs{
+				BucketName: "some-bucket",
+				Name:       "other/file.txt",
+			},
+		},
+	})
+	defer e.Cleanup()
+
+	p := gcp.NewGCSDatasourceProvider(cs.NewBucketClient(e.Client()))
+
+	ref := service.DatasourceRef{
+		Type:   service.DatasourceTypeGCS,
+		Bucket: "some-bucket",
+		Prefix: "exports/",
+	}
+
+	t.Run("Exists", func(t *testing.T) {
+		exists, err := p.Exists(context.Background(), ref)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = p.Exists(context.Background(), service.DatasourceRef{Bucket: "missing-bucket"})
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("Metadata", func(t *testing.T) {
+		meta, err := p.Metadata(context.Background(), ref)
+		assert.NoError(t, err)
+		assert.Equal(t, []*service.GCSObject{
+			{
+				Name:        "exports/data.csv",
+				ContentType: "text/csv",
+				Size:        7,
+			},
+		}, meta.Objects)
+		assert.Empty(t, meta.Schema)
+
+		_, err = p.Metadata(context.Background(), service.DatasourceRef{Bucket: "missing-bucket"})
+		assert.True(t, errs.KindIs(errs.NotExist, err))
+	})
+}
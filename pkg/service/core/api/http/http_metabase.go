@@ -222,7 +222,7 @@ func (c *metabaseAPI) CreateDatabase(ctx context.Context, team, name, saJSON, sa
 	}
 
 	db := NewDatabase{
-		Name: strings.Split(team, "@")[0] + ": " + name,
+		Name: databaseName(team, name),
 		Details: Details{
 			DatasetID:          ds.Dataset,
 			ProjectID:          ds.ProjectID,
@@ -315,6 +315,29 @@ func (c *metabaseAPI) Tables(ctx context.Context, dbID int) ([]service.MetabaseT
 	return ret, nil
 }
 
+// databaseName prefixes the name of the database with the team part of
+// the owner group, so databases are grouped by team in Metabase
+func databaseName(team, name string) string {
+	return strings.Split(team, "@")[0] + ": " + name
+}
+
+func (c *metabaseAPI) UpdateDatabaseName(ctx context.Context, id int, team, name string) error {
+	const op errs.Op = "metabaseAPI.UpdateDatabaseName"
+
+	db := struct {
+		Name string `json:"name"`
+	}{
+		Name: databaseName(team, name),
+	}
+
+	err := c.request(ctx, http.MethodPut, fmt.Sprintf("/database/%d", id), db, nil)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
 func (c *metabaseAPI) DeleteDatabase(ctx context.Context, id int) error {
 	const op errs.Op = "metabaseAPI.DeleteDatabase"
 
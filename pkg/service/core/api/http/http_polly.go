@@ -3,8 +3,10 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/navikt/nada-backend/pkg/errs"
@@ -65,6 +67,78 @@ func (p *pollyAPI) SearchPolly(_ context.Context, q string) ([]*service.QueryPol
 	return ret, nil
 }
 
+type PollyProcessResponse struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Start      string `json:"start"`
+	End        string `json:"end"`
+	LegalBases []struct {
+		Description string `json:"description"`
+	} `json:"legalBases"`
+}
+
+func (p *pollyAPI) GetPollyProcess(ctx context.Context, externalID string) (*service.PollyProcess, error) {
+	const op errs.Op = "http.GetPollyProcess"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.apiURL+"/"+url.PathEscape(externalID), nil)
+	if err != nil {
+		return nil, errs.E(errs.IO, op, err)
+	}
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return nil, errs.E(errs.IO, op, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, errs.E(errs.NotExist, op, fmt.Errorf("treatment %s not found", externalID))
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errs.E(errs.IO, op, fmt.Errorf("unexpected status code %d for treatment %s", res.StatusCode, externalID))
+	}
+
+	var pr PollyProcessResponse
+	if err := json.NewDecoder(res.Body).Decode(&pr); err != nil {
+		return nil, errs.E(errs.IO, op, err)
+	}
+
+	process := &service.PollyProcess{
+		ExternalID: pr.ID,
+		Name:       pr.Name,
+	}
+
+	if process.ValidFrom, err = parsePollyDate(pr.Start); err != nil {
+		return nil, errs.E(errs.IO, op, err)
+	}
+
+	if process.ValidTo, err = parsePollyDate(pr.End); err != nil {
+		return nil, errs.E(errs.IO, op, err)
+	}
+
+	for _, lb := range pr.LegalBases {
+		process.LegalBases = append(process.LegalBases, lb.Description)
+	}
+
+	return process, nil
+}
+
+// parsePollyDate parses the dates of the treatment catalogue, which are
+// given without time of day
+func parsePollyDate(date string) (*time.Time, error) {
+	if date == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return nil, fmt.Errorf("parsing date %q: %w", date, err)
+	}
+
+	return &t, nil
+}
+
 func NewPollyAPI(url, apiURL string) *pollyAPI {
 	return &pollyAPI{
 		client: &http.Client{
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/navikt/nada-backend/pkg/errs"
 	"github.com/navikt/nada-backend/pkg/service"
 	slackapi "github.com/slack-go/slack"
@@ -30,6 +31,131 @@ func (a *slackAPI) SendSlackNotification(channel, message string) error {
 	return nil
 }
 
+// SendSlackNotificationToUser sends a direct message to the Slack user
+// registered with the given email
+func (a *slackAPI) SendSlackNotificationToUser(email, message string) error {
+	const op = "slackAPI.SendSlackNotificationToUser"
+
+	user, err := a.api.GetUserByEmail(email)
+	if err != nil {
+		return errs.E(errs.IO, op, err)
+	}
+
+	_, _, _, err = a.api.SendMessage(user.ID, slackapi.MsgOptionText(message, false))
+	if err != nil {
+		return errs.E(errs.IO, op, err)
+	}
+
+	return nil
+}
+
+// SendAccessRequestApproval sends the message with buttons for approving or
+// denying the access request, the buttons carry the id of the access request
+func (a *slackAPI) SendAccessRequestApproval(channel, message string, accessRequestID uuid.UUID) error {
+	const op = "slackAPI.SendAccessRequestApproval"
+
+	approve := slackapi.NewButtonBlockElement(
+		string(service.SlackInteractionApproveAccessRequest),
+		accessRequestID.String(),
+		slackapi.NewTextBlockObject(slackapi.PlainTextType, "Godkjenn", false, false),
+	).WithStyle(slackapi.StylePrimary)
+
+	deny := slackapi.NewButtonBlockElement(
+		string(service.SlackInteractionDenyAccessRequest),
+		accessRequestID.String(),
+		slackapi.NewTextBlockObject(slackapi.PlainTextType, "Avslå", false, false),
+	).WithStyle(slackapi.StyleDanger)
+
+	_, _, _, err := a.api.SendMessage(channel,
+		slackapi.MsgOptionText(message, false),
+		slackapi.MsgOptionBlocks(
+			slackapi.NewSectionBlock(slackapi.NewTextBlockObject(slackapi.MarkdownType, message, false, false), nil, nil),
+			slackapi.NewActionBlock(accessRequestID.String(), approve, deny),
+		),
+	)
+	if err != nil {
+		return errs.E(errs.IO, op, err)
+	}
+
+	return nil
+}
+
+// UpdateAccessRequestMessage replaces the approval message, and its buttons,
+// with the outcome of the access request
+func (a *slackAPI) UpdateAccessRequestMessage(channel, timestamp, message string) error {
+	const op = "slackAPI.UpdateAccessRequestMessage"
+
+	_, _, _, err := a.api.UpdateMessage(channel, timestamp,
+		slackapi.MsgOptionText(message, false),
+		slackapi.MsgOptionBlocks(
+			slackapi.NewSectionBlock(slackapi.NewTextBlockObject(slackapi.MarkdownType, message, false, false), nil, nil),
+		),
+	)
+	if err != nil {
+		return errs.E(errs.IO, op, err)
+	}
+
+	return nil
+}
+
+func (a *slackAPI) OpenDenyAccessRequestModal(triggerID string, interaction service.SlackInteraction) error {
+	const op = "slackAPI.OpenDenyAccessRequestModal"
+
+	reason := slackapi.NewPlainTextInputBlockElement(nil, service.SlackDenyReasonInputID)
+	reason.Multiline = true
+
+	input := slackapi.NewInputBlock(
+		service.SlackDenyReasonInputID,
+		slackapi.NewTextBlockObject(slackapi.PlainTextType, "Begrunnelse", false, false),
+		nil,
+		reason,
+	)
+	input.Optional = true
+
+	_, err := a.api.OpenView(triggerID, slackapi.ModalViewRequest{
+		Type:            slackapi.VTModal,
+		Title:           slackapi.NewTextBlockObject(slackapi.PlainTextType, "Avslå søknad", false, false),
+		Submit:          slackapi.NewTextBlockObject(slackapi.PlainTextType, "Avslå", false, false),
+		Close:           slackapi.NewTextBlockObject(slackapi.PlainTextType, "Avbryt", false, false),
+		CallbackID:      string(service.SlackInteractionDenyAccessRequestReason),
+		PrivateMetadata: interaction.Metadata(),
+		Blocks: slackapi.Blocks{
+			BlockSet: []slackapi.Block{input},
+		},
+	})
+	if err != nil {
+		return errs.E(errs.IO, op, err)
+	}
+
+	return nil
+}
+
+func (a *slackAPI) SendSlackEphemeral(channel, userID, message string) error {
+	const op = "slackAPI.SendSlackEphemeral"
+
+	_, err := a.api.PostEphemeral(channel, userID, slackapi.MsgOptionText(message, false))
+	if err != nil {
+		return errs.E(errs.IO, op, err)
+	}
+
+	return nil
+}
+
+func (a *slackAPI) GetSlackUserEmail(userID string) (string, error) {
+	const op = "slackAPI.GetSlackUserEmail"
+
+	user, err := a.api.GetUserInfo(userID)
+	if err != nil {
+		return "", errs.E(errs.IO, op, err)
+	}
+
+	if user.Profile.Email == "" {
+		return "", errs.E(errs.NotExist, op, fmt.Errorf("slack user %s has no email", userID))
+	}
+
+	return user.Profile.Email, nil
+}
+
 func (a *slackAPI) IsValidSlackChannel(name string) error {
 	const op = "slackAPI.IsValidSlackChannel"
 
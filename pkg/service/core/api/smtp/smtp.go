@@ -0,0 +1,110 @@
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+type emailAPI struct {
+	host     string
+	port     int
+	from     string
+	username string
+	password string
+}
+
+var _ service.EmailAPI = &emailAPI{}
+
+func (a *emailAPI) SendEmail(ctx context.Context, to, subject, body string) error {
+	const op errs.Op = "emailAPI.SendEmail"
+
+	addr := net.JoinHostPort(a.host, strconv.Itoa(a.port))
+
+	conn, err := (&net.Dialer{Timeout: 10 * time.Second}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return errs.E(errs.IO, op, err)
+	}
+
+	client, err := smtp.NewClient(conn, a.host)
+	if err != nil {
+		_ = conn.Close()
+		return errs.E(errs.IO, op, err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: a.host}); err != nil {
+			return errs.E(errs.IO, op, err)
+		}
+	}
+
+	if a.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", a.username, a.password, a.host)); err != nil {
+			return errs.E(errs.IO, op, err)
+		}
+	}
+
+	if err := client.Mail(a.from); err != nil {
+		return errs.E(errs.IO, op, err)
+	}
+
+	if err := client.Rcpt(to); err != nil {
+		return errs.E(errs.IO, op, err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return errs.E(errs.IO, op, err)
+	}
+
+	if _, err := w.Write(message(a.from, to, subject, body)); err != nil {
+		return errs.E(errs.IO, op, err)
+	}
+
+	if err := w.Close(); err != nil {
+		return errs.E(errs.IO, op, err)
+	}
+
+	if err := client.Quit(); err != nil {
+		return errs.E(errs.IO, op, err)
+	}
+
+	return nil
+}
+
+func message(from, to, subject, body string) []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(body)
+	b.WriteString("\r\n")
+
+	return b.Bytes()
+}
+
+func NewEmailAPI(host string, port int, from, username, password string) *emailAPI {
+	return &emailAPI{
+		host:     host,
+		port:     port,
+		from:     from,
+		username: username,
+		password: password,
+	}
+}
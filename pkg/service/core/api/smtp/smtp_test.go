@@ -0,0 +1,32 @@
+package smtp_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/navikt/nada-backend/pkg/service/core/api/smtp"
+	"github.com/navikt/nada-backend/pkg/smtp/emulator"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendEmail(t *testing.T) {
+	e := emulator.New(zerolog.Nop())
+	host, port := e.Run()
+	defer e.Close()
+
+	api := smtp.NewEmailAPI(host, port, "nada@nav.no", "", "")
+
+	err := api.SendEmail(context.Background(), "user@nav.no", "Søknad om tilgang godkjent", "Søknaden er godkjent.\nLink: https://data.nav.no")
+	require.NoError(t, err)
+
+	messages := e.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, &emulator.Message{
+		From:    "nada@nav.no",
+		To:      []string{"user@nav.no"},
+		Subject: "Søknad om tilgang godkjent",
+		Body:    "Søknaden er godkjent.\nLink: https://data.nav.no",
+	}, messages[0])
+}
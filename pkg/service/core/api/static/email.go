@@ -0,0 +1,23 @@
+package static
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+)
+
+type emailAPI struct {
+	log zerolog.Logger
+}
+
+func (e *emailAPI) SendEmail(_ context.Context, to, subject, body string) error {
+	e.log.Info().Msgf("Sending email to %v: subject: %v: body: %v", to, subject, body)
+
+	return nil
+}
+
+func NewEmailAPI(log zerolog.Logger) *emailAPI {
+	return &emailAPI{
+		log: log,
+	}
+}
@@ -0,0 +1,30 @@
+package static
+
+import (
+	"context"
+
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+type googleGroupsAPI struct {
+	groups map[string]service.Groups
+}
+
+func (g *googleGroupsAPI) Groups(_ context.Context, email *string) (service.Groups, error) {
+	if email == nil {
+		var all service.Groups
+		for _, groups := range g.groups {
+			all = append(all, groups...)
+		}
+
+		return all, nil
+	}
+
+	return g.groups[*email], nil
+}
+
+func NewGoogleGroupsAPI(groups map[string]service.Groups) *googleGroupsAPI {
+	return &googleGroupsAPI{
+		groups: groups,
+	}
+}
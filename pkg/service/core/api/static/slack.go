@@ -1,6 +1,8 @@
 package static
 
 import (
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/service"
 	"github.com/rs/zerolog"
 )
 
@@ -14,6 +16,44 @@ func (s *slackAPI) SendSlackNotification(channel, message string) error {
 	return nil
 }
 
+func (s *slackAPI) SendSlackNotificationToUser(email, message string) error {
+	s.log.Info().Msgf("Sending slack notification to user %v: message: %v", email, message)
+
+	return nil
+}
+
+func (s *slackAPI) SendAccessRequestApproval(channel, message string, accessRequestID uuid.UUID) error {
+	s.log.Info().Msgf("Sending slack approval of access request %v to channel %v: message: %v", accessRequestID, channel, message)
+
+	return nil
+}
+
+func (s *slackAPI) UpdateAccessRequestMessage(channel, timestamp, message string) error {
+	s.log.Info().Msgf("Updating slack message %v in channel %v: message: %v", timestamp, channel, message)
+
+	return nil
+}
+
+func (s *slackAPI) OpenDenyAccessRequestModal(triggerID string, interaction service.SlackInteraction) error {
+	s.log.Info().Msgf("Opening slack modal %v for denying access request %v", triggerID, interaction.AccessRequestID)
+
+	return nil
+}
+
+func (s *slackAPI) SendSlackEphemeral(channel, userID, message string) error {
+	s.log.Info().Msgf("Sending slack ephemeral message to user %v in channel %v: message: %v", userID, channel, message)
+
+	return nil
+}
+
+// GetSlackUserEmail uses the user id as the email, so the user id of an
+// interaction decides which catalogue user it is performed as
+func (s *slackAPI) GetSlackUserEmail(userID string) (string, error) {
+	s.log.Info().Msgf("Getting email of slack user %v", userID)
+
+	return userID, nil
+}
+
 func (s *slackAPI) IsValidSlackChannel(channel string) error {
 	s.log.Info().Msgf("Validating slack channel %s", channel)
 
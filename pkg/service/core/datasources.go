@@ -0,0 +1,39 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+func datasourceProvider(providers service.DatasourceProviders, t service.DatasourceType) (service.DatasourceProvider, error) {
+	const op errs.Op = "core.datasourceProvider"
+
+	provider, ok := providers.Provider(t)
+	if !ok {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("unsupported datasource type %s", t))
+	}
+
+	return provider, nil
+}
+
+// datasetDatasource returns the reference to the data behind the dataset and
+// the provider for its type of datasource
+func datasetDatasource(ctx context.Context, storage service.DatasourceStorage, providers service.DatasourceProviders, datasetID uuid.UUID) (*service.DatasourceRef, service.DatasourceProvider, error) {
+	const op errs.Op = "core.datasetDatasource"
+
+	ref, err := storage.GetDatasourceRef(ctx, datasetID)
+	if err != nil {
+		return nil, nil, errs.E(op, err)
+	}
+
+	provider, err := datasourceProvider(providers, ref.Type)
+	if err != nil {
+		return nil, nil, errs.E(op, err)
+	}
+
+	return ref, provider, nil
+}
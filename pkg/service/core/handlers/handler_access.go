@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi"
 	"github.com/google/uuid"
@@ -66,7 +67,7 @@ func (h *AccessHandler) GrantAccessToDataset(ctx context.Context, _ *http.Reques
 	return &transport.Empty{}, nil
 }
 
-func (h *AccessHandler) GetAccessRequests(ctx context.Context, r *http.Request, _ interface{}) (*service.AccessRequestsWrapper, error) {
+func (h *AccessHandler) GetAccessRequests(ctx context.Context, r *http.Request, page service.PageRequest) (*service.Page[*service.AccessRequest], error) {
 	op := "AccessHandler.GetAccessRequests"
 
 	id, err := uuid.Parse(r.URL.Query().Get("datasetId"))
@@ -74,7 +75,7 @@ func (h *AccessHandler) GetAccessRequests(ctx context.Context, r *http.Request,
 		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing access request id: %w", err))
 	}
 
-	access, err := h.accessService.GetAccessRequests(ctx, id)
+	access, err := h.accessService.GetAccessRequests(ctx, id, page)
 	if err != nil {
 		return nil, errs.E(op, err)
 	}
@@ -167,6 +168,55 @@ func (h *AccessHandler) UpdateAccessRequest(ctx context.Context, _ *http.Request
 	return &transport.Empty{}, nil
 }
 
+func (h *AccessHandler) RenewAccess(ctx context.Context, _ *http.Request, in service.RenewAccessDTO) (*service.AccessRequest, error) {
+	const op errs.Op = "AccessHandler.RenewAccess"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing access id: %w", err))
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	ar, err := h.accessService.RenewAccess(ctx, user, id, in)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return ar, nil
+}
+
+// GetExpiringAccess returns the accesses to the datasets of the user that
+// expire within the days, 30 by default
+func (h *AccessHandler) GetExpiringAccess(ctx context.Context, r *http.Request, _ any) (*service.ExpiringAccesses, error) {
+	const op errs.Op = "AccessHandler.GetExpiringAccess"
+
+	days := 30
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		d, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("days"), fmt.Errorf("parsing days: %w", err))
+		}
+
+		days = d
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	accesses, err := h.accessService.GetExpiringAccess(ctx, user, days)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return accesses, nil
+}
+
 func NewAccessHandler(
 	service service.AccessService,
 	metabaseService service.MetabaseService,
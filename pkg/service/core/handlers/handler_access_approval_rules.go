@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/auth"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+)
+
+type AccessApprovalRulesHandler struct {
+	service service.AccessApprovalRuleService
+}
+
+func (h *AccessApprovalRulesHandler) GetAccessApprovalRules(ctx context.Context, r *http.Request, _ any) (*service.AccessApprovalRules, error) {
+	const op errs.Op = "AccessApprovalRulesHandler.GetAccessApprovalRules"
+
+	id, err := uuid.Parse(r.URL.Query().Get("datasetId"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("datasetId"), fmt.Errorf("parsing dataset id: %w", err))
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	rules, err := h.service.GetAccessApprovalRules(ctx, user, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return rules, nil
+}
+
+func (h *AccessApprovalRulesHandler) CreateAccessApprovalRule(ctx context.Context, _ *http.Request, in service.NewAccessApprovalRule) (*service.AccessApprovalRule, error) {
+	const op errs.Op = "AccessApprovalRulesHandler.CreateAccessApprovalRule"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	rule, err := h.service.CreateAccessApprovalRule(ctx, user, in)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return rule, nil
+}
+
+func (h *AccessApprovalRulesHandler) DeleteAccessApprovalRule(ctx context.Context, _ *http.Request, _ any) (*transport.Empty, error) {
+	const op errs.Op = "AccessApprovalRulesHandler.DeleteAccessApprovalRule"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	err = h.service.DeleteAccessApprovalRule(ctx, user, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return &transport.Empty{}, nil
+}
+
+func NewAccessApprovalRulesHandler(service service.AccessApprovalRuleService) *AccessApprovalRulesHandler {
+	return &AccessApprovalRulesHandler{
+		service: service,
+	}
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"gopkg.in/yaml.v3"
+)
+
+// maxCatalogueSpecSize is the largest spec we accept, in bytes
+const maxCatalogueSpecSize = 10 << 20
+
+type CatalogueApplyHandler struct {
+	service service.CatalogueApplyService
+}
+
+func (h *CatalogueApplyHandler) Apply(ctx context.Context, r *http.Request, _ any) (*service.CataloguePlan, error) {
+	const op errs.Op = "CatalogueApplyHandler.Apply"
+
+	teamEmail, ok := r.Context().Value(ContextKeyTeamEmail).(string)
+	if !ok {
+		return nil, errs.E(errs.Internal, op, fmt.Errorf("team not found in context"))
+	}
+
+	spec, err := parseCatalogueSpecFromRequest(r)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	opts, err := parseCatalogueApplyOptionsFromRequest(r)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	plan, err := h.service.Apply(ctx, teamEmail, spec, *opts)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return plan, nil
+}
+
+// parseCatalogueSpecFromRequest reads the spec from the body, which is YAML
+// if the content type says so and JSON otherwise
+func parseCatalogueSpecFromRequest(r *http.Request) (*service.CatalogueSpec, error) {
+	const op errs.Op = "parseCatalogueSpecFromRequest"
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxCatalogueSpecSize))
+	if err != nil {
+		return nil, errs.E(errs.IO, op, err)
+	}
+
+	spec := &service.CatalogueSpec{}
+
+	if strings.Contains(r.Header.Get("Content-Type"), "yaml") {
+		err = yaml.Unmarshal(data, spec)
+	} else {
+		err = json.Unmarshal(data, spec)
+	}
+
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing spec: %w", err))
+	}
+
+	return spec, nil
+}
+
+func parseCatalogueApplyOptionsFromRequest(r *http.Request) (*service.CatalogueApplyOptions, error) {
+	const op errs.Op = "parseCatalogueApplyOptionsFromRequest"
+
+	options := &service.CatalogueApplyOptions{}
+
+	for param, v := range map[string]*bool{
+		"planOnly": &options.PlanOnly,
+		"prune":    &options.Prune,
+	} {
+		raw := r.URL.Query().Get(param)
+		if raw == "" {
+			continue
+		}
+
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errs.E(errs.InvalidRequest, op, errs.Parameter(param), err)
+		}
+
+		*v = b
+	}
+
+	return options, nil
+}
+
+func NewCatalogueApplyHandler(service service.CatalogueApplyService) *CatalogueApplyHandler {
+	return &CatalogueApplyHandler{service: service}
+}
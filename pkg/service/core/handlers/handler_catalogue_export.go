@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+)
+
+type CatalogueExportHandler struct {
+	service service.CatalogueExportService
+}
+
+func (h *CatalogueExportHandler) ExportDCAT(ctx context.Context, r *http.Request, _ any) (*transport.ByteWriter, error) {
+	const op errs.Op = "CatalogueExportHandler.ExportDCAT"
+
+	opts, err := parseDCATExportOptionsFromRequest(r)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	export, err := h.service.ExportDCAT(ctx, *opts)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return transport.NewByteWriter(export.ContentType, "", export.Data), nil
+}
+
+// parseDCATExportOptionsFromRequest reads the export options from the query,
+// falling back to the Accept header for the format
+func parseDCATExportOptionsFromRequest(r *http.Request) (*service.DCATExportOptions, error) {
+	const op errs.Op = "parseDCATExportOptionsFromRequest"
+
+	query := r.URL.Query()
+
+	options := &service.DCATExportOptions{
+		Format: service.DCATFormatJSONLD,
+	}
+
+	if format := query.Get("format"); format != "" {
+		options.Format = service.DCATFormat(format)
+	} else if strings.Contains(r.Header.Get("Accept"), "text/turtle") {
+		options.Format = service.DCATFormatTurtle
+	}
+
+	if since := query.Get("modifiedSince"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("modifiedSince"), err)
+		}
+
+		options.ModifiedSince = &t
+	}
+
+	if exclude := query.Get("exclude"); exclude != "" {
+		for _, f := range strings.Split(exclude, ",") {
+			options.ExcludeFields = append(options.ExcludeFields, service.DCATField(strings.TrimSpace(f)))
+		}
+	}
+
+	if excludeSensitive := query.Get("excludeSensitive"); excludeSensitive != "" {
+		v, err := strconv.ParseBool(excludeSensitive)
+		if err != nil {
+			return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("excludeSensitive"), err)
+		}
+
+		options.ExcludeSensitive = v
+	}
+
+	return options, nil
+}
+
+func NewCatalogueExportHandler(service service.CatalogueExportService) *CatalogueExportHandler {
+	return &CatalogueExportHandler{service: service}
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/auth"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+)
+
+type ComplianceHandler struct {
+	service service.ComplianceService
+}
+
+func (h *ComplianceHandler) GetReport(ctx context.Context, r *http.Request, _ any) (*service.ComplianceReport, error) {
+	const op errs.Op = "ComplianceHandler.GetReport"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	filter, err := complianceReportFilter(r)
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	report, err := h.service.GetReport(ctx, user, filter)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return report, nil
+}
+
+func (h *ComplianceHandler) ExportReport(ctx context.Context, r *http.Request, _ any) (*transport.ByteWriter, error) {
+	const op errs.Op = "ComplianceHandler.ExportReport"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	filter, err := complianceReportFilter(r)
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	format := service.ComplianceExportFormatCSV
+	if f := r.URL.Query().Get("format"); f != "" {
+		format = service.ComplianceExportFormat(f)
+	}
+
+	export, err := h.service.ExportReport(ctx, user, filter, format)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return transport.NewByteWriter(export.ContentType, "", export.Data), nil
+}
+
+func complianceReportFilter(r *http.Request) (service.ComplianceReportFilter, error) {
+	filter := service.ComplianceReportFilter{}
+
+	if id := r.URL.Query().Get("productAreaId"); id != "" {
+		productAreaID, err := uuid.Parse(id)
+		if err != nil {
+			return filter, fmt.Errorf("parsing productAreaId: %w", err)
+		}
+
+		filter.ProductAreaID = &productAreaID
+	}
+
+	if id := r.URL.Query().Get("teamId"); id != "" {
+		teamID, err := uuid.Parse(id)
+		if err != nil {
+			return filter, fmt.Errorf("parsing teamId: %w", err)
+		}
+
+		filter.TeamID = &teamID
+	}
+
+	return filter, nil
+}
+
+func NewComplianceHandler(s service.ComplianceService) *ComplianceHandler {
+	return &ComplianceHandler{service: s}
+}
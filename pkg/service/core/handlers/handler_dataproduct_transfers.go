@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/auth"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+type DataproductTransferHandler struct {
+	service service.DataproductTransferService
+}
+
+func (h *DataproductTransferHandler) RequestDataproductTransfer(ctx context.Context, _ *http.Request, in service.NewDataproductTransfer) (*service.DataproductTransfer, error) {
+	const op errs.Op = "DataproductTransferHandler.RequestDataproductTransfer"
+
+	err := in.Validate()
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	transfer, err := h.service.RequestDataproductTransfer(ctx, user, in)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return transfer, nil
+}
+
+func (h *DataproductTransferHandler) GetDataproductTransfersForUser(ctx context.Context, _ *http.Request, _ any) ([]*service.DataproductTransfer, error) {
+	const op errs.Op = "DataproductTransferHandler.GetDataproductTransfersForUser"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	transfers, err := h.service.GetDataproductTransfersForUser(ctx, user)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return transfers, nil
+}
+
+func (h *DataproductTransferHandler) GetDataproductTransfersForDataproduct(ctx context.Context, _ *http.Request, _ any) ([]*service.DataproductTransfer, error) {
+	const op errs.Op = "DataproductTransferHandler.GetDataproductTransfersForDataproduct"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	transfers, err := h.service.GetDataproductTransfersForDataproduct(ctx, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return transfers, nil
+}
+
+func (h *DataproductTransferHandler) AcceptDataproductTransfer(ctx context.Context, _ *http.Request, _ any) (*service.DataproductTransfer, error) {
+	const op errs.Op = "DataproductTransferHandler.AcceptDataproductTransfer"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	transfer, err := h.service.AcceptDataproductTransfer(ctx, user, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return transfer, nil
+}
+
+func (h *DataproductTransferHandler) RejectDataproductTransfer(ctx context.Context, _ *http.Request, in service.RejectDataproductTransfer) (*service.DataproductTransfer, error) {
+	const op errs.Op = "DataproductTransferHandler.RejectDataproductTransfer"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	transfer, err := h.service.RejectDataproductTransfer(ctx, user, id, in)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return transfer, nil
+}
+
+func (h *DataproductTransferHandler) CancelDataproductTransfer(ctx context.Context, _ *http.Request, _ any) (*service.DataproductTransfer, error) {
+	const op errs.Op = "DataproductTransferHandler.CancelDataproductTransfer"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	transfer, err := h.service.CancelDataproductTransfer(ctx, user, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return transfer, nil
+}
+
+func NewDataproductTransferHandler(service service.DataproductTransferService) *DataproductTransferHandler {
+	return &DataproductTransferHandler{service: service}
+}
@@ -91,10 +91,10 @@ func (h *DataProductsHandler) UpdateDataProduct(ctx context.Context, _ *http.Req
 	return dp, nil
 }
 
-func (h *DataProductsHandler) GetDatasetsMinimal(ctx context.Context, _ *http.Request, _ interface{}) ([]*service.DatasetMinimal, error) {
+func (h *DataProductsHandler) GetDatasetsMinimal(ctx context.Context, _ *http.Request, page service.PageRequest) (*service.Page[*service.DatasetMinimal], error) {
 	const op errs.Op = "DataProductsHandler.GetDatasetsMinimal"
 
-	datasets, err := h.service.GetDatasetsMinimal(ctx)
+	datasets, err := h.service.GetDatasetsMinimalPage(ctx, page)
 	if err != nil {
 		return nil, errs.E(op, err)
 	}
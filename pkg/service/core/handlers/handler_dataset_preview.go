@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/auth"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+type DatasetPreviewHandler struct {
+	service service.DatasetPreviewService
+}
+
+func (h *DatasetPreviewHandler) GetDatasetPreview(ctx context.Context, r *http.Request, _ any) (*service.DatasetPreview, error) {
+	const op errs.Op = "DatasetPreviewHandler.GetDatasetPreview"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	limit := service.DatasetPreviewDefaultLimit
+	if l := r.URL.Query().Get("limit"); l != "" {
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit < 1 || limit > service.DatasetPreviewMaxLimit {
+			return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("limit"), fmt.Errorf("limit must be between 1 and %d", service.DatasetPreviewMaxLimit))
+		}
+	}
+
+	preview, err := h.service.GetDatasetPreview(ctx, auth.GetUser(ctx), id, limit)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return preview, nil
+}
+
+func (h *DatasetPreviewHandler) UpdateDatasetPreviewSettings(ctx context.Context, _ *http.Request, in service.UpdateDatasetPreviewSettings) (*service.DatasetPreviewSettings, error) {
+	const op errs.Op = "DatasetPreviewHandler.UpdateDatasetPreviewSettings"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	settings, err := h.service.UpdateDatasetPreviewSettings(ctx, user, id, in)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return settings, nil
+}
+
+func NewDatasetPreviewHandler(s service.DatasetPreviewService) *DatasetPreviewHandler {
+	return &DatasetPreviewHandler{service: s}
+}
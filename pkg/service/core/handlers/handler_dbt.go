@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+type DbtHandler struct {
+	service service.DbtService
+}
+
+func (h *DbtHandler) ImportManifest(ctx context.Context, r *http.Request, manifest *service.DbtManifest) (*service.DbtImportReport, error) {
+	const op errs.Op = "DbtHandler.ImportManifest"
+
+	teamEmail, ok := r.Context().Value(ContextKeyTeamEmail).(string)
+	if !ok {
+		return nil, errs.E(errs.Internal, op, fmt.Errorf("team not found in context"))
+	}
+
+	opts, err := parseDbtImportOptionsFromRequest(r)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	report, err := h.service.ImportManifest(ctx, teamEmail, *opts, manifest)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return report, nil
+}
+
+func parseDbtImportOptionsFromRequest(r *http.Request) (*service.DbtImportOptions, error) {
+	const op errs.Op = "parseDbtImportOptionsFromRequest"
+
+	query := r.URL.Query()
+
+	options := &service.DbtImportOptions{
+		Pii: service.PiiLevel(query.Get("pii")),
+	}
+
+	if dryRun := query.Get("dryRun"); dryRun != "" {
+		v, err := strconv.ParseBool(dryRun)
+		if err != nil {
+			return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("dryRun"), err)
+		}
+
+		options.DryRun = v
+	}
+
+	if dataproductID := query.Get("createInDataproduct"); dataproductID != "" {
+		id, err := uuid.Parse(dataproductID)
+		if err != nil {
+			return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("createInDataproduct"), err)
+		}
+
+		options.CreateInDataproduct = &id
+	}
+
+	return options, nil
+}
+
+func NewDbtHandler(service service.DbtService) *DbtHandler {
+	return &DbtHandler{service: service}
+}
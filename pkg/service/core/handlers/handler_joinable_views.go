@@ -10,6 +10,7 @@ import (
 	"github.com/navikt/nada-backend/pkg/auth"
 	"github.com/navikt/nada-backend/pkg/errs"
 	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
 )
 
 type JoinableViewsHandler struct {
@@ -70,6 +71,79 @@ func (h *JoinableViewsHandler) GetJoinableView(ctx context.Context, _ *http.Requ
 	return view, nil
 }
 
+func (h *JoinableViewsHandler) DeleteJoinableView(ctx context.Context, _ *http.Request, _ any) (*transport.Empty, error) {
+	const op errs.Op = "JoinableViewsHandler.DeleteJoinableView"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	err = h.service.DeleteJoinableView(ctx, user, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return &transport.Empty{}, nil
+}
+
+func (h *JoinableViewsHandler) ExtendJoinableView(ctx context.Context, _ *http.Request, in service.ExtendJoinableView) (*service.JoinableView, error) {
+	const op errs.Op = "JoinableViewsHandler.ExtendJoinableView"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	err = in.Validate()
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	view, err := h.service.ExtendJoinableView(ctx, user, id, in)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return view, nil
+}
+
+func (h *JoinableViewsHandler) ShareJoinableView(ctx context.Context, _ *http.Request, in service.ShareJoinableView) (*transport.Empty, error) {
+	const op errs.Op = "JoinableViewsHandler.ShareJoinableView"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	err = in.Validate()
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	err = h.service.ShareJoinableView(ctx, user, id, in)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return &transport.Empty{}, nil
+}
+
 func NewJoinableViewsHandler(service service.JoinableViewsService) *JoinableViewsHandler {
 	return &JoinableViewsHandler{service: service}
 }
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/auth"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+type LifecycleHandler struct {
+	service service.LifecycleService
+}
+
+func (h *LifecycleHandler) UpdateDataproductLifecycle(ctx context.Context, _ *http.Request, in service.UpdateLifecycle) (*service.Lifecycle, error) {
+	const op errs.Op = "LifecycleHandler.UpdateDataproductLifecycle"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	err = in.Validate()
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	lifecycle, err := h.service.UpdateDataproductLifecycle(ctx, user, id, in)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return lifecycle, nil
+}
+
+func (h *LifecycleHandler) UpdateDatasetLifecycle(ctx context.Context, _ *http.Request, in service.UpdateLifecycle) (*service.Lifecycle, error) {
+	const op errs.Op = "LifecycleHandler.UpdateDatasetLifecycle"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	err = in.Validate()
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	lifecycle, err := h.service.UpdateDatasetLifecycle(ctx, user, id, in)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return lifecycle, nil
+}
+
+func NewLifecycleHandler(service service.LifecycleService) *LifecycleHandler {
+	return &LifecycleHandler{service: service}
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/navikt/nada-backend/pkg/auth"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+)
+
+type NotificationsHandler struct {
+	service service.NotificationService
+}
+
+func (h *NotificationsHandler) GetNotifications(ctx context.Context, r *http.Request, page service.PageRequest) (*service.Page[*service.Notification], error) {
+	const op errs.Op = "NotificationsHandler.GetNotifications"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	unreadOnly := false
+	if unread := r.URL.Query().Get("unread"); unread != "" {
+		v, err := strconv.ParseBool(unread)
+		if err != nil {
+			return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("unread"), err)
+		}
+
+		unreadOnly = v
+	}
+
+	notifications, err := h.service.GetNotifications(ctx, user, unreadOnly, page)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return notifications, nil
+}
+
+func (h *NotificationsHandler) MarkNotificationsRead(ctx context.Context, _ *http.Request, in service.MarkNotificationsRead) (*transport.Empty, error) {
+	const op errs.Op = "NotificationsHandler.MarkNotificationsRead"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	err := h.service.MarkNotificationsRead(ctx, user, in)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return &transport.Empty{}, nil
+}
+
+func (h *NotificationsHandler) MarkAllNotificationsRead(ctx context.Context, _ *http.Request, _ any) (*transport.Empty, error) {
+	const op errs.Op = "NotificationsHandler.MarkAllNotificationsRead"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	err := h.service.MarkAllNotificationsRead(ctx, user)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return &transport.Empty{}, nil
+}
+
+func (h *NotificationsHandler) GetNotificationPreferences(ctx context.Context, _ *http.Request, _ any) (*service.NotificationPreferences, error) {
+	const op errs.Op = "NotificationsHandler.GetNotificationPreferences"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	preferences, err := h.service.GetNotificationPreferences(ctx, user)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return preferences, nil
+}
+
+func (h *NotificationsHandler) UpdateNotificationPreferences(ctx context.Context, _ *http.Request, in service.NotificationPreferences) (*service.NotificationPreferences, error) {
+	const op errs.Op = "NotificationsHandler.UpdateNotificationPreferences"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	preferences, err := h.service.UpdateNotificationPreferences(ctx, user, in)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return preferences, nil
+}
+
+func NewNotificationsHandler(service service.NotificationService) *NotificationsHandler {
+	return &NotificationsHandler{service: service}
+}
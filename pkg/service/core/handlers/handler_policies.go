@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/auth"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+)
+
+type PoliciesHandler struct {
+	service service.PolicyService
+}
+
+func (h *PoliciesHandler) GetPolicies(ctx context.Context, r *http.Request, _ any) (*service.Policies, error) {
+	const op errs.Op = "PoliciesHandler.GetPolicies"
+
+	var datasetID *uuid.UUID
+	if raw := r.URL.Query().Get("datasetId"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("datasetId"), fmt.Errorf("parsing dataset id: %w", err))
+		}
+
+		datasetID = &id
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	policies, err := h.service.GetPolicies(ctx, user, datasetID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return policies, nil
+}
+
+func (h *PoliciesHandler) CreatePolicy(ctx context.Context, _ *http.Request, in service.NewPolicy) (*service.Policy, error) {
+	const op errs.Op = "PoliciesHandler.CreatePolicy"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	policy, err := h.service.CreatePolicy(ctx, user, in)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return policy, nil
+}
+
+func (h *PoliciesHandler) DeletePolicy(ctx context.Context, _ *http.Request, _ any) (*transport.Empty, error) {
+	const op errs.Op = "PoliciesHandler.DeletePolicy"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	err = h.service.DeletePolicy(ctx, user, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return &transport.Empty{}, nil
+}
+
+func (h *PoliciesHandler) DryRun(ctx context.Context, _ *http.Request, in service.PolicyDryRun) (*service.PolicyDecision, error) {
+	const op errs.Op = "PoliciesHandler.DryRun"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	decision, err := h.service.DryRun(ctx, user, in)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return decision, nil
+}
+
+func NewPoliciesHandler(service service.PolicyService) *PoliciesHandler {
+	return &PoliciesHandler{
+		service: service,
+	}
+}
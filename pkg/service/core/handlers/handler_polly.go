@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/go-chi/chi"
+	"github.com/navikt/nada-backend/pkg/auth"
 	"github.com/navikt/nada-backend/pkg/errs"
 
 	"github.com/navikt/nada-backend/pkg/service"
@@ -24,6 +26,33 @@ func (h *PollyHandler) SearchPolly(ctx context.Context, r *http.Request, _ any)
 	return result, nil
 }
 
+func (h *PollyHandler) GetPurposeHistory(ctx context.Context, _ *http.Request, _ any) (*service.PollyPurposeHistory, error) {
+	const op errs.Op = "PollyHandler.GetPurposeHistory"
+
+	history, err := h.pollyService.GetPurposeHistory(ctx, chi.URLParamFromCtx(ctx, "externalID"))
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return history, nil
+}
+
+func (h *PollyHandler) GetAccessWithInvalidPurpose(ctx context.Context, _ *http.Request, _ any) (*service.AccessesWithPollyPurpose, error) {
+	const op errs.Op = "PollyHandler.GetAccessWithInvalidPurpose"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	accesses, err := h.pollyService.GetAccessWithInvalidPurpose(ctx, user)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return accesses, nil
+}
+
 func NewPollyHandler(s service.PollyService) *PollyHandler {
 	return &PollyHandler{pollyService: s}
 }
@@ -43,6 +43,73 @@ func (h *ProductAreasHandler) GetProductAreaWithAssets(ctx context.Context, _ *h
 	return pa, nil
 }
 
+func (h *ProductAreasHandler) GetDataproducts(ctx context.Context, r *http.Request, page service.PageRequest) (*service.Page[*service.Dataproduct], error) {
+	const op errs.Op = "ProductAreasHandler.GetDataproducts"
+
+	id, teamID, err := productAreaAndTeamID(ctx, r)
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	dataproducts, err := h.service.GetDataproducts(ctx, id, teamID, page)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return dataproducts, nil
+}
+
+func (h *ProductAreasHandler) GetStories(ctx context.Context, r *http.Request, page service.PageRequest) (*service.Page[*service.Story], error) {
+	const op errs.Op = "ProductAreasHandler.GetStories"
+
+	id, teamID, err := productAreaAndTeamID(ctx, r)
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	stories, err := h.service.GetStories(ctx, id, teamID, page)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return stories, nil
+}
+
+func (h *ProductAreasHandler) GetInsightProducts(ctx context.Context, r *http.Request, page service.PageRequest) (*service.Page[*service.InsightProduct], error) {
+	const op errs.Op = "ProductAreasHandler.GetInsightProducts"
+
+	id, teamID, err := productAreaAndTeamID(ctx, r)
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	insightProducts, err := h.service.GetInsightProducts(ctx, id, teamID, page)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return insightProducts, nil
+}
+
+func productAreaAndTeamID(ctx context.Context, r *http.Request) (uuid.UUID, *uuid.UUID, error) {
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return uuid.Nil, nil, fmt.Errorf("parsing id: %w", err)
+	}
+
+	t := r.URL.Query().Get("teamId")
+	if t == "" {
+		return id, nil, nil
+	}
+
+	teamID, err := uuid.Parse(t)
+	if err != nil {
+		return uuid.Nil, nil, fmt.Errorf("parsing teamId: %w", err)
+	}
+
+	return id, &teamID, nil
+}
+
 func NewProductAreasHandler(service service.ProductAreaService) *ProductAreasHandler {
 	return &ProductAreasHandler{service: service}
 }
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/auth"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+type ProfilingHandler struct {
+	service service.ProfilingService
+}
+
+func (h *ProfilingHandler) GetDatasetProfiles(ctx context.Context, r *http.Request, _ any) (*service.DatasetProfiles, error) {
+	const op errs.Op = "ProfilingHandler.GetDatasetProfiles"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	limit := 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit < 1 {
+			return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("limit"), fmt.Errorf("limit must be a positive number"))
+		}
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	profiles, err := h.service.GetDatasetProfiles(ctx, user, id, limit)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return profiles, nil
+}
+
+func NewProfilingHandler(s service.ProfilingService) *ProfilingHandler {
+	return &ProfilingHandler{service: s}
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/auth"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+)
+
+type QualityCheckHandler struct {
+	service service.QualityCheckService
+}
+
+func (h *QualityCheckHandler) GetQualityChecks(ctx context.Context, _ *http.Request, _ any) (*service.QualityChecks, error) {
+	const op errs.Op = "QualityCheckHandler.GetQualityChecks"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	checks, err := h.service.GetQualityChecks(ctx, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return checks, nil
+}
+
+func (h *QualityCheckHandler) CreateQualityCheck(ctx context.Context, _ *http.Request, in service.NewQualityCheck) (*service.QualityCheck, error) {
+	const op errs.Op = "QualityCheckHandler.CreateQualityCheck"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	check, err := h.service.CreateQualityCheck(ctx, user, id, in)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return check, nil
+}
+
+func (h *QualityCheckHandler) DeleteQualityCheck(ctx context.Context, _ *http.Request, _ any) (*transport.Empty, error) {
+	const op errs.Op = "QualityCheckHandler.DeleteQualityCheck"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	err = h.service.DeleteQualityCheck(ctx, user, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return &transport.Empty{}, nil
+}
+
+func (h *QualityCheckHandler) RunQualityCheck(ctx context.Context, _ *http.Request, _ any) (*service.QualityCheckRun, error) {
+	const op errs.Op = "QualityCheckHandler.RunQualityCheck"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	run, err := h.service.RunQualityCheck(ctx, user, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return run, nil
+}
+
+func (h *QualityCheckHandler) GetQualityCheckRuns(ctx context.Context, r *http.Request, _ any) (*service.QualityCheckRuns, error) {
+	const op errs.Op = "QualityCheckHandler.GetQualityCheckRuns"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	limit := 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit < 1 {
+			return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("limit"), fmt.Errorf("limit must be a positive number"))
+		}
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	runs, err := h.service.GetQualityCheckRuns(ctx, user, id, limit)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return runs, nil
+}
+
+func NewQualityCheckHandler(s service.QualityCheckService) *QualityCheckHandler {
+	return &QualityCheckHandler{service: s}
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/auth"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+)
+
+type RecertificationHandler struct {
+	service service.RecertificationService
+}
+
+func (h *RecertificationHandler) GetCampaigns(ctx context.Context, _ *http.Request, _ any) (*service.RecertificationCampaigns, error) {
+	const op errs.Op = "RecertificationHandler.GetCampaigns"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	campaigns, err := h.service.GetCampaigns(ctx, user)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return campaigns, nil
+}
+
+func (h *RecertificationHandler) CreateCampaign(ctx context.Context, _ *http.Request, in service.NewRecertificationCampaign) (*service.RecertificationCampaign, error) {
+	const op errs.Op = "RecertificationHandler.CreateCampaign"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	campaign, err := h.service.CreateCampaign(ctx, user, in)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return campaign, nil
+}
+
+func (h *RecertificationHandler) GetCampaign(ctx context.Context, _ *http.Request, _ any) (*service.RecertificationCampaignWithReviews, error) {
+	const op errs.Op = "RecertificationHandler.GetCampaign"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	campaign, err := h.service.GetCampaign(ctx, user, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return campaign, nil
+}
+
+func (h *RecertificationHandler) ExportCampaign(ctx context.Context, r *http.Request, _ any) (*transport.ByteWriter, error) {
+	const op errs.Op = "RecertificationHandler.ExportCampaign"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	format := service.RecertificationExportFormatCSV
+	if f := r.URL.Query().Get("format"); f != "" {
+		format = service.RecertificationExportFormat(f)
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	export, err := h.service.ExportCampaign(ctx, user, id, format)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return transport.NewByteWriter(export.ContentType, "", export.Data), nil
+}
+
+func (h *RecertificationHandler) ReviewAccess(ctx context.Context, _ *http.Request, in service.RecertificationReviewInput) (*service.RecertificationReview, error) {
+	const op errs.Op = "RecertificationHandler.ReviewAccess"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	review, err := h.service.ReviewAccess(ctx, user, id, in)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return review, nil
+}
+
+func NewRecertificationHandler(service service.RecertificationService) *RecertificationHandler {
+	return &RecertificationHandler{
+		service: service,
+	}
+}
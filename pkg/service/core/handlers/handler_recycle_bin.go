@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/auth"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+type RecycleBinHandler struct {
+	service service.RecycleBinService
+}
+
+func (h *RecycleBinHandler) GetDeletedItems(ctx context.Context, r *http.Request, _ any) ([]*service.DeletedItem, error) {
+	const op errs.Op = "RecycleBinHandler.GetDeletedItems"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	items, err := h.service.GetDeletedItems(ctx, user, r.URL.Query().Get("group"))
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return items, nil
+}
+
+func (h *RecycleBinHandler) RestoreItem(ctx context.Context, _ *http.Request, _ any) (*service.DeletedItem, error) {
+	const op errs.Op = "RecycleBinHandler.RestoreItem"
+
+	id, err := uuid.Parse(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("parsing id: %w", err))
+	}
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	item, err := h.service.RestoreItem(ctx, user, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return item, nil
+}
+
+func NewRecycleBinHandler(service service.RecycleBinService) *RecycleBinHandler {
+	return &RecycleBinHandler{service: service}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -33,6 +34,32 @@ func (h *SearchHandler) Search(ctx context.Context, r *http.Request, _ any) (*se
 	return result, nil
 }
 
+const (
+	defaultSuggestLimit = 10
+	maxSuggestLimit     = 50
+)
+
+func (h *SearchHandler) Suggest(ctx context.Context, r *http.Request, _ any) (*service.SearchSuggestions, error) {
+	const op errs.Op = "SearchHandler.Suggest"
+
+	limit := defaultSuggestLimit
+	if l := r.URL.Query().Get("limit"); l != "" {
+		var err error
+
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit < 1 || limit > maxSuggestLimit {
+			return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("limit"), fmt.Errorf("limit must be between 1 and %d", maxSuggestLimit))
+		}
+	}
+
+	suggestions, err := h.service.Suggest(ctx, r.URL.Query().Get("text"), limit)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return suggestions, nil
+}
+
 func parseSearchOptionsFromRequest(r *http.Request) (*service.SearchOptions, error) {
 	const op errs.Op = "parseSearchOptionsFromRequest"
 
@@ -68,11 +95,34 @@ func parseSearchOptionsFromRequest(r *http.Request) (*service.SearchOptions, err
 		}
 	}
 
+	// Parse 'productAreaIDs' parameter
+	if productAreaIDs, ok := query["productAreaIDs"]; ok && len(productAreaIDs) > 0 {
+		ids := strings.Split(productAreaIDs[0], ",")
+		for _, id := range ids {
+			productAreaID, err := uuid.Parse(id)
+			if err != nil {
+				return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("productAreaIDs"), err)
+			}
+
+			options.ProductAreaIDs = append(options.ProductAreaIDs, productAreaID)
+		}
+	}
+
+	// Parse 'piiLevels' parameter
+	if piiLevels, ok := query["piiLevels"]; ok && len(piiLevels) > 0 {
+		options.PiiLevels = strings.Split(piiLevels[0], ",")
+	}
+
 	// Parse 'services' parameter
 	if services, ok := query["services"]; ok && len(services) > 0 {
 		options.Services = strings.Split(services[0], ",")
 	}
 
+	// Parse 'qualityStatuses' parameter
+	if qualityStatuses, ok := query["qualityStatuses"]; ok && len(qualityStatuses) > 0 {
+		options.QualityStatuses = strings.Split(qualityStatuses[0], ",")
+	}
+
 	// Parse 'types' parameter
 	if types, ok := query["types"]; ok && len(types) > 0 {
 		options.Types = strings.Split(types[0], ",")
@@ -2,16 +2,23 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 
+	"github.com/google/uuid"
 	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+	"github.com/slack-go/slack"
 
 	"github.com/navikt/nada-backend/pkg/service"
 )
 
 type SlackHandler struct {
-	service service.SlackService
+	service       service.SlackService
+	signingSecret string
 }
 
 type isValidSlackChannelResult struct {
@@ -36,8 +43,116 @@ func (h *SlackHandler) IsValidSlackChannel(_ context.Context, r *http.Request, _
 	}, nil
 }
 
-func NewSlackHandler(service service.SlackService) *SlackHandler {
+// HandleInteraction receives the button clicks and modal submissions on
+// access request approval messages, Slack expects an empty 200 response
+func (h *SlackHandler) HandleInteraction(ctx context.Context, r *http.Request, _ any) (*transport.ByteWriter, error) {
+	const op errs.Op = "SlackHandler.HandleInteraction"
+
+	body, err := h.verifiedBody(r)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	interaction, err := parseSlackInteraction(body)
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	err = h.service.HandleInteraction(ctx, interaction)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return transport.NewByteWriter("text/plain", "", nil), nil
+}
+
+// verifiedBody reads the request body and verifies that it is signed by Slack
+// with the signing secret
+func (h *SlackHandler) verifiedBody(r *http.Request) ([]byte, error) {
+	const op errs.Op = "SlackHandler.verifiedBody"
+
+	if h.signingSecret == "" {
+		return nil, errs.E(errs.Unauthenticated, op, fmt.Errorf("slack signing secret is not configured"))
+	}
+
+	verifier, err := slack.NewSecretsVerifier(r.Header, h.signingSecret)
+	if err != nil {
+		return nil, errs.E(errs.Unauthenticated, op, err)
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, errs.E(errs.IO, op, err)
+	}
+
+	_, err = verifier.Write(body)
+	if err != nil {
+		return nil, errs.E(errs.Internal, op, err)
+	}
+
+	err = verifier.Ensure()
+	if err != nil {
+		return nil, errs.E(errs.Unauthenticated, op, err)
+	}
+
+	return body, nil
+}
+
+func parseSlackInteraction(body []byte) (service.SlackInteraction, error) {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return service.SlackInteraction{}, fmt.Errorf("parsing slack interaction form: %w", err)
+	}
+
+	callback := slack.InteractionCallback{}
+	err = json.Unmarshal([]byte(form.Get("payload")), &callback)
+	if err != nil {
+		return service.SlackInteraction{}, fmt.Errorf("parsing slack interaction payload: %w", err)
+	}
+
+	interaction := service.SlackInteraction{
+		UserID:    callback.User.ID,
+		TriggerID: callback.TriggerID,
+	}
+
+	switch callback.Type {
+	case slack.InteractionTypeBlockActions:
+		if len(callback.ActionCallback.BlockActions) != 1 {
+			return service.SlackInteraction{}, fmt.Errorf("expected one slack block action, got %d", len(callback.ActionCallback.BlockActions))
+		}
+
+		action := callback.ActionCallback.BlockActions[0]
+
+		interaction.Type = service.SlackInteractionType(action.ActionID)
+		interaction.Channel = callback.Container.ChannelID
+		interaction.MessageTimestamp = callback.Container.MessageTs
+
+		interaction.AccessRequestID, err = uuid.Parse(action.Value)
+		if err != nil {
+			return service.SlackInteraction{}, fmt.Errorf("parsing access request id of slack block action: %w", err)
+		}
+	case slack.InteractionTypeViewSubmission:
+		interaction.Type = service.SlackInteractionType(callback.View.CallbackID)
+
+		err = interaction.SetMetadata(callback.View.PrivateMetadata)
+		if err != nil {
+			return service.SlackInteraction{}, err
+		}
+
+		if callback.View.State != nil {
+			reason := callback.View.State.Values[service.SlackDenyReasonInputID][service.SlackDenyReasonInputID].Value
+			interaction.Reason = &reason
+		}
+	default:
+		return service.SlackInteraction{}, fmt.Errorf("unsupported slack interaction type %s", callback.Type)
+	}
+
+	return interaction, nil
+}
+
+func NewSlackHandler(service service.SlackService, signingSecret string) *SlackHandler {
 	return &SlackHandler{
-		service: service,
+		service:       service,
+		signingSecret: signingSecret,
 	}
 }
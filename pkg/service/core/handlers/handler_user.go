@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/go-chi/chi"
+
 	"github.com/navikt/nada-backend/pkg/errs"
 
 	"github.com/navikt/nada-backend/pkg/auth"
@@ -26,6 +28,104 @@ func (h *UserHandler) GetUserData(ctx context.Context, _ *http.Request, _ any) (
 	return h.service.GetUserData(ctx, user)
 }
 
+func (h *UserHandler) GetDataproducts(ctx context.Context, _ *http.Request, page service.PageRequest) (*service.Page[*service.Dataproduct], error) {
+	const op errs.Op = "UserHandler.GetDataproducts"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	dataproducts, err := h.service.GetDataproducts(ctx, user, page)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return dataproducts, nil
+}
+
+func (h *UserHandler) GetAccessibleDatasets(ctx context.Context, _ *http.Request, page service.PageRequest) (*service.Page[*service.AccessibleDataset], error) {
+	const op errs.Op = "UserHandler.GetAccessibleDatasets"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	kind := service.AccessibleDatasetsKind(chi.URLParamFromCtx(ctx, "kind"))
+
+	datasets, err := h.service.GetAccessibleDatasets(ctx, user, kind, page)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return datasets, nil
+}
+
+func (h *UserHandler) GetStories(ctx context.Context, _ *http.Request, page service.PageRequest) (*service.Page[*service.Story], error) {
+	const op errs.Op = "UserHandler.GetStories"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	stories, err := h.service.GetStories(ctx, user, page)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return stories, nil
+}
+
+func (h *UserHandler) GetInsightProducts(ctx context.Context, _ *http.Request, page service.PageRequest) (*service.Page[*service.InsightProduct], error) {
+	const op errs.Op = "UserHandler.GetInsightProducts"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	insightProducts, err := h.service.GetInsightProducts(ctx, user, page)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return insightProducts, nil
+}
+
+func (h *UserHandler) GetAccessRequests(ctx context.Context, _ *http.Request, page service.PageRequest) (*service.Page[*service.AccessRequest], error) {
+	const op errs.Op = "UserHandler.GetAccessRequests"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	accessRequests, err := h.service.GetAccessRequests(ctx, user, page)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return accessRequests, nil
+}
+
+func (h *UserHandler) GetAccessRequestsAsGranter(ctx context.Context, _ *http.Request, page service.PageRequest) (*service.Page[*service.AccessRequestForGranter], error) {
+	const op errs.Op = "UserHandler.GetAccessRequestsAsGranter"
+
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.E(errs.Unauthenticated, op, errs.Str("no user in context"))
+	}
+
+	accessRequests, err := h.service.GetAccessRequestsAsGranter(ctx, user, page)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return accessRequests, nil
+}
+
 func NewUserHandler(service service.UserService) *UserHandler {
 	return &UserHandler{service: service}
 }
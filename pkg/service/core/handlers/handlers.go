@@ -8,21 +8,35 @@ import (
 )
 
 type Handlers struct {
-	StoryHandler          *StoryHandler
-	TokenHandler          *TokenHandler
-	DataProductsHandler   *DataProductsHandler
-	MetabaseHandler       *MetabaseHandler
-	AccessHandler         *AccessHandler
-	ProductAreasHandler   *ProductAreasHandler
-	BigQueryHandler       *BigQueryHandler
-	SearchHandler         *SearchHandler
-	UserHandler           *UserHandler
-	SlackHandler          *SlackHandler
-	JoinableViewsHandler  *JoinableViewsHandler
-	InsightProductHandler *InsightProductHandler
-	TeamKatalogenHandler  *TeamkatalogenHandler
-	PollyHandler          *PollyHandler
-	KeywordsHandler       *KeywordsHandler
+	StoryHandler               *StoryHandler
+	TokenHandler               *TokenHandler
+	DataProductsHandler        *DataProductsHandler
+	DataproductTransferHandler *DataproductTransferHandler
+	DatasetPreviewHandler      *DatasetPreviewHandler
+	MetabaseHandler            *MetabaseHandler
+	AccessHandler              *AccessHandler
+	AccessApprovalRulesHandler *AccessApprovalRulesHandler
+	ProductAreasHandler        *ProductAreasHandler
+	BigQueryHandler            *BigQueryHandler
+	SearchHandler              *SearchHandler
+	UserHandler                *UserHandler
+	SlackHandler               *SlackHandler
+	JoinableViewsHandler       *JoinableViewsHandler
+	InsightProductHandler      *InsightProductHandler
+	TeamKatalogenHandler       *TeamkatalogenHandler
+	PollyHandler               *PollyHandler
+	ProfilingHandler           *ProfilingHandler
+	QualityCheckHandler        *QualityCheckHandler
+	KeywordsHandler            *KeywordsHandler
+	LifecycleHandler           *LifecycleHandler
+	NotificationsHandler       *NotificationsHandler
+	PoliciesHandler            *PoliciesHandler
+	RecertificationHandler     *RecertificationHandler
+	RecycleBinHandler          *RecycleBinHandler
+	CatalogueApplyHandler      *CatalogueApplyHandler
+	CatalogueExportHandler     *CatalogueExportHandler
+	ComplianceHandler          *ComplianceHandler
+	DbtHandler                 *DbtHandler
 }
 
 func NewHandlers(
@@ -32,20 +46,34 @@ func NewHandlers(
 	log zerolog.Logger,
 ) *Handlers {
 	return &Handlers{
-		StoryHandler:          NewStoryHandler(cfg.EmailSuffix, s.StoryService, s.TokenService, log),
-		TokenHandler:          NewTokenHandler(s.TokenService, cfg.API.AuthToken, log),
-		DataProductsHandler:   NewDataProductsHandler(s.DataProductService),
-		MetabaseHandler:       NewMetabaseHandler(s.MetaBaseService, mappingQueue),
-		AccessHandler:         NewAccessHandler(s.AccessService, s.MetaBaseService, cfg.Metabase.GCPProject),
-		ProductAreasHandler:   NewProductAreasHandler(s.ProductAreaService),
-		BigQueryHandler:       NewBigQueryHandler(s.BigQueryService),
-		SearchHandler:         NewSearchHandler(s.SearchService),
-		UserHandler:           NewUserHandler(s.UserService),
-		SlackHandler:          NewSlackHandler(s.SlackService),
-		JoinableViewsHandler:  NewJoinableViewsHandler(s.JoinableViewService),
-		InsightProductHandler: NewInsightProductHandler(s.InsightProductService),
-		TeamKatalogenHandler:  NewTeamKatalogenHandler(s.TeamKatalogenService),
-		PollyHandler:          NewPollyHandler(s.PollyService),
-		KeywordsHandler:       NewKeywordsHandler(s.KeyWordService),
+		StoryHandler:               NewStoryHandler(cfg.EmailSuffix, s.StoryService, s.TokenService, log),
+		TokenHandler:               NewTokenHandler(s.TokenService, cfg.API.AuthToken, log),
+		DataProductsHandler:        NewDataProductsHandler(s.DataProductService),
+		DataproductTransferHandler: NewDataproductTransferHandler(s.DataproductTransferService),
+		DatasetPreviewHandler:      NewDatasetPreviewHandler(s.DatasetPreviewService),
+		MetabaseHandler:            NewMetabaseHandler(s.MetaBaseService, mappingQueue),
+		AccessHandler:              NewAccessHandler(s.AccessService, s.MetaBaseService, cfg.Metabase.GCPProject),
+		AccessApprovalRulesHandler: NewAccessApprovalRulesHandler(s.AccessApprovalRuleService),
+		ProductAreasHandler:        NewProductAreasHandler(s.ProductAreaService),
+		BigQueryHandler:            NewBigQueryHandler(s.BigQueryService),
+		SearchHandler:              NewSearchHandler(s.SearchService),
+		UserHandler:                NewUserHandler(s.UserService),
+		SlackHandler:               NewSlackHandler(s.SlackService, cfg.Slack.SigningSecret),
+		JoinableViewsHandler:       NewJoinableViewsHandler(s.JoinableViewService),
+		InsightProductHandler:      NewInsightProductHandler(s.InsightProductService),
+		TeamKatalogenHandler:       NewTeamKatalogenHandler(s.TeamKatalogenService),
+		PollyHandler:               NewPollyHandler(s.PollyService),
+		ProfilingHandler:           NewProfilingHandler(s.ProfilingService),
+		QualityCheckHandler:        NewQualityCheckHandler(s.QualityCheckService),
+		KeywordsHandler:            NewKeywordsHandler(s.KeyWordService),
+		LifecycleHandler:           NewLifecycleHandler(s.LifecycleService),
+		NotificationsHandler:       NewNotificationsHandler(s.NotificationService),
+		PoliciesHandler:            NewPoliciesHandler(s.PolicyService),
+		RecertificationHandler:     NewRecertificationHandler(s.RecertificationService),
+		RecycleBinHandler:          NewRecycleBinHandler(s.RecycleBinService),
+		CatalogueApplyHandler:      NewCatalogueApplyHandler(s.CatalogueApplyService),
+		CatalogueExportHandler:     NewCatalogueExportHandler(s.CatalogueExportService),
+		ComplianceHandler:          NewComplianceHandler(s.ComplianceService),
+		DbtHandler:                 NewDbtHandler(s.DbtService),
 	}
 }
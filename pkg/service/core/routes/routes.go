@@ -8,6 +8,21 @@ import (
 	"github.com/docker/cli/cli/command/formatter/tabwriter"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/cors"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+)
+
+var (
+	// pageByName is for lists of things with a name, like dataproducts and stories
+	pageByName = transport.PageOptions{
+		Sort: []string{service.SortByName, service.SortByCreated},
+	}
+
+	// pageByNewest is for lists where the most recent items are the most relevant
+	pageByNewest = transport.PageOptions{
+		Sort:  []string{service.SortByCreated},
+		Order: service.SortOrderDesc,
+	}
 )
 
 type AddRoutesFn func(router chi.Router)
@@ -17,17 +17,21 @@ type AccessEndpoints struct {
 	UpdateAccessRequest   http.HandlerFunc
 	GrantAccessToDataset  http.HandlerFunc
 	RevokeAccessToDataset http.HandlerFunc
+	RenewAccess           http.HandlerFunc
+	GetExpiringAccess     http.HandlerFunc
 }
 
 func NewAccessEndpoints(log zerolog.Logger, h *handlers.AccessHandler) *AccessEndpoints {
 	return &AccessEndpoints{
-		GetAccessRequests:     transport.For(h.GetAccessRequests).Build(log),
+		GetAccessRequests:     transport.ForPage(h.GetAccessRequests, pageByNewest).Build(log),
 		ProcessAccessRequest:  transport.For(h.ProcessAccessRequest).Build(log),
 		CreateAccessRequest:   transport.For(h.NewAccessRequest).RequestFromJSON().Build(log),
 		DeleteAccessRequest:   transport.For(h.DeleteAccessRequest).Build(log),
 		UpdateAccessRequest:   transport.For(h.UpdateAccessRequest).RequestFromJSON().Build(log),
 		GrantAccessToDataset:  transport.For(h.GrantAccessToDataset).RequestFromJSON().Build(log),
 		RevokeAccessToDataset: transport.For(h.RevokeAccessToDataset).Build(log),
+		RenewAccess:           transport.For(h.RenewAccess).RequestFromJSON().Build(log),
+		GetExpiringAccess:     transport.For(h.GetExpiringAccess).Build(log),
 	}
 }
 
@@ -47,6 +51,8 @@ func NewAccessRoutes(endpoints *AccessEndpoints, auth func(http.Handler) http.Ha
 			r.Use(auth)
 			r.Post("/grant", endpoints.GrantAccessToDataset)
 			r.Post("/revoke", endpoints.RevokeAccessToDataset)
+			r.Get("/expiring", endpoints.GetExpiringAccess)
+			r.Post("/{id}/renew", endpoints.RenewAccess)
 		})
 	}
 }
@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+	"github.com/rs/zerolog"
+)
+
+type AccessApprovalRulesEndpoints struct {
+	GetAccessApprovalRules   http.HandlerFunc
+	CreateAccessApprovalRule http.HandlerFunc
+	DeleteAccessApprovalRule http.HandlerFunc
+}
+
+func NewAccessApprovalRulesEndpoints(log zerolog.Logger, h *handlers.AccessApprovalRulesHandler) *AccessApprovalRulesEndpoints {
+	return &AccessApprovalRulesEndpoints{
+		GetAccessApprovalRules:   transport.For(h.GetAccessApprovalRules).Build(log),
+		CreateAccessApprovalRule: transport.For(h.CreateAccessApprovalRule).RequestFromJSON().Build(log),
+		DeleteAccessApprovalRule: transport.For(h.DeleteAccessApprovalRule).Build(log),
+	}
+}
+
+func NewAccessApprovalRulesRoutes(endpoints *AccessApprovalRulesEndpoints, auth func(http.Handler) http.Handler) AddRoutesFn {
+	return func(router chi.Router) {
+		router.Route("/api/accessApprovalRules", func(r chi.Router) {
+			r.Use(auth)
+			r.Get("/", endpoints.GetAccessApprovalRules)
+			r.Post("/new", endpoints.CreateAccessApprovalRule)
+			r.Delete("/{id}", endpoints.DeleteAccessApprovalRule)
+		})
+	}
+}
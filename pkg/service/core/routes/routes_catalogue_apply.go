@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+	"github.com/rs/zerolog"
+)
+
+type CatalogueApplyEndpoints struct {
+	Apply http.HandlerFunc
+}
+
+func NewCatalogueApplyEndpoints(log zerolog.Logger, h *handlers.CatalogueApplyHandler) *CatalogueApplyEndpoints {
+	return &CatalogueApplyEndpoints{
+		Apply: transport.For(h.Apply).Build(log),
+	}
+}
+
+func NewCatalogueApplyRoutes(endpoints *CatalogueApplyEndpoints, nadaToken func(http.Handler) http.Handler) AddRoutesFn {
+	return func(router chi.Router) {
+		router.Route("/api/catalogue", func(r chi.Router) {
+			// Used programmatically, e.g., from a CI pipeline applying the spec
+			// kept in git, which relies on the Nada team token
+			r.Use(nadaToken)
+			r.Post("/apply", endpoints.Apply)
+		})
+	}
+}
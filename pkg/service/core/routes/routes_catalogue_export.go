@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+	"github.com/rs/zerolog"
+)
+
+type CatalogueExportEndpoints struct {
+	ExportDCAT http.HandlerFunc
+}
+
+func NewCatalogueExportEndpoints(log zerolog.Logger, h *handlers.CatalogueExportHandler) *CatalogueExportEndpoints {
+	return &CatalogueExportEndpoints{
+		ExportDCAT: transport.For(h.ExportDCAT).Build(log),
+	}
+}
+
+func NewCatalogueExportRoutes(endpoints *CatalogueExportEndpoints) AddRoutesFn {
+	return func(router chi.Router) {
+		router.Route("/api/dcat", func(r chi.Router) {
+			r.Get("/catalog", endpoints.ExportDCAT)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+	"github.com/rs/zerolog"
+)
+
+type ComplianceEndpoints struct {
+	GetReport    http.HandlerFunc
+	ExportReport http.HandlerFunc
+}
+
+func NewComplianceEndpoints(log zerolog.Logger, h *handlers.ComplianceHandler) *ComplianceEndpoints {
+	return &ComplianceEndpoints{
+		GetReport:    transport.For(h.GetReport).Build(log),
+		ExportReport: transport.For(h.ExportReport).Build(log),
+	}
+}
+
+func NewComplianceRoutes(endpoints *ComplianceEndpoints, auth func(http.Handler) http.Handler) AddRoutesFn {
+	return func(router chi.Router) {
+		router.Route("/api/compliance", func(r chi.Router) {
+			r.Use(auth)
+			r.Get("/personal-data", endpoints.GetReport)
+			r.Get("/personal-data/export", endpoints.ExportReport)
+		})
+	}
+}
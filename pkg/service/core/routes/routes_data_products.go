@@ -28,7 +28,7 @@ func NewDataProductsEndpoints(log zerolog.Logger, h *handlers.DataProductsHandle
 		CreateDataProduct:                  transport.For(h.CreateDataProduct).RequestFromJSON().Build(log),
 		DeleteDataProduct:                  transport.For(h.DeleteDataProduct).Build(log),
 		UpdateDataProduct:                  transport.For(h.UpdateDataProduct).RequestFromJSON().Build(log),
-		GetDatasetsMinimal:                 transport.For(h.GetDatasetsMinimal).Build(log),
+		GetDatasetsMinimal:                 transport.ForPage(h.GetDatasetsMinimal, pageByName).Build(log),
 		GetDataset:                         transport.For(h.GetDataset).Build(log),
 		CreateDataset:                      transport.For(h.CreateDataset).RequestFromJSON().Build(log),
 		UpdateDataset:                      transport.For(h.UpdateDataset).RequestFromJSON().Build(log),
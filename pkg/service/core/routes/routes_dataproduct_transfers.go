@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+	"github.com/rs/zerolog"
+)
+
+type DataproductTransferEndpoints struct {
+	RequestDataproductTransfer            http.HandlerFunc
+	GetDataproductTransfersForUser        http.HandlerFunc
+	GetDataproductTransfersForDataproduct http.HandlerFunc
+	AcceptDataproductTransfer             http.HandlerFunc
+	RejectDataproductTransfer             http.HandlerFunc
+	CancelDataproductTransfer             http.HandlerFunc
+}
+
+func NewDataproductTransferEndpoints(log zerolog.Logger, h *handlers.DataproductTransferHandler) *DataproductTransferEndpoints {
+	return &DataproductTransferEndpoints{
+		RequestDataproductTransfer:            transport.For(h.RequestDataproductTransfer).RequestFromJSON().Build(log),
+		GetDataproductTransfersForUser:        transport.For(h.GetDataproductTransfersForUser).Build(log),
+		GetDataproductTransfersForDataproduct: transport.For(h.GetDataproductTransfersForDataproduct).Build(log),
+		AcceptDataproductTransfer:             transport.For(h.AcceptDataproductTransfer).Build(log),
+		RejectDataproductTransfer:             transport.For(h.RejectDataproductTransfer).RequestFromJSON().Build(log),
+		CancelDataproductTransfer:             transport.For(h.CancelDataproductTransfer).Build(log),
+	}
+}
+
+func NewDataproductTransferRoutes(endpoints *DataproductTransferEndpoints, auth func(http.Handler) http.Handler) AddRoutesFn {
+	return func(router chi.Router) {
+		router.Route("/api/dataproductTransfers", func(r chi.Router) {
+			r.Use(auth)
+			r.Post("/new", endpoints.RequestDataproductTransfer)
+			r.Get("/", endpoints.GetDataproductTransfersForUser)
+			r.Get("/dataproduct/{id}", endpoints.GetDataproductTransfersForDataproduct)
+			r.Post("/{id}/accept", endpoints.AcceptDataproductTransfer)
+			r.Post("/{id}/reject", endpoints.RejectDataproductTransfer)
+			r.Post("/{id}/cancel", endpoints.CancelDataproductTransfer)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+	"github.com/rs/zerolog"
+)
+
+type DatasetPreviewEndpoints struct {
+	GetDatasetPreview            http.HandlerFunc
+	UpdateDatasetPreviewSettings http.HandlerFunc
+}
+
+func NewDatasetPreviewEndpoints(log zerolog.Logger, h *handlers.DatasetPreviewHandler) *DatasetPreviewEndpoints {
+	return &DatasetPreviewEndpoints{
+		GetDatasetPreview:            transport.For(h.GetDatasetPreview).Build(log),
+		UpdateDatasetPreviewSettings: transport.For(h.UpdateDatasetPreviewSettings).RequestFromJSON().Build(log),
+	}
+}
+
+func NewDatasetPreviewRoutes(endpoints *DatasetPreviewEndpoints, auth func(http.Handler) http.Handler) AddRoutesFn {
+	return func(router chi.Router) {
+		// Might otherwise conflict with DatasetRoutes in routes_dataproducts.go
+		router.With(auth).Get("/api/datasets/{id}/preview", endpoints.GetDatasetPreview)
+		router.With(auth).Put("/api/datasets/{id}/preview/settings", endpoints.UpdateDatasetPreviewSettings)
+	}
+}
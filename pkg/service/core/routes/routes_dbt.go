@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+	"github.com/rs/zerolog"
+)
+
+type DbtEndpoints struct {
+	ImportManifest http.HandlerFunc
+}
+
+func NewDbtEndpoints(log zerolog.Logger, h *handlers.DbtHandler) *DbtEndpoints {
+	return &DbtEndpoints{
+		ImportManifest: transport.For(h.ImportManifest).RequestFromJSON().Build(log),
+	}
+}
+
+func NewDbtRoutes(endpoints *DbtEndpoints, nadaToken func(http.Handler) http.Handler) AddRoutesFn {
+	return func(router chi.Router) {
+		router.Route("/api/dbt", func(r chi.Router) {
+			// Used programmatically, e.g., from a CI pipeline after dbt has run,
+			// which relies on the Nada team token
+			r.Use(nadaToken)
+			r.Post("/import", endpoints.ImportManifest)
+		})
+	}
+}
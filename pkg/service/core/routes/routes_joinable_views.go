@@ -13,6 +13,9 @@ type JoinableViewsEndpoints struct {
 	CreateJoinableViews     http.HandlerFunc
 	GetJoinableViewsForUser http.HandlerFunc
 	GetJoinableView         http.HandlerFunc
+	DeleteJoinableView      http.HandlerFunc
+	ExtendJoinableView      http.HandlerFunc
+	ShareJoinableView       http.HandlerFunc
 }
 
 func NewJoinableViewsEndpoints(log zerolog.Logger, h *handlers.JoinableViewsHandler) *JoinableViewsEndpoints {
@@ -20,6 +23,9 @@ func NewJoinableViewsEndpoints(log zerolog.Logger, h *handlers.JoinableViewsHand
 		CreateJoinableViews:     transport.For(h.CreateJoinableViews).RequestFromJSON().Build(log),
 		GetJoinableViewsForUser: transport.For(h.GetJoinableViewsForUser).Build(log),
 		GetJoinableView:         transport.For(h.GetJoinableView).Build(log),
+		DeleteJoinableView:      transport.For(h.DeleteJoinableView).Build(log),
+		ExtendJoinableView:      transport.For(h.ExtendJoinableView).RequestFromJSON().Build(log),
+		ShareJoinableView:       transport.For(h.ShareJoinableView).RequestFromJSON().Build(log),
 	}
 }
 
@@ -30,6 +36,9 @@ func NewJoinableViewsRoutes(endpoints *JoinableViewsEndpoints, auth func(http.Ha
 			r.Post("/new", endpoints.CreateJoinableViews)
 			r.Get("/", endpoints.GetJoinableViewsForUser)
 			r.Get("/{id}", endpoints.GetJoinableView)
+			r.Delete("/{id}", endpoints.DeleteJoinableView)
+			r.Put("/{id}/expires", endpoints.ExtendJoinableView)
+			r.Post("/{id}/share", endpoints.ShareJoinableView)
 		})
 	}
 }
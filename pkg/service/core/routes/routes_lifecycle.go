@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+	"github.com/rs/zerolog"
+)
+
+type LifecycleEndpoints struct {
+	UpdateDataproductLifecycle http.HandlerFunc
+	UpdateDatasetLifecycle     http.HandlerFunc
+}
+
+func NewLifecycleEndpoints(log zerolog.Logger, h *handlers.LifecycleHandler) *LifecycleEndpoints {
+	return &LifecycleEndpoints{
+		UpdateDataproductLifecycle: transport.For(h.UpdateDataproductLifecycle).RequestFromJSON().Build(log),
+		UpdateDatasetLifecycle:     transport.For(h.UpdateDatasetLifecycle).RequestFromJSON().Build(log),
+	}
+}
+
+func NewLifecycleRoutes(endpoints *LifecycleEndpoints, auth func(http.Handler) http.Handler) AddRoutesFn {
+	return func(router chi.Router) {
+		router.Route("/api/lifecycle", func(r chi.Router) {
+			r.Use(auth)
+			r.Put("/dataproducts/{id}", endpoints.UpdateDataproductLifecycle)
+			r.Put("/datasets/{id}", endpoints.UpdateDatasetLifecycle)
+		})
+	}
+}
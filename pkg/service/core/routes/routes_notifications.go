@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+	"github.com/rs/zerolog"
+)
+
+type NotificationsEndpoints struct {
+	GetNotifications              http.HandlerFunc
+	MarkNotificationsRead         http.HandlerFunc
+	MarkAllNotificationsRead      http.HandlerFunc
+	GetNotificationPreferences    http.HandlerFunc
+	UpdateNotificationPreferences http.HandlerFunc
+}
+
+func NewNotificationsEndpoints(log zerolog.Logger, h *handlers.NotificationsHandler) *NotificationsEndpoints {
+	return &NotificationsEndpoints{
+		GetNotifications:              transport.ForPage(h.GetNotifications, pageByNewest).Build(log),
+		MarkNotificationsRead:         transport.For(h.MarkNotificationsRead).RequestFromJSON().Build(log),
+		MarkAllNotificationsRead:      transport.For(h.MarkAllNotificationsRead).Build(log),
+		GetNotificationPreferences:    transport.For(h.GetNotificationPreferences).Build(log),
+		UpdateNotificationPreferences: transport.For(h.UpdateNotificationPreferences).RequestFromJSON().Build(log),
+	}
+}
+
+func NewNotificationsRoutes(endpoints *NotificationsEndpoints, auth func(http.Handler) http.Handler) AddRoutesFn {
+	return func(router chi.Router) {
+		router.Route("/api/notifications", func(r chi.Router) {
+			r.Use(auth)
+			r.Get("/", endpoints.GetNotifications)
+			r.Post("/read", endpoints.MarkNotificationsRead)
+			r.Post("/readAll", endpoints.MarkAllNotificationsRead)
+			r.Get("/preferences", endpoints.GetNotificationPreferences)
+			r.Put("/preferences", endpoints.UpdateNotificationPreferences)
+		})
+	}
+}
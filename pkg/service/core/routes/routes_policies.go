@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+	"github.com/rs/zerolog"
+)
+
+type PoliciesEndpoints struct {
+	GetPolicies  http.HandlerFunc
+	CreatePolicy http.HandlerFunc
+	DeletePolicy http.HandlerFunc
+	DryRun       http.HandlerFunc
+}
+
+func NewPoliciesEndpoints(log zerolog.Logger, h *handlers.PoliciesHandler) *PoliciesEndpoints {
+	return &PoliciesEndpoints{
+		GetPolicies:  transport.For(h.GetPolicies).Build(log),
+		CreatePolicy: transport.For(h.CreatePolicy).RequestFromJSON().Build(log),
+		DeletePolicy: transport.For(h.DeletePolicy).Build(log),
+		DryRun:       transport.For(h.DryRun).RequestFromJSON().Build(log),
+	}
+}
+
+func NewPoliciesRoutes(endpoints *PoliciesEndpoints, auth func(http.Handler) http.Handler) AddRoutesFn {
+	return func(router chi.Router) {
+		router.Route("/api/policies", func(r chi.Router) {
+			r.Use(auth)
+			r.Get("/", endpoints.GetPolicies)
+			r.Post("/new", endpoints.CreatePolicy)
+			r.Post("/dryRun", endpoints.DryRun)
+			r.Delete("/{id}", endpoints.DeletePolicy)
+		})
+	}
+}
@@ -10,19 +10,25 @@ import (
 )
 
 type PollyEndpoints struct {
-	SearchPolly http.HandlerFunc
+	SearchPolly                 http.HandlerFunc
+	GetPurposeHistory           http.HandlerFunc
+	GetAccessWithInvalidPurpose http.HandlerFunc
 }
 
 func NewPollyEndpoints(log zerolog.Logger, h *handlers.PollyHandler) *PollyEndpoints {
 	return &PollyEndpoints{
-		SearchPolly: transport.For(h.SearchPolly).Build(log),
+		SearchPolly:                 transport.For(h.SearchPolly).Build(log),
+		GetPurposeHistory:           transport.For(h.GetPurposeHistory).Build(log),
+		GetAccessWithInvalidPurpose: transport.For(h.GetAccessWithInvalidPurpose).Build(log),
 	}
 }
 
-func NewPollyRoutes(endpoints *PollyEndpoints) AddRoutesFn {
+func NewPollyRoutes(endpoints *PollyEndpoints, auth func(http.Handler) http.Handler) AddRoutesFn {
 	return func(router chi.Router) {
 		router.Route("/api/polly", func(r chi.Router) {
 			r.Get("/", endpoints.SearchPolly)
+			r.With(auth).Get("/purposes/invalid", endpoints.GetAccessWithInvalidPurpose)
+			r.With(auth).Get("/purposes/{externalID}/history", endpoints.GetPurposeHistory)
 		})
 	}
 }
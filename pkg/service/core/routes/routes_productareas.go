@@ -12,12 +12,18 @@ import (
 type ProductAreaEndpoints struct {
 	GetProductAreas          http.HandlerFunc
 	GetProductAreaWithAssets http.HandlerFunc
+	GetDataproducts          http.HandlerFunc
+	GetStories               http.HandlerFunc
+	GetInsightProducts       http.HandlerFunc
 }
 
 func NewProductAreaEndpoints(log zerolog.Logger, h *handlers.ProductAreasHandler) *ProductAreaEndpoints {
 	return &ProductAreaEndpoints{
 		GetProductAreas:          transport.For(h.GetProductAreas).Build(log),
 		GetProductAreaWithAssets: transport.For(h.GetProductAreaWithAssets).Build(log),
+		GetDataproducts:          transport.ForPage(h.GetDataproducts, pageByName).Build(log),
+		GetStories:               transport.ForPage(h.GetStories, pageByName).Build(log),
+		GetInsightProducts:       transport.ForPage(h.GetInsightProducts, pageByName).Build(log),
 	}
 }
 
@@ -26,6 +32,9 @@ func NewProductAreaRoutes(endpoints *ProductAreaEndpoints) AddRoutesFn {
 		router.Route("/api/productareas", func(r chi.Router) {
 			r.Get("/", endpoints.GetProductAreas)
 			r.Get("/{id}", endpoints.GetProductAreaWithAssets)
+			r.Get("/{id}/dataproducts", endpoints.GetDataproducts)
+			r.Get("/{id}/stories", endpoints.GetStories)
+			r.Get("/{id}/insightProducts", endpoints.GetInsightProducts)
 		})
 	}
 }
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+	"github.com/rs/zerolog"
+)
+
+type ProfilingEndpoints struct {
+	GetDatasetProfiles http.HandlerFunc
+}
+
+func NewProfilingEndpoints(log zerolog.Logger, h *handlers.ProfilingHandler) *ProfilingEndpoints {
+	return &ProfilingEndpoints{
+		GetDatasetProfiles: transport.For(h.GetDatasetProfiles).Build(log),
+	}
+}
+
+func NewProfilingRoutes(endpoints *ProfilingEndpoints, auth func(http.Handler) http.Handler) AddRoutesFn {
+	return func(router chi.Router) {
+		// Might otherwise conflict with DatasetRoutes in routes_dataproducts.go
+		router.With(auth).Get("/api/datasets/{id}/profiles", endpoints.GetDatasetProfiles)
+	}
+}
@@ -0,0 +1,43 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+	"github.com/rs/zerolog"
+)
+
+type QualityCheckEndpoints struct {
+	GetQualityChecks    http.HandlerFunc
+	CreateQualityCheck  http.HandlerFunc
+	DeleteQualityCheck  http.HandlerFunc
+	RunQualityCheck     http.HandlerFunc
+	GetQualityCheckRuns http.HandlerFunc
+}
+
+func NewQualityCheckEndpoints(log zerolog.Logger, h *handlers.QualityCheckHandler) *QualityCheckEndpoints {
+	return &QualityCheckEndpoints{
+		GetQualityChecks:    transport.For(h.GetQualityChecks).Build(log),
+		CreateQualityCheck:  transport.For(h.CreateQualityCheck).RequestFromJSON().Build(log),
+		DeleteQualityCheck:  transport.For(h.DeleteQualityCheck).Build(log),
+		RunQualityCheck:     transport.For(h.RunQualityCheck).Build(log),
+		GetQualityCheckRuns: transport.For(h.GetQualityCheckRuns).Build(log),
+	}
+}
+
+func NewQualityCheckRoutes(endpoints *QualityCheckEndpoints, auth func(http.Handler) http.Handler) AddRoutesFn {
+	return func(router chi.Router) {
+		// Might otherwise conflict with DatasetRoutes in routes_dataproducts.go
+		router.With(auth).Get("/api/datasets/{id}/quality-checks", endpoints.GetQualityChecks)
+		router.With(auth).Post("/api/datasets/{id}/quality-checks", endpoints.CreateQualityCheck)
+
+		router.Route("/api/quality-checks", func(r chi.Router) {
+			r.Use(auth)
+			r.Delete("/{id}", endpoints.DeleteQualityCheck)
+			r.Post("/{id}/run", endpoints.RunQualityCheck)
+			r.Get("/{id}/runs", endpoints.GetQualityCheckRuns)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+	"github.com/rs/zerolog"
+)
+
+type RecertificationEndpoints struct {
+	GetCampaigns   http.HandlerFunc
+	CreateCampaign http.HandlerFunc
+	GetCampaign    http.HandlerFunc
+	ExportCampaign http.HandlerFunc
+	ReviewAccess   http.HandlerFunc
+}
+
+func NewRecertificationEndpoints(log zerolog.Logger, h *handlers.RecertificationHandler) *RecertificationEndpoints {
+	return &RecertificationEndpoints{
+		GetCampaigns:   transport.For(h.GetCampaigns).Build(log),
+		CreateCampaign: transport.For(h.CreateCampaign).RequestFromJSON().Build(log),
+		GetCampaign:    transport.For(h.GetCampaign).Build(log),
+		ExportCampaign: transport.For(h.ExportCampaign).Build(log),
+		ReviewAccess:   transport.For(h.ReviewAccess).RequestFromJSON().Build(log),
+	}
+}
+
+func NewRecertificationRoutes(endpoints *RecertificationEndpoints, auth func(http.Handler) http.Handler) AddRoutesFn {
+	return func(router chi.Router) {
+		router.Route("/api/recertifications", func(r chi.Router) {
+			r.Use(auth)
+			r.Get("/", endpoints.GetCampaigns)
+			r.Post("/new", endpoints.CreateCampaign)
+			r.Get("/{id}", endpoints.GetCampaign)
+			r.Get("/{id}/export", endpoints.ExportCampaign)
+			r.Post("/reviews/{id}", endpoints.ReviewAccess)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/transport"
+	"github.com/rs/zerolog"
+)
+
+type RecycleBinEndpoints struct {
+	GetDeletedItems http.HandlerFunc
+	RestoreItem     http.HandlerFunc
+}
+
+func NewRecycleBinEndpoints(log zerolog.Logger, h *handlers.RecycleBinHandler) *RecycleBinEndpoints {
+	return &RecycleBinEndpoints{
+		GetDeletedItems: transport.For(h.GetDeletedItems).Build(log),
+		RestoreItem:     transport.For(h.RestoreItem).Build(log),
+	}
+}
+
+func NewRecycleBinRoutes(endpoints *RecycleBinEndpoints, auth func(http.Handler) http.Handler) AddRoutesFn {
+	return func(router chi.Router) {
+		router.Route("/api/recycleBin", func(r chi.Router) {
+			r.Use(auth)
+			r.Get("/", endpoints.GetDeletedItems)
+			r.Post("/{id}/restore", endpoints.RestoreItem)
+		})
+	}
+}
@@ -10,12 +10,14 @@ import (
 )
 
 type SearchEndpoints struct {
-	Search http.HandlerFunc
+	Search  http.HandlerFunc
+	Suggest http.HandlerFunc
 }
 
 func NewSearchEndpoints(log zerolog.Logger, h *handlers.SearchHandler) *SearchEndpoints {
 	return &SearchEndpoints{
-		Search: transport.For(h.Search).Build(log),
+		Search:  transport.For(h.Search).Build(log),
+		Suggest: transport.For(h.Suggest).Build(log),
 	}
 }
 
@@ -23,6 +25,7 @@ func NewSearchRoutes(endpoints *SearchEndpoints) AddRoutesFn {
 	return func(router chi.Router) {
 		router.Route("/api/search", func(r chi.Router) {
 			r.Get("/", endpoints.Search)
+			r.Get("/suggest", endpoints.Suggest)
 		})
 	}
 }
@@ -11,11 +11,13 @@ import (
 
 type SlackEndpoints struct {
 	IsValidSlackChannel http.HandlerFunc
+	HandleInteraction   http.HandlerFunc
 }
 
 func NewSlackEndpoints(log zerolog.Logger, h *handlers.SlackHandler) *SlackEndpoints {
 	return &SlackEndpoints{
 		IsValidSlackChannel: transport.For(h.IsValidSlackChannel).Build(log),
+		HandleInteraction:   transport.For(h.HandleInteraction).Build(log),
 	}
 }
 
@@ -23,6 +25,7 @@ func NewSlackRoutes(endpoints *SlackEndpoints) AddRoutesFn {
 	return func(router chi.Router) {
 		router.Route("/api/slack", func(r chi.Router) {
 			r.Get("/isValid", endpoints.IsValidSlackChannel)
+			r.Post("/interactions", endpoints.HandleInteraction)
 		})
 	}
 }
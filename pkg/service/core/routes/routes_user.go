@@ -10,12 +10,24 @@ import (
 )
 
 type UserEndpoints struct {
-	GetUserData http.HandlerFunc
+	GetUserData                http.HandlerFunc
+	GetDataproducts            http.HandlerFunc
+	GetAccessibleDatasets      http.HandlerFunc
+	GetStories                 http.HandlerFunc
+	GetInsightProducts         http.HandlerFunc
+	GetAccessRequests          http.HandlerFunc
+	GetAccessRequestsAsGranter http.HandlerFunc
 }
 
 func NewUserEndpoints(log zerolog.Logger, h *handlers.UserHandler) *UserEndpoints {
 	return &UserEndpoints{
-		GetUserData: transport.For(h.GetUserData).Build(log),
+		GetUserData:                transport.For(h.GetUserData).Build(log),
+		GetDataproducts:            transport.ForPage(h.GetDataproducts, pageByName).Build(log),
+		GetAccessibleDatasets:      transport.ForPage(h.GetAccessibleDatasets, pageByName).Build(log),
+		GetStories:                 transport.ForPage(h.GetStories, pageByName).Build(log),
+		GetInsightProducts:         transport.ForPage(h.GetInsightProducts, pageByName).Build(log),
+		GetAccessRequests:          transport.ForPage(h.GetAccessRequests, pageByNewest).Build(log),
+		GetAccessRequestsAsGranter: transport.ForPage(h.GetAccessRequestsAsGranter, pageByNewest).Build(log),
 	}
 }
 
@@ -24,6 +36,12 @@ func NewUserRoutes(endpoints *UserEndpoints, auth func(http.Handler) http.Handle
 		router.Route("/api/userData", func(r chi.Router) {
 			r.Use(auth)
 			r.Get("/", endpoints.GetUserData)
+			r.Get("/dataproducts", endpoints.GetDataproducts)
+			r.Get("/accessibleDatasets/{kind}", endpoints.GetAccessibleDatasets)
+			r.Get("/stories", endpoints.GetStories)
+			r.Get("/insightProducts", endpoints.GetInsightProducts)
+			r.Get("/accessRequests", endpoints.GetAccessRequests)
+			r.Get("/accessRequestsAsGranter", endpoints.GetAccessRequestsAsGranter)
 		})
 	}
 }
@@ -3,7 +3,6 @@ package core
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"strings"
 	"time"
 
@@ -16,24 +15,28 @@ var _ service.AccessService = (*accessService)(nil)
 
 type accessService struct {
 	dataCatalogueURL    string
-	slackapi            service.SlackAPI
+	notificationService service.NotificationService
+	policyService       service.PolicyService
+	approvalRuleStorage service.AccessApprovalRuleStorage
 	pollyStorage        service.PollyStorage
 	accessStorage       service.AccessStorage
 	dataProductStorage  service.DataProductsStorage
 	bigQueryStorage     service.BigQueryStorage
 	joinableViewStorage service.JoinableViewsStorage
 	bigQueryAPI         service.BigQueryAPI
+	datasourceStorage   service.DatasourceStorage
+	providers           service.DatasourceProviders
 }
 
-func (s *accessService) GetAccessRequests(ctx context.Context, datasetID uuid.UUID) (*service.AccessRequestsWrapper, error) {
+func (s *accessService) GetAccessRequests(ctx context.Context, datasetID uuid.UUID, page service.PageRequest) (*service.Page[*service.AccessRequest], error) {
 	const op errs.Op = "accessService.GetAccessRequests"
 
-	requests, err := s.accessStorage.ListAccessRequestsForDataset(ctx, datasetID)
+	requests, err := s.accessStorage.ListAccessRequestsForDataset(ctx, datasetID, page)
 	if err != nil {
 		return nil, errs.E(op, err)
 	}
 
-	for _, r := range requests {
+	for _, r := range requests.Items {
 		if r.Polly != nil {
 			polly, err := s.pollyStorage.GetPollyDocumentation(ctx, r.Polly.ID)
 			if err != nil {
@@ -44,9 +47,7 @@ func (s *accessService) GetAccessRequests(ctx context.Context, datasetID uuid.UU
 		}
 	}
 
-	return &service.AccessRequestsWrapper{
-		AccessRequests: requests,
-	}, nil
+	return requests, nil
 }
 
 func (s *accessService) CreateAccessRequest(ctx context.Context, user *service.User, input service.NewAccessRequestDTO) error {
@@ -73,6 +74,40 @@ func (s *accessService) CreateAccessRequest(ctx context.Context, user *service.U
 		}
 	}
 
+	ds, err := s.dataProductStorage.GetDataset(ctx, input.DatasetID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	if !ds.Lifecycle.Active() {
+		return errs.E(errs.InvalidRequest, op, fmt.Errorf("dataset %v is %s and does not accept new access requests", ds.ID, ds.Lifecycle.Status))
+	}
+
+	var purpose *string
+	var purposeStatus *service.PollyPurposeStatus
+	if input.Polly != nil {
+		purpose = &input.Polly.Name
+
+		purposeStatus, err = s.purposeStatus(ctx, input.Polly.ExternalID)
+		if err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	err = s.policyService.Enforce(ctx, service.PolicyInput{
+		Action:        service.PolicyActionRequestAccess,
+		Subject:       subj,
+		SubjectType:   subjType,
+		SubjectGroups: subjectGroups(user, subj, subjType),
+		DatasetID:     ds.ID,
+		Purpose:       purpose,
+		PurposeStatus: purposeStatus,
+		Expires:       input.Expires,
+	})
+	if err != nil {
+		return errs.E(op, err)
+	}
+
 	var pollyID uuid.NullUUID
 	if input.Polly != nil {
 		dbPolly, err := s.pollyStorage.CreatePollyDocumentation(ctx, *input.Polly)
@@ -88,23 +123,60 @@ func (s *accessService) CreateAccessRequest(ctx context.Context, user *service.U
 		return errs.E(op, err)
 	}
 
-	ds, err := s.dataProductStorage.GetDataset(ctx, input.DatasetID)
+	err = s.submitAccessRequest(ctx, user, accessRequest, ds)
 	if err != nil {
 		return errs.E(op, err)
 	}
 
+	return nil
+}
+
+// submitAccessRequest approves a new access request if an automatic approval
+// rule matches it, and otherwise asks the owners of the dataset to process it
+func (s *accessService) submitAccessRequest(ctx context.Context, requester *service.User, accessRequest *service.AccessRequest, ds *service.Dataset) error {
+	const op errs.Op = "accessService.submitAccessRequest"
+
 	dp, err := s.dataProductStorage.GetDataproduct(ctx, ds.DataproductID)
 	if err != nil {
 		return errs.E(op, err)
 	}
 
-	slackMessage := createAccessRequestSlackNotification(dp, ds, s.dataCatalogueURL, accessRequest.Owner)
+	rule, err := s.matchingApprovalRule(ctx, requester, accessRequest, ds)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	if rule != nil {
+		err = s.approveAccessRequest(ctx, &service.User{Email: rule.CreatedBy}, accessRequest, ds, dp)
+		if err != nil {
+			return errs.E(op, err)
+		}
+
+		err = s.approvalRuleStorage.SetAccessRequestApprovalRule(ctx, accessRequest.ID, rule.ID)
+		if err != nil {
+			return errs.E(op, err)
+		}
 
-	if dp.Owner.TeamContact == nil || *dp.Owner.TeamContact == "" {
 		return nil
 	}
 
-	err = s.slackapi.SendSlackNotification(*dp.Owner.TeamContact, slackMessage)
+	link := datasetLink(s.dataCatalogueURL, dp.ID, dp.Name, ds.ID)
+
+	title := "Ny søknad om tilgang"
+	if accessRequest.RenewsAccessID != nil {
+		title = "Ny søknad om fornyet tilgang"
+	}
+
+	err = s.notificationService.Notify(ctx, service.NewNotification{
+		EventType:       service.NotificationEventAccessRequestCreated,
+		Recipients:      []string{service.SubjectTypeGroup + ":" + dp.Owner.Group},
+		Title:           title,
+		Message:         createAccessRequestNotification(dp, ds, accessRequest.Owner),
+		Link:            &link,
+		ReferenceID:     &accessRequest.ID,
+		TeamChannel:     dp.Owner.TeamContact,
+		ApprovalRequest: true,
+	})
 	if err != nil {
 		return errs.E(op, err)
 	}
@@ -112,29 +184,278 @@ func (s *accessService) CreateAccessRequest(ctx context.Context, user *service.U
 	return nil
 }
 
-func createAccessRequestSlackNotification(dp *service.DataproductWithDataset, ds *service.Dataset, dataCatalogueURL, subject string) string {
-	link := fmt.Sprintf(
-		"\nLink: %s/dataproduct/%s/%s/%s",
-		dataCatalogueURL,
-		dp.ID.String(),
-		url.QueryEscape(dp.Name),
-		ds.ID.String(),
-	)
+// RenewAccess sends an access request for the subject and owner of an access
+// that has not been revoked, the expiry defaults to extending the access by as
+// long as it was originally granted for
+func (s *accessService) RenewAccess(ctx context.Context, user *service.User, accessID uuid.UUID, input service.RenewAccessDTO) (*service.AccessRequest, error) {
+	const op errs.Op = "accessService.RenewAccess"
 
-	dsp := fmt.Sprintf(
-		"\nDatasett: %s\nDataprodukt: %s",
-		ds.Name,
-		dp.Name,
-	)
+	if err := input.Validate(); err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	access, err := s.accessStorage.GetAccessToDataset(ctx, accessID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if err := ensureOwner(user, access.Owner); err != nil && !strings.EqualFold(service.SubjectTypeUser+":"+user.Email, access.Subject) {
+		return nil, errs.E(op, err)
+	}
+
+	if access.Revoked != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("access %v is revoked", access.ID))
+	}
+
+	if access.Expires == nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("access %v does not expire", access.ID))
+	}
+
+	expires := input.Expires
+	if expires == nil {
+		renewed := access.Expires.Add(access.Expires.Sub(access.Created))
+		expires = &renewed
+	}
+
+	if !expires.After(*access.Expires) {
+		return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("expires"), fmt.Errorf("expires must be after the current expiry %s", access.Expires.Format(time.RFC3339)))
+	}
+
+	_, err = s.accessStorage.GetPendingRenewalAccessRequest(ctx, access.ID)
+	if err == nil {
+		return nil, errs.E(errs.Exist, op, fmt.Errorf("access %v already has a pending renewal request", access.ID))
+	}
+
+	if !errs.KindIs(errs.NotExist, err) {
+		return nil, errs.E(op, err)
+	}
+
+	ds, err := s.dataProductStorage.GetDataset(ctx, access.DatasetID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if !ds.Lifecycle.Active() {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("dataset %v is %s and does not accept new access requests", ds.ID, ds.Lifecycle.Status))
+	}
+
+	var pollyID uuid.NullUUID
+	var purpose *string
+	var purposeStatus *service.PollyPurposeStatus
+
+	switch {
+	case input.Polly != nil:
+		purpose = &input.Polly.Name
+
+		purposeStatus, err = s.purposeStatus(ctx, input.Polly.ExternalID)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+	case access.AccessRequestID != nil:
+		original, err := s.accessStorage.GetAccessRequest(ctx, *access.AccessRequestID)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		if original.Polly != nil {
+			polly, err := s.pollyStorage.GetPollyDocumentation(ctx, original.Polly.ID)
+			if err != nil {
+				return nil, errs.E(op, err)
+			}
+
+			pollyID = uuid.NullUUID{UUID: polly.ID, Valid: true}
+			purpose = &polly.Name
+			purposeStatus = polly.Status
+		}
+	}
+
+	subjType, subj, _ := strings.Cut(access.Subject, ":")
+
+	err = s.policyService.Enforce(ctx, service.PolicyInput{
+		Action:        service.PolicyActionRequestAccess,
+		Subject:       subj,
+		SubjectType:   subjType,
+		SubjectGroups: subjectGroups(user, subj, subjType),
+		DatasetID:     ds.ID,
+		Purpose:       purpose,
+		PurposeStatus: purposeStatus,
+		Expires:       expires,
+	})
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if input.Polly != nil {
+		dbPolly, err := s.pollyStorage.CreatePollyDocumentation(ctx, *input.Polly)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		pollyID = uuid.NullUUID{UUID: dbPolly.ID, Valid: true}
+	}
+
+	accessRequest, err := s.accessStorage.CreateRenewalAccessRequest(ctx, access, pollyID, expires)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	err = s.submitAccessRequest(ctx, user, accessRequest, ds)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	accessRequest, err = s.accessStorage.GetAccessRequest(ctx, accessRequest.ID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return accessRequest, nil
+}
+
+func (s *accessService) GetExpiringAccess(ctx context.Context, user *service.User, days int) (*service.ExpiringAccesses, error) {
+	const op errs.Op = "accessService.GetExpiringAccess"
+
+	if days <= 0 {
+		return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("days"), fmt.Errorf("days must be positive"))
+	}
+
+	accesses, err := s.accessStorage.ListExpiringAccessForGroups(ctx, user.GoogleGroups.Emails(), days)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return &service.ExpiringAccesses{
+		Accesses: accesses,
+	}, nil
+}
+
+// matchingApprovalRule returns the first automatic approval rule of the
+// dataset that matches the access request, or nil when none match
+func (s *accessService) matchingApprovalRule(ctx context.Context, requester *service.User, ar *service.AccessRequest, ds *service.Dataset) (*service.AccessApprovalRule, error) {
+	const op errs.Op = "accessService.matchingApprovalRule"
+
+	input, err := s.grantPolicyInput(ctx, ar)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	decision, err := s.policyService.Decide(ctx, input)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if !decision.Allow {
+		return nil, nil
+	}
+
+	if !requestedOnOwnBehalf(requester, ar) {
+		return nil, nil
+	}
+
+	rules, err := s.approvalRuleStorage.GetAccessApprovalRulesForDataset(ctx, ds.ID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
 
+	now := time.Now()
+	for _, rule := range rules {
+		if approvalRuleMatches(rule, requester, ar, ds, now) {
+			return rule, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// requestedOnOwnBehalf returns true when the requester is the user the access
+// is requested for, or a member of the group that owns the access request, the
+// owner is given by the requester and can not be trusted on its own
+func requestedOnOwnBehalf(requester *service.User, ar *service.AccessRequest) bool {
+	if ar.SubjectType == service.SubjectTypeUser {
+		return strings.EqualFold(ar.Subject, requester.Email)
+	}
+
+	return requester.GoogleGroups.Contains(ar.Owner)
+}
+
+// approvalRuleMatches returns true when the access request meets every
+// condition of the rule, where the requester is who sent the request
+func approvalRuleMatches(rule *service.AccessApprovalRule, requester *service.User, ar *service.AccessRequest, ds *service.Dataset, now time.Time) bool {
+	if rule.SubjectType != nil && *rule.SubjectType != ar.SubjectType {
+		return false
+	}
+
+	if rule.SubjectGroup != nil {
+		var member bool
+
+		switch ar.SubjectType {
+		case service.SubjectTypeUser:
+			member = strings.EqualFold(ar.Subject, requester.Email) && requester.GoogleGroups.Contains(*rule.SubjectGroup)
+		case service.SubjectTypeGroup:
+			member = strings.EqualFold(ar.Subject, *rule.SubjectGroup) && requester.GoogleGroups.Contains(ar.Subject)
+		case service.SubjectTypeServiceAccount:
+			member = strings.EqualFold(ar.Owner, *rule.SubjectGroup) && requester.GoogleGroups.Contains(ar.Owner)
+		}
+
+		if !member {
+			return false
+		}
+	}
+
+	if rule.Pii != nil && *rule.Pii != ds.Pii {
+		return false
+	}
+
+	if rule.MaxExpiryDays != nil {
+		if ar.Expires == nil || ar.Expires.After(now.AddDate(0, 0, *rule.MaxExpiryDays)) {
+			return false
+		}
+	}
+
+	if rule.RequirePolly && ar.Polly == nil {
+		return false
+	}
+
+	return true
+}
+
+func createAccessRequestNotification(dp *service.DataproductWithDataset, ds *service.Dataset, subject string) string {
 	return fmt.Sprintf(
-		"%s har sendt en søknad om tilgang for: %s%s",
+		"%s har sendt en søknad om tilgang for:\nDatasett: %s\nDataprodukt: %s",
 		subject,
-		dsp,
-		link,
+		ds.Name,
+		dp.Name,
 	)
 }
 
+// notifyRequester notifies who sent an access request that it has been
+// processed, where the owner of the request is stored without a subject
+// type, and is only a group when the request is for a group
+func (s *accessService) notifyRequester(ctx context.Context, ar *service.AccessRequest, dp *service.DataproductWithDataset, ds *service.Dataset, eventType service.NotificationEventType, title, message string) error {
+	const op errs.Op = "accessService.notifyRequester"
+
+	ownerType := service.SubjectTypeUser
+	if ar.SubjectType == service.SubjectTypeGroup {
+		ownerType = service.SubjectTypeGroup
+	}
+
+	link := datasetLink(s.dataCatalogueURL, dp.ID, dp.Name, ds.ID)
+
+	err := s.notificationService.Notify(ctx, service.NewNotification{
+		EventType:   eventType,
+		Recipients:  []string{ownerType + ":" + ar.Owner},
+		Title:       title,
+		Message:     message,
+		Link:        &link,
+		ReferenceID: &ar.ID,
+	})
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
 func (s *accessService) DeleteAccessRequest(ctx context.Context, user *service.User, accessRequestID uuid.UUID) error {
 	const op errs.Op = "accessService.DeleteAccessRequest"
 
@@ -191,33 +512,63 @@ func (s *accessService) ApproveAccessRequest(ctx context.Context, user *service.
 		return errs.E(op, err)
 	}
 
-	bq, err := s.bigQueryStorage.GetBigqueryDatasource(ctx, ds.ID, false)
+	dp, err := s.dataProductStorage.GetDataproduct(ctx, ds.DataproductID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	err = ensureUserInGroup(user, dp.Owner.Group)
 	if err != nil {
 		return errs.E(op, err)
 	}
 
-	dp, err := s.dataProductStorage.GetDataproduct(ctx, ds.DataproductID)
+	err = s.approveAccessRequest(ctx, user, ar, ds, dp)
 	if err != nil {
 		return errs.E(op, err)
 	}
 
-	err = ensureUserInGroup(user, dp.Owner.Group)
+	return nil
+}
+
+// approveAccessRequest grants the access and approves the request on behalf of
+// the granter, who is either an owner or the creator of an approval rule
+func (s *accessService) approveAccessRequest(ctx context.Context, granter *service.User, ar *service.AccessRequest, ds *service.Dataset, dp *service.DataproductWithDataset) error {
+	const op errs.Op = "accessService.approveAccessRequest"
+
+	ref, provider, err := datasetDatasource(ctx, s.datasourceStorage, s.providers, ds.ID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	input, err := s.grantPolicyInput(ctx, ar)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	err = s.policyService.Enforce(ctx, input)
 	if err != nil {
 		return errs.E(op, err)
 	}
 
-	if ds.Pii == "sensitive" && ar.Subject == "all-users@nav.no" {
-		return errs.E(errs.InvalidRequest, op, fmt.Errorf("datasett som inneholder personopplysninger kan ikke gjøres tilgjengelig for alle interne brukere"))
+	if ar.RenewsAccessID != nil {
+		renewed, err := s.renewAccess(ctx, granter, ar, ds, dp)
+		if err != nil {
+			return errs.E(op, err)
+		}
+
+		if renewed {
+			return nil
+		}
 	}
 
 	subjWithType := ar.SubjectType + ":" + ar.Subject
-	if err := s.bigQueryAPI.Grant(ctx, bq.ProjectID, bq.Dataset, bq.Table, subjWithType); err != nil {
+	if err := provider.Grant(ctx, *ref, subjWithType); err != nil {
 		return errs.E(op, err)
 	}
 
 	err = s.accessStorage.GrantAccessToDatasetAndApproveRequest(
 		ctx,
-		user,
+		granter,
 		ds.ID,
 		subjWithType,
 		ar.Owner,
@@ -228,6 +579,101 @@ func (s *accessService) ApproveAccessRequest(ctx context.Context, user *service.
 		return errs.E(op, err)
 	}
 
+	err = s.notifyRequester(ctx, ar, dp, ds,
+		service.NotificationEventAccessRequestApproved,
+		"Søknad om tilgang godkjent",
+		fmt.Sprintf("Søknaden om tilgang til datasettet %s i dataproduktet %s er godkjent.", ds.Name, dp.Name),
+	)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+// renewAccess extends the access that the request renews, and returns false
+// when the access has been revoked or has expired, so that a new access must
+// be granted instead
+func (s *accessService) renewAccess(ctx context.Context, granter *service.User, ar *service.AccessRequest, ds *service.Dataset, dp *service.DataproductWithDataset) (bool, error) {
+	const op errs.Op = "accessService.renewAccess"
+
+	access, err := s.accessStorage.GetAccessToDataset(ctx, *ar.RenewsAccessID)
+	if err != nil {
+		return false, errs.E(op, err)
+	}
+
+	if access.Revoked != nil || (access.Expires != nil && access.Expires.Before(time.Now())) {
+		return false, nil
+	}
+
+	err = s.accessStorage.RenewAccessToDatasetAndApproveRequest(ctx, granter, access.ID, ar.ID, ar.Expires)
+	if err != nil {
+		return false, errs.E(op, err)
+	}
+
+	err = s.notifyRequester(ctx, ar, dp, ds,
+		service.NotificationEventAccessRequestApproved,
+		"Søknad om fornyet tilgang godkjent",
+		fmt.Sprintf("Søknaden om fornyet tilgang til datasettet %s i dataproduktet %s er godkjent.", ds.Name, dp.Name),
+	)
+	if err != nil {
+		return false, errs.E(op, err)
+	}
+
+	return true, nil
+}
+
+// grantPolicyInput describes granting the access request to the policies, the
+// groups of the subject are not known when the request is approved
+func (s *accessService) grantPolicyInput(ctx context.Context, ar *service.AccessRequest) (service.PolicyInput, error) {
+	const op errs.Op = "accessService.grantPolicyInput"
+
+	var purpose *string
+	var purposeStatus *service.PollyPurposeStatus
+	if ar.Polly != nil {
+		polly, err := s.pollyStorage.GetPollyDocumentation(ctx, ar.Polly.ID)
+		if err != nil {
+			return service.PolicyInput{}, errs.E(op, err)
+		}
+
+		purpose = &polly.Name
+		purposeStatus = polly.Status
+	}
+
+	return service.PolicyInput{
+		Action:        service.PolicyActionGrantAccess,
+		Subject:       ar.Subject,
+		SubjectType:   ar.SubjectType,
+		DatasetID:     ar.DatasetID,
+		Purpose:       purpose,
+		PurposeStatus: purposeStatus,
+		Expires:       ar.Expires,
+	}, nil
+}
+
+// purposeStatus returns the status of the purpose when it was last
+// revalidated, or nil if it has not been revalidated yet
+func (s *accessService) purposeStatus(ctx context.Context, externalID string) (*service.PollyPurposeStatus, error) {
+	const op errs.Op = "accessService.purposeStatus"
+
+	purpose, err := s.pollyStorage.GetPollyPurpose(ctx, externalID)
+	if err != nil {
+		if errs.KindIs(errs.NotExist, err) {
+			return nil, nil
+		}
+
+		return nil, errs.E(op, err)
+	}
+
+	return &purpose.Status, nil
+}
+
+// subjectGroups returns the groups of the subject when it is the user
+func subjectGroups(user *service.User, subject, subjectType string) []string {
+	if subjectType == service.SubjectTypeUser && strings.EqualFold(subject, user.Email) {
+		return user.GoogleGroups.Emails()
+	}
+
 	return nil
 }
 
@@ -259,6 +705,16 @@ func (s *accessService) DenyAccessRequest(ctx context.Context, user *service.Use
 		return errs.E(op, err)
 	}
 
+	message := fmt.Sprintf("Søknaden om tilgang til datasettet %s i dataproduktet %s er avslått.", ds.Name, dp.Name)
+	if reason != nil && *reason != "" {
+		message += fmt.Sprintf("\nBegrunnelse: %s", *reason)
+	}
+
+	err = s.notifyRequester(ctx, ar, dp, ds, service.NotificationEventAccessRequestDenied, "Søknad om tilgang avslått", message)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
 	return nil
 }
 
@@ -280,7 +736,7 @@ func (s *accessService) RevokeAccessToDataset(ctx context.Context, user *service
 		return errs.E(op, err)
 	}
 
-	bqds, err := s.bigQueryStorage.GetBigqueryDatasource(ctx, access.DatasetID, false)
+	ref, provider, err := datasetDatasource(ctx, s.datasourceStorage, s.providers, access.DatasetID)
 	if err != nil {
 		return errs.E(op, err)
 	}
@@ -297,22 +753,29 @@ func (s *accessService) RevokeAccessToDataset(ctx context.Context, user *service
 
 	subjectWithoutType := subjectParts[1]
 
-	if len(bqds.PseudoColumns) > 0 {
-		joinableViews, err := s.joinableViewStorage.GetJoinableViewsForReferenceAndUser(ctx, subjectWithoutType, ds.ID)
+	if ref.Type == service.DatasourceTypeBigQuery {
+		bqds, err := s.bigQueryStorage.GetBigqueryDatasource(ctx, access.DatasetID, false)
 		if err != nil {
 			return errs.E(op, err)
 		}
 
-		for _, jv := range joinableViews {
-			// FIXME: this is a bit of a hack, we should probably have a better way to get the joinable view name
-			joinableViewName := makeJoinableViewName(bqds.ProjectID, bqds.Dataset, bqds.Table)
-			if err := s.bigQueryAPI.Revoke(ctx, gcpProjectID, jv.Dataset, joinableViewName, access.Subject); err != nil {
+		if len(bqds.PseudoColumns) > 0 {
+			joinableViews, err := s.joinableViewStorage.GetJoinableViewsForReferenceAndUser(ctx, subjectWithoutType, ds.ID)
+			if err != nil {
 				return errs.E(op, err)
 			}
+
+			for _, jv := range joinableViews {
+				// FIXME: this is a bit of a hack, we should probably have a better way to get the joinable view name
+				joinableViewName := makeJoinableViewName(bqds.ProjectID, bqds.Dataset, bqds.Table)
+				if err := s.bigQueryAPI.Revoke(ctx, gcpProjectID, jv.Dataset, joinableViewName, access.Subject); err != nil {
+					return errs.E(op, err)
+				}
+			}
 		}
 	}
 
-	if err := s.bigQueryAPI.Revoke(ctx, bqds.ProjectID, bqds.Dataset, bqds.Table, access.Subject); err != nil {
+	if err := provider.Revoke(ctx, *ref, access.Subject); err != nil {
 		return errs.E(op, err)
 	}
 
@@ -355,19 +818,28 @@ func (s *accessService) GrantAccessToDataset(ctx context.Context, user *service.
 		return errs.E(op, err)
 	}
 
-	if ds.Pii == "sensitive" && subj == "all-users@nav.no" {
-		return errs.E(errs.InvalidRequest, op, fmt.Errorf("datasett som inneholder personopplysninger kan ikke gjøres tilgjengelig for alle interne brukere"))
+	subjType := service.SubjectTypeUser
+	if input.SubjectType != nil {
+		subjType = *input.SubjectType
 	}
 
-	bqds, err := s.bigQueryStorage.GetBigqueryDatasource(ctx, ds.ID, false)
+	err = s.policyService.Enforce(ctx, service.PolicyInput{
+		Action:        service.PolicyActionGrantAccess,
+		Subject:       subj,
+		SubjectType:   subjType,
+		SubjectGroups: subjectGroups(user, subj, subjType),
+		DatasetID:     ds.ID,
+		Expires:       input.Expires,
+	})
 	if err != nil {
 		return errs.E(op, err)
 	}
 
-	subjType := service.SubjectTypeUser
-	if input.SubjectType != nil {
-		subjType = *input.SubjectType
+	ref, provider, err := datasetDatasource(ctx, s.datasourceStorage, s.providers, ds.ID)
+	if err != nil {
+		return errs.E(op, err)
 	}
+
 	subjWithType := subjType + ":" + subj
 
 	owner := subj
@@ -375,21 +847,28 @@ func (s *accessService) GrantAccessToDataset(ctx context.Context, user *service.
 		owner = *input.Owner
 	}
 
-	if len(bqds.PseudoColumns) > 0 {
-		joinableViews, err := s.joinableViewStorage.GetJoinableViewsForReferenceAndUser(ctx, subj, ds.ID)
+	if ref.Type == service.DatasourceTypeBigQuery {
+		bqds, err := s.bigQueryStorage.GetBigqueryDatasource(ctx, ds.ID, false)
 		if err != nil {
 			return errs.E(op, err)
 		}
 
-		for _, jv := range joinableViews {
-			joinableViewName := makeJoinableViewName(bqds.ProjectID, bqds.Dataset, bqds.Table)
-			if err := s.bigQueryAPI.Grant(ctx, gcpProjectID, jv.Dataset, joinableViewName, subjWithType); err != nil {
+		if len(bqds.PseudoColumns) > 0 {
+			joinableViews, err := s.joinableViewStorage.GetJoinableViewsForReferenceAndUser(ctx, subj, ds.ID)
+			if err != nil {
 				return errs.E(op, err)
 			}
+
+			for _, jv := range joinableViews {
+				joinableViewName := makeJoinableViewName(bqds.ProjectID, bqds.Dataset, bqds.Table)
+				if err := s.bigQueryAPI.Grant(ctx, gcpProjectID, jv.Dataset, joinableViewName, subjWithType); err != nil {
+					return errs.E(op, err)
+				}
+			}
 		}
 	}
 
-	if err := s.bigQueryAPI.Grant(ctx, bqds.ProjectID, bqds.Dataset, bqds.Table, subjWithType); err != nil {
+	if err := provider.Grant(ctx, *ref, subjWithType); err != nil {
 		return errs.E(op, err)
 	}
 
@@ -411,24 +890,73 @@ func ensureOwner(user *service.User, owner string) error {
 	return errs.E(errs.Unauthorized, op, errs.UserName(user.Email), fmt.Errorf("user is not owner"))
 }
 
+// hasDatasetAccess returns true if the user is a member of the owner group
+// of the dataset, or has an active access to it either personally or through
+// a group
+func hasDatasetAccess(ctx context.Context, dataProductStorage service.DataProductsStorage, accessStorage service.AccessStorage, user *service.User, ds *service.Dataset) (bool, error) {
+	const op errs.Op = "hasDatasetAccess"
+
+	if user == nil {
+		return false, nil
+	}
+
+	dp, err := dataProductStorage.GetDataproduct(ctx, ds.DataproductID)
+	if err != nil {
+		return false, errs.E(op, err)
+	}
+
+	if user.GoogleGroups.Contains(dp.Owner.Group) {
+		return true, nil
+	}
+
+	accesses, err := accessStorage.ListActiveAccessToDataset(ctx, ds.ID)
+	if err != nil {
+		return false, errs.E(op, err)
+	}
+
+	// The subjects are stored in lower case, while the emails of the user
+	// and groups might not be
+	for _, a := range accesses {
+		if strings.EqualFold(a.Subject, service.SubjectTypeUser+":"+user.Email) {
+			return true, nil
+		}
+
+		for _, g := range user.GoogleGroups.Emails() {
+			if strings.EqualFold(a.Subject, service.SubjectTypeGroup+":"+g) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
 func NewAccessService(
 	dataCatalogueURL string,
-	slackapi service.SlackAPI,
+	notificationService service.NotificationService,
+	policyService service.PolicyService,
+	approvalRuleStorage service.AccessApprovalRuleStorage,
 	pollyStorage service.PollyStorage,
 	accessStorage service.AccessStorage,
 	dataProductStorage service.DataProductsStorage,
 	bigQueryStorage service.BigQueryStorage,
 	joinableViewStorage service.JoinableViewsStorage,
 	bigQueryAPI service.BigQueryAPI,
+	datasourceStorage service.DatasourceStorage,
+	providers service.DatasourceProviders,
 ) *accessService {
 	return &accessService{
 		dataCatalogueURL:    dataCatalogueURL,
-		slackapi:            slackapi,
+		notificationService: notificationService,
+		policyService:       policyService,
+		approvalRuleStorage: approvalRuleStorage,
 		pollyStorage:        pollyStorage,
 		accessStorage:       accessStorage,
 		dataProductStorage:  dataProductStorage,
 		bigQueryStorage:     bigQueryStorage,
 		joinableViewStorage: joinableViewStorage,
 		bigQueryAPI:         bigQueryAPI,
+		datasourceStorage:   datasourceStorage,
+		providers:           providers,
 	}
 }
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+var _ service.AccessApprovalRuleService = &accessApprovalRuleService{}
+
+type accessApprovalRuleService struct {
+	approvalRuleStorage service.AccessApprovalRuleStorage
+	dataProductStorage  service.DataProductsStorage
+}
+
+func (s *accessApprovalRuleService) GetAccessApprovalRules(ctx context.Context, user *service.User, datasetID uuid.UUID) (*service.AccessApprovalRules, error) {
+	const op errs.Op = "accessApprovalRuleService.GetAccessApprovalRules"
+
+	if err := s.ensureDatasetOwner(ctx, user, datasetID); err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	rules, err := s.approvalRuleStorage.GetAccessApprovalRulesForDataset(ctx, datasetID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return &service.AccessApprovalRules{
+		Rules: rules,
+	}, nil
+}
+
+func (s *accessApprovalRuleService) CreateAccessApprovalRule(ctx context.Context, user *service.User, input service.NewAccessApprovalRule) (*service.AccessApprovalRule, error) {
+	const op errs.Op = "accessApprovalRuleService.CreateAccessApprovalRule"
+
+	if err := input.Validate(); err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	if err := s.ensureDatasetOwner(ctx, user, input.DatasetID); err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	rule, err := s.approvalRuleStorage.CreateAccessApprovalRule(ctx, input, user.Email)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return rule, nil
+}
+
+// DeleteAccessApprovalRule stops the rule from approving new access requests,
+// the access requests it has approved still refer to it
+func (s *accessApprovalRuleService) DeleteAccessApprovalRule(ctx context.Context, user *service.User, id uuid.UUID) error {
+	const op errs.Op = "accessApprovalRuleService.DeleteAccessApprovalRule"
+
+	rule, err := s.approvalRuleStorage.GetAccessApprovalRule(ctx, id)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	if err := s.ensureDatasetOwner(ctx, user, rule.DatasetID); err != nil {
+		return errs.E(op, err)
+	}
+
+	err = s.approvalRuleStorage.DeleteAccessApprovalRule(ctx, id)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+func (s *accessApprovalRuleService) ensureDatasetOwner(ctx context.Context, user *service.User, datasetID uuid.UUID) error {
+	const op errs.Op = "accessApprovalRuleService.ensureDatasetOwner"
+
+	ds, err := s.dataProductStorage.GetDataset(ctx, datasetID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	dp, err := s.dataProductStorage.GetDataproduct(ctx, ds.DataproductID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	if err := ensureUserInGroup(user, dp.Owner.Group); err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+func NewAccessApprovalRuleService(
+	approvalRuleStorage service.AccessApprovalRuleStorage,
+	dataProductStorage service.DataProductsStorage,
+) *accessApprovalRuleService {
+	return &accessApprovalRuleService{
+		approvalRuleStorage: approvalRuleStorage,
+		dataProductStorage:  dataProductStorage,
+	}
+}
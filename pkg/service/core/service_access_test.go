@@ -0,0 +1,83 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticDataProductsStorage struct {
+	service.DataProductsStorage
+	dp *service.DataproductWithDataset
+}
+
+func (s *staticDataProductsStorage) GetDataproduct(context.Context, uuid.UUID) (*service.DataproductWithDataset, error) {
+	return s.dp, nil
+}
+
+type staticAccessStorage struct {
+	service.AccessStorage
+	accesses []*service.Access
+}
+
+func (s *staticAccessStorage) ListActiveAccessToDataset(context.Context, uuid.UUID) ([]*service.Access, error) {
+	return s.accesses, nil
+}
+
+func TestHasDatasetAccess(t *testing.T) {
+	ds := &service.Dataset{ID: uuid.New(), DataproductID: uuid.New()}
+
+	dataProductStorage := &staticDataProductsStorage{
+		dp: &service.DataproductWithDataset{
+			Dataproduct: service.Dataproduct{
+				ID:    ds.DataproductID,
+				Owner: &service.DataproductOwner{Group: "team@nav.no"},
+			},
+		},
+	}
+
+	// Subjects are stored in lower case
+	accessStorage := &staticAccessStorage{
+		accesses: []*service.Access{
+			{Subject: "user:ola.nordmann@nav.no"},
+			{Subject: "group:readers@nav.no"},
+		},
+	}
+
+	testCases := []struct {
+		name   string
+		user   *service.User
+		expect bool
+	}{
+		{
+			name:   "user with mixed case email",
+			user:   &service.User{Email: "Ola.Nordmann@nav.no"},
+			expect: true,
+		},
+		{
+			name: "member of group with mixed case email",
+			user: &service.User{
+				Email:        "kari.nordmann@nav.no",
+				GoogleGroups: service.Groups{{Email: "Readers@nav.no"}},
+			},
+			expect: true,
+		},
+		{
+			name:   "user without access",
+			user:   &service.User{Email: "kari.nordmann@nav.no"},
+			expect: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := hasDatasetAccess(context.Background(), dataProductStorage, accessStorage, tc.user, ds)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, got)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/navikt/nada-backend/pkg/errs"
@@ -13,9 +14,15 @@ import (
 )
 
 type bigQueryService struct {
-	bigQueryStorage    service.BigQueryStorage
-	dataProductStorage service.DataProductsStorage
-	bigQueryAPI        service.BigQueryAPI
+	dataCatalogueURL    string
+	bigQueryStorage     service.BigQueryStorage
+	dataProductStorage  service.DataProductsStorage
+	datasourceStorage   service.DatasourceStorage
+	accessStorage       service.AccessStorage
+	bigQueryAPI         service.BigQueryAPI
+	providers           service.DatasourceProviders
+	notificationService service.NotificationService
+	profilingStorage    service.ProfilingStorage
 }
 
 var _ service.BigQueryService = &bigQueryService{}
@@ -54,20 +61,129 @@ func (s *bigQueryService) GetBigQueryColumns(ctx context.Context, projectID stri
 		return nil, errs.E(op, err)
 	}
 
+	profile, err := s.profilingStorage.GetLatestTableProfile(ctx, projectID, datasetID, tableID)
+	if err != nil && !errs.KindIs(errs.NotExist, err) {
+		return nil, errs.E(op, err)
+	}
+
 	return &service.BQColumns{
 		BQColumns: metadata.Schema.Columns,
+		Profile:   withoutValueStatistics(profile),
 	}, nil
 }
 
+// withoutValueStatistics leaves out the min, max and top values of the columns,
+// since the columns are available without access to the dataset
+func withoutValueStatistics(profile *service.DatasetProfile) *service.DatasetProfile {
+	if profile == nil {
+		return nil
+	}
+
+	p := *profile
+	p.Columns = make([]*service.ColumnProfile, len(profile.Columns))
+
+	for i, c := range profile.Columns {
+		p.Columns[i] = &service.ColumnProfile{
+			Name:          c.Name,
+			Type:          c.Type,
+			NullRate:      c.NullRate,
+			DistinctCount: c.DistinctCount,
+			TopValues:     []*service.ColumnValueCount{},
+			Masked:        c.Masked,
+		}
+	}
+
+	return &p
+}
+
 func (s *bigQueryService) UpdateMetadata(ctx context.Context, ds *service.BigQuery) error {
 	const op errs.Op = "bigQueryService.UpdateMetadata"
 
-	metadata, err := s.bigQueryAPI.TableMetadata(ctx, ds.ProjectID, ds.Dataset, ds.Table)
+	err := s.updateDatasourceMetadata(ctx, ds.Ref())
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+func (s *bigQueryService) updateDatasourceMetadata(ctx context.Context, ref service.DatasourceRef) error {
+	const op errs.Op = "bigQueryService.updateDatasourceMetadata"
+
+	provider, err := datasourceProvider(s.providers, ref.Type)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	metadata, err := provider.Metadata(ctx, ref)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	previous, err := s.datasourceStorage.GetDatasourceSchema(ctx, ref)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	err = s.datasourceStorage.UpdateDatasourceMetadata(ctx, ref, *metadata)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	// A datasource that has never been synced has no schema to compare with
+	if len(previous) == 0 {
+		return nil
+	}
+
+	if changes := schemaChanges(previous, metadata.Schema); len(changes) > 0 {
+		if err := s.notifySchemaChanged(ctx, ref, changes); err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	return nil
+}
+
+// notifySchemaChanged notifies the owners and the consumers with active
+// access to a dataset that the schema of its datasource has changed
+func (s *bigQueryService) notifySchemaChanged(ctx context.Context, ref service.DatasourceRef, changes []string) error {
+	const op errs.Op = "bigQueryService.notifySchemaChanged"
+
+	ds, err := s.dataProductStorage.GetDataset(ctx, ref.DatasetID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	dp, err := s.dataProductStorage.GetDataproduct(ctx, ds.DataproductID)
 	if err != nil {
 		return errs.E(op, err)
 	}
 
-	err = s.bigQueryStorage.UpdateBigqueryDatasourceSchema(ctx, ds.DatasetID, metadata)
+	accesses, err := s.accessStorage.ListActiveAccessToDataset(ctx, ds.ID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	recipients := []string{service.SubjectTypeGroup + ":" + dp.Owner.Group}
+	for _, a := range accesses {
+		recipients = append(recipients, consumerSubjects(a.Subject, a.Owner)...)
+	}
+
+	link := datasetLink(s.dataCatalogueURL, dp.ID, dp.Name, ds.ID)
+
+	err = s.notificationService.Notify(ctx, service.NewNotification{
+		EventType:  service.NotificationEventSchemaChanged,
+		Recipients: recipients,
+		Title:      "Skjemaendring i datasett",
+		Message: fmt.Sprintf(
+			"Skjemaet til datasettet %s i dataproduktet %s er endret:\n%s",
+			ds.Name,
+			dp.Name,
+			strings.Join(changes, "\n"),
+		),
+		Link:        &link,
+		ReferenceID: &ds.ID,
+	})
 	if err != nil {
 		return errs.E(op, err)
 	}
@@ -75,70 +191,116 @@ func (s *bigQueryService) UpdateMetadata(ctx context.Context, ds *service.BigQue
 	return nil
 }
 
+// schemaChanges describes the columns that have been added, removed or
+// changed type or mode, changes to descriptions are not included
+func schemaChanges(previous, current []*service.BigqueryColumn) []string {
+	var changes []string
+
+	columns := map[string]*service.BigqueryColumn{}
+	for _, c := range previous {
+		columns[c.Name] = c
+	}
+
+	for _, c := range current {
+		p, ok := columns[c.Name]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("Ny kolonne: %s (%s)", c.Name, c.Type))
+			continue
+		}
+
+		delete(columns, c.Name)
+
+		if p.Type != c.Type || p.Mode != c.Mode {
+			changes = append(changes, fmt.Sprintf("Endret kolonne: %s (%s %s -> %s %s)", c.Name, p.Mode, p.Type, c.Mode, c.Type))
+		}
+	}
+
+	for _, p := range previous {
+		if _, removed := columns[p.Name]; removed {
+			changes = append(changes, fmt.Sprintf("Fjernet kolonne: %s", p.Name))
+		}
+	}
+
+	return changes
+}
+
+// SyncBigQueryTables syncs the metadata and schema of all datasources,
+// regardless of type, through their datasource provider.
 func (s *bigQueryService) SyncBigQueryTables(ctx context.Context) error {
 	const op errs.Op = "bigQueryService.SyncBigQueryTables"
 
-	bqs, err := s.bigQueryStorage.GetBigqueryDatasources(ctx)
+	refs, err := s.datasourceStorage.GetDatasourceRefs(ctx)
 	if err != nil {
 		return errs.E(op, err)
 	}
 
 	var errList []error
 
-	for _, bq := range bqs {
-		err := s.UpdateMetadata(ctx, bq)
+	for _, ref := range refs {
+		err := s.updateDatasourceMetadata(ctx, *ref)
 		if err != nil {
-			errList = s.handleSyncError(ctx, errList, err, bq)
+			errList = s.handleSyncError(ctx, errList, err, *ref)
 		}
 	}
 
 	// FIXME: not very nice, should probably log all the errors and return something more generic here
 	if len(errList) != 0 {
-		errMessage := fmt.Sprintf("syncing bigquery tables: %v", errList)
+		errMessage := fmt.Sprintf("syncing datasources: %v", errList)
 		return errs.E(errs.IO, op, fmt.Errorf("%w", errors.New(errMessage)))
 	}
 
 	return nil
 }
 
-func (s *bigQueryService) handleSyncError(ctx context.Context, errs []error, err error, bq *service.BigQuery) []error {
+func (s *bigQueryService) handleSyncError(ctx context.Context, errList []error, err error, ref service.DatasourceRef) []error {
 	var e *googleapi.Error
 
-	if ok := errors.As(err, &e); ok {
-		if e.Code == http.StatusNotFound {
-			if err := s.handleTableNotFound(ctx, bq); err != nil {
-				errs = append(errs, err)
-			}
-		} else {
-			errs = append(errs, err)
-		}
+	notFound := errs.KindIs(errs.NotExist, err) || (errors.As(err, &e) && e.Code == http.StatusNotFound)
+	if !notFound {
+		return append(errList, err)
+	}
+
+	if err := s.handleDatasourceNotFound(ctx, ref); err != nil {
+		errList = append(errList, err)
 	}
 
-	return errs
+	return errList
 }
 
 const (
 	removalTime = -168 * time.Hour // 1 week
 )
 
-func (s *bigQueryService) handleTableNotFound(ctx context.Context, bq *service.BigQuery) error {
-	if bq.MissingSince == nil {
-		return s.bigQueryStorage.UpdateBigqueryDatasourceMissing(ctx, bq.DatasetID)
-	} else if bq.MissingSince.Before(time.Now().Add(removalTime)) {
-		return s.dataProductStorage.DeleteDataset(ctx, bq.DatasetID)
+func (s *bigQueryService) handleDatasourceNotFound(ctx context.Context, ref service.DatasourceRef) error {
+	if ref.MissingSince == nil {
+		return s.datasourceStorage.UpdateDatasourceMissing(ctx, ref)
+	} else if ref.MissingSince.Before(time.Now().Add(removalTime)) {
+		return s.dataProductStorage.DeleteDataset(ctx, ref.DatasetID)
 	}
 
 	return nil
 }
 
 func NewBigQueryService(
+	dataCatalogueURL string,
 	bigQueryStorage service.BigQueryStorage,
 	bigQueryAPI service.BigQueryAPI,
 	dataProductStorage service.DataProductsStorage,
+	datasourceStorage service.DatasourceStorage,
+	accessStorage service.AccessStorage,
+	providers service.DatasourceProviders,
+	notificationService service.NotificationService,
+	profilingStorage service.ProfilingStorage,
 ) *bigQueryService {
 	return &bigQueryService{
-		bigQueryStorage:    bigQueryStorage,
-		bigQueryAPI:        bigQueryAPI,
-		dataProductStorage: dataProductStorage,
+		dataCatalogueURL:    dataCatalogueURL,
+		bigQueryStorage:     bigQueryStorage,
+		bigQueryAPI:         bigQueryAPI,
+		dataProductStorage:  dataProductStorage,
+		datasourceStorage:   datasourceStorage,
+		accessStorage:       accessStorage,
+		providers:           providers,
+		notificationService: notificationService,
+		profilingStorage:    profilingStorage,
 	}
 }
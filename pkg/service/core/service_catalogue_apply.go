@@ -0,0 +1,613 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+var _ service.CatalogueApplyService = &catalogueApplyService{}
+
+type catalogueApplyService struct {
+	gcpProjectID        string
+	dataProductStorage  service.DataProductsStorage
+	bigQueryStorage     service.BigQueryStorage
+	dataProductsService service.DataProductsService
+	accessService       service.AccessService
+}
+
+// catalogueStep is an action in the plan, together with how to execute it
+type catalogueStep struct {
+	action *service.CataloguePlanAction
+	apply  func(ctx context.Context) error
+}
+
+func (s *catalogueApplyService) Apply(ctx context.Context, teamEmail string, spec *service.CatalogueSpec, opts service.CatalogueApplyOptions) (*service.CataloguePlan, error) {
+	const op errs.Op = "catalogueApplyService.Apply"
+
+	if err := spec.Validate(); err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	steps, err := s.plan(ctx, teamUser(teamEmail), spec, opts.Prune)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	plan := &service.CataloguePlan{
+		Actions: []*service.CataloguePlanAction{},
+	}
+
+	for _, step := range steps {
+		step.action.Status = service.CatalogueActionStatusPlanned
+		plan.Actions = append(plan.Actions, step.action)
+	}
+
+	if opts.PlanOnly {
+		return plan, nil
+	}
+
+	// The steps go through the services, which also change BigQuery and Metabase,
+	// so they can't be rolled back. Instead, we stop at the first failure and
+	// report what was applied before it.
+	failed := false
+
+	for _, step := range steps {
+		if failed {
+			step.action.Status = service.CatalogueActionStatusSkipped
+			continue
+		}
+
+		if err := step.apply(ctx); err != nil {
+			msg := err.Error()
+			step.action.Status = service.CatalogueActionStatusFailed
+			step.action.Error = &msg
+			failed = true
+
+			continue
+		}
+
+		step.action.Status = service.CatalogueActionStatusApplied
+	}
+
+	plan.Applied = !failed
+
+	return plan, nil
+}
+
+// plan diffs the spec against the dataproducts owned by the team. The pruning
+// comes last, after everything in the spec has been created and updated.
+func (s *catalogueApplyService) plan(ctx context.Context, user *service.User, spec *service.CatalogueSpec, prune bool) ([]*catalogueStep, error) {
+	const op errs.Op = "catalogueApplyService.plan"
+
+	current, _, err := s.dataProductStorage.GetDataproductsWithDatasetsAndAccessRequests(ctx, nil, []string{user.Email})
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	byName := map[string]*service.DataproductWithDataset{}
+	for i, dp := range current {
+		if _, ok := byName[dp.Name]; ok {
+			return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("the team owns more than one dataproduct named %s", dp.Name))
+		}
+
+		byName[dp.Name] = &current[i]
+	}
+
+	var steps, pruneSteps []*catalogueStep
+
+	for _, dpSpec := range spec.Dataproducts {
+		dp, ok := byName[dpSpec.Name]
+		if !ok {
+			steps = append(steps, s.createDataproduct(user, dpSpec)...)
+			continue
+		}
+
+		delete(byName, dpSpec.Name)
+
+		dpSteps, dpPruneSteps, err := s.updateDataproduct(ctx, user, dp, dpSpec, prune)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		steps = append(steps, dpSteps...)
+		pruneSteps = append(pruneSteps, dpPruneSteps...)
+	}
+
+	if prune {
+		for _, dp := range current {
+			if _, ok := byName[dp.Name]; !ok {
+				continue
+			}
+
+			id := dp.ID
+			pruneSteps = append(pruneSteps, &catalogueStep{
+				action: &service.CataloguePlanAction{
+					Action:  service.CatalogueActionDelete,
+					Kind:    service.CatalogueResourceDataproduct,
+					Path:    dp.Name,
+					ID:      &id,
+					Changes: []*service.CatalogueFieldChange{},
+				},
+				apply: func(ctx context.Context) error {
+					_, err := s.dataProductsService.DeleteDataproduct(ctx, user, id)
+					return err
+				},
+			})
+		}
+	}
+
+	return append(steps, pruneSteps...), nil
+}
+
+func (s *catalogueApplyService) createDataproduct(user *service.User, spec *service.DataproductSpec) []*catalogueStep {
+	// The datasets are created in the dataproduct once it exists
+	dpID := &uuid.UUID{}
+
+	changes := []*service.CatalogueFieldChange{}
+	changes = appendChange(changes, "description", nil, escapedPtr(spec.Description))
+	changes = appendChange(changes, "teamkatalogenURL", nil, spec.TeamkatalogenURL)
+	changes = appendChange(changes, "teamContact", nil, spec.TeamContact)
+	changes = appendChange(changes, "teamID", nil, uuidToStrPtr(spec.TeamID))
+
+	steps := []*catalogueStep{
+		{
+			action: &service.CataloguePlanAction{
+				Action:  service.CatalogueActionCreate,
+				Kind:    service.CatalogueResourceDataproduct,
+				Path:    spec.Name,
+				Changes: changes,
+			},
+			apply: func(ctx context.Context) error {
+				dp, err := s.dataProductsService.CreateDataproduct(ctx, user, service.NewDataproduct{
+					Name:             spec.Name,
+					Description:      copyStrPtr(spec.Description),
+					Group:            user.Email,
+					TeamkatalogenURL: spec.TeamkatalogenURL,
+					TeamContact:      spec.TeamContact,
+					TeamID:           spec.TeamID,
+				})
+				if err != nil {
+					return err
+				}
+
+				*dpID = dp.ID
+
+				return nil
+			},
+		},
+	}
+
+	for _, dsSpec := range spec.Datasets {
+		steps = append(steps, s.createDataset(user, dpID, spec.Name, dsSpec)...)
+	}
+
+	return steps
+}
+
+func (s *catalogueApplyService) updateDataproduct(ctx context.Context, user *service.User, dp *service.DataproductWithDataset, spec *service.DataproductSpec, prune bool) ([]*catalogueStep, []*catalogueStep, error) {
+	const op errs.Op = "catalogueApplyService.updateDataproduct"
+
+	var steps, pruneSteps []*catalogueStep
+
+	changes := []*service.CatalogueFieldChange{}
+	changes = appendChange(changes, "description", dp.Description, escapedPtr(spec.Description))
+	changes = appendChange(changes, "teamkatalogenURL", dp.Owner.TeamkatalogenURL, spec.TeamkatalogenURL)
+	changes = appendChange(changes, "teamContact", dp.Owner.TeamContact, spec.TeamContact)
+	changes = appendChange(changes, "teamID", uuidToStrPtr(dp.Owner.TeamID), uuidToStrPtr(spec.TeamID))
+
+	dpID := dp.ID
+
+	if len(changes) > 0 {
+		slug := dp.Slug
+		steps = append(steps, &catalogueStep{
+			action: &service.CataloguePlanAction{
+				Action:  service.CatalogueActionUpdate,
+				Kind:    service.CatalogueResourceDataproduct,
+				Path:    spec.Name,
+				ID:      &dpID,
+				Changes: changes,
+			},
+			apply: func(ctx context.Context) error {
+				_, err := s.dataProductsService.UpdateDataproduct(ctx, user, dpID, service.UpdateDataproductDto{
+					Name:             spec.Name,
+					Description:      copyStrPtr(spec.Description),
+					Slug:             &slug,
+					TeamkatalogenURL: spec.TeamkatalogenURL,
+					TeamContact:      spec.TeamContact,
+					TeamID:           spec.TeamID,
+				})
+
+				return err
+			},
+		})
+	}
+
+	datasets := map[string]*service.DatasetInDataproduct{}
+	for _, ds := range dp.Datasets {
+		if _, ok := datasets[ds.Name]; ok {
+			return nil, nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("dataproduct %s has more than one dataset named %s", dp.Name, ds.Name))
+		}
+
+		datasets[ds.Name] = ds
+	}
+
+	for _, dsSpec := range spec.Datasets {
+		existing, ok := datasets[dsSpec.Name]
+		if !ok {
+			steps = append(steps, s.createDataset(user, &dpID, spec.Name, dsSpec)...)
+			continue
+		}
+
+		delete(datasets, dsSpec.Name)
+
+		ds, err := s.dataProductStorage.GetDataset(ctx, existing.ID)
+		if err != nil {
+			return nil, nil, errs.E(op, err)
+		}
+
+		dsSteps, err := s.updateDataset(ctx, user, spec.Name, ds, dsSpec, prune)
+		if err != nil {
+			return nil, nil, errs.E(op, err)
+		}
+
+		steps = append(steps, dsSteps...)
+	}
+
+	if prune {
+		for _, ds := range dp.Datasets {
+			if _, ok := datasets[ds.Name]; !ok {
+				continue
+			}
+
+			id := ds.ID
+			pruneSteps = append(pruneSteps, &catalogueStep{
+				action: &service.CataloguePlanAction{
+					Action:  service.CatalogueActionDelete,
+					Kind:    service.CatalogueResourceDataset,
+					Path:    spec.Name + "/" + ds.Name,
+					ID:      &id,
+					Changes: []*service.CatalogueFieldChange{},
+				},
+				apply: func(ctx context.Context) error {
+					_, err := s.dataProductsService.DeleteDataset(ctx, user, id)
+					return err
+				},
+			})
+		}
+	}
+
+	return steps, pruneSteps, nil
+}
+
+func (s *catalogueApplyService) createDataset(user *service.User, dpID *uuid.UUID, dpName string, spec *service.DatasetSpec) []*catalogueStep {
+	path := dpName + "/" + spec.Name
+	dsID := &uuid.UUID{}
+
+	changes := []*service.CatalogueFieldChange{}
+	changes = appendChange(changes, "description", nil, escapedPtr(spec.Description))
+	changes = appendChange(changes, "pii", nil, strToStrPtr(string(spec.Pii)))
+	changes = appendChange(changes, "keywords", nil, joinedPtr(spec.Keywords))
+	changes = appendChange(changes, "repo", nil, spec.Repo)
+	changes = appendChange(changes, "anonymisationDescription", nil, spec.AnonymisationDescription)
+	changes = appendChange(changes, "targetUser", nil, spec.TargetUser)
+	changes = appendChange(changes, "bigquery", nil, strToStrPtr(bigQuerySpecString(spec.BigQuery)))
+
+	steps := []*catalogueStep{
+		{
+			action: &service.CataloguePlanAction{
+				Action:  service.CatalogueActionCreate,
+				Kind:    service.CatalogueResourceDataset,
+				Path:    path,
+				Changes: changes,
+			},
+			apply: func(ctx context.Context) error {
+				ds, err := s.dataProductsService.CreateDataset(ctx, user, service.NewDataset{
+					DataproductID:            *dpID,
+					Name:                     spec.Name,
+					Description:              copyStrPtr(spec.Description),
+					Repo:                     spec.Repo,
+					Pii:                      spec.Pii,
+					Keywords:                 spec.Keywords,
+					AnonymisationDescription: spec.AnonymisationDescription,
+					TargetUser:               spec.TargetUser,
+					BigQuery: service.NewBigQuery{
+						ProjectID:    spec.BigQuery.ProjectID,
+						Dataset:      spec.BigQuery.Dataset,
+						Table:        spec.BigQuery.Table,
+						Scope:        spec.BigQuery.Scope,
+						TablePattern: spec.BigQuery.TablePattern,
+					},
+				})
+				if err != nil {
+					return err
+				}
+
+				*dsID = ds.ID
+
+				return nil
+			},
+		},
+	}
+
+	for _, a := range spec.Access {
+		steps = append(steps, s.grantAccess(user, dsID, path, a, nil))
+	}
+
+	return steps
+}
+
+func (s *catalogueApplyService) updateDataset(ctx context.Context, user *service.User, dpName string, ds *service.Dataset, spec *service.DatasetSpec, prune bool) ([]*catalogueStep, error) {
+	const op errs.Op = "catalogueApplyService.updateDataset"
+
+	path := dpName + "/" + ds.Name
+	dsID := ds.ID
+
+	if ds.Datasource == nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("dataset %s is not backed by BigQuery", path))
+	}
+
+	// A pseudonymised dataset is specified by the table the view is made from
+	bq := ds.Datasource
+	if len(bq.PseudoColumns) > 0 {
+		ref, err := s.bigQueryStorage.GetBigqueryDatasource(ctx, ds.ID, true)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		bq = ref
+	}
+
+	if current, wanted := bigQueryString(bq), bigQuerySpecString(spec.BigQuery); current != wanted {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("the datasource of dataset %s can't be changed from %s to %s", path, current, wanted))
+	}
+
+	var steps []*catalogueStep
+
+	changes := []*service.CatalogueFieldChange{}
+	changes = appendChange(changes, "description", ds.Description, escapedPtr(spec.Description))
+	changes = appendChange(changes, "pii", strToStrPtr(string(ds.Pii)), strToStrPtr(string(spec.Pii)))
+	changes = appendChange(changes, "keywords", joinedPtr(ds.Keywords), joinedPtr(spec.Keywords))
+	changes = appendChange(changes, "repo", ds.Repo, spec.Repo)
+	changes = appendChange(changes, "anonymisationDescription", ds.AnonymisationDescription, spec.AnonymisationDescription)
+	changes = appendChange(changes, "targetUser", ds.TargetUser, spec.TargetUser)
+
+	if len(changes) > 0 {
+		slug := ds.Slug
+		piiTags := "{}"
+		if ds.Datasource.PiiTags != nil {
+			piiTags = *ds.Datasource.PiiTags
+		}
+		pseudoColumns := ds.Datasource.PseudoColumns
+
+		steps = append(steps, &catalogueStep{
+			action: &service.CataloguePlanAction{
+				Action:  service.CatalogueActionUpdate,
+				Kind:    service.CatalogueResourceDataset,
+				Path:    path,
+				ID:      &dsID,
+				Changes: changes,
+			},
+			apply: func(ctx context.Context) error {
+				_, err := s.dataProductsService.UpdateDataset(ctx, user, dsID, service.UpdateDatasetDto{
+					Name:                     spec.Name,
+					Description:              copyStrPtr(spec.Description),
+					Slug:                     &slug,
+					Repo:                     spec.Repo,
+					Pii:                      spec.Pii,
+					Keywords:                 spec.Keywords,
+					AnonymisationDescription: spec.AnonymisationDescription,
+					PiiTags:                  &piiTags,
+					TargetUser:               spec.TargetUser,
+					PseudoColumns:            pseudoColumns,
+				})
+
+				return err
+			},
+		})
+	}
+
+	active := map[string]*service.Access{}
+	for _, a := range ds.Access {
+		if a.Revoked != nil || (a.Expires != nil && a.Expires.Before(time.Now())) {
+			continue
+		}
+
+		active[a.Subject] = a
+	}
+
+	for _, a := range spec.Access {
+		current, ok := active[a.Subject]
+		delete(active, a.Subject)
+
+		if ok && sameExpiry(current.Expires, a.Expires) {
+			continue
+		}
+
+		var currentExpires *time.Time
+		if ok {
+			currentExpires = current.Expires
+		}
+
+		steps = append(steps, s.grantAccess(user, &dsID, path, a, currentExpires))
+	}
+
+	if prune {
+		// The access grants left are the ones not in the spec
+		for _, a := range ds.Access {
+			if active[a.Subject] != a {
+				continue
+			}
+
+			accessID := a.ID
+			steps = append(steps, &catalogueStep{
+				action: &service.CataloguePlanAction{
+					Action:  service.CatalogueActionRevoke,
+					Kind:    service.CatalogueResourceAccess,
+					Path:    path + "/" + a.Subject,
+					ID:      &accessID,
+					Changes: []*service.CatalogueFieldChange{},
+				},
+				apply: func(ctx context.Context) error {
+					return s.accessService.RevokeAccessToDataset(ctx, user, accessID, s.gcpProjectID)
+				},
+			})
+		}
+	}
+
+	return steps, nil
+}
+
+func (s *catalogueApplyService) grantAccess(user *service.User, dsID *uuid.UUID, path string, spec *service.AccessSpec, currentExpires *time.Time) *catalogueStep {
+	subjectType, subject, _ := strings.Cut(spec.Subject, ":")
+
+	changes := []*service.CatalogueFieldChange{}
+	changes = appendChange(changes, "expires", timeToStrPtr(currentExpires), timeToStrPtr(spec.Expires))
+
+	return &catalogueStep{
+		action: &service.CataloguePlanAction{
+			Action:  service.CatalogueActionGrant,
+			Kind:    service.CatalogueResourceAccess,
+			Path:    path + "/" + spec.Subject,
+			Changes: changes,
+		},
+		apply: func(ctx context.Context) error {
+			return s.accessService.GrantAccessToDataset(ctx, user, service.GrantAccessData{
+				DatasetID:   *dsID,
+				Expires:     spec.Expires,
+				Subject:     &subject,
+				Owner:       spec.Owner,
+				SubjectType: &subjectType,
+			}, s.gcpProjectID)
+		},
+	}
+}
+
+// appendChange adds a change to the list of changes if the values differ
+func appendChange(changes []*service.CatalogueFieldChange, field string, old, new *string) []*service.CatalogueFieldChange {
+	o, n := "", ""
+	if old != nil {
+		o = *old
+	}
+
+	if new != nil {
+		n = *new
+	}
+
+	if o == n {
+		return changes
+	}
+
+	return append(changes, &service.CatalogueFieldChange{
+		Field: field,
+		Old:   o,
+		New:   n,
+	})
+}
+
+// escapedPtr returns the value as it is stored, since descriptions are HTML escaped
+func escapedPtr(s *string) *string {
+	if s == nil || *s == "" {
+		return s
+	}
+
+	escaped := html.EscapeString(*s)
+
+	return &escaped
+}
+
+// copyStrPtr copies the value, so it isn't escaped in place by the services
+func copyStrPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+
+	c := *s
+
+	return &c
+}
+
+func strToStrPtr(s string) *string {
+	return &s
+}
+
+func joinedPtr(s []string) *string {
+	sorted := slices.Clone(s)
+	slices.Sort(sorted)
+
+	return strToStrPtr(strings.Join(sorted, ", "))
+}
+
+func uuidToStrPtr(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+
+	return strToStrPtr(id.String())
+}
+
+func timeToStrPtr(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	return strToStrPtr(t.UTC().Format(time.RFC3339))
+}
+
+func sameExpiry(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.Truncate(time.Second).Equal(b.Truncate(time.Second))
+}
+
+func bigQueryString(bq *service.BigQuery) string {
+	return bigQuerySpecString(service.BigQuerySpec{
+		ProjectID:    bq.ProjectID,
+		Dataset:      bq.Dataset,
+		Table:        bq.Table,
+		Scope:        bq.Scope,
+		TablePattern: bq.TablePattern,
+	})
+}
+
+func bigQuerySpecString(bq service.BigQuerySpec) string {
+	if bq.Scope == service.BigQueryScopeDataset {
+		return fmt.Sprintf("%s.%s.%s", bq.ProjectID, bq.Dataset, bq.TablePattern)
+	}
+
+	return fmt.Sprintf("%s.%s.%s", bq.ProjectID, bq.Dataset, bq.Table)
+}
+
+// teamUser is the user a team token acts as, which is a member of the team
+// group only, so the services authorize it as the owner of the team's resources
+func teamUser(teamEmail string) *service.User {
+	return &service.User{
+		Email:        teamEmail,
+		GoogleGroups: service.Groups{{Email: teamEmail}},
+	}
+}
+
+func NewCatalogueApplyService(
+	gcpProjectID string,
+	dataProductStorage service.DataProductsStorage,
+	bigQueryStorage service.BigQueryStorage,
+	dataProductsService service.DataProductsService,
+	accessService service.AccessService,
+) *catalogueApplyService {
+	return &catalogueApplyService{
+		gcpProjectID:        gcpProjectID,
+		dataProductStorage:  dataProductStorage,
+		bigQueryStorage:     bigQueryStorage,
+		dataProductsService: dataProductsService,
+		accessService:       accessService,
+	}
+}
@@ -0,0 +1,523 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+const (
+	dcatLanguage          = "nb"
+	dcatDefaultTitle      = "Datamarkedsplassen"
+	accessRightNonPublic  = "http://publications.europa.eu/resource/authority/access-right/NON_PUBLIC"
+	accessRightRestricted = "http://publications.europa.eu/resource/authority/access-right/RESTRICTED"
+)
+
+var _ service.CatalogueExportService = &catalogueExportService{}
+
+type catalogueExportService struct {
+	catalogueExportStorage service.CatalogueExportStorage
+	dataCatalogueURL       string
+	catalogue              service.DCATCatalogue
+}
+
+func (s *catalogueExportService) ExportDCAT(ctx context.Context, opts service.DCATExportOptions) (*service.DCATExport, error) {
+	const op errs.Op = "catalogueExportService.ExportDCAT"
+
+	if err := opts.Validate(); err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	entries, err := s.catalogueExportStorage.GetCatalogueEntries(ctx, opts.ModifiedSince)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	graph := s.dcatGraph(entries, opts)
+
+	var data []byte
+
+	switch opts.Format {
+	case service.DCATFormatTurtle:
+		data = graph.turtle()
+	default:
+		data, err = graph.jsonLD()
+		if err != nil {
+			return nil, errs.E(errs.Internal, op, err)
+		}
+	}
+
+	return &service.DCATExport{
+		ContentType: opts.Format.ContentType(),
+		Data:        data,
+	}, nil
+}
+
+// dcatGraph maps the catalogue to DCAT-AP, where a dataproduct is a
+// dcat:Dataset and each of its datasets is a dcat:Distribution
+func (s *catalogueExportService) dcatGraph(entries []*service.CatalogueEntry, opts service.DCATExportOptions) *rdfGraph {
+	base := s.baseURL()
+
+	graph := &rdfGraph{
+		prefixes: []rdfPrefix{
+			{"dcat", "http://www.w3.org/ns/dcat#"},
+			{"dct", "http://purl.org/dc/terms/"},
+			{"foaf", "http://xmlns.com/foaf/0.1/"},
+			{"vcard", "http://www.w3.org/2006/vcard/ns#"},
+			{"xsd", "http://www.w3.org/2001/XMLSchema#"},
+			{"nada", base + "/ns#"},
+		},
+	}
+
+	title := s.catalogue.Title
+	if title == "" {
+		title = dcatDefaultTitle
+	}
+
+	catalog := &rdfResource{iri: base + "/api/dcat/catalog", types: []string{"dcat:Catalog"}}
+	catalog.add("dct:title", rdfLangLiteral(title))
+	catalog.add("foaf:homepage", rdfIRI(base))
+
+	if s.catalogue.PublisherName != "" {
+		publisher := &rdfResource{iri: s.catalogue.PublisherURI, types: []string{"foaf:Agent"}}
+		publisher.add("foaf:name", rdfLangLiteral(s.catalogue.PublisherName))
+		catalog.add("dct:publisher", rdfNode(publisher))
+	}
+
+	if s.catalogue.LicenseURI != "" {
+		catalog.add("dct:license", rdfIRI(s.catalogue.LicenseURI))
+	}
+
+	graph.resources = append(graph.resources, catalog)
+
+	var modified time.Time
+
+	for _, e := range entries {
+		dpURL := fmt.Sprintf("%s/dataproduct/%s/%s", base, e.ID, url.PathEscape(e.Slug))
+
+		dataset := &rdfResource{iri: dpURL, types: []string{"dcat:Dataset"}}
+		dataset.add("dct:identifier", rdfLiteral(e.ID.String()))
+		dataset.add("dct:title", rdfLangLiteral(e.Name))
+
+		if e.Description != nil && *e.Description != "" && !opts.Excludes(service.DCATFieldDescription) {
+			dataset.add("dct:description", rdfLangLiteral(*e.Description))
+		}
+
+		dataset.add("dct:issued", rdfDateTime(e.Created))
+		dataset.add("dct:modified", rdfDateTime(e.LastModified))
+		dataset.add("dcat:landingPage", rdfIRI(dpURL))
+
+		if !opts.Excludes(service.DCATFieldPublisher) {
+			dataset.add("dct:publisher", rdfNode(s.ownerAgent(e)))
+		}
+
+		if !opts.Excludes(service.DCATFieldContactPoint) {
+			dataset.add("dcat:contactPoint", rdfNode(s.contactPoint(e)))
+		}
+
+		var distributions []*rdfResource
+
+		keywords := map[string]struct{}{}
+
+		for _, ds := range e.Datasets {
+			if ds.LifecycleStatus == service.LifecycleStatusRetired {
+				continue
+			}
+
+			if opts.ExcludeSensitive && ds.Pii == service.PiiLevelSensitive {
+				continue
+			}
+
+			for _, k := range ds.Keywords {
+				keywords[k] = struct{}{}
+			}
+
+			distributions = append(distributions, s.distribution(dpURL, ds, opts))
+
+			if ds.LastModified.After(modified) {
+				modified = ds.LastModified
+			}
+		}
+
+		if !opts.Excludes(service.DCATFieldKeywords) {
+			dataset.add("dcat:keyword", sortedLangLiterals(keywords)...)
+		}
+
+		for _, d := range distributions {
+			dataset.add("dcat:distribution", rdfIRI(d.iri))
+		}
+
+		catalog.add("dcat:dataset", rdfIRI(dataset.iri))
+
+		graph.resources = append(graph.resources, dataset)
+		graph.resources = append(graph.resources, distributions...)
+
+		if e.LastModified.After(modified) {
+			modified = e.LastModified
+		}
+	}
+
+	if !modified.IsZero() {
+		catalog.add("dct:modified", rdfDateTime(modified))
+	}
+
+	return graph
+}
+
+func (s *catalogueExportService) distribution(dpURL string, ds *service.CatalogueEntryDataset, opts service.DCATExportOptions) *rdfResource {
+	dsURL := fmt.Sprintf("%s/%s", dpURL, ds.ID)
+
+	d := &rdfResource{iri: dsURL, types: []string{"dcat:Distribution"}}
+	d.add("dct:identifier", rdfLiteral(ds.ID.String()))
+	d.add("dct:title", rdfLangLiteral(ds.Name))
+
+	if ds.Description != nil && *ds.Description != "" && !opts.Excludes(service.DCATFieldDescription) {
+		d.add("dct:description", rdfLangLiteral(*ds.Description))
+	}
+
+	d.add("dct:issued", rdfDateTime(ds.Created))
+	d.add("dct:modified", rdfDateTime(ds.LastModified))
+	d.add("dcat:accessURL", rdfIRI(dsURL))
+	d.add("dct:format", rdfLiteral(string(ds.Type)))
+
+	if s.catalogue.LicenseURI != "" {
+		d.add("dct:license", rdfIRI(s.catalogue.LicenseURI))
+	}
+
+	if !opts.Excludes(service.DCATFieldPii) {
+		accessRight := accessRightRestricted
+		if ds.Pii == service.PiiLevelSensitive {
+			accessRight = accessRightNonPublic
+		}
+
+		d.add("dct:accessRights", rdfIRI(accessRight))
+		d.add("nada:piiLevel", rdfLiteral(string(ds.Pii)))
+	}
+
+	return d
+}
+
+// ownerAgent describes the team owning the dataproduct, identified by its
+// page in Teamkatalogen when the dataproduct is connected to a team
+func (s *catalogueExportService) ownerAgent(e *service.CatalogueEntry) *rdfResource {
+	var iri string
+	if e.TeamkatalogenURL != nil {
+		iri = *e.TeamkatalogenURL
+	}
+
+	name := e.Group
+	if e.TeamName != nil && *e.TeamName != "" {
+		name = *e.TeamName
+	}
+
+	agent := &rdfResource{iri: iri, types: []string{"foaf:Organization"}}
+	agent.add("foaf:name", rdfLiteral(name))
+
+	return agent
+}
+
+func (s *catalogueExportService) contactPoint(e *service.CatalogueEntry) *rdfResource {
+	name := e.Group
+	if e.TeamName != nil && *e.TeamName != "" {
+		name = *e.TeamName
+	}
+
+	contact := &rdfResource{types: []string{"vcard:Organization"}}
+	contact.add("vcard:fn", rdfLiteral(name))
+	contact.add("vcard:hasEmail", rdfIRI("mailto:"+e.Group))
+
+	if e.TeamkatalogenURL != nil && *e.TeamkatalogenURL != "" {
+		contact.add("vcard:hasURL", rdfIRI(*e.TeamkatalogenURL))
+	}
+
+	if e.TeamContact != nil && *e.TeamContact != "" {
+		contact.add("vcard:note", rdfLiteral(*e.TeamContact))
+	}
+
+	return contact
+}
+
+func (s *catalogueExportService) baseURL() string {
+	base := strings.TrimSuffix(s.dataCatalogueURL, "/")
+	if !strings.HasPrefix(base, "http://") && !strings.HasPrefix(base, "https://") {
+		base = "https://" + base
+	}
+
+	return base
+}
+
+func sortedLangLiterals(values map[string]struct{}) []rdfTerm {
+	sorted := make([]string, 0, len(values))
+	for v := range values {
+		sorted = append(sorted, v)
+	}
+
+	sort.Strings(sorted)
+
+	terms := make([]rdfTerm, len(sorted))
+	for i, v := range sorted {
+		terms[i] = rdfLangLiteral(v)
+	}
+
+	return terms
+}
+
+type rdfPrefix struct {
+	prefix string
+	iri    string
+}
+
+// rdfGraph is a minimal RDF graph, just enough to serialise the catalogue
+// export as JSON-LD and Turtle. Predicates and types use the prefixes of
+// the graph, while object IRIs are always absolute.
+type rdfGraph struct {
+	prefixes  []rdfPrefix
+	resources []*rdfResource
+}
+
+// rdfResource is a subject with its properties, without an IRI it is a
+// blank node and can only be used as the object of another resource
+type rdfResource struct {
+	iri        string
+	types      []string
+	properties []rdfProperty
+}
+
+type rdfProperty struct {
+	predicate string
+	objects   []rdfTerm
+}
+
+type rdfTerm struct {
+	iri      string
+	value    string
+	datatype string
+	lang     string
+	node     *rdfResource
+}
+
+func (r *rdfResource) add(predicate string, objects ...rdfTerm) {
+	if len(objects) == 0 {
+		return
+	}
+
+	for i, p := range r.properties {
+		if p.predicate == predicate {
+			r.properties[i].objects = append(r.properties[i].objects, objects...)
+			return
+		}
+	}
+
+	r.properties = append(r.properties, rdfProperty{predicate: predicate, objects: objects})
+}
+
+func rdfIRI(iri string) rdfTerm {
+	return rdfTerm{iri: iri}
+}
+
+func rdfLiteral(value string) rdfTerm {
+	return rdfTerm{value: value}
+}
+
+func rdfLangLiteral(value string) rdfTerm {
+	return rdfTerm{value: value, lang: dcatLanguage}
+}
+
+func rdfDateTime(t time.Time) rdfTerm {
+	return rdfTerm{value: t.UTC().Format(time.RFC3339), datatype: "xsd:dateTime"}
+}
+
+func rdfNode(r *rdfResource) rdfTerm {
+	return rdfTerm{node: r}
+}
+
+func (g *rdfGraph) jsonLD() ([]byte, error) {
+	ldContext := map[string]string{}
+	for _, p := range g.prefixes {
+		ldContext[p.prefix] = p.iri
+	}
+
+	resources := make([]map[string]any, len(g.resources))
+	for i, r := range g.resources {
+		resources[i] = r.jsonLD()
+	}
+
+	return json.MarshalIndent(map[string]any{
+		"@context": ldContext,
+		"@graph":   resources,
+	}, "", "  ")
+}
+
+func (r *rdfResource) jsonLD() map[string]any {
+	obj := map[string]any{}
+
+	if r.iri != "" {
+		obj["@id"] = r.iri
+	}
+
+	switch len(r.types) {
+	case 0:
+	case 1:
+		obj["@type"] = r.types[0]
+	default:
+		obj["@type"] = r.types
+	}
+
+	for _, p := range r.properties {
+		if len(p.objects) == 1 {
+			obj[p.predicate] = p.objects[0].jsonLD()
+			continue
+		}
+
+		values := make([]any, len(p.objects))
+		for i, o := range p.objects {
+			values[i] = o.jsonLD()
+		}
+
+		obj[p.predicate] = values
+	}
+
+	return obj
+}
+
+func (t rdfTerm) jsonLD() any {
+	switch {
+	case t.node != nil:
+		return t.node.jsonLD()
+	case t.iri != "":
+		return map[string]string{"@id": t.iri}
+	case t.lang != "":
+		return map[string]string{"@value": t.value, "@language": t.lang}
+	case t.datatype != "":
+		return map[string]string{"@value": t.value, "@type": t.datatype}
+	default:
+		return t.value
+	}
+}
+
+func (g *rdfGraph) turtle() []byte {
+	var b strings.Builder
+
+	for _, p := range g.prefixes {
+		fmt.Fprintf(&b, "@prefix %s: <%s> .\n", p.prefix, turtleIRI(p.iri))
+	}
+
+	// Nested resources with an IRI are written as subjects of their own
+	// after the resources referring to them
+	resources := g.resources
+	for i := 0; i < len(resources); i++ {
+		r := resources[i]
+
+		b.WriteString("\n")
+		b.WriteString(turtleIRIRef(r.iri))
+		b.WriteString("\n")
+		resources = append(resources, r.turtleProperties(&b, "    ")...)
+		b.WriteString(" .\n")
+	}
+
+	return []byte(b.String())
+}
+
+// turtleProperties writes the types and properties of the resource, and
+// returns the nested resources that must be written as subjects of their own
+func (r *rdfResource) turtleProperties(b *strings.Builder, indent string) []*rdfResource {
+	var named []*rdfResource
+
+	lines := make([]string, 0, len(r.properties)+1)
+
+	if len(r.types) > 0 {
+		lines = append(lines, indent+"a "+strings.Join(r.types, ", "))
+	}
+
+	for _, p := range r.properties {
+		objects := make([]string, len(p.objects))
+		for i, o := range p.objects {
+			if o.node != nil && o.node.iri != "" {
+				named = append(named, o.node)
+				objects[i] = turtleIRIRef(o.node.iri)
+
+				continue
+			}
+
+			objects[i] = o.turtle(indent, &named)
+		}
+
+		lines = append(lines, indent+p.predicate+" "+strings.Join(objects, ", "))
+	}
+
+	b.WriteString(strings.Join(lines, " ;\n"))
+
+	return named
+}
+
+func (t rdfTerm) turtle(indent string, named *[]*rdfResource) string {
+	switch {
+	case t.node != nil:
+		var b strings.Builder
+		b.WriteString("[\n")
+		*named = append(*named, t.node.turtleProperties(&b, indent+"    ")...)
+		b.WriteString("\n" + indent + "]")
+
+		return b.String()
+	case t.iri != "":
+		return turtleIRIRef(t.iri)
+	case t.lang != "":
+		return turtleString(t.value) + "@" + t.lang
+	case t.datatype != "":
+		return turtleString(t.value) + "^^" + t.datatype
+	default:
+		return turtleString(t.value)
+	}
+}
+
+func turtleIRIRef(iri string) string {
+	return "<" + turtleIRI(iri) + ">"
+}
+
+// turtleIRI percent encodes the characters that are not allowed in an IRI
+// reference in Turtle
+func turtleIRI(iri string) string {
+	var b strings.Builder
+
+	for _, c := range iri {
+		if c <= 0x20 || strings.ContainsRune("<>\"{}|^`\\", c) {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+
+		b.WriteRune(c)
+	}
+
+	return b.String()
+}
+
+func turtleString(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+	)
+
+	return `"` + r.Replace(s) + `"`
+}
+
+func NewCatalogueExportService(
+	catalogueExportStorage service.CatalogueExportStorage,
+	dataCatalogueURL string,
+	catalogue service.DCATCatalogue,
+) *catalogueExportService {
+	return &catalogueExportService{
+		catalogueExportStorage: catalogueExportStorage,
+		dataCatalogueURL:       dataCatalogueURL,
+		catalogue:              catalogue,
+	}
+}
@@ -0,0 +1,210 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/xlsx"
+)
+
+var _ service.ComplianceService = &complianceService{}
+
+type complianceService struct {
+	complianceStorage service.ComplianceStorage
+	adminGroup        string
+}
+
+func (s *complianceService) GetReport(ctx context.Context, user *service.User, filter service.ComplianceReportFilter) (*service.ComplianceReport, error) {
+	const op errs.Op = "complianceService.GetReport"
+
+	if err := ensureUserInGroup(user, s.adminGroup); err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	datasets, err := s.complianceStorage.GetPersonalDataInventory(ctx, filter)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return &service.ComplianceReport{
+		Generated: time.Now(),
+		Datasets:  datasets,
+	}, nil
+}
+
+func (s *complianceService) ExportReport(ctx context.Context, user *service.User, filter service.ComplianceReportFilter, format service.ComplianceExportFormat) (*service.ComplianceExport, error) {
+	const op errs.Op = "complianceService.ExportReport"
+
+	if format != service.ComplianceExportFormatCSV && format != service.ComplianceExportFormatXLSX {
+		return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("format"), fmt.Errorf("unsupported export format: %s", format))
+	}
+
+	report, err := s.GetReport(ctx, user, filter)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	rows := complianceRows(report)
+
+	var buf bytes.Buffer
+
+	switch format {
+	case service.ComplianceExportFormatXLSX:
+		if err := xlsx.Write(&buf, "Personopplysninger", rows); err != nil {
+			return nil, errs.E(errs.Internal, op, err)
+		}
+
+		return &service.ComplianceExport{
+			ContentType: xlsx.ContentType,
+			Data:        buf.Bytes(),
+		}, nil
+	default:
+		w := csv.NewWriter(&buf)
+		if err := w.WriteAll(escapeFormulas(rows)); err != nil {
+			return nil, errs.E(errs.Internal, op, err)
+		}
+
+		return &service.ComplianceExport{
+			ContentType: "text/csv; charset=utf-8",
+			Data:        buf.Bytes(),
+		}, nil
+	}
+}
+
+// complianceRows flattens the report to one row per active access, datasets
+// without any access get a single row with the access columns left empty
+func complianceRows(report *service.ComplianceReport) [][]string {
+	rows := [][]string{{
+		"product_area", "team", "dataproduct", "dataset", "owner_group", "team_contact", "pii", "pii_tags",
+		"pii_mismatch", "pseudo_columns", "anonymisation_description", "metabase", "subject", "access_owner", "granter",
+		"granted", "expires", "purpose", "purpose_status", "purpose_url",
+	}}
+
+	for _, ds := range report.Datasets {
+		datasetColumns := []string{
+			ptrToString(ds.ProductAreaName),
+			ptrToString(ds.TeamName),
+			ds.DataproductName,
+			ds.DatasetName,
+			ds.OwnerGroup,
+			ptrToString(ds.TeamContact),
+			string(ds.Pii),
+			formatPiiTags(ds.PiiTags),
+			formatBool(ds.PiiMismatch),
+			formatPseudoColumns(ds.PseudoColumns),
+			ptrToString(ds.AnonymisationDescription),
+			formatMetabaseExposure(ds.MetabaseExposure),
+		}
+
+		if len(ds.Accesses) == 0 {
+			rows = append(rows, append(datasetColumns, make([]string, 8)...))
+			continue
+		}
+
+		for _, a := range ds.Accesses {
+			var purpose, purposeStatus, purposeURL string
+			if a.Purpose != nil {
+				purpose = a.Purpose.Name
+				purposeURL = a.Purpose.URL
+			}
+
+			if a.PurposeStatus != nil {
+				purposeStatus = string(*a.PurposeStatus)
+			}
+
+			rows = append(rows, append(slices.Clone(datasetColumns),
+				a.Subject,
+				a.Owner,
+				a.Granter,
+				a.Created.Format(time.RFC3339),
+				formatTimePtr(a.Expires),
+				purpose,
+				purposeStatus,
+				purposeURL,
+			))
+		}
+	}
+
+	return rows
+}
+
+// escapeFormulas prefixes the cells that spreadsheets would evaluate as
+// formulas when opening the CSV, so they are shown as text instead
+func escapeFormulas(rows [][]string) [][]string {
+	escaped := make([][]string, len(rows))
+	for i, row := range rows {
+		escaped[i] = make([]string, len(row))
+		for j, cell := range row {
+			if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+				cell = "'" + cell
+			}
+
+			escaped[i][j] = cell
+		}
+	}
+
+	return escaped
+}
+
+func formatBool(b bool) string {
+	if b {
+		return "yes"
+	}
+
+	return ""
+}
+
+func formatPiiTags(tags map[string]string) string {
+	columns := make([]string, 0, len(tags))
+	for column := range tags {
+		columns = append(columns, column)
+	}
+
+	slices.Sort(columns)
+
+	formatted := make([]string, len(columns))
+	for i, column := range columns {
+		formatted[i] = column + "=" + tags[column]
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
+func formatPseudoColumns(columns []service.PseudoColumn) string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func formatMetabaseExposure(exposure *service.MetabaseExposure) string {
+	if exposure == nil {
+		return ""
+	}
+
+	return string(*exposure)
+}
+
+func ptrToString(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func NewComplianceService(complianceStorage service.ComplianceStorage, adminGroup string) *complianceService {
+	return &complianceService{
+		complianceStorage: complianceStorage,
+		adminGroup:        adminGroup,
+	}
+}
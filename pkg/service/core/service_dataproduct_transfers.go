@@ -0,0 +1,209 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/rs/zerolog"
+)
+
+type dataproductTransferService struct {
+	dataproductTransferStorage service.DataproductTransferStorage
+	dataProductStorage         service.DataProductsStorage
+	storyStorage               service.StoryStorage
+	metabaseService            service.MetabaseService
+	log                        zerolog.Logger
+}
+
+var _ service.DataproductTransferService = &dataproductTransferService{}
+
+func (s *dataproductTransferService) RequestDataproductTransfer(ctx context.Context, user *service.User, input service.NewDataproductTransfer) (*service.DataproductTransfer, error) {
+	const op errs.Op = "dataproductTransferService.RequestDataproductTransfer"
+
+	dp, err := s.dataProductStorage.GetDataproduct(ctx, input.DataproductID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if err := ensureUserInGroup(user, dp.Owner.Group); err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if input.Group == dp.Owner.Group {
+		return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("group"), fmt.Errorf("dataproduct is already owned by %s", input.Group))
+	}
+
+	transfers, err := s.dataproductTransferStorage.GetDataproductTransfersForDataproduct(ctx, dp.ID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	for _, t := range transfers {
+		if t.Status == service.DataproductTransferStatusPending {
+			return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("dataproduct %v already has a pending transfer to %s", dp.ID, t.To.Group))
+		}
+	}
+
+	for _, id := range input.StoryIDs {
+		story, err := s.storyStorage.GetStory(ctx, id)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		if story.Group != dp.Owner.Group {
+			return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("storyIDs"), fmt.Errorf("story %v is not owned by %s", id, dp.Owner.Group))
+		}
+	}
+
+	transfer, err := s.dataproductTransferStorage.CreateDataproductTransfer(ctx, dp, input, user.Email)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return transfer, nil
+}
+
+func (s *dataproductTransferService) GetDataproductTransfersForUser(ctx context.Context, user *service.User) ([]*service.DataproductTransfer, error) {
+	const op errs.Op = "dataproductTransferService.GetDataproductTransfersForUser"
+
+	transfers, err := s.dataproductTransferStorage.GetDataproductTransfersForGroups(ctx, user.GoogleGroups.Emails())
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return transfers, nil
+}
+
+func (s *dataproductTransferService) GetDataproductTransfersForDataproduct(ctx context.Context, id uuid.UUID) ([]*service.DataproductTransfer, error) {
+	const op errs.Op = "dataproductTransferService.GetDataproductTransfersForDataproduct"
+
+	transfers, err := s.dataproductTransferStorage.GetDataproductTransfersForDataproduct(ctx, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return transfers, nil
+}
+
+func (s *dataproductTransferService) AcceptDataproductTransfer(ctx context.Context, user *service.User, id uuid.UUID) (*service.DataproductTransfer, error) {
+	const op errs.Op = "dataproductTransferService.AcceptDataproductTransfer"
+
+	transfer, err := s.getPendingTransfer(ctx, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if err := ensureUserInGroup(user, transfer.To.Group); err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	dp, err := s.dataProductStorage.GetDataproduct(ctx, transfer.DataproductID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	// Metabase is moved before the transfer is stored, so if it fails the
+	// transfer is still pending and can be accepted again
+	err = s.transferMetabase(ctx, dp, transfer.To.Group)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	accepted, err := s.dataproductTransferStorage.AcceptDataproductTransfer(ctx, id, user.Email)
+	if err != nil {
+		if rerr := s.transferMetabase(ctx, dp, transfer.From.Group); rerr != nil {
+			s.log.Error().Err(rerr).Msgf("moving metabase databases of dataproduct %v back to %s", dp.ID, transfer.From.Group)
+		}
+
+		return nil, errs.E(op, err)
+	}
+
+	return accepted, nil
+}
+
+func (s *dataproductTransferService) transferMetabase(ctx context.Context, dp *service.DataproductWithDataset, group string) error {
+	const op errs.Op = "dataproductTransferService.transferMetabase"
+
+	for _, ds := range dp.Datasets {
+		err := s.metabaseService.TransferDataset(ctx, ds.ID, group)
+		if err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *dataproductTransferService) RejectDataproductTransfer(ctx context.Context, user *service.User, id uuid.UUID, input service.RejectDataproductTransfer) (*service.DataproductTransfer, error) {
+	const op errs.Op = "dataproductTransferService.RejectDataproductTransfer"
+
+	transfer, err := s.getPendingTransfer(ctx, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if err := ensureUserInGroup(user, transfer.To.Group); err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	transfer, err = s.dataproductTransferStorage.ResolveDataproductTransfer(ctx, id, service.DataproductTransferStatusRejected, user.Email, input.Reason)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return transfer, nil
+}
+
+func (s *dataproductTransferService) CancelDataproductTransfer(ctx context.Context, user *service.User, id uuid.UUID) (*service.DataproductTransfer, error) {
+	const op errs.Op = "dataproductTransferService.CancelDataproductTransfer"
+
+	transfer, err := s.getPendingTransfer(ctx, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if err := ensureUserInGroup(user, transfer.From.Group); err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	transfer, err = s.dataproductTransferStorage.ResolveDataproductTransfer(ctx, id, service.DataproductTransferStatusCancelled, user.Email, nil)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return transfer, nil
+}
+
+func (s *dataproductTransferService) getPendingTransfer(ctx context.Context, id uuid.UUID) (*service.DataproductTransfer, error) {
+	const op errs.Op = "dataproductTransferService.getPendingTransfer"
+
+	transfer, err := s.dataproductTransferStorage.GetDataproductTransfer(ctx, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if transfer.Status != service.DataproductTransferStatusPending {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("dataproduct transfer %v is %s", id, transfer.Status))
+	}
+
+	return transfer, nil
+}
+
+func NewDataproductTransferService(
+	dataproductTransferStorage service.DataproductTransferStorage,
+	dataProductStorage service.DataProductsStorage,
+	storyStorage service.StoryStorage,
+	metabaseService service.MetabaseService,
+	log zerolog.Logger,
+) *dataproductTransferService {
+	return &dataproductTransferService{
+		dataproductTransferStorage: dataproductTransferStorage,
+		dataProductStorage:         dataProductStorage,
+		storyStorage:               storyStorage,
+		metabaseService:            metabaseService,
+		log:                        log,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"html"
+	"path"
 
 	"github.com/google/uuid"
 	"github.com/navikt/nada-backend/pkg/auth"
@@ -18,6 +19,10 @@ type dataProductsService struct {
 	bigQueryStorage    service.BigQueryStorage
 	bigQueryAPI        service.BigQueryAPI
 	naisConsoleStorage service.NaisConsoleStorage
+	accessStorage      service.AccessStorage
+	metabaseService    service.MetabaseService
+	datasourceStorage  service.DatasourceStorage
+	providers          service.DatasourceProviders
 	allUsersGroup      string
 }
 
@@ -73,6 +78,17 @@ func (s *dataProductsService) GetDatasetsMinimal(ctx context.Context) ([]*servic
 	return datasets, nil
 }
 
+func (s *dataProductsService) GetDatasetsMinimalPage(ctx context.Context, page service.PageRequest) (*service.Page[*service.DatasetMinimal], error) {
+	const op errs.Op = "dataProductsService.GetDatasetsMinimalPage"
+
+	datasets, err := s.dataProductStorage.GetDatasetsMinimalPage(ctx, page)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return datasets, nil
+}
+
 func (s *dataProductsService) CreateDataproduct(ctx context.Context, user *service.User, input service.NewDataproduct) (*service.DataproductMinimal, error) {
 	const op errs.Op = "dataProductsService.CreateDataproduct"
 
@@ -104,6 +120,10 @@ func (s *dataProductsService) UpdateDataproduct(ctx context.Context, user *servi
 		return nil, errs.E(op, err)
 	}
 
+	if err := ensureUnmodified(input.Precondition, dp.ETag()); err != nil {
+		return nil, errs.E(op, err)
+	}
+
 	if input.Description != nil && *input.Description != "" {
 		*input.Description = html.EscapeString(*input.Description)
 	}
@@ -128,7 +148,22 @@ func (s *dataProductsService) DeleteDataproduct(ctx context.Context, user *servi
 		return nil, errs.E(op, err)
 	}
 
-	err = s.dataProductStorage.DeleteDataproduct(ctx, id)
+	// The dataproduct is moved to the recycle bin, so the access and mapping
+	// rows are kept, while the IAM bindings and Metabase databases are
+	// removed. They are re-established if the dataproduct is restored
+	for _, ds := range dp.Datasets {
+		err := s.revokeDatasetAccesses(ctx, ds.ID)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		err = s.metabaseService.DeleteDatabase(ctx, ds.ID)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+	}
+
+	err = s.dataProductStorage.SoftDeleteDataproduct(ctx, id, user.Email)
 	if err != nil {
 		return nil, errs.E(op, err)
 	}
@@ -136,6 +171,33 @@ func (s *dataProductsService) DeleteDataproduct(ctx context.Context, user *servi
 	return dp, nil
 }
 
+func (s *dataProductsService) revokeDatasetAccesses(ctx context.Context, id uuid.UUID) error {
+	const op errs.Op = "dataProductsService.revokeDatasetAccesses"
+
+	ref, provider, err := datasetDatasource(ctx, s.datasourceStorage, s.providers, id)
+	if err != nil {
+		if errs.KindIs(errs.NotExist, err) {
+			return nil
+		}
+
+		return errs.E(op, err)
+	}
+
+	accesses, err := s.accessStorage.ListActiveAccessToDataset(ctx, id)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	for _, a := range accesses {
+		err := provider.Revoke(ctx, *ref, a.Subject)
+		if err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	return nil
+}
+
 func (s *dataProductsService) CreateDataset(ctx context.Context, user *service.User, input service.NewDataset) (*service.Dataset, error) {
 	const op errs.Op = "dataProductsService.CreateDataset"
 
@@ -148,9 +210,32 @@ func (s *dataProductsService) CreateDataset(ctx context.Context, user *service.U
 		return nil, errs.E(op, err)
 	}
 
+	if input.GCS != nil {
+		ds, err := s.createGCSDataset(ctx, user, dp.Owner.Group, input)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		return ds, nil
+	}
+
+	if input.BigQuery.Scope == service.BigQueryScopeDataset {
+		ds, err := s.createDatasetScopedDataset(ctx, user, dp.Owner.Group, input)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		return ds, nil
+	}
+
 	var referenceDatasource *service.NewBigQuery
 	var pseudoBigQuery *service.NewBigQuery
 	if len(input.PseudoColumns) > 0 {
+		err := s.validatePseudoColumns(ctx, input.BigQuery.ProjectID, input.BigQuery.Dataset, input.BigQuery.Table, input.PseudoColumns)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
 		projectID, datasetID, tableID, err := s.bigQueryAPI.CreatePseudonymisedView(ctx, input.BigQuery.ProjectID,
 			input.BigQuery.Dataset, input.BigQuery.Table, input.PseudoColumns)
 		if err != nil {
@@ -182,7 +267,141 @@ func (s *dataProductsService) CreateDataset(ctx context.Context, user *service.U
 	}
 
 	if pseudoBigQuery == nil && updatedInput.GrantAllUsers != nil && *updatedInput.GrantAllUsers {
-		if err := s.bigQueryAPI.Grant(ctx, updatedInput.BigQuery.ProjectID, updatedInput.BigQuery.Dataset, updatedInput.BigQuery.Table, s.allUsersGroup); err != nil {
+		provider, err := datasourceProvider(s.providers, service.DatasourceTypeBigQuery)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		if err := provider.Grant(ctx, updatedInput.BigQuery.Ref(), s.allUsersGroup); err != nil {
+			return nil, errs.E(op, err)
+		}
+	}
+
+	return ds, nil
+}
+
+func (s *dataProductsService) createGCSDataset(ctx context.Context, user *service.User, group string, input service.NewDataset) (*service.Dataset, error) {
+	const op errs.Op = "dataProductsService.createGCSDataset"
+
+	if err := input.GCS.Validate(); err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	if len(input.PseudoColumns) > 0 {
+		return nil, errs.E(errs.InvalidRequest, op, errs.Str("pseudonymisation is only supported for BigQuery datasources"))
+	}
+
+	if err := s.ensureGroupOwnsGCPProject(ctx, group, input.GCS.ProjectID); err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	provider, err := datasourceProvider(s.providers, service.DatasourceTypeGCS)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	ref := input.GCS.Ref()
+
+	exists, err := provider.Exists(ctx, ref)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if !exists {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("bucket %s does not exist", input.GCS.Bucket))
+	}
+
+	meta, err := provider.Metadata(ctx, ref)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if input.Description != nil && *input.Description != "" {
+		*input.Description = html.EscapeString(*input.Description)
+	}
+
+	ds, err := s.dataProductStorage.CreateGCSDataset(ctx, input, *meta, user)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if input.GrantAllUsers != nil && *input.GrantAllUsers {
+		if err := provider.Grant(ctx, ref, s.allUsersGroup); err != nil {
+			return nil, errs.E(op, err)
+		}
+	}
+
+	return ds, nil
+}
+
+// createDatasetScopedDataset registers a whole BigQuery dataset, optionally
+// limited to the tables matching a pattern, as a single dataset.
+func (s *dataProductsService) createDatasetScopedDataset(ctx context.Context, user *service.User, group string, input service.NewDataset) (*service.Dataset, error) {
+	const op errs.Op = "dataProductsService.createDatasetScopedDataset"
+
+	if input.BigQuery.TablePattern != "" {
+		if _, err := path.Match(input.BigQuery.TablePattern, ""); err != nil {
+			return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("invalid table pattern %s: %w", input.BigQuery.TablePattern, err))
+		}
+	}
+
+	if len(input.PseudoColumns) > 0 {
+		return nil, errs.E(errs.InvalidRequest, op, errs.Str("pseudonymisation is not supported for dataset scoped datasources"))
+	}
+
+	if err := s.ensureGroupOwnsGCPProject(ctx, group, input.BigQuery.ProjectID); err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	provider, err := datasourceProvider(s.providers, service.DatasourceTypeBigQuery)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	input.BigQuery.Table = ""
+	ref := input.BigQuery.Ref()
+
+	exists, err := provider.Exists(ctx, ref)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if !exists {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("dataset %s.%s does not exist", input.BigQuery.ProjectID, input.BigQuery.Dataset))
+	}
+
+	meta, err := provider.Metadata(ctx, ref)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if len(meta.Tables) == 0 {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("no tables in %s.%s match the pattern %q", input.BigQuery.ProjectID, input.BigQuery.Dataset, input.BigQuery.TablePattern))
+	}
+
+	for _, t := range meta.Tables {
+		switch t.TableType {
+		case service.ViewTable, service.MaterializedView:
+			err := s.bigQueryAPI.AddToAuthorizedViews(ctx, ref.ProjectID, ref.Dataset, ref.ProjectID, ref.Dataset, t.Name)
+			if err != nil {
+				return nil, errs.E(op, err)
+			}
+		}
+	}
+
+	input.Metadata = meta.BigqueryMetadata()
+
+	if input.Description != nil && *input.Description != "" {
+		*input.Description = html.EscapeString(*input.Description)
+	}
+
+	ds, err := s.dataProductStorage.CreateDataset(ctx, input, nil, user)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if input.GrantAllUsers != nil && *input.GrantAllUsers {
+		if err := provider.Grant(ctx, ref, s.allUsersGroup); err != nil {
 			return nil, errs.E(op, err)
 		}
 	}
@@ -234,11 +453,23 @@ func (s *dataProductsService) ensureGroupOwnsGCPProject(ctx context.Context, gro
 func (s *dataProductsService) prepareBigQuery(ctx context.Context, srcProject, srcDataset, sinkProject, sinkDataset, sinkTable string) (*service.BigqueryMetadata, error) {
 	const op errs.Op = "dataProductsService.prepareBigQuery"
 
-	metadata, err := s.bigQueryAPI.TableMetadata(ctx, sinkProject, sinkDataset, sinkTable)
+	provider, err := datasourceProvider(s.providers, service.DatasourceTypeBigQuery)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	meta, err := provider.Metadata(ctx, service.DatasourceRef{
+		Type:      service.DatasourceTypeBigQuery,
+		ProjectID: sinkProject,
+		Dataset:   sinkDataset,
+		Table:     sinkTable,
+	})
 	if err != nil {
 		return nil, errs.E(op, err)
 	}
 
+	metadata := meta.BigqueryMetadata()
+
 	switch metadata.TableType {
 	case service.RegularTable:
 	case service.ViewTable:
@@ -300,6 +531,10 @@ func (s *dataProductsService) UpdateDataset(ctx context.Context, user *service.U
 		return "", errs.E(op, err)
 	}
 
+	if err := ensureUnmodified(input.Precondition, ds.ETag()); err != nil {
+		return "", errs.E(op, err)
+	}
+
 	if input.Description != nil && *input.Description != "" {
 		*input.Description = html.EscapeString(*input.Description)
 	}
@@ -329,6 +564,11 @@ func (s *dataProductsService) UpdateDataset(ctx context.Context, user *service.U
 			return "", errs.E(op, err)
 		}
 
+		err = s.validatePseudoColumns(ctx, referenceDatasource.ProjectID, referenceDatasource.Dataset, referenceDatasource.Table, input.PseudoColumns)
+		if err != nil {
+			return "", errs.E(op, err)
+		}
+
 		_, _, _, err = s.bigQueryAPI.CreatePseudonymisedView(ctx, referenceDatasource.ProjectID,
 			referenceDatasource.Dataset, referenceDatasource.Table, input.PseudoColumns)
 		if err != nil {
@@ -357,11 +597,44 @@ func (s *dataProductsService) UpdateDataset(ctx context.Context, user *service.U
 	return updatedID, nil
 }
 
+// ensureUnmodified fails early if an update is based on an old version of the
+// resource, the update itself is also conditional in case of concurrent updates
+func ensureUnmodified(p service.Precondition, etag string) error {
+	const op errs.Op = "ensureUnmodified"
+
+	if p.IfMatch != nil && service.ETag(*p.IfMatch) != etag {
+		return errs.E(errs.PreconditionFailed, op, fmt.Errorf("the resource has been modified, the current version is %s", etag))
+	}
+
+	return nil
+}
+
+// validatePseudoColumns checks the pseudo columns against the current schema of the table
+func (s *dataProductsService) validatePseudoColumns(ctx context.Context, projectID, datasetID, tableID string, columns []service.PseudoColumn) error {
+	const op errs.Op = "dataProductsService.validatePseudoColumns"
+
+	metadata, err := s.bigQueryAPI.TableMetadata(ctx, projectID, datasetID, tableID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	err = service.ValidatePseudoColumns(columns, metadata.Schema.Columns)
+	if err != nil {
+		return errs.E(errs.InvalidRequest, op, err, errs.Parameter("pseudoColumns"))
+	}
+
+	return nil
+}
+
 func NewDataProductsService(
 	dataProductStorage service.DataProductsStorage,
 	bigQueryStorage service.BigQueryStorage,
 	bigQueryAPI service.BigQueryAPI,
 	naisConsoleStorage service.NaisConsoleStorage,
+	accessStorage service.AccessStorage,
+	metabaseService service.MetabaseService,
+	datasourceStorage service.DatasourceStorage,
+	providers service.DatasourceProviders,
 	allUsersGroup string,
 ) *dataProductsService {
 	return &dataProductsService{
@@ -369,6 +642,10 @@ func NewDataProductsService(
 		bigQueryStorage:    bigQueryStorage,
 		bigQueryAPI:        bigQueryAPI,
 		naisConsoleStorage: naisConsoleStorage,
+		accessStorage:      accessStorage,
+		metabaseService:    metabaseService,
+		datasourceStorage:  datasourceStorage,
+		providers:          providers,
 		allUsersGroup:      allUsersGroup,
 	}
 }
@@ -0,0 +1,257 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+var _ service.DatasetPreviewService = &datasetPreviewService{}
+
+type datasetPreviewService struct {
+	datasetPreviewStorage service.DatasetPreviewStorage
+	dataProductStorage    service.DataProductsStorage
+	bigQueryStorage       service.BigQueryStorage
+	accessStorage         service.AccessStorage
+	bigQueryAPI           service.BigQueryAPI
+}
+
+func (s *datasetPreviewService) GetDatasetPreview(ctx context.Context, user *service.User, datasetID uuid.UUID, limit int) (*service.DatasetPreview, error) {
+	const op errs.Op = "datasetPreviewService.GetDatasetPreview"
+
+	if limit <= 0 {
+		limit = service.DatasetPreviewDefaultLimit
+	}
+
+	limit = min(limit, service.DatasetPreviewMaxLimit)
+
+	ds, err := s.dataProductStorage.GetDataset(ctx, datasetID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	datasource, err := s.bigQueryStorage.GetBigqueryDatasource(ctx, ds.ID, false)
+	if err != nil {
+		if errs.KindIs(errs.NotExist, err) {
+			return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("dataset %s does not have a BigQuery datasource", ds.ID))
+		}
+
+		return nil, errs.E(op, err)
+	}
+
+	hasAccess, err := s.hasAccess(ctx, user, ds)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if !hasAccess {
+		return syntheticPreview(ds.ID, datasource.Schema, limit, "user does not have access to the dataset"), nil
+	}
+
+	if ds.Pii == service.PiiLevelSensitive {
+		enabled, err := s.previewEnabled(ctx, ds.ID)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		if !enabled {
+			return syntheticPreview(ds.ID, datasource.Schema, limit, "the owner has not enabled previews of the dataset, it contains sensitive personal data"), nil
+		}
+	}
+
+	if datasource.Scope == service.BigQueryScopeDataset {
+		return syntheticPreview(ds.ID, datasource.Schema, limit, "previews of dataset scoped datasources are not supported"), nil
+	}
+
+	// The rows of a pseudonymised dataset are read from the table the view
+	// was created from, with the pseudonymised columns masked
+	source := datasource
+	if len(datasource.PseudoColumns) > 0 {
+		source, err = s.bigQueryStorage.GetBigqueryDatasource(ctx, ds.ID, true)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+	}
+
+	if source.TableType != service.RegularTable {
+		return syntheticPreview(ds.ID, datasource.Schema, limit, fmt.Sprintf("previews of tables of type %s are not supported", source.TableType)), nil
+	}
+
+	table, err := s.bigQueryAPI.TableRows(ctx, source.ProjectID, source.Dataset, source.Table, limit)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	masked := []string{}
+	for _, c := range datasource.PseudoColumns {
+		masked = append(masked, c.Name)
+	}
+
+	rows := make([]map[string]any, len(table.Rows))
+	for i, values := range table.Rows {
+		row := map[string]any{}
+
+		for j, c := range table.Schema {
+			if j >= len(values) {
+				break
+			}
+
+			row[c.Name] = values[j]
+		}
+
+		for _, name := range masked {
+			if _, ok := row[name]; ok {
+				row[name] = nil
+			}
+		}
+
+		rows[i] = row
+	}
+
+	return &service.DatasetPreview{
+		DatasetID:     ds.ID,
+		Kind:          service.DatasetPreviewKindSample,
+		Schema:        table.Schema,
+		Rows:          rows,
+		MaskedColumns: masked,
+	}, nil
+}
+
+// hasAccess returns true if the user is a member of the owner group, or
+// has an active access to the dataset either personally or through a group
+func (s *datasetPreviewService) hasAccess(ctx context.Context, user *service.User, ds *service.Dataset) (bool, error) {
+	const op errs.Op = "datasetPreviewService.hasAccess"
+
+	ok, err := hasDatasetAccess(ctx, s.dataProductStorage, s.accessStorage, user, ds)
+	if err != nil {
+		return false, errs.E(op, err)
+	}
+
+	return ok, nil
+}
+
+func (s *datasetPreviewService) previewEnabled(ctx context.Context, datasetID uuid.UUID) (bool, error) {
+	const op errs.Op = "datasetPreviewService.previewEnabled"
+
+	settings, err := s.datasetPreviewStorage.GetDatasetPreviewSettings(ctx, datasetID)
+	if err != nil {
+		if errs.KindIs(errs.NotExist, err) {
+			return false, nil
+		}
+
+		return false, errs.E(op, err)
+	}
+
+	return settings.Enabled, nil
+}
+
+func (s *datasetPreviewService) UpdateDatasetPreviewSettings(ctx context.Context, user *service.User, datasetID uuid.UUID, input service.UpdateDatasetPreviewSettings) (*service.DatasetPreviewSettings, error) {
+	const op errs.Op = "datasetPreviewService.UpdateDatasetPreviewSettings"
+
+	if err := input.Validate(); err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	ds, err := s.dataProductStorage.GetDataset(ctx, datasetID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	dp, err := s.dataProductStorage.GetDataproduct(ctx, ds.DataproductID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if err := ensureUserInGroup(user, dp.Owner.Group); err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	settings, err := s.datasetPreviewStorage.UpdateDatasetPreviewSettings(ctx, ds.ID, *input.Enabled, user.Email)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return settings, nil
+}
+
+func syntheticPreview(datasetID uuid.UUID, schema []*service.BigqueryColumn, limit int, reason string) *service.DatasetPreview {
+	rows := make([]map[string]any, limit)
+	for i := range rows {
+		row := map[string]any{}
+
+		for _, c := range schema {
+			value := syntheticValue(c, i)
+			if strings.EqualFold(c.Mode, "REPEATED") && value != nil {
+				value = []any{value}
+			}
+
+			row[c.Name] = value
+		}
+
+		rows[i] = row
+	}
+
+	return &service.DatasetPreview{
+		DatasetID:     datasetID,
+		Kind:          service.DatasetPreviewKindSynthetic,
+		Reason:        &reason,
+		Schema:        schema,
+		Rows:          rows,
+		MaskedColumns: []string{},
+	}
+}
+
+// syntheticValue returns an example value for the type of the column, the
+// values only depend on the column and the row number, and never on the
+// contents of the table
+func syntheticValue(column *service.BigqueryColumn, row int) any {
+	base := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC).AddDate(0, 0, row)
+
+	switch strings.ToUpper(column.Type) {
+	case "STRING":
+		return fmt.Sprintf("%s_%d", column.Name, row+1)
+	case "INTEGER", "INT64":
+		return row + 1
+	case "FLOAT", "FLOAT64", "NUMERIC", "BIGNUMERIC":
+		return float64(row) + 0.5
+	case "BOOLEAN", "BOOL":
+		return row%2 == 0
+	case "DATE":
+		return base.Format(time.DateOnly)
+	case "DATETIME":
+		return base.Format("2006-01-02T15:04:05")
+	case "TIMESTAMP":
+		return base.Format(time.RFC3339)
+	case "TIME":
+		return base.Format(time.TimeOnly)
+	case "BYTES":
+		return "ZXhhbXBsZQ=="
+	case "GEOGRAPHY":
+		return "POINT(10.75 59.91)"
+	case "JSON":
+		return map[string]any{}
+	default:
+		return nil
+	}
+}
+
+func NewDatasetPreviewService(
+	datasetPreviewStorage service.DatasetPreviewStorage,
+	dataProductStorage service.DataProductsStorage,
+	bigQueryStorage service.BigQueryStorage,
+	accessStorage service.AccessStorage,
+	bigQueryAPI service.BigQueryAPI,
+) *datasetPreviewService {
+	return &datasetPreviewService{
+		datasetPreviewStorage: datasetPreviewStorage,
+		dataProductStorage:    dataProductStorage,
+		bigQueryStorage:       bigQueryStorage,
+		accessStorage:         accessStorage,
+		bigQueryAPI:           bigQueryAPI,
+	}
+}
@@ -0,0 +1,316 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+var _ service.DbtService = &dbtService{}
+
+type dbtService struct {
+	dbtStorage          service.DbtStorage
+	dataProductStorage  service.DataProductsStorage
+	bigQueryStorage     service.BigQueryStorage
+	dataProductsService service.DataProductsService
+}
+
+func (s *dbtService) ImportManifest(ctx context.Context, teamEmail string, opts service.DbtImportOptions, manifest *service.DbtManifest) (*service.DbtImportReport, error) {
+	const op errs.Op = "dbtService.ImportManifest"
+
+	if err := manifest.Validate(); err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	if err := opts.Validate(); err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	if opts.CreateInDataproduct != nil {
+		dp, err := s.dataProductStorage.GetDataproduct(ctx, *opts.CreateInDataproduct)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		if dp.Owner.Group != teamEmail {
+			return nil, errs.E(errs.Unauthorized, op, fmt.Errorf("dataproduct %s is not owned by %s", dp.ID, teamEmail))
+		}
+	}
+
+	models := manifest.Models()
+
+	var projectIDs []string
+	seen := map[string]bool{}
+	for _, m := range models {
+		if !seen[m.Database] {
+			seen[m.Database] = true
+			projectIDs = append(projectIDs, m.Database)
+		}
+	}
+
+	candidates, err := s.dbtStorage.GetDbtImportCandidates(ctx, projectIDs)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	byTable := map[string][]*service.DbtImportCandidate{}
+	for _, c := range candidates {
+		byTable[c.Key()] = append(byTable[c.Key()], c)
+	}
+
+	report := &service.DbtImportReport{
+		DryRun: opts.DryRun,
+		Models: []*service.DbtModelResult{},
+	}
+
+	for _, m := range models {
+		matches := byTable[fmt.Sprintf("%s.%s.%s", m.Database, m.Schema, m.Table())]
+
+		// The models are imported one by one, so a failure is reported
+		// for the model and the import continues with the next one
+		if len(matches) == 0 {
+			result, err := s.createDataset(ctx, teamEmail, opts, m)
+			if err != nil {
+				markDbtModelFailed(result, err)
+			}
+
+			report.Models = append(report.Models, result)
+
+			continue
+		}
+
+		for _, c := range matches {
+			result, err := s.updateDataset(ctx, teamEmail, opts, m, c)
+			if err != nil {
+				markDbtModelFailed(result, err)
+			}
+
+			report.Models = append(report.Models, result)
+		}
+	}
+
+	return report, nil
+}
+
+func (s *dbtService) updateDataset(ctx context.Context, teamEmail string, opts service.DbtImportOptions, model *service.DbtNode, c *service.DbtImportCandidate) (*service.DbtModelResult, error) {
+	const op errs.Op = "dbtService.updateDataset"
+
+	result := newDbtModelResult(model)
+	result.DatasetID = &c.DatasetID
+
+	if c.Group != teamEmail {
+		result.Action = service.DbtImportActionSkipped
+		result.Reason = fmt.Sprintf("dataset is owned by %s", c.Group)
+
+		return result, nil
+	}
+
+	update, changes := dbtDatasetUpdate(model, c.Description, c.Keywords, c.Schema)
+	update.DatasetID = c.DatasetID
+	update.UpdatedBy = teamEmail
+	result.Changes = changes
+
+	if len(changes) == 0 {
+		result.Action = service.DbtImportActionUnchanged
+
+		return result, nil
+	}
+
+	result.Action = service.DbtImportActionUpdated
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	if err := s.dbtStorage.UpdateDatasetFromDbt(ctx, update); err != nil {
+		return result, errs.E(op, err)
+	}
+
+	return result, nil
+}
+
+func (s *dbtService) createDataset(ctx context.Context, teamEmail string, opts service.DbtImportOptions, model *service.DbtNode) (*service.DbtModelResult, error) {
+	const op errs.Op = "dbtService.createDataset"
+
+	result := newDbtModelResult(model)
+
+	if opts.CreateInDataproduct == nil {
+		result.Action = service.DbtImportActionUnmatched
+		result.Reason = "no dataset in the catalogue for the table"
+
+		return result, nil
+	}
+
+	result.Action = service.DbtImportActionCreated
+
+	_, changes := dbtDatasetUpdate(model, nil, nil, nil)
+	result.Changes = changes
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	var description *string
+	if d := strings.TrimSpace(model.Description); d != "" {
+		description = &d
+	}
+
+	ds, err := s.dataProductsService.CreateDataset(ctx, teamUser(teamEmail), service.NewDataset{
+		DataproductID: *opts.CreateInDataproduct,
+		Name:          model.Name,
+		Description:   description,
+		Pii:           opts.Pii,
+		Keywords:      append([]string{}, model.Tags...),
+		BigQuery: service.NewBigQuery{
+			ProjectID: model.Database,
+			Dataset:   model.Schema,
+			Table:     model.Table(),
+		},
+	})
+	if err != nil {
+		return result, errs.E(op, err)
+	}
+
+	result.DatasetID = &ds.ID
+
+	bq, err := s.bigQueryStorage.GetBigqueryDatasource(ctx, ds.ID, false)
+	if err != nil {
+		return result, errs.E(op, err)
+	}
+
+	update, _ := dbtDatasetUpdate(model, ds.Description, ds.Keywords, bq.Schema)
+	update.DatasetID = ds.ID
+	update.UpdatedBy = teamEmail
+
+	if len(update.ColumnDescriptions) > 0 {
+		if err := s.dbtStorage.UpdateDatasetFromDbt(ctx, update); err != nil {
+			return result, errs.E(op, err)
+		}
+	}
+
+	return result, nil
+}
+
+// dbtDatasetUpdate merges the metadata of the model into the current metadata of
+// a dataset. The description is replaced, the tags are added to the keywords and the
+// column descriptions are set for the columns in the schema.
+func dbtDatasetUpdate(model *service.DbtNode, description *string, keywords []string, schema []*service.BigqueryColumn) (service.DbtDatasetUpdate, []*service.DbtFieldChange) {
+	changes := []*service.DbtFieldChange{}
+
+	update := service.DbtDatasetUpdate{
+		Description:        description,
+		Keywords:           append([]string{}, keywords...),
+		ColumnDescriptions: map[string]string{},
+	}
+
+	if d := strings.TrimSpace(model.Description); d != "" {
+		escaped := html.EscapeString(d)
+		if description == nil || escaped != *description {
+			old := ""
+			if description != nil {
+				old = *description
+			}
+
+			changes = append(changes, &service.DbtFieldChange{
+				Field: "description",
+				Old:   old,
+				New:   escaped,
+			})
+			update.Description = &escaped
+		}
+	}
+
+	existing := map[string]bool{}
+	for _, k := range keywords {
+		existing[k] = true
+	}
+
+	for _, tag := range model.Tags {
+		if !existing[tag] {
+			existing[tag] = true
+			update.Keywords = append(update.Keywords, tag)
+		}
+	}
+
+	if len(update.Keywords) != len(keywords) {
+		changes = append(changes, &service.DbtFieldChange{
+			Field: "keywords",
+			Old:   strings.Join(keywords, ", "),
+			New:   strings.Join(update.Keywords, ", "),
+		})
+	}
+
+	columns := map[string]string{}
+	for name, c := range model.Columns {
+		if c == nil || strings.TrimSpace(c.Description) == "" {
+			continue
+		}
+
+		columns[strings.ToLower(name)] = strings.TrimSpace(c.Description)
+	}
+
+	for _, c := range schema {
+		// Column names are case-insensitive in BigQuery
+		d, ok := columns[strings.ToLower(c.Name)]
+		if !ok || d == c.Description {
+			continue
+		}
+
+		changes = append(changes, &service.DbtFieldChange{
+			Field: "columns." + c.Name,
+			Old:   c.Description,
+			New:   d,
+		})
+
+		update.ColumnDescriptions[c.Name] = d
+	}
+
+	if len(update.ColumnDescriptions) > 0 {
+		update.Schema = make([]*service.BigqueryColumn, len(schema))
+		for i, c := range schema {
+			column := *c
+			if d, ok := update.ColumnDescriptions[c.Name]; ok {
+				column.Description = d
+			}
+
+			update.Schema[i] = &column
+		}
+	}
+
+	return update, changes
+}
+
+// markDbtModelFailed reports the error for the model, the changes are kept
+// to tell what the import was about to do
+func markDbtModelFailed(result *service.DbtModelResult, err error) {
+	result.Action = service.DbtImportActionFailed
+	result.Reason = err.Error()
+}
+
+func newDbtModelResult(model *service.DbtNode) *service.DbtModelResult {
+	return &service.DbtModelResult{
+		Model:     model.UniqueID,
+		ProjectID: model.Database,
+		Dataset:   model.Schema,
+		Table:     model.Table(),
+		Changes:   []*service.DbtFieldChange{},
+	}
+}
+
+func NewDbtService(
+	dbtStorage service.DbtStorage,
+	dataProductStorage service.DataProductsStorage,
+	bigQueryStorage service.BigQueryStorage,
+	dataProductsService service.DataProductsService,
+) *dbtService {
+	return &dbtService{
+		dbtStorage:          dbtStorage,
+		dataProductStorage:  dataProductStorage,
+		bigQueryStorage:     bigQueryStorage,
+		dataProductsService: dataProductsService,
+	}
+}
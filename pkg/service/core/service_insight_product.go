@@ -27,7 +27,7 @@ func (s *insightProductService) DeleteInsightProduct(ctx context.Context, user *
 		return nil, errs.E(errs.Unauthorized, op, errs.UserName(user.Email), fmt.Errorf("user not authorized to delete product"))
 	}
 
-	err = s.insightProductStorage.DeleteInsightProduct(ctx, id)
+	err = s.insightProductStorage.SoftDeleteInsightProduct(ctx, id, user.Email)
 	if err != nil {
 		return nil, errs.E(op, err)
 	}
@@ -47,6 +47,10 @@ func (s *insightProductService) UpdateInsightProduct(ctx context.Context, user *
 		return nil, errs.E(errs.Unauthorized, op, errs.UserName(user.Email), fmt.Errorf("user not authorized to update product"))
 	}
 
+	if err := ensureUnmodified(input.Precondition, existing.ETag()); err != nil {
+		return nil, errs.E(op, err)
+	}
+
 	productSQL, err := s.insightProductStorage.UpdateInsightProduct(ctx, id, input)
 	if err != nil {
 		return nil, errs.E(op, err)
@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/navikt/nada-backend/pkg/errs"
@@ -15,6 +16,8 @@ type joinableViewsService struct {
 	dataProductStorage   service.DataProductsStorage
 	bigQueryAPI          service.BigQueryAPI
 	bigQueryStorage      service.BigQueryStorage
+	centralDataProject   string
+	policyService        service.PolicyService
 }
 
 var _ service.JoinableViewsService = &joinableViewsService{}
@@ -52,6 +55,180 @@ func (s *joinableViewsService) SetJoinableViewDeleted(ctx context.Context, id uu
 	return nil
 }
 
+func (s *joinableViewsService) SetJoinableViewDatasourceViewDeleted(ctx context.Context, joinableViewID, datasourceID uuid.UUID) error {
+	const op = "joinableViewsService.SetJoinableViewDatasourceViewDeleted"
+
+	err := s.joinableViewsStorage.SetJoinableViewDatasourceViewDeleted(ctx, joinableViewID, datasourceID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+func (s *joinableViewsService) GetJoinableViewShares(ctx context.Context, id uuid.UUID) ([]string, error) {
+	const op = "joinableViewsService.GetJoinableViewShares"
+
+	groups, err := s.joinableViewsStorage.GetJoinableViewShares(ctx, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return groups, nil
+}
+
+// getOwnedJoinableView returns the joinable view set if it is owned by the user and not deleted
+func (s *joinableViewsService) getOwnedJoinableView(ctx context.Context, user *service.User, id uuid.UUID) (*service.JoinableViewMetadata, error) {
+	const op = "joinableViewsService.getOwnedJoinableView"
+
+	jv, err := s.joinableViewsStorage.GetJoinableViewMetadata(ctx, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if jv.Owner != user.Email {
+		return nil, errs.E(errs.Unauthorized, op, errs.UserName(user.Email), fmt.Errorf("user is not the owner of the joinable views"))
+	}
+
+	if jv.Deleted != nil {
+		return nil, errs.E(errs.NotExist, op, fmt.Errorf("joinable views %v have been deleted", id))
+	}
+
+	return jv, nil
+}
+
+func (s *joinableViewsService) DeleteJoinableView(ctx context.Context, user *service.User, id uuid.UUID) error {
+	const op = "joinableViewsService.DeleteJoinableView"
+
+	jv, err := s.getOwnedJoinableView(ctx, user, id)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	err = s.deleteJoinableView(ctx, jv)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+func (s *joinableViewsService) deleteJoinableView(ctx context.Context, jv *service.JoinableViewMetadata) error {
+	const op = "joinableViewsService.deleteJoinableView"
+
+	err := s.bigQueryAPI.DeleteJoinableDataset(ctx, jv.Name)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	err = s.joinableViewsStorage.SetJoinableViewDeleted(ctx, jv.ID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+func (s *joinableViewsService) ExtendJoinableView(ctx context.Context, user *service.User, id uuid.UUID, input service.ExtendJoinableView) (*service.JoinableView, error) {
+	const op = "joinableViewsService.ExtendJoinableView"
+
+	jv, err := s.getOwnedJoinableView(ctx, user, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if jv.Expires != nil && jv.Expires.Before(time.Now()) {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("joinable views %v have already expired", id))
+	}
+
+	err = s.joinableViewsStorage.SetJoinableViewExpires(ctx, jv.ID, &input.Expires)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return &service.JoinableView{
+		ID:      jv.ID,
+		Name:    jv.Name,
+		Created: jv.Created,
+		Expires: &input.Expires,
+	}, nil
+}
+
+func (s *joinableViewsService) ShareJoinableView(ctx context.Context, user *service.User, id uuid.UUID, input service.ShareJoinableView) error {
+	const op = "joinableViewsService.ShareJoinableView"
+
+	jv, err := s.getOwnedJoinableView(ctx, user, id)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	datasets, err := s.joinableViewsStorage.GetJoinableViewWithDataset(ctx, jv.ID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	subject := service.SubjectTypeGroup + ":" + input.Group
+
+	// The group must be allowed to read all the datasets, otherwise
+	// the joinable views would leak the pseudonymised data
+	for _, ds := range datasets {
+		if ds.Deleted != nil || ds.Group == input.Group {
+			continue
+		}
+
+		accesses, err := s.accessStorage.ListActiveAccessToDataset(ctx, ds.DatasetID.UUID)
+		if err != nil {
+			return errs.E(op, err)
+		}
+
+		if !service.HasSubject(accesses, subject) {
+			return errs.E(errs.Unauthorized, op, fmt.Errorf("group %v does not have access to dataset %v", input.Group, ds.DatasetID.UUID))
+		}
+	}
+
+	for _, ds := range datasets {
+		if ds.Deleted != nil {
+			continue
+		}
+
+		viewName := makeJoinableViewName(ds.BqProject, ds.BqDataset, ds.BqTable)
+		if err := s.bigQueryAPI.Grant(ctx, s.centralDataProject, jv.Name, viewName, subject); err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	err = s.joinableViewsStorage.CreateJoinableViewShare(ctx, jv.ID, input.Group)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+// CleanupJoinableViews deletes the joinable view sets that have expired, or where all the datasets
+// they were created from have been deleted
+func (s *joinableViewsService) CleanupJoinableViews(ctx context.Context) error {
+	const op = "joinableViewsService.CleanupJoinableViews"
+
+	views, err := s.joinableViewsStorage.GetJoinableViewsToBeCleanedUp(ctx)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	var failed []error
+	for _, jv := range views {
+		if err := s.deleteJoinableView(ctx, jv); err != nil {
+			failed = append(failed, err)
+		}
+	}
+
+	if len(failed) > 0 {
+		return errs.E(op, fmt.Errorf("deleting %d of %d joinable views: %w", len(failed), len(views), failed[0]))
+	}
+
+	return nil
+}
+
 func (s *joinableViewsService) GetJoinableViewsForUser(ctx context.Context, user *service.User) ([]service.JoinableView, error) {
 	const op = "joinableViewsService.GetJoinableViewsForUser"
 
@@ -128,6 +305,11 @@ func (s *joinableViewsService) GetJoinableView(ctx context.Context, user *servic
 		jv.PseudoDatasources = append(jv.PseudoDatasources, *jvbq)
 	}
 
+	jv.SharedWith, err = s.joinableViewsStorage.GetJoinableViewShares(ctx, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
 	return &jv, nil
 }
 
@@ -141,30 +323,16 @@ func (s *joinableViewsService) CreateJoinableViews(ctx context.Context, user *se
 			return "", errs.E(op, err)
 		}
 
-		dataproduct, err := s.dataProductStorage.GetDataproduct(ctx, dataset.DataproductID)
+		err = s.policyService.Enforce(ctx, service.PolicyInput{
+			Action:        service.PolicyActionJoinableView,
+			Subject:       user.Email,
+			SubjectType:   service.SubjectTypeUser,
+			SubjectGroups: user.GoogleGroups.Emails(),
+			DatasetID:     dataset.ID,
+			Expires:       input.Expires,
+		})
 		if err != nil {
-			return "", errs.E(op, err)
-		}
-
-		if !user.GoogleGroups.Contains(dataproduct.Owner.Group) {
-			access, err := s.accessStorage.ListActiveAccessToDataset(ctx, dataset.ID)
-			if err != nil {
-				return "", errs.E(op, err)
-			}
-
-			accessSet := make(map[string]int)
-			for _, da := range access {
-				accessSet[da.Subject] = 1
-			}
-
-			for _, ugg := range user.GoogleGroups {
-				accessSet["group:"+ugg.Email] = 1
-			}
-
-			accessSet["user:"+user.Email] = 1
-			if len(accessSet) == len(user.GoogleGroups.Emails())+1+len(access) {
-				return "", errs.E(errs.Unauthorized, op, errs.UserName(user.Email), fmt.Errorf("user not authorized to create joinable views"))
-			}
+			return "", errs.E(op, errs.UserName(user.Email), err)
 		}
 
 		datasets = append(datasets, dataset)
@@ -240,6 +408,8 @@ func NewJoinableViewsService(
 	dataProductStorage service.DataProductsStorage,
 	bigQueryAPI service.BigQueryAPI,
 	bigQueryStorage service.BigQueryStorage,
+	centralDataProject string,
+	policyService service.PolicyService,
 ) *joinableViewsService {
 	return &joinableViewsService{
 		joinableViewsStorage: joinableViewsStorage,
@@ -247,5 +417,7 @@ func NewJoinableViewsService(
 		dataProductStorage:   dataProductStorage,
 		bigQueryAPI:          bigQueryAPI,
 		bigQueryStorage:      bigQueryStorage,
+		centralDataProject:   centralDataProject,
+		policyService:        policyService,
 	}
 }
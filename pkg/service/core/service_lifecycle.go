@@ -0,0 +1,368 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/rs/zerolog"
+)
+
+type lifecycleService struct {
+	dataCatalogueURL    string
+	lifecycleStorage    service.LifecycleStorage
+	dataProductStorage  service.DataProductsStorage
+	accessStorage       service.AccessStorage
+	bigQueryStorage     service.BigQueryStorage
+	datasourceStorage   service.DatasourceStorage
+	providers           service.DatasourceProviders
+	metabaseService     service.MetabaseService
+	notificationService service.NotificationService
+	log                 zerolog.Logger
+}
+
+var _ service.LifecycleService = &lifecycleService{}
+
+func (s *lifecycleService) UpdateDataproductLifecycle(ctx context.Context, user *service.User, id uuid.UUID, input service.UpdateLifecycle) (*service.Lifecycle, error) {
+	const op errs.Op = "lifecycleService.UpdateDataproductLifecycle"
+
+	dp, err := s.dataProductStorage.GetDataproduct(ctx, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if err := ensureUserInGroup(user, dp.Owner.Group); err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if dp.Lifecycle != nil && dp.Lifecycle.Status == service.LifecycleStatusRetired {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("dataproduct %v is retired", id))
+	}
+
+	if input.ReplacedBy != nil {
+		if *input.ReplacedBy == id {
+			return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("replacedBy"), fmt.Errorf("dataproduct can not replace itself"))
+		}
+
+		_, err := s.dataProductStorage.GetDataproduct(ctx, *input.ReplacedBy)
+		if err != nil {
+			return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("replacedBy"), err)
+		}
+	}
+
+	lifecycle := lifecycleFromUpdate(input)
+
+	err = s.lifecycleStorage.SetDataproductLifecycle(ctx, id, lifecycle)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	// The datasets follow the dataproduct, but they are replaced by
+	// datasets and not dataproducts, so the replacement is not copied
+	datasetLifecycle := lifecycle
+	datasetLifecycle.ReplacedBy = nil
+
+	for _, ds := range dp.Datasets {
+		if ds.Lifecycle != nil && ds.Lifecycle.Status == service.LifecycleStatusRetired {
+			continue
+		}
+
+		err := s.lifecycleStorage.SetDatasetLifecycle(ctx, ds.ID, datasetLifecycle)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		if ds.Lifecycle.Active() && !datasetLifecycle.Active() {
+			s.notifyConsumers(ctx, dp, ds.ID, ds.Name, &datasetLifecycle)
+		}
+	}
+
+	return &lifecycle, nil
+}
+
+func (s *lifecycleService) UpdateDatasetLifecycle(ctx context.Context, user *service.User, id uuid.UUID, input service.UpdateLifecycle) (*service.Lifecycle, error) {
+	const op errs.Op = "lifecycleService.UpdateDatasetLifecycle"
+
+	ds, err := s.dataProductStorage.GetDataset(ctx, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	dp, err := s.dataProductStorage.GetDataproduct(ctx, ds.DataproductID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if err := ensureUserInGroup(user, dp.Owner.Group); err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if ds.Lifecycle != nil && ds.Lifecycle.Status == service.LifecycleStatusRetired {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("dataset %v is retired", id))
+	}
+
+	if input.ReplacedBy != nil {
+		if *input.ReplacedBy == id {
+			return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("replacedBy"), fmt.Errorf("dataset can not replace itself"))
+		}
+
+		_, err := s.dataProductStorage.GetDataset(ctx, *input.ReplacedBy)
+		if err != nil {
+			return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("replacedBy"), err)
+		}
+	}
+
+	lifecycle := lifecycleFromUpdate(input)
+
+	err = s.lifecycleStorage.SetDatasetLifecycle(ctx, id, lifecycle)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if ds.Lifecycle.Active() && !lifecycle.Active() {
+		s.notifyConsumers(ctx, dp, ds.ID, ds.Name, &lifecycle)
+	}
+
+	return &lifecycle, nil
+}
+
+// RetireSunsetDatasets retires the dataproducts and datasets that have
+// passed their sunset date, revoking all access to the datasets and
+// marking their datasources as deleted. A dataset that fails to be retired
+// is logged and retried on the next run, together with its dataproduct
+func (s *lifecycleService) RetireSunsetDatasets(ctx context.Context) error {
+	const op errs.Op = "lifecycleService.RetireSunsetDatasets"
+
+	dpIDs, err := s.lifecycleStorage.GetDataproductsToRetire(ctx)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	for _, id := range dpIDs {
+		if err := s.retireDataproduct(ctx, id); err != nil {
+			s.log.Error().Err(err).Msgf("retiring dataproduct %v", id)
+		}
+	}
+
+	dsIDs, err := s.lifecycleStorage.GetDatasetsToRetire(ctx)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	for _, id := range dsIDs {
+		ds, err := s.dataProductStorage.GetDataset(ctx, id)
+		if err != nil {
+			s.log.Error().Err(err).Msgf("getting dataset %v to retire", id)
+			continue
+		}
+
+		if err := s.retireDataset(ctx, id, *ds.Lifecycle); err != nil {
+			s.log.Error().Err(err).Msgf("retiring dataset %v", id)
+		}
+	}
+
+	return nil
+}
+
+// retireDataproduct retires the datasets of the dataproduct, and only
+// retires the dataproduct itself once all of them are retired
+func (s *lifecycleService) retireDataproduct(ctx context.Context, id uuid.UUID) error {
+	const op errs.Op = "lifecycleService.retireDataproduct"
+
+	dp, err := s.dataProductStorage.GetDataproduct(ctx, id)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	var failed error
+	for _, ds := range dp.Datasets {
+		if ds.Lifecycle != nil && ds.Lifecycle.Status == service.LifecycleStatusRetired {
+			continue
+		}
+
+		if err := s.retireDataset(ctx, ds.ID, *dp.Lifecycle); err != nil {
+			s.log.Error().Err(err).Msgf("retiring dataset %v of dataproduct %v", ds.ID, id)
+			failed = err
+		}
+	}
+
+	if failed != nil {
+		return errs.E(op, fmt.Errorf("not all datasets were retired: %w", failed))
+	}
+
+	lifecycle := *dp.Lifecycle
+	lifecycle.Status = service.LifecycleStatusRetired
+
+	err = s.lifecycleStorage.SetDataproductLifecycle(ctx, id, lifecycle)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+func (s *lifecycleService) retireDataset(ctx context.Context, id uuid.UUID, lifecycle service.Lifecycle) error {
+	const op errs.Op = "lifecycleService.retireDataset"
+
+	accesses, err := s.accessStorage.ListActiveAccessToDataset(ctx, id)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	ref, provider, err := datasetDatasource(ctx, s.datasourceStorage, s.providers, id)
+	if err != nil && !errs.KindIs(errs.NotExist, err) {
+		return errs.E(op, err)
+	}
+
+	for _, a := range accesses {
+		if ref != nil {
+			err := provider.Revoke(ctx, *ref, a.Subject)
+			if err != nil {
+				return errs.E(op, err)
+			}
+		}
+
+		err := s.accessStorage.RevokeAccessToDataset(ctx, a.ID)
+		if err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	err = s.metabaseService.DeleteDatabase(ctx, id)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	if ref != nil && ref.Type == service.DatasourceTypeBigQuery {
+		bq, err := s.bigQueryStorage.GetBigqueryDatasource(ctx, id, false)
+		if err != nil {
+			return errs.E(op, err)
+		}
+
+		err = s.dataProductStorage.SetDatasourceDeleted(ctx, bq.ID)
+		if err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	lifecycle.Status = service.LifecycleStatusRetired
+
+	err = s.lifecycleStorage.SetDatasetLifecycle(ctx, id, lifecycle)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+// notifyConsumers notifies the consumers with active access to a dataset
+// that has been deprecated, failing to notify does not stop the
+// deprecation, so errors are only logged
+func (s *lifecycleService) notifyConsumers(ctx context.Context, dp *service.DataproductWithDataset, dsID uuid.UUID, dsName string, lifecycle *service.Lifecycle) {
+	accesses, err := s.accessStorage.ListActiveAccessToDataset(ctx, dsID)
+	if err != nil {
+		s.log.Error().Err(err).Msgf("listing active access to deprecated dataset %v", dsID)
+		return
+	}
+
+	var replacement *service.Dataset
+	if lifecycle.ReplacedBy != nil {
+		replacement, err = s.dataProductStorage.GetDataset(ctx, *lifecycle.ReplacedBy)
+		if err != nil {
+			s.log.Error().Err(err).Msgf("getting replacement for deprecated dataset %v", dsID)
+		}
+	}
+
+	var recipients []string
+	for _, a := range accesses {
+		recipients = append(recipients, consumerSubjects(a.Subject, a.Owner)...)
+	}
+
+	link := datasetLink(s.dataCatalogueURL, dp.ID, dp.Name, dsID)
+
+	err = s.notificationService.Notify(ctx, service.NewNotification{
+		EventType:   service.NotificationEventDatasetDeprecated,
+		Recipients:  recipients,
+		Title:       "Datasett utfaset",
+		Message:     createDeprecationNotification(dp, dsName, lifecycle, replacement, s.dataCatalogueURL),
+		Link:        &link,
+		ReferenceID: &dsID,
+	})
+	if err != nil {
+		s.log.Error().Err(err).Msgf("notifying consumers about deprecated dataset %v", dsID)
+	}
+}
+
+func createDeprecationNotification(dp *service.DataproductWithDataset, dsName string, lifecycle *service.Lifecycle, replacement *service.Dataset, dataCatalogueURL string) string {
+	message := fmt.Sprintf(
+		"Datasettet %s i dataproduktet %s som du har tilgang til er utfaset.",
+		dsName,
+		dp.Name,
+	)
+
+	if lifecycle.Sunset != nil {
+		message += fmt.Sprintf("\nTilgangen fjernes %s.", lifecycle.Sunset.Format("02.01.2006"))
+	}
+
+	if lifecycle.Reason != nil && *lifecycle.Reason != "" {
+		message += fmt.Sprintf("\nBegrunnelse: %s", *lifecycle.Reason)
+	}
+
+	if replacement != nil {
+		message += fmt.Sprintf(
+			"\nErstattes av %s: %s",
+			replacement.Name,
+			datasetLink(dataCatalogueURL, replacement.DataproductID, replacement.Name, replacement.ID),
+		)
+	}
+
+	return message
+}
+
+func datasetLink(dataCatalogueURL string, dpID uuid.UUID, dpName string, dsID uuid.UUID) string {
+	return fmt.Sprintf(
+		"%s/dataproduct/%s/%s/%s",
+		dataCatalogueURL,
+		dpID.String(),
+		url.QueryEscape(dpName),
+		dsID.String(),
+	)
+}
+
+func lifecycleFromUpdate(input service.UpdateLifecycle) service.Lifecycle {
+	return service.Lifecycle{
+		Status:     input.Status,
+		ReplacedBy: input.ReplacedBy,
+		Sunset:     input.Sunset,
+		Reason:     input.Reason,
+	}
+}
+
+func NewLifecycleService(
+	dataCatalogueURL string,
+	lifecycleStorage service.LifecycleStorage,
+	dataProductStorage service.DataProductsStorage,
+	accessStorage service.AccessStorage,
+	bigQueryStorage service.BigQueryStorage,
+	datasourceStorage service.DatasourceStorage,
+	providers service.DatasourceProviders,
+	metabaseService service.MetabaseService,
+	notificationService service.NotificationService,
+	log zerolog.Logger,
+) *lifecycleService {
+	return &lifecycleService{
+		dataCatalogueURL:    dataCatalogueURL,
+		lifecycleStorage:    lifecycleStorage,
+		dataProductStorage:  dataProductStorage,
+		accessStorage:       accessStorage,
+		bigQueryStorage:     bigQueryStorage,
+		datasourceStorage:   datasourceStorage,
+		providers:           providers,
+		metabaseService:     metabaseService,
+		notificationService: notificationService,
+		log:                 log,
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -33,12 +34,16 @@ type metabaseService struct {
 	metabaseAPI       service.MetabaseAPI
 	bigqueryAPI       service.BigQueryAPI
 	serviceAccountAPI service.ServiceAccountAPI
+	providers         service.DatasourceProviders
 
 	thirdPartyMappingStorage service.ThirdPartyMappingStorage
 	metabaseStorage          service.MetabaseStorage
 	bigqueryStorage          service.BigQueryStorage
 	dataproductStorage       service.DataProductsStorage
 	accessStorage            service.AccessStorage
+	datasourceStorage        service.DatasourceStorage
+
+	policyService service.PolicyService
 
 	log zerolog.Logger
 }
@@ -60,6 +65,22 @@ func (s *metabaseService) CreateMappingRequest(ctx context.Context, user *servic
 		return errs.E(op, err)
 	}
 
+	if slices.Contains(services, service.MappingServiceMetabase) {
+		if ds.Datasource == nil {
+			return errs.E(errs.InvalidRequest, op, fmt.Errorf("metabase only supports datasets with a BigQuery datasource"))
+		}
+
+		err = s.policyService.Enforce(ctx, service.PolicyInput{
+			Action:      service.PolicyActionMetabaseMapping,
+			Subject:     s.serviceAccountEmail,
+			SubjectType: service.SubjectTypeServiceAccount,
+			DatasetID:   ds.ID,
+		})
+		if err != nil {
+			return errs.E(op, err)
+		}
+	}
+
 	err = s.thirdPartyMappingStorage.MapDataset(ctx, datasetID, services)
 	if err != nil {
 		return errs.E(op, err)
@@ -250,12 +271,12 @@ func (s *metabaseService) addMetabaseGroupMember(ctx context.Context, dsID uuid.
 func (s *metabaseService) restore(ctx context.Context, datasetID uuid.UUID, saEmail string) error {
 	const op errs.Op = "metabaseService.restore"
 
-	ds, err := s.bigqueryStorage.GetBigqueryDatasource(ctx, datasetID, false)
+	ref, provider, err := datasetDatasource(ctx, s.datasourceStorage, s.providers, datasetID)
 	if err != nil {
 		return errs.E(op, err)
 	}
 
-	err = s.bigqueryAPI.Grant(ctx, ds.ProjectID, ds.Dataset, ds.Table, "serviceAccount:"+saEmail)
+	err = provider.Grant(ctx, *ref, "serviceAccount:"+saEmail)
 	if err != nil {
 		return errs.E(op, err)
 	}
@@ -469,12 +490,20 @@ func (s *metabaseService) addAllUsersDataset(ctx context.Context, dsID uuid.UUID
 func (s *metabaseService) create(ctx context.Context, ds dsWrapper) error {
 	const op errs.Op = "metabaseService.create"
 
+	// Metabase databases are only created for BigQuery datasources
 	datasource, err := s.bigqueryStorage.GetBigqueryDatasource(ctx, ds.Dataset.ID, false)
 	if err != nil {
 		return errs.E(op, err)
 	}
 
-	err = s.bigqueryAPI.Grant(ctx, datasource.ProjectID, datasource.Dataset, datasource.Table, "serviceAccount:"+ds.Email)
+	ref := datasource.Ref()
+
+	provider, err := datasourceProvider(s.providers, ref.Type)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	err = provider.Grant(ctx, ref, "serviceAccount:"+ds.Email)
 	if err != nil {
 		return errs.E(op, err)
 	}
@@ -495,7 +524,14 @@ func (s *metabaseService) create(ctx context.Context, ds dsWrapper) error {
 			return errs.E(op, err)
 		}
 
-		if err := s.waitForDatabase(ctx, dbID, datasource.Table); err != nil {
+		tables := datasource.TableNames()
+		if len(tables) == 0 {
+			return errs.E(errs.InvalidRequest, op, fmt.Errorf("datasource for dataset %v has no tables", ds.Dataset.ID))
+		}
+
+		// For a dataset scoped datasource it is enough to wait for the
+		// first of its tables to be synced
+		if err := s.waitForDatabase(ctx, dbID, tables[0]); err != nil {
 			if err := s.cleanupOnCreateDatabaseError(ctx, dbID, ds); err != nil {
 				return errs.E(op, err)
 			}
@@ -580,6 +616,121 @@ func (s *metabaseService) cleanupOnCreateDatabaseError(ctx context.Context, dbID
 	return nil
 }
 
+// TransferDataset moves the Metabase database and the restricted collection
+// of a dataset over to a new owner group. For restricted databases the
+// permissions of the dataset permission group on the database and the
+// collection are reapplied, and the members of the group are synced with
+// the active accesses to the dataset. It is idempotent, so it can be
+// retried if the transfer fails halfway
+func (s *metabaseService) TransferDataset(ctx context.Context, dsID uuid.UUID, ownerGroup string) error {
+	const op errs.Op = "metabaseService.TransferDataset"
+
+	meta, err := s.metabaseStorage.GetMetadata(ctx, dsID, false)
+	if err != nil {
+		if errs.KindIs(errs.NotExist, err) {
+			return nil
+		}
+
+		return errs.E(op, err)
+	}
+
+	ds, err := s.dataproductStorage.GetDataset(ctx, dsID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	if meta.DatabaseID != nil {
+		err = s.metabaseAPI.UpdateDatabaseName(ctx, *meta.DatabaseID, ownerGroup, ds.Name)
+		if err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	if meta.CollectionID != nil {
+		err = s.metabaseAPI.UpdateCollection(ctx, &service.MetabaseCollection{
+			ID:          *meta.CollectionID,
+			Name:        fmt.Sprintf("%s %s", ds.Name, service.MetabaseRestrictedCollectionTag),
+			Description: fmt.Sprintf("Collection for %s, owned by %s", ds.Name, ownerGroup),
+		})
+		if err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	if !isRestrictedDatabase(meta) || meta.PermissionGroupID == nil || *meta.PermissionGroupID == 0 {
+		return nil
+	}
+
+	if meta.DatabaseID != nil {
+		err = s.metabaseAPI.RestrictAccessToDatabase(ctx, *meta.PermissionGroupID, *meta.DatabaseID)
+		if err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	err = s.metabaseAPI.SetCollectionAccess(ctx, *meta.PermissionGroupID, *meta.CollectionID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	err = s.syncMetabaseGroupMembers(ctx, dsID, *meta.PermissionGroupID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+// syncMetabaseGroupMembers makes the members of the permission group match
+// the users with an active access to the dataset
+func (s *metabaseService) syncMetabaseGroupMembers(ctx context.Context, dsID uuid.UUID, groupID int) error {
+	const op errs.Op = "metabaseService.syncMetabaseGroupMembers"
+
+	accesses, err := s.accessStorage.ListActiveAccessToDataset(ctx, dsID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	members, err := s.metabaseAPI.GetPermissionGroup(ctx, groupID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	users := map[string]bool{}
+	for _, a := range accesses {
+		email, sType, err := parseSubject(a.Subject)
+		if err != nil {
+			return errs.E(op, err)
+		}
+
+		if sType != "user" {
+			continue
+		}
+
+		users[email] = true
+
+		if exists, _ := memberExists(members, email); exists {
+			continue
+		}
+
+		if err := s.metabaseAPI.AddPermissionGroupMember(ctx, groupID, email); err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	for _, m := range members {
+		if users[m.Email] {
+			continue
+		}
+
+		if err := s.metabaseAPI.RemovePermissionGroupMember(ctx, m.ID); err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	return nil
+}
+
 func (s *metabaseService) DeleteDatabase(ctx context.Context, dsID uuid.UUID) error {
 	const op errs.Op = "metabaseService.DeleteDatabase"
 
@@ -612,6 +763,26 @@ func (s *metabaseService) DeleteDatabase(ctx context.Context, dsID uuid.UUID) er
 func (s *metabaseService) deleteAllUsersDatabase(ctx context.Context, meta *service.MetabaseMetadata) error {
 	const op errs.Op = "metabaseService.deleteAllUsersDatabase"
 
+	// Open databases use the shared service account, unless the database
+	// was restricted before it was opened. The service account is not
+	// deleted, it only loses its access to this dataset
+	saEmail := meta.SAEmail
+	if saEmail == "" {
+		saEmail = s.serviceAccountEmail
+	}
+
+	ref, provider, err := datasetDatasource(ctx, s.datasourceStorage, s.providers, meta.DatasetID)
+	if err != nil && !errs.KindIs(errs.NotExist, err) {
+		return errs.E(op, err)
+	}
+
+	if ref != nil {
+		err = provider.Revoke(ctx, *ref, "serviceAccount:"+saEmail)
+		if err != nil {
+			return errs.E(op, err)
+		}
+	}
+
 	if meta.DatabaseID != nil {
 		err := s.metabaseAPI.DeleteDatabase(ctx, *meta.DatabaseID)
 		if err != nil {
@@ -619,7 +790,7 @@ func (s *metabaseService) deleteAllUsersDatabase(ctx context.Context, meta *serv
 		}
 	}
 
-	err := s.metabaseStorage.DeleteMetadata(ctx, meta.DatasetID)
+	err = s.metabaseStorage.DeleteMetadata(ctx, meta.DatasetID)
 	if err != nil {
 		return errs.E(op, err)
 	}
@@ -630,12 +801,12 @@ func (s *metabaseService) deleteAllUsersDatabase(ctx context.Context, meta *serv
 func (s *metabaseService) deleteRestrictedDatabase(ctx context.Context, datasetID uuid.UUID, meta *service.MetabaseMetadata) error {
 	const op errs.Op = "metabaseService.deleteRestrictedDatabase"
 
-	ds, err := s.bigqueryStorage.GetBigqueryDatasource(ctx, datasetID, false)
+	ref, provider, err := datasetDatasource(ctx, s.datasourceStorage, s.providers, datasetID)
 	if err != nil {
 		return errs.E(op, err)
 	}
 
-	err = s.bigqueryAPI.Revoke(ctx, ds.ProjectID, ds.Dataset, ds.Table, "serviceAccount:"+meta.SAEmail)
+	err = provider.Revoke(ctx, *ref, "serviceAccount:"+meta.SAEmail)
 	if err != nil {
 		return errs.E(op, err)
 	}
@@ -734,12 +905,12 @@ func (s *metabaseService) softDeleteDatabase(ctx context.Context, datasetID uuid
 		return errs.E(op, err)
 	}
 
-	ds, err := s.bigqueryStorage.GetBigqueryDatasource(ctx, datasetID, false)
+	ref, provider, err := datasetDatasource(ctx, s.datasourceStorage, s.providers, datasetID)
 	if err != nil {
 		return errs.E(op, err)
 	}
 
-	err = s.bigqueryAPI.Revoke(ctx, ds.ProjectID, ds.Dataset, ds.Table, "serviceAccount:"+mbMeta.SAEmail)
+	err = provider.Revoke(ctx, *ref, "serviceAccount:"+mbMeta.SAEmail)
 	if err != nil {
 		return errs.E(op, err)
 	}
@@ -831,12 +1002,14 @@ func (s *metabaseService) SyncTableVisibility(ctx context.Context, meta *service
 		return errs.E(errs.IO, op, err)
 	}
 
-	includedTables := []string{bq.Table}
+	includedTables := bq.TableNames()
 	if !isRestrictedDatabase(meta) {
-		includedTables, err = s.metabaseStorage.GetOpenTablesInSameBigQueryDataset(ctx, bq.ProjectID, bq.Dataset)
+		openTables, err := s.metabaseStorage.GetOpenTablesInSameBigQueryDataset(ctx, bq.ProjectID, bq.Dataset)
 		if err != nil {
 			return errs.E(op, err)
 		}
+
+		includedTables = append(includedTables, openTables...)
 	}
 
 	var includedIDs, excludedIDs []int
@@ -912,11 +1085,14 @@ func NewMetabaseService(
 	mbapi service.MetabaseAPI,
 	bqapi service.BigQueryAPI,
 	saapi service.ServiceAccountAPI,
+	providers service.DatasourceProviders,
 	tpms service.ThirdPartyMappingStorage,
 	mbs service.MetabaseStorage,
 	bqs service.BigQueryStorage,
 	dps service.DataProductsStorage,
 	as service.AccessStorage,
+	dss service.DatasourceStorage,
+	ps service.PolicyService,
 	log zerolog.Logger,
 ) *metabaseService {
 	return &metabaseService{
@@ -927,11 +1103,14 @@ func NewMetabaseService(
 		metabaseAPI:              mbapi,
 		bigqueryAPI:              bqapi,
 		serviceAccountAPI:        saapi,
+		providers:                providers,
 		thirdPartyMappingStorage: tpms,
 		metabaseStorage:          mbs,
 		bigqueryStorage:          bqs,
 		dataproductStorage:       dps,
 		accessStorage:            as,
+		datasourceStorage:        dss,
+		policyService:            ps,
 		log:                      log,
 	}
 }
@@ -0,0 +1,454 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// notificationDigestInterval is how long notifications wait before they
+	// are sent in a digest
+	notificationDigestInterval = 24 * time.Hour
+	// notificationDeliveryMaxAttempts is how many times a queued delivery is
+	// attempted before it is given up
+	notificationDeliveryMaxAttempts = 10
+	// notificationDeliveryBatchSize is how many queued deliveries are sent
+	// in one run
+	notificationDeliveryBatchSize = 100
+)
+
+// accessExpiryReminderDays is how many days before an access expires that the
+// subject is reminded, in increasing order, so that an access which is close to
+// expiring is only reminded of the nearest one
+var accessExpiryReminderDays = []int{3, 14}
+
+var _ service.NotificationService = &notificationService{}
+
+type notificationService struct {
+	dataCatalogueURL    string
+	notificationStorage service.NotificationStorage
+	slackapi            service.SlackAPI
+	emailapi            service.EmailAPI
+	log                 zerolog.Logger
+}
+
+// Notify stores the notification in the inbox of every recipient, and queues
+// the deliveries on Slack and email, which are sent by DeliverNotifications
+// outside of the request
+func (s *notificationService) Notify(ctx context.Context, n service.NewNotification) error {
+	const op errs.Op = "notificationService.Notify"
+
+	notified := map[string]bool{}
+
+	for _, recipient := range n.Recipients {
+		email, subjectType, err := parseSubject(recipient)
+		if err != nil {
+			s.log.Warn().Err(err).Msgf("skipping notification recipient %v", recipient)
+			continue
+		}
+
+		if subjectType == service.SubjectTypeServiceAccount || notified[email] {
+			continue
+		}
+
+		notified[email] = true
+
+		stored := &service.NewStoredNotification{
+			Recipient:   email,
+			EventType:   n.EventType,
+			Title:       n.Title,
+			Message:     n.Message,
+			Link:        n.Link,
+			ReferenceID: n.ReferenceID,
+		}
+
+		var preference *service.NotificationPreference
+		if subjectType == service.SubjectTypeUser {
+			preference, err = s.preference(ctx, email, n.EventType)
+			if err != nil {
+				return errs.E(op, err)
+			}
+
+			if preference.Digest && preference.Channel != service.NotificationChannelInApp {
+				stored.DigestChannel = &preference.Channel
+			}
+		}
+
+		_, err = s.notificationStorage.CreateNotification(ctx, stored)
+		if err != nil {
+			return errs.E(op, err)
+		}
+
+		if preference == nil || stored.DigestChannel != nil {
+			continue
+		}
+
+		var kind service.NotificationDeliveryKind
+		switch preference.Channel {
+		case service.NotificationChannelSlack:
+			kind = service.NotificationDeliveryKindSlackUser
+		case service.NotificationChannelEmail:
+			kind = service.NotificationDeliveryKindEmail
+		default:
+			continue
+		}
+
+		err = s.notificationStorage.CreateNotificationDelivery(ctx, &service.NewNotificationDelivery{
+			Kind:      kind,
+			Recipient: email,
+			Title:     n.Title,
+			Text:      notificationText(n.Message, n.Link),
+		})
+		if err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	if n.TeamChannel != nil && *n.TeamChannel != "" {
+		delivery := &service.NewNotificationDelivery{
+			Kind:      service.NotificationDeliveryKindSlackChannel,
+			Recipient: *n.TeamChannel,
+			Title:     n.Title,
+			Text:      notificationText(n.Message, n.Link),
+		}
+
+		if n.ApprovalRequest && n.ReferenceID != nil {
+			delivery.Kind = service.NotificationDeliveryKindSlackApproval
+			delivery.ReferenceID = n.ReferenceID
+		}
+
+		err := s.notificationStorage.CreateNotificationDelivery(ctx, delivery)
+		if err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	return nil
+}
+
+// DeliverNotifications sends the queued deliveries, a failed delivery is
+// retried on a later run with a growing delay, until it runs out of attempts
+func (s *notificationService) DeliverNotifications(ctx context.Context) error {
+	const op errs.Op = "notificationService.DeliverNotifications"
+
+	deliveries, err := s.notificationStorage.GetPendingNotificationDeliveries(ctx, notificationDeliveryMaxAttempts, notificationDeliveryBatchSize)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	for _, d := range deliveries {
+		err := s.deliver(ctx, d)
+		if err != nil {
+			s.log.Error().Err(err).Msgf("delivering notification %v to %v on %v, attempt %d", d.ID, d.Recipient, d.Kind, d.Attempts+1)
+
+			if err := s.notificationStorage.MarkNotificationDeliveryFailed(ctx, d.ID, err.Error()); err != nil {
+				return errs.E(op, err)
+			}
+
+			continue
+		}
+
+		if err := s.notificationStorage.MarkNotificationDelivered(ctx, d.ID); err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *notificationService) preference(ctx context.Context, email string, eventType service.NotificationEventType) (*service.NotificationPreference, error) {
+	const op errs.Op = "notificationService.preference"
+
+	preferences, err := s.notificationStorage.GetNotificationPreferences(ctx, email)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	for _, p := range preferences {
+		if p.EventType == eventType {
+			return p, nil
+		}
+	}
+
+	return defaultNotificationPreference(eventType), nil
+}
+
+func (s *notificationService) deliver(ctx context.Context, d *service.NotificationDelivery) error {
+	const op errs.Op = "notificationService.deliver"
+
+	var err error
+
+	switch d.Kind {
+	case service.NotificationDeliveryKindSlackUser:
+		err = s.slackapi.SendSlackNotificationToUser(d.Recipient, d.Text)
+	case service.NotificationDeliveryKindSlackChannel:
+		err = s.slackapi.SendSlackNotification(d.Recipient, d.Text)
+	case service.NotificationDeliveryKindSlackApproval:
+		if d.ReferenceID == nil {
+			return errs.E(errs.Internal, op, fmt.Errorf("approval request delivery %v has no reference", d.ID))
+		}
+
+		err = s.slackapi.SendAccessRequestApproval(d.Recipient, d.Text, *d.ReferenceID)
+	case service.NotificationDeliveryKindEmail:
+		err = s.emailapi.SendEmail(ctx, d.Recipient, d.Title, d.Text)
+	default:
+		return errs.E(errs.Internal, op, fmt.Errorf("unknown delivery kind %s", d.Kind))
+	}
+
+	if err != nil {
+		return errs.E(errs.IO, op, err)
+	}
+
+	return nil
+}
+
+func (s *notificationService) GetNotifications(ctx context.Context, user *service.User, unreadOnly bool, page service.PageRequest) (*service.Page[*service.Notification], error) {
+	const op errs.Op = "notificationService.GetNotifications"
+
+	notifications, err := s.notificationStorage.GetNotifications(ctx, user.Email, user.GoogleGroups.Emails(), unreadOnly, page)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return notifications, nil
+}
+
+func (s *notificationService) MarkNotificationsRead(ctx context.Context, user *service.User, input service.MarkNotificationsRead) error {
+	const op errs.Op = "notificationService.MarkNotificationsRead"
+
+	if err := input.Validate(); err != nil {
+		return errs.E(errs.InvalidRequest, op, err)
+	}
+
+	err := s.notificationStorage.MarkNotificationsRead(ctx, user.Email, user.GoogleGroups.Emails(), input.IDs)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+func (s *notificationService) MarkAllNotificationsRead(ctx context.Context, user *service.User) error {
+	const op errs.Op = "notificationService.MarkAllNotificationsRead"
+
+	err := s.notificationStorage.MarkAllNotificationsRead(ctx, user.Email, user.GoogleGroups.Emails())
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+// GetNotificationPreferences returns the preference for every event type,
+// including the default for the event types the user has not changed
+func (s *notificationService) GetNotificationPreferences(ctx context.Context, user *service.User) (*service.NotificationPreferences, error) {
+	const op errs.Op = "notificationService.GetNotificationPreferences"
+
+	stored, err := s.notificationStorage.GetNotificationPreferences(ctx, user.Email)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	byEventType := map[service.NotificationEventType]*service.NotificationPreference{}
+	for _, p := range stored {
+		byEventType[p.EventType] = p
+	}
+
+	preferences := &service.NotificationPreferences{
+		Preferences: make([]*service.NotificationPreference, len(service.NotificationEventTypes)),
+	}
+
+	for i, eventType := range service.NotificationEventTypes {
+		p, ok := byEventType[eventType]
+		if !ok {
+			p = defaultNotificationPreference(eventType)
+		}
+
+		preferences.Preferences[i] = p
+	}
+
+	return preferences, nil
+}
+
+func (s *notificationService) UpdateNotificationPreferences(ctx context.Context, user *service.User, input service.NotificationPreferences) (*service.NotificationPreferences, error) {
+	const op errs.Op = "notificationService.UpdateNotificationPreferences"
+
+	if err := input.Validate(); err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	err := s.notificationStorage.UpsertNotificationPreferences(ctx, user.Email, input.Preferences)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	preferences, err := s.GetNotificationPreferences(ctx, user)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return preferences, nil
+}
+
+// NotifyExpiringAccess reminds the subject and owner of every access that
+// expires within one of the reminder days, once for each of them, and again
+// if the expiry of the access changes
+func (s *notificationService) NotifyExpiringAccess(ctx context.Context) error {
+	const op errs.Op = "notificationService.NotifyExpiringAccess"
+
+	for _, days := range accessExpiryReminderDays {
+		accesses, err := s.notificationStorage.GetExpiringAccessToNotify(ctx, days)
+		if err != nil {
+			return errs.E(op, err)
+		}
+
+		for _, a := range accesses {
+			link := datasetLink(s.dataCatalogueURL, a.DataproductID, a.DataproductName, a.DatasetID)
+			accessID := a.AccessID
+
+			err := s.Notify(ctx, service.NewNotification{
+				EventType:  service.NotificationEventAccessExpiring,
+				Recipients: consumerSubjects(a.Subject, a.Owner),
+				Title:      "Tilgang utløper snart",
+				Message: fmt.Sprintf(
+					"Tilgangen til datasettet %s i dataproduktet %s utløper %s. Du kan søke om å fornye tilgangen fra datasettet.",
+					a.DatasetName,
+					a.DataproductName,
+					a.Expires.Format("02.01.2006"),
+				),
+				Link:        &link,
+				ReferenceID: &accessID,
+			})
+			if err != nil {
+				return errs.E(op, err)
+			}
+
+			err = s.notificationStorage.MarkAccessExpiryNotified(ctx, a, days)
+			if err != nil {
+				return errs.E(op, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SendDigests sends the pending notifications of every user who has had
+// notifications waiting for at least the digest interval, a failed delivery
+// is retried on the next run
+func (s *notificationService) SendDigests(ctx context.Context) error {
+	const op errs.Op = "notificationService.SendDigests"
+
+	recipients, err := s.notificationStorage.GetDigestRecipients(ctx, time.Now().Add(-notificationDigestInterval))
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	for _, r := range recipients {
+		notifications, err := s.notificationStorage.GetPendingDigestNotifications(ctx, r)
+		if err != nil {
+			return errs.E(op, err)
+		}
+
+		if len(notifications) == 0 {
+			continue
+		}
+
+		title, text := notificationDigest(notifications)
+
+		switch r.Channel {
+		case service.NotificationChannelSlack:
+			err = s.slackapi.SendSlackNotificationToUser(r.Email, text)
+		case service.NotificationChannelEmail:
+			err = s.emailapi.SendEmail(ctx, r.Email, title, text)
+		}
+
+		if err != nil {
+			s.log.Error().Err(err).Msgf("sending notification digest to %v on %v", r.Email, r.Channel)
+			continue
+		}
+
+		ids := make([]uuid.UUID, len(notifications))
+		for i, n := range notifications {
+			ids[i] = n.ID
+		}
+
+		if err := s.notificationStorage.MarkDigestSent(ctx, ids); err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	return nil
+}
+
+// defaultNotificationPreference only shows the notification in the inbox,
+// users opt in to direct messages on Slack or email
+func defaultNotificationPreference(eventType service.NotificationEventType) *service.NotificationPreference {
+	return &service.NotificationPreference{
+		EventType: eventType,
+		Channel:   service.NotificationChannelInApp,
+		Digest:    false,
+	}
+}
+
+func notificationText(message string, link *string) string {
+	if link == nil {
+		return message
+	}
+
+	return message + "\nLink: " + *link
+}
+
+func notificationDigest(notifications []*service.Notification) (string, string) {
+	title := fmt.Sprintf("Du har %d nye varsler på Datamarkedsplassen", len(notifications))
+
+	var b strings.Builder
+
+	b.WriteString(title + ":")
+
+	for _, n := range notifications {
+		b.WriteString("\n\n" + n.Title + "\n" + notificationText(n.Message, n.Link))
+	}
+
+	return title, b.String()
+}
+
+// consumerSubjects returns the subjects to notify about an access, where the
+// owner is stored without a subject type, and is responsible for the access
+// of a service account
+func consumerSubjects(subject, owner string) []string {
+	subjects := []string{subject}
+
+	if strings.HasPrefix(subject, service.SubjectTypeServiceAccount+":") && owner != "" {
+		if !strings.Contains(owner, ":") {
+			owner = service.SubjectTypeUser + ":" + owner
+		}
+
+		subjects = append(subjects, owner)
+	}
+
+	return subjects
+}
+
+func NewNotificationService(
+	dataCatalogueURL string,
+	notificationStorage service.NotificationStorage,
+	slackapi service.SlackAPI,
+	emailapi service.EmailAPI,
+	log zerolog.Logger,
+) *notificationService {
+	return &notificationService{
+		dataCatalogueURL:    dataCatalogueURL,
+		notificationStorage: notificationStorage,
+		slackapi:            slackapi,
+		emailapi:            emailapi,
+		log:                 log,
+	}
+}
@@ -0,0 +1,500 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+var _ service.PolicyService = &policyService{}
+
+const (
+	// policyCostLimit stops the evaluation of conditions that are far more
+	// expensive than the checks of the subject and dataset they are meant for
+	policyCostLimit = 10_000
+	// policyInterruptCheckFrequency is how many iterations of a comprehension
+	// are evaluated between checks of whether the evaluation is cancelled
+	policyInterruptCheckFrequency = 100
+	// maxCachedPrograms bounds the number of compiled conditions kept in memory
+	maxCachedPrograms = 1_000
+)
+
+type policyService struct {
+	policyStorage      service.PolicyStorage
+	dataProductStorage service.DataProductsStorage
+	accessStorage      service.AccessStorage
+	adminGroup         string
+	builtIn            []*service.Policy
+
+	mu       sync.RWMutex
+	programs map[string]cel.Program
+}
+
+func (s *policyService) Decide(ctx context.Context, input service.PolicyInput) (*service.PolicyDecision, error) {
+	const op errs.Op = "policyService.Decide"
+
+	policies, err := s.applicablePolicies(ctx, input.DatasetID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	decision, err := s.decide(ctx, input, policies, nil)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return decision, nil
+}
+
+func (s *policyService) Enforce(ctx context.Context, input service.PolicyInput) error {
+	const op errs.Op = "policyService.Enforce"
+
+	decision, err := s.Decide(ctx, input)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	if !decision.Allow {
+		return errs.E(errs.Unauthorized, op, fmt.Errorf("%s", strings.Join(decision.Reasons, "; ")))
+	}
+
+	return nil
+}
+
+func (s *policyService) DryRun(ctx context.Context, user *service.User, input service.PolicyDryRun) (*service.PolicyDecision, error) {
+	const op errs.Op = "policyService.DryRun"
+
+	if err := input.Input.Validate(); err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	if err := s.ensureCanManage(ctx, user, &input.Input.DatasetID); err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	policies, err := s.applicablePolicies(ctx, input.Input.DatasetID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if input.Candidate != nil {
+		if err := s.validatePolicy(*input.Candidate); err != nil {
+			return nil, errs.E(errs.InvalidRequest, op, err)
+		}
+
+	}
+
+	// The candidate is evaluated without caching its compiled condition,
+	// since it is not stored and would otherwise be kept in memory forever
+	var candidate *service.Policy
+	if input.Candidate != nil && (input.Candidate.DatasetID == nil || *input.Candidate.DatasetID == input.Input.DatasetID) {
+		candidate = &service.Policy{
+			DatasetID: input.Candidate.DatasetID,
+			Name:      input.Candidate.Name,
+			Condition: input.Candidate.Condition,
+			Reason:    input.Candidate.Reason,
+			CreatedBy: user.Email,
+			Created:   time.Now(),
+		}
+	}
+
+	decision, err := s.decide(ctx, input.Input, policies, candidate)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return decision, nil
+}
+
+// GetPolicies returns the organisation wide policies, including the built-in
+// ones, when no dataset is given, otherwise the policies of the dataset
+func (s *policyService) GetPolicies(ctx context.Context, user *service.User, datasetID *uuid.UUID) (*service.Policies, error) {
+	const op errs.Op = "policyService.GetPolicies"
+
+	if datasetID == nil {
+		policies, err := s.policyStorage.GetOrganisationPolicies(ctx)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		return &service.Policies{
+			Policies: append(slices.Clone(s.builtIn), policies...),
+		}, nil
+	}
+
+	if err := s.ensureDatasetOwner(ctx, user, *datasetID); err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	policies, err := s.policyStorage.GetPoliciesForDataset(ctx, *datasetID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return &service.Policies{
+		Policies: policies,
+	}, nil
+}
+
+func (s *policyService) CreatePolicy(ctx context.Context, user *service.User, input service.NewPolicy) (*service.Policy, error) {
+	const op errs.Op = "policyService.CreatePolicy"
+
+	if err := s.validatePolicy(input); err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	if err := s.ensureCanManage(ctx, user, input.DatasetID); err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	policy, err := s.policyStorage.CreatePolicy(ctx, input, user.Email)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return policy, nil
+}
+
+func (s *policyService) DeletePolicy(ctx context.Context, user *service.User, id uuid.UUID) error {
+	const op errs.Op = "policyService.DeletePolicy"
+
+	policy, err := s.policyStorage.GetPolicy(ctx, id)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	if err := s.ensureCanManage(ctx, user, policy.DatasetID); err != nil {
+		return errs.E(op, err)
+	}
+
+	err = s.policyStorage.DeletePolicy(ctx, id)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+// ensureCanManage requires the admin group for organisation wide policies,
+// and the owner of the dataset for dataset policies
+func (s *policyService) ensureCanManage(ctx context.Context, user *service.User, datasetID *uuid.UUID) error {
+	const op errs.Op = "policyService.ensureCanManage"
+
+	if datasetID == nil {
+		if err := ensureUserInGroup(user, s.adminGroup); err != nil {
+			return errs.E(op, err)
+		}
+
+		return nil
+	}
+
+	if err := s.ensureDatasetOwner(ctx, user, *datasetID); err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+func (s *policyService) ensureDatasetOwner(ctx context.Context, user *service.User, datasetID uuid.UUID) error {
+	const op errs.Op = "policyService.ensureDatasetOwner"
+
+	ds, err := s.dataProductStorage.GetDataset(ctx, datasetID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	dp, err := s.dataProductStorage.GetDataproduct(ctx, ds.DataproductID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	if err := ensureUserInGroup(user, dp.Owner.Group); err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+func (s *policyService) applicablePolicies(ctx context.Context, datasetID uuid.UUID) ([]*service.Policy, error) {
+	const op errs.Op = "policyService.applicablePolicies"
+
+	organisation, err := s.policyStorage.GetOrganisationPolicies(ctx)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	dataset, err := s.policyStorage.GetPoliciesForDataset(ctx, datasetID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	policies := slices.Clone(s.builtIn)
+	policies = append(policies, organisation...)
+	policies = append(policies, dataset...)
+
+	return policies, nil
+}
+
+// decide denies the action when any of the policies, or the candidate if
+// given, match. A policy that fails to evaluate also denies the action.
+func (s *policyService) decide(ctx context.Context, input service.PolicyInput, policies []*service.Policy, candidate *service.Policy) (*service.PolicyDecision, error) {
+	const op errs.Op = "policyService.decide"
+
+	if err := input.Validate(); err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	vars, err := s.policyVariables(ctx, input)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	decision := &service.PolicyDecision{
+		Allow:   true,
+		Reasons: []string{},
+	}
+
+	if candidate != nil {
+		policies = append(slices.Clone(policies), candidate)
+	}
+
+	for _, p := range policies {
+		deny, err := s.evaluatePolicy(ctx, p.Condition, p != candidate, vars)
+		if err != nil {
+			decision.Allow = false
+			decision.Reasons = append(decision.Reasons, fmt.Sprintf("%s: kunne ikke evalueres: %v", p.Name, err))
+
+			continue
+		}
+
+		if deny {
+			decision.Allow = false
+			decision.Reasons = append(decision.Reasons, fmt.Sprintf("%s: %s", p.Name, p.Reason))
+		}
+	}
+
+	return decision, nil
+}
+
+// policyVariables looks up the dataset and the access of the subject, and
+// returns the variables a policy condition is evaluated with
+func (s *policyService) policyVariables(ctx context.Context, input service.PolicyInput) (map[string]any, error) {
+	const op errs.Op = "policyService.policyVariables"
+
+	ds, err := s.dataProductStorage.GetDataset(ctx, input.DatasetID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	dp, err := s.dataProductStorage.GetDataproduct(ctx, ds.DataproductID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	access, err := s.accessStorage.ListActiveAccessToDataset(ctx, ds.ID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	groups := slices.Clone(input.SubjectGroups)
+	if groups == nil {
+		groups = []string{}
+	}
+
+	if input.SubjectType == service.SubjectTypeGroup {
+		groups = append(groups, input.Subject)
+	}
+
+	subjects := map[string]bool{}
+	for _, a := range access {
+		subjects[a.Subject] = true
+	}
+
+	hasAccess := subjects[input.SubjectType+":"+input.Subject]
+	for _, g := range groups {
+		hasAccess = hasAccess || subjects[service.SubjectTypeGroup+":"+g]
+	}
+
+	keywords := ds.Keywords
+	if keywords == nil {
+		keywords = []string{}
+	}
+
+	request := map[string]any{}
+	if input.Purpose != nil {
+		request["purpose"] = *input.Purpose
+	}
+
+	if input.PurposeStatus != nil {
+		request["purposeStatus"] = string(*input.PurposeStatus)
+	}
+
+	if input.Expires != nil {
+		request["expires"] = *input.Expires
+	}
+
+	return map[string]any{
+		"action": string(input.Action),
+		"subject": map[string]any{
+			"email":     input.Subject,
+			"type":      input.SubjectType,
+			"groups":    groups,
+			"isOwner":   slices.Contains(groups, dp.Owner.Group),
+			"hasAccess": hasAccess,
+		},
+		"dataset": map[string]any{
+			"id":            ds.ID.String(),
+			"name":          ds.Name,
+			"pii":           string(ds.Pii),
+			"keywords":      keywords,
+			"ownerGroup":    dp.Owner.Group,
+			"dataproductID": dp.ID.String(),
+		},
+		"request": request,
+		"now":     time.Now(),
+	}, nil
+}
+
+var policyEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("action", cel.StringType),
+		cel.Variable("subject", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("dataset", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("request", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("now", cel.TimestampType),
+	)
+})
+
+func compilePolicy(condition string) (cel.Program, error) {
+	env, err := policyEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	ast, iss := env.Compile(condition)
+	if iss != nil && iss.Err() != nil {
+		return nil, iss.Err()
+	}
+
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("condition must evaluate to a bool, got %v", ast.OutputType())
+	}
+
+	return env.Program(ast,
+		cel.CostLimit(policyCostLimit),
+		cel.InterruptCheckFrequency(policyInterruptCheckFrequency),
+	)
+}
+
+// program returns the compiled condition, conditions are compiled once and
+// cached since they are evaluated whenever access is requested or granted.
+// The cache is cleared when full, as the conditions of deleted and changed
+// policies would otherwise be kept.
+func (s *policyService) program(condition string) (cel.Program, error) {
+	s.mu.RLock()
+	prg, ok := s.programs[condition]
+	s.mu.RUnlock()
+
+	if ok {
+		return prg, nil
+	}
+
+	prg, err := compilePolicy(condition)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if len(s.programs) >= maxCachedPrograms {
+		s.programs = map[string]cel.Program{}
+	}
+	s.programs[condition] = prg
+	s.mu.Unlock()
+
+	return prg, nil
+}
+
+func (s *policyService) evaluatePolicy(ctx context.Context, condition string, cache bool, vars map[string]any) (bool, error) {
+	compile := compilePolicy
+	if cache {
+		compile = s.program
+	}
+
+	prg, err := compile(condition)
+	if err != nil {
+		return false, err
+	}
+
+	val, _, err := prg.ContextEval(ctx, vars)
+	if err != nil {
+		return false, err
+	}
+
+	deny, ok := val.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("condition evaluated to %v, not a bool", val.Value())
+	}
+
+	return deny, nil
+}
+
+func (s *policyService) validatePolicy(policy service.NewPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := compilePolicy(policy.Condition); err != nil {
+		return fmt.Errorf("invalid condition: %w", err)
+	}
+
+	return nil
+}
+
+// builtInPolicies are the organisation wide rules that used to be hardcoded
+// where access is granted and joinable views are created, the all users group
+// is configured with the subject type prefix, e.g. group:all-users@nav.no
+func builtInPolicies(allUsersGroup string) []*service.Policy {
+	allUsersEmail := strings.TrimPrefix(allUsersGroup, service.SubjectTypeGroup+":")
+
+	return []*service.Policy{
+		{
+			ID:        uuid.MustParse("6c0c6a4e-0c1a-4f7e-9a43-5d1f0b8f4f01"),
+			Name:      "sensitive-all-users",
+			Condition: fmt.Sprintf(`action in ["grant_access", "request_access"] && dataset.pii == "sensitive" && subject.email == %q`, allUsersEmail),
+			Reason:    "datasett som inneholder personopplysninger kan ikke gjøres tilgjengelig for alle interne brukere",
+			BuiltIn:   true,
+		},
+		{
+			ID:        uuid.MustParse("6c0c6a4e-0c1a-4f7e-9a43-5d1f0b8f4f02"),
+			Name:      "joinable-view-access",
+			Condition: `action == "joinable_view" && !subject.isOwner && !subject.hasAccess`,
+			Reason:    "brukeren må eie eller ha tilgang til datasettet for å lage sammenkoblbare viewer",
+			BuiltIn:   true,
+		},
+	}
+}
+
+func NewPolicyService(
+	policyStorage service.PolicyStorage,
+	dataProductStorage service.DataProductsStorage,
+	accessStorage service.AccessStorage,
+	adminGroup string,
+	allUsersGroup string,
+) *policyService {
+	return &policyService{
+		policyStorage:      policyStorage,
+		dataProductStorage: dataProductStorage,
+		accessStorage:      accessStorage,
+		adminGroup:         adminGroup,
+		builtIn:            builtInPolicies(allUsersGroup),
+		programs:           map[string]cel.Program{},
+	}
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluatePolicyCostLimit(t *testing.T) {
+	s := NewPolicyService(nil, nil, nil, "", "group:all-users@nav.no")
+
+	vars := map[string]any{
+		"action":  "grant_access",
+		"subject": map[string]any{"email": "ola.nordmann@nav.no"},
+		"dataset": map[string]any{"pii": "sensitive"},
+		"request": map[string]any{},
+		"now":     time.Now(),
+	}
+
+	testCases := []struct {
+		name      string
+		condition string
+		deny      bool
+		expectErr string
+	}{
+		{
+			name:      "cheap condition",
+			condition: `action == "grant_access" && dataset.pii == "sensitive"`,
+			deny:      true,
+		},
+		{
+			name: "expensive condition",
+			condition: `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9].all(a,
+				[0, 1, 2, 3, 4, 5, 6, 7, 8, 9].all(b,
+				[0, 1, 2, 3, 4, 5, 6, 7, 8, 9].all(c,
+				[0, 1, 2, 3, 4, 5, 6, 7, 8, 9].all(d, a + b + c + d >= 0))))`,
+			expectErr: "cost limit exceeded",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			deny, err := s.evaluatePolicy(context.Background(), tc.condition, false, vars)
+			if tc.expectErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.deny, deny)
+		})
+	}
+
+	assert.Empty(t, s.programs)
+}
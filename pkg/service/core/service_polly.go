@@ -2,16 +2,23 @@ package core
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/navikt/nada-backend/pkg/errs"
 	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/rs/zerolog"
 )
 
 var _ service.PollyService = &pollyService{}
 
 type pollyService struct {
-	pollyStorage service.PollyStorage
-	pollyAPI     service.PollyAPI
+	pollyStorage        service.PollyStorage
+	pollyAPI            service.PollyAPI
+	notificationService service.NotificationService
+	log                 zerolog.Logger
 }
 
 func (p *pollyService) SearchPolly(ctx context.Context, q string) ([]*service.QueryPolly, error) {
@@ -25,6 +32,182 @@ func (p *pollyService) SearchPolly(ctx context.Context, q string) ([]*service.Qu
 	return res, nil
 }
 
-func NewPollyService(storage service.PollyStorage, api service.PollyAPI) *pollyService {
-	return &pollyService{pollyStorage: storage, pollyAPI: api}
+func (p *pollyService) RevalidatePurposes(ctx context.Context) error {
+	const op errs.Op = "pollyService.RevalidatePurposes"
+
+	referenced, err := p.pollyStorage.GetReferencedPollyPurposes(ctx)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	now := time.Now()
+	for _, ref := range referenced {
+		purpose, err := p.fetchPurpose(ctx, ref, now)
+		if err != nil {
+			p.log.Error().Err(err).Msgf("fetching purpose %s from the treatment catalogue", ref.ExternalID)
+			continue
+		}
+
+		previous, err := p.pollyStorage.GetPollyPurpose(ctx, ref.ExternalID)
+		if err != nil && !errs.KindIs(errs.NotExist, err) {
+			return errs.E(op, err)
+		}
+
+		err = p.pollyStorage.UpdatePollyPurpose(ctx, purpose)
+		if err != nil {
+			return errs.E(op, err)
+		}
+
+		if purpose.Status == service.PollyPurposeStatusValid || (previous != nil && previous.Status == purpose.Status) {
+			continue
+		}
+
+		if err := p.notifyOwners(ctx, purpose); err != nil {
+			p.log.Error().Err(err).Msgf("notifying owners of grants with purpose %s", purpose.ExternalID)
+		}
+	}
+
+	return nil
+}
+
+// fetchPurpose returns the current status of the purpose, a treatment that
+// has been deleted from the catalogue is withdrawn
+func (p *pollyService) fetchPurpose(ctx context.Context, ref *service.QueryPolly, now time.Time) (*service.PollyPurpose, error) {
+	const op errs.Op = "pollyService.fetchPurpose"
+
+	process, err := p.pollyAPI.GetPollyProcess(ctx, ref.ExternalID)
+	if err != nil {
+		if errs.KindIs(errs.NotExist, err) {
+			return &service.PollyPurpose{
+				ExternalID: ref.ExternalID,
+				Name:       ref.Name,
+				Status:     service.PollyPurposeStatusWithdrawn,
+				Checked:    now,
+			}, nil
+		}
+
+		return nil, errs.E(op, err)
+	}
+
+	return &service.PollyPurpose{
+		ExternalID: ref.ExternalID,
+		Name:       process.Name,
+		Status:     pollyPurposeStatus(process, now),
+		ValidFrom:  process.ValidFrom,
+		ValidTo:    process.ValidTo,
+		Checked:    now,
+	}, nil
+}
+
+// pollyPurposeStatus returns expired when the treatment ended before today,
+// and withdrawn when it no longer has a legal basis
+func pollyPurposeStatus(process *service.PollyProcess, now time.Time) service.PollyPurposeStatus {
+	if process.ValidTo != nil && process.ValidTo.AddDate(0, 0, 1).Before(now) {
+		return service.PollyPurposeStatusExpired
+	}
+
+	if len(process.LegalBases) == 0 {
+		return service.PollyPurposeStatusWithdrawn
+	}
+
+	return service.PollyPurposeStatusValid
+}
+
+func (p *pollyService) notifyOwners(ctx context.Context, purpose *service.PollyPurpose) error {
+	const op errs.Op = "pollyService.notifyOwners"
+
+	accesses, err := p.pollyStorage.ListActiveAccessForPollyPurpose(ctx, purpose.ExternalID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	var groups []string
+
+	owned := map[string][]*service.AccessWithPollyPurpose{}
+	for _, a := range accesses {
+		if _, ok := owned[a.OwnerGroup]; !ok {
+			groups = append(groups, a.OwnerGroup)
+		}
+
+		owned[a.OwnerGroup] = append(owned[a.OwnerGroup], a)
+	}
+
+	status := "trukket tilbake"
+	if purpose.Status == service.PollyPurposeStatusExpired {
+		status = "utløpt"
+	}
+
+	for _, group := range groups {
+		var datasets []string
+		for _, a := range owned[group] {
+			if !slices.Contains(datasets, a.DatasetName) {
+				datasets = append(datasets, a.DatasetName)
+			}
+		}
+
+		err := p.notificationService.Notify(ctx, service.NewNotification{
+			EventType:  service.NotificationEventPurposeInvalidated,
+			Recipients: []string{service.SubjectTypeGroup + ":" + group},
+			Title:      "Behandlingsgrunnlag er ikke lenger gyldig",
+			Message: fmt.Sprintf(
+				"Behandlingsgrunnlaget for formålet %s i behandlingskatalogen er %s. %d tilganger til datasettene %s er gitt med dette formålet, og bør fjernes eller fornyes med et nytt formål.",
+				purpose.Name,
+				status,
+				len(owned[group]),
+				strings.Join(datasets, ", "),
+			),
+			TeamChannel: owned[group][0].TeamContact,
+		})
+		if err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *pollyService) GetPurposeHistory(ctx context.Context, externalID string) (*service.PollyPurposeHistory, error) {
+	const op errs.Op = "pollyService.GetPurposeHistory"
+
+	purpose, err := p.pollyStorage.GetPollyPurpose(ctx, externalID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	history, err := p.pollyStorage.GetPollyPurposeHistory(ctx, externalID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return &service.PollyPurposeHistory{
+		Purpose: purpose,
+		History: history,
+	}, nil
+}
+
+func (p *pollyService) GetAccessWithInvalidPurpose(ctx context.Context, user *service.User) (*service.AccessesWithPollyPurpose, error) {
+	const op errs.Op = "pollyService.GetAccessWithInvalidPurpose"
+
+	accesses, err := p.pollyStorage.ListAccessWithInvalidPollyPurposeForGroups(ctx, user.GoogleGroups.Emails())
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return &service.AccessesWithPollyPurpose{
+		Accesses: accesses,
+	}, nil
+}
+
+func NewPollyService(
+	storage service.PollyStorage,
+	api service.PollyAPI,
+	notificationService service.NotificationService,
+	log zerolog.Logger,
+) *pollyService {
+	return &pollyService{
+		pollyStorage:        storage,
+		pollyAPI:            api,
+		notificationService: notificationService,
+		log:                 log,
+	}
 }
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/navikt/nada-backend/pkg/errs"
@@ -97,6 +98,77 @@ func (s *productAreaService) GetProductAreaWithAssets(ctx context.Context, id uu
 	return productArea, nil
 }
 
+func (s *productAreaService) GetDataproducts(ctx context.Context, id uuid.UUID, teamID *uuid.UUID, page service.PageRequest) (*service.Page[*service.Dataproduct], error) {
+	const op errs.Op = "productAreaService.GetDataproducts"
+
+	teamIDs, err := s.teamIDs(ctx, id, teamID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	dataproducts, err := s.dataProductStorage.GetDataproductsByTeamIDPage(ctx, teamIDs, page)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return dataproducts, nil
+}
+
+func (s *productAreaService) GetStories(ctx context.Context, id uuid.UUID, teamID *uuid.UUID, page service.PageRequest) (*service.Page[*service.Story], error) {
+	const op errs.Op = "productAreaService.GetStories"
+
+	teamIDs, err := s.teamIDs(ctx, id, teamID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	stories, err := s.storyStorage.GetStoriesByTeamIDPage(ctx, teamIDs, page)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return stories, nil
+}
+
+func (s *productAreaService) GetInsightProducts(ctx context.Context, id uuid.UUID, teamID *uuid.UUID, page service.PageRequest) (*service.Page[*service.InsightProduct], error) {
+	const op errs.Op = "productAreaService.GetInsightProducts"
+
+	teamIDs, err := s.teamIDs(ctx, id, teamID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	insightProducts, err := s.insightProductStorage.GetInsightProductsByTeamIDPage(ctx, teamIDs, page)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return insightProducts, nil
+}
+
+// teamIDs returns the teams in the product area, or only the given team if it is in the product area
+func (s *productAreaService) teamIDs(ctx context.Context, id uuid.UUID, teamID *uuid.UUID) ([]uuid.UUID, error) {
+	const op errs.Op = "productAreaService.teamIDs"
+
+	pa, err := s.productAreaStorage.GetProductArea(ctx, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	teamIDs := make([]uuid.UUID, 0, len(pa.Teams))
+	for _, t := range pa.Teams {
+		if teamID == nil || t.ID == *teamID {
+			teamIDs = append(teamIDs, t.ID)
+		}
+	}
+
+	if teamID != nil && len(teamIDs) == 0 {
+		return nil, errs.E(errs.NotExist, op, fmt.Errorf("team %s is not in product area %s", teamID, id))
+	}
+
+	return teamIDs, nil
+}
+
 func (s *productAreaService) GetProductAreas(ctx context.Context) (*service.ProductAreasDto, error) {
 	const op errs.Op = "productAreaService.GetProductAreas"
 
@@ -0,0 +1,325 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/rs/zerolog"
+)
+
+const (
+	defaultProfilingBytesBudget = 100 * 1024 * 1024 * 1024
+	defaultProfilesLimit        = 30
+	maxProfilesLimit            = 365
+)
+
+var _ service.ProfilingService = &profilingService{}
+
+type profilingService struct {
+	profilingStorage   service.ProfilingStorage
+	dataProductStorage service.DataProductsStorage
+	accessStorage      service.AccessStorage
+	bigQueryAPI        service.BigQueryAPI
+	budget             int64
+	log                zerolog.Logger
+}
+
+func (s *profilingService) ProfileDatasources(ctx context.Context) error {
+	const op errs.Op = "profilingService.ProfileDatasources"
+
+	datasources, err := s.profilingStorage.GetDatasourcesToProfile(ctx)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	remaining := s.budget
+	for _, ds := range datasources {
+		profile, err := s.profileDatasource(ctx, ds, &remaining)
+		if err != nil {
+			s.log.Error().Err(err).Msgf("profiling %s.%s.%s", ds.ProjectID, ds.Dataset, ds.Table)
+			continue
+		}
+
+		if profile == nil {
+			continue
+		}
+
+		err = s.profilingStorage.CreateDatasetProfile(ctx, profile)
+		if err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	return nil
+}
+
+// profileDatasource returns nil if the datasource has no columns to profile,
+// or if profiling it would exceed the remaining budget
+func (s *profilingService) profileDatasource(ctx context.Context, ds *service.DatasourceToProfile, remaining *int64) (*service.DatasetProfile, error) {
+	const op errs.Op = "profilingService.profileDatasource"
+
+	columns := profileColumns(ds)
+	if len(columns) == 0 {
+		return nil, nil
+	}
+
+	query, err := s.bigQueryAPI.ComposeProfileQuery(ds.ProjectID, ds.Dataset, ds.Table, columns)
+	if err != nil {
+		return nil, errs.E(errs.Internal, op, err)
+	}
+
+	res, err := s.queryWithinBudget(ctx, query, 1, remaining)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if res == nil {
+		s.log.Info().Msgf("skipping profiling of %s.%s.%s, it would exceed the budget", ds.ProjectID, ds.Dataset, ds.Table)
+		return nil, nil
+	}
+
+	if len(res.Rows) != 1 || len(res.Rows[0]) != 1+4*len(columns) {
+		return nil, errs.E(errs.Internal, op, fmt.Errorf("unexpected profile result for %s.%s.%s", ds.ProjectID, ds.Dataset, ds.Table))
+	}
+
+	row := res.Rows[0]
+	rowCount := int64Value(row[0])
+
+	profile := &service.DatasetProfile{
+		DatasetID:      ds.DatasetID,
+		ProjectID:      ds.ProjectID,
+		Dataset:        ds.Dataset,
+		Table:          ds.Table,
+		RowCount:       rowCount,
+		BytesProcessed: res.BytesProcessed,
+	}
+
+	var lowCardinality []service.ProfileColumn
+
+	for i, c := range columns {
+		values := row[1+4*i : 5+4*i]
+
+		column := &service.ColumnProfile{
+			Name:   c.Name,
+			Type:   c.Type,
+			Min:    stringValue(values[2]),
+			Max:    stringValue(values[3]),
+			Masked: c.Masked,
+		}
+
+		if rowCount > 0 {
+			column.NullRate = float64(int64Value(values[0])) / float64(rowCount)
+		}
+
+		if values[1] != nil {
+			distinct := int64Value(values[1])
+			column.DistinctCount = &distinct
+
+			if !c.Masked && distinct > 0 && distinct <= service.ProfileTopValuesMaxDistinct {
+				lowCardinality = append(lowCardinality, c)
+			}
+		}
+
+		profile.Columns = append(profile.Columns, column)
+	}
+
+	if len(lowCardinality) > 0 {
+		err := s.profileTopValues(ctx, ds, lowCardinality, profile, remaining)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+	}
+
+	return profile, nil
+}
+
+func (s *profilingService) profileTopValues(ctx context.Context, ds *service.DatasourceToProfile, columns []service.ProfileColumn, profile *service.DatasetProfile, remaining *int64) error {
+	const op errs.Op = "profilingService.profileTopValues"
+
+	query, err := s.bigQueryAPI.ComposeTopValuesQuery(ds.ProjectID, ds.Dataset, ds.Table, columns, service.ProfileTopValuesLimit)
+	if err != nil {
+		return errs.E(errs.Internal, op, err)
+	}
+
+	res, err := s.queryWithinBudget(ctx, query, len(columns)*service.ProfileTopValuesLimit, remaining)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	if res == nil {
+		s.log.Info().Msgf("skipping top values of %s.%s.%s, it would exceed the budget", ds.ProjectID, ds.Dataset, ds.Table)
+		return nil
+	}
+
+	profile.BytesProcessed += res.BytesProcessed
+
+	byName := map[string]*service.ColumnProfile{}
+	for _, c := range profile.Columns {
+		byName[c.Name] = c
+	}
+
+	for _, row := range res.Rows {
+		if len(row) != 3 {
+			return errs.E(errs.Internal, op, fmt.Errorf("unexpected top values result for %s.%s.%s", ds.ProjectID, ds.Dataset, ds.Table))
+		}
+
+		name := stringValue(row[0])
+		if name == nil || byName[*name] == nil {
+			continue
+		}
+
+		byName[*name].TopValues = append(byName[*name].TopValues, &service.ColumnValueCount{
+			Value: stringValue(row[1]),
+			Count: int64Value(row[2]),
+		})
+	}
+
+	return nil
+}
+
+// queryWithinBudget returns nil if the estimated bytes processed by the
+// query exceeds the remaining budget, otherwise the budget is reduced by the
+// bytes processed
+func (s *profilingService) queryWithinBudget(ctx context.Context, query string, maxRows int, remaining *int64) (*service.BigQueryQueryResult, error) {
+	const op errs.Op = "profilingService.queryWithinBudget"
+
+	estimate, err := s.bigQueryAPI.EstimateQueryBytes(ctx, query)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if estimate > *remaining {
+		return nil, nil
+	}
+
+	res, err := s.bigQueryAPI.Query(ctx, query, maxRows, *remaining)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if res.BytesProcessed == 0 {
+		res.BytesProcessed = estimate
+	}
+
+	*remaining -= res.BytesProcessed
+
+	return res, nil
+}
+
+// profileColumns returns the scalar columns of the datasource, where the
+// columns with personal data are masked. A column has personal data if it
+// is pseudonymised or tagged with a PII tag, and every column of a dataset
+// with sensitive personal data is considered to have personal data
+func profileColumns(ds *service.DatasourceToProfile) []service.ProfileColumn {
+	pseudo := map[string]bool{}
+	for _, c := range ds.PseudoColumns {
+		pseudo[c.Name] = true
+	}
+
+	var columns []service.ProfileColumn
+
+	for _, c := range ds.Schema {
+		if strings.EqualFold(c.Mode, "REPEATED") {
+			continue
+		}
+
+		_, tagged := ds.PiiTags[c.Name]
+
+		column := service.ProfileColumn{
+			Name:   c.Name,
+			Type:   c.Type,
+			Masked: tagged || pseudo[c.Name] || ds.Pii == service.PiiLevelSensitive,
+		}
+
+		if column.Profilable() {
+			columns = append(columns, column)
+		}
+	}
+
+	return columns
+}
+
+func int64Value(v any) int64 {
+	switch n := v.(type) {
+	case int64:
+		return n
+	case int:
+		return int64(n)
+	case float64:
+		return int64(n)
+	case *big.Rat:
+		f, _ := n.Float64()
+		return int64(f)
+	}
+
+	return 0
+}
+
+func stringValue(v any) *string {
+	if v == nil {
+		return nil
+	}
+
+	s := fmt.Sprint(v)
+
+	return &s
+}
+
+func (s *profilingService) GetDatasetProfiles(ctx context.Context, user *service.User, datasetID uuid.UUID, limit int) (*service.DatasetProfiles, error) {
+	const op errs.Op = "profilingService.GetDatasetProfiles"
+
+	ds, err := s.dataProductStorage.GetDataset(ctx, datasetID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	// The profiles contain values of the columns, so they require access to the dataset
+	hasAccess, err := hasDatasetAccess(ctx, s.dataProductStorage, s.accessStorage, user, ds)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if !hasAccess {
+		return nil, errs.E(errs.Unauthorized, op, errs.UserName(user.Email), fmt.Errorf("user does not have access to dataset %v", ds.ID))
+	}
+
+	if limit <= 0 {
+		limit = defaultProfilesLimit
+	}
+
+	profiles, err := s.profilingStorage.GetDatasetProfiles(ctx, datasetID, min(limit, maxProfilesLimit))
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return &service.DatasetProfiles{
+		Profiles: profiles,
+	}, nil
+}
+
+func NewProfilingService(
+	storage service.ProfilingStorage,
+	dataProductStorage service.DataProductsStorage,
+	accessStorage service.AccessStorage,
+	bigQueryAPI service.BigQueryAPI,
+	budget int64,
+	log zerolog.Logger,
+) *profilingService {
+	if budget <= 0 {
+		budget = defaultProfilingBytesBudget
+	}
+
+	return &profilingService{
+		profilingStorage:   storage,
+		dataProductStorage: dataProductStorage,
+		accessStorage:      accessStorage,
+		bigQueryAPI:        bigQueryAPI,
+		budget:             budget,
+		log:                log,
+	}
+}
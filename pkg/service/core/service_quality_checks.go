@@ -0,0 +1,407 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/rs/zerolog"
+)
+
+var _ service.QualityCheckService = &qualityCheckService{}
+
+// The errors of a check that can not be run, only these messages are stored
+// with the run and sent to the owners, since the underlying errors from
+// BigQuery can contain values from the table
+var (
+	errQualityCheckInvalid     = errors.New("the query of the check is not valid")
+	errQualityCheckOtherTables = errors.New("the check reads from other tables than the table of the dataset")
+	errQualityCheckTooLarge    = fmt.Errorf("the check would process more than %d bytes", service.QualityCheckMaxBytesBilled)
+	errQualityCheckNotRun      = errors.New("the check could not be run")
+)
+
+type qualityCheckService struct {
+	dataCatalogueURL    string
+	qualityCheckStorage service.QualityCheckStorage
+	dataProductStorage  service.DataProductsStorage
+	accessStorage       service.AccessStorage
+	bigQueryAPI         service.BigQueryAPI
+	notificationService service.NotificationService
+	log                 zerolog.Logger
+}
+
+func (s *qualityCheckService) GetQualityChecks(ctx context.Context, datasetID uuid.UUID) (*service.QualityChecks, error) {
+	const op errs.Op = "qualityCheckService.GetQualityChecks"
+
+	ds, err := s.dataProductStorage.GetDataset(ctx, datasetID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	checks, err := s.qualityCheckStorage.GetQualityChecks(ctx, ds.ID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return &service.QualityChecks{
+		Checks: checks,
+	}, nil
+}
+
+func (s *qualityCheckService) CreateQualityCheck(ctx context.Context, user *service.User, datasetID uuid.UUID, input service.NewQualityCheck) (*service.QualityCheck, error) {
+	const op errs.Op = "qualityCheckService.CreateQualityCheck"
+
+	if err := input.Validate(); err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	ds, err := s.ensureOwner(ctx, user, datasetID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	dsrc := ds.Datasource
+	if dsrc == nil || dsrc.Scope == service.BigQueryScopeDataset {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("dataset %v does not have a BigQuery table", ds.ID))
+	}
+
+	existing, err := s.qualityCheckStorage.GetQualityChecks(ctx, ds.ID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	for _, c := range existing {
+		if c.Name == input.Name {
+			return nil, errs.E(errs.Exist, op, fmt.Errorf("dataset %v already has a check named %v", ds.ID, input.Name))
+		}
+	}
+
+	check := &service.QualityCheck{
+		DatasetID: ds.ID,
+		Name:      input.Name,
+		Kind:      input.Kind,
+		Column:    input.Column,
+		Condition: input.Condition,
+		SQL:       input.SQL,
+		Threshold: input.Threshold,
+	}
+
+	// Compose and dry run the query, so the owner gets to know right away
+	// if the check can not be run
+	_, err = s.composeQuery(ctx, dsrc.ProjectID, dsrc.Dataset, dsrc.Table, check)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	created, err := s.qualityCheckStorage.CreateQualityCheck(ctx, ds.ID, input, user.Email)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return created, nil
+}
+
+func (s *qualityCheckService) DeleteQualityCheck(ctx context.Context, user *service.User, id uuid.UUID) error {
+	const op errs.Op = "qualityCheckService.DeleteQualityCheck"
+
+	check, err := s.qualityCheckStorage.GetQualityCheck(ctx, id)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	_, err = s.ensureOwner(ctx, user, check.DatasetID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	err = s.qualityCheckStorage.DeleteQualityCheck(ctx, check.ID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+func (s *qualityCheckService) RunQualityCheck(ctx context.Context, user *service.User, id uuid.UUID) (*service.QualityCheckRun, error) {
+	const op errs.Op = "qualityCheckService.RunQualityCheck"
+
+	check, err := s.qualityCheckStorage.GetQualityCheck(ctx, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	_, err = s.ensureOwner(ctx, user, check.DatasetID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	checks, err := s.qualityCheckStorage.GetQualityChecksToRun(ctx, &check.ID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if len(checks) == 0 {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("the table of check %v is missing", check.ID))
+	}
+
+	run, err := s.runCheck(ctx, checks[0])
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return run, nil
+}
+
+func (s *qualityCheckService) GetQualityCheckRuns(ctx context.Context, user *service.User, id uuid.UUID, limit int) (*service.QualityCheckRuns, error) {
+	const op errs.Op = "qualityCheckService.GetQualityCheckRuns"
+
+	check, err := s.qualityCheckStorage.GetQualityCheck(ctx, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	ds, err := s.dataProductStorage.GetDataset(ctx, check.DatasetID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	hasAccess, err := hasDatasetAccess(ctx, s.dataProductStorage, s.accessStorage, user, ds)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if !hasAccess {
+		return nil, errs.E(errs.Unauthorized, op, errs.UserName(user.Email), fmt.Errorf("user does not have access to dataset %v", ds.ID))
+	}
+
+	if limit <= 0 {
+		limit = service.QualityCheckRunsDefaultLimit
+	}
+
+	runs, err := s.qualityCheckStorage.GetQualityCheckRuns(ctx, check.ID, min(limit, service.QualityCheckRunsMaxLimit))
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return &service.QualityCheckRuns{
+		Runs: runs,
+	}, nil
+}
+
+func (s *qualityCheckService) RunQualityChecks(ctx context.Context) error {
+	const op errs.Op = "qualityCheckService.RunQualityChecks"
+
+	checks, err := s.qualityCheckStorage.GetQualityChecksToRun(ctx, nil)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	// A check that can not be stored should not stop the other checks
+	for _, c := range checks {
+		_, err := s.runCheck(ctx, c)
+		if err != nil {
+			s.log.Error().Err(err).Msgf("running quality check %v of dataset %v", c.Check.ID, c.Check.DatasetID)
+		}
+	}
+
+	return nil
+}
+
+// runCheck stores the result of running the check, where a check that can
+// not be run gets the status error, and notifies the owners if the status
+// changed to failed or error
+func (s *qualityCheckService) runCheck(ctx context.Context, c *service.QualityCheckToRun) (*service.QualityCheckRun, error) {
+	const op errs.Op = "qualityCheckService.runCheck"
+
+	newRun := &service.NewQualityCheckRun{
+		CheckID: c.Check.ID,
+		Status:  service.QualityCheckStatusPassed,
+	}
+
+	measured, bytesProcessed, err := s.measure(ctx, c)
+	if err != nil {
+		s.log.Info().Err(err).Msgf("running quality check %v of dataset %v", c.Check.ID, c.Check.DatasetID)
+
+		message := runErrorMessage(err)
+		newRun.Status = service.QualityCheckStatusError
+		newRun.Error = &message
+	} else {
+		newRun.Measured = &measured
+		newRun.BytesProcessed = bytesProcessed
+
+		if !c.Check.Passed(measured) {
+			newRun.Status = service.QualityCheckStatusFailed
+		}
+	}
+
+	run, err := s.qualityCheckStorage.CreateQualityCheckRun(ctx, newRun)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if run.Status != service.QualityCheckStatusPassed && (c.PreviousStatus == nil || *c.PreviousStatus != run.Status) {
+		s.notifyOwners(ctx, c, run)
+	}
+
+	return run, nil
+}
+
+func (s *qualityCheckService) measure(ctx context.Context, c *service.QualityCheckToRun) (int64, int64, error) {
+	const op errs.Op = "qualityCheckService.measure"
+
+	query, err := s.composeQuery(ctx, c.ProjectID, c.Dataset, c.Table, c.Check)
+	if err != nil {
+		return 0, 0, errs.E(op, err)
+	}
+
+	res, err := s.bigQueryAPI.Query(ctx, query, 1, service.QualityCheckMaxBytesBilled)
+	if err != nil {
+		return 0, 0, errs.E(op, err)
+	}
+
+	if len(res.Rows) != 1 || len(res.Rows[0]) != 1 {
+		return 0, 0, errs.E(errs.Internal, op, fmt.Errorf("unexpected result of quality check %v", c.Check.ID))
+	}
+
+	return int64Value(res.Rows[0][0]), res.BytesProcessed, nil
+}
+
+// composeQuery returns the query of the check, and makes sure it does not
+// read from other tables than the table of the dataset, and does not
+// process more bytes than a check is allowed to
+func (s *qualityCheckService) composeQuery(ctx context.Context, projectID, datasetID, tableID string, check *service.QualityCheck) (string, error) {
+	const op errs.Op = "qualityCheckService.composeQuery"
+
+	query, err := s.bigQueryAPI.ComposeQualityCheckQuery(projectID, datasetID, tableID, check)
+	if err != nil {
+		return "", errs.E(errs.InvalidRequest, op, fmt.Errorf("%w: %w", errQualityCheckInvalid, err))
+	}
+
+	tables, err := s.bigQueryAPI.QueryReferencedTables(ctx, query)
+	if err != nil {
+		return "", errs.E(errs.InvalidRequest, op, fmt.Errorf("%w: %w", errQualityCheckInvalid, err))
+	}
+
+	table := fmt.Sprintf("%s.%s.%s", projectID, datasetID, tableID)
+	for _, t := range tables {
+		if t != table {
+			return "", errs.E(errs.InvalidRequest, op, fmt.Errorf("%w: check %v reads from %v, only %v is allowed", errQualityCheckOtherTables, check.Name, t, table))
+		}
+	}
+
+	estimate, err := s.bigQueryAPI.EstimateQueryBytes(ctx, query)
+	if err != nil {
+		return "", errs.E(errs.InvalidRequest, op, fmt.Errorf("%w: %w", errQualityCheckInvalid, err))
+	}
+
+	if estimate > service.QualityCheckMaxBytesBilled {
+		return "", errs.E(errs.InvalidRequest, op, fmt.Errorf("%w: check %v would process %d bytes", errQualityCheckTooLarge, check.Name, estimate))
+	}
+
+	return query, nil
+}
+
+// runErrorMessage returns the message stored with a run of a check that
+// could not be run, the error itself is only logged
+func runErrorMessage(err error) string {
+	for _, e := range []error{errQualityCheckInvalid, errQualityCheckOtherTables, errQualityCheckTooLarge} {
+		if errors.Is(err, e) {
+			return e.Error()
+		}
+	}
+
+	return errQualityCheckNotRun.Error()
+}
+
+func (s *qualityCheckService) ensureOwner(ctx context.Context, user *service.User, datasetID uuid.UUID) (*service.Dataset, error) {
+	const op errs.Op = "qualityCheckService.ensureOwner"
+
+	ds, err := s.dataProductStorage.GetDataset(ctx, datasetID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	dp, err := s.dataProductStorage.GetDataproduct(ctx, ds.DataproductID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if err := ensureUserInGroup(user, dp.Owner.Group); err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return ds, nil
+}
+
+// notifyOwners notifies the owners of the dataset that a check failed or
+// could not be run, failing to notify does not stop the other checks, so
+// errors are only logged
+func (s *qualityCheckService) notifyOwners(ctx context.Context, c *service.QualityCheckToRun, run *service.QualityCheckRun) {
+	link := datasetLink(s.dataCatalogueURL, c.DataproductID, c.DataproductName, c.Check.DatasetID)
+
+	err := s.notificationService.Notify(ctx, service.NewNotification{
+		EventType:   service.NotificationEventQualityCheckFailed,
+		Recipients:  []string{service.SubjectTypeGroup + ":" + c.OwnerGroup},
+		Title:       "Datakvalitetssjekk feilet",
+		Message:     createQualityCheckNotification(c, run),
+		Link:        &link,
+		ReferenceID: &c.Check.DatasetID,
+	})
+	if err != nil {
+		s.log.Error().Err(err).Msgf("notifying owners about quality check %v", c.Check.ID)
+	}
+}
+
+func createQualityCheckNotification(c *service.QualityCheckToRun, run *service.QualityCheckRun) string {
+	var message strings.Builder
+
+	fmt.Fprintf(
+		&message,
+		"Datakvalitetssjekken %s på datasettet %s i dataproduktet %s",
+		c.Check.Name,
+		c.DatasetName,
+		c.DataproductName,
+	)
+
+	if run.Status == service.QualityCheckStatusError {
+		message.WriteString(" kunne ikke kjøres.")
+
+		if run.Error != nil {
+			fmt.Fprintf(&message, "\nFeil: %s", *run.Error)
+		}
+
+		return message.String()
+	}
+
+	message.WriteString(" feilet.")
+
+	if run.Measured != nil {
+		fmt.Fprintf(&message, "\nMålt verdi: %d", *run.Measured)
+	}
+
+	return message.String()
+}
+
+func NewQualityCheckService(
+	dataCatalogueURL string,
+	qualityCheckStorage service.QualityCheckStorage,
+	dataProductStorage service.DataProductsStorage,
+	accessStorage service.AccessStorage,
+	bigQueryAPI service.BigQueryAPI,
+	notificationService service.NotificationService,
+	log zerolog.Logger,
+) *qualityCheckService {
+	return &qualityCheckService{
+		dataCatalogueURL:    dataCatalogueURL,
+		qualityCheckStorage: qualityCheckStorage,
+		dataProductStorage:  dataProductStorage,
+		accessStorage:       accessStorage,
+		bigQueryAPI:         bigQueryAPI,
+		notificationService: notificationService,
+		log:                 log,
+	}
+}
@@ -0,0 +1,535 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// recertificationReminderInterval is how often the owners are reminded
+	// of grants they have not reviewed
+	recertificationReminderInterval = 7 * 24 * time.Hour
+	// recertificationFinalNotice is how long before the deadline the owners
+	// are reminded every day
+	recertificationFinalNotice = 3 * 24 * time.Hour
+)
+
+var _ service.RecertificationService = &recertificationService{}
+
+type recertificationService struct {
+	recertificationStorage service.RecertificationStorage
+	dataProductStorage     service.DataProductsStorage
+	accessStorage          service.AccessStorage
+	accessService          service.AccessService
+	notificationService    service.NotificationService
+	adminGroup             string
+	gcpProjectID           string
+	log                    zerolog.Logger
+}
+
+// CreateCampaign snapshots the active grants of the datasets, and asks the
+// owner teams to review them, the user must be an admin or own every dataset
+func (s *recertificationService) CreateCampaign(ctx context.Context, user *service.User, input service.NewRecertificationCampaign) (*service.RecertificationCampaign, error) {
+	const op errs.Op = "recertificationService.CreateCampaign"
+
+	if err := input.Validate(); err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	var accesses []*service.Access
+
+	seen := map[uuid.UUID]bool{}
+	for _, id := range input.DatasetIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		ds, err := s.dataProductStorage.GetDataset(ctx, id)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		dp, err := s.dataProductStorage.GetDataproduct(ctx, ds.DataproductID)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		if !user.GoogleGroups.Contains(s.adminGroup) {
+			if err := ensureUserInGroup(user, dp.Owner.Group); err != nil {
+				return nil, errs.E(op, err)
+			}
+		}
+
+		active, err := s.accessStorage.ListActiveAccessToDataset(ctx, ds.ID)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		accesses = append(accesses, active...)
+	}
+
+	campaign, err := s.recertificationStorage.CreateRecertificationCampaign(ctx, input, accesses, user.Email)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	reviews, err := s.recertificationStorage.GetRecertificationReviews(ctx, campaign.ID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	err = s.notifyOwners(ctx, campaign, reviews, "Gjennomgang av tilganger")
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	campaign.Progress = recertificationProgress(reviews)
+
+	return campaign, nil
+}
+
+// GetCampaigns returns every campaign to admins, and otherwise the campaigns
+// the user has created or has grants to review in
+func (s *recertificationService) GetCampaigns(ctx context.Context, user *service.User) (*service.RecertificationCampaigns, error) {
+	const op errs.Op = "recertificationService.GetCampaigns"
+
+	var campaigns []*service.RecertificationCampaign
+
+	var err error
+	if user.GoogleGroups.Contains(s.adminGroup) {
+		campaigns, err = s.recertificationStorage.GetRecertificationCampaigns(ctx)
+	} else {
+		campaigns, err = s.recertificationStorage.GetRecertificationCampaignsForGroups(ctx, user.GoogleGroups.Emails(), user.Email)
+	}
+
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	for _, c := range campaigns {
+		reviews, err := s.recertificationStorage.GetRecertificationReviews(ctx, c.ID)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		c.Progress = recertificationProgress(reviews)
+	}
+
+	return &service.RecertificationCampaigns{
+		Campaigns: campaigns,
+	}, nil
+}
+
+func (s *recertificationService) GetCampaign(ctx context.Context, user *service.User, id uuid.UUID) (*service.RecertificationCampaignWithReviews, error) {
+	const op errs.Op = "recertificationService.GetCampaign"
+
+	campaign, err := s.recertificationStorage.GetRecertificationCampaign(ctx, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	reviews, err := s.recertificationStorage.GetRecertificationReviews(ctx, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	canView := campaign.CreatedBy == user.Email || user.GoogleGroups.Contains(s.adminGroup)
+	for _, r := range reviews {
+		canView = canView || user.GoogleGroups.Contains(r.OwnerGroup)
+	}
+
+	if !canView {
+		return nil, errs.E(errs.Unauthorized, op, errs.UserName(user.Email), fmt.Errorf("user can not view recertification campaign %v", id))
+	}
+
+	campaign.Progress = recertificationProgress(reviews)
+
+	return &service.RecertificationCampaignWithReviews{
+		RecertificationCampaign: *campaign,
+		Reviews:                 reviews,
+	}, nil
+}
+
+// ReviewAccess confirms, shortens or revokes a grant on behalf of the owner
+// team, a shortened grant is revoked when it expires
+func (s *recertificationService) ReviewAccess(ctx context.Context, user *service.User, reviewID uuid.UUID, input service.RecertificationReviewInput) (*service.RecertificationReview, error) {
+	const op errs.Op = "recertificationService.ReviewAccess"
+
+	if err := input.Validate(); err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err)
+	}
+
+	review, err := s.recertificationStorage.GetRecertificationReview(ctx, reviewID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if err := ensureUserInGroup(user, review.OwnerGroup); err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	campaign, err := s.recertificationStorage.GetRecertificationCampaign(ctx, review.CampaignID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if campaign.Completed != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("recertification campaign %v is completed", campaign.ID))
+	}
+
+	if review.Decision != nil {
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("access %v has already been reviewed", review.AccessID))
+	}
+
+	access, err := s.accessStorage.GetAccessToDataset(ctx, review.AccessID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	switch input.Decision {
+	case service.RecertificationDecisionShortened:
+		if access.Revoked != nil {
+			return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("access %v is revoked", access.ID))
+		}
+
+		if access.Expires != nil && !input.Expires.Before(*access.Expires) {
+			return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("expires must be before the current expiry %s", access.Expires.Format(time.RFC3339)))
+		}
+	case service.RecertificationDecisionRevoked:
+		if access.Revoked == nil {
+			err = s.accessService.RevokeAccessToDataset(ctx, user, access.ID, s.gcpProjectID)
+			if err != nil {
+				return nil, errs.E(op, err)
+			}
+		}
+	}
+
+	err = s.recertificationStorage.ReviewRecertification(ctx, review, input.Decision, input.Expires, &user.Email)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	review, err = s.recertificationStorage.GetRecertificationReview(ctx, reviewID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return review, nil
+}
+
+// ExportCampaign returns the campaign with its progress and every decision,
+// which after the deadline is the evidence of the recertification
+func (s *recertificationService) ExportCampaign(ctx context.Context, user *service.User, id uuid.UUID, format service.RecertificationExportFormat) (*service.RecertificationExport, error) {
+	const op errs.Op = "recertificationService.ExportCampaign"
+
+	campaign, err := s.GetCampaign(ctx, user, id)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	switch format {
+	case service.RecertificationExportFormatJSON:
+		data, err := json.Marshal(campaign)
+		if err != nil {
+			return nil, errs.E(errs.Internal, op, err)
+		}
+
+		return &service.RecertificationExport{
+			ContentType: "application/json; charset=utf-8",
+			Data:        data,
+		}, nil
+	case service.RecertificationExportFormatCSV:
+		data, err := recertificationCSV(campaign)
+		if err != nil {
+			return nil, errs.E(errs.Internal, op, err)
+		}
+
+		return &service.RecertificationExport{
+			ContentType: "text/csv; charset=utf-8",
+			Data:        data,
+		}, nil
+	default:
+		return nil, errs.E(errs.InvalidRequest, op, errs.Parameter("format"), fmt.Errorf("unsupported export format: %s", format))
+	}
+}
+
+func (s *recertificationService) ProcessCampaigns(ctx context.Context) error {
+	const op errs.Op = "recertificationService.ProcessCampaigns"
+
+	campaigns, err := s.recertificationStorage.GetOpenRecertificationCampaigns(ctx)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	now := time.Now()
+	for _, c := range campaigns {
+		reviews, err := s.recertificationStorage.GetRecertificationReviews(ctx, c.ID)
+		if err != nil {
+			return errs.E(op, err)
+		}
+
+		if !now.Before(c.Deadline) {
+			if err := s.closeCampaign(ctx, c, reviews); err != nil {
+				s.log.Error().Err(err).Msgf("closing recertification campaign %v", c.ID)
+			}
+
+			continue
+		}
+
+		if !recertificationReminderDue(c, now) {
+			continue
+		}
+
+		if err := s.notifyOwners(ctx, c, reviews, "Påminnelse: gjennomgang av tilganger"); err != nil {
+			s.log.Error().Err(err).Msgf("reminding owners of recertification campaign %v", c.ID)
+			continue
+		}
+
+		if err := s.recertificationStorage.SetRecertificationCampaignReminded(ctx, c.ID); err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	return nil
+}
+
+// closeCampaign records the grants that were not reviewed before the
+// deadline, and revokes them if the campaign is configured to. The revocation
+// is done on behalf of the owner team, on the authority of the campaign
+func (s *recertificationService) closeCampaign(ctx context.Context, c *service.RecertificationCampaign, reviews []*service.RecertificationReview) error {
+	const op errs.Op = "recertificationService.closeCampaign"
+
+	for _, r := range reviews {
+		if r.Decision != nil {
+			continue
+		}
+
+		decision := service.RecertificationDecisionUnconfirmed
+
+		if c.RevokeUnconfirmed {
+			access, err := s.accessStorage.GetAccessToDataset(ctx, r.AccessID)
+			if err != nil {
+				return errs.E(op, err)
+			}
+
+			if access.Revoked == nil {
+				granter := &service.User{
+					Email:        c.CreatedBy,
+					GoogleGroups: service.Groups{{Email: r.OwnerGroup}},
+				}
+
+				err = s.accessService.RevokeAccessToDataset(ctx, granter, access.ID, s.gcpProjectID)
+				if err != nil {
+					return errs.E(op, err)
+				}
+			}
+
+			decision = service.RecertificationDecisionRevokedAtDeadline
+		}
+
+		err := s.recertificationStorage.ReviewRecertification(ctx, r, decision, nil, nil)
+		if err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	err := s.recertificationStorage.CompleteRecertificationCampaign(ctx, c.ID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+// notifyOwners notifies each owner team of the grants it has not yet reviewed
+func (s *recertificationService) notifyOwners(ctx context.Context, c *service.RecertificationCampaign, reviews []*service.RecertificationReview, title string) error {
+	const op errs.Op = "recertificationService.notifyOwners"
+
+	var groups []string
+
+	pending := map[string][]*service.RecertificationReview{}
+	for _, r := range reviews {
+		if r.Decision != nil {
+			continue
+		}
+
+		if _, ok := pending[r.OwnerGroup]; !ok {
+			groups = append(groups, r.OwnerGroup)
+		}
+
+		pending[r.OwnerGroup] = append(pending[r.OwnerGroup], r)
+	}
+
+	for _, group := range groups {
+		var datasets []string
+		for _, r := range pending[group] {
+			if !slices.Contains(datasets, r.DatasetName) {
+				datasets = append(datasets, r.DatasetName)
+			}
+		}
+
+		message := fmt.Sprintf(
+			"Tilgangsgjennomgangen %s har %d tilganger til datasettene %s som må bekreftes, forkortes eller fjernes innen %s.",
+			c.Name,
+			len(pending[group]),
+			strings.Join(datasets, ", "),
+			c.Deadline.Format("02.01.2006"),
+		)
+		if c.RevokeUnconfirmed {
+			message += " Tilganger som ikke er gjennomgått innen fristen blir fjernet."
+		}
+
+		err := s.notificationService.Notify(ctx, service.NewNotification{
+			EventType:   service.NotificationEventAccessRecertification,
+			Recipients:  []string{service.SubjectTypeGroup + ":" + group},
+			Title:       title,
+			Message:     message,
+			ReferenceID: &c.ID,
+			TeamChannel: pending[group][0].TeamContact,
+		})
+		if err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	return nil
+}
+
+// recertificationReminderDue reminds the owners every reminder interval, and
+// every day when the deadline is close
+func recertificationReminderDue(c *service.RecertificationCampaign, now time.Time) bool {
+	last := c.Created
+	if c.LastReminded != nil {
+		last = *c.LastReminded
+	}
+
+	interval := recertificationReminderInterval
+	if c.Deadline.Sub(now) <= recertificationFinalNotice {
+		interval = 24 * time.Hour
+	}
+
+	return now.Sub(last) >= interval
+}
+
+func recertificationProgress(reviews []*service.RecertificationReview) service.RecertificationProgress {
+	progress := service.RecertificationProgress{
+		Total: len(reviews),
+	}
+
+	for _, r := range reviews {
+		if r.Decision == nil {
+			progress.Pending++
+			continue
+		}
+
+		switch *r.Decision {
+		case service.RecertificationDecisionConfirmed:
+			progress.Confirmed++
+		case service.RecertificationDecisionShortened:
+			progress.Shortened++
+		case service.RecertificationDecisionRevoked:
+			progress.Revoked++
+		case service.RecertificationDecisionRevokedAtDeadline:
+			progress.RevokedAtDeadline++
+		case service.RecertificationDecisionUnconfirmed:
+			progress.Unconfirmed++
+		}
+	}
+
+	return progress
+}
+
+func recertificationCSV(c *service.RecertificationCampaignWithReviews) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+
+	err := w.Write([]string{
+		"campaign", "deadline", "dataproduct", "dataset", "owner_group", "subject", "owner", "granter",
+		"expires", "decision", "new_expires", "reviewed_by", "reviewed",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range c.Reviews {
+		decision := "pending"
+		if r.Decision != nil {
+			decision = string(*r.Decision)
+		}
+
+		reviewedBy := ""
+		if r.ReviewedBy != nil {
+			reviewedBy = *r.ReviewedBy
+		}
+
+		err := w.Write([]string{
+			c.Name,
+			c.Deadline.Format(time.RFC3339),
+			r.DataproductName,
+			r.DatasetName,
+			r.OwnerGroup,
+			r.Subject,
+			r.Owner,
+			r.Granter,
+			formatTimePtr(r.Expires),
+			decision,
+			formatTimePtr(r.NewExpires),
+			reviewedBy,
+			formatTimePtr(r.Reviewed),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func formatTimePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+func NewRecertificationService(
+	recertificationStorage service.RecertificationStorage,
+	dataProductStorage service.DataProductsStorage,
+	accessStorage service.AccessStorage,
+	accessService service.AccessService,
+	notificationService service.NotificationService,
+	adminGroup string,
+	gcpProjectID string,
+	log zerolog.Logger,
+) *recertificationService {
+	return &recertificationService{
+		recertificationStorage: recertificationStorage,
+		dataProductStorage:     dataProductStorage,
+		accessStorage:          accessStorage,
+		accessService:          accessService,
+		notificationService:    notificationService,
+		adminGroup:             adminGroup,
+		gcpProjectID:           gcpProjectID,
+		log:                    log,
+	}
+}
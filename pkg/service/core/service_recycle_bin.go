@@ -0,0 +1,237 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/rs/zerolog"
+)
+
+var _ service.RecycleBinService = &recycleBinService{}
+
+type recycleBinService struct {
+	recycleBinStorage     service.RecycleBinStorage
+	dataProductStorage    service.DataProductsStorage
+	storyStorage          service.StoryStorage
+	storyAPI              service.StoryAPI
+	insightProductStorage service.InsightProductStorage
+	accessStorage         service.AccessStorage
+	datasourceStorage     service.DatasourceStorage
+	providers             service.DatasourceProviders
+	metabaseService       service.MetabaseService
+	log                   zerolog.Logger
+}
+
+func (s *recycleBinService) GetDeletedItems(ctx context.Context, user *service.User, group string) ([]*service.DeletedItem, error) {
+	const op errs.Op = "recycleBinService.GetDeletedItems"
+
+	groups := user.GoogleGroups.Emails()
+	if group != "" {
+		if err := ensureUserInGroup(user, group); err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		groups = []string{group}
+	}
+
+	if len(groups) == 0 {
+		return []*service.DeletedItem{}, nil
+	}
+
+	items, err := s.recycleBinStorage.GetDeletedItems(ctx, service.DeletedItemsFilter{
+		Groups: groups,
+	})
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return items, nil
+}
+
+func (s *recycleBinService) RestoreItem(ctx context.Context, user *service.User, id uuid.UUID) (*service.DeletedItem, error) {
+	const op errs.Op = "recycleBinService.RestoreItem"
+
+	items, err := s.recycleBinStorage.GetDeletedItems(ctx, service.DeletedItemsFilter{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	if len(items) == 0 {
+		return nil, errs.E(errs.NotExist, op, fmt.Errorf("no deleted item with id %v", id))
+	}
+
+	item := items[0]
+
+	if err := ensureUserInGroup(user, item.Group); err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	switch item.Type {
+	case service.DeletedItemTypeDataproduct:
+		err = s.restoreDataproduct(ctx, id)
+	case service.DeletedItemTypeStory:
+		err = s.recycleBinStorage.RestoreStory(ctx, id)
+	case service.DeletedItemTypeInsightProduct:
+		err = s.recycleBinStorage.RestoreInsightProduct(ctx, id)
+	default:
+		err = errs.E(errs.Internal, op, fmt.Errorf("unknown deleted item type %s", item.Type))
+	}
+
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return item, nil
+}
+
+// restoreDataproduct brings the dataproduct back, and re-establishes the
+// IAM bindings and Metabase mappings of its datasets, which were removed
+// when the dataproduct was deleted
+func (s *recycleBinService) restoreDataproduct(ctx context.Context, id uuid.UUID) error {
+	const op errs.Op = "recycleBinService.restoreDataproduct"
+
+	err := s.recycleBinStorage.RestoreDataproduct(ctx, id)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	dp, err := s.dataProductStorage.GetDataproduct(ctx, id)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	for _, d := range dp.Datasets {
+		ds, err := s.dataProductStorage.GetDataset(ctx, d.ID)
+		if err != nil {
+			return errs.E(op, err)
+		}
+
+		err = s.grantDatasetAccesses(ctx, ds.ID)
+		if err != nil {
+			return errs.E(op, err)
+		}
+
+		if len(ds.Mappings) > 0 {
+			err = s.metabaseService.MapDataset(ctx, ds.ID, ds.Mappings)
+			if err != nil {
+				return errs.E(op, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *recycleBinService) grantDatasetAccesses(ctx context.Context, id uuid.UUID) error {
+	const op errs.Op = "recycleBinService.grantDatasetAccesses"
+
+	ref, provider, err := datasetDatasource(ctx, s.datasourceStorage, s.providers, id)
+	if err != nil {
+		if errs.KindIs(errs.NotExist, err) {
+			return nil
+		}
+
+		return errs.E(op, err)
+	}
+
+	accesses, err := s.accessStorage.ListActiveAccessToDataset(ctx, id)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	for _, a := range accesses {
+		err := provider.Grant(ctx, *ref, a.Subject)
+		if err != nil {
+			return errs.E(op, err)
+		}
+	}
+
+	return nil
+}
+
+// PurgeDeletedItems permanently deletes the items that have been in the
+// recycle bin for longer than the retention period. An item that fails to
+// be purged is logged and retried on the next run
+func (s *recycleBinService) PurgeDeletedItems(ctx context.Context) error {
+	const op errs.Op = "recycleBinService.PurgeDeletedItems"
+
+	before := time.Now().Add(-service.RecycleBinRetention)
+
+	items, err := s.recycleBinStorage.GetDeletedItems(ctx, service.DeletedItemsFilter{
+		DeletedBefore: &before,
+	})
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	for _, item := range items {
+		switch item.Type {
+		case service.DeletedItemTypeDataproduct:
+			err = s.dataProductStorage.DeleteDataproduct(ctx, item.ID)
+		case service.DeletedItemTypeStory:
+			err = s.purgeStory(ctx, item.ID)
+		case service.DeletedItemTypeInsightProduct:
+			err = s.insightProductStorage.DeleteInsightProduct(ctx, item.ID)
+		default:
+			err = errs.E(errs.Internal, op, fmt.Errorf("unknown deleted item type %s", item.Type))
+		}
+
+		if err != nil {
+			s.log.Error().Err(err).Msgf("purging %s %v", item.Type, item.ID)
+			continue
+		}
+
+		s.log.Info().Msgf("purged %s %v deleted by %s", item.Type, item.ID, item.DeletedBy)
+	}
+
+	return nil
+}
+
+func (s *recycleBinService) purgeStory(ctx context.Context, id uuid.UUID) error {
+	const op errs.Op = "recycleBinService.purgeStory"
+
+	// The files are deleted first, so a failure leaves the story in the
+	// recycle bin to be purged on the next run
+	if err := s.storyAPI.DeleteStoryFolder(ctx, id.String()); err != nil {
+		return errs.E(op, err)
+	}
+
+	err := s.storyStorage.DeleteStory(ctx, id)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+func NewRecycleBinService(
+	recycleBinStorage service.RecycleBinStorage,
+	dataProductStorage service.DataProductsStorage,
+	storyStorage service.StoryStorage,
+	storyAPI service.StoryAPI,
+	insightProductStorage service.InsightProductStorage,
+	accessStorage service.AccessStorage,
+	datasourceStorage service.DatasourceStorage,
+	providers service.DatasourceProviders,
+	metabaseService service.MetabaseService,
+	log zerolog.Logger,
+) *recycleBinService {
+	return &recycleBinService{
+		recycleBinStorage:     recycleBinStorage,
+		dataProductStorage:    dataProductStorage,
+		storyStorage:          storyStorage,
+		storyAPI:              storyAPI,
+		insightProductStorage: insightProductStorage,
+		accessStorage:         accessStorage,
+		datasourceStorage:     datasourceStorage,
+		providers:             providers,
+		metabaseService:       metabaseService,
+		log:                   log,
+	}
+}
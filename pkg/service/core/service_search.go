@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -21,6 +22,24 @@ type searchService struct {
 func (s *searchService) Search(ctx context.Context, query *service.SearchOptions) (*service.SearchResult, error) {
 	const op errs.Op = "searchService.Search"
 
+	total, err := s.searchStorage.SearchCount(ctx, query)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	// Fall back to trigram similarity when the full text search gives no hits,
+	// so that typos and partial words still give results
+	if total == 0 && query.Text != "" && !query.Fuzzy {
+		fuzzy := *query
+		fuzzy.Fuzzy = true
+		query = &fuzzy
+
+		total, err = s.searchStorage.SearchCount(ctx, query)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+	}
+
 	res, err := s.searchStorage.Search(ctx, query)
 	if err != nil {
 		return nil, errs.E(op, err)
@@ -28,12 +47,15 @@ func (s *searchService) Search(ctx context.Context, query *service.SearchOptions
 
 	order := map[string]int{}
 	var dataproducts []uuid.UUID
+	var datasets []uuid.UUID
 	var stories []uuid.UUID
 	excerpts := map[uuid.UUID]string{}
 	for i, sr := range res {
 		switch sr.ElementType {
 		case "dataproduct":
 			dataproducts = append(dataproducts, sr.ElementID)
+		case "dataset":
+			datasets = append(datasets, sr.ElementID)
 		case "story":
 			stories = append(stories, sr.ElementID)
 		default:
@@ -61,6 +83,19 @@ func (s *searchService) Search(ctx context.Context, query *service.SearchOptions
 		})
 	}
 
+	// The hits are limited to a page, so the datasets are read one by one
+	for _, id := range datasets {
+		ds, err := s.dataProductsStorage.GetDataset(ctx, id)
+		if err != nil {
+			return nil, errs.E(op, err)
+		}
+
+		ret = append(ret, &service.SearchResultRow{
+			Excerpt: excerpts[ds.ID],
+			Result:  ds,
+		})
+	}
+
 	for _, s := range ss {
 		ret = append(ret, &service.SearchResultRow{
 			Excerpt: excerpts[s.ID],
@@ -70,8 +105,43 @@ func (s *searchService) Search(ctx context.Context, query *service.SearchOptions
 
 	sortSearch(ret, order)
 
+	facets, err := s.searchStorage.SearchFacets(ctx, query)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
 	return &service.SearchResult{
 		Results: ret,
+		Total:   total,
+		Facets:  facets,
+		Fuzzy:   query.Fuzzy,
+	}, nil
+}
+
+func (s *searchService) Suggest(ctx context.Context, prefix string, limit int) (*service.SearchSuggestions, error) {
+	const op errs.Op = "searchService.Suggest"
+
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return &service.SearchSuggestions{
+			Names:    []*service.SearchSuggestion{},
+			Keywords: []service.KeywordItem{},
+		}, nil
+	}
+
+	names, err := s.searchStorage.SearchSuggestNames(ctx, prefix, limit)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	keywords, err := s.searchStorage.SearchSuggestKeywords(ctx, prefix, limit)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return &service.SearchSuggestions{
+		Names:    names,
+		Keywords: keywords,
 	}, nil
 }
 
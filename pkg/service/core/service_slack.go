@@ -1,12 +1,19 @@
 package core
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/navikt/nada-backend/pkg/errs"
 	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/rs/zerolog"
 )
 
 type slackService struct {
-	slackAPI service.SlackAPI
+	slackAPI        service.SlackAPI
+	googleGroupsAPI service.GoogleGroupsAPI
+	accessService   service.AccessService
+	log             zerolog.Logger
 }
 
 func (s *slackService) IsValidSlackChannel(name string) error {
@@ -20,8 +27,97 @@ func (s *slackService) IsValidSlackChannel(name string) error {
 	return nil
 }
 
-func NewSlackService(slackAPI service.SlackAPI) service.SlackService {
+// HandleInteraction approves or denies an access request on behalf of the
+// Slack user who clicked the button, with the same ownership checks as in the
+// web UI, a denial first opens a modal asking for the reason. The outcome
+// replaces the buttons in the original message, and a failure is shown only
+// to the Slack user
+func (s *slackService) HandleInteraction(ctx context.Context, interaction service.SlackInteraction) error {
+	const op errs.Op = "slackService.HandleInteraction"
+
+	if interaction.Type == service.SlackInteractionDenyAccessRequest {
+		err := s.slackAPI.OpenDenyAccessRequestModal(interaction.TriggerID, interaction)
+		if err != nil {
+			return errs.E(op, err)
+		}
+
+		return nil
+	}
+
+	user, err := s.slackUser(ctx, interaction.UserID)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	var outcome string
+
+	switch interaction.Type {
+	case service.SlackInteractionApproveAccessRequest:
+		err = s.accessService.ApproveAccessRequest(ctx, user, interaction.AccessRequestID)
+		outcome = fmt.Sprintf(":white_check_mark: Søknaden om tilgang ble godkjent av %s.", user.Email)
+	case service.SlackInteractionDenyAccessRequestReason:
+		err = s.accessService.DenyAccessRequest(ctx, user, interaction.AccessRequestID, interaction.Reason)
+		outcome = fmt.Sprintf(":x: Søknaden om tilgang ble avslått av %s.", user.Email)
+		if interaction.Reason != nil && *interaction.Reason != "" {
+			outcome += fmt.Sprintf("\nBegrunnelse: %s", *interaction.Reason)
+		}
+	default:
+		return errs.E(errs.InvalidRequest, op, fmt.Errorf("unknown slack interaction %v", interaction.Type))
+	}
+
+	if err != nil {
+		s.log.Warn().Err(err).Msgf("handling slack interaction %v on access request %v", interaction.Type, interaction.AccessRequestID)
+
+		message := "Kunne ikke behandle søknaden om tilgang."
+		if errs.KindIs(errs.Unauthorized, err) {
+			message = "Du må være medlem av gruppen som eier dataproduktet for å behandle søknaden om tilgang."
+		}
+
+		if err := s.slackAPI.SendSlackEphemeral(interaction.Channel, interaction.UserID, message); err != nil {
+			return errs.E(op, err)
+		}
+
+		return nil
+	}
+
+	err = s.slackAPI.UpdateAccessRequestMessage(interaction.Channel, interaction.MessageTimestamp, outcome)
+	if err != nil {
+		return errs.E(op, err)
+	}
+
+	return nil
+}
+
+// slackUser maps the Slack user to the catalogue user with the same email
+func (s *slackService) slackUser(ctx context.Context, userID string) (*service.User, error) {
+	const op errs.Op = "slackService.slackUser"
+
+	email, err := s.slackAPI.GetSlackUserEmail(userID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	groups, err := s.googleGroupsAPI.Groups(ctx, &email)
+	if err != nil {
+		return nil, errs.E(errs.IO, op, err)
+	}
+
+	return &service.User{
+		Email:        email,
+		GoogleGroups: groups,
+	}, nil
+}
+
+func NewSlackService(
+	slackAPI service.SlackAPI,
+	googleGroupsAPI service.GoogleGroupsAPI,
+	accessService service.AccessService,
+	log zerolog.Logger,
+) service.SlackService {
 	return &slackService{
-		slackAPI: slackAPI,
+		slackAPI:        slackAPI,
+		googleGroupsAPI: googleGroupsAPI,
+		accessService:   accessService,
+		log:             log,
 	}
 }
@@ -145,15 +145,13 @@ func (s *storyService) DeleteStory(ctx context.Context, user *service.User, stor
 		return nil, errs.E(errs.Unauthorized, op, errs.UserName(user.Email), fmt.Errorf("user not in the group of the data story: %s", story.Group))
 	}
 
-	err = s.storyStorage.DeleteStory(ctx, storyID)
+	// The story files are kept in the bucket until the story is purged
+	// from the recycle bin
+	err = s.storyStorage.SoftDeleteStory(ctx, storyID, user.Email)
 	if err != nil {
 		return nil, errs.E(op, err)
 	}
 
-	if err := s.storyAPI.DeleteStoryFolder(ctx, storyID.String()); err != nil {
-		return nil, errs.E(op, err)
-	}
-
 	return story, nil
 }
 
@@ -169,6 +167,10 @@ func (s *storyService) UpdateStory(ctx context.Context, user *service.User, stor
 		return nil, errs.E(errs.Unauthorized, op, errs.UserName(user.Email), fmt.Errorf("user not in the group of the data story: %s", existing.Group))
 	}
 
+	if err := ensureUnmodified(input.Precondition, existing.ETag()); err != nil {
+		return nil, errs.E(op, err)
+	}
+
 	story, err := s.storyStorage.UpdateStory(ctx, storyID, input)
 	if err != nil {
 		return nil, errs.E(op, err)
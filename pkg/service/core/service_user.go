@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/google/uuid"
 	"github.com/navikt/nada-backend/pkg/auth"
 	"github.com/navikt/nada-backend/pkg/errs"
 	"github.com/navikt/nada-backend/pkg/service"
@@ -69,61 +68,93 @@ func (s *userService) GetUserData(ctx context.Context, user *service.User) (*ser
 
 	userData.NadaTokens = tokens
 
-	dpwithds, dar, err := s.dataProductStorage.GetDataproductsWithDatasetsAndAccessRequests(ctx, []uuid.UUID{}, userData.GoogleGroups.Emails())
+	return userData, nil
+}
+
+func (s *userService) GetDataproducts(ctx context.Context, user *service.User, page service.PageRequest) (*service.Page[*service.Dataproduct], error) {
+	const op errs.Op = "userService.GetDataproducts"
+
+	dataproducts, err := s.dataProductStorage.GetDataproductsByGroupsPage(ctx, user.GoogleGroups.Emails(), page)
 	if err != nil {
 		return nil, errs.E(op, err)
 	}
 
-	for _, dpds := range dpwithds {
-		userData.Dataproducts = append(userData.Dataproducts, dpds.Dataproduct)
-	}
+	return dataproducts, nil
+}
+
+func (s *userService) GetAccessibleDatasets(ctx context.Context, user *service.User, kind service.AccessibleDatasetsKind, page service.PageRequest) (*service.Page[*service.AccessibleDataset], error) {
+	const op errs.Op = "userService.GetAccessibleDatasets"
 
-	userData.AccessRequestsAsGranter = dar
+	var datasets *service.Page[*service.AccessibleDataset]
+	var err error
+
+	requester := "user:" + strings.ToLower(user.Email)
+
+	switch kind {
+	case service.AccessibleDatasetsOwned:
+		datasets, err = s.dataProductStorage.GetOwnedDatasets(ctx, user.GoogleGroups.Emails(), page)
+	case service.AccessibleDatasetsGranted:
+		datasets, err = s.dataProductStorage.GetGrantedDatasets(ctx, user.GoogleGroups.Emails(), requester, page)
+	case service.AccessibleDatasetsServiceAccountGranted:
+		datasets, err = s.dataProductStorage.GetServiceAccountGrantedDatasets(ctx, user.GoogleGroups.Emails(), requester, page)
+	default:
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("unknown kind of accessible datasets: %s", kind))
+	}
 
-	owned, granted, serviceAccountGranted, err := s.dataProductStorage.GetAccessibleDatasets(ctx, userData.GoogleGroups.Emails(), "user:"+strings.ToLower(user.Email))
 	if err != nil {
 		return nil, errs.E(op, err)
 	}
 
-	userData.Accessable = service.AccessibleDatasets{
-		Owned:                 owned,
-		Granted:               granted,
-		ServiceAccountGranted: serviceAccountGranted,
-	}
+	return datasets, nil
+}
+
+func (s *userService) GetStories(ctx context.Context, user *service.User, page service.PageRequest) (*service.Page[*service.Story], error) {
+	const op errs.Op = "userService.GetStories"
 
-	dbStories, err := s.storyStorage.GetStoriesWithTeamkatalogenByGroups(ctx, user.GoogleGroups.Emails())
+	stories, err := s.storyStorage.GetStoriesWithTeamkatalogenByGroups(ctx, user.GoogleGroups.Emails(), page)
 	if err != nil {
 		return nil, errs.E(op, err)
 	}
 
-	userData.Stories = dbStories
+	return stories, nil
+}
+
+func (s *userService) GetInsightProducts(ctx context.Context, user *service.User, page service.PageRequest) (*service.Page[*service.InsightProduct], error) {
+	const op errs.Op = "userService.GetInsightProducts"
 
-	dbProducts, err := s.insightProductStorage.GetInsightProductsByGroups(ctx, user.GoogleGroups.Emails())
+	insightProducts, err := s.insightProductStorage.GetInsightProductsByGroups(ctx, user.GoogleGroups.Emails(), page)
 	if err != nil {
 		return nil, errs.E(op, err)
 	}
 
-	for _, p := range dbProducts {
-		userData.InsightProducts = append(userData.InsightProducts, *p)
-	}
+	return insightProducts, nil
+}
 
-	groups := []string{strings.ToLower(user.Email)}
+func (s *userService) GetAccessRequests(ctx context.Context, user *service.User, page service.PageRequest) (*service.Page[*service.AccessRequest], error) {
+	const op errs.Op = "userService.GetAccessRequests"
+
+	owners := []string{strings.ToLower(user.Email)}
 	for _, g := range user.GoogleGroups {
-		groups = append(groups, strings.ToLower(g.Email))
+		owners = append(owners, strings.ToLower(g.Email))
 	}
 
-	accessRequestSQLs, err := s.accessStorage.ListAccessRequestsForOwner(ctx, groups)
+	accessRequests, err := s.accessStorage.ListAccessRequestsForOwner(ctx, owners, page)
 	if err != nil {
-		if !errs.KindIs(errs.NotExist, err) {
-			return nil, errs.E(op, err)
-		}
+		return nil, errs.E(op, err)
 	}
 
-	for _, ar := range accessRequestSQLs {
-		userData.AccessRequests = append(userData.AccessRequests, *ar)
+	return accessRequests, nil
+}
+
+func (s *userService) GetAccessRequestsAsGranter(ctx context.Context, user *service.User, page service.PageRequest) (*service.Page[*service.AccessRequestForGranter], error) {
+	const op errs.Op = "userService.GetAccessRequestsAsGranter"
+
+	accessRequests, err := s.accessStorage.ListAccessRequestsForGranter(ctx, user.GoogleGroups.Emails(), page)
+	if err != nil {
+		return nil, errs.E(op, err)
 	}
 
-	return userData, nil
+	return accessRequests, nil
 }
 
 func teamNamesFromGroups(groups service.Groups) []string {
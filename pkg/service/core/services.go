@@ -9,28 +9,43 @@ import (
 )
 
 type Services struct {
-	AccessService         service.AccessService
-	BigQueryService       service.BigQueryService
-	DataProductService    service.DataProductsService
-	InsightProductService service.InsightProductService
-	JoinableViewService   service.JoinableViewsService
-	KeyWordService        service.KeywordsService
-	MetaBaseService       service.MetabaseService
-	PollyService          service.PollyService
-	ProductAreaService    service.ProductAreaService
-	SearchService         service.SearchService
-	SlackService          service.SlackService
-	StoryService          service.StoryService
-	TeamKatalogenService  service.TeamKatalogenService
-	TokenService          service.TokenService
-	UserService           service.UserService
-	NaisConsoleService    service.NaisConsoleService
+	AccessApprovalRuleService  service.AccessApprovalRuleService
+	AccessService              service.AccessService
+	BigQueryService            service.BigQueryService
+	CatalogueApplyService      service.CatalogueApplyService
+	CatalogueExportService     service.CatalogueExportService
+	ComplianceService          service.ComplianceService
+	DataProductService         service.DataProductsService
+	DataproductTransferService service.DataproductTransferService
+	DatasetPreviewService      service.DatasetPreviewService
+	DbtService                 service.DbtService
+	InsightProductService      service.InsightProductService
+	JoinableViewService        service.JoinableViewsService
+	KeyWordService             service.KeywordsService
+	LifecycleService           service.LifecycleService
+	MetaBaseService            service.MetabaseService
+	NotificationService        service.NotificationService
+	PolicyService              service.PolicyService
+	PollyService               service.PollyService
+	ProductAreaService         service.ProductAreaService
+	ProfilingService           service.ProfilingService
+	QualityCheckService        service.QualityCheckService
+	RecertificationService     service.RecertificationService
+	RecycleBinService          service.RecycleBinService
+	SearchService              service.SearchService
+	SlackService               service.SlackService
+	StoryService               service.StoryService
+	TeamKatalogenService       service.TeamKatalogenService
+	TokenService               service.TokenService
+	UserService                service.UserService
+	NaisConsoleService         service.NaisConsoleService
 }
 
 func NewServices(
 	cfg config.Config,
 	stores *storage.Stores,
 	clients *api.Clients,
+	googleGroups service.GoogleGroupsAPI,
 	log zerolog.Logger,
 ) (*Services, error) {
 	// FIXME: not sure about this..
@@ -39,28 +54,126 @@ func NewServices(
 		return nil, err
 	}
 
+	policyService := NewPolicyService(
+		stores.PolicyStorage,
+		stores.DataProductsStorage,
+		stores.AccessStorage,
+		cfg.PolicyAdminGroup,
+		cfg.AllUsersGroup,
+	)
+
+	metabaseService := NewMetabaseService(
+		cfg.Metabase.GCPProject,
+		mbSa,
+		mbSaEmail,
+		cfg.AllUsersGroup,
+		clients.MetaBaseAPI,
+		clients.BigQueryAPI,
+		clients.ServiceAccountAPI,
+		clients.DatasourceProviders,
+		stores.ThirdPartyMappingStorage,
+		stores.MetaBaseStorage,
+		stores.BigQueryStorage,
+		stores.DataProductsStorage,
+		stores.AccessStorage,
+		stores.DatasourceStorage,
+		policyService,
+		log.With().Str("service", "metabase").Logger(),
+	)
+
+	dataProductService := NewDataProductsService(
+		stores.DataProductsStorage,
+		stores.BigQueryStorage,
+		clients.BigQueryAPI,
+		stores.NaisConsoleStorage,
+		stores.AccessStorage,
+		metabaseService,
+		stores.DatasourceStorage,
+		clients.DatasourceProviders,
+		cfg.AllUsersGroup,
+	)
+
+	notificationService := NewNotificationService(
+		cfg.Server.Hostname,
+		stores.NotificationStorage,
+		clients.SlackAPI,
+		clients.EmailAPI,
+		log.With().Str("service", "notifications").Logger(),
+	)
+
+	accessService := NewAccessService(
+		cfg.Server.Hostname,
+		notificationService,
+		policyService,
+		stores.AccessApprovalRuleStorage,
+		stores.PollyStorage,
+		stores.AccessStorage,
+		stores.DataProductsStorage,
+		stores.BigQueryStorage,
+		stores.JoinableViewsStorage,
+		clients.BigQueryAPI,
+		stores.DatasourceStorage,
+		clients.DatasourceProviders,
+	)
+
 	return &Services{
-		AccessService: NewAccessService(
-			cfg.Server.Hostname,
-			clients.SlackAPI,
-			stores.PollyStorage,
-			stores.AccessStorage,
+		AccessApprovalRuleService: NewAccessApprovalRuleService(
+			stores.AccessApprovalRuleStorage,
 			stores.DataProductsStorage,
-			stores.BigQueryStorage,
-			stores.JoinableViewsStorage,
-			clients.BigQueryAPI,
 		),
+		AccessService: accessService,
 		BigQueryService: NewBigQueryService(
+			cfg.Server.Hostname,
 			stores.BigQueryStorage,
 			clients.BigQueryAPI,
 			stores.DataProductsStorage,
+			stores.DatasourceStorage,
+			stores.AccessStorage,
+			clients.DatasourceProviders,
+			notificationService,
+			stores.ProfilingStorage,
 		),
-		DataProductService: NewDataProductsService(
+		CatalogueApplyService: NewCatalogueApplyService(
+			cfg.Metabase.GCPProject,
 			stores.DataProductsStorage,
 			stores.BigQueryStorage,
+			dataProductService,
+			accessService,
+		),
+		CatalogueExportService: NewCatalogueExportService(
+			stores.CatalogueExportStorage,
+			cfg.Server.Hostname,
+			service.DCATCatalogue{
+				Title:         cfg.DCAT.Title,
+				PublisherName: cfg.DCAT.PublisherName,
+				PublisherURI:  cfg.DCAT.PublisherURI,
+				LicenseURI:    cfg.DCAT.LicenseURI,
+			},
+		),
+		ComplianceService: NewComplianceService(
+			stores.ComplianceStorage,
+			cfg.ComplianceAdminGroup,
+		),
+		DataProductService: dataProductService,
+		DataproductTransferService: NewDataproductTransferService(
+			stores.DataproductTransferStorage,
+			stores.DataProductsStorage,
+			stores.StoryStorage,
+			metabaseService,
+			log.With().Str("service", "dataproduct_transfers").Logger(),
+		),
+		DatasetPreviewService: NewDatasetPreviewService(
+			stores.DatasetPreviewStorage,
+			stores.DataProductsStorage,
+			stores.BigQueryStorage,
+			stores.AccessStorage,
 			clients.BigQueryAPI,
-			stores.NaisConsoleStorage,
-			cfg.AllUsersGroup,
+		),
+		DbtService: NewDbtService(
+			stores.DbtStorage,
+			stores.DataProductsStorage,
+			stores.BigQueryStorage,
+			dataProductService,
 		),
 		InsightProductService: NewInsightProductService(
 			stores.InsightProductStorage,
@@ -71,29 +184,33 @@ func NewServices(
 			stores.DataProductsStorage,
 			clients.BigQueryAPI,
 			stores.BigQueryStorage,
+			cfg.BigQuery.CentralGCPProject,
+			policyService,
 		),
 		KeyWordService: NewKeywordsService(
 			stores.KeyWordStorage,
 			cfg.KeywordsAdminGroup,
 		),
-		MetaBaseService: NewMetabaseService(
-			cfg.Metabase.GCPProject,
-			mbSa,
-			mbSaEmail,
-			cfg.AllUsersGroup,
-			clients.MetaBaseAPI,
-			clients.BigQueryAPI,
-			clients.ServiceAccountAPI,
-			stores.ThirdPartyMappingStorage,
-			stores.MetaBaseStorage,
-			stores.BigQueryStorage,
+		LifecycleService: NewLifecycleService(
+			cfg.Server.Hostname,
+			stores.LifecycleStorage,
 			stores.DataProductsStorage,
 			stores.AccessStorage,
-			log.With().Str("service", "metabase").Logger(),
+			stores.BigQueryStorage,
+			stores.DatasourceStorage,
+			clients.DatasourceProviders,
+			metabaseService,
+			notificationService,
+			log.With().Str("service", "lifecycle").Logger(),
 		),
+		MetaBaseService:     metabaseService,
+		NotificationService: notificationService,
+		PolicyService:       policyService,
 		PollyService: NewPollyService(
 			stores.PollyStorage,
 			clients.PollyAPI,
+			notificationService,
+			log.With().Str("service", "polly").Logger(),
 		),
 		ProductAreaService: NewProductAreaService(
 			stores.ProductAreaStorage,
@@ -101,6 +218,45 @@ func NewServices(
 			stores.InsightProductStorage,
 			stores.StoryStorage,
 		),
+		ProfilingService: NewProfilingService(
+			stores.ProfilingStorage,
+			stores.DataProductsStorage,
+			stores.AccessStorage,
+			clients.BigQueryAPI,
+			cfg.BigQuery.ProfilingBytesBudget,
+			log.With().Str("service", "profiling").Logger(),
+		),
+		QualityCheckService: NewQualityCheckService(
+			cfg.Server.Hostname,
+			stores.QualityCheckStorage,
+			stores.DataProductsStorage,
+			stores.AccessStorage,
+			clients.BigQueryAPI,
+			notificationService,
+			log.With().Str("service", "quality_checks").Logger(),
+		),
+		RecertificationService: NewRecertificationService(
+			stores.RecertificationStorage,
+			stores.DataProductsStorage,
+			stores.AccessStorage,
+			accessService,
+			notificationService,
+			cfg.PolicyAdminGroup,
+			cfg.Metabase.GCPProject,
+			log.With().Str("service", "recertification").Logger(),
+		),
+		RecycleBinService: NewRecycleBinService(
+			stores.RecycleBinStorage,
+			stores.DataProductsStorage,
+			stores.StoryStorage,
+			clients.StoryAPI,
+			stores.InsightProductStorage,
+			stores.AccessStorage,
+			stores.DatasourceStorage,
+			clients.DatasourceProviders,
+			metabaseService,
+			log.With().Str("service", "recycle_bin").Logger(),
+		),
 		SearchService: NewSearchService(
 			stores.SearchStorage,
 			stores.StoryStorage,
@@ -108,6 +264,9 @@ func NewServices(
 		),
 		SlackService: NewSlackService(
 			clients.SlackAPI,
+			googleGroups,
+			accessService,
+			log.With().Str("service", "slack").Logger(),
 		),
 		StoryService: NewStoryService(
 			stores.StoryStorage,
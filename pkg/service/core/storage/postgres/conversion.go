@@ -2,11 +2,15 @@ package postgres
 
 import (
 	"database/sql"
+	"encoding/json"
 	"net/url"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/database/gensql"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/sqlc-dev/pqtype"
 )
 
 type Converter[O any] interface {
@@ -31,15 +35,6 @@ func ptrToIntDefault(v *int, def int) int {
 	return *v
 }
 
-func matchAny(s string, targetSet []string) bool {
-	for _, v := range targetSet {
-		if s == v {
-			return true
-		}
-	}
-	return false
-}
-
 func nullInt32ToIntPtr(ni sql.NullInt32) *int {
 	if !ni.Valid {
 		return nil
@@ -50,6 +45,22 @@ func nullInt32ToIntPtr(ni sql.NullInt32) *int {
 	return &i
 }
 
+func nullInt64ToPtr(ni sql.NullInt64) *int64 {
+	if !ni.Valid {
+		return nil
+	}
+
+	return &ni.Int64
+}
+
+func ptrToNullInt64(i *int64) sql.NullInt64 {
+	if i == nil {
+		return sql.NullInt64{}
+	}
+
+	return sql.NullInt64{Int64: *i, Valid: true}
+}
+
 func nullStringToString(ns sql.NullString) string {
 	if !ns.Valid {
 		return ""
@@ -121,3 +132,71 @@ func emailOfSubjectToLower(subjectWithType string) string {
 
 	return strings.Join(parts, ":")
 }
+
+func pseudoColumnsToJSON(columns []service.PseudoColumn) (json.RawMessage, error) {
+	if columns == nil {
+		columns = []service.PseudoColumn{}
+	}
+
+	return json.Marshal(columns)
+}
+
+func pseudoColumnsFromJSON(raw json.RawMessage) ([]service.PseudoColumn, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var columns []service.PseudoColumn
+	if err := json.Unmarshal(raw, &columns); err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
+func bigQueryScopeOrDefault(scope service.BigQueryScope) string {
+	if scope == "" {
+		return string(service.BigQueryScopeTable)
+	}
+
+	return string(scope)
+}
+
+func memberTablesToJSON(tables []*service.BigQueryMemberTable) (pqtype.NullRawMessage, error) {
+	if tables == nil {
+		return pqtype.NullRawMessage{}, nil
+	}
+
+	raw, err := json.Marshal(tables)
+	if err != nil {
+		return pqtype.NullRawMessage{}, err
+	}
+
+	return pqtype.NullRawMessage{RawMessage: raw, Valid: true}, nil
+}
+
+func memberTablesFromJSON(raw pqtype.NullRawMessage) ([]*service.BigQueryMemberTable, error) {
+	if !raw.Valid {
+		return nil, nil
+	}
+
+	var tables []*service.BigQueryMemberTable
+	if err := json.Unmarshal(raw.RawMessage, &tables); err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+func applyColumnDescriptions(columns []*service.BigqueryColumn, descriptions []gensql.DatasetColumnDescription) {
+	byName := make(map[string]string, len(descriptions))
+	for _, d := range descriptions {
+		byName[d.ColumnName] = d.Description
+	}
+
+	for _, c := range columns {
+		if d, ok := byName[c.Name]; ok {
+			c.Description = d
+		}
+	}
+}
@@ -22,9 +22,14 @@ func AccessQueriesWithTxFn(m *AccessQueriesMock, t database.Transacter, err erro
 	}
 }
 
-func (m *AccessQueriesMock) ListAccessRequestsForOwner(ctx context.Context, owner []string) ([]gensql.DatasetAccessRequest, error) {
-	args := m.Called(ctx, owner)
-	return args.Get(0).([]gensql.DatasetAccessRequest), args.Error(1)
+func (m *AccessQueriesMock) ListAccessRequestsForOwnerPage(ctx context.Context, arg gensql.ListAccessRequestsForOwnerPageParams) ([]gensql.ListAccessRequestsForOwnerPageRow, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]gensql.ListAccessRequestsForOwnerPageRow), args.Error(1)
+}
+
+func (m *AccessQueriesMock) ListAccessRequestsForGranterPage(ctx context.Context, arg gensql.ListAccessRequestsForGranterPageParams) ([]gensql.ListAccessRequestsForGranterPageRow, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]gensql.ListAccessRequestsForGranterPageRow), args.Error(1)
 }
 
 func (m *AccessQueriesMock) ListUnrevokedExpiredAccessEntries(ctx context.Context) ([]gensql.DatasetAccess, error) {
@@ -37,9 +42,14 @@ func (m *AccessQueriesMock) ListActiveAccessToDataset(ctx context.Context, datas
 	return args.Get(0).([]gensql.DatasetAccess), args.Error(1)
 }
 
-func (m *AccessQueriesMock) ListAccessRequestsForDataset(ctx context.Context, datasetID uuid.UUID) ([]gensql.DatasetAccessRequest, error) {
-	args := m.Called(ctx, datasetID)
-	return args.Get(0).([]gensql.DatasetAccessRequest), args.Error(1)
+func (m *AccessQueriesMock) ListActiveAccessToTablePatternDatasources(ctx context.Context) ([]gensql.DatasetAccess, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]gensql.DatasetAccess), args.Error(1)
+}
+
+func (m *AccessQueriesMock) ListAccessRequestsForDatasetPage(ctx context.Context, arg gensql.ListAccessRequestsForDatasetPageParams) ([]gensql.ListAccessRequestsForDatasetPageRow, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]gensql.ListAccessRequestsForDatasetPageRow), args.Error(1)
 }
 
 func (m *AccessQueriesMock) CreateAccessRequestForDataset(ctx context.Context, params gensql.CreateAccessRequestForDatasetParams) (gensql.DatasetAccessRequest, error) {
@@ -91,3 +101,23 @@ func (m *AccessQueriesMock) GetAccessToDataset(ctx context.Context, id uuid.UUID
 	args := m.Called(ctx, id)
 	return args.Get(0).(gensql.DatasetAccess), args.Error(1)
 }
+
+func (m *AccessQueriesMock) CreateRenewalAccessRequest(ctx context.Context, params gensql.CreateRenewalAccessRequestParams) (gensql.DatasetAccessRequest, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(gensql.DatasetAccessRequest), args.Error(1)
+}
+
+func (m *AccessQueriesMock) GetPendingRenewalAccessRequest(ctx context.Context, renewsAccessID uuid.NullUUID) (gensql.DatasetAccessRequest, error) {
+	args := m.Called(ctx, renewsAccessID)
+	return args.Get(0).(gensql.DatasetAccessRequest), args.Error(1)
+}
+
+func (m *AccessQueriesMock) ListExpiringAccessForGroups(ctx context.Context, params gensql.ListExpiringAccessForGroupsParams) ([]gensql.ListExpiringAccessForGroupsRow, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]gensql.ListExpiringAccessForGroupsRow), args.Error(1)
+}
+
+func (m *AccessQueriesMock) UpdateAccessExpires(ctx context.Context, params gensql.UpdateAccessExpiresParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+// The keyset queries take the position of the last item on the previous page,
+// and return one row more than the limit, so we know if there is a next page.
+
+func pageAfterID(page service.PageRequest) uuid.NullUUID {
+	if page.After == nil {
+		return uuid.NullUUID{}
+	}
+
+	return uuidToNullUUID(page.After.ID)
+}
+
+func pageAfterName(page service.PageRequest) string {
+	if page.After == nil || page.Sort != service.SortByName {
+		return ""
+	}
+
+	return page.After.Key
+}
+
+func pageAfterCreated(page service.PageRequest) time.Time {
+	if page.After == nil || page.Sort != service.SortByCreated {
+		return time.Time{}
+	}
+
+	created, _ := page.After.Created()
+
+	return created
+}
+
+// pageKey returns the sort key of an item in a list sorted by name or creation time
+func pageKey(page service.PageRequest, name string, created time.Time) string {
+	if page.Sort == service.SortByCreated {
+		return service.CreatedKey(created)
+	}
+
+	return name
+}
+
+func pageLimit(page service.PageRequest) int32 {
+	return int32(page.Limit + 1)
+}
+
+// pageFrom converts the rows of a keyset query to a page, where position returns
+// the sort key and ID of a row
+func pageFrom[R any, T any](rows []R, page service.PageRequest, position func(R) (string, uuid.UUID), item func(R) (T, error)) (*service.Page[T], error) {
+	p := &service.Page[T]{
+		Items: []T{},
+	}
+
+	if len(rows) > page.Limit {
+		rows = rows[:page.Limit]
+
+		key, id := position(rows[len(rows)-1])
+		p.Next = &service.Cursor{
+			Sort:  page.Sort,
+			Order: page.Order,
+			Key:   key,
+			ID:    id,
+		}
+	}
+
+	for _, r := range rows {
+		i, err := item(r)
+		if err != nil {
+			return nil, err
+		}
+
+		p.Items = append(p.Items, i)
+	}
+
+	return p, nil
+}
@@ -16,10 +16,12 @@ import (
 )
 
 type AccessQueries interface {
-	ListAccessRequestsForOwner(ctx context.Context, owner []string) ([]gensql.DatasetAccessRequest, error)
+	ListAccessRequestsForOwnerPage(ctx context.Context, arg gensql.ListAccessRequestsForOwnerPageParams) ([]gensql.ListAccessRequestsForOwnerPageRow, error)
+	ListAccessRequestsForGranterPage(ctx context.Context, arg gensql.ListAccessRequestsForGranterPageParams) ([]gensql.ListAccessRequestsForGranterPageRow, error)
 	ListUnrevokedExpiredAccessEntries(ctx context.Context) ([]gensql.DatasetAccess, error)
 	ListActiveAccessToDataset(ctx context.Context, datasetID uuid.UUID) ([]gensql.DatasetAccess, error)
-	ListAccessRequestsForDataset(ctx context.Context, datasetID uuid.UUID) ([]gensql.DatasetAccessRequest, error)
+	ListActiveAccessToTablePatternDatasources(ctx context.Context) ([]gensql.DatasetAccess, error)
+	ListAccessRequestsForDatasetPage(ctx context.Context, arg gensql.ListAccessRequestsForDatasetPageParams) ([]gensql.ListAccessRequestsForDatasetPageRow, error)
 	CreateAccessRequestForDataset(ctx context.Context, params gensql.CreateAccessRequestForDatasetParams) (gensql.DatasetAccessRequest, error)
 	GetAccessRequest(ctx context.Context, id uuid.UUID) (gensql.DatasetAccessRequest, error)
 	DeleteAccessRequest(ctx context.Context, id uuid.UUID) error
@@ -30,6 +32,10 @@ type AccessQueries interface {
 	RevokeAccessToDataset(ctx context.Context, id uuid.UUID) error
 	DenyAccessRequest(ctx context.Context, params gensql.DenyAccessRequestParams) error
 	GetAccessToDataset(ctx context.Context, id uuid.UUID) (gensql.DatasetAccess, error)
+	CreateRenewalAccessRequest(ctx context.Context, params gensql.CreateRenewalAccessRequestParams) (gensql.DatasetAccessRequest, error)
+	GetPendingRenewalAccessRequest(ctx context.Context, renewsAccessID uuid.NullUUID) (gensql.DatasetAccessRequest, error)
+	ListExpiringAccessForGroups(ctx context.Context, params gensql.ListExpiringAccessForGroupsParams) ([]gensql.ListExpiringAccessForGroupsRow, error)
+	UpdateAccessExpires(ctx context.Context, params gensql.UpdateAccessExpiresParams) error
 }
 
 var _ service.AccessStorage = &accessStorage{}
@@ -41,15 +47,68 @@ type accessStorage struct {
 	withTxFn AccessQueriesWithTxFn
 }
 
-func (s *accessStorage) ListAccessRequestsForOwner(ctx context.Context, owner []string) ([]*service.AccessRequest, error) {
+func (s *accessStorage) ListAccessRequestsForOwner(ctx context.Context, owner []string, page service.PageRequest) (*service.Page[*service.AccessRequest], error) {
 	const op errs.Op = "accessStorage.ListAccessRequestsForOwner"
 
-	raw, err := s.queries.ListAccessRequestsForOwner(ctx, owner)
+	raw, err := s.queries.ListAccessRequestsForOwnerPage(ctx, gensql.ListAccessRequestsForOwnerPageParams{
+		Owner:        owner,
+		AfterID:      pageAfterID(page),
+		Descending:   page.Descending(),
+		AfterCreated: pageAfterCreated(page),
+		Lim:          pageLimit(page),
+	})
 	if err != nil {
 		return nil, errs.E(errs.Database, op, err, errs.Parameter("owner"))
 	}
 
-	accessRequests, err := From(DatasetAccessRequests(raw))
+	accessRequests, err := pageFrom(raw, page,
+		func(r gensql.ListAccessRequestsForOwnerPageRow) (string, uuid.UUID) {
+			return service.CreatedKey(r.DatasetAccessRequest.Created), r.DatasetAccessRequest.ID
+		},
+		func(r gensql.ListAccessRequestsForOwnerPageRow) (*service.AccessRequest, error) {
+			return From(DatasetAccessRequest(r.DatasetAccessRequest))
+		},
+	)
+	if err != nil {
+		return nil, errs.E(errs.Internal, op, err)
+	}
+
+	return accessRequests, nil
+}
+
+func (s *accessStorage) ListAccessRequestsForGranter(ctx context.Context, groups []string, page service.PageRequest) (*service.Page[*service.AccessRequestForGranter], error) {
+	const op errs.Op = "accessStorage.ListAccessRequestsForGranter"
+
+	raw, err := s.queries.ListAccessRequestsForGranterPage(ctx, gensql.ListAccessRequestsForGranterPageParams{
+		Groups:       groups,
+		AfterID:      pageAfterID(page),
+		Descending:   page.Descending(),
+		AfterCreated: pageAfterCreated(page),
+		Lim:          pageLimit(page),
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err, errs.Parameter("groups"))
+	}
+
+	accessRequests, err := pageFrom(raw, page,
+		func(r gensql.ListAccessRequestsForGranterPageRow) (string, uuid.UUID) {
+			return service.CreatedKey(r.DatasetAccessRequest.Created), r.DatasetAccessRequest.ID
+		},
+		func(r gensql.ListAccessRequestsForGranterPageRow) (*service.AccessRequestForGranter, error) {
+			ar, err := From(DatasetAccessRequest(r.DatasetAccessRequest))
+			if err != nil {
+				return nil, err
+			}
+
+			return &service.AccessRequestForGranter{
+				AccessRequest:   *ar,
+				DataproductID:   r.DataproductID,
+				DataproductSlug: r.DataproductSlug,
+				DatasetName:     r.DatasetName,
+				DataproductName: r.DataproductName,
+			}, nil
+		},
+	)
 	if err != nil {
 		return nil, errs.E(errs.Internal, op, err)
 	}
@@ -91,15 +150,45 @@ func (s *accessStorage) ListActiveAccessToDataset(ctx context.Context, datasetID
 	return ret, nil
 }
 
-func (s *accessStorage) ListAccessRequestsForDataset(ctx context.Context, datasetID uuid.UUID) ([]*service.AccessRequest, error) {
+func (s *accessStorage) ListActiveAccessToTablePatternDatasources(ctx context.Context) ([]*service.Access, error) {
+	const op errs.Op = "accessStorage.ListActiveAccessToTablePatternDatasources"
+
+	access, err := s.queries.ListActiveAccessToTablePatternDatasources(ctx)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	var ret []*service.Access
+	for _, e := range access {
+		ar, _ := From(DatasetAccess(e))
+		ret = append(ret, ar)
+	}
+
+	return ret, nil
+}
+
+func (s *accessStorage) ListAccessRequestsForDataset(ctx context.Context, datasetID uuid.UUID, page service.PageRequest) (*service.Page[*service.AccessRequest], error) {
 	const op errs.Op = "accessStorage.ListAccessRequestsForDataset"
 
-	raw, err := s.queries.ListAccessRequestsForDataset(ctx, datasetID)
+	raw, err := s.queries.ListAccessRequestsForDatasetPage(ctx, gensql.ListAccessRequestsForDatasetPageParams{
+		DatasetID:    datasetID,
+		AfterID:      pageAfterID(page),
+		Descending:   page.Descending(),
+		AfterCreated: pageAfterCreated(page),
+		Lim:          pageLimit(page),
+	})
 	if err != nil {
 		return nil, errs.E(errs.Database, op, err, errs.Parameter("datasetID"))
 	}
 
-	accessRequests, err := From(DatasetAccessRequests(raw))
+	accessRequests, err := pageFrom(raw, page,
+		func(r gensql.ListAccessRequestsForDatasetPageRow) (string, uuid.UUID) {
+			return service.CreatedKey(r.DatasetAccessRequest.Created), r.DatasetAccessRequest.ID
+		},
+		func(r gensql.ListAccessRequestsForDatasetPageRow) (*service.AccessRequest, error) {
+			return From(DatasetAccessRequest(r.DatasetAccessRequest))
+		},
+	)
 	if err != nil {
 		return nil, errs.E(errs.Internal, op, err)
 	}
@@ -133,6 +222,49 @@ func (s *accessStorage) CreateAccessRequestForDataset(ctx context.Context, datas
 	return ar, nil
 }
 
+func (s *accessStorage) CreateRenewalAccessRequest(ctx context.Context, access *service.Access, pollyDocumentationID uuid.NullUUID, expires *time.Time) (*service.AccessRequest, error) {
+	const op errs.Op = "accessStorage.CreateRenewalAccessRequest"
+
+	raw, err := s.queries.CreateRenewalAccessRequest(ctx, gensql.CreateRenewalAccessRequestParams{
+		DatasetID:            access.DatasetID,
+		Subject:              access.Subject,
+		Owner:                access.Owner,
+		Expires:              ptrToNullTime(expires),
+		PollyDocumentationID: pollyDocumentationID,
+		RenewsAccessID:       uuidToNullUUID(access.ID),
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	ar, err := From(DatasetAccessRequest(raw))
+	if err != nil {
+		return nil, errs.E(errs.Internal, op, err)
+	}
+
+	return ar, nil
+}
+
+func (s *accessStorage) GetPendingRenewalAccessRequest(ctx context.Context, accessID uuid.UUID) (*service.AccessRequest, error) {
+	const op errs.Op = "accessStorage.GetPendingRenewalAccessRequest"
+
+	raw, err := s.queries.GetPendingRenewalAccessRequest(ctx, uuidToNullUUID(accessID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.E(errs.NotExist, op, err, errs.Parameter("accessID"))
+		}
+
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	ar, err := From(DatasetAccessRequest(raw))
+	if err != nil {
+		return nil, errs.E(errs.Internal, op, err)
+	}
+
+	return ar, nil
+}
+
 func (s *accessStorage) GetAccessRequest(ctx context.Context, accessRequestID uuid.UUID) (*service.AccessRequest, error) {
 	const op errs.Op = "accessStorage.GetAccessRequest"
 
@@ -280,6 +412,70 @@ func (s *accessStorage) GrantAccessToDatasetAndRenew(ctx context.Context, datase
 	return nil
 }
 
+// RenewAccessToDatasetAndApproveRequest extends the access to the expiry of
+// the renewal request, instead of granting a new access
+func (s *accessStorage) RenewAccessToDatasetAndApproveRequest(ctx context.Context, user *service.User, accessID, accessRequestID uuid.UUID, expires *time.Time) error {
+	const op errs.Op = "accessStorage.RenewAccessToDatasetAndApproveRequest"
+
+	q, tx, err := s.withTxFn()
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+	defer tx.Rollback()
+
+	err = q.UpdateAccessExpires(ctx, gensql.UpdateAccessExpiresParams{
+		Expires: ptrToNullTime(expires),
+		ID:      accessID,
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	err = q.ApproveAccessRequest(ctx, gensql.ApproveAccessRequestParams{
+		ID:      accessRequestID,
+		Granter: sql.NullString{String: user.Email, Valid: true},
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *accessStorage) ListExpiringAccessForGroups(ctx context.Context, groups []string, days int) ([]*service.ExpiringAccess, error) {
+	const op errs.Op = "accessStorage.ListExpiringAccessForGroups"
+
+	raw, err := s.queries.ListExpiringAccessForGroups(ctx, gensql.ListExpiringAccessForGroupsParams{
+		Groups: groups,
+		Days:   int32(days),
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err, errs.Parameter("groups"))
+	}
+
+	accesses := make([]*service.ExpiringAccess, len(raw))
+	for i, a := range raw {
+		accesses[i] = &service.ExpiringAccess{
+			AccessID:         a.AccessID,
+			Subject:          a.Subject,
+			Owner:            a.Owner,
+			Expires:          a.Expires,
+			DatasetID:        a.DatasetID,
+			DatasetName:      a.DatasetName,
+			DataproductID:    a.DataproductID,
+			DataproductName:  a.DataproductName,
+			RenewalRequestID: nullUUIDToUUIDPtr(a.RenewalRequestID),
+		}
+	}
+
+	return accesses, nil
+}
+
 func (s *accessStorage) DenyAccessRequest(ctx context.Context, user *service.User, accessRequestID uuid.UUID, reason *string) error {
 	const op errs.Op = "accessStorage.DenyAccessRequest"
 
@@ -310,6 +506,7 @@ func (s *accessStorage) GetAccessToDataset(ctx context.Context, id uuid.UUID) (*
 	return &service.Access{
 		ID:              access.ID,
 		Subject:         access.Subject,
+		Owner:           access.Owner,
 		Granter:         access.Granter,
 		Expires:         nullTimeToPtr(access.Expires),
 		Created:         access.Created,
@@ -336,6 +533,7 @@ func (a DatasetAccess) To() (*service.Access, error) {
 	return &service.Access{
 		ID:              a.ID,
 		Subject:         a.Subject,
+		Owner:           a.Owner,
 		Granter:         a.Granter,
 		Expires:         nullTimeToPtr(a.Expires),
 		Created:         a.Created,
@@ -380,18 +578,20 @@ func (d DatasetAccessRequest) To() (*service.AccessRequest, error) {
 	}
 
 	return &service.AccessRequest{
-		ID:          d.ID,
-		DatasetID:   d.DatasetID,
-		Subject:     subject,
-		SubjectType: subjectType,
-		Created:     d.Created,
-		Status:      status,
-		Closed:      nullTimeToPtr(d.Closed),
-		Expires:     nullTimeToPtr(d.Expires),
-		Granter:     nullStringToPtr(d.Granter),
-		Owner:       d.Owner,
-		Polly:       polly,
-		Reason:      nullStringToPtr(d.Reason),
+		ID:             d.ID,
+		DatasetID:      d.DatasetID,
+		Subject:        subject,
+		SubjectType:    subjectType,
+		Created:        d.Created,
+		Status:         status,
+		Closed:         nullTimeToPtr(d.Closed),
+		Expires:        nullTimeToPtr(d.Expires),
+		Granter:        nullStringToPtr(d.Granter),
+		Owner:          d.Owner,
+		Polly:          polly,
+		Reason:         nullStringToPtr(d.Reason),
+		ApprovalRuleID: nullUUIDToUUIDPtr(d.ApprovalRuleID),
+		RenewsAccessID: nullUUIDToUUIDPtr(d.RenewsAccessID),
 	}, nil
 }
 
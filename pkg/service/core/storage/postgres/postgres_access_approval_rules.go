@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/database/gensql"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+var _ service.AccessApprovalRuleStorage = &accessApprovalRuleStorage{}
+
+type accessApprovalRuleStorage struct {
+	db *database.Repo
+}
+
+func (s *accessApprovalRuleStorage) CreateAccessApprovalRule(ctx context.Context, rule service.NewAccessApprovalRule, createdBy string) (*service.AccessApprovalRule, error) {
+	const op errs.Op = "accessApprovalRuleStorage.CreateAccessApprovalRule"
+
+	pii := gensql.NullPiiLevel{}
+	if rule.Pii != nil {
+		pii = gensql.NullPiiLevel{PiiLevel: gensql.PiiLevel(*rule.Pii), Valid: true}
+	}
+
+	maxExpiryDays := sql.NullInt32{}
+	if rule.MaxExpiryDays != nil {
+		maxExpiryDays = sql.NullInt32{Int32: int32(*rule.MaxExpiryDays), Valid: true}
+	}
+
+	raw, err := s.db.Querier.CreateAccessApprovalRule(ctx, gensql.CreateAccessApprovalRuleParams{
+		DatasetID:     rule.DatasetID,
+		SubjectType:   ptrToNullString(rule.SubjectType),
+		SubjectGroup:  ptrToNullString(rule.SubjectGroup),
+		Pii:           pii,
+		MaxExpiryDays: maxExpiryDays,
+		RequirePolly:  rule.RequirePolly,
+		CreatedBy:     createdBy,
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return accessApprovalRuleFromSQL(raw), nil
+}
+
+func (s *accessApprovalRuleStorage) GetAccessApprovalRule(ctx context.Context, id uuid.UUID) (*service.AccessApprovalRule, error) {
+	const op errs.Op = "accessApprovalRuleStorage.GetAccessApprovalRule"
+
+	raw, err := s.db.Querier.GetAccessApprovalRule(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.E(errs.NotExist, op, err)
+		}
+
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return accessApprovalRuleFromSQL(raw), nil
+}
+
+func (s *accessApprovalRuleStorage) GetAccessApprovalRulesForDataset(ctx context.Context, datasetID uuid.UUID) ([]*service.AccessApprovalRule, error) {
+	const op errs.Op = "accessApprovalRuleStorage.GetAccessApprovalRulesForDataset"
+
+	raw, err := s.db.Querier.GetAccessApprovalRulesForDataset(ctx, datasetID)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	rules := make([]*service.AccessApprovalRule, len(raw))
+	for i, r := range raw {
+		rules[i] = accessApprovalRuleFromSQL(r)
+	}
+
+	return rules, nil
+}
+
+func (s *accessApprovalRuleStorage) DeleteAccessApprovalRule(ctx context.Context, id uuid.UUID) error {
+	const op errs.Op = "accessApprovalRuleStorage.DeleteAccessApprovalRule"
+
+	err := s.db.Querier.DeleteAccessApprovalRule(ctx, id)
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *accessApprovalRuleStorage) SetAccessRequestApprovalRule(ctx context.Context, accessRequestID, ruleID uuid.UUID) error {
+	const op errs.Op = "accessApprovalRuleStorage.SetAccessRequestApprovalRule"
+
+	err := s.db.Querier.SetAccessRequestApprovalRule(ctx, gensql.SetAccessRequestApprovalRuleParams{
+		ID:             accessRequestID,
+		ApprovalRuleID: uuidToNullUUID(ruleID),
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func accessApprovalRuleFromSQL(r gensql.DatasetAccessApprovalRule) *service.AccessApprovalRule {
+	var pii *service.PiiLevel
+	if r.Pii.Valid {
+		p := service.PiiLevel(r.Pii.PiiLevel)
+		pii = &p
+	}
+
+	return &service.AccessApprovalRule{
+		ID:            r.ID,
+		DatasetID:     r.DatasetID,
+		SubjectType:   nullStringToPtr(r.SubjectType),
+		SubjectGroup:  nullStringToPtr(r.SubjectGroup),
+		Pii:           pii,
+		MaxExpiryDays: nullInt32ToIntPtr(r.MaxExpiryDays),
+		RequirePolly:  r.RequirePolly,
+		CreatedBy:     r.CreatedBy,
+		Created:       r.Created,
+	}
+}
+
+func NewAccessApprovalRuleStorage(db *database.Repo) *accessApprovalRuleStorage {
+	return &accessApprovalRuleStorage{
+		db: db,
+	}
+}
@@ -15,6 +15,8 @@ import (
 func TestDatasetAccessRequest_To(t *testing.T) {
 	t.Parallel()
 
+	renewsAccessID := uuid.MustParse("8E6D2F8A-4B0C-4B8E-9C4B-2D1B0F6E7A11")
+
 	testCases := []struct {
 		name           string
 		input          gensql.DatasetAccessRequest
@@ -40,6 +42,27 @@ func TestDatasetAccessRequest_To(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "Renewal request",
+			input: gensql.DatasetAccessRequest{
+				ID:             uuid.MustParse("14726B25-FACE-47C7-AC55-782799362E58"),
+				DatasetID:      uuid.MustParse("14726B25-FACE-47C7-AC55-782799362E58"),
+				Subject:        "group:group1",
+				Owner:          "group1",
+				Status:         "pending",
+				RenewsAccessID: uuid.NullUUID{UUID: renewsAccessID, Valid: true},
+			},
+			expectedResult: &service.AccessRequest{
+				ID:             uuid.MustParse("14726B25-FACE-47C7-AC55-782799362E58"),
+				DatasetID:      uuid.MustParse("14726B25-FACE-47C7-AC55-782799362E58"),
+				Subject:        "group1",
+				SubjectType:    "group",
+				Owner:          "group1",
+				Status:         "pending",
+				RenewsAccessID: &renewsAccessID,
+			},
+			expectedErr: nil,
+		},
 		{
 			name: "Error parsing subject",
 			input: gensql.DatasetAccessRequest{
@@ -23,13 +23,18 @@ type bigQueryStorage struct {
 func (s *bigQueryStorage) UpdateBigqueryDatasource(ctx context.Context, input service.BigQueryDataSourceUpdate) error {
 	const op errs.Op = "bigQueryStorage.UpdateBigqueryDatasource"
 
-	err := s.db.Querier.UpdateBigqueryDatasource(ctx, gensql.UpdateBigqueryDatasourceParams{
+	pseudoColumns, err := pseudoColumnsToJSON(input.PseudoColumns)
+	if err != nil {
+		return errs.E(errs.InvalidRequest, op, err, errs.Parameter("pseudo_columns"))
+	}
+
+	err = s.db.Querier.UpdateBigqueryDatasource(ctx, gensql.UpdateBigqueryDatasourceParams{
 		DatasetID: input.DatasetID,
 		PiiTags: pqtype.NullRawMessage{
 			RawMessage: json.RawMessage(ptrToString(input.PiiTags)),
 			Valid:      len(ptrToString(input.PiiTags)) > 4,
 		},
-		PseudoColumns: input.PseudoColumns,
+		PseudoColumns: pseudoColumns,
 	})
 	if err != nil {
 		return errs.E(errs.Database, op, err)
@@ -48,12 +53,17 @@ func (s *bigQueryStorage) GetPseudoDatasourcesToDelete(ctx context.Context) ([]*
 
 	var pseudoViews []*service.BigQuery
 	for _, d := range rows {
+		pseudoColumns, err := pseudoColumnsFromJSON(d.PseudoColumns)
+		if err != nil {
+			return nil, errs.E(errs.Internal, op, err)
+		}
+
 		pseudoViews = append(pseudoViews, &service.BigQuery{
 			ID:            d.ID,
 			Dataset:       d.Dataset,
 			ProjectID:     d.ProjectID,
 			Table:         d.TableName,
-			PseudoColumns: d.PseudoColumns,
+			PseudoColumns: pseudoColumns,
 		})
 	}
 
@@ -74,21 +84,35 @@ func (s *bigQueryStorage) UpdateBigqueryDatasourceMissing(ctx context.Context, d
 func (s *bigQueryStorage) UpdateBigqueryDatasourceSchema(ctx context.Context, datasetID uuid.UUID, meta service.BigqueryMetadata) error {
 	const op errs.Op = "bigQueryStorage.UpdateBigqueryDatasourceSchema"
 
+	// Column descriptions imported from elsewhere, e.g., dbt, take
+	// precedence over the ones in BigQuery
+	descriptions, err := s.db.Querier.GetDatasetColumnDescriptions(ctx, datasetID)
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	applyColumnDescriptions(meta.Schema.Columns, descriptions)
+
 	schemaJSON, err := json.Marshal(meta.Schema.Columns)
 	if err != nil {
 		return errs.E(errs.InvalidRequest, op, err)
 	}
 
+	memberTables, err := memberTablesToJSON(meta.Tables)
+	if err != nil {
+		return errs.E(errs.InvalidRequest, op, err)
+	}
+
 	err = s.db.Querier.UpdateBigqueryDatasourceSchema(ctx, gensql.UpdateBigqueryDatasourceSchemaParams{
 		Schema: pqtype.NullRawMessage{
 			RawMessage: schemaJSON,
 			Valid:      true,
 		},
-		LastModified:  meta.LastModified,
-		Expires:       sql.NullTime{Time: meta.Expires, Valid: !meta.Expires.IsZero()},
-		Description:   sql.NullString{String: meta.Description, Valid: true},
-		PseudoColumns: nil,
-		DatasetID:     datasetID,
+		LastModified: meta.LastModified,
+		Expires:      sql.NullTime{Time: meta.Expires, Valid: !meta.Expires.IsZero()},
+		Description:  sql.NullString{String: meta.Description, Valid: true},
+		Tables:       memberTables,
+		DatasetID:    datasetID,
 	})
 	if err != nil {
 		return errs.E(errs.Database, op, err)
@@ -120,6 +144,16 @@ func (s *bigQueryStorage) GetBigqueryDatasources(ctx context.Context) ([]*servic
 			}
 		}
 
+		pseudoColumns, err := pseudoColumnsFromJSON(bq.PseudoColumns)
+		if err != nil {
+			return nil, errs.E(errs.Internal, op, err)
+		}
+
+		memberTables, err := memberTablesFromJSON(bq.Tables)
+		if err != nil {
+			return nil, errs.E(errs.Internal, op, err)
+		}
+
 		ret[i] = &service.BigQuery{
 			ID:            bq.ID,
 			DatasetID:     bq.DatasetID,
@@ -133,8 +167,11 @@ func (s *bigQueryStorage) GetBigqueryDatasources(ctx context.Context) ([]*servic
 			Description:   bq.Description.String,
 			PiiTags:       &piiTags,
 			MissingSince:  &bq.MissingSince.Time,
-			PseudoColumns: bq.PseudoColumns,
+			PseudoColumns: pseudoColumns,
 			Schema:        schema.Columns,
+			Scope:         service.BigQueryScope(bq.Scope),
+			TablePattern:  bq.TablePattern,
+			Tables:        memberTables,
 		}
 	}
 
@@ -169,6 +206,16 @@ func (s *bigQueryStorage) GetBigqueryDatasource(ctx context.Context, datasetID u
 		}
 	}
 
+	pseudoColumns, err := pseudoColumnsFromJSON(bq.PseudoColumns)
+	if err != nil {
+		return nil, errs.E(errs.Internal, op, err)
+	}
+
+	memberTables, err := memberTablesFromJSON(bq.Tables)
+	if err != nil {
+		return nil, errs.E(errs.Internal, op, err)
+	}
+
 	return &service.BigQuery{
 		ID:            bq.ID,
 		DatasetID:     bq.DatasetID,
@@ -182,8 +229,11 @@ func (s *bigQueryStorage) GetBigqueryDatasource(ctx context.Context, datasetID u
 		Description:   bq.Description.String,
 		PiiTags:       &piiTags,
 		MissingSince:  &bq.MissingSince.Time,
-		PseudoColumns: bq.PseudoColumns,
+		PseudoColumns: pseudoColumns,
 		Schema:        schema.Columns,
+		Scope:         service.BigQueryScope(bq.Scope),
+		TablePattern:  bq.TablePattern,
+		Tables:        memberTables,
 	}, nil
 }
 
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+var _ service.CatalogueExportStorage = &catalogueExportStorage{}
+
+type catalogueExportStorage struct {
+	db *database.Repo
+}
+
+func (s *catalogueExportStorage) GetCatalogueEntries(ctx context.Context, modifiedSince *time.Time) ([]*service.CatalogueEntry, error) {
+	const op errs.Op = "catalogueExportStorage.GetCatalogueEntries"
+
+	rows, err := s.db.Querier.GetCatalogueExportRows(ctx, ptrToNullTime(modifiedSince))
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	var entries []*service.CatalogueEntry
+
+	seen := map[uuid.UUID]*service.CatalogueEntry{}
+
+	for _, row := range rows {
+		entry, ok := seen[row.DpID]
+		if !ok {
+			entry = &service.CatalogueEntry{
+				ID:               row.DpID,
+				Name:             row.DpName,
+				Description:      nullStringToPtr(row.DpDescription),
+				Slug:             row.DpSlug,
+				Group:            row.DpGroup,
+				Created:          row.DpCreated,
+				LastModified:     row.DpLastModified,
+				TeamName:         nullStringToPtr(row.TeamName),
+				TeamkatalogenURL: nullStringToPtr(row.TeamkatalogenUrl),
+				TeamContact:      nullStringToPtr(row.TeamContact),
+			}
+
+			seen[row.DpID] = entry
+			entries = append(entries, entry)
+		}
+
+		if !row.DsID.Valid {
+			continue
+		}
+
+		entry.Datasets = append(entry.Datasets, &service.CatalogueEntryDataset{
+			ID:              row.DsID.UUID,
+			Name:            row.DsName.String,
+			Description:     nullStringToPtr(row.DsDescription),
+			Slug:            row.DsSlug.String,
+			Keywords:        row.DsKeywords,
+			Pii:             service.PiiLevel(row.DsPii.PiiLevel),
+			Type:            service.DatasourceType(row.DsType.DatasourceType),
+			LifecycleStatus: service.LifecycleStatus(row.DsLifecycleStatus.LifecycleStatus),
+			Created:         row.DsCreated.Time,
+			LastModified:    row.DsLastModified.Time,
+		})
+	}
+
+	return entries, nil
+}
+
+func NewCatalogueExportStorage(db *database.Repo) *catalogueExportStorage {
+	return &catalogueExportStorage{
+		db: db,
+	}
+}
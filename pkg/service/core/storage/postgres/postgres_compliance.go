@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/database/gensql"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+var _ service.ComplianceStorage = &complianceStorage{}
+
+type complianceStorage struct {
+	db *database.Repo
+}
+
+func (s *complianceStorage) GetPersonalDataInventory(ctx context.Context, filter service.ComplianceReportFilter) ([]*service.PersonalDataset, error) {
+	const op errs.Op = "complianceStorage.GetPersonalDataInventory"
+
+	raw, err := s.db.Querier.GetPersonalDataInventory(ctx, gensql.GetPersonalDataInventoryParams{
+		ProductAreaID: uuidPtrToNullUUID(filter.ProductAreaID),
+		TeamID:        uuidPtrToNullUUID(filter.TeamID),
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	datasets := make([]*service.PersonalDataset, len(raw))
+	datasetIDs := make([]uuid.UUID, len(raw))
+	byID := map[uuid.UUID]*service.PersonalDataset{}
+
+	for i, r := range raw {
+		ds, err := personalDatasetFromSQL(r)
+		if err != nil {
+			return nil, errs.E(errs.Internal, op, err)
+		}
+
+		datasets[i] = ds
+		datasetIDs[i] = ds.DatasetID
+		byID[ds.DatasetID] = ds
+	}
+
+	accesses, err := s.db.Querier.ListActiveAccessWithPurposeForDatasets(ctx, datasetIDs)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	for _, a := range accesses {
+		ds, ok := byID[a.DatasetID]
+		if !ok {
+			continue
+		}
+
+		ds.Accesses = append(ds.Accesses, personalDataAccessFromSQL(a))
+	}
+
+	return datasets, nil
+}
+
+func personalDatasetFromSQL(r gensql.GetPersonalDataInventoryRow) (*service.PersonalDataset, error) {
+	piiTags := map[string]string{}
+	if r.PiiTags.Valid && len(r.PiiTags.RawMessage) > 0 {
+		if err := json.Unmarshal(r.PiiTags.RawMessage, &piiTags); err != nil {
+			return nil, err
+		}
+	}
+
+	piiMismatch := false
+	if service.PiiLevel(r.Pii) == service.PiiLevelNone {
+		for _, tag := range piiTags {
+			if tag != service.PiiTagNone {
+				piiMismatch = true
+			}
+		}
+	}
+
+	var pseudoColumns []service.PseudoColumn
+	if r.PseudoColumns.Valid {
+		var err error
+
+		pseudoColumns, err = pseudoColumnsFromJSON(r.PseudoColumns.RawMessage)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var exposure *service.MetabaseExposure
+	if r.InMetabase {
+		e := service.MetabaseExposureRestricted
+		if r.MetabasePermissionGroupID.Valid && r.MetabasePermissionGroupID.Int32 == 0 {
+			e = service.MetabaseExposureOpen
+		}
+
+		exposure = &e
+	}
+
+	return &service.PersonalDataset{
+		DatasetID:                r.DatasetID,
+		DatasetName:              r.DatasetName,
+		DataproductID:            r.DataproductID,
+		DataproductName:          r.DataproductName,
+		Pii:                      service.PiiLevel(r.Pii),
+		AnonymisationDescription: nullStringToPtr(r.AnonymisationDescription),
+		PiiTags:                  piiTags,
+		PiiMismatch:              piiMismatch,
+		PseudoColumns:            pseudoColumns,
+		OwnerGroup:               r.OwnerGroup,
+		TeamContact:              nullStringToPtr(r.TeamContact),
+		TeamID:                   nullUUIDToUUIDPtr(r.TeamID),
+		TeamName:                 nullStringToPtr(r.TeamName),
+		ProductAreaID:            nullUUIDToUUIDPtr(r.ProductAreaID),
+		ProductAreaName:          nullStringToPtr(r.ProductAreaName),
+		MetabaseExposure:         exposure,
+		Accesses:                 []*service.PersonalDataAccess{},
+	}, nil
+}
+
+func personalDataAccessFromSQL(a gensql.ListActiveAccessWithPurposeForDatasetsRow) *service.PersonalDataAccess {
+	var purpose *service.QueryPolly
+	if a.PurposeExternalID.Valid {
+		purpose = &service.QueryPolly{
+			ExternalID: a.PurposeExternalID.String,
+			Name:       a.PurposeName.String,
+			URL:        a.PurposeUrl.String,
+		}
+	}
+
+	var status *service.PollyPurposeStatus
+	if a.PurposeStatus.Valid {
+		s := service.PollyPurposeStatus(a.PurposeStatus.PollyPurposeStatus)
+		status = &s
+	}
+
+	return &service.PersonalDataAccess{
+		AccessID:      a.AccessID,
+		Subject:       a.Subject,
+		Owner:         a.Owner,
+		Granter:       a.Granter,
+		Created:       a.Created,
+		Expires:       nullTimeToPtr(a.Expires),
+		Purpose:       purpose,
+		PurposeStatus: status,
+	}
+}
+
+func NewComplianceStorage(db *database.Repo) *complianceStorage {
+	return &complianceStorage{
+		db: db,
+	}
+}
@@ -62,6 +62,85 @@ func (s *dataProductStorage) GetDataproductsByTeamID(ctx context.Context, teamID
 	return dps, nil
 }
 
+func (s *dataProductStorage) GetDataproductsByTeamIDPage(ctx context.Context, teamIDs []uuid.UUID, page service.PageRequest) (*service.Page[*service.Dataproduct], error) {
+	const op errs.Op = "dataProductStorage.GetDataproductsByTeamIDPage"
+
+	raw, err := s.db.Querier.GetDataproductsByProductAreaPage(ctx, gensql.GetDataproductsByProductAreaPageParams{
+		Sort:         page.Sort,
+		TeamID:       teamIDs,
+		AfterID:      pageAfterID(page),
+		Descending:   page.Descending(),
+		AfterName:    pageAfterName(page),
+		AfterCreated: pageAfterCreated(page),
+		Lim:          pageLimit(page),
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	dataproducts, err := pageFrom(raw, page,
+		func(r gensql.GetDataproductsByProductAreaPageRow) (string, uuid.UUID) {
+			return pageKey(page, r.DataproductWithTeamkatalogenView.Name, r.DataproductWithTeamkatalogenView.Created), r.DataproductWithTeamkatalogenView.ID
+		},
+		func(r gensql.GetDataproductsByProductAreaPageRow) (*service.Dataproduct, error) {
+			return s.dataproductWithKeywords(ctx, &r.DataproductWithTeamkatalogenView)
+		},
+	)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return dataproducts, nil
+}
+
+func (s *dataProductStorage) GetDataproductsByGroupsPage(ctx context.Context, groups []string, page service.PageRequest) (*service.Page[*service.Dataproduct], error) {
+	const op errs.Op = "dataProductStorage.GetDataproductsByGroupsPage"
+
+	raw, err := s.db.Querier.GetDataproductsByGroupsPage(ctx, gensql.GetDataproductsByGroupsPageParams{
+		Sort:         page.Sort,
+		Groups:       groups,
+		AfterID:      pageAfterID(page),
+		Descending:   page.Descending(),
+		AfterName:    pageAfterName(page),
+		AfterCreated: pageAfterCreated(page),
+		Lim:          pageLimit(page),
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	dataproducts, err := pageFrom(raw, page,
+		func(r gensql.GetDataproductsByGroupsPageRow) (string, uuid.UUID) {
+			return pageKey(page, r.DataproductWithTeamkatalogenView.Name, r.DataproductWithTeamkatalogenView.Created), r.DataproductWithTeamkatalogenView.ID
+		},
+		func(r gensql.GetDataproductsByGroupsPageRow) (*service.Dataproduct, error) {
+			return s.dataproductWithKeywords(ctx, &r.DataproductWithTeamkatalogenView)
+		},
+	)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return dataproducts, nil
+}
+
+func (s *dataProductStorage) dataproductWithKeywords(ctx context.Context, raw *gensql.DataproductWithTeamkatalogenView) (*service.Dataproduct, error) {
+	dp := dataproductFromSQL(raw)
+
+	keywords, err := s.GetDataproductKeywords(ctx, dp.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if keywords == nil {
+		keywords = []string{}
+	}
+
+	dp.Keywords = keywords
+
+	return dp, nil
+}
+
 func (s *dataProductStorage) GetDataproductsNumberByTeam(ctx context.Context, teamID uuid.UUID) (int64, error) {
 	const op errs.Op = "dataProductStorage.GetDataproductsNumberByTeam"
 
@@ -77,71 +156,118 @@ func (s *dataProductStorage) GetDataproductsNumberByTeam(ctx context.Context, te
 	return n, nil
 }
 
-func (s *dataProductStorage) GetAccessibleDatasets(ctx context.Context, userGroups []string, requester string) (owned []*service.AccessibleDataset, granted []*service.AccessibleDataset, serviceAccountGranted []*service.AccessibleDataset, err error) {
-	const op errs.Op = "dataProductStorage.GetAccessibleDatasets"
+func (s *dataProductStorage) GetOwnedDatasets(ctx context.Context, groups []string, page service.PageRequest) (*service.Page[*service.AccessibleDataset], error) {
+	const op errs.Op = "dataProductStorage.GetOwnedDatasets"
+
+	raw, err := s.db.Querier.GetOwnedDatasetsPage(ctx, gensql.GetOwnedDatasetsPageParams{
+		Sort:         page.Sort,
+		Groups:       groups,
+		AfterID:      pageAfterID(page),
+		Descending:   page.Descending(),
+		AfterName:    pageAfterName(page),
+		AfterCreated: pageAfterCreated(page),
+		Lim:          pageLimit(page),
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	datasets, err := pageFrom(raw, page,
+		func(r gensql.GetOwnedDatasetsPageRow) (string, uuid.UUID) {
+			return pageKey(page, r.Dataset.Name, r.Dataset.Created), r.Dataset.ID
+		},
+		func(r gensql.GetOwnedDatasetsPageRow) (*service.AccessibleDataset, error) {
+			return accessibleDatasetFromSQL(r.Dataset, r.DpSlug, r.DpName, r.Group, nil), nil
+		},
+	)
+	if err != nil {
+		return nil, errs.E(errs.Internal, op, err)
+	}
+
+	return datasets, nil
+}
 
-	datasetsSQL, err := s.db.Querier.GetAccessibleDatasets(ctx, gensql.GetAccessibleDatasetsParams{
-		Groups:    userGroups,
-		Requester: requester,
+func (s *dataProductStorage) GetGrantedDatasets(ctx context.Context, groups []string, requester string, page service.PageRequest) (*service.Page[*service.AccessibleDataset], error) {
+	const op errs.Op = "dataProductStorage.GetGrantedDatasets"
+
+	raw, err := s.db.Querier.GetGrantedDatasetsPage(ctx, gensql.GetGrantedDatasetsPageParams{
+		Requester:    requester,
+		Groups:       groups,
+		Sort:         page.Sort,
+		AfterID:      pageAfterID(page),
+		Descending:   page.Descending(),
+		AfterName:    pageAfterName(page),
+		AfterCreated: pageAfterCreated(page),
+		Lim:          pageLimit(page),
 	})
 	if err != nil {
-		return nil, nil, nil, errs.E(errs.Database, op, err)
+		return nil, errs.E(errs.Database, op, err)
 	}
 
-	for _, d := range datasetsSQL {
-		if matchAny(nullStringToString(d.Group), userGroups) {
-			owned = append(owned, accessibleDatasetFromSql(&d))
-		} else {
-			granted = append(granted, accessibleDatasetFromSql(&d))
-		}
+	datasets, err := pageFrom(raw, page,
+		func(r gensql.GetGrantedDatasetsPageRow) (string, uuid.UUID) {
+			return pageKey(page, r.Dataset.Name, r.Dataset.Created), r.Dataset.ID
+		},
+		func(r gensql.GetGrantedDatasetsPageRow) (*service.AccessibleDataset, error) {
+			return accessibleDatasetFromSQL(r.Dataset, r.DpSlug, r.DpName, r.Group, &r.Subject), nil
+		},
+	)
+	if err != nil {
+		return nil, errs.E(errs.Internal, op, err)
 	}
 
-	serviceAccountAccessible, err := s.db.Querier.GetAccessibleDatasetsByOwnedServiceAccounts(ctx, gensql.GetAccessibleDatasetsByOwnedServiceAccountsParams{
-		Requester: requester,
-		Groups:    userGroups,
+	return datasets, nil
+}
+
+func (s *dataProductStorage) GetServiceAccountGrantedDatasets(ctx context.Context, groups []string, requester string, page service.PageRequest) (*service.Page[*service.AccessibleDataset], error) {
+	const op errs.Op = "dataProductStorage.GetServiceAccountGrantedDatasets"
+
+	raw, err := s.db.Querier.GetServiceAccountGrantedDatasetsPage(ctx, gensql.GetServiceAccountGrantedDatasetsPageParams{
+		Sort:         page.Sort,
+		Requester:    requester,
+		Groups:       groups,
+		AfterID:      pageAfterID(page),
+		Descending:   page.Descending(),
+		AfterName:    pageAfterName(page),
+		AfterCreated: pageAfterCreated(page),
+		Lim:          pageLimit(page),
 	})
 	if err != nil {
-		return nil, nil, nil, errs.E(errs.Database, op, err)
+		return nil, errs.E(errs.Database, op, err)
 	}
 
-	for _, d := range serviceAccountAccessible {
-		serviceAccountGranted = append(serviceAccountGranted, &service.AccessibleDataset{
-			Dataset: service.Dataset{
-				ID:            d.ID,
-				Name:          d.Name,
-				DataproductID: d.DataproductID,
-				Keywords:      d.Keywords,
-				Slug:          d.Slug,
-				Description:   nullStringToPtr(d.Description),
-				Created:       d.Created,
-				LastModified:  d.LastModified,
-			},
-			Group:           nullStringToString(d.Group),
-			DpSlug:          *nullStringToPtr(d.DpSlug),
-			DataproductName: nullStringToString(d.DpName),
-			Subject:         nullStringToPtr(d.Subject),
-		})
+	// A dataset can be granted to several service accounts, so the access is the position in the list
+	datasets, err := pageFrom(raw, page,
+		func(r gensql.GetServiceAccountGrantedDatasetsPageRow) (string, uuid.UUID) {
+			return pageKey(page, r.Dataset.Name, r.Dataset.Created), r.AccessID
+		},
+		func(r gensql.GetServiceAccountGrantedDatasetsPageRow) (*service.AccessibleDataset, error) {
+			return accessibleDatasetFromSQL(r.Dataset, r.DpSlug, r.DpName, r.Group, &r.Subject), nil
+		},
+	)
+	if err != nil {
+		return nil, errs.E(errs.Internal, op, err)
 	}
 
-	return
+	return datasets, nil
 }
 
-func accessibleDatasetFromSql(d *gensql.GetAccessibleDatasetsRow) *service.AccessibleDataset {
+func accessibleDatasetFromSQL(ds gensql.Dataset, dpSlug, dpName, group string, subject *string) *service.AccessibleDataset {
 	return &service.AccessibleDataset{
 		Dataset: service.Dataset{
-			ID:            d.ID,
-			Name:          d.Name,
-			DataproductID: d.DataproductID,
-			Keywords:      d.Keywords,
-			Slug:          d.Slug,
-			Description:   nullStringToPtr(d.Description),
-			Created:       d.Created,
-			LastModified:  d.LastModified,
+			ID:            ds.ID,
+			Name:          ds.Name,
+			DataproductID: ds.DataproductID,
+			Keywords:      ds.Keywords,
+			Slug:          ds.Slug,
+			Description:   nullStringToPtr(ds.Description),
+			Created:       ds.Created,
+			LastModified:  ds.LastModified,
 		},
-		Group:           nullStringToString(d.Group),
-		DpSlug:          *nullStringToPtr(d.DpSlug),
-		DataproductName: nullStringToString(d.DpName),
-		Subject:         nullStringToPtr(d.Subject),
+		Group:           group,
+		DpSlug:          dpSlug,
+		DataproductName: dpName,
+		Subject:         subject,
 	}
 }
 
@@ -174,16 +300,20 @@ func dataproductsWithDatasetAndAccessRequestsForGranterFromSQL(dprrows []gensql.
 	dprows := make([]gensql.GetDataproductsWithDatasetsRow, len(dprrows))
 	for i, dprrow := range dprrows {
 		dprows[i] = gensql.GetDataproductsWithDatasetsRow{
-			DpID:             dprrow.DpID,
-			DpName:           dprrow.DpName,
-			DpCreated:        dprrow.DpCreated,
-			DpLastModified:   dprrow.DpLastModified,
-			DpDescription:    dprrow.DpDescription,
-			DpSlug:           dprrow.DpSlug,
-			DpGroup:          dprrow.DpGroup,
-			TeamkatalogenUrl: dprrow.TeamkatalogenUrl,
-			TeamContact:      dprrow.TeamContact,
-			TeamID:           dprrow.TeamID,
+			DpID:                dprrow.DpID,
+			DpName:              dprrow.DpName,
+			DpCreated:           dprrow.DpCreated,
+			DpLastModified:      dprrow.DpLastModified,
+			DpDescription:       dprrow.DpDescription,
+			DpSlug:              dprrow.DpSlug,
+			DpGroup:             dprrow.DpGroup,
+			TeamkatalogenUrl:    dprrow.TeamkatalogenUrl,
+			TeamContact:         dprrow.TeamContact,
+			TeamID:              dprrow.TeamID,
+			DpLifecycleStatus:   dprrow.DpLifecycleStatus,
+			DpReplacedBy:        dprrow.DpReplacedBy,
+			DpSunset:            dprrow.DpSunset,
+			DpDeprecationReason: dprrow.DpDeprecationReason,
 		}
 	}
 	dp := dataproductsWithDatasetFromSQL(dprows)
@@ -299,6 +429,43 @@ func (s *dataProductStorage) GetDatasetsMinimal(ctx context.Context) ([]*service
 	return dss, nil
 }
 
+func (s *dataProductStorage) GetDatasetsMinimalPage(ctx context.Context, page service.PageRequest) (*service.Page[*service.DatasetMinimal], error) {
+	const op errs.Op = "dataProductStorage.GetDatasetsMinimalPage"
+
+	raw, err := s.db.Querier.GetDatasetsMinimalPage(ctx, gensql.GetDatasetsMinimalPageParams{
+		Sort:         page.Sort,
+		AfterID:      pageAfterID(page),
+		Descending:   page.Descending(),
+		AfterName:    pageAfterName(page),
+		AfterCreated: pageAfterCreated(page),
+		Lim:          pageLimit(page),
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	datasets, err := pageFrom(raw, page,
+		func(r gensql.GetDatasetsMinimalPageRow) (string, uuid.UUID) {
+			return pageKey(page, r.Name, r.Created), r.ID
+		},
+		func(r gensql.GetDatasetsMinimalPageRow) (*service.DatasetMinimal, error) {
+			return &service.DatasetMinimal{
+				ID:              r.ID,
+				Name:            r.Name,
+				Created:         r.Created,
+				BigQueryProject: r.ProjectID,
+				BigQueryDataset: r.Dataset,
+				BigQueryTable:   r.TableName,
+			}, nil
+		},
+	)
+	if err != nil {
+		return nil, errs.E(errs.Internal, op, err)
+	}
+
+	return datasets, nil
+}
+
 func (s *dataProductStorage) UpdateDataset(ctx context.Context, id uuid.UUID, input service.UpdateDatasetDto) (string, error) {
 	const op errs.Op = "dataProductStorage.UpdateDataset"
 
@@ -317,8 +484,16 @@ func (s *dataProductStorage) UpdateDataset(ctx context.Context, id uuid.UUID, in
 		DataproductID:            *input.DataproductID,
 		AnonymisationDescription: ptrToNullString(input.AnonymisationDescription),
 		TargetUser:               ptrToNullString(input.TargetUser),
+		IfMatch:                  ptrToNullTime(input.IfMatch),
 	})
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) && input.IfMatch != nil {
+			// The update is conditional, so check whether the dataset is missing or has been modified
+			if _, getErr := s.db.Querier.GetDataset(ctx, id); getErr == nil {
+				return "", errs.E(errs.PreconditionFailed, op, fmt.Errorf("dataset %s has been modified", id))
+			}
+		}
+
 		if errors.Is(err, sql.ErrNoRows) {
 			return "", errs.E(errs.NotExist, op, err)
 		}
@@ -385,6 +560,16 @@ func (s *dataProductStorage) CreateDataset(ctx context.Context, ds service.NewDa
 		return nil, errs.E(errs.InvalidRequest, op, err, errs.Parameter("pii_tags"))
 	}
 
+	pseudoColumns, err := pseudoColumnsToJSON(ds.PseudoColumns)
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err, errs.Parameter("pseudo_columns"))
+	}
+
+	memberTables, err := memberTablesToJSON(ds.Metadata.Tables)
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err, errs.Parameter("tables"))
+	}
+
 	_, err = querier.CreateBigqueryDatasource(ctx, gensql.CreateBigqueryDatasourceParams{
 		DatasetID:    created.ID,
 		ProjectID:    ds.BigQuery.ProjectID,
@@ -399,8 +584,11 @@ func (s *dataProductStorage) CreateDataset(ctx context.Context, ds service.NewDa
 			RawMessage: json.RawMessage([]byte(ptrToString(ds.BigQuery.PiiTags))),
 			Valid:      len(ptrToString(ds.BigQuery.PiiTags)) > 4,
 		},
-		PseudoColumns: ds.PseudoColumns,
+		PseudoColumns: pseudoColumns,
 		IsReference:   false,
+		Scope:         bigQueryScopeOrDefault(ds.BigQuery.Scope),
+		TablePattern:  ds.BigQuery.TablePattern,
+		Tables:        memberTables,
 	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -425,8 +613,9 @@ func (s *dataProductStorage) CreateDataset(ctx context.Context, ds service.NewDa
 				RawMessage: json.RawMessage([]byte(ptrToString(ds.BigQuery.PiiTags))),
 				Valid:      len(ptrToString(ds.BigQuery.PiiTags)) > 4,
 			},
-			PseudoColumns: ds.PseudoColumns,
+			PseudoColumns: pseudoColumns,
 			IsReference:   true,
+			Scope:         string(service.BigQueryScopeTable),
 		})
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
@@ -473,6 +662,96 @@ func (s *dataProductStorage) CreateDataset(ctx context.Context, ds service.NewDa
 	return ret, nil
 }
 
+func (s *dataProductStorage) CreateGCSDataset(ctx context.Context, ds service.NewDataset, meta service.DatasourceMetadata, user *service.User) (*service.Dataset, error) {
+	const op errs.Op = "dataProductStorage.CreateGCSDataset"
+
+	if ds.GCS == nil {
+		return nil, errs.E(errs.InvalidRequest, op, errs.Str("missing gcs datasource"), errs.Parameter("gcs"))
+	}
+
+	tx, err := s.db.GetDB().Begin()
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+	defer tx.Rollback()
+
+	if ds.Keywords == nil {
+		ds.Keywords = []string{}
+	}
+
+	querier := s.db.Querier.WithTx(tx)
+
+	created, err := querier.CreateDataset(ctx, gensql.CreateDatasetParams{
+		Name:                     ds.Name,
+		DataproductID:            ds.DataproductID,
+		Description:              ptrToNullString(ds.Description),
+		Pii:                      gensql.PiiLevel(ds.Pii),
+		Type:                     gensql.DatasourceTypeGcs,
+		Slug:                     slugify(ds.Slug, ds.Name),
+		Repo:                     ptrToNullString(ds.Repo),
+		Keywords:                 ds.Keywords,
+		AnonymisationDescription: ptrToNullString(ds.AnonymisationDescription),
+		TargetUser:               ptrToNullString(ds.TargetUser),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.E(errs.NotExist, op, err)
+		}
+
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	objectsJSON, err := json.Marshal(meta.Objects)
+	if err != nil {
+		return nil, errs.E(errs.InvalidRequest, op, err, errs.Parameter("objects"))
+	}
+
+	_, err = querier.CreateGCSDatasource(ctx, gensql.CreateGCSDatasourceParams{
+		DatasetID:    created.ID,
+		ProjectID:    ds.GCS.ProjectID,
+		Bucket:       ds.GCS.Bucket,
+		Prefix:       ds.GCS.Prefix,
+		Description:  meta.Description,
+		Objects:      pqtype.NullRawMessage{RawMessage: objectsJSON, Valid: len(objectsJSON) > 4},
+		Created:      meta.Created,
+		LastModified: meta.LastModified,
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	if ds.GrantAllUsers != nil && *ds.GrantAllUsers {
+		_, err = querier.GrantAccessToDataset(ctx, gensql.GrantAccessToDatasetParams{
+			DatasetID: created.ID,
+			Expires:   sql.NullTime{},
+			Subject:   emailOfSubjectToLower("group:all-users@nav.no"),
+			Granter:   user.Email,
+		})
+		if err != nil {
+			return nil, errs.E(errs.Database, op, err)
+		}
+	}
+
+	for _, keyword := range ds.Keywords {
+		err = querier.CreateTagIfNotExist(ctx, keyword)
+		if err != nil {
+			s.log.Warn().Err(err).Msg("failed to create tag when creating dataset in database")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	ret, err := s.GetDataset(ctx, created.ID)
+	if err != nil {
+		return nil, errs.E(op, err)
+	}
+
+	return ret, nil
+}
+
 func (s *dataProductStorage) DeleteDataproduct(ctx context.Context, id uuid.UUID) error {
 	const op errs.Op = "dataProductStorage.DeleteDataproduct"
 
@@ -484,6 +763,20 @@ func (s *dataProductStorage) DeleteDataproduct(ctx context.Context, id uuid.UUID
 	return nil
 }
 
+func (s *dataProductStorage) SoftDeleteDataproduct(ctx context.Context, id uuid.UUID, deletedBy string) error {
+	const op errs.Op = "dataProductStorage.SoftDeleteDataproduct"
+
+	err := s.db.Querier.SoftDeleteDataproduct(ctx, gensql.SoftDeleteDataproductParams{
+		ID:        id,
+		DeletedBy: sql.NullString{String: deletedBy, Valid: true},
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
 func (s *dataProductStorage) UpdateDataproduct(ctx context.Context, id uuid.UUID, input service.UpdateDataproductDto) (*service.DataproductMinimal, error) {
 	const op errs.Op = "dataProductStorage.UpdateDataproduct"
 
@@ -495,8 +788,16 @@ func (s *dataProductStorage) UpdateDataproduct(ctx context.Context, id uuid.UUID
 		TeamContact:           ptrToNullString(input.TeamContact),
 		Slug:                  slugify(input.Slug, input.Name),
 		TeamID:                uuidPtrToNullUUID(input.TeamID),
+		IfMatch:               ptrToNullTime(input.IfMatch),
 	})
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) && input.IfMatch != nil {
+			// The update is conditional, so check whether the dataproduct is missing or has been modified
+			if _, getErr := s.db.Querier.GetDataproduct(ctx, id); getErr == nil {
+				return nil, errs.E(errs.PreconditionFailed, op, fmt.Errorf("dataproduct %s has been modified", id))
+			}
+		}
+
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errs.E(errs.NotExist, op, err)
 		}
@@ -576,6 +877,33 @@ func (s *dataProductStorage) GetDataset(ctx context.Context, id uuid.UUID) (*ser
 		return nil, errs.E(errs.Internal, op, err)
 	}
 
+	if ds != nil && ds.Datasource == nil {
+		gcs, err := s.db.Querier.GetGCSDatasource(ctx, ds.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.E(errs.Database, op, err)
+		}
+
+		if err == nil {
+			ds.GCSDatasource = gcsDatasourceFromSQL(gcs)
+		}
+	}
+
+	if ds == nil {
+		return nil, errs.E(errs.NotExist, op, fmt.Errorf("dataset with id %v does not exist", id))
+	}
+
+	quality, err := s.db.Querier.GetDatasetQualityStatus(ctx, ds.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	if err == nil {
+		ds.QualityStatus = &service.DatasetQualityStatus{
+			Status:  service.QualityCheckStatus(quality.Status),
+			LastRun: quality.LastRun,
+		}
+	}
+
 	return ds, nil
 }
 
@@ -605,6 +933,12 @@ func (s *dataProductStorage) datasetFromSQL(dsrows []gensql.DatasetView) (*servi
 				Datasource:        nil,
 				Pii:               service.PiiLevel(dsrow.Pii),
 				MetabaseDeletedAt: nullTimeToPtr(dsrow.MbDeletedAt),
+				Lifecycle: lifecycleFromSQL(
+					dsrow.DsLifecycleStatus,
+					dsrow.DsReplacedBy,
+					dsrow.DsSunset,
+					dsrow.DsDeprecationReason,
+				),
 			}
 		}
 
@@ -616,6 +950,16 @@ func (s *dataProductStorage) datasetFromSQL(dsrows []gensql.DatasetView) (*servi
 				}
 			}
 
+			pseudoColumns, err := pseudoColumnsFromJSON(dsrow.PseudoColumns)
+			if err != nil {
+				return nil, errs.E(errs.Internal, op, fmt.Errorf("unmarshalling pseudo columns: %w", err))
+			}
+
+			memberTables, err := memberTablesFromJSON(dsrow.BqTables)
+			if err != nil {
+				return nil, errs.E(errs.Internal, op, fmt.Errorf("unmarshalling member tables: %w", err))
+			}
+
 			dsrc := &service.BigQuery{
 				ID:            dsrow.BqID,
 				DatasetID:     dsrow.DsID,
@@ -629,8 +973,11 @@ func (s *dataProductStorage) datasetFromSQL(dsrows []gensql.DatasetView) (*servi
 				Description:   dsrow.BqDescription.String,
 				PiiTags:       &piiTags,
 				MissingSince:  nullTimeToPtr(dsrow.BqMissingSince),
-				PseudoColumns: dsrow.PseudoColumns,
+				PseudoColumns: pseudoColumns,
 				Schema:        schema,
+				Scope:         service.BigQueryScope(dsrow.BqScope),
+				TablePattern:  dsrow.BqTablePattern,
+				Tables:        memberTables,
 			}
 			dataset.Datasource = dsrc
 		}
@@ -760,6 +1107,12 @@ __loop_rows:
 					TeamID:           nullUUIDToUUIDPtr(dprow.TeamID),
 					ProductAreaID:    nullUUIDToUUIDPtr(dprow.PaID),
 				},
+				Lifecycle: lifecycleFromSQL(
+					dprow.DpLifecycleStatus,
+					dprow.DpReplacedBy,
+					dprow.DpSunset,
+					dprow.DpDeprecationReason,
+				),
 			},
 		}
 
@@ -815,6 +1168,12 @@ func datasetsInDataProductFromSQL(dsrows []gensql.GetDataproductsWithDatasetsRow
 				Keywords:               dsrow.DsKeywords,
 				DataproductID:          dsrow.DpID,
 				DataSourceLastModified: dsrow.DsrcLastModified.Time,
+				Lifecycle: lifecycleFromSQL(
+					dsrow.DsLifecycleStatus.LifecycleStatus,
+					dsrow.DsReplacedBy,
+					dsrow.DsSunset,
+					dsrow.DsDeprecationReason,
+				),
 			}
 			datasets = append(datasets, ds)
 		}
@@ -857,6 +1216,21 @@ func dataproductFromSQL(dp *gensql.DataproductWithTeamkatalogenView) *service.Da
 		Slug:            dp.Slug,
 		TeamName:        nullStringToPtr(dp.TeamName),
 		ProductAreaName: nullStringToString(dp.PaName),
+		Lifecycle: lifecycleFromSQL(
+			dp.LifecycleStatus,
+			dp.ReplacedBy,
+			dp.Sunset,
+			dp.DeprecationReason,
+		),
+	}
+}
+
+func lifecycleFromSQL(status gensql.LifecycleStatus, replacedBy uuid.NullUUID, sunset sql.NullTime, reason sql.NullString) *service.Lifecycle {
+	return &service.Lifecycle{
+		Status:     service.LifecycleStatus(status),
+		ReplacedBy: nullUUIDToUUIDPtr(replacedBy),
+		Sunset:     nullTimeToPtr(sunset),
+		Reason:     nullStringToPtr(reason),
 	}
 }
 
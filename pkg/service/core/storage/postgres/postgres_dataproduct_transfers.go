@@ -0,0 +1,242 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/database/gensql"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+var _ service.DataproductTransferStorage = &dataproductTransferStorage{}
+
+type dataproductTransferStorage struct {
+	db *database.Repo
+}
+
+func (s *dataproductTransferStorage) CreateDataproductTransfer(ctx context.Context, dp *service.DataproductWithDataset, input service.NewDataproductTransfer, requestedBy string) (*service.DataproductTransfer, error) {
+	const op errs.Op = "dataproductTransferStorage.CreateDataproductTransfer"
+
+	storyIDs := input.StoryIDs
+	if storyIDs == nil {
+		storyIDs = []uuid.UUID{}
+	}
+
+	raw, err := s.db.Querier.CreateDataproductTransfer(ctx, gensql.CreateDataproductTransferParams{
+		DataproductID:      dp.ID,
+		FromGroup:          dp.Owner.Group,
+		FromTeamID:         uuidPtrToNullUUID(dp.Owner.TeamID),
+		ToGroup:            input.Group,
+		ToTeamID:           uuidPtrToNullUUID(input.TeamID),
+		ToTeamkatalogenUrl: ptrToNullString(input.TeamkatalogenURL),
+		ToTeamContact:      ptrToNullString(input.TeamContact),
+		StoryIds:           storyIDs,
+		RequestedBy:        requestedBy,
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return dataproductTransferFromSQL(raw), nil
+}
+
+func (s *dataproductTransferStorage) GetDataproductTransfer(ctx context.Context, id uuid.UUID) (*service.DataproductTransfer, error) {
+	const op errs.Op = "dataproductTransferStorage.GetDataproductTransfer"
+
+	raw, err := s.db.Querier.GetDataproductTransfer(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.E(errs.NotExist, op, err)
+		}
+
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return dataproductTransferFromSQL(raw), nil
+}
+
+func (s *dataproductTransferStorage) GetDataproductTransfersForGroups(ctx context.Context, groups []string) ([]*service.DataproductTransfer, error) {
+	const op errs.Op = "dataproductTransferStorage.GetDataproductTransfersForGroups"
+
+	rows, err := s.db.Querier.GetDataproductTransfersForGroups(ctx, groups)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	transfers := make([]*service.DataproductTransfer, len(rows))
+	for i, row := range rows {
+		transfers[i] = dataproductTransferFromSQL(row)
+	}
+
+	return transfers, nil
+}
+
+func (s *dataproductTransferStorage) GetDataproductTransfersForDataproduct(ctx context.Context, dataproductID uuid.UUID) ([]*service.DataproductTransfer, error) {
+	const op errs.Op = "dataproductTransferStorage.GetDataproductTransfersForDataproduct"
+
+	rows, err := s.db.Querier.GetDataproductTransfersForDataproduct(ctx, dataproductID)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	transfers := make([]*service.DataproductTransfer, len(rows))
+	for i, row := range rows {
+		transfers[i] = dataproductTransferFromSQL(row)
+	}
+
+	return transfers, nil
+}
+
+func (s *dataproductTransferStorage) ResolveDataproductTransfer(ctx context.Context, id uuid.UUID, status service.DataproductTransferStatus, resolvedBy string, reason *string) (*service.DataproductTransfer, error) {
+	const op errs.Op = "dataproductTransferStorage.ResolveDataproductTransfer"
+
+	raw, err := s.db.Querier.ResolveDataproductTransfer(ctx, gensql.ResolveDataproductTransferParams{
+		Status:     gensql.DataproductTransferStatus(status),
+		ResolvedBy: sql.NullString{String: resolvedBy, Valid: true},
+		Reason:     ptrToNullString(reason),
+		ID:         id,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("dataproduct transfer %v is not pending", id))
+		}
+
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return dataproductTransferFromSQL(raw), nil
+}
+
+// AcceptDataproductTransfer moves the dataproduct, the stories included in the
+// transfer and the accesses and pending access requests owned by the sending
+// group over to the receiving group, and marks the transfer as accepted. The
+// accepted transfer lists the access the sending group keeps to the datasets
+func (s *dataproductTransferStorage) AcceptDataproductTransfer(ctx context.Context, id uuid.UUID, acceptedBy string) (*service.DataproductTransfer, error) {
+	const op errs.Op = "dataproductTransferStorage.AcceptDataproductTransfer"
+
+	tx, err := s.db.GetDB().Begin()
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+	defer tx.Rollback()
+
+	q := s.db.Querier.WithTx(tx)
+
+	raw, err := q.ResolveDataproductTransfer(ctx, gensql.ResolveDataproductTransferParams{
+		Status:     gensql.DataproductTransferStatusAccepted,
+		ResolvedBy: sql.NullString{String: acceptedBy, Valid: true},
+		ID:         id,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("dataproduct transfer %v is not pending", id))
+		}
+
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	err = q.TransferDataproductOwner(ctx, gensql.TransferDataproductOwnerParams{
+		ToGroup:            raw.ToGroup,
+		ToTeamID:           raw.ToTeamID,
+		ToTeamkatalogenUrl: raw.ToTeamkatalogenUrl,
+		ToTeamContact:      raw.ToTeamContact,
+		ID:                 raw.DataproductID,
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	if len(raw.StoryIds) > 0 {
+		err = q.TransferStoriesOwner(ctx, gensql.TransferStoriesOwnerParams{
+			ToGroup:            raw.ToGroup,
+			ToTeamID:           raw.ToTeamID,
+			ToTeamkatalogenUrl: raw.ToTeamkatalogenUrl,
+			Ids:                raw.StoryIds,
+			FromGroup:          raw.FromGroup,
+		})
+		if err != nil {
+			return nil, errs.E(errs.Database, op, err)
+		}
+	}
+
+	err = q.TransferDatasetAccessOwner(ctx, gensql.TransferDatasetAccessOwnerParams{
+		FromGroup:     raw.FromGroup,
+		ToGroup:       raw.ToGroup,
+		DataproductID: raw.DataproductID,
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	err = q.TransferDatasetAccessRequestsOwner(ctx, gensql.TransferDatasetAccessRequestsOwnerParams{
+		FromGroup:     raw.FromGroup,
+		ToGroup:       raw.ToGroup,
+		DataproductID: raw.DataproductID,
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	// Access granted to the sending group is not moved, as it is the access of
+	// the group itself, so we return it for the receiving group to review
+	retained, err := q.GetActiveDatasetAccessForDataproductSubject(ctx, gensql.GetActiveDatasetAccessForDataproductSubjectParams{
+		DataproductID: raw.DataproductID,
+		Subject:       "group:" + raw.FromGroup,
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	transfer := dataproductTransferFromSQL(raw)
+	for _, a := range retained {
+		access, _ := DatasetAccess(a).To()
+		transfer.RetainedAccess = append(transfer.RetainedAccess, access)
+	}
+
+	return transfer, nil
+}
+
+func dataproductTransferFromSQL(t gensql.DataproductTransfer) *service.DataproductTransfer {
+	storyIDs := t.StoryIds
+	if storyIDs == nil {
+		storyIDs = []uuid.UUID{}
+	}
+
+	return &service.DataproductTransfer{
+		ID:            t.ID,
+		DataproductID: t.DataproductID,
+		From: service.DataproductOwner{
+			Group:  t.FromGroup,
+			TeamID: nullUUIDToUUIDPtr(t.FromTeamID),
+		},
+		To: service.DataproductOwner{
+			Group:            t.ToGroup,
+			TeamkatalogenURL: nullStringToPtr(t.ToTeamkatalogenUrl),
+			TeamContact:      nullStringToPtr(t.ToTeamContact),
+			TeamID:           nullUUIDToUUIDPtr(t.ToTeamID),
+		},
+		StoryIDs:    storyIDs,
+		Status:      service.DataproductTransferStatus(t.Status),
+		RequestedBy: t.RequestedBy,
+		Created:     t.Created,
+		ResolvedBy:  nullStringToPtr(t.ResolvedBy),
+		Resolved:    nullTimeToPtr(t.Resolved),
+		Reason:      nullStringToPtr(t.Reason),
+	}
+}
+
+func NewDataproductTransferStorage(db *database.Repo) *dataproductTransferStorage {
+	return &dataproductTransferStorage{
+		db: db,
+	}
+}
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/database/gensql"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+var _ service.DatasetPreviewStorage = &datasetPreviewStorage{}
+
+type datasetPreviewStorage struct {
+	db *database.Repo
+}
+
+func (s *datasetPreviewStorage) GetDatasetPreviewSettings(ctx context.Context, datasetID uuid.UUID) (*service.DatasetPreviewSettings, error) {
+	const op errs.Op = "datasetPreviewStorage.GetDatasetPreviewSettings"
+
+	raw, err := s.db.Querier.GetDatasetPreviewSettings(ctx, datasetID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.E(errs.NotExist, op, err)
+		}
+
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return datasetPreviewSettingsFromSQL(raw), nil
+}
+
+func (s *datasetPreviewStorage) UpdateDatasetPreviewSettings(ctx context.Context, datasetID uuid.UUID, enabled bool, updatedBy string) (*service.DatasetPreviewSettings, error) {
+	const op errs.Op = "datasetPreviewStorage.UpdateDatasetPreviewSettings"
+
+	raw, err := s.db.Querier.UpsertDatasetPreviewSettings(ctx, gensql.UpsertDatasetPreviewSettingsParams{
+		DatasetID: datasetID,
+		Enabled:   enabled,
+		UpdatedBy: updatedBy,
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return datasetPreviewSettingsFromSQL(raw), nil
+}
+
+func datasetPreviewSettingsFromSQL(raw gensql.DatasetPreviewSetting) *service.DatasetPreviewSettings {
+	return &service.DatasetPreviewSettings{
+		DatasetID: raw.DatasetID,
+		Enabled:   raw.Enabled,
+		UpdatedBy: raw.UpdatedBy,
+		Updated:   raw.Updated,
+	}
+}
+
+func NewDatasetPreviewStorage(db *database.Repo) *datasetPreviewStorage {
+	return &datasetPreviewStorage{
+		db: db,
+	}
+}
@@ -0,0 +1,250 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/database/gensql"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/sqlc-dev/pqtype"
+)
+
+var _ service.DatasourceStorage = &datasourceStorage{}
+
+type datasourceStorage struct {
+	db *database.Repo
+}
+
+func (s *datasourceStorage) GetDatasourceRef(ctx context.Context, datasetID uuid.UUID) (*service.DatasourceRef, error) {
+	const op errs.Op = "datasourceStorage.GetDatasourceRef"
+
+	dsType, err := s.db.Querier.GetDatasetType(ctx, datasetID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.E(errs.NotExist, op, err)
+		}
+
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	switch service.DatasourceType(dsType) {
+	case service.DatasourceTypeBigQuery:
+		bq, err := s.db.Querier.GetBigqueryDatasource(ctx, gensql.GetBigqueryDatasourceParams{
+			DatasetID:   datasetID,
+			IsReference: false,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, errs.E(errs.NotExist, op, err)
+			}
+
+			return nil, errs.E(errs.Database, op, err)
+		}
+
+		return bigQueryRefFromSQL(bq), nil
+	case service.DatasourceTypeGCS:
+		gcs, err := s.db.Querier.GetGCSDatasource(ctx, datasetID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, errs.E(errs.NotExist, op, err)
+			}
+
+			return nil, errs.E(errs.Database, op, err)
+		}
+
+		ref := gcsDatasourceFromSQL(gcs).Ref()
+
+		return &ref, nil
+	}
+
+	return nil, errs.E(errs.Internal, op, fmt.Errorf("unknown datasource type %s", dsType))
+}
+
+func (s *datasourceStorage) GetDatasourceRefs(ctx context.Context) ([]*service.DatasourceRef, error) {
+	const op errs.Op = "datasourceStorage.GetDatasourceRefs"
+
+	bqs, err := s.db.Querier.GetBigqueryDatasources(ctx)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	gcss, err := s.db.Querier.GetGCSDatasources(ctx)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	refs := make([]*service.DatasourceRef, 0, len(bqs)+len(gcss))
+
+	for _, bq := range bqs {
+		refs = append(refs, bigQueryRefFromSQL(bq))
+	}
+
+	for _, gcs := range gcss {
+		ref := gcsDatasourceFromSQL(gcs).Ref()
+		refs = append(refs, &ref)
+	}
+
+	return refs, nil
+}
+
+// GetDatasourceSchema returns the schema stored at the last sync of the
+// datasource, which is empty if it has never been synced
+func (s *datasourceStorage) GetDatasourceSchema(ctx context.Context, ref service.DatasourceRef) ([]*service.BigqueryColumn, error) {
+	const op errs.Op = "datasourceStorage.GetDatasourceSchema"
+
+	var raw pqtype.NullRawMessage
+
+	switch ref.Type {
+	case service.DatasourceTypeBigQuery:
+		bq, err := s.db.Querier.GetBigqueryDatasource(ctx, gensql.GetBigqueryDatasourceParams{
+			DatasetID:   ref.DatasetID,
+			IsReference: false,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, errs.E(errs.NotExist, op, err)
+			}
+
+			return nil, errs.E(errs.Database, op, err)
+		}
+
+		raw = bq.Schema
+	case service.DatasourceTypeGCS:
+		// Objects in a bucket have no schema
+		return nil, nil
+	default:
+		return nil, errs.E(errs.InvalidRequest, op, fmt.Errorf("unknown datasource type %s", ref.Type))
+	}
+
+	var schema []*service.BigqueryColumn
+	if raw.Valid {
+		if err := json.Unmarshal(raw.RawMessage, &schema); err != nil {
+			return nil, errs.E(errs.Internal, op, err)
+		}
+	}
+
+	return schema, nil
+}
+
+func (s *datasourceStorage) UpdateDatasourceMetadata(ctx context.Context, ref service.DatasourceRef, meta service.DatasourceMetadata) error {
+	const op errs.Op = "datasourceStorage.UpdateDatasourceMetadata"
+
+	schemaJSON, err := json.Marshal(meta.Schema)
+	if err != nil {
+		return errs.E(errs.InvalidRequest, op, err)
+	}
+
+	switch ref.Type {
+	case service.DatasourceTypeBigQuery:
+		memberTables, err := memberTablesToJSON(meta.Tables)
+		if err != nil {
+			return errs.E(errs.InvalidRequest, op, err)
+		}
+
+		err = s.db.Querier.UpdateBigqueryDatasourceSchema(ctx, gensql.UpdateBigqueryDatasourceSchemaParams{
+			Schema: pqtype.NullRawMessage{
+				RawMessage: schemaJSON,
+				Valid:      true,
+			},
+			LastModified: meta.LastModified,
+			Expires:      sql.NullTime{Time: meta.Expires, Valid: !meta.Expires.IsZero()},
+			Description:  sql.NullString{String: meta.Description, Valid: true},
+			Tables:       memberTables,
+			DatasetID:    ref.DatasetID,
+		})
+		if err != nil {
+			return errs.E(errs.Database, op, err)
+		}
+	case service.DatasourceTypeGCS:
+		objectsJSON, err := json.Marshal(meta.Objects)
+		if err != nil {
+			return errs.E(errs.InvalidRequest, op, err)
+		}
+
+		err = s.db.Querier.UpdateGCSDatasourceMetadata(ctx, gensql.UpdateGCSDatasourceMetadataParams{
+			Objects: pqtype.NullRawMessage{
+				RawMessage: objectsJSON,
+				Valid:      true,
+			},
+			LastModified: meta.LastModified,
+			Description:  meta.Description,
+			DatasetID:    ref.DatasetID,
+		})
+	default:
+		return errs.E(errs.InvalidRequest, op, fmt.Errorf("unknown datasource type %s", ref.Type))
+	}
+
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *datasourceStorage) UpdateDatasourceMissing(ctx context.Context, ref service.DatasourceRef) error {
+	const op errs.Op = "datasourceStorage.UpdateDatasourceMissing"
+
+	var err error
+
+	switch ref.Type {
+	case service.DatasourceTypeBigQuery:
+		err = s.db.Querier.UpdateBigqueryDatasourceMissing(ctx, ref.DatasetID)
+	case service.DatasourceTypeGCS:
+		err = s.db.Querier.UpdateGCSDatasourceMissing(ctx, ref.DatasetID)
+	default:
+		return errs.E(errs.InvalidRequest, op, fmt.Errorf("unknown datasource type %s", ref.Type))
+	}
+
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func bigQueryRefFromSQL(bq gensql.DatasourceBigquery) *service.DatasourceRef {
+	return &service.DatasourceRef{
+		Type:         service.DatasourceTypeBigQuery,
+		DatasetID:    bq.DatasetID,
+		ProjectID:    bq.ProjectID,
+		Dataset:      bq.Dataset,
+		Table:        bq.TableName,
+		Scope:        service.BigQueryScope(bq.Scope),
+		TablePattern: bq.TablePattern,
+		MissingSince: nullTimeToPtr(bq.MissingSince),
+	}
+}
+
+func gcsDatasourceFromSQL(gcs gensql.DatasourceGc) *service.GCSDatasource {
+	var objects []*service.GCSObject
+	if gcs.Objects.Valid {
+		// The objects are written by us, so a decoding error leaves them
+		// empty rather than hiding the datasource.
+		_ = json.Unmarshal(gcs.Objects.RawMessage, &objects)
+	}
+
+	return &service.GCSDatasource{
+		ID:           gcs.ID,
+		DatasetID:    gcs.DatasetID,
+		ProjectID:    gcs.ProjectID,
+		Bucket:       gcs.Bucket,
+		Prefix:       gcs.Prefix,
+		Description:  gcs.Description,
+		Created:      gcs.Created,
+		LastModified: gcs.LastModified,
+		MissingSince: nullTimeToPtr(gcs.MissingSince),
+		Objects:      objects,
+	}
+}
+
+func NewDatasourceStorage(db *database.Repo) *datasourceStorage {
+	return &datasourceStorage{
+		db: db,
+	}
+}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/database/gensql"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/sqlc-dev/pqtype"
+)
+
+const columnDescriptionSourceDbt = "dbt"
+
+var _ service.DbtStorage = &dbtStorage{}
+
+type dbtStorage struct {
+	db *database.Repo
+}
+
+func (s *dbtStorage) GetDbtImportCandidates(ctx context.Context, projectIDs []string) ([]*service.DbtImportCandidate, error) {
+	const op errs.Op = "dbtStorage.GetDbtImportCandidates"
+
+	rows, err := s.db.Querier.GetDbtImportCandidates(ctx, projectIDs)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	candidates := make([]*service.DbtImportCandidate, len(rows))
+	for i, row := range rows {
+		var schema []*service.BigqueryColumn
+		if row.BqSchema.Valid {
+			if err := json.Unmarshal(row.BqSchema.RawMessage, &schema); err != nil {
+				return nil, errs.E(errs.Internal, op, err)
+			}
+		}
+
+		candidates[i] = &service.DbtImportCandidate{
+			DatasetID:     row.DsID,
+			DatasetName:   row.DsName,
+			DataproductID: row.DsDpID,
+			Group:         row.DpGroup,
+			Description:   nullStringToPtr(row.DsDescription),
+			Keywords:      row.DsKeywords,
+			ProjectID:     row.BqProject,
+			Dataset:       row.BqDataset,
+			Table:         row.BqTableName,
+			Schema:        schema,
+		}
+	}
+
+	return candidates, nil
+}
+
+func (s *dbtStorage) UpdateDatasetFromDbt(ctx context.Context, update service.DbtDatasetUpdate) error {
+	const op errs.Op = "dbtStorage.UpdateDatasetFromDbt"
+
+	schemaJSON, err := json.Marshal(update.Schema)
+	if err != nil {
+		return errs.E(errs.InvalidRequest, op, err)
+	}
+
+	if update.Keywords == nil {
+		update.Keywords = []string{}
+	}
+
+	tx, err := s.db.GetDB().Begin()
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+	defer tx.Rollback()
+
+	querier := s.db.Querier.WithTx(tx)
+
+	err = querier.UpdateDatasetDescriptionAndKeywords(ctx, gensql.UpdateDatasetDescriptionAndKeywordsParams{
+		ID:          update.DatasetID,
+		Description: ptrToNullString(update.Description),
+		Keywords:    update.Keywords,
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	for name, description := range update.ColumnDescriptions {
+		err = querier.UpsertDatasetColumnDescription(ctx, gensql.UpsertDatasetColumnDescriptionParams{
+			DatasetID:   update.DatasetID,
+			ColumnName:  name,
+			Description: description,
+			Source:      columnDescriptionSourceDbt,
+			UpdatedBy:   update.UpdatedBy,
+		})
+		if err != nil {
+			return errs.E(errs.Database, op, err)
+		}
+	}
+
+	if update.Schema != nil {
+		err = querier.UpdateBigqueryDatasourceSchemaColumns(ctx, gensql.UpdateBigqueryDatasourceSchemaColumnsParams{
+			Schema: pqtype.NullRawMessage{
+				RawMessage: schemaJSON,
+				Valid:      true,
+			},
+			DatasetID: update.DatasetID,
+		})
+		if err != nil {
+			return errs.E(errs.Database, op, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func NewDbtStorage(db *database.Repo) *dbtStorage {
+	return &dbtStorage{
+		db: db,
+	}
+}
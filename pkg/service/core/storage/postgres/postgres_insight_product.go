@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/navikt/nada-backend/pkg/database"
@@ -29,6 +30,20 @@ func (s *insightProductStorage) DeleteInsightProduct(ctx context.Context, id uui
 	return nil
 }
 
+func (s *insightProductStorage) SoftDeleteInsightProduct(ctx context.Context, id uuid.UUID, deletedBy string) error {
+	const op errs.Op = "insightProductStorage.SoftDeleteInsightProduct"
+
+	err := s.db.Querier.SoftDeleteInsightProduct(ctx, gensql.SoftDeleteInsightProductParams{
+		ID:        id,
+		DeletedBy: sql.NullString{String: deletedBy, Valid: true},
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
 func (s *insightProductStorage) CreateInsightProduct(ctx context.Context, creator string, input service.NewInsightProduct) (*service.InsightProduct, error) {
 	const op errs.Op = "insightProductStorage.CreateInsightProduct"
 
@@ -75,8 +90,16 @@ func (s *insightProductStorage) UpdateInsightProduct(ctx context.Context, id uui
 		TeamID:           uuidPtrToNullUUID(input.TeamID),
 		Type:             input.TypeArg,
 		Link:             input.Link,
+		IfMatch:          ptrToNullTime(input.IfMatch),
 	})
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) && input.IfMatch != nil {
+			// The update is conditional, so check whether the insight product is missing or has been modified
+			if _, getErr := s.db.Querier.GetInsightProduct(ctx, id); getErr == nil {
+				return nil, errs.E(errs.PreconditionFailed, op, fmt.Errorf("insight product %s has been modified", id))
+			}
+		}
+
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errs.E(errs.NotExist, op, err)
 		}
@@ -107,21 +130,32 @@ func (s *insightProductStorage) GetInsightProductWithTeamkatalogen(ctx context.C
 	return insightProductFromSQL(&raw), nil
 }
 
-func (s *insightProductStorage) GetInsightProductsByGroups(ctx context.Context, groups []string) ([]*service.InsightProduct, error) {
+func (s *insightProductStorage) GetInsightProductsByGroups(ctx context.Context, groups []string, page service.PageRequest) (*service.Page[*service.InsightProduct], error) {
 	const op errs.Op = "insightProductStorage.GetInsightProductsByGroups"
 
-	raw, err := s.db.Querier.GetInsightProductsByGroups(ctx, groups)
+	raw, err := s.db.Querier.GetInsightProductsByGroupsPage(ctx, gensql.GetInsightProductsByGroupsPageParams{
+		Sort:         page.Sort,
+		Groups:       groups,
+		AfterID:      pageAfterID(page),
+		Descending:   page.Descending(),
+		AfterName:    pageAfterName(page),
+		AfterCreated: pageAfterCreated(page),
+		Lim:          pageLimit(page),
+	})
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, nil
-		}
-
 		return nil, errs.E(errs.Database, op, err)
 	}
 
-	insightProducts := make([]*service.InsightProduct, len(raw))
-	for idx, ip := range raw {
-		insightProducts[idx] = insightProductFromSQL(&ip)
+	insightProducts, err := pageFrom(raw, page,
+		func(r gensql.GetInsightProductsByGroupsPageRow) (string, uuid.UUID) {
+			return pageKey(page, r.InsightProductWithTeamkatalogenView.Name, r.InsightProductWithTeamkatalogenView.Created), r.InsightProductWithTeamkatalogenView.ID
+		},
+		func(r gensql.GetInsightProductsByGroupsPageRow) (*service.InsightProduct, error) {
+			return insightProductFromSQL(&r.InsightProductWithTeamkatalogenView), nil
+		},
+	)
+	if err != nil {
+		return nil, errs.E(errs.Internal, op, err)
 	}
 
 	return insightProducts, nil
@@ -147,6 +181,37 @@ func (s *insightProductStorage) GetInsightProductsByTeamID(ctx context.Context,
 	return insightProducts, nil
 }
 
+func (s *insightProductStorage) GetInsightProductsByTeamIDPage(ctx context.Context, teamIDs []uuid.UUID, page service.PageRequest) (*service.Page[*service.InsightProduct], error) {
+	const op errs.Op = "insightProductStorage.GetInsightProductsByTeamIDPage"
+
+	raw, err := s.db.Querier.GetInsightProductsByProductAreaPage(ctx, gensql.GetInsightProductsByProductAreaPageParams{
+		Sort:         page.Sort,
+		TeamID:       teamIDs,
+		AfterID:      pageAfterID(page),
+		Descending:   page.Descending(),
+		AfterName:    pageAfterName(page),
+		AfterCreated: pageAfterCreated(page),
+		Lim:          pageLimit(page),
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	insightProducts, err := pageFrom(raw, page,
+		func(r gensql.GetInsightProductsByProductAreaPageRow) (string, uuid.UUID) {
+			return pageKey(page, r.InsightProductWithTeamkatalogenView.Name, r.InsightProductWithTeamkatalogenView.Created), r.InsightProductWithTeamkatalogenView.ID
+		},
+		func(r gensql.GetInsightProductsByProductAreaPageRow) (*service.InsightProduct, error) {
+			return insightProductFromSQL(&r.InsightProductWithTeamkatalogenView), nil
+		},
+	)
+	if err != nil {
+		return nil, errs.E(errs.Internal, op, err)
+	}
+
+	return insightProducts, nil
+}
+
 func (s *insightProductStorage) GetInsightProductsNumberByTeam(ctx context.Context, teamID uuid.UUID) (int64, error) {
 	const op errs.Op = "insightProductStorage.GetInsightProductsNumberByTeam"
 
@@ -176,6 +241,7 @@ func insightProductFromSQL(insightProductSQL *gensql.InsightProductWithTeamkatal
 		Group:            insightProductSQL.Group,
 		Link:             insightProductSQL.Link,
 		TeamName:         nullStringToPtr(insightProductSQL.TeamName),
+		LastModified:     &insightProductSQL.LastModified,
 		ProductAreaName:  nullStringToString(insightProductSQL.PaName),
 	}
 }
@@ -27,11 +27,12 @@ func (s *joinableViewStorage) GetJoinableViewsToBeDeletedWithRefDatasource(ctx c
 		return nil, errs.E(errs.Database, op, err)
 	}
 
-	joinableViews := make([]service.JoinableViewToBeDeletedWithRefDatasource, 0, len(rows))
+	joinableViews := make([]service.JoinableViewToBeDeletedWithRefDatasource, len(rows))
 	for i, row := range rows {
 		joinableViews[i] = service.JoinableViewToBeDeletedWithRefDatasource{
 			JoinableViewID:   row.JoinableViewID,
 			JoinableViewName: row.JoinableViewName,
+			DatasourceID:     row.DatasourceID,
 			BqProjectID:      row.BqProjectID,
 			BqDatasetID:      row.BqDatasetID,
 			BqTableID:        row.BqTableID,
@@ -49,7 +50,7 @@ func (s *joinableViewStorage) GetJoinableViewsWithReference(ctx context.Context)
 		return nil, errs.E(errs.Database, op, err)
 	}
 
-	joinableViews := make([]service.JoinableViewWithReference, 0, len(rows))
+	joinableViews := make([]service.JoinableViewWithReference, len(rows))
 	for i, row := range rows {
 		joinableViews[i] = service.JoinableViewWithReference{
 			Owner:               row.Owner,
@@ -77,6 +78,101 @@ func (s *joinableViewStorage) SetJoinableViewDeleted(ctx context.Context, id uui
 	return nil
 }
 
+func (s *joinableViewStorage) GetJoinableViewMetadata(ctx context.Context, id uuid.UUID) (*service.JoinableViewMetadata, error) {
+	const op errs.Op = "joinableViewStorage.GetJoinableViewMetadata"
+
+	jv, err := s.db.Querier.GetJoinableView(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.E(errs.NotExist, op, err)
+		}
+
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return joinableViewMetadataFromSQL(jv), nil
+}
+
+func (s *joinableViewStorage) SetJoinableViewExpires(ctx context.Context, id uuid.UUID, expires *time.Time) error {
+	const op errs.Op = "joinableViewStorage.SetJoinableViewExpires"
+
+	err := s.db.Querier.SetJoinableViewExpires(ctx, gensql.SetJoinableViewExpiresParams{
+		Expires: ptrToNullTime(expires),
+		ID:      id,
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *joinableViewStorage) SetJoinableViewDatasourceViewDeleted(ctx context.Context, joinableViewID, datasourceID uuid.UUID) error {
+	const op errs.Op = "joinableViewStorage.SetJoinableViewDatasourceViewDeleted"
+
+	err := s.db.Querier.SetJoinableViewDatasourceViewDeleted(ctx, gensql.SetJoinableViewDatasourceViewDeletedParams{
+		JoinableViewID: joinableViewID,
+		DatasourceID:   datasourceID,
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *joinableViewStorage) CreateJoinableViewShare(ctx context.Context, id uuid.UUID, group string) error {
+	const op errs.Op = "joinableViewStorage.CreateJoinableViewShare"
+
+	err := s.db.Querier.CreateJoinableViewShare(ctx, gensql.CreateJoinableViewShareParams{
+		JoinableViewID: id,
+		GroupEmail:     group,
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *joinableViewStorage) GetJoinableViewShares(ctx context.Context, id uuid.UUID) ([]string, error) {
+	const op errs.Op = "joinableViewStorage.GetJoinableViewShares"
+
+	groups, err := s.db.Querier.GetJoinableViewShares(ctx, id)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return groups, nil
+}
+
+func (s *joinableViewStorage) GetJoinableViewsToBeCleanedUp(ctx context.Context) ([]*service.JoinableViewMetadata, error) {
+	const op errs.Op = "joinableViewStorage.GetJoinableViewsToBeCleanedUp"
+
+	rows, err := s.db.Querier.GetJoinableViewsToBeCleanedUp(ctx)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	views := make([]*service.JoinableViewMetadata, len(rows))
+	for i, row := range rows {
+		views[i] = joinableViewMetadataFromSQL(row)
+	}
+
+	return views, nil
+}
+
+func joinableViewMetadataFromSQL(jv gensql.JoinableView) *service.JoinableViewMetadata {
+	return &service.JoinableViewMetadata{
+		ID:      jv.ID,
+		Name:    jv.Name,
+		Owner:   jv.Owner,
+		Created: jv.Created,
+		Expires: nullTimeToPtr(jv.Expires),
+		Deleted: nullTimeToPtr(jv.Deleted),
+	}
+}
+
 func (s *joinableViewStorage) GetJoinableViewsForOwner(ctx context.Context, user *service.User) ([]service.JoinableViewForOwner, error) {
 	const op errs.Op = "joinableViewStorage.GetJoinableViewsForOwner"
 
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/database/gensql"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+var _ service.LifecycleStorage = &lifecycleStorage{}
+
+type lifecycleStorage struct {
+	db *database.Repo
+}
+
+func (s *lifecycleStorage) SetDataproductLifecycle(ctx context.Context, id uuid.UUID, lifecycle service.Lifecycle) error {
+	const op errs.Op = "lifecycleStorage.SetDataproductLifecycle"
+
+	err := s.db.Querier.SetDataproductLifecycle(ctx, gensql.SetDataproductLifecycleParams{
+		LifecycleStatus:   gensql.LifecycleStatus(lifecycle.Status),
+		ReplacedBy:        uuidPtrToNullUUID(lifecycle.ReplacedBy),
+		Sunset:            ptrToNullTime(lifecycle.Sunset),
+		DeprecationReason: ptrToNullString(lifecycle.Reason),
+		ID:                id,
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *lifecycleStorage) SetDatasetLifecycle(ctx context.Context, id uuid.UUID, lifecycle service.Lifecycle) error {
+	const op errs.Op = "lifecycleStorage.SetDatasetLifecycle"
+
+	err := s.db.Querier.SetDatasetLifecycle(ctx, gensql.SetDatasetLifecycleParams{
+		LifecycleStatus:   gensql.LifecycleStatus(lifecycle.Status),
+		ReplacedBy:        uuidPtrToNullUUID(lifecycle.ReplacedBy),
+		Sunset:            ptrToNullTime(lifecycle.Sunset),
+		DeprecationReason: ptrToNullString(lifecycle.Reason),
+		ID:                id,
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *lifecycleStorage) GetDatasetsToRetire(ctx context.Context) ([]uuid.UUID, error) {
+	const op errs.Op = "lifecycleStorage.GetDatasetsToRetire"
+
+	ids, err := s.db.Querier.GetDatasetsToRetire(ctx)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return ids, nil
+}
+
+func (s *lifecycleStorage) GetDataproductsToRetire(ctx context.Context) ([]uuid.UUID, error) {
+	const op errs.Op = "lifecycleStorage.GetDataproductsToRetire"
+
+	ids, err := s.db.Querier.GetDataproductsToRetire(ctx)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return ids, nil
+}
+
+func NewLifecycleStorage(db *database.Repo) *lifecycleStorage {
+	return &lifecycleStorage{
+		db: db,
+	}
+}
@@ -0,0 +1,330 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/database/gensql"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+var _ service.NotificationStorage = &notificationStorage{}
+
+type notificationStorage struct {
+	db *database.Repo
+}
+
+func (s *notificationStorage) CreateNotification(ctx context.Context, n *service.NewStoredNotification) (*service.Notification, error) {
+	const op errs.Op = "notificationStorage.CreateNotification"
+
+	digestChannel := gensql.NullNotificationChannel{}
+	if n.DigestChannel != nil {
+		digestChannel = gensql.NullNotificationChannel{
+			NotificationChannel: gensql.NotificationChannel(*n.DigestChannel),
+			Valid:               true,
+		}
+	}
+
+	raw, err := s.db.Querier.CreateNotification(ctx, gensql.CreateNotificationParams{
+		Recipient:     n.Recipient,
+		EventType:     string(n.EventType),
+		Title:         n.Title,
+		Message:       n.Message,
+		Link:          ptrToNullString(n.Link),
+		ReferenceID:   uuidPtrToNullUUID(n.ReferenceID),
+		DigestChannel: digestChannel,
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return notificationFromSQL(raw, nil), nil
+}
+
+func (s *notificationStorage) GetNotifications(ctx context.Context, email string, groups []string, unreadOnly bool, page service.PageRequest) (*service.Page[*service.Notification], error) {
+	const op errs.Op = "notificationStorage.GetNotifications"
+
+	raw, err := s.db.Querier.GetNotificationsPage(ctx, gensql.GetNotificationsPageParams{
+		Email:        email,
+		Recipients:   append([]string{email}, groups...),
+		UnreadOnly:   unreadOnly,
+		AfterID:      pageAfterID(page),
+		Descending:   page.Descending(),
+		AfterCreated: pageAfterCreated(page),
+		Lim:          pageLimit(page),
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	notifications, err := pageFrom(raw, page,
+		func(r gensql.GetNotificationsPageRow) (string, uuid.UUID) {
+			return service.CreatedKey(r.Notification.Created), r.Notification.ID
+		},
+		func(r gensql.GetNotificationsPageRow) (*service.Notification, error) {
+			return notificationFromSQL(r.Notification, nullTimeToPtr(r.ReadAt)), nil
+		},
+	)
+	if err != nil {
+		return nil, errs.E(errs.Internal, op, err)
+	}
+
+	return notifications, nil
+}
+
+func (s *notificationStorage) MarkNotificationsRead(ctx context.Context, email string, groups []string, ids []uuid.UUID) error {
+	const op errs.Op = "notificationStorage.MarkNotificationsRead"
+
+	err := s.db.Querier.MarkNotificationsRead(ctx, gensql.MarkNotificationsReadParams{
+		Email:      email,
+		Ids:        ids,
+		Recipients: append([]string{email}, groups...),
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *notificationStorage) MarkAllNotificationsRead(ctx context.Context, email string, groups []string) error {
+	const op errs.Op = "notificationStorage.MarkAllNotificationsRead"
+
+	err := s.db.Querier.MarkAllNotificationsRead(ctx, gensql.MarkAllNotificationsReadParams{
+		Email:      email,
+		Recipients: append([]string{email}, groups...),
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *notificationStorage) GetNotificationPreferences(ctx context.Context, email string) ([]*service.NotificationPreference, error) {
+	const op errs.Op = "notificationStorage.GetNotificationPreferences"
+
+	raw, err := s.db.Querier.GetNotificationPreferences(ctx, email)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	preferences := make([]*service.NotificationPreference, len(raw))
+	for i, p := range raw {
+		preferences[i] = &service.NotificationPreference{
+			EventType: service.NotificationEventType(p.EventType),
+			Channel:   service.NotificationChannel(p.Channel),
+			Digest:    p.Digest,
+		}
+	}
+
+	return preferences, nil
+}
+
+func (s *notificationStorage) UpsertNotificationPreferences(ctx context.Context, email string, preferences []*service.NotificationPreference) error {
+	const op errs.Op = "notificationStorage.UpsertNotificationPreferences"
+
+	params := gensql.UpsertNotificationPreferencesParams{
+		Email:      email,
+		EventTypes: make([]string, len(preferences)),
+		Channels:   make([]string, len(preferences)),
+		Digests:    make([]bool, len(preferences)),
+	}
+
+	for i, p := range preferences {
+		params.EventTypes[i] = string(p.EventType)
+		params.Channels[i] = string(p.Channel)
+		params.Digests[i] = p.Digest
+	}
+
+	err := s.db.Querier.UpsertNotificationPreferences(ctx, params)
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *notificationStorage) GetDigestRecipients(ctx context.Context, pendingSince time.Time) ([]*service.DigestRecipient, error) {
+	const op errs.Op = "notificationStorage.GetDigestRecipients"
+
+	raw, err := s.db.Querier.GetDigestRecipients(ctx, pendingSince)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	recipients := make([]*service.DigestRecipient, len(raw))
+	for i, r := range raw {
+		recipients[i] = &service.DigestRecipient{
+			Email:   r.Recipient,
+			Channel: service.NotificationChannel(r.DigestChannel),
+		}
+	}
+
+	return recipients, nil
+}
+
+func (s *notificationStorage) GetPendingDigestNotifications(ctx context.Context, recipient *service.DigestRecipient) ([]*service.Notification, error) {
+	const op errs.Op = "notificationStorage.GetPendingDigestNotifications"
+
+	raw, err := s.db.Querier.GetPendingDigestNotifications(ctx, gensql.GetPendingDigestNotificationsParams{
+		Recipient: recipient.Email,
+		DigestChannel: gensql.NullNotificationChannel{
+			NotificationChannel: gensql.NotificationChannel(recipient.Channel),
+			Valid:               true,
+		},
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	notifications := make([]*service.Notification, len(raw))
+	for i, n := range raw {
+		notifications[i] = notificationFromSQL(n, nil)
+	}
+
+	return notifications, nil
+}
+
+func (s *notificationStorage) MarkDigestSent(ctx context.Context, ids []uuid.UUID) error {
+	const op errs.Op = "notificationStorage.MarkDigestSent"
+
+	err := s.db.Querier.MarkNotificationDigestSent(ctx, ids)
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *notificationStorage) GetExpiringAccessToNotify(ctx context.Context, days int) ([]*service.ExpiringAccess, error) {
+	const op errs.Op = "notificationStorage.GetExpiringAccessToNotify"
+
+	raw, err := s.db.Querier.GetExpiringAccessToNotify(ctx, int32(days))
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	accesses := make([]*service.ExpiringAccess, len(raw))
+	for i, a := range raw {
+		accesses[i] = &service.ExpiringAccess{
+			AccessID:        a.AccessID,
+			Subject:         a.Subject,
+			Owner:           a.Owner,
+			Expires:         a.Expires,
+			DatasetID:       a.DatasetID,
+			DatasetName:     a.DatasetName,
+			DataproductID:   a.DataproductID,
+			DataproductName: a.DataproductName,
+		}
+	}
+
+	return accesses, nil
+}
+
+func (s *notificationStorage) MarkAccessExpiryNotified(ctx context.Context, access *service.ExpiringAccess, days int) error {
+	const op errs.Op = "notificationStorage.MarkAccessExpiryNotified"
+
+	err := s.db.Querier.MarkAccessExpiryNotified(ctx, gensql.MarkAccessExpiryNotifiedParams{
+		AccessID:   access.AccessID,
+		Expires:    access.Expires,
+		DaysBefore: int32(days),
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *notificationStorage) CreateNotificationDelivery(ctx context.Context, d *service.NewNotificationDelivery) error {
+	const op errs.Op = "notificationStorage.CreateNotificationDelivery"
+
+	err := s.db.Querier.CreateNotificationDelivery(ctx, gensql.CreateNotificationDeliveryParams{
+		Kind:        string(d.Kind),
+		Recipient:   d.Recipient,
+		Title:       d.Title,
+		Text:        d.Text,
+		ReferenceID: uuidPtrToNullUUID(d.ReferenceID),
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *notificationStorage) GetPendingNotificationDeliveries(ctx context.Context, maxAttempts, limit int) ([]*service.NotificationDelivery, error) {
+	const op errs.Op = "notificationStorage.GetPendingNotificationDeliveries"
+
+	raw, err := s.db.Querier.GetPendingNotificationDeliveries(ctx, gensql.GetPendingNotificationDeliveriesParams{
+		MaxAttempts: int32(maxAttempts),
+		Lim:         int32(limit),
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	deliveries := make([]*service.NotificationDelivery, len(raw))
+	for i, d := range raw {
+		deliveries[i] = &service.NotificationDelivery{
+			ID:          d.ID,
+			Kind:        service.NotificationDeliveryKind(d.Kind),
+			Recipient:   d.Recipient,
+			Title:       d.Title,
+			Text:        d.Text,
+			ReferenceID: nullUUIDToUUIDPtr(d.ReferenceID),
+			Attempts:    int(d.Attempts),
+		}
+	}
+
+	return deliveries, nil
+}
+
+func (s *notificationStorage) MarkNotificationDelivered(ctx context.Context, id uuid.UUID) error {
+	const op errs.Op = "notificationStorage.MarkNotificationDelivered"
+
+	err := s.db.Querier.MarkNotificationDelivered(ctx, id)
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *notificationStorage) MarkNotificationDeliveryFailed(ctx context.Context, id uuid.UUID, reason string) error {
+	const op errs.Op = "notificationStorage.MarkNotificationDeliveryFailed"
+
+	err := s.db.Querier.MarkNotificationDeliveryFailed(ctx, gensql.MarkNotificationDeliveryFailedParams{
+		LastError: sql.NullString{String: reason, Valid: true},
+		ID:        id,
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func notificationFromSQL(n gensql.Notification, read *time.Time) *service.Notification {
+	return &service.Notification{
+		ID:        n.ID,
+		Recipient: n.Recipient,
+		EventType: service.NotificationEventType(n.EventType),
+		Title:     n.Title,
+		Message:   n.Message,
+		Link:      nullStringToPtr(n.Link),
+		Created:   n.Created,
+		Read:      read,
+	}
+}
+
+func NewNotificationStorage(db *database.Repo) *notificationStorage {
+	return &notificationStorage{
+		db: db,
+	}
+}
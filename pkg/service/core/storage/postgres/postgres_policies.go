@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/database/gensql"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+var _ service.PolicyStorage = &policyStorage{}
+
+type policyStorage struct {
+	db *database.Repo
+}
+
+func (s *policyStorage) CreatePolicy(ctx context.Context, policy service.NewPolicy, createdBy string) (*service.Policy, error) {
+	const op errs.Op = "policyStorage.CreatePolicy"
+
+	datasetID := uuid.NullUUID{}
+	if policy.DatasetID != nil {
+		datasetID = uuidToNullUUID(*policy.DatasetID)
+	}
+
+	raw, err := s.db.Querier.CreateAccessPolicy(ctx, gensql.CreateAccessPolicyParams{
+		DatasetID: datasetID,
+		Name:      policy.Name,
+		Condition: policy.Condition,
+		Reason:    policy.Reason,
+		CreatedBy: createdBy,
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return policyFromSQL(raw), nil
+}
+
+func (s *policyStorage) GetPolicy(ctx context.Context, id uuid.UUID) (*service.Policy, error) {
+	const op errs.Op = "policyStorage.GetPolicy"
+
+	raw, err := s.db.Querier.GetAccessPolicy(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.E(errs.NotExist, op, err)
+		}
+
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return policyFromSQL(raw), nil
+}
+
+func (s *policyStorage) GetOrganisationPolicies(ctx context.Context) ([]*service.Policy, error) {
+	const op errs.Op = "policyStorage.GetOrganisationPolicies"
+
+	raw, err := s.db.Querier.GetOrganisationAccessPolicies(ctx)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return policiesFromSQL(raw), nil
+}
+
+func (s *policyStorage) GetPoliciesForDataset(ctx context.Context, datasetID uuid.UUID) ([]*service.Policy, error) {
+	const op errs.Op = "policyStorage.GetPoliciesForDataset"
+
+	raw, err := s.db.Querier.GetAccessPoliciesForDataset(ctx, uuidToNullUUID(datasetID))
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return policiesFromSQL(raw), nil
+}
+
+func (s *policyStorage) DeletePolicy(ctx context.Context, id uuid.UUID) error {
+	const op errs.Op = "policyStorage.DeletePolicy"
+
+	err := s.db.Querier.DeleteAccessPolicy(ctx, id)
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func policiesFromSQL(raw []gensql.AccessPolicy) []*service.Policy {
+	policies := make([]*service.Policy, len(raw))
+	for i, p := range raw {
+		policies[i] = policyFromSQL(p)
+	}
+
+	return policies
+}
+
+func policyFromSQL(p gensql.AccessPolicy) *service.Policy {
+	return &service.Policy{
+		ID:        p.ID,
+		DatasetID: nullUUIDToUUIDPtr(p.DatasetID),
+		Name:      p.Name,
+		Condition: p.Condition,
+		Reason:    p.Reason,
+		CreatedBy: p.CreatedBy,
+		Created:   p.Created,
+	}
+}
+
+func NewPolicyStorage(db *database.Repo) *policyStorage {
+	return &policyStorage{
+		db: db,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/navikt/nada-backend/pkg/database"
@@ -53,6 +54,12 @@ func (s *pollyStorage) GetPollyDocumentation(ctx context.Context, id uuid.UUID)
 		return nil, errs.E(errs.Database, op, err)
 	}
 
+	var status *service.PollyPurposeStatus
+	if pollyDoc.Status.Valid {
+		s := service.PollyPurposeStatus(pollyDoc.Status.PollyPurposeStatus)
+		status = &s
+	}
+
 	return &service.Polly{
 		ID: pollyDoc.ID,
 		QueryPolly: service.QueryPolly{
@@ -60,9 +67,194 @@ func (s *pollyStorage) GetPollyDocumentation(ctx context.Context, id uuid.UUID)
 			Name:       pollyDoc.Name,
 			URL:        pollyDoc.Url,
 		},
+		Status:  status,
+		ValidTo: nullTimeToPtr(pollyDoc.ValidTo),
 	}, nil
 }
 
+func (s *pollyStorage) GetReferencedPollyPurposes(ctx context.Context) ([]*service.QueryPolly, error) {
+	const op errs.Op = "pollyStorage.GetReferencedPollyPurposes"
+
+	raw, err := s.db.Querier.GetReferencedPollyPurposes(ctx)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	purposes := make([]*service.QueryPolly, len(raw))
+	for i, p := range raw {
+		purposes[i] = &service.QueryPolly{
+			ExternalID: p.ExternalID,
+			Name:       p.Name,
+			URL:        p.Url,
+		}
+	}
+
+	return purposes, nil
+}
+
+func (s *pollyStorage) GetPollyPurpose(ctx context.Context, externalID string) (*service.PollyPurpose, error) {
+	const op errs.Op = "pollyStorage.GetPollyPurpose"
+
+	raw, err := s.db.Querier.GetPollyPurpose(ctx, externalID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.E(errs.NotExist, op, err)
+		}
+
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return &service.PollyPurpose{
+		ExternalID: raw.ExternalID,
+		Name:       raw.Name,
+		Status:     service.PollyPurposeStatus(raw.Status),
+		ValidFrom:  nullTimeToPtr(raw.ValidFrom),
+		ValidTo:    nullTimeToPtr(raw.ValidTo),
+		Checked:    raw.LastChecked,
+	}, nil
+}
+
+func (s *pollyStorage) UpdatePollyPurpose(ctx context.Context, purpose *service.PollyPurpose) error {
+	const op errs.Op = "pollyStorage.UpdatePollyPurpose"
+
+	tx, err := s.db.GetDB().Begin()
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+	defer tx.Rollback()
+
+	q := s.db.Querier.WithTx(tx)
+
+	changed := true
+
+	current, err := q.GetPollyPurpose(ctx, purpose.ExternalID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return errs.E(errs.Database, op, err)
+	}
+
+	if err == nil {
+		changed = current.Name != purpose.Name ||
+			service.PollyPurposeStatus(current.Status) != purpose.Status ||
+			!timePtrEqual(nullTimeToPtr(current.ValidFrom), purpose.ValidFrom) ||
+			!timePtrEqual(nullTimeToPtr(current.ValidTo), purpose.ValidTo)
+	}
+
+	err = q.UpsertPollyPurpose(ctx, gensql.UpsertPollyPurposeParams{
+		ExternalID: purpose.ExternalID,
+		Name:       purpose.Name,
+		Status:     gensql.PollyPurposeStatus(purpose.Status),
+		ValidFrom:  ptrToNullTime(purpose.ValidFrom),
+		ValidTo:    ptrToNullTime(purpose.ValidTo),
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	if changed {
+		err = q.CreatePollyPurposeHistory(ctx, gensql.CreatePollyPurposeHistoryParams{
+			ExternalID: purpose.ExternalID,
+			Name:       purpose.Name,
+			Status:     gensql.PollyPurposeStatus(purpose.Status),
+			ValidFrom:  ptrToNullTime(purpose.ValidFrom),
+			ValidTo:    ptrToNullTime(purpose.ValidTo),
+		})
+		if err != nil {
+			return errs.E(errs.Database, op, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *pollyStorage) GetPollyPurposeHistory(ctx context.Context, externalID string) ([]*service.PollyPurpose, error) {
+	const op errs.Op = "pollyStorage.GetPollyPurposeHistory"
+
+	raw, err := s.db.Querier.GetPollyPurposeHistory(ctx, externalID)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	history := make([]*service.PollyPurpose, len(raw))
+	for i, h := range raw {
+		history[i] = &service.PollyPurpose{
+			ExternalID: h.ExternalID,
+			Name:       h.Name,
+			Status:     service.PollyPurposeStatus(h.Status),
+			ValidFrom:  nullTimeToPtr(h.ValidFrom),
+			ValidTo:    nullTimeToPtr(h.ValidTo),
+			Checked:    h.Recorded,
+		}
+	}
+
+	return history, nil
+}
+
+func (s *pollyStorage) ListActiveAccessForPollyPurpose(ctx context.Context, externalID string) ([]*service.AccessWithPollyPurpose, error) {
+	const op errs.Op = "pollyStorage.ListActiveAccessForPollyPurpose"
+
+	raw, err := s.db.Querier.ListActiveAccessForPollyPurpose(ctx, externalID)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	accesses := make([]*service.AccessWithPollyPurpose, len(raw))
+	for i, a := range raw {
+		accesses[i] = accessWithPollyPurposeFromSQL(gensql.ListAccessWithInvalidPollyPurposeForGroupsRow(a))
+	}
+
+	return accesses, nil
+}
+
+func (s *pollyStorage) ListAccessWithInvalidPollyPurposeForGroups(ctx context.Context, groups []string) ([]*service.AccessWithPollyPurpose, error) {
+	const op errs.Op = "pollyStorage.ListAccessWithInvalidPollyPurposeForGroups"
+
+	raw, err := s.db.Querier.ListAccessWithInvalidPollyPurposeForGroups(ctx, groups)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	accesses := make([]*service.AccessWithPollyPurpose, len(raw))
+	for i, a := range raw {
+		accesses[i] = accessWithPollyPurposeFromSQL(a)
+	}
+
+	return accesses, nil
+}
+
+func accessWithPollyPurposeFromSQL(a gensql.ListAccessWithInvalidPollyPurposeForGroupsRow) *service.AccessWithPollyPurpose {
+	return &service.AccessWithPollyPurpose{
+		AccessID:        a.AccessID,
+		Subject:         a.Subject,
+		Owner:           a.Owner,
+		Expires:         nullTimeToPtr(a.Expires),
+		DatasetID:       a.DatasetID,
+		DatasetName:     a.DatasetName,
+		DataproductID:   a.DataproductID,
+		DataproductName: a.DataproductName,
+		OwnerGroup:      a.OwnerGroup,
+		TeamContact:     nullStringToPtr(a.TeamContact),
+		Purpose: service.QueryPolly{
+			ExternalID: a.ExternalID,
+			Name:       a.PurposeName,
+			URL:        a.PurposeUrl,
+		},
+		PurposeStatus: service.PollyPurposeStatus(a.PurposeStatus),
+	}
+}
+
+func timePtrEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
+
 func NewPollyStorage(db *database.Repo) *pollyStorage {
 	return &pollyStorage{
 		db: db,
@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/database/gensql"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+var _ service.ProfilingStorage = &profilingStorage{}
+
+type profilingStorage struct {
+	db *database.Repo
+}
+
+func (s *profilingStorage) GetDatasourcesToProfile(ctx context.Context) ([]*service.DatasourceToProfile, error) {
+	const op errs.Op = "profilingStorage.GetDatasourcesToProfile"
+
+	raw, err := s.db.Querier.GetDatasourcesToProfile(ctx)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	datasources := make([]*service.DatasourceToProfile, len(raw))
+	for i, r := range raw {
+		var schema []*service.BigqueryColumn
+		if r.Schema.Valid {
+			if err := json.Unmarshal(r.Schema.RawMessage, &schema); err != nil {
+				return nil, errs.E(errs.Internal, op, fmt.Errorf("unmarshalling schema: %w", err))
+			}
+		}
+
+		piiTags := map[string]string{}
+		if r.PiiTags.Valid && len(r.PiiTags.RawMessage) > 0 {
+			if err := json.Unmarshal(r.PiiTags.RawMessage, &piiTags); err != nil {
+				return nil, errs.E(errs.Internal, op, fmt.Errorf("unmarshalling pii tags: %w", err))
+			}
+		}
+
+		pseudoColumns, err := pseudoColumnsFromJSON(r.PseudoColumns)
+		if err != nil {
+			return nil, errs.E(errs.Internal, op, fmt.Errorf("unmarshalling pseudo columns: %w", err))
+		}
+
+		datasources[i] = &service.DatasourceToProfile{
+			DatasetID:     r.DatasetID,
+			Pii:           service.PiiLevel(r.Pii),
+			ProjectID:     r.ProjectID,
+			Dataset:       r.Dataset,
+			Table:         r.TableName,
+			Schema:        schema,
+			PiiTags:       piiTags,
+			PseudoColumns: pseudoColumns,
+		}
+	}
+
+	return datasources, nil
+}
+
+func (s *profilingStorage) CreateDatasetProfile(ctx context.Context, profile *service.DatasetProfile) error {
+	const op errs.Op = "profilingStorage.CreateDatasetProfile"
+
+	columns, err := json.Marshal(profile.Columns)
+	if err != nil {
+		return errs.E(errs.Internal, op, fmt.Errorf("marshalling columns: %w", err))
+	}
+
+	err = s.db.Querier.CreateDatasetProfile(ctx, gensql.CreateDatasetProfileParams{
+		DatasetID:      profile.DatasetID,
+		ProjectID:      profile.ProjectID,
+		Dataset:        profile.Dataset,
+		TableName:      profile.Table,
+		RowCount:       profile.RowCount,
+		BytesProcessed: profile.BytesProcessed,
+		Columns:        columns,
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *profilingStorage) GetDatasetProfiles(ctx context.Context, datasetID uuid.UUID, limit int) ([]*service.DatasetProfile, error) {
+	const op errs.Op = "profilingStorage.GetDatasetProfiles"
+
+	raw, err := s.db.Querier.GetDatasetProfiles(ctx, gensql.GetDatasetProfilesParams{
+		DatasetID: datasetID,
+		Lim:       int32(limit),
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	profiles := make([]*service.DatasetProfile, len(raw))
+	for i, r := range raw {
+		profiles[i], err = datasetProfileFromSQL(r)
+		if err != nil {
+			return nil, errs.E(errs.Internal, op, err)
+		}
+	}
+
+	return profiles, nil
+}
+
+func (s *profilingStorage) GetLatestTableProfile(ctx context.Context, projectID, datasetID, tableID string) (*service.DatasetProfile, error) {
+	const op errs.Op = "profilingStorage.GetLatestTableProfile"
+
+	raw, err := s.db.Querier.GetLatestTableProfile(ctx, gensql.GetLatestTableProfileParams{
+		ProjectID: projectID,
+		Dataset:   datasetID,
+		TableName: tableID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.E(errs.NotExist, op, err)
+		}
+
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	profile, err := datasetProfileFromSQL(raw)
+	if err != nil {
+		return nil, errs.E(errs.Internal, op, err)
+	}
+
+	return profile, nil
+}
+
+func datasetProfileFromSQL(raw gensql.DatasetProfile) (*service.DatasetProfile, error) {
+	var columns []*service.ColumnProfile
+	if err := json.Unmarshal(raw.Columns, &columns); err != nil {
+		return nil, fmt.Errorf("unmarshalling columns: %w", err)
+	}
+
+	return &service.DatasetProfile{
+		ID:             raw.ID,
+		DatasetID:      raw.DatasetID,
+		ProjectID:      raw.ProjectID,
+		Dataset:        raw.Dataset,
+		Table:          raw.TableName,
+		RowCount:       raw.RowCount,
+		BytesProcessed: raw.BytesProcessed,
+		Columns:        columns,
+		Created:        raw.Created,
+	}, nil
+}
+
+func NewProfilingStorage(db *database.Repo) *profilingStorage {
+	return &profilingStorage{
+		db: db,
+	}
+}
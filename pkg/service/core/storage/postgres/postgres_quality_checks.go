@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/database/gensql"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+var _ service.QualityCheckStorage = &qualityCheckStorage{}
+
+type qualityCheckStorage struct {
+	db *database.Repo
+}
+
+func (s *qualityCheckStorage) CreateQualityCheck(ctx context.Context, datasetID uuid.UUID, input service.NewQualityCheck, createdBy string) (*service.QualityCheck, error) {
+	const op errs.Op = "qualityCheckStorage.CreateQualityCheck"
+
+	raw, err := s.db.Querier.CreateQualityCheck(ctx, gensql.CreateQualityCheckParams{
+		DatasetID:   datasetID,
+		Name:        input.Name,
+		Description: ptrToNullString(input.Description),
+		Kind:        gensql.QualityCheckKind(input.Kind),
+		ColumnName:  ptrToNullString(input.Column),
+		Condition:   ptrToNullString(input.Condition),
+		SqlQuery:    ptrToNullString(input.SQL),
+		Threshold:   ptrToNullInt64(input.Threshold),
+		CreatedBy:   createdBy,
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return qualityCheckFromSQL(raw), nil
+}
+
+func (s *qualityCheckStorage) GetQualityCheck(ctx context.Context, id uuid.UUID) (*service.QualityCheck, error) {
+	const op errs.Op = "qualityCheckStorage.GetQualityCheck"
+
+	raw, err := s.db.Querier.GetQualityCheck(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.E(errs.NotExist, op, err)
+		}
+
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return qualityCheckFromSQL(raw), nil
+}
+
+func (s *qualityCheckStorage) GetQualityChecks(ctx context.Context, datasetID uuid.UUID) ([]*service.QualityCheck, error) {
+	const op errs.Op = "qualityCheckStorage.GetQualityChecks"
+
+	raw, err := s.db.Querier.GetQualityChecks(ctx, datasetID)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	checks := make([]*service.QualityCheck, len(raw))
+	for i, r := range raw {
+		checks[i] = qualityCheckFromSQL(r.QualityCheck)
+
+		if r.RunID.Valid {
+			checks[i].LastRun = &service.QualityCheckRun{
+				ID:             r.RunID.UUID,
+				CheckID:        r.QualityCheck.ID,
+				Status:         service.QualityCheckStatus(r.RunStatus.QualityCheckStatus),
+				Measured:       nullInt64ToPtr(r.RunMeasured),
+				Error:          nullStringToPtr(r.RunError),
+				BytesProcessed: r.RunBytesProcessed.Int64,
+				Started:        r.RunStarted.Time,
+			}
+		}
+	}
+
+	return checks, nil
+}
+
+func (s *qualityCheckStorage) GetQualityChecksToRun(ctx context.Context, id *uuid.UUID) ([]*service.QualityCheckToRun, error) {
+	const op errs.Op = "qualityCheckStorage.GetQualityChecksToRun"
+
+	raw, err := s.db.Querier.GetQualityChecksToRun(ctx, uuidPtrToNullUUID(id))
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	checks := make([]*service.QualityCheckToRun, len(raw))
+	for i, r := range raw {
+		checks[i] = &service.QualityCheckToRun{
+			Check:           qualityCheckFromSQL(r.QualityCheck),
+			DataproductID:   r.DataproductID,
+			DataproductName: r.DataproductName,
+			DatasetName:     r.DatasetName,
+			OwnerGroup:      r.OwnerGroup,
+			ProjectID:       r.ProjectID,
+			Dataset:         r.Dataset,
+			Table:           r.TableName,
+		}
+
+		if r.PreviousStatus.Valid {
+			status := service.QualityCheckStatus(r.PreviousStatus.QualityCheckStatus)
+			checks[i].PreviousStatus = &status
+		}
+	}
+
+	return checks, nil
+}
+
+func (s *qualityCheckStorage) DeleteQualityCheck(ctx context.Context, id uuid.UUID) error {
+	const op errs.Op = "qualityCheckStorage.DeleteQualityCheck"
+
+	err := s.db.Querier.DeleteQualityCheck(ctx, id)
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *qualityCheckStorage) CreateQualityCheckRun(ctx context.Context, run *service.NewQualityCheckRun) (*service.QualityCheckRun, error) {
+	const op errs.Op = "qualityCheckStorage.CreateQualityCheckRun"
+
+	raw, err := s.db.Querier.CreateQualityCheckRun(ctx, gensql.CreateQualityCheckRunParams{
+		CheckID:        run.CheckID,
+		Status:         gensql.QualityCheckStatus(run.Status),
+		Measured:       ptrToNullInt64(run.Measured),
+		Error:          ptrToNullString(run.Error),
+		BytesProcessed: run.BytesProcessed,
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return qualityCheckRunFromSQL(raw), nil
+}
+
+func (s *qualityCheckStorage) GetQualityCheckRuns(ctx context.Context, checkID uuid.UUID, limit int) ([]*service.QualityCheckRun, error) {
+	const op errs.Op = "qualityCheckStorage.GetQualityCheckRuns"
+
+	raw, err := s.db.Querier.GetQualityCheckRuns(ctx, gensql.GetQualityCheckRunsParams{
+		CheckID: checkID,
+		Lim:     int32(limit),
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	runs := make([]*service.QualityCheckRun, len(raw))
+	for i, r := range raw {
+		runs[i] = qualityCheckRunFromSQL(r)
+	}
+
+	return runs, nil
+}
+
+func qualityCheckFromSQL(raw gensql.QualityCheck) *service.QualityCheck {
+	return &service.QualityCheck{
+		ID:          raw.ID,
+		DatasetID:   raw.DatasetID,
+		Name:        raw.Name,
+		Description: nullStringToPtr(raw.Description),
+		Kind:        service.QualityCheckKind(raw.Kind),
+		Column:      nullStringToPtr(raw.ColumnName),
+		Condition:   nullStringToPtr(raw.Condition),
+		SQL:         nullStringToPtr(raw.SqlQuery),
+		Threshold:   nullInt64ToPtr(raw.Threshold),
+		CreatedBy:   raw.CreatedBy,
+		Created:     raw.Created,
+	}
+}
+
+func qualityCheckRunFromSQL(raw gensql.QualityCheckRun) *service.QualityCheckRun {
+	return &service.QualityCheckRun{
+		ID:             raw.ID,
+		CheckID:        raw.CheckID,
+		Status:         service.QualityCheckStatus(raw.Status),
+		Measured:       nullInt64ToPtr(raw.Measured),
+		Error:          nullStringToPtr(raw.Error),
+		BytesProcessed: raw.BytesProcessed,
+		Started:        raw.Started,
+	}
+}
+
+func NewQualityCheckStorage(db *database.Repo) *qualityCheckStorage {
+	return &qualityCheckStorage{
+		db: db,
+	}
+}
@@ -0,0 +1,266 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/database/gensql"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+var _ service.RecertificationStorage = &recertificationStorage{}
+
+type recertificationStorage struct {
+	db *database.Repo
+}
+
+func (s *recertificationStorage) CreateRecertificationCampaign(ctx context.Context, input service.NewRecertificationCampaign, accesses []*service.Access, createdBy string) (*service.RecertificationCampaign, error) {
+	const op errs.Op = "recertificationStorage.CreateRecertificationCampaign"
+
+	tx, err := s.db.GetDB().Begin()
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+	defer tx.Rollback()
+
+	q := s.db.Querier.WithTx(tx)
+
+	raw, err := q.CreateRecertificationCampaign(ctx, gensql.CreateRecertificationCampaignParams{
+		Name:              input.Name,
+		Deadline:          input.Deadline,
+		RevokeUnconfirmed: input.RevokeUnconfirmed,
+		CreatedBy:         createdBy,
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	for _, a := range accesses {
+		err = q.CreateRecertificationReview(ctx, gensql.CreateRecertificationReviewParams{
+			CampaignID: raw.ID,
+			DatasetID:  a.DatasetID,
+			AccessID:   a.ID,
+			Subject:    a.Subject,
+			Owner:      a.Owner,
+			Granter:    a.Granter,
+			Expires:    ptrToNullTime(a.Expires),
+		})
+		if err != nil {
+			return nil, errs.E(errs.Database, op, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return recertificationCampaignFromSQL(raw), nil
+}
+
+func (s *recertificationStorage) GetRecertificationCampaign(ctx context.Context, id uuid.UUID) (*service.RecertificationCampaign, error) {
+	const op errs.Op = "recertificationStorage.GetRecertificationCampaign"
+
+	raw, err := s.db.Querier.GetRecertificationCampaign(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.E(errs.NotExist, op, err)
+		}
+
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return recertificationCampaignFromSQL(raw), nil
+}
+
+func (s *recertificationStorage) GetRecertificationCampaigns(ctx context.Context) ([]*service.RecertificationCampaign, error) {
+	const op errs.Op = "recertificationStorage.GetRecertificationCampaigns"
+
+	raw, err := s.db.Querier.GetRecertificationCampaigns(ctx)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return recertificationCampaignsFromSQL(raw), nil
+}
+
+func (s *recertificationStorage) GetRecertificationCampaignsForGroups(ctx context.Context, groups []string, createdBy string) ([]*service.RecertificationCampaign, error) {
+	const op errs.Op = "recertificationStorage.GetRecertificationCampaignsForGroups"
+
+	raw, err := s.db.Querier.GetRecertificationCampaignsForGroups(ctx, gensql.GetRecertificationCampaignsForGroupsParams{
+		CreatedBy: createdBy,
+		Groups:    groups,
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return recertificationCampaignsFromSQL(raw), nil
+}
+
+func (s *recertificationStorage) GetOpenRecertificationCampaigns(ctx context.Context) ([]*service.RecertificationCampaign, error) {
+	const op errs.Op = "recertificationStorage.GetOpenRecertificationCampaigns"
+
+	raw, err := s.db.Querier.GetOpenRecertificationCampaigns(ctx)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return recertificationCampaignsFromSQL(raw), nil
+}
+
+func (s *recertificationStorage) GetRecertificationReviews(ctx context.Context, campaignID uuid.UUID) ([]*service.RecertificationReview, error) {
+	const op errs.Op = "recertificationStorage.GetRecertificationReviews"
+
+	raw, err := s.db.Querier.GetRecertificationReviews(ctx, campaignID)
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	reviews := make([]*service.RecertificationReview, len(raw))
+	for i, r := range raw {
+		reviews[i] = recertificationReviewFromSQL(r)
+	}
+
+	return reviews, nil
+}
+
+func (s *recertificationStorage) GetRecertificationReview(ctx context.Context, id uuid.UUID) (*service.RecertificationReview, error) {
+	const op errs.Op = "recertificationStorage.GetRecertificationReview"
+
+	raw, err := s.db.Querier.GetRecertificationReview(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.E(errs.NotExist, op, err)
+		}
+
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	return recertificationReviewFromSQL(gensql.GetRecertificationReviewsRow(raw)), nil
+}
+
+func (s *recertificationStorage) ReviewRecertification(ctx context.Context, review *service.RecertificationReview, decision service.RecertificationDecision, newExpires *time.Time, reviewedBy *string) error {
+	const op errs.Op = "recertificationStorage.ReviewRecertification"
+
+	tx, err := s.db.GetDB().Begin()
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+	defer tx.Rollback()
+
+	q := s.db.Querier.WithTx(tx)
+
+	err = q.ReviewRecertification(ctx, gensql.ReviewRecertificationParams{
+		Decision: gensql.NullRecertificationDecision{
+			RecertificationDecision: gensql.RecertificationDecision(decision),
+			Valid:                   true,
+		},
+		NewExpires: ptrToNullTime(newExpires),
+		ReviewedBy: ptrToNullString(reviewedBy),
+		ID:         review.ID,
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	if decision == service.RecertificationDecisionShortened {
+		err = q.UpdateAccessExpires(ctx, gensql.UpdateAccessExpiresParams{
+			Expires: ptrToNullTime(newExpires),
+			ID:      review.AccessID,
+		})
+		if err != nil {
+			return errs.E(errs.Database, op, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *recertificationStorage) SetRecertificationCampaignReminded(ctx context.Context, id uuid.UUID) error {
+	const op errs.Op = "recertificationStorage.SetRecertificationCampaignReminded"
+
+	err := s.db.Querier.SetRecertificationCampaignReminded(ctx, id)
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *recertificationStorage) CompleteRecertificationCampaign(ctx context.Context, id uuid.UUID) error {
+	const op errs.Op = "recertificationStorage.CompleteRecertificationCampaign"
+
+	err := s.db.Querier.CompleteRecertificationCampaign(ctx, id)
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func recertificationCampaignsFromSQL(raw []gensql.RecertificationCampaign) []*service.RecertificationCampaign {
+	campaigns := make([]*service.RecertificationCampaign, len(raw))
+	for i, c := range raw {
+		campaigns[i] = recertificationCampaignFromSQL(c)
+	}
+
+	return campaigns
+}
+
+func recertificationCampaignFromSQL(c gensql.RecertificationCampaign) *service.RecertificationCampaign {
+	return &service.RecertificationCampaign{
+		ID:                c.ID,
+		Name:              c.Name,
+		Deadline:          c.Deadline,
+		RevokeUnconfirmed: c.RevokeUnconfirmed,
+		CreatedBy:         c.CreatedBy,
+		Created:           c.Created,
+		LastReminded:      nullTimeToPtr(c.LastReminded),
+		Completed:         nullTimeToPtr(c.Completed),
+	}
+}
+
+func recertificationReviewFromSQL(r gensql.GetRecertificationReviewsRow) *service.RecertificationReview {
+	var decision *service.RecertificationDecision
+	if r.Decision.Valid {
+		d := service.RecertificationDecision(r.Decision.RecertificationDecision)
+		decision = &d
+	}
+
+	return &service.RecertificationReview{
+		ID:              r.ID,
+		CampaignID:      r.CampaignID,
+		DatasetID:       r.DatasetID,
+		DatasetName:     r.DatasetName,
+		DataproductID:   r.DataproductID,
+		DataproductName: r.DataproductName,
+		OwnerGroup:      r.OwnerGroup,
+		TeamContact:     nullStringToPtr(r.TeamContact),
+		AccessID:        r.AccessID,
+		Subject:         r.Subject,
+		Owner:           r.Owner,
+		Granter:         r.Granter,
+		Expires:         nullTimeToPtr(r.Expires),
+		Decision:        decision,
+		NewExpires:      nullTimeToPtr(r.NewExpires),
+		ReviewedBy:      nullStringToPtr(r.ReviewedBy),
+		Reviewed:        nullTimeToPtr(r.Reviewed),
+	}
+}
+
+func NewRecertificationStorage(db *database.Repo) *recertificationStorage {
+	return &recertificationStorage{
+		db: db,
+	}
+}
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/database/gensql"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+var _ service.RecycleBinStorage = &recycleBinStorage{}
+
+type recycleBinStorage struct {
+	db *database.Repo
+}
+
+func (s *recycleBinStorage) GetDeletedItems(ctx context.Context, filter service.DeletedItemsFilter) ([]*service.DeletedItem, error) {
+	const op errs.Op = "recycleBinStorage.GetDeletedItems"
+
+	rows, err := s.db.Querier.GetDeletedItems(ctx, gensql.GetDeletedItemsParams{
+		Groups:        filter.Groups,
+		ID:            uuidPtrToNullUUID(filter.ID),
+		DeletedBefore: ptrToNullTime(filter.DeletedBefore),
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	items := make([]*service.DeletedItem, len(rows))
+	for i, row := range rows {
+		items[i] = &service.DeletedItem{
+			ID:         row.ID,
+			Name:       row.Name,
+			Type:       service.DeletedItemType(row.ItemType),
+			Group:      row.Group,
+			Deleted:    row.Deleted,
+			DeletedBy:  row.DeletedBy,
+			PurgeAfter: row.Deleted.Add(service.RecycleBinRetention),
+		}
+	}
+
+	return items, nil
+}
+
+func (s *recycleBinStorage) RestoreDataproduct(ctx context.Context, id uuid.UUID) error {
+	const op errs.Op = "recycleBinStorage.RestoreDataproduct"
+
+	err := s.db.Querier.RestoreDataproduct(ctx, id)
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *recycleBinStorage) RestoreStory(ctx context.Context, id uuid.UUID) error {
+	const op errs.Op = "recycleBinStorage.RestoreStory"
+
+	err := s.db.Querier.RestoreStory(ctx, id)
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func (s *recycleBinStorage) RestoreInsightProduct(ctx context.Context, id uuid.UUID) error {
+	const op errs.Op = "recycleBinStorage.RestoreInsightProduct"
+
+	err := s.db.Querier.RestoreInsightProduct(ctx, id)
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
+func NewRecycleBinStorage(db *database.Repo) *recycleBinStorage {
+	return &recycleBinStorage{
+		db: db,
+	}
+}
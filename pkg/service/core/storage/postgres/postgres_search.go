@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"strings"
 
 	"github.com/navikt/nada-backend/pkg/database"
 	"github.com/navikt/nada-backend/pkg/database/gensql"
@@ -19,14 +20,18 @@ func (s *searchStorage) Search(ctx context.Context, query *service.SearchOptions
 	const op errs.Op = "searchStorage.Search"
 
 	res, err := s.db.Querier.Search(ctx, gensql.SearchParams{
-		Query:   query.Text,
-		Keyword: query.Keywords,
-		Grp:     query.Groups,
-		TeamID:  query.TeamIDs,
-		Service: query.Services,
-		Types:   query.Types,
-		Lim:     int32(ptrToIntDefault(query.Limit, 24)),
-		Offs:    int32(ptrToIntDefault(query.Offset, 0)),
+		Query:         query.Text,
+		Fuzzy:         query.Fuzzy,
+		Keyword:       query.Keywords,
+		Grp:           query.Groups,
+		TeamID:        query.TeamIDs,
+		ProductAreaID: query.ProductAreaIDs,
+		Pii:           query.PiiLevels,
+		Service:       query.Services,
+		QualityStatus: query.QualityStatuses,
+		Types:         query.Types,
+		Lim:           int32(ptrToIntDefault(query.Limit, 24)),
+		Offs:          int32(ptrToIntDefault(query.Offset, 0)),
 	})
 	if err != nil {
 		return nil, errs.E(errs.Database, op, err)
@@ -45,6 +50,136 @@ func (s *searchStorage) Search(ctx context.Context, query *service.SearchOptions
 	return results, nil
 }
 
+func (s *searchStorage) SearchCount(ctx context.Context, query *service.SearchOptions) (int, error) {
+	const op errs.Op = "searchStorage.SearchCount"
+
+	total, err := s.db.Querier.SearchCount(ctx, gensql.SearchCountParams{
+		Query:         query.Text,
+		Fuzzy:         query.Fuzzy,
+		Keyword:       query.Keywords,
+		Grp:           query.Groups,
+		TeamID:        query.TeamIDs,
+		ProductAreaID: query.ProductAreaIDs,
+		Pii:           query.PiiLevels,
+		Service:       query.Services,
+		QualityStatus: query.QualityStatuses,
+		Types:         query.Types,
+	})
+	if err != nil {
+		return 0, errs.E(errs.Database, op, err)
+	}
+
+	return int(total), nil
+}
+
+func (s *searchStorage) SearchFacets(ctx context.Context, query *service.SearchOptions) (*service.SearchFacets, error) {
+	const op errs.Op = "searchStorage.SearchFacets"
+
+	rows, err := s.db.Querier.SearchFacets(ctx, gensql.SearchFacetsParams{
+		Query:         query.Text,
+		Fuzzy:         query.Fuzzy,
+		Keyword:       query.Keywords,
+		Grp:           query.Groups,
+		TeamID:        query.TeamIDs,
+		ProductAreaID: query.ProductAreaIDs,
+		Pii:           query.PiiLevels,
+		Service:       query.Services,
+		QualityStatus: query.QualityStatuses,
+		Types:         query.Types,
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	facets := &service.SearchFacets{
+		Keywords:        []*service.SearchFacetValue{},
+		Teams:           []*service.SearchFacetValue{},
+		ProductAreas:    []*service.SearchFacetValue{},
+		PiiLevels:       []*service.SearchFacetValue{},
+		Types:           []*service.SearchFacetValue{},
+		Services:        []*service.SearchFacetValue{},
+		QualityStatuses: []*service.SearchFacetValue{},
+	}
+
+	for _, r := range rows {
+		value := &service.SearchFacetValue{
+			Value: r.Value,
+			Label: r.Label,
+			Count: int(r.Count),
+		}
+
+		switch r.Facet {
+		case "keywords":
+			facets.Keywords = append(facets.Keywords, value)
+		case "teams":
+			facets.Teams = append(facets.Teams, value)
+		case "productAreas":
+			facets.ProductAreas = append(facets.ProductAreas, value)
+		case "piiLevels":
+			facets.PiiLevels = append(facets.PiiLevels, value)
+		case "types":
+			facets.Types = append(facets.Types, value)
+		case "services":
+			facets.Services = append(facets.Services, value)
+		case "qualityStatuses":
+			facets.QualityStatuses = append(facets.QualityStatuses, value)
+		}
+	}
+
+	return facets, nil
+}
+
+func (s *searchStorage) SearchSuggestNames(ctx context.Context, prefix string, limit int) ([]*service.SearchSuggestion, error) {
+	const op errs.Op = "searchStorage.SearchSuggestNames"
+
+	rows, err := s.db.Querier.SearchSuggestNames(ctx, gensql.SearchSuggestNamesParams{
+		Prefix: escapeLikePattern(prefix),
+		Term:   prefix,
+		Lim:    int32(limit),
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	suggestions := make([]*service.SearchSuggestion, len(rows))
+	for i, r := range rows {
+		suggestions[i] = &service.SearchSuggestion{
+			ID:   r.ID,
+			Name: r.Name,
+			Type: r.ElementType,
+		}
+	}
+
+	return suggestions, nil
+}
+
+func (s *searchStorage) SearchSuggestKeywords(ctx context.Context, prefix string, limit int) ([]service.KeywordItem, error) {
+	const op errs.Op = "searchStorage.SearchSuggestKeywords"
+
+	rows, err := s.db.Querier.SearchSuggestKeywords(ctx, gensql.SearchSuggestKeywordsParams{
+		Prefix: escapeLikePattern(prefix),
+		Lim:    int32(limit),
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	keywords := make([]service.KeywordItem, len(rows))
+	for i, r := range rows {
+		keywords[i] = service.KeywordItem{
+			Keyword: r.Keyword,
+			Count:   int(r.Count),
+		}
+	}
+
+	return keywords, nil
+}
+
+// escapeLikePattern escapes the characters that have a special meaning in a LIKE pattern
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
 func NewSearchStorage(db *database.Repo) *searchStorage {
 	return &searchStorage{
 		db: db,
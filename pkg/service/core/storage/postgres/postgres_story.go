@@ -71,6 +71,37 @@ func (s *storyStorage) GetStoriesByTeamID(ctx context.Context, teamIDs []uuid.UU
 	return stories, nil
 }
 
+func (s *storyStorage) GetStoriesByTeamIDPage(ctx context.Context, teamIDs []uuid.UUID, page service.PageRequest) (*service.Page[*service.Story], error) {
+	const op errs.Op = "storyStorage.GetStoriesByTeamIDPage"
+
+	raw, err := s.db.Querier.GetStoriesByProductAreaPage(ctx, gensql.GetStoriesByProductAreaPageParams{
+		Sort:         page.Sort,
+		TeamID:       teamIDs,
+		AfterID:      pageAfterID(page),
+		Descending:   page.Descending(),
+		AfterName:    pageAfterName(page),
+		AfterCreated: pageAfterCreated(page),
+		Lim:          pageLimit(page),
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, op, err)
+	}
+
+	stories, err := pageFrom(raw, page,
+		func(r gensql.GetStoriesByProductAreaPageRow) (string, uuid.UUID) {
+			return pageKey(page, r.StoryWithTeamkatalogenView.Name, r.StoryWithTeamkatalogenView.Created), r.StoryWithTeamkatalogenView.ID
+		},
+		func(r gensql.GetStoriesByProductAreaPageRow) (*service.Story, error) {
+			return From(StoryWithTeamkatalogenView(r.StoryWithTeamkatalogenView))
+		},
+	)
+	if err != nil {
+		return nil, errs.E(errs.Internal, op, err)
+	}
+
+	return stories, nil
+}
+
 func (s *storyStorage) GetStoriesNumberByTeam(ctx context.Context, teamID uuid.UUID) (int64, error) {
 	const op errs.Op = "storyStorage.GetStoriesNumberByTeam"
 
@@ -97,8 +128,16 @@ func (s *storyStorage) UpdateStory(ctx context.Context, id uuid.UUID, input serv
 		TeamkatalogenUrl: ptrToNullString(input.TeamkatalogenURL),
 		TeamID:           uuidPtrToNullUUID(input.TeamID),
 		OwnerGroup:       input.Group,
+		IfMatch:          ptrToNullTime(input.IfMatch),
 	})
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) && input.IfMatch != nil {
+			// The update is conditional, so check whether the story is missing or has been modified
+			if _, getErr := s.db.Querier.GetStory(ctx, id); getErr == nil {
+				return nil, errs.E(errs.PreconditionFailed, op, fmt.Errorf("story %s has been modified", id))
+			}
+		}
+
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errs.E(errs.NotExist, op, err)
 		}
@@ -125,6 +164,20 @@ func (s *storyStorage) DeleteStory(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+func (s *storyStorage) SoftDeleteStory(ctx context.Context, id uuid.UUID, deletedBy string) error {
+	const op errs.Op = "storyStorage.SoftDeleteStory"
+
+	err := s.db.Querier.SoftDeleteStory(ctx, gensql.SoftDeleteStoryParams{
+		ID:        id,
+		DeletedBy: sql.NullString{String: deletedBy, Valid: true},
+	})
+	if err != nil {
+		return errs.E(errs.Database, op, err)
+	}
+
+	return nil
+}
+
 func (s *storyStorage) CreateStory(ctx context.Context, creator string, newStory *service.NewStory) (*service.Story, error) {
 	const op errs.Op = "storyStorage.CreateStory"
 
@@ -196,15 +249,30 @@ func (s *storyStorage) GetStoriesWithTeamkatalogenByIDs(ctx context.Context, ids
 	return stories, nil
 }
 
-func (s *storyStorage) GetStoriesWithTeamkatalogenByGroups(ctx context.Context, groups []string) ([]*service.Story, error) {
+func (s *storyStorage) GetStoriesWithTeamkatalogenByGroups(ctx context.Context, groups []string, page service.PageRequest) (*service.Page[*service.Story], error) {
 	const op errs.Op = "storyStorage.GetStoriesWithTeamkatalogenByGroups"
 
-	raw, err := s.db.Querier.GetStoriesWithTeamkatalogenByGroups(ctx, groups)
+	raw, err := s.db.Querier.GetStoriesWithTeamkatalogenByGroupsPage(ctx, gensql.GetStoriesWithTeamkatalogenByGroupsPageParams{
+		Sort:         page.Sort,
+		Groups:       groups,
+		AfterID:      pageAfterID(page),
+		Descending:   page.Descending(),
+		AfterName:    pageAfterName(page),
+		AfterCreated: pageAfterCreated(page),
+		Lim:          pageLimit(page),
+	})
 	if err != nil {
 		return nil, errs.E(errs.Database, op, err)
 	}
 
-	stories, err := From(StoryWithTeamkatalogenViewList(raw))
+	stories, err := pageFrom(raw, page,
+		func(r gensql.GetStoriesWithTeamkatalogenByGroupsPageRow) (string, uuid.UUID) {
+			return pageKey(page, r.StoryWithTeamkatalogenView.Name, r.StoryWithTeamkatalogenView.Created), r.StoryWithTeamkatalogenView.ID
+		},
+		func(r gensql.GetStoriesWithTeamkatalogenByGroupsPageRow) (*service.Story, error) {
+			return From(StoryWithTeamkatalogenView(r.StoryWithTeamkatalogenView))
+		},
+	)
 	if err != nil {
 		return nil, errs.E(errs.Internal, op, err)
 	}
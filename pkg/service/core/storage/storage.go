@@ -9,20 +9,34 @@ import (
 )
 
 type Stores struct {
-	AccessStorage            service.AccessStorage
-	BigQueryStorage          service.BigQueryStorage
-	DataProductsStorage      service.DataProductsStorage
-	InsightProductStorage    service.InsightProductStorage
-	JoinableViewsStorage     service.JoinableViewsStorage
-	KeyWordStorage           service.KeywordsStorage
-	MetaBaseStorage          service.MetabaseStorage
-	PollyStorage             service.PollyStorage
-	ProductAreaStorage       service.ProductAreaStorage
-	SearchStorage            service.SearchStorage
-	StoryStorage             service.StoryStorage
-	ThirdPartyMappingStorage service.ThirdPartyMappingStorage
-	TokenStorage             service.TokenStorage
-	NaisConsoleStorage       service.NaisConsoleStorage
+	AccessApprovalRuleStorage  service.AccessApprovalRuleStorage
+	AccessStorage              service.AccessStorage
+	BigQueryStorage            service.BigQueryStorage
+	CatalogueExportStorage     service.CatalogueExportStorage
+	ComplianceStorage          service.ComplianceStorage
+	DataProductsStorage        service.DataProductsStorage
+	DataproductTransferStorage service.DataproductTransferStorage
+	DatasetPreviewStorage      service.DatasetPreviewStorage
+	DatasourceStorage          service.DatasourceStorage
+	DbtStorage                 service.DbtStorage
+	InsightProductStorage      service.InsightProductStorage
+	JoinableViewsStorage       service.JoinableViewsStorage
+	KeyWordStorage             service.KeywordsStorage
+	LifecycleStorage           service.LifecycleStorage
+	MetaBaseStorage            service.MetabaseStorage
+	NotificationStorage        service.NotificationStorage
+	PolicyStorage              service.PolicyStorage
+	PollyStorage               service.PollyStorage
+	ProductAreaStorage         service.ProductAreaStorage
+	ProfilingStorage           service.ProfilingStorage
+	QualityCheckStorage        service.QualityCheckStorage
+	RecertificationStorage     service.RecertificationStorage
+	RecycleBinStorage          service.RecycleBinStorage
+	SearchStorage              service.SearchStorage
+	StoryStorage               service.StoryStorage
+	ThirdPartyMappingStorage   service.ThirdPartyMappingStorage
+	TokenStorage               service.TokenStorage
+	NaisConsoleStorage         service.NaisConsoleStorage
 }
 
 func NewStores(
@@ -31,19 +45,33 @@ func NewStores(
 	log zerolog.Logger,
 ) *Stores {
 	return &Stores{
-		AccessStorage:            postgres.NewAccessStorage(db.Querier, database.WithTx[postgres.AccessQueries](db)),
-		BigQueryStorage:          postgres.NewBigQueryStorage(db),
-		DataProductsStorage:      postgres.NewDataProductStorage(cfg.Metabase.DatabasesBaseURL, db, log),
-		InsightProductStorage:    postgres.NewInsightProductStorage(db),
-		JoinableViewsStorage:     postgres.NewJoinableViewStorage(db),
-		KeyWordStorage:           postgres.NewKeywordsStorage(db),
-		MetaBaseStorage:          postgres.NewMetabaseStorage(db),
-		PollyStorage:             postgres.NewPollyStorage(db),
-		ProductAreaStorage:       postgres.NewProductAreaStorage(db),
-		SearchStorage:            postgres.NewSearchStorage(db),
-		StoryStorage:             postgres.NewStoryStorage(db),
-		ThirdPartyMappingStorage: postgres.NewThirdPartyMappingStorage(db),
-		TokenStorage:             postgres.NewTokenStorage(db),
-		NaisConsoleStorage:       postgres.NewNaisConsoleStorage(db),
+		AccessApprovalRuleStorage:  postgres.NewAccessApprovalRuleStorage(db),
+		AccessStorage:              postgres.NewAccessStorage(db.Querier, database.WithTx[postgres.AccessQueries](db)),
+		BigQueryStorage:            postgres.NewBigQueryStorage(db),
+		CatalogueExportStorage:     postgres.NewCatalogueExportStorage(db),
+		ComplianceStorage:          postgres.NewComplianceStorage(db),
+		DataProductsStorage:        postgres.NewDataProductStorage(cfg.Metabase.DatabasesBaseURL, db, log),
+		DataproductTransferStorage: postgres.NewDataproductTransferStorage(db),
+		DatasetPreviewStorage:      postgres.NewDatasetPreviewStorage(db),
+		DatasourceStorage:          postgres.NewDatasourceStorage(db),
+		DbtStorage:                 postgres.NewDbtStorage(db),
+		InsightProductStorage:      postgres.NewInsightProductStorage(db),
+		JoinableViewsStorage:       postgres.NewJoinableViewStorage(db),
+		KeyWordStorage:             postgres.NewKeywordsStorage(db),
+		LifecycleStorage:           postgres.NewLifecycleStorage(db),
+		MetaBaseStorage:            postgres.NewMetabaseStorage(db),
+		NotificationStorage:        postgres.NewNotificationStorage(db),
+		PolicyStorage:              postgres.NewPolicyStorage(db),
+		PollyStorage:               postgres.NewPollyStorage(db),
+		ProductAreaStorage:         postgres.NewProductAreaStorage(db),
+		ProfilingStorage:           postgres.NewProfilingStorage(db),
+		QualityCheckStorage:        postgres.NewQualityCheckStorage(db),
+		RecertificationStorage:     postgres.NewRecertificationStorage(db),
+		RecycleBinStorage:          postgres.NewRecycleBinStorage(db),
+		SearchStorage:              postgres.NewSearchStorage(db),
+		StoryStorage:               postgres.NewStoryStorage(db),
+		ThirdPartyMappingStorage:   postgres.NewThirdPartyMappingStorage(db),
+		TokenStorage:               postgres.NewTokenStorage(db),
+		NaisConsoleStorage:         postgres.NewNaisConsoleStorage(db),
 	}
 }
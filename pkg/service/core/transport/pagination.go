@@ -0,0 +1,129 @@
+package transport
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/navikt/nada-backend/pkg/service"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+// Pager is implemented by responses that are a page of a longer list, the
+// position of the next page is encoded as an opaque cursor
+type Pager interface {
+	NextPage() *service.Cursor
+	SetNextCursor(cursor string)
+}
+
+type PageOptions struct {
+	// Sort is the fields the list can be sorted by, the first being the default
+	Sort []string
+	// Order is the default sort order
+	Order service.SortOrder
+}
+
+// ForPage creates a transport for a list endpoint, where the page is decoded from
+// the query parameters limit, cursor, sort and order
+func ForPage[Out any](target TargetFunc[service.PageRequest, Out], opts PageOptions) *Transport[service.PageRequest, Out] {
+	return &Transport[service.PageRequest, Out]{
+		decoderFn: func(r *http.Request) (service.PageRequest, error) {
+			return PageRequestFromQuery(r, opts)
+		},
+		targetFn: target,
+	}
+}
+
+func PageRequestFromQuery(r *http.Request, opts PageOptions) (service.PageRequest, error) {
+	q := r.URL.Query()
+
+	page := service.PageRequest{
+		Limit: DefaultPageLimit,
+		Sort:  q.Get("sort"),
+		Order: service.SortOrder(q.Get("order")),
+	}
+
+	if l := q.Get("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil || limit < 1 || limit > MaxPageLimit {
+			return page, fmt.Errorf("limit must be a number between 1 and %d", MaxPageLimit)
+		}
+
+		page.Limit = limit
+	}
+
+	if c := q.Get("cursor"); c != "" {
+		cursor, err := DecodeCursor(c)
+		if err != nil {
+			return page, err
+		}
+
+		if page.Sort != "" && page.Sort != cursor.Sort || page.Order != "" && page.Order != cursor.Order {
+			return page, fmt.Errorf("cursor is for a list sorted by %s %s", cursor.Sort, cursor.Order)
+		}
+
+		if cursor.Sort == service.SortByCreated {
+			if _, err := cursor.Created(); err != nil {
+				return page, fmt.Errorf("invalid cursor")
+			}
+		}
+
+		page.Sort = cursor.Sort
+		page.Order = cursor.Order
+		page.After = cursor
+	}
+
+	if page.Sort == "" && len(opts.Sort) > 0 {
+		page.Sort = opts.Sort[0]
+	}
+
+	if !slices.Contains(opts.Sort, page.Sort) {
+		return page, fmt.Errorf("sort must be one of %v", opts.Sort)
+	}
+
+	if page.Order == "" {
+		page.Order = opts.Order
+	}
+
+	if page.Order == "" {
+		page.Order = service.SortOrderAsc
+	}
+
+	if page.Order != service.SortOrderAsc && page.Order != service.SortOrderDesc {
+		return page, fmt.Errorf("order must be %s or %s", service.SortOrderAsc, service.SortOrderDesc)
+	}
+
+	return page, nil
+}
+
+func EncodeCursor(cursor *service.Cursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func DecodeCursor(s string) (*service.Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	cursor := &service.Cursor{}
+
+	err = json.Unmarshal(data, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	return cursor, nil
+}
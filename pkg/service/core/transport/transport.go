@@ -9,6 +9,7 @@ package transport
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -24,6 +25,18 @@ type Encoder interface {
 	Encode(w http.ResponseWriter) error
 }
 
+// ETagger is implemented by responses that are a version of a resource,
+// the ETag is sent to the client so it can be used in If-Match
+type ETagger interface {
+	ETag() string
+}
+
+// IfMatcher is implemented by requests that update a resource, they are
+// only applied if the resource is still the version given in If-Match
+type IfMatcher interface {
+	SetIfMatch(etag string) error
+}
+
 // DecoderFunc is a function that decodes a request into a struct
 type DecoderFunc[In any] func(r *http.Request) (In, error)
 
@@ -95,12 +108,37 @@ func (h *Transport[In, Out]) Build(logger zerolog.Logger) http.HandlerFunc {
 			}
 		}
 
+		// Updates must be based on a known version of the resource, so
+		// concurrent updates don't silently overwrite each other
+		if m, ok := any(&in).(IfMatcher); ok {
+			err = ifMatch(r, m)
+			if err != nil {
+				errs.HTTPErrorResponse(w, logger, err)
+				return
+			}
+		}
+
 		out, err := h.targetFn(r.Context(), r, in)
 		if err != nil {
 			errs.HTTPErrorResponse(w, logger, err)
 			return
 		}
 
+		// If the output is a page of a list, give the client an opaque cursor to the next page
+		if p, ok := any(out).(Pager); ok && p.NextPage() != nil {
+			cursor, err := EncodeCursor(p.NextPage())
+			if err != nil {
+				errs.HTTPErrorResponse(w, logger, errs.E(errs.Internal, err))
+				return
+			}
+
+			p.SetNextCursor(cursor)
+		}
+
+		if v, ok := any(out).(ETagger); ok && v.ETag() != "" {
+			w.Header().Set("ETag", v.ETag())
+		}
+
 		// If the output implements the Encoder interface, use it
 		if v, ok := any(out).(Encoder); ok {
 			err := v.Encode(w)
@@ -122,6 +160,25 @@ func (h *Transport[In, Out]) Build(logger zerolog.Logger) http.HandlerFunc {
 	}
 }
 
+func ifMatch(r *http.Request, m IfMatcher) error {
+	etag := r.Header.Get("If-Match")
+
+	switch etag {
+	case "":
+		return errs.E(errs.PreconditionRequired, fmt.Errorf("missing If-Match header"))
+	case "*":
+		// Any version of the resource will do
+		return nil
+	}
+
+	err := m.SetIfMatch(etag)
+	if err != nil {
+		return errs.E(errs.InvalidRequest, err)
+	}
+
+	return nil
+}
+
 type Redirect struct {
 	newURL string
 	r      *http.Request
@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/service"
 	"github.com/rs/zerolog"
 	"github.com/sebdah/goldie/v2"
 	"github.com/stretchr/testify/assert"
@@ -18,6 +20,27 @@ type TestData struct {
 	ID string `json:"id,omitempty"`
 }
 
+type TestVersionedData struct {
+	ID      string `json:"id,omitempty"`
+	ifMatch string
+}
+
+func (d *TestVersionedData) SetIfMatch(etag string) error {
+	d.ifMatch = etag
+
+	return nil
+}
+
+func (d *TestVersionedData) ETag() string {
+	return `"2"`
+}
+
+func withHeader(r *http.Request, key, value string) *http.Request {
+	r.Header.Set(key, value)
+
+	return r
+}
+
 type testSimpleHandler struct {
 	invocations int
 	Data        []byte
@@ -89,6 +112,33 @@ func (h *testSimpleHandler) Accepted(_ context.Context, _ *http.Request, _ any)
 	return &Accepted{}, nil
 }
 
+func (h *testSimpleHandler) Page(_ context.Context, _ *http.Request, page service.PageRequest) (*service.Page[*TestData], error) {
+	h.invocations++
+
+	after := ""
+	if page.After != nil {
+		after = page.After.Key
+	}
+
+	return &service.Page[*TestData]{
+		Items: []*TestData{{ID: after}},
+		Next: &service.Cursor{
+			Sort:  page.Sort,
+			Order: page.Order,
+			Key:   "b",
+			ID:    uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+		},
+	}, nil
+}
+
+func (h *testSimpleHandler) Versioned(_ context.Context, _ *http.Request, in TestVersionedData) (*TestVersionedData, error) {
+	h.invocations++
+
+	return &TestVersionedData{
+		ID: in.ifMatch,
+	}, nil
+}
+
 func TestHandlerFor(t *testing.T) {
 	simple := &testSimpleHandler{
 		Data:   []byte("test"),
@@ -104,6 +154,7 @@ func TestHandlerFor(t *testing.T) {
 		request *http.Request
 		status  int
 		count   int
+		etag    string
 	}{
 		{
 			name: "handler-for-json-response",
@@ -186,6 +237,67 @@ func TestHandlerFor(t *testing.T) {
 			status:  http.StatusAccepted,
 			count:   1,
 		},
+		{
+			name: "handler-for-page",
+			desc: "Invokes the handler with the page from the query and expects an opaque cursor to the next page",
+			routes: map[string]http.HandlerFunc{
+				"/page": ForPage(simple.Page, PageOptions{Sort: []string{"name"}}).Build(logger),
+			},
+			request: httptest.NewRequest(http.MethodGet, "/page?limit=1", nil),
+			status:  http.StatusOK,
+			count:   1,
+		},
+		{
+			name: "handler-for-page-with-cursor",
+			desc: "Invokes the handler and expects the cursor to be decoded",
+			routes: map[string]http.HandlerFunc{
+				"/page": ForPage(simple.Page, PageOptions{Sort: []string{"name"}}).Build(logger),
+			},
+			request: httptest.NewRequest(http.MethodGet, "/page?cursor=eyJzIjoibmFtZSIsIm8iOiJhc2MiLCJrIjoiYSIsImkiOiIwMDAwMDAwMC0wMDAwLTAwMDAtMDAwMC0wMDAwMDAwMDAwMDAifQ", nil),
+			status:  http.StatusOK,
+			count:   1,
+		},
+		{
+			name: "handler-for-page-invalid-created-cursor",
+			desc: "Expects the handler not to be invoked when the cursor of a list sorted by created has no time",
+			routes: map[string]http.HandlerFunc{
+				"/page": ForPage(simple.Page, PageOptions{Sort: []string{"name", "created"}}).Build(logger),
+			},
+			request: httptest.NewRequest(http.MethodGet, "/page?cursor=eyJzIjoiY3JlYXRlZCIsIm8iOiJhc2MiLCJrIjoiYSIsImkiOiIwMDAwMDAwMC0wMDAwLTAwMDAtMDAwMC0wMDAwMDAwMDAwMDAifQ", nil),
+			status:  http.StatusBadRequest,
+			count:   0,
+		},
+		{
+			name: "handler-for-page-invalid-sort",
+			desc: "Expects the handler not to be invoked when sorting by a field that is not allowed",
+			routes: map[string]http.HandlerFunc{
+				"/page": ForPage(simple.Page, PageOptions{Sort: []string{"name"}}).Build(logger),
+			},
+			request: httptest.NewRequest(http.MethodGet, "/page?sort=created", nil),
+			status:  http.StatusBadRequest,
+			count:   0,
+		},
+		{
+			name: "handler-for-if-match",
+			desc: "Invokes the handler with the version from If-Match and expects the ETag of the response",
+			routes: map[string]http.HandlerFunc{
+				"/versioned": For(simple.Versioned).RequestFromJSON().Build(logger),
+			},
+			request: withHeader(httptest.NewRequest(http.MethodPut, "/versioned", strings.NewReader(`{}`)), "If-Match", `"1"`),
+			status:  http.StatusOK,
+			count:   1,
+			etag:    `"2"`,
+		},
+		{
+			name: "handler-for-if-match-missing",
+			desc: "Expects the handler not to be invoked when an update has no If-Match header",
+			routes: map[string]http.HandlerFunc{
+				"/versioned": For(simple.Versioned).RequestFromJSON().Build(logger),
+			},
+			request: httptest.NewRequest(http.MethodPut, "/versioned", strings.NewReader(`{}`)),
+			status:  http.StatusPreconditionRequired,
+			count:   0,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -194,7 +306,7 @@ func TestHandlerFor(t *testing.T) {
 
 			r := chi.NewRouter()
 			for path, handler := range tc.routes {
-				r.Get(path, handler)
+				r.Method(tc.request.Method, path, handler)
 			}
 
 			r.ServeHTTP(rr, tc.request)
@@ -205,6 +317,7 @@ func TestHandlerFor(t *testing.T) {
 
 			assert.Equal(t, tc.status, rr.Code)
 			assert.Equal(t, tc.count, simple.Invocations())
+			assert.Equal(t, tc.etag, rr.Header().Get("ETag"))
 			defer simple.Reset()
 
 			g := goldie.New(t)
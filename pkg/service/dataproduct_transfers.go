@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/google/uuid"
+)
+
+type DataproductTransferStorage interface {
+	CreateDataproductTransfer(ctx context.Context, dp *DataproductWithDataset, input NewDataproductTransfer, requestedBy string) (*DataproductTransfer, error)
+	GetDataproductTransfer(ctx context.Context, id uuid.UUID) (*DataproductTransfer, error)
+	GetDataproductTransfersForGroups(ctx context.Context, groups []string) ([]*DataproductTransfer, error)
+	GetDataproductTransfersForDataproduct(ctx context.Context, dataproductID uuid.UUID) ([]*DataproductTransfer, error)
+	ResolveDataproductTransfer(ctx context.Context, id uuid.UUID, status DataproductTransferStatus, resolvedBy string, reason *string) (*DataproductTransfer, error)
+	AcceptDataproductTransfer(ctx context.Context, id uuid.UUID, acceptedBy string) (*DataproductTransfer, error)
+}
+
+type DataproductTransferService interface {
+	RequestDataproductTransfer(ctx context.Context, user *User, input NewDataproductTransfer) (*DataproductTransfer, error)
+	GetDataproductTransfersForUser(ctx context.Context, user *User) ([]*DataproductTransfer, error)
+	GetDataproductTransfersForDataproduct(ctx context.Context, id uuid.UUID) ([]*DataproductTransfer, error)
+	AcceptDataproductTransfer(ctx context.Context, user *User, id uuid.UUID) (*DataproductTransfer, error)
+	RejectDataproductTransfer(ctx context.Context, user *User, id uuid.UUID, input RejectDataproductTransfer) (*DataproductTransfer, error)
+	CancelDataproductTransfer(ctx context.Context, user *User, id uuid.UUID) (*DataproductTransfer, error)
+}
+
+type DataproductTransferStatus string
+
+const (
+	DataproductTransferStatusPending   DataproductTransferStatus = "pending"
+	DataproductTransferStatusAccepted  DataproductTransferStatus = "accepted"
+	DataproductTransferStatusRejected  DataproductTransferStatus = "rejected"
+	DataproductTransferStatusCancelled DataproductTransferStatus = "cancelled"
+)
+
+// DataproductTransfer records the handover of a dataproduct, and
+// optionally some of the stories of the same team, from one owner
+// group to another
+type DataproductTransfer struct {
+	ID            uuid.UUID                 `json:"id"`
+	DataproductID uuid.UUID                 `json:"dataproductID"`
+	From          DataproductOwner          `json:"from"`
+	To            DataproductOwner          `json:"to"`
+	StoryIDs      []uuid.UUID               `json:"storyIDs"`
+	Status        DataproductTransferStatus `json:"status"`
+	RequestedBy   string                    `json:"requestedBy"`
+	Created       time.Time                 `json:"created"`
+	ResolvedBy    *string                   `json:"resolvedBy"`
+	Resolved      *time.Time                `json:"resolved"`
+	Reason        *string                   `json:"reason"`
+
+	// RetainedAccess is the access the sending group still has to the datasets
+	// of an accepted transfer, which the receiving group can revoke if the
+	// sending group should no longer have access
+	RetainedAccess []*Access `json:"retainedAccess,omitempty"`
+}
+
+// NewDataproductTransfer contains the receiving team of a dataproduct
+// transfer, the receiving team must accept the transfer before it
+// takes effect
+type NewDataproductTransfer struct {
+	DataproductID    uuid.UUID   `json:"dataproductID"`
+	Group            string      `json:"group"`
+	TeamkatalogenURL *string     `json:"teamkatalogenURL"`
+	TeamContact      *string     `json:"teamContact"`
+	TeamID           *uuid.UUID  `json:"teamID"`
+	StoryIDs         []uuid.UUID `json:"storyIDs"`
+}
+
+func (t NewDataproductTransfer) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.DataproductID, validation.Required),
+		validation.Field(&t.Group, validation.Required, is.EmailFormat),
+		validation.Field(&t.TeamkatalogenURL, validation.NilOrNotEmpty, is.URL),
+	)
+}
+
+type RejectDataproductTransfer struct {
+	Reason *string `json:"reason"`
+}
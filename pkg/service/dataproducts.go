@@ -10,19 +10,26 @@ import (
 type DataProductsStorage interface {
 	CreateDataproduct(ctx context.Context, input NewDataproduct) (*DataproductMinimal, error)
 	CreateDataset(ctx context.Context, ds NewDataset, referenceDatasource *NewBigQuery, user *User) (*Dataset, error)
+	CreateGCSDataset(ctx context.Context, ds NewDataset, meta DatasourceMetadata, user *User) (*Dataset, error)
 	DeleteDataproduct(ctx context.Context, id uuid.UUID) error
+	SoftDeleteDataproduct(ctx context.Context, id uuid.UUID, deletedBy string) error
 	DeleteDataset(ctx context.Context, id uuid.UUID) error
-	GetAccessibleDatasets(ctx context.Context, userGroups []string, requester string) (owned []*AccessibleDataset, granted []*AccessibleDataset, serviceAccountGranted []*AccessibleDataset, err error)
 	GetAccessiblePseudoDatasourcesByUser(ctx context.Context, subjectsAsOwner []string, subjectsAsAccesser []string) ([]*PseudoDataset, error)
 	GetDataproduct(ctx context.Context, id uuid.UUID) (*DataproductWithDataset, error)
 	GetDataproductKeywords(ctx context.Context, dpid uuid.UUID) ([]string, error)
 	GetDataproducts(ctx context.Context, ids []uuid.UUID) ([]DataproductWithDataset, error)
+	GetDataproductsByGroupsPage(ctx context.Context, groups []string, page PageRequest) (*Page[*Dataproduct], error)
 	GetDataproductsByTeamID(ctx context.Context, teamIDs []uuid.UUID) ([]*Dataproduct, error)
+	GetDataproductsByTeamIDPage(ctx context.Context, teamIDs []uuid.UUID, page PageRequest) (*Page[*Dataproduct], error)
 	GetDataproductsNumberByTeam(ctx context.Context, teamID uuid.UUID) (int64, error)
 	GetDataproductsWithDatasetsAndAccessRequests(ctx context.Context, ids []uuid.UUID, groups []string) ([]DataproductWithDataset, []AccessRequestForGranter, error)
 	GetDataset(ctx context.Context, id uuid.UUID) (*Dataset, error)
 	GetDatasetsMinimal(ctx context.Context) ([]*DatasetMinimal, error)
+	GetDatasetsMinimalPage(ctx context.Context, page PageRequest) (*Page[*DatasetMinimal], error)
+	GetGrantedDatasets(ctx context.Context, groups []string, requester string, page PageRequest) (*Page[*AccessibleDataset], error)
+	GetOwnedDatasets(ctx context.Context, groups []string, page PageRequest) (*Page[*AccessibleDataset], error)
 	GetOwnerGroupOfDataset(ctx context.Context, datasetID uuid.UUID) (string, error)
+	GetServiceAccountGrantedDatasets(ctx context.Context, groups []string, requester string, page PageRequest) (*Page[*AccessibleDataset], error)
 	SetDatasourceDeleted(ctx context.Context, id uuid.UUID) error
 	UpdateDataproduct(ctx context.Context, id uuid.UUID, input UpdateDataproductDto) (*DataproductMinimal, error)
 	UpdateDataset(ctx context.Context, id uuid.UUID, input UpdateDatasetDto) (string, error)
@@ -38,6 +45,7 @@ type DataProductsService interface {
 	GetDataset(ctx context.Context, id uuid.UUID) (*Dataset, error)
 	GetAccessiblePseudoDatasetsForUser(ctx context.Context, user *User) ([]*PseudoDataset, error)
 	GetDatasetsMinimal(ctx context.Context) ([]*DatasetMinimal, error)
+	GetDatasetsMinimalPage(ctx context.Context, page PageRequest) (*Page[*DatasetMinimal], error)
 	GetDataproduct(ctx context.Context, id uuid.UUID) (*DataproductWithDataset, error)
 }
 
@@ -52,23 +60,31 @@ const (
 type DatasourceType string
 
 type Dataset struct {
-	ID                       uuid.UUID  `json:"id"`
-	DataproductID            uuid.UUID  `json:"dataproductID"`
-	Name                     string     `json:"name"`
-	Created                  time.Time  `json:"created"`
-	LastModified             time.Time  `json:"lastModified"`
-	Description              *string    `json:"description"`
-	Slug                     string     `json:"slug"`
-	Repo                     *string    `json:"repo"`
-	Pii                      PiiLevel   `json:"pii"`
-	Keywords                 []string   `json:"keywords"`
-	AnonymisationDescription *string    `json:"anonymisationDescription"`
-	TargetUser               *string    `json:"targetUser"`
-	Access                   []*Access  `json:"access"`
-	Mappings                 []string   `json:"mappings"`
-	Datasource               *BigQuery  `json:"datasource"`
-	MetabaseUrl              *string    `json:"metabaseUrl"`
-	MetabaseDeletedAt        *time.Time `json:"metabaseDeletedAt"`
+	ID                       uuid.UUID      `json:"id"`
+	DataproductID            uuid.UUID      `json:"dataproductID"`
+	Name                     string         `json:"name"`
+	Created                  time.Time      `json:"created"`
+	LastModified             time.Time      `json:"lastModified"`
+	Description              *string        `json:"description"`
+	Slug                     string         `json:"slug"`
+	Repo                     *string        `json:"repo"`
+	Pii                      PiiLevel       `json:"pii"`
+	Keywords                 []string       `json:"keywords"`
+	AnonymisationDescription *string        `json:"anonymisationDescription"`
+	TargetUser               *string        `json:"targetUser"`
+	Access                   []*Access      `json:"access"`
+	Mappings                 []string       `json:"mappings"`
+	Datasource               *BigQuery      `json:"datasource"`
+	GCSDatasource            *GCSDatasource `json:"gcsDatasource"`
+	MetabaseUrl              *string        `json:"metabaseUrl"`
+	MetabaseDeletedAt        *time.Time     `json:"metabaseDeletedAt"`
+	Lifecycle                *Lifecycle     `json:"lifecycle"`
+	// QualityStatus is nil if none of the quality checks have been run
+	QualityStatus *DatasetQualityStatus `json:"qualityStatus"`
+}
+
+func (d *Dataset) ETag() string {
+	return ETag(d.LastModified)
 }
 
 type AccessibleDataset struct {
@@ -80,14 +96,17 @@ type AccessibleDataset struct {
 	Subject         *string `json:"subject"`
 }
 
-type AccessibleDatasets struct {
-	// owned
-	Owned []*AccessibleDataset `json:"owned"`
-	// granted
-	Granted []*AccessibleDataset `json:"granted"`
-	// service account granted
-	ServiceAccountGranted []*AccessibleDataset `json:"serviceAccountGranted"`
-}
+// AccessibleDatasetsKind is how the user has access to the datasets
+type AccessibleDatasetsKind string
+
+const (
+	// AccessibleDatasetsOwned are the datasets in dataproducts owned by the user's groups
+	AccessibleDatasetsOwned AccessibleDatasetsKind = "owned"
+	// AccessibleDatasetsGranted are the datasets the user or the user's groups are granted access to
+	AccessibleDatasetsGranted AccessibleDatasetsKind = "granted"
+	// AccessibleDatasetsServiceAccountGranted are the datasets service accounts owned by the user are granted access to
+	AccessibleDatasetsServiceAccountGranted AccessibleDatasetsKind = "serviceAccountGranted"
+)
 
 type DatasetMinimal struct {
 	ID              uuid.UUID `json:"id"`
@@ -99,15 +118,16 @@ type DatasetMinimal struct {
 }
 
 type DatasetInDataproduct struct {
-	ID                     uuid.UUID `json:"id"`
-	DataproductID          uuid.UUID `json:"-"`
-	Name                   string    `json:"name"`
-	Created                time.Time `json:"created"`
-	LastModified           time.Time `json:"lastModified"`
-	Description            *string   `json:"description"`
-	Slug                   string    `json:"slug"`
-	Keywords               []string  `json:"keywords"`
-	DataSourceLastModified time.Time `json:"dataSourceLastModified"`
+	ID                     uuid.UUID  `json:"id"`
+	DataproductID          uuid.UUID  `json:"-"`
+	Name                   string     `json:"name"`
+	Created                time.Time  `json:"created"`
+	LastModified           time.Time  `json:"lastModified"`
+	Description            *string    `json:"description"`
+	Slug                   string     `json:"slug"`
+	Keywords               []string   `json:"keywords"`
+	DataSourceLastModified time.Time  `json:"dataSourceLastModified"`
+	Lifecycle              *Lifecycle `json:"lifecycle"`
 }
 
 type NewDataset struct {
@@ -119,25 +139,27 @@ type NewDataset struct {
 	Pii                      PiiLevel    `json:"pii"`
 	Keywords                 []string    `json:"keywords"`
 	BigQuery                 NewBigQuery `json:"bigquery"`
+	GCS                      *NewGCS     `json:"gcs"`
 	AnonymisationDescription *string     `json:"anonymisationDescription"`
 	GrantAllUsers            *bool       `json:"grantAllUsers"`
 	TargetUser               *string     `json:"targetUser"`
 	Metadata                 BigqueryMetadata
-	PseudoColumns            []string `json:"pseudoColumns"`
+	PseudoColumns            []PseudoColumn `json:"pseudoColumns"`
 }
 
 type UpdateDatasetDto struct {
-	Name                     string     `json:"name"`
-	Description              *string    `json:"description"`
-	Slug                     *string    `json:"slug"`
-	Repo                     *string    `json:"repo"`
-	Pii                      PiiLevel   `json:"pii"`
-	Keywords                 []string   `json:"keywords"`
-	DataproductID            *uuid.UUID `json:"dataproductID"`
-	AnonymisationDescription *string    `json:"anonymisationDescription"`
-	PiiTags                  *string    `json:"piiTags"`
-	TargetUser               *string    `json:"targetUser"`
-	PseudoColumns            []string   `json:"pseudoColumns"`
+	Name                     string         `json:"name"`
+	Description              *string        `json:"description"`
+	Slug                     *string        `json:"slug"`
+	Repo                     *string        `json:"repo"`
+	Pii                      PiiLevel       `json:"pii"`
+	Keywords                 []string       `json:"keywords"`
+	DataproductID            *uuid.UUID     `json:"dataproductID"`
+	AnonymisationDescription *string        `json:"anonymisationDescription"`
+	PiiTags                  *string        `json:"piiTags"`
+	TargetUser               *string        `json:"targetUser"`
+	PseudoColumns            []PseudoColumn `json:"pseudoColumns"`
+	Precondition             `json:"-"`
 }
 
 type DataproductOwner struct {
@@ -159,6 +181,11 @@ type Dataproduct struct {
 	Keywords        []string          `json:"keywords"`
 	TeamName        *string           `json:"teamName"`
 	ProductAreaName string            `json:"productAreaName"`
+	Lifecycle       *Lifecycle        `json:"lifecycle"`
+}
+
+func (d *Dataproduct) ETag() string {
+	return ETag(d.LastModified)
 }
 
 type DataproductMinimal struct {
@@ -171,6 +198,10 @@ type DataproductMinimal struct {
 	Owner        *DataproductOwner `json:"owner"`
 }
 
+func (d *DataproductMinimal) ETag() string {
+	return ETag(d.LastModified)
+}
+
 type DataproductWithDataset struct {
 	Dataproduct
 	Datasets []*DatasetInDataproduct `json:"datasets"`
@@ -218,6 +249,7 @@ type UpdateDataproductDto struct {
 	TeamContact      *string    `json:"teamContact"`
 	ProductAreaID    *uuid.UUID `json:"productAreaID"`
 	TeamID           *uuid.UUID `json:"teamID"`
+	Precondition     `json:"-"`
 }
 
 const (
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+const (
+	DatasourceTypeBigQuery DatasourceType = "bigquery"
+	DatasourceTypeGCS      DatasourceType = "gcs"
+)
+
+// DatasourceProvider is implemented once for every kind of storage a
+// dataset can be backed by, and lets the rest of the code work with
+// datasources without knowing where the data lives.
+type DatasourceProvider interface {
+	Type() DatasourceType
+	Exists(ctx context.Context, ref DatasourceRef) (bool, error)
+	Metadata(ctx context.Context, ref DatasourceRef) (*DatasourceMetadata, error)
+	// Schema returns the columns of tabular datasources, and nothing for
+	// datasources such as buckets that have no schema.
+	Schema(ctx context.Context, ref DatasourceRef) ([]*BigqueryColumn, error)
+	Grant(ctx context.Context, ref DatasourceRef, member string) error
+	Revoke(ctx context.Context, ref DatasourceRef, member string) error
+}
+
+type DatasourceProviders map[DatasourceType]DatasourceProvider
+
+// Provider returns the provider for the given datasource type, and false if
+// no provider has been registered for it.
+func (p DatasourceProviders) Provider(t DatasourceType) (DatasourceProvider, bool) {
+	provider, ok := p[t]
+
+	return provider, ok
+}
+
+func NewDatasourceProviders(providers ...DatasourceProvider) DatasourceProviders {
+	p := DatasourceProviders{}
+
+	for _, provider := range providers {
+		p[provider.Type()] = provider
+	}
+
+	return p
+}
+
+type DatasourceStorage interface {
+	GetDatasourceRef(ctx context.Context, datasetID uuid.UUID) (*DatasourceRef, error)
+	GetDatasourceRefs(ctx context.Context) ([]*DatasourceRef, error)
+	GetDatasourceSchema(ctx context.Context, ref DatasourceRef) ([]*BigqueryColumn, error)
+	UpdateDatasourceMetadata(ctx context.Context, ref DatasourceRef, meta DatasourceMetadata) error
+	UpdateDatasourceMissing(ctx context.Context, ref DatasourceRef) error
+}
+
+// DatasourceRef identifies the data behind a dataset. For BigQuery the data
+// is a table within a dataset, for GCS it is every object in a bucket below
+// an optional prefix.
+type DatasourceRef struct {
+	Type         DatasourceType `json:"type"`
+	DatasetID    uuid.UUID      `json:"datasetID"`
+	ProjectID    string         `json:"projectID"`
+	Dataset      string         `json:"dataset,omitempty"`
+	Table        string         `json:"table,omitempty"`
+	Bucket       string         `json:"bucket,omitempty"`
+	Prefix       string         `json:"prefix,omitempty"`
+	Scope        BigQueryScope  `json:"scope,omitempty"`
+	TablePattern string         `json:"tablePattern,omitempty"`
+	MissingSince *time.Time     `json:"missingSince,omitempty"`
+}
+
+type DatasourceMetadata struct {
+	Description  string                 `json:"description"`
+	Created      time.Time              `json:"created"`
+	LastModified time.Time              `json:"lastModified"`
+	Expires      time.Time              `json:"expires"`
+	TableType    BigQueryTableType      `json:"tableType,omitempty"`
+	Schema       []*BigqueryColumn      `json:"schema"`
+	Tables       []*BigQueryMemberTable `json:"tables,omitempty"`
+	Objects      []*GCSObject           `json:"objects,omitempty"`
+}
+
+// BigqueryMetadata converts the generic metadata into the shape stored
+// for BigQuery datasources.
+func (m DatasourceMetadata) BigqueryMetadata() BigqueryMetadata {
+	return BigqueryMetadata{
+		Schema:       BigquerySchema{Columns: m.Schema},
+		TableType:    m.TableType,
+		LastModified: m.LastModified,
+		Created:      m.Created,
+		Expires:      m.Expires,
+		Description:  m.Description,
+		Tables:       m.Tables,
+	}
+}
+
+func (b *BigQuery) Ref() DatasourceRef {
+	return DatasourceRef{
+		Type:         DatasourceTypeBigQuery,
+		DatasetID:    b.DatasetID,
+		ProjectID:    b.ProjectID,
+		Dataset:      b.Dataset,
+		Table:        b.Table,
+		Scope:        b.Scope,
+		TablePattern: b.TablePattern,
+		MissingSince: b.MissingSince,
+	}
+}
+
+type GCSDatasource struct {
+	ID           uuid.UUID    `json:"id"`
+	DatasetID    uuid.UUID    `json:"datasetID"`
+	ProjectID    string       `json:"projectID"`
+	Bucket       string       `json:"bucket"`
+	Prefix       string       `json:"prefix"`
+	Description  string       `json:"description"`
+	Created      time.Time    `json:"created"`
+	LastModified time.Time    `json:"lastModified"`
+	MissingSince *time.Time   `json:"missingSince"`
+	Objects      []*GCSObject `json:"objects"`
+}
+
+// GCSObject is one of the objects below the prefix of a GCS datasource, only
+// the first objects are listed for large buckets.
+type GCSObject struct {
+	Name        string `json:"name"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+}
+
+func (g *GCSDatasource) Ref() DatasourceRef {
+	return DatasourceRef{
+		Type:         DatasourceTypeGCS,
+		DatasetID:    g.DatasetID,
+		ProjectID:    g.ProjectID,
+		Bucket:       g.Bucket,
+		Prefix:       g.Prefix,
+		MissingSince: g.MissingSince,
+	}
+}
+
+type NewGCS struct {
+	ProjectID string `json:"projectID"`
+	Bucket    string `json:"bucket"`
+	Prefix    string `json:"prefix"`
+}
+
+func (g NewGCS) Validate() error {
+	return validation.ValidateStruct(&g,
+		validation.Field(&g.ProjectID, validation.Required),
+		validation.Field(&g.Bucket, validation.Required),
+	)
+}
+
+func (g NewGCS) Ref() DatasourceRef {
+	return DatasourceRef{
+		Type:      DatasourceTypeGCS,
+		ProjectID: g.ProjectID,
+		Bucket:    g.Bucket,
+		Prefix:    g.Prefix,
+	}
+}
+
+func (b NewBigQuery) Ref() DatasourceRef {
+	return DatasourceRef{
+		Type:         DatasourceTypeBigQuery,
+		ProjectID:    b.ProjectID,
+		Dataset:      b.Dataset,
+		Table:        b.Table,
+		Scope:        b.Scope,
+		TablePattern: b.TablePattern,
+	}
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+type DbtStorage interface {
+	GetDbtImportCandidates(ctx context.Context, projectIDs []string) ([]*DbtImportCandidate, error)
+	UpdateDatasetFromDbt(ctx context.Context, update DbtDatasetUpdate) error
+}
+
+type DbtService interface {
+	ImportManifest(ctx context.Context, teamEmail string, opts DbtImportOptions, manifest *DbtManifest) (*DbtImportReport, error)
+}
+
+// DbtManifest contains the parts of a dbt manifest.json that
+// are used when syncing dataset metadata
+type DbtManifest struct {
+	Metadata DbtManifestMetadata `json:"metadata"`
+	Nodes    map[string]*DbtNode `json:"nodes"`
+}
+
+func (m DbtManifest) Validate() error {
+	return validation.ValidateStruct(&m,
+		validation.Field(&m.Nodes, validation.Required),
+	)
+}
+
+// Models returns the nodes that are materialised as BigQuery tables or
+// views, sorted by their unique id
+func (m DbtManifest) Models() []*DbtNode {
+	var models []*DbtNode
+
+	for _, n := range m.Nodes {
+		if n == nil {
+			continue
+		}
+
+		switch n.ResourceType {
+		case DbtResourceTypeModel, DbtResourceTypeSnapshot, DbtResourceTypeSeed:
+		default:
+			continue
+		}
+
+		if n.Config.Materialized == "ephemeral" {
+			continue
+		}
+
+		models = append(models, n)
+	}
+
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].UniqueID < models[j].UniqueID
+	})
+
+	return models
+}
+
+type DbtManifestMetadata struct {
+	DbtSchemaVersion string `json:"dbt_schema_version"`
+	DbtVersion       string `json:"dbt_version"`
+	ProjectName      string `json:"project_name"`
+}
+
+const (
+	DbtResourceTypeModel    = "model"
+	DbtResourceTypeSnapshot = "snapshot"
+	DbtResourceTypeSeed     = "seed"
+)
+
+type DbtNode struct {
+	UniqueID     string                `json:"unique_id"`
+	ResourceType string                `json:"resource_type"`
+	Name         string                `json:"name"`
+	Alias        string                `json:"alias"`
+	Database     string                `json:"database"`
+	Schema       string                `json:"schema"`
+	Description  string                `json:"description"`
+	Tags         []string              `json:"tags"`
+	Columns      map[string]*DbtColumn `json:"columns"`
+	Config       DbtNodeConfig         `json:"config"`
+}
+
+// Table returns the BigQuery table the node is materialised as, which
+// is named after the alias when one is set
+func (n *DbtNode) Table() string {
+	if n.Alias != "" {
+		return n.Alias
+	}
+
+	return n.Name
+}
+
+type DbtNodeConfig struct {
+	Materialized string `json:"materialized"`
+}
+
+type DbtColumn struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type DbtImportOptions struct {
+	DryRun bool
+	// CreateInDataproduct creates datasets for the models that are not
+	// in the catalogue in the given dataproduct, when set
+	CreateInDataproduct *uuid.UUID
+	// Pii is the PII level of the created datasets
+	Pii PiiLevel
+}
+
+func (o DbtImportOptions) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.Pii,
+			validation.When(o.CreateInDataproduct != nil, validation.Required.Error("is required when creating datasets")),
+			validation.In(PiiLevelSensitive, PiiLevelAnonymised, PiiLevelNone),
+		),
+	)
+}
+
+// DbtImportCandidate is a dataset backed by a BigQuery table, which a
+// dbt model can be matched against
+type DbtImportCandidate struct {
+	DatasetID     uuid.UUID
+	DatasetName   string
+	DataproductID uuid.UUID
+	Group         string
+	Description   *string
+	Keywords      []string
+	ProjectID     string
+	Dataset       string
+	Table         string
+	Schema        []*BigqueryColumn
+}
+
+// Key returns the fully qualified name of the table
+func (c *DbtImportCandidate) Key() string {
+	return fmt.Sprintf("%s.%s.%s", c.ProjectID, c.Dataset, c.Table)
+}
+
+type DbtDatasetUpdate struct {
+	DatasetID   uuid.UUID
+	Description *string
+	Keywords    []string
+	// ColumnDescriptions are the descriptions from dbt by column name,
+	// which are kept when the schema is synced from BigQuery
+	ColumnDescriptions map[string]string
+	Schema             []*BigqueryColumn
+	UpdatedBy          string
+}
+
+type DbtImportAction string
+
+const (
+	DbtImportActionUpdated   DbtImportAction = "updated"
+	DbtImportActionCreated   DbtImportAction = "created"
+	DbtImportActionUnchanged DbtImportAction = "unchanged"
+	DbtImportActionUnmatched DbtImportAction = "unmatched"
+	DbtImportActionSkipped   DbtImportAction = "skipped"
+	DbtImportActionFailed    DbtImportAction = "failed"
+)
+
+type DbtImportReport struct {
+	DryRun bool              `json:"dryRun"`
+	Models []*DbtModelResult `json:"models"`
+}
+
+// StatusCode signals that the import failed for some of the models, the
+// other models are imported and the report tells which failed and why
+func (r *DbtImportReport) StatusCode() int {
+	for _, m := range r.Models {
+		if m.Action == DbtImportActionFailed {
+			return http.StatusInternalServerError
+		}
+	}
+
+	return http.StatusOK
+}
+
+// DbtModelResult describes what was, or in a dry run would have been,
+// done with a dataset matched by a model
+type DbtModelResult struct {
+	Model     string            `json:"model"`
+	ProjectID string            `json:"projectID"`
+	Dataset   string            `json:"dataset"`
+	Table     string            `json:"table"`
+	DatasetID *uuid.UUID        `json:"datasetID"`
+	Action    DbtImportAction   `json:"action"`
+	Reason    string            `json:"reason,omitempty"`
+	Changes   []*DbtFieldChange `json:"changes"`
+}
+
+type DbtFieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
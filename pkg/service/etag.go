@@ -0,0 +1,47 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ETag returns the entity tag for the version of a resource that was last
+// modified at the given time
+func ETag(lastModified time.Time) string {
+	return fmt.Sprintf(`"%x"`, lastModified.UnixMicro())
+}
+
+// ParseETag returns when the version of a resource given by an entity tag
+// was last modified
+func ParseETag(etag string) (time.Time, error) {
+	if len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		return time.Time{}, fmt.Errorf("invalid entity tag: %s", etag)
+	}
+
+	micros, err := strconv.ParseInt(etag[1:len(etag)-1], 16, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid entity tag: %s", etag)
+	}
+
+	return time.UnixMicro(micros), nil
+}
+
+// Precondition makes an update conditional on the resource not having been
+// modified since the version the client based the update on
+type Precondition struct {
+	// IfMatch is when the version given in the If-Match header was last
+	// modified, the update is unconditional if it is nil
+	IfMatch *time.Time
+}
+
+func (p *Precondition) SetIfMatch(etag string) error {
+	lastModified, err := ParseETag(etag)
+	if err != nil {
+		return err
+	}
+
+	p.IfMatch = &lastModified
+
+	return nil
+}
@@ -10,11 +10,13 @@ import (
 type InsightProductStorage interface {
 	GetInsightProductsNumberByTeam(ctx context.Context, teamID uuid.UUID) (int64, error)
 	GetInsightProductsByTeamID(ctx context.Context, teamIDs []uuid.UUID) ([]*InsightProduct, error)
-	GetInsightProductsByGroups(ctx context.Context, groups []string) ([]*InsightProduct, error)
+	GetInsightProductsByTeamIDPage(ctx context.Context, teamIDs []uuid.UUID, page PageRequest) (*Page[*InsightProduct], error)
+	GetInsightProductsByGroups(ctx context.Context, groups []string, page PageRequest) (*Page[*InsightProduct], error)
 	GetInsightProductWithTeamkatalogen(ctx context.Context, id uuid.UUID) (*InsightProduct, error)
 	UpdateInsightProduct(ctx context.Context, id uuid.UUID, in UpdateInsightProductDto) (*InsightProduct, error)
 	CreateInsightProduct(ctx context.Context, creator string, in NewInsightProduct) (*InsightProduct, error)
 	DeleteInsightProduct(ctx context.Context, id uuid.UUID) error
+	SoftDeleteInsightProduct(ctx context.Context, id uuid.UUID, deletedBy string) error
 }
 
 type InsightProductService interface {
@@ -54,6 +56,14 @@ type InsightProduct struct {
 	ProductAreaName string     `json:"productAreaName"`
 }
 
+func (p *InsightProduct) ETag() string {
+	if p.LastModified == nil {
+		return ""
+	}
+
+	return ETag(*p.LastModified)
+}
+
 type UpdateInsightProductDto struct {
 	Name             string     `json:"name"`
 	Description      string     `json:"description"`
@@ -64,6 +74,7 @@ type UpdateInsightProductDto struct {
 	ProductAreaID    *uuid.UUID `json:"productAreaID"`
 	TeamID           *uuid.UUID `json:"teamID"`
 	Group            string     `json:"group"`
+	Precondition     `json:"-"`
 }
 
 // NewInsightProduct contains the metadata and content of insight products.
@@ -3,8 +3,11 @@ package service
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/google/uuid"
 )
 
@@ -16,6 +19,12 @@ type JoinableViewsStorage interface {
 	GetJoinableViewsToBeDeletedWithRefDatasource(ctx context.Context) ([]JoinableViewToBeDeletedWithRefDatasource, error)
 	GetJoinableViewsWithReference(ctx context.Context) ([]JoinableViewWithReference, error)
 	SetJoinableViewDeleted(ctx context.Context, id uuid.UUID) error
+	GetJoinableViewMetadata(ctx context.Context, id uuid.UUID) (*JoinableViewMetadata, error)
+	SetJoinableViewExpires(ctx context.Context, id uuid.UUID, expires *time.Time) error
+	SetJoinableViewDatasourceViewDeleted(ctx context.Context, joinableViewID, datasourceID uuid.UUID) error
+	CreateJoinableViewShare(ctx context.Context, id uuid.UUID, group string) error
+	GetJoinableViewShares(ctx context.Context, id uuid.UUID) ([]string, error)
+	GetJoinableViewsToBeCleanedUp(ctx context.Context) ([]*JoinableViewMetadata, error)
 }
 
 type JoinableViewsService interface {
@@ -25,11 +34,18 @@ type JoinableViewsService interface {
 	GetJoinableViewsToBeDeletedWithRefDatasource(ctx context.Context) ([]JoinableViewToBeDeletedWithRefDatasource, error)
 	GetJoinableViewsWithReference(ctx context.Context) ([]JoinableViewWithReference, error)
 	SetJoinableViewDeleted(ctx context.Context, id uuid.UUID) error
+	SetJoinableViewDatasourceViewDeleted(ctx context.Context, joinableViewID, datasourceID uuid.UUID) error
+	GetJoinableViewShares(ctx context.Context, id uuid.UUID) ([]string, error)
+	DeleteJoinableView(ctx context.Context, user *User, id uuid.UUID) error
+	ExtendJoinableView(ctx context.Context, user *User, id uuid.UUID, input ExtendJoinableView) (*JoinableView, error)
+	ShareJoinableView(ctx context.Context, user *User, id uuid.UUID, input ShareJoinableView) error
+	CleanupJoinableViews(ctx context.Context) error
 }
 
 type JoinableViewToBeDeletedWithRefDatasource struct {
 	JoinableViewID   uuid.UUID
 	JoinableViewName string
+	DatasourceID     uuid.UUID
 	BqProjectID      string
 	BqDatasetID      string
 	BqTableID        string
@@ -59,6 +75,16 @@ type JoinableViewWithDataset struct {
 	JoinableViewExpires *time.Time
 }
 
+// JoinableViewMetadata contains the metadata of a joinable view set
+type JoinableViewMetadata struct {
+	ID      uuid.UUID
+	Name    string
+	Owner   string
+	Created time.Time
+	Expires *time.Time
+	Deleted *time.Time
+}
+
 type JoinableViewForReferenceAndUser struct {
 	ID      uuid.UUID
 	Dataset string
@@ -101,4 +127,42 @@ type PseudoDatasource struct {
 type JoinableViewWithDatasource struct {
 	JoinableView
 	PseudoDatasources []PseudoDatasource `json:"pseudoDatasources"`
+	// SharedWith is the groups the joinable views are shared with
+	SharedWith []string `json:"sharedWith"`
+}
+
+// JoinableViewMaxExtension is how far into the future the expiry of a
+// joinable view set can be extended
+const JoinableViewMaxExtension = 365 * 24 * time.Hour
+
+// ExtendJoinableView contains the new expiry of a joinable view set
+type ExtendJoinableView struct {
+	Expires time.Time `json:"expires"`
+}
+
+func (e ExtendJoinableView) Validate() error {
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Expires, validation.Required, validation.By(func(value interface{}) error {
+			if !e.Expires.After(time.Now()) {
+				return fmt.Errorf("must be in the future")
+			}
+
+			if e.Expires.After(time.Now().Add(JoinableViewMaxExtension)) {
+				return fmt.Errorf("must be within %d days", JoinableViewMaxExtension/(24*time.Hour))
+			}
+
+			return nil
+		})),
+	)
+}
+
+// ShareJoinableView contains the group to share a joinable view set with
+type ShareJoinableView struct {
+	Group string `json:"group"`
+}
+
+func (s ShareJoinableView) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Group, validation.Required, is.EmailFormat),
+	)
 }
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+type LifecycleStorage interface {
+	SetDataproductLifecycle(ctx context.Context, id uuid.UUID, lifecycle Lifecycle) error
+	SetDatasetLifecycle(ctx context.Context, id uuid.UUID, lifecycle Lifecycle) error
+	GetDatasetsToRetire(ctx context.Context) ([]uuid.UUID, error)
+	GetDataproductsToRetire(ctx context.Context) ([]uuid.UUID, error)
+}
+
+type LifecycleService interface {
+	UpdateDataproductLifecycle(ctx context.Context, user *User, id uuid.UUID, input UpdateLifecycle) (*Lifecycle, error)
+	UpdateDatasetLifecycle(ctx context.Context, user *User, id uuid.UUID, input UpdateLifecycle) (*Lifecycle, error)
+	RetireSunsetDatasets(ctx context.Context) error
+}
+
+type LifecycleStatus string
+
+const (
+	LifecycleStatusActive          LifecycleStatus = "active"
+	LifecycleStatusDeprecated      LifecycleStatus = "deprecated"
+	LifecycleStatusSunsetScheduled LifecycleStatus = "sunset_scheduled"
+	LifecycleStatusRetired         LifecycleStatus = "retired"
+)
+
+// Lifecycle describes where a dataproduct or dataset is in its lifecycle,
+// and which dataproduct or dataset consumers should move to instead
+type Lifecycle struct {
+	Status     LifecycleStatus `json:"status"`
+	ReplacedBy *uuid.UUID      `json:"replacedBy"`
+	Sunset     *time.Time      `json:"sunset"`
+	Reason     *string         `json:"reason"`
+}
+
+// Active returns true if the dataproduct or dataset has not been deprecated
+func (l *Lifecycle) Active() bool {
+	return l == nil || l.Status == LifecycleStatusActive
+}
+
+// UpdateLifecycle contains the new lifecycle state set by the owner, a
+// dataset can only be retired by passing its sunset date
+type UpdateLifecycle struct {
+	Status     LifecycleStatus `json:"status"`
+	ReplacedBy *uuid.UUID      `json:"replacedBy"`
+	Sunset     *time.Time      `json:"sunset"`
+	Reason     *string         `json:"reason"`
+}
+
+func (u UpdateLifecycle) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.Status, validation.Required, validation.In(
+			LifecycleStatusActive,
+			LifecycleStatusDeprecated,
+			LifecycleStatusSunsetScheduled,
+		)),
+		validation.Field(&u.Sunset,
+			validation.When(u.Status == LifecycleStatusSunsetScheduled, validation.Required),
+			validation.When(u.Status == LifecycleStatusActive, validation.Nil),
+			validation.By(func(value interface{}) error {
+				if u.Sunset != nil && !u.Sunset.After(time.Now()) {
+					return fmt.Errorf("sunset must be in the future")
+				}
+
+				return nil
+			}),
+		),
+		validation.Field(&u.ReplacedBy, validation.When(u.Status == LifecycleStatusActive, validation.Nil)),
+	)
+}
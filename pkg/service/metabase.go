@@ -54,6 +54,7 @@ type MetabaseAPI interface {
 	Tables(ctx context.Context, dbID int) ([]MetabaseTable, error)
 	GetCollections(ctx context.Context) ([]*MetabaseCollection, error)
 	UpdateCollection(ctx context.Context, collection *MetabaseCollection) error
+	UpdateDatabaseName(ctx context.Context, id int, team, name string) error
 }
 
 type MetabaseService interface {
@@ -65,6 +66,7 @@ type MetabaseService interface {
 	GrantMetabaseAccess(ctx context.Context, dsID uuid.UUID, subject, subjectType string) error
 	CreateMappingRequest(ctx context.Context, user *User, datasetID uuid.UUID, services []string) error
 	MapDataset(ctx context.Context, datasetID uuid.UUID, services []string) error
+	TransferDataset(ctx context.Context, dsID uuid.UUID, ownerGroup string) error
 }
 
 type MetabaseField struct{}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+type NotificationStorage interface {
+	CreateNotification(ctx context.Context, n *NewStoredNotification) (*Notification, error)
+	GetNotifications(ctx context.Context, email string, groups []string, unreadOnly bool, page PageRequest) (*Page[*Notification], error)
+	MarkNotificationsRead(ctx context.Context, email string, groups []string, ids []uuid.UUID) error
+	MarkAllNotificationsRead(ctx context.Context, email string, groups []string) error
+	GetNotificationPreferences(ctx context.Context, email string) ([]*NotificationPreference, error)
+	UpsertNotificationPreferences(ctx context.Context, email string, preferences []*NotificationPreference) error
+	GetDigestRecipients(ctx context.Context, pendingSince time.Time) ([]*DigestRecipient, error)
+	GetPendingDigestNotifications(ctx context.Context, recipient *DigestRecipient) ([]*Notification, error)
+	MarkDigestSent(ctx context.Context, ids []uuid.UUID) error
+	// GetExpiringAccessToNotify returns the active accesses that expire within
+	// the days, and have not been notified of it for their current expiry
+	GetExpiringAccessToNotify(ctx context.Context, days int) ([]*ExpiringAccess, error)
+	MarkAccessExpiryNotified(ctx context.Context, access *ExpiringAccess, days int) error
+	CreateNotificationDelivery(ctx context.Context, d *NewNotificationDelivery) error
+	// GetPendingNotificationDeliveries returns the deliveries that have not
+	// been delivered, and are due for another attempt
+	GetPendingNotificationDeliveries(ctx context.Context, maxAttempts, limit int) ([]*NotificationDelivery, error)
+	MarkNotificationDelivered(ctx context.Context, id uuid.UUID) error
+	MarkNotificationDeliveryFailed(ctx context.Context, id uuid.UUID, reason string) error
+}
+
+type NotificationService interface {
+	// Notify adds a notification to the inbox of the recipients and queues
+	// it for delivery on the channel each user has chosen for the event type
+	Notify(ctx context.Context, n NewNotification) error
+	GetNotifications(ctx context.Context, user *User, unreadOnly bool, page PageRequest) (*Page[*Notification], error)
+	MarkNotificationsRead(ctx context.Context, user *User, input MarkNotificationsRead) error
+	MarkAllNotificationsRead(ctx context.Context, user *User) error
+	GetNotificationPreferences(ctx context.Context, user *User) (*NotificationPreferences, error)
+	UpdateNotificationPreferences(ctx context.Context, user *User, input NotificationPreferences) (*NotificationPreferences, error)
+	NotifyExpiringAccess(ctx context.Context) error
+	SendDigests(ctx context.Context) error
+	// DeliverNotifications sends the queued notifications on Slack and email
+	DeliverNotifications(ctx context.Context) error
+}
+
+type EmailAPI interface {
+	SendEmail(ctx context.Context, to, subject, body string) error
+}
+
+type NotificationEventType string
+
+const (
+	NotificationEventAccessRequestCreated  NotificationEventType = "access_request_created"
+	NotificationEventAccessRequestApproved NotificationEventType = "access_request_approved"
+	NotificationEventAccessRequestDenied   NotificationEventType = "access_request_denied"
+	NotificationEventAccessExpiring        NotificationEventType = "access_expiring"
+	NotificationEventSchemaChanged         NotificationEventType = "schema_changed"
+	NotificationEventDatasetDeprecated     NotificationEventType = "dataset_deprecated"
+	NotificationEventAccessRecertification NotificationEventType = "access_recertification"
+	NotificationEventPurposeInvalidated    NotificationEventType = "purpose_invalidated"
+	NotificationEventQualityCheckFailed    NotificationEventType = "quality_check_failed"
+)
+
+var NotificationEventTypes = []NotificationEventType{
+	NotificationEventAccessRequestCreated,
+	NotificationEventAccessRequestApproved,
+	NotificationEventAccessRequestDenied,
+	NotificationEventAccessExpiring,
+	NotificationEventSchemaChanged,
+	NotificationEventDatasetDeprecated,
+	NotificationEventAccessRecertification,
+	NotificationEventPurposeInvalidated,
+	NotificationEventQualityCheckFailed,
+}
+
+type NotificationChannel string
+
+const (
+	NotificationChannelSlack NotificationChannel = "slack"
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelInApp NotificationChannel = "inApp"
+)
+
+// Notification is an entry in the inbox of a user, or of a group the user
+// is a member of
+type Notification struct {
+	ID        uuid.UUID             `json:"id"`
+	Recipient string                `json:"recipient"`
+	EventType NotificationEventType `json:"eventType"`
+	Title     string                `json:"title"`
+	Message   string                `json:"message"`
+	Link      *string               `json:"link"`
+	Created   time.Time             `json:"created"`
+	Read      *time.Time            `json:"read"`
+}
+
+// NewNotification is an event to notify about, where the recipients are
+// subjects on the form user:<email> or group:<email>, groups only get the
+// notification in their inbox, and in their team channel if it is set. When
+// ApprovalRequest is set the team channel message lets the team approve or
+// deny the access request referenced by ReferenceID
+type NewNotification struct {
+	EventType       NotificationEventType
+	Recipients      []string
+	Title           string
+	Message         string
+	Link            *string
+	ReferenceID     *uuid.UUID
+	TeamChannel     *string
+	ApprovalRequest bool
+}
+
+// NewStoredNotification is a notification for one recipient, where
+// DigestChannel is set when the notification is delivered in a digest
+type NewStoredNotification struct {
+	Recipient     string
+	EventType     NotificationEventType
+	Title         string
+	Message       string
+	Link          *string
+	ReferenceID   *uuid.UUID
+	DigestChannel *NotificationChannel
+}
+
+type NotificationPreference struct {
+	EventType NotificationEventType `json:"eventType"`
+	Channel   NotificationChannel   `json:"channel"`
+	Digest    bool                  `json:"digest"`
+}
+
+func (p NotificationPreference) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.EventType, validation.Required, validation.In(
+			NotificationEventAccessRequestCreated,
+			NotificationEventAccessRequestApproved,
+			NotificationEventAccessRequestDenied,
+			NotificationEventAccessExpiring,
+			NotificationEventSchemaChanged,
+			NotificationEventDatasetDeprecated,
+			NotificationEventAccessRecertification,
+			NotificationEventPurposeInvalidated,
+			NotificationEventQualityCheckFailed,
+		)),
+		validation.Field(&p.Channel, validation.Required, validation.In(
+			NotificationChannelSlack,
+			NotificationChannelEmail,
+			NotificationChannelInApp,
+		)),
+	)
+}
+
+// NotificationPreferences has a preference for every event type, event
+// types the user has not set a preference for are only shown in the inbox
+type NotificationPreferences struct {
+	Preferences []*NotificationPreference `json:"preferences"`
+}
+
+func (p NotificationPreferences) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Preferences, validation.Required),
+	)
+}
+
+type MarkNotificationsRead struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
+func (m MarkNotificationsRead) Validate() error {
+	return validation.ValidateStruct(&m,
+		validation.Field(&m.IDs, validation.Required),
+	)
+}
+
+type NotificationDeliveryKind string
+
+const (
+	NotificationDeliveryKindSlackUser     NotificationDeliveryKind = "slack_user"
+	NotificationDeliveryKindSlackChannel  NotificationDeliveryKind = "slack_channel"
+	NotificationDeliveryKindSlackApproval NotificationDeliveryKind = "slack_approval"
+	NotificationDeliveryKindEmail         NotificationDeliveryKind = "email"
+)
+
+// NewNotificationDelivery is a message to send on Slack or email, where the
+// recipient is an email, or a Slack channel for the channel kinds
+type NewNotificationDelivery struct {
+	Kind        NotificationDeliveryKind
+	Recipient   string
+	Title       string
+	Text        string
+	ReferenceID *uuid.UUID
+}
+
+// NotificationDelivery is a message queued for delivery, which is retried
+// until it is delivered or runs out of attempts
+type NotificationDelivery struct {
+	ID          uuid.UUID
+	Kind        NotificationDeliveryKind
+	Recipient   string
+	Title       string
+	Text        string
+	ReferenceID *uuid.UUID
+	Attempts    int
+}
+
+// DigestRecipient is a user with notifications waiting to be sent in a
+// digest on the given channel
+type DigestRecipient struct {
+	Email   string
+	Channel NotificationChannel
+}
+
+// ExpiringAccess is an active access to a dataset that expires soon, with
+// the renewal request of the access if one is pending
+type ExpiringAccess struct {
+	AccessID         uuid.UUID  `json:"accessID"`
+	Subject          string     `json:"subject"`
+	Owner            string     `json:"owner"`
+	Expires          time.Time  `json:"expires"`
+	DatasetID        uuid.UUID  `json:"datasetID"`
+	DatasetName      string     `json:"datasetName"`
+	DataproductID    uuid.UUID  `json:"dataproductID"`
+	DataproductName  string     `json:"dataproductName"`
+	RenewalRequestID *uuid.UUID `json:"renewalRequestID"`
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+const (
+	SortByName    = "name"
+	SortByCreated = "created"
+)
+
+// PageRequest is a request for a page of a list sorted by one field, where
+// After is the position of the last item on the previous page
+type PageRequest struct {
+	Limit int
+	Sort  string
+	Order SortOrder
+	After *Cursor
+}
+
+func (p PageRequest) Descending() bool {
+	return p.Order == SortOrderDesc
+}
+
+// Cursor is a position in a sorted list, given by the sort key and the ID
+// of an item, which breaks ties between items with the same sort key
+type Cursor struct {
+	Sort  string    `json:"s"`
+	Order SortOrder `json:"o"`
+	Key   string    `json:"k"`
+	ID    uuid.UUID `json:"i"`
+}
+
+// CreatedKey is the sort key of an item in a list sorted by creation time
+func CreatedKey(created time.Time) string {
+	return created.UTC().Format(time.RFC3339Nano)
+}
+
+// Created returns the creation time in the key of a cursor for a list sorted
+// by creation time
+func (c *Cursor) Created() (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, c.Key)
+}
+
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"nextCursor,omitempty"`
+
+	// Next is the position of the last item, set when there are more pages
+	Next *Cursor `json:"-"`
+}
+
+func (p *Page[T]) NextPage() *Cursor {
+	return p.Next
+}
+
+func (p *Page[T]) SetNextCursor(cursor string) {
+	p.NextCursor = cursor
+}
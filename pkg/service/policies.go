@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+type PolicyStorage interface {
+	CreatePolicy(ctx context.Context, policy NewPolicy, createdBy string) (*Policy, error)
+	GetPolicy(ctx context.Context, id uuid.UUID) (*Policy, error)
+	GetOrganisationPolicies(ctx context.Context) ([]*Policy, error)
+	GetPoliciesForDataset(ctx context.Context, datasetID uuid.UUID) ([]*Policy, error)
+	DeletePolicy(ctx context.Context, id uuid.UUID) error
+}
+
+type PolicyService interface {
+	// Decide evaluates the built-in, organisation wide and dataset policies
+	// that apply to the input
+	Decide(ctx context.Context, input PolicyInput) (*PolicyDecision, error)
+	// Enforce returns an Unauthorized error with the reasons when the
+	// decision for the input is to deny
+	Enforce(ctx context.Context, input PolicyInput) error
+	DryRun(ctx context.Context, user *User, input PolicyDryRun) (*PolicyDecision, error)
+	GetPolicies(ctx context.Context, user *User, datasetID *uuid.UUID) (*Policies, error)
+	CreatePolicy(ctx context.Context, user *User, input NewPolicy) (*Policy, error)
+	DeletePolicy(ctx context.Context, user *User, id uuid.UUID) error
+}
+
+type PolicyAction string
+
+const (
+	PolicyActionGrantAccess     PolicyAction = "grant_access"
+	PolicyActionRequestAccess   PolicyAction = "request_access"
+	PolicyActionJoinableView    PolicyAction = "joinable_view"
+	PolicyActionMetabaseMapping PolicyAction = "metabase_mapping"
+)
+
+// Policy denies an action on a dataset when its condition, a CEL expression,
+// evaluates to true. A policy without a dataset applies to the whole
+// organisation. The expression has access to the variables:
+//
+//   - action: the action, e.g. "grant_access"
+//   - subject: map with email, type, groups, isOwner and hasAccess
+//   - dataset: map with id, name, pii, keywords, ownerGroup and dataproductID
+//   - request: map with purpose, purposeStatus and expires, each only present
+//     when known. A policy on purposeStatus, e.g. request.purposeStatus != "valid",
+//     requires a new purpose when the legal basis of the old one is no longer valid
+//   - now: the time of the evaluation
+type Policy struct {
+	ID        uuid.UUID  `json:"id"`
+	DatasetID *uuid.UUID `json:"datasetID"`
+	Name      string     `json:"name"`
+	Condition string     `json:"condition"`
+	// Reason is shown to the user when the policy denies the action
+	Reason string `json:"reason"`
+	// BuiltIn policies are part of the code and can not be deleted
+	BuiltIn   bool      `json:"builtIn"`
+	CreatedBy string    `json:"createdBy"`
+	Created   time.Time `json:"created"`
+}
+
+type Policies struct {
+	Policies []*Policy `json:"policies"`
+}
+
+type NewPolicy struct {
+	DatasetID *uuid.UUID `json:"datasetID"`
+	Name      string     `json:"name"`
+	Condition string     `json:"condition"`
+	Reason    string     `json:"reason"`
+}
+
+func (p NewPolicy) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Name, validation.Required),
+		validation.Field(&p.Condition, validation.Required),
+		validation.Field(&p.Reason, validation.Required),
+	)
+}
+
+// PolicyInput describes who wants to do what on which dataset, the dataset
+// attributes and whether the subject owns or has access to the dataset are
+// looked up when the policies are evaluated
+type PolicyInput struct {
+	Action      PolicyAction `json:"action"`
+	Subject     string       `json:"subject"`
+	SubjectType string       `json:"subjectType"`
+	// SubjectGroups are the groups of the subject, if it is a user
+	SubjectGroups []string  `json:"subjectGroups"`
+	DatasetID     uuid.UUID `json:"datasetID"`
+	Purpose       *string   `json:"purpose"`
+	// PurposeStatus is the status of the purpose in the treatment catalogue
+	// when it was last revalidated
+	PurposeStatus *PollyPurposeStatus `json:"purposeStatus"`
+	Expires       *time.Time          `json:"expires"`
+}
+
+func (i PolicyInput) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(&i.Action, validation.Required, validation.In(
+			PolicyActionGrantAccess,
+			PolicyActionRequestAccess,
+			PolicyActionJoinableView,
+			PolicyActionMetabaseMapping,
+		)),
+		validation.Field(&i.Subject, validation.Required),
+		validation.Field(&i.SubjectType, validation.Required, validation.In(SubjectTypeUser, SubjectTypeGroup, SubjectTypeServiceAccount)),
+	)
+}
+
+// PolicyDryRun evaluates the input against the current policies, and the
+// candidate policy if one is given, without doing anything
+type PolicyDryRun struct {
+	Input     PolicyInput `json:"input"`
+	Candidate *NewPolicy  `json:"candidate"`
+}
+
+type PolicyDecision struct {
+	Allow bool `json:"allow"`
+	// Reasons are the reasons given by the policies that denied the action
+	Reasons []string `json:"reasons"`
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -9,19 +10,44 @@ import (
 type PollyStorage interface {
 	CreatePollyDocumentation(ctx context.Context, input PollyInput) (Polly, error)
 	GetPollyDocumentation(ctx context.Context, id uuid.UUID) (*Polly, error)
+	// GetReferencedPollyPurposes returns the purposes of the active grants
+	// and pending access requests
+	GetReferencedPollyPurposes(ctx context.Context) ([]*QueryPolly, error)
+	GetPollyPurpose(ctx context.Context, externalID string) (*PollyPurpose, error)
+	// UpdatePollyPurpose stores the current status of the purpose, and records
+	// it in the history when the status, name or validity period has changed
+	UpdatePollyPurpose(ctx context.Context, purpose *PollyPurpose) error
+	GetPollyPurposeHistory(ctx context.Context, externalID string) ([]*PollyPurpose, error)
+	ListActiveAccessForPollyPurpose(ctx context.Context, externalID string) ([]*AccessWithPollyPurpose, error)
+	ListAccessWithInvalidPollyPurposeForGroups(ctx context.Context, groups []string) ([]*AccessWithPollyPurpose, error)
 }
 
 type PollyAPI interface {
 	SearchPolly(ctx context.Context, q string) ([]*QueryPolly, error)
+	// GetPollyProcess returns the treatment as it is currently documented,
+	// or an error of kind NotExist if it has been deleted
+	GetPollyProcess(ctx context.Context, externalID string) (*PollyProcess, error)
 }
 
 type PollyService interface {
 	SearchPolly(ctx context.Context, q string) ([]*QueryPolly, error)
+	// RevalidatePurposes re-fetches the purposes referenced by grants and
+	// access requests, and alerts the dataset owners when the legal basis of
+	// a grant has been withdrawn or has expired
+	RevalidatePurposes(ctx context.Context) error
+	GetPurposeHistory(ctx context.Context, externalID string) (*PollyPurposeHistory, error)
+	// GetAccessWithInvalidPurpose returns the grants to the datasets owned by
+	// the groups of the user whose purpose is no longer valid
+	GetAccessWithInvalidPurpose(ctx context.Context, user *User) (*AccessesWithPollyPurpose, error)
 }
 
 type Polly struct {
 	ID uuid.UUID `json:"id"`
 	QueryPolly
+	// Status is the status of the purpose when it was last revalidated, it is
+	// not set if the purpose has not been revalidated yet
+	Status  *PollyPurposeStatus `json:"status"`
+	ValidTo *time.Time          `json:"validTo"`
 }
 
 type PollyInput struct {
@@ -34,3 +60,58 @@ type QueryPolly struct {
 	Name       string `json:"name"`
 	URL        string `json:"url"`
 }
+
+// PollyProcess is a treatment in the treatment catalogue
+type PollyProcess struct {
+	ExternalID string
+	Name       string
+	ValidFrom  *time.Time
+	ValidTo    *time.Time
+	LegalBases []string
+}
+
+type PollyPurposeStatus string
+
+const (
+	PollyPurposeStatusValid   PollyPurposeStatus = "valid"
+	PollyPurposeStatusExpired PollyPurposeStatus = "expired"
+	// PollyPurposeStatusWithdrawn is used when the treatment has been deleted,
+	// or no longer has a legal basis
+	PollyPurposeStatusWithdrawn PollyPurposeStatus = "withdrawn"
+)
+
+// PollyPurpose is the status of a purpose when it was checked against the
+// treatment catalogue
+type PollyPurpose struct {
+	ExternalID string             `json:"externalID"`
+	Name       string             `json:"name"`
+	Status     PollyPurposeStatus `json:"status"`
+	ValidFrom  *time.Time         `json:"validFrom"`
+	ValidTo    *time.Time         `json:"validTo"`
+	Checked    time.Time          `json:"checked"`
+}
+
+type PollyPurposeHistory struct {
+	Purpose *PollyPurpose   `json:"purpose"`
+	History []*PollyPurpose `json:"history"`
+}
+
+// AccessWithPollyPurpose is an active grant and the purpose it was granted for
+type AccessWithPollyPurpose struct {
+	AccessID        uuid.UUID          `json:"accessID"`
+	Subject         string             `json:"subject"`
+	Owner           string             `json:"owner"`
+	Expires         *time.Time         `json:"expires"`
+	DatasetID       uuid.UUID          `json:"datasetID"`
+	DatasetName     string             `json:"datasetName"`
+	DataproductID   uuid.UUID          `json:"dataproductID"`
+	DataproductName string             `json:"dataproductName"`
+	OwnerGroup      string             `json:"ownerGroup"`
+	TeamContact     *string            `json:"teamContact"`
+	Purpose         QueryPolly         `json:"purpose"`
+	PurposeStatus   PollyPurposeStatus `json:"purposeStatus"`
+}
+
+type AccessesWithPollyPurpose struct {
+	Accesses []*AccessWithPollyPurpose `json:"accesses"`
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+const (
+	DatasetPreviewDefaultLimit = 10
+	DatasetPreviewMaxLimit     = 100
+)
+
+type DatasetPreviewStorage interface {
+	// GetDatasetPreviewSettings returns an error of kind NotExist if the
+	// owner has never changed the settings of the dataset
+	GetDatasetPreviewSettings(ctx context.Context, datasetID uuid.UUID) (*DatasetPreviewSettings, error)
+	UpdateDatasetPreviewSettings(ctx context.Context, datasetID uuid.UUID, enabled bool, updatedBy string) (*DatasetPreviewSettings, error)
+}
+
+type DatasetPreviewService interface {
+	// GetDatasetPreview returns a sample of the rows in the dataset to the
+	// users with access, and synthetic rows based on the schema to the others
+	GetDatasetPreview(ctx context.Context, user *User, datasetID uuid.UUID, limit int) (*DatasetPreview, error)
+	UpdateDatasetPreviewSettings(ctx context.Context, user *User, datasetID uuid.UUID, input UpdateDatasetPreviewSettings) (*DatasetPreviewSettings, error)
+}
+
+type DatasetPreviewKind string
+
+const (
+	// DatasetPreviewKindSample contains real rows read from the table
+	DatasetPreviewKindSample DatasetPreviewKind = "sample"
+	// DatasetPreviewKindSynthetic contains example values generated from the
+	// column types, and nothing read from the table
+	DatasetPreviewKindSynthetic DatasetPreviewKind = "synthetic"
+)
+
+type DatasetPreview struct {
+	DatasetID uuid.UUID          `json:"datasetID"`
+	Kind      DatasetPreviewKind `json:"kind"`
+	// Reason explains why the preview is synthetic
+	Reason *string           `json:"reason"`
+	Schema []*BigqueryColumn `json:"schema"`
+	Rows   []map[string]any  `json:"rows"`
+	// MaskedColumns are the pseudonymised columns, their values are
+	// replaced with null in a sample
+	MaskedColumns []string `json:"maskedColumns"`
+}
+
+// DatasetPreviewSettings decides whether the users with access get a sample
+// of a dataset with sensitive personal data, which is disabled by default
+type DatasetPreviewSettings struct {
+	DatasetID uuid.UUID `json:"datasetID"`
+	Enabled   bool      `json:"enabled"`
+	UpdatedBy string    `json:"updatedBy"`
+	Updated   time.Time `json:"updated"`
+}
+
+type UpdateDatasetPreviewSettings struct {
+	Enabled *bool `json:"enabled"`
+}
+
+func (u UpdateDatasetPreviewSettings) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.Enabled, validation.NotNil),
+	)
+}
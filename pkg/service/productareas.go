@@ -16,6 +16,9 @@ type ProductAreaStorage interface {
 type ProductAreaService interface {
 	GetProductAreas(ctx context.Context) (*ProductAreasDto, error)
 	GetProductAreaWithAssets(ctx context.Context, id uuid.UUID) (*ProductAreaWithAssets, error)
+	GetDataproducts(ctx context.Context, id uuid.UUID, teamID *uuid.UUID, page PageRequest) (*Page[*Dataproduct], error)
+	GetStories(ctx context.Context, id uuid.UUID, teamID *uuid.UUID, page PageRequest) (*Page[*Story], error)
+	GetInsightProducts(ctx context.Context, id uuid.UUID, teamID *uuid.UUID, page PageRequest) (*Page[*InsightProduct], error)
 }
 
 type UpsertProductAreaRequest struct {
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ProfileTopValuesMaxDistinct is the highest number of distinct values a
+	// column can have for its top values to be profiled
+	ProfileTopValuesMaxDistinct = 20
+	ProfileTopValuesLimit       = 5
+)
+
+type ProfilingStorage interface {
+	// GetDatasourcesToProfile returns the table scoped datasources that are
+	// not missing, and the personal data level of their datasets
+	GetDatasourcesToProfile(ctx context.Context) ([]*DatasourceToProfile, error)
+	CreateDatasetProfile(ctx context.Context, profile *DatasetProfile) error
+	GetDatasetProfiles(ctx context.Context, datasetID uuid.UUID, limit int) ([]*DatasetProfile, error)
+	// GetLatestTableProfile returns an error of kind NotExist if the table
+	// has not been profiled
+	GetLatestTableProfile(ctx context.Context, projectID, datasetID, tableID string) (*DatasetProfile, error)
+}
+
+type ProfilingService interface {
+	// ProfileDatasources profiles the columns of the registered datasources,
+	// skipping the tables that would exceed the budget of bytes processed
+	ProfileDatasources(ctx context.Context) error
+	GetDatasetProfiles(ctx context.Context, user *User, datasetID uuid.UUID, limit int) (*DatasetProfiles, error)
+}
+
+type DatasourceToProfile struct {
+	DatasetID     uuid.UUID
+	Pii           PiiLevel
+	ProjectID     string
+	Dataset       string
+	Table         string
+	Schema        []*BigqueryColumn
+	PiiTags       map[string]string
+	PseudoColumns []PseudoColumn
+}
+
+// ProfileColumn is a column to profile, the min, max and top values are not
+// profiled for a masked column
+type ProfileColumn struct {
+	Name   string
+	Type   string
+	Masked bool
+}
+
+// Profilable returns false for the columns that are not a scalar value
+func (c ProfileColumn) Profilable() bool {
+	switch strings.ToUpper(c.Type) {
+	case "RECORD", "STRUCT", "RANGE":
+		return false
+	}
+
+	return true
+}
+
+// Groupable returns true if the distinct values of the column can be counted
+func (c ProfileColumn) Groupable() bool {
+	switch strings.ToUpper(c.Type) {
+	case "GEOGRAPHY", "JSON", "RECORD", "STRUCT", "RANGE":
+		return false
+	}
+
+	return true
+}
+
+// Orderable returns true if the min and max of the column are meaningful
+func (c ProfileColumn) Orderable() bool {
+	return c.Groupable() && !strings.EqualFold(c.Type, "BYTES")
+}
+
+type DatasetProfile struct {
+	ID             uuid.UUID        `json:"id"`
+	DatasetID      uuid.UUID        `json:"datasetID"`
+	ProjectID      string           `json:"projectID"`
+	Dataset        string           `json:"dataset"`
+	Table          string           `json:"table"`
+	RowCount       int64            `json:"rowCount"`
+	BytesProcessed int64            `json:"bytesProcessed"`
+	Columns        []*ColumnProfile `json:"columns"`
+	Created        time.Time        `json:"created"`
+}
+
+// ColumnProfile are the statistics of a column, the values are only set for
+// the columns of a type that supports them
+type ColumnProfile struct {
+	Name          string  `json:"name"`
+	Type          string  `json:"type"`
+	NullRate      float64 `json:"nullRate"`
+	DistinctCount *int64  `json:"distinctCount"`
+	Min           *string `json:"min"`
+	Max           *string `json:"max"`
+	// TopValues are the most frequent values of a column with few distinct values
+	TopValues []*ColumnValueCount `json:"topValues"`
+	// Masked is true for the columns with personal data, which only have
+	// their null rate and distinct count profiled
+	Masked bool `json:"masked"`
+}
+
+type ColumnValueCount struct {
+	Value *string `json:"value"`
+	Count int64   `json:"count"`
+}
+
+type DatasetProfiles struct {
+	Profiles []*DatasetProfile `json:"profiles"`
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+const (
+	// QualityCheckTablePlaceholder is replaced with the table of the dataset
+	// in the query of a check of kind sql
+	QualityCheckTablePlaceholder = "{{table}}"
+	QualityCheckRunsDefaultLimit = 30
+	QualityCheckRunsMaxLimit     = 365
+	// QualityCheckMaxBytesBilled is the number of bytes a single run of a
+	// check can process, checks that would process more are not run
+	QualityCheckMaxBytesBilled int64 = 10 * 1024 * 1024 * 1024
+)
+
+type QualityCheckStorage interface {
+	CreateQualityCheck(ctx context.Context, datasetID uuid.UUID, input NewQualityCheck, createdBy string) (*QualityCheck, error)
+	// GetQualityCheck returns an error of kind NotExist if the check does not exist
+	GetQualityCheck(ctx context.Context, id uuid.UUID) (*QualityCheck, error)
+	// GetQualityChecks returns the checks of a dataset with their latest run
+	GetQualityChecks(ctx context.Context, datasetID uuid.UUID) ([]*QualityCheck, error)
+	// GetQualityChecksToRun returns the checks of the datasets with a table
+	// scoped datasource that is not missing, or only the check with the id
+	// if it is set
+	GetQualityChecksToRun(ctx context.Context, id *uuid.UUID) ([]*QualityCheckToRun, error)
+	DeleteQualityCheck(ctx context.Context, id uuid.UUID) error
+	CreateQualityCheckRun(ctx context.Context, run *NewQualityCheckRun) (*QualityCheckRun, error)
+	GetQualityCheckRuns(ctx context.Context, checkID uuid.UUID, limit int) ([]*QualityCheckRun, error)
+}
+
+type QualityCheckService interface {
+	GetQualityChecks(ctx context.Context, datasetID uuid.UUID) (*QualityChecks, error)
+	CreateQualityCheck(ctx context.Context, user *User, datasetID uuid.UUID, input NewQualityCheck) (*QualityCheck, error)
+	DeleteQualityCheck(ctx context.Context, user *User, id uuid.UUID) error
+	// RunQualityCheck runs a check right away on behalf of an owner of the dataset
+	RunQualityCheck(ctx context.Context, user *User, id uuid.UUID) (*QualityCheckRun, error)
+	// GetQualityCheckRuns returns the run history of a check to the owners
+	// of the dataset and the users with access to it
+	GetQualityCheckRuns(ctx context.Context, user *User, id uuid.UUID, limit int) (*QualityCheckRuns, error)
+	// RunQualityChecks runs all the checks, and notifies the owners of the
+	// datasets with checks that start failing
+	RunQualityChecks(ctx context.Context) error
+}
+
+type QualityCheckKind string
+
+const (
+	// QualityCheckKindSQL counts the rows returned by the query of the check,
+	// which are the rows violating the check
+	QualityCheckKindSQL QualityCheckKind = "sql"
+	// QualityCheckKindNotNull counts the rows where the column is null
+	QualityCheckKindNotNull QualityCheckKind = "not_null"
+	// QualityCheckKindUnique counts the values of the column that occur in
+	// more than one row
+	QualityCheckKindUnique QualityCheckKind = "unique"
+	// QualityCheckKindRowCount counts the rows of the table
+	QualityCheckKindRowCount QualityCheckKind = "row_count"
+)
+
+type QualityCheckStatus string
+
+const (
+	QualityCheckStatusPassed QualityCheckStatus = "passed"
+	QualityCheckStatusFailed QualityCheckStatus = "failed"
+	// QualityCheckStatusError is the status of a check that could not be run
+	QualityCheckStatusError QualityCheckStatus = "error"
+)
+
+// QualityCheck is a check of the table of a dataset, the measured value of a
+// row_count check must be at least the threshold, which defaults to 1, and
+// the measured value of the other checks must be at most the threshold,
+// which defaults to 0
+type QualityCheck struct {
+	ID          uuid.UUID        `json:"id"`
+	DatasetID   uuid.UUID        `json:"datasetID"`
+	Name        string           `json:"name"`
+	Description *string          `json:"description"`
+	Kind        QualityCheckKind `json:"kind"`
+	Column      *string          `json:"column"`
+	// Condition limits the rows that are checked, and is not used by a check
+	// of kind sql
+	Condition *string          `json:"condition"`
+	SQL       *string          `json:"sql"`
+	Threshold *int64           `json:"threshold"`
+	CreatedBy string           `json:"createdBy"`
+	Created   time.Time        `json:"created"`
+	LastRun   *QualityCheckRun `json:"lastRun"`
+}
+
+// Passed returns true if the measured value is within the threshold
+func (c *QualityCheck) Passed(measured int64) bool {
+	if c.Kind == QualityCheckKindRowCount {
+		threshold := int64(1)
+		if c.Threshold != nil {
+			threshold = *c.Threshold
+		}
+
+		return measured >= threshold
+	}
+
+	threshold := int64(0)
+	if c.Threshold != nil {
+		threshold = *c.Threshold
+	}
+
+	return measured <= threshold
+}
+
+type NewQualityCheck struct {
+	Name        string           `json:"name"`
+	Description *string          `json:"description"`
+	Kind        QualityCheckKind `json:"kind"`
+	Column      *string          `json:"column"`
+	Condition   *string          `json:"condition"`
+	SQL         *string          `json:"sql"`
+	Threshold   *int64           `json:"threshold"`
+}
+
+func (c NewQualityCheck) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Name, validation.Required, validation.Length(1, 128)),
+		validation.Field(&c.Kind, validation.Required, validation.In(
+			QualityCheckKindSQL,
+			QualityCheckKindNotNull,
+			QualityCheckKindUnique,
+			QualityCheckKindRowCount,
+		)),
+		validation.Field(&c.Column,
+			validation.When(c.Kind == QualityCheckKindNotNull || c.Kind == QualityCheckKindUnique, validation.Required),
+			validation.When(c.Kind == QualityCheckKindSQL || c.Kind == QualityCheckKindRowCount, validation.Nil),
+		),
+		validation.Field(&c.Condition, validation.When(c.Kind == QualityCheckKindSQL, validation.Nil)),
+		validation.Field(&c.SQL,
+			validation.When(c.Kind == QualityCheckKindSQL, validation.Required),
+			validation.When(c.Kind != QualityCheckKindSQL, validation.Nil),
+		),
+		validation.Field(&c.Threshold, validation.Min(int64(0))),
+	)
+}
+
+// QualityCheckToRun is a check with the table of its dataset, and the owner
+// to notify when it fails
+type QualityCheckToRun struct {
+	Check           *QualityCheck
+	DataproductID   uuid.UUID
+	DataproductName string
+	DatasetName     string
+	OwnerGroup      string
+	ProjectID       string
+	Dataset         string
+	Table           string
+	// PreviousStatus is the status of the latest run, nil if never run
+	PreviousStatus *QualityCheckStatus
+}
+
+type QualityCheckRun struct {
+	ID       uuid.UUID          `json:"id"`
+	CheckID  uuid.UUID          `json:"checkID"`
+	Status   QualityCheckStatus `json:"status"`
+	Measured *int64             `json:"measured"`
+	// Error is the reason a check could not be run
+	Error          *string   `json:"error"`
+	BytesProcessed int64     `json:"bytesProcessed"`
+	Started        time.Time `json:"started"`
+}
+
+type NewQualityCheckRun struct {
+	CheckID        uuid.UUID
+	Status         QualityCheckStatus
+	Measured       *int64
+	Error          *string
+	BytesProcessed int64
+}
+
+type QualityChecks struct {
+	Checks []*QualityCheck `json:"checks"`
+}
+
+type QualityCheckRuns struct {
+	Runs []*QualityCheckRun `json:"runs"`
+}
+
+// DatasetQualityStatus is failed if the latest run of a check of the dataset
+// failed, error if one could not be run, and passed otherwise
+type DatasetQualityStatus struct {
+	Status  QualityCheckStatus `json:"status"`
+	LastRun time.Time          `json:"lastRun"`
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+type RecertificationStorage interface {
+	// CreateRecertificationCampaign creates the campaign with a review for
+	// each of the accesses
+	CreateRecertificationCampaign(ctx context.Context, input NewRecertificationCampaign, accesses []*Access, createdBy string) (*RecertificationCampaign, error)
+	GetRecertificationCampaign(ctx context.Context, id uuid.UUID) (*RecertificationCampaign, error)
+	GetRecertificationCampaigns(ctx context.Context) ([]*RecertificationCampaign, error)
+	GetRecertificationCampaignsForGroups(ctx context.Context, groups []string, createdBy string) ([]*RecertificationCampaign, error)
+	GetOpenRecertificationCampaigns(ctx context.Context) ([]*RecertificationCampaign, error)
+	GetRecertificationReviews(ctx context.Context, campaignID uuid.UUID) ([]*RecertificationReview, error)
+	GetRecertificationReview(ctx context.Context, id uuid.UUID) (*RecertificationReview, error)
+	// ReviewRecertification records the decision, and sets the new expiry
+	// of the access when it is shortened, decisions made at the deadline have
+	// no reviewer
+	ReviewRecertification(ctx context.Context, review *RecertificationReview, decision RecertificationDecision, newExpires *time.Time, reviewedBy *string) error
+	SetRecertificationCampaignReminded(ctx context.Context, id uuid.UUID) error
+	CompleteRecertificationCampaign(ctx context.Context, id uuid.UUID) error
+}
+
+type RecertificationService interface {
+	CreateCampaign(ctx context.Context, user *User, input NewRecertificationCampaign) (*RecertificationCampaign, error)
+	GetCampaigns(ctx context.Context, user *User) (*RecertificationCampaigns, error)
+	GetCampaign(ctx context.Context, user *User, id uuid.UUID) (*RecertificationCampaignWithReviews, error)
+	ReviewAccess(ctx context.Context, user *User, reviewID uuid.UUID, input RecertificationReviewInput) (*RecertificationReview, error)
+	ExportCampaign(ctx context.Context, user *User, id uuid.UUID, format RecertificationExportFormat) (*RecertificationExport, error)
+	// ProcessCampaigns reminds the owners of pending reviews, and closes the
+	// campaigns that have passed their deadline
+	ProcessCampaigns(ctx context.Context) error
+}
+
+type RecertificationDecision string
+
+const (
+	RecertificationDecisionConfirmed RecertificationDecision = "confirmed"
+	RecertificationDecisionShortened RecertificationDecision = "shortened"
+	RecertificationDecisionRevoked   RecertificationDecision = "revoked"
+	// RecertificationDecisionRevokedAtDeadline is recorded for grants that
+	// were not reviewed before the deadline, and were revoked
+	RecertificationDecisionRevokedAtDeadline RecertificationDecision = "revoked_at_deadline"
+	// RecertificationDecisionUnconfirmed is recorded for grants that were not
+	// reviewed before the deadline, and were kept
+	RecertificationDecisionUnconfirmed RecertificationDecision = "unconfirmed"
+)
+
+// RecertificationCampaign asks the owners of a set of datasets to re-confirm
+// that each active grant is still needed before the deadline
+type RecertificationCampaign struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Deadline time.Time `json:"deadline"`
+	// RevokeUnconfirmed revokes the grants that have not been reviewed when
+	// the deadline passes
+	RevokeUnconfirmed bool                    `json:"revokeUnconfirmed"`
+	CreatedBy         string                  `json:"createdBy"`
+	Created           time.Time               `json:"created"`
+	LastReminded      *time.Time              `json:"lastReminded"`
+	Completed         *time.Time              `json:"completed"`
+	Progress          RecertificationProgress `json:"progress"`
+}
+
+type RecertificationProgress struct {
+	Total             int `json:"total"`
+	Pending           int `json:"pending"`
+	Confirmed         int `json:"confirmed"`
+	Shortened         int `json:"shortened"`
+	Revoked           int `json:"revoked"`
+	RevokedAtDeadline int `json:"revokedAtDeadline"`
+	Unconfirmed       int `json:"unconfirmed"`
+}
+
+type RecertificationCampaigns struct {
+	Campaigns []*RecertificationCampaign `json:"campaigns"`
+}
+
+type RecertificationCampaignWithReviews struct {
+	RecertificationCampaign
+	Reviews []*RecertificationReview `json:"reviews"`
+}
+
+// RecertificationReview is the snapshot of an active grant when the campaign
+// was launched, and the decision of the owner
+type RecertificationReview struct {
+	ID              uuid.UUID                `json:"id"`
+	CampaignID      uuid.UUID                `json:"campaignID"`
+	DatasetID       uuid.UUID                `json:"datasetID"`
+	DatasetName     string                   `json:"datasetName"`
+	DataproductID   uuid.UUID                `json:"dataproductID"`
+	DataproductName string                   `json:"dataproductName"`
+	OwnerGroup      string                   `json:"ownerGroup"`
+	TeamContact     *string                  `json:"teamContact"`
+	AccessID        uuid.UUID                `json:"accessID"`
+	Subject         string                   `json:"subject"`
+	Owner           string                   `json:"owner"`
+	Granter         string                   `json:"granter"`
+	Expires         *time.Time               `json:"expires"`
+	Decision        *RecertificationDecision `json:"decision"`
+	NewExpires      *time.Time               `json:"newExpires"`
+	ReviewedBy      *string                  `json:"reviewedBy"`
+	Reviewed        *time.Time               `json:"reviewed"`
+}
+
+type NewRecertificationCampaign struct {
+	Name              string      `json:"name"`
+	DatasetIDs        []uuid.UUID `json:"datasetIDs"`
+	Deadline          time.Time   `json:"deadline"`
+	RevokeUnconfirmed bool        `json:"revokeUnconfirmed"`
+}
+
+func (c NewRecertificationCampaign) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Name, validation.Required),
+		validation.Field(&c.DatasetIDs, validation.Required),
+		validation.Field(&c.Deadline, validation.Required, validation.Min(time.Now()).Error("must be in the future")),
+	)
+}
+
+// RecertificationReviewInput is the decision of the owner on a grant, Expires
+// is the new expiry of a shortened grant
+type RecertificationReviewInput struct {
+	Decision RecertificationDecision `json:"decision"`
+	Expires  *time.Time              `json:"expires"`
+}
+
+func (i RecertificationReviewInput) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(&i.Decision, validation.Required, validation.In(
+			RecertificationDecisionConfirmed,
+			RecertificationDecisionShortened,
+			RecertificationDecisionRevoked,
+		)),
+		validation.Field(&i.Expires,
+			validation.When(i.Decision == RecertificationDecisionShortened, validation.Required, validation.Min(time.Now()).Error("must be in the future")),
+			validation.When(i.Decision != RecertificationDecisionShortened, validation.Nil),
+		),
+	)
+}
+
+type RecertificationExportFormat string
+
+const (
+	RecertificationExportFormatJSON RecertificationExportFormat = "json"
+	RecertificationExportFormatCSV  RecertificationExportFormat = "csv"
+)
+
+type RecertificationExport struct {
+	ContentType string
+	Data        []byte
+}
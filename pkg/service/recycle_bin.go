@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecycleBinRetention is how long deleted dataproducts, stories and insight
+// products are kept before they are purged
+const RecycleBinRetention = 30 * 24 * time.Hour
+
+type RecycleBinStorage interface {
+	GetDeletedItems(ctx context.Context, filter DeletedItemsFilter) ([]*DeletedItem, error)
+	RestoreDataproduct(ctx context.Context, id uuid.UUID) error
+	RestoreStory(ctx context.Context, id uuid.UUID) error
+	RestoreInsightProduct(ctx context.Context, id uuid.UUID) error
+}
+
+type RecycleBinService interface {
+	GetDeletedItems(ctx context.Context, user *User, group string) ([]*DeletedItem, error)
+	RestoreItem(ctx context.Context, user *User, id uuid.UUID) (*DeletedItem, error)
+	PurgeDeletedItems(ctx context.Context) error
+}
+
+type DeletedItemType string
+
+const (
+	DeletedItemTypeDataproduct    DeletedItemType = "dataproduct"
+	DeletedItemTypeStory          DeletedItemType = "story"
+	DeletedItemTypeInsightProduct DeletedItemType = "insight_product"
+)
+
+// DeletedItem is a dataproduct, story or insight product in the recycle bin
+type DeletedItem struct {
+	ID         uuid.UUID       `json:"id"`
+	Name       string          `json:"name"`
+	Type       DeletedItemType `json:"type"`
+	Group      string          `json:"group"`
+	Deleted    time.Time       `json:"deleted"`
+	DeletedBy  string          `json:"deletedBy"`
+	PurgeAfter time.Time       `json:"purgeAfter"`
+}
+
+// DeletedItemsFilter narrows down the items in the recycle bin, the zero
+// value matches all items
+type DeletedItemsFilter struct {
+	Groups        []string
+	ID            *uuid.UUID
+	DeletedBefore *time.Time
+}
@@ -8,10 +8,15 @@ import (
 
 type SearchStorage interface {
 	Search(ctx context.Context, query *SearchOptions) ([]*SearchResultRaw, error)
+	SearchCount(ctx context.Context, query *SearchOptions) (int, error)
+	SearchFacets(ctx context.Context, query *SearchOptions) (*SearchFacets, error)
+	SearchSuggestNames(ctx context.Context, prefix string, limit int) ([]*SearchSuggestion, error)
+	SearchSuggestKeywords(ctx context.Context, prefix string, limit int) ([]KeywordItem, error)
 }
 
 type SearchService interface {
 	Search(ctx context.Context, query *SearchOptions) (*SearchResult, error)
+	Suggest(ctx context.Context, prefix string, limit int) (*SearchSuggestions, error)
 }
 
 func (DataproductWithDataset) IsSearchResult() {}
@@ -26,6 +31,32 @@ type ResultItem interface {
 
 type SearchResult struct {
 	Results []*SearchResultRow `json:"results"`
+	// Total number of hits matching the query, regardless of limit and offset
+	Total  int           `json:"total"`
+	Facets *SearchFacets `json:"facets"`
+	// Fuzzy is true if the full text search had no hits, and the
+	// results are based on trigram similarity instead
+	Fuzzy bool `json:"fuzzy"`
+}
+
+// SearchFacets contains the number of hits for each facet value,
+// computed over the filtered result set
+type SearchFacets struct {
+	Keywords     []*SearchFacetValue `json:"keywords"`
+	Teams        []*SearchFacetValue `json:"teams"`
+	ProductAreas []*SearchFacetValue `json:"productAreas"`
+	PiiLevels    []*SearchFacetValue `json:"piiLevels"`
+	Types        []*SearchFacetValue `json:"types"`
+	Services     []*SearchFacetValue `json:"services"`
+	// QualityStatuses are the statuses of the data quality checks of datasets
+	QualityStatuses []*SearchFacetValue `json:"qualityStatuses"`
+}
+
+type SearchFacetValue struct {
+	Value string `json:"value"`
+	// Human readable name of the value, e.g., the team name for a team id
+	Label string `json:"label,omitempty"`
+	Count int    `json:"count"`
 }
 
 type SearchResultRaw struct {
@@ -50,11 +81,32 @@ type SearchOptions struct {
 	Groups []string `json:"groups"`
 	// Filter on team_id
 	TeamIDs []uuid.UUID `json:"teamIDs"`
+	// Filter on product area
+	ProductAreaIDs []uuid.UUID `json:"productAreaIDs"`
+	// Filter on pii level
+	PiiLevels []string `json:"piiLevels"`
 	// Filter on enabled services
 	Services []string `json:"services"`
+	// Filter on the status of the data quality checks of datasets
+	QualityStatuses []string `json:"qualityStatuses"`
 	// Filter on types
 	Types []string `json:"types"`
 
+	// Use trigram similarity on names and keywords instead of full text search
+	Fuzzy bool `json:"fuzzy"`
+
 	Limit  *int `json:"limit"`
 	Offset *int `json:"offset"`
 }
+
+type SearchSuggestions struct {
+	Names    []*SearchSuggestion `json:"names"`
+	Keywords []KeywordItem       `json:"keywords"`
+}
+
+type SearchSuggestion struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	// Type is one of dataproduct, dataset, story or team
+	Type string `json:"type"`
+}
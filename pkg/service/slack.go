@@ -1,10 +1,82 @@
 package service
 
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
 type SlackAPI interface {
 	SendSlackNotification(channel, message string) error
+	SendSlackNotificationToUser(email, message string) error
+	SendAccessRequestApproval(channel, message string, accessRequestID uuid.UUID) error
+	UpdateAccessRequestMessage(channel, timestamp, message string) error
+	OpenDenyAccessRequestModal(triggerID string, interaction SlackInteraction) error
+	SendSlackEphemeral(channel, userID, message string) error
+	GetSlackUserEmail(userID string) (string, error)
 	IsValidSlackChannel(name string) error
 }
 
 type SlackService interface {
 	IsValidSlackChannel(name string) error
+	HandleInteraction(ctx context.Context, interaction SlackInteraction) error
+}
+
+// GoogleGroupsAPI looks up the Google groups of a user, which decides what
+// the user owns in the catalogue
+type GoogleGroupsAPI interface {
+	Groups(ctx context.Context, email *string) (Groups, error)
+}
+
+type SlackInteractionType string
+
+const (
+	SlackInteractionApproveAccessRequest SlackInteractionType = "approve_access_request"
+	SlackInteractionDenyAccessRequest    SlackInteractionType = "deny_access_request"
+	// SlackInteractionDenyAccessRequestReason is the submission of the modal
+	// where the owner gives the reason for denying an access request
+	SlackInteractionDenyAccessRequestReason SlackInteractionType = "deny_access_request_reason"
+)
+
+// SlackDenyReasonInputID is the block and action id of the reason input in
+// the deny reason modal
+const SlackDenyReasonInputID = "reason"
+
+// SlackInteraction is a button click or modal submission on an access request
+// approval message, Channel and MessageTimestamp identify the message to
+// update with the outcome
+type SlackInteraction struct {
+	Type             SlackInteractionType
+	UserID           string
+	AccessRequestID  uuid.UUID
+	TriggerID        string
+	Channel          string
+	MessageTimestamp string
+	Reason           *string
+}
+
+// Metadata identifies the access request and the approval message, and is
+// carried through the deny reason modal
+func (i SlackInteraction) Metadata() string {
+	return strings.Join([]string{i.AccessRequestID.String(), i.Channel, i.MessageTimestamp}, "|")
+}
+
+func (i *SlackInteraction) SetMetadata(metadata string) error {
+	parts := strings.Split(metadata, "|")
+	if len(parts) != 3 {
+		return fmt.Errorf("invalid slack interaction metadata: %s", metadata)
+	}
+
+	id, err := uuid.Parse(parts[0])
+	if err != nil {
+		return fmt.Errorf("invalid access request id in slack interaction metadata: %w", err)
+	}
+
+	i.AccessRequestID = id
+	i.Channel = parts[1]
+	i.MessageTimestamp = parts[2]
+
+	return nil
 }
@@ -11,13 +11,15 @@ import (
 )
 
 type StoryStorage interface {
-	GetStoriesWithTeamkatalogenByGroups(ctx context.Context, groups []string) ([]*Story, error)
+	GetStoriesWithTeamkatalogenByGroups(ctx context.Context, groups []string, page PageRequest) (*Page[*Story], error)
 	GetStoriesWithTeamkatalogenByIDs(ctx context.Context, ids []uuid.UUID) ([]*Story, error)
 	GetStoriesNumberByTeam(ctx context.Context, teamID uuid.UUID) (int64, error)
 	GetStoriesByTeamID(ctx context.Context, teamIDs []uuid.UUID) ([]*Story, error)
+	GetStoriesByTeamIDPage(ctx context.Context, teamIDs []uuid.UUID, page PageRequest) (*Page[*Story], error)
 	GetStory(ctx context.Context, id uuid.UUID) (*Story, error)
 	CreateStory(ctx context.Context, creator string, newStory *NewStory) (*Story, error)
 	DeleteStory(ctx context.Context, id uuid.UUID) error
+	SoftDeleteStory(ctx context.Context, id uuid.UUID, deletedBy string) error
 	UpdateStory(ctx context.Context, id uuid.UUID, input UpdateStoryDto) (*Story, error)
 }
 
@@ -75,6 +77,14 @@ type Story struct {
 	ProductAreaName string  `json:"productAreaName"`
 }
 
+func (s *Story) ETag() string {
+	if s.LastModified == nil {
+		return ""
+	}
+
+	return ETag(*s.LastModified)
+}
+
 // NewStory contains the metadata and content of data stories.
 type NewStory struct {
 	// id of data story.
@@ -110,6 +120,7 @@ type UpdateStoryDto struct {
 	ProductAreaID    *uuid.UUID `json:"productAreaID"`
 	TeamID           *uuid.UUID `json:"teamID"`
 	Group            string     `json:"group"`
+	Precondition     `json:"-"`
 }
 
 type Object struct {
@@ -10,6 +10,12 @@ import (
 
 type UserService interface {
 	GetUserData(ctx context.Context, user *User) (*UserInfo, error)
+	GetDataproducts(ctx context.Context, user *User, page PageRequest) (*Page[*Dataproduct], error)
+	GetAccessibleDatasets(ctx context.Context, user *User, kind AccessibleDatasetsKind, page PageRequest) (*Page[*AccessibleDataset], error)
+	GetStories(ctx context.Context, user *User, page PageRequest) (*Page[*Story], error)
+	GetInsightProducts(ctx context.Context, user *User, page PageRequest) (*Page[*InsightProduct], error)
+	GetAccessRequests(ctx context.Context, user *User, page PageRequest) (*Page[*AccessRequest], error)
+	GetAccessRequestsAsGranter(ctx context.Context, user *User, page PageRequest) (*Page[*AccessRequestForGranter], error)
 }
 
 type User struct {
@@ -93,22 +99,4 @@ type UserInfo struct {
 
 	// loginExpiration is when the token expires.
 	LoginExpiration time.Time `json:"loginExpiration"`
-
-	// dataproducts is a list of dataproducts with one of the users groups as owner.
-	Dataproducts []Dataproduct `json:"dataproducts"`
-
-	// accessable is a list of datasets which the user has either owns or has explicit access to.
-	Accessable AccessibleDatasets `json:"accessable"`
-
-	// stories is the stories owned by the user's group
-	Stories []*Story `json:"stories"`
-
-	// insight products is the insight products owned by the user's group
-	InsightProducts []InsightProduct `json:"insightProducts"`
-
-	// accessRequests is a list of access requests where either the user or one of the users groups is owner.
-	AccessRequests []AccessRequest `json:"accessRequests"`
-
-	// accessRequestsAsGranter is a list of access requests where one of the users groups is obliged to handle.
-	AccessRequestsAsGranter []AccessRequestForGranter `json:"accessRequestsAsGranter"`
 }
@@ -0,0 +1,183 @@
+package emulator
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// Message is an email received by the emulator
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Emulator is a minimal SMTP server that accepts every email and keeps it
+// in memory, so tests can check what was sent
+type Emulator struct {
+	listener net.Listener
+
+	mu       sync.Mutex
+	messages []*Message
+
+	log zerolog.Logger
+}
+
+func New(log zerolog.Logger) *Emulator {
+	return &Emulator{
+		log: log,
+	}
+}
+
+// Run starts the emulator on a random local port, and returns its host and port
+func (e *Emulator) Run() (string, int) {
+	e.log.Info().Msg("starting smtp emulator")
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+
+	e.listener = listener
+
+	go e.serve()
+
+	addr := listener.Addr().(*net.TCPAddr)
+
+	return addr.IP.String(), addr.Port
+}
+
+func (e *Emulator) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.messages = nil
+}
+
+func (e *Emulator) Close() {
+	_ = e.listener.Close()
+}
+
+func (e *Emulator) Messages() []*Message {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append([]*Message{}, e.messages...)
+}
+
+func (e *Emulator) serve() {
+	for {
+		conn, err := e.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go e.handle(conn)
+	}
+}
+
+func (e *Emulator) handle(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+
+	reply := func(line string) {
+		_, _ = w.WriteString(line + "\r\n")
+		_ = w.Flush()
+	}
+
+	reply("220 localhost SMTP emulator")
+
+	msg := &Message{}
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		switch verb {
+		case "EHLO", "HELO":
+			reply("250 localhost")
+		case "MAIL":
+			msg = &Message{From: address(line)}
+			reply("250 OK")
+		case "RCPT":
+			msg.To = append(msg.To, address(line))
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+
+			var data strings.Builder
+
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+
+				if strings.TrimRight(l, "\r\n") == "." {
+					break
+				}
+
+				data.WriteString(strings.TrimPrefix(l, "."))
+			}
+
+			e.receive(msg, data.String())
+			reply("250 OK")
+		case "RSET", "NOOP":
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func (e *Emulator) receive(msg *Message, data string) {
+	parsed, err := mail.ReadMessage(strings.NewReader(data))
+	if err != nil {
+		e.log.Error().Err(err).Msg("parsing email")
+		return
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil {
+		subject = parsed.Header.Get("Subject")
+	}
+
+	body, _ := io.ReadAll(parsed.Body)
+
+	msg.Subject = subject
+	msg.Body = strings.TrimRight(strings.ReplaceAll(string(body), "\r\n", "\n"), "\n")
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.messages = append(e.messages, msg)
+}
+
+func address(line string) string {
+	i := strings.Index(line, "<")
+	j := strings.LastIndex(line, ">")
+
+	if i < 0 || j < i {
+		return ""
+	}
+
+	return line[i+1 : j]
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/navikt/nada-backend/pkg/auth"
 	"github.com/rs/zerolog"
 
@@ -21,16 +22,20 @@ type Ensurer struct {
 	bigQueryAPI         service.BigQueryAPI
 	bigQueryService     service.BigQueryService
 	joinableViewService service.JoinableViewsService
-
-	googleGroups       *auth.GoogleGroupClient
-	centralDataProject string
-	log                zerolog.Logger
-	errs               *prometheus.CounterVec
+	datasourceStorage   service.DatasourceStorage
+	providers           service.DatasourceProviders
+
+	googleGroups         *auth.GoogleGroupClient
+	centralDataProject   string
+	joinableViewsCleanup bool
+	log                  zerolog.Logger
+	errs                 *prometheus.CounterVec
 }
 
 func NewEnsurer(
 	googleGroups *auth.GoogleGroupClient,
 	centralDataProject string,
+	joinableViewsCleanup bool,
 	errs *prometheus.CounterVec,
 	accessStorage service.AccessStorage,
 	metabaseService service.MetabaseService,
@@ -39,20 +44,25 @@ func NewEnsurer(
 	bigQueryAPI service.BigQueryAPI,
 	bigQueryService service.BigQueryService,
 	joinableViewService service.JoinableViewsService,
+	datasourceStorage service.DatasourceStorage,
+	providers service.DatasourceProviders,
 	log zerolog.Logger,
 ) *Ensurer {
 	return &Ensurer{
-		accessStorage:       accessStorage,
-		metabaseService:     metabaseService,
-		dataProductsStorage: dataProductsStorage,
-		bigQueryStorage:     bigQueryStorage,
-		bigQueryAPI:         bigQueryAPI,
-		bigQueryService:     bigQueryService,
-		joinableViewService: joinableViewService,
-		googleGroups:        googleGroups,
-		centralDataProject:  centralDataProject,
-		log:                 log,
-		errs:                errs,
+		accessStorage:        accessStorage,
+		metabaseService:      metabaseService,
+		dataProductsStorage:  dataProductsStorage,
+		bigQueryStorage:      bigQueryStorage,
+		bigQueryAPI:          bigQueryAPI,
+		bigQueryService:      bigQueryService,
+		joinableViewService:  joinableViewService,
+		datasourceStorage:    datasourceStorage,
+		providers:            providers,
+		googleGroups:         googleGroups,
+		centralDataProject:   centralDataProject,
+		joinableViewsCleanup: joinableViewsCleanup,
+		log:                  log,
+		errs:                 errs,
 	}
 }
 
@@ -77,14 +87,22 @@ func (e *Ensurer) run(ctx context.Context) {
 	}
 
 	for _, entry := range entries {
-		ds, err := e.bigQueryStorage.GetBigqueryDatasource(ctx, entry.DatasetID, false)
+		ref, err := e.datasourceStorage.GetDatasourceRef(ctx, entry.DatasetID)
 		if err != nil {
-			e.log.Error().Err(err).Msg("getting dataproduct datasource for expired access entry")
-			e.errs.WithLabelValues("GetBigqueryDatasource").Inc()
+			e.log.Error().Err(err).Msg("getting dataset datasource for expired access entry")
+			e.errs.WithLabelValues("GetDatasourceRef").Inc()
 			continue
 		}
-		if err := e.bigQueryAPI.Revoke(ctx, ds.ProjectID, ds.Dataset, ds.Table, entry.Subject); err != nil {
-			e.log.Error().Err(err).Msgf("revoking IAM access for %v on %v.%v.%v", entry.Subject, ds.ProjectID, ds.Dataset, ds.Table)
+
+		provider, ok := e.providers.Provider(ref.Type)
+		if !ok {
+			e.log.Error().Msgf("no datasource provider for type %v on dataset %v", ref.Type, entry.DatasetID)
+			e.errs.WithLabelValues("DatasourceProvider").Inc()
+			continue
+		}
+
+		if err := provider.Revoke(ctx, *ref, entry.Subject); err != nil {
+			e.log.Error().Err(err).Msgf("revoking %v access for %v on dataset %v", ref.Type, entry.Subject, entry.DatasetID)
 			e.errs.WithLabelValues("Revoke").Inc()
 			continue
 		}
@@ -102,9 +120,15 @@ func (e *Ensurer) run(ctx context.Context) {
 		}
 	}
 
-	// TODO: enable pseudo feature
-	if true {
-		return
+	if err := e.ensureTablePatternAccesses(ctx); err != nil {
+		e.log.Error().Err(err).Msg("ensuring accesses to table pattern datasources")
+	}
+
+	if e.joinableViewsCleanup {
+		if err := e.joinableViewService.CleanupJoinableViews(ctx); err != nil {
+			e.log.Error().Err(err).Msg("cleaning up expired and orphaned joinable views")
+			e.errs.WithLabelValues("CleanupJoinableViews").Inc()
+		}
 	}
 
 	if err := e.ensureDeleteJoinableViewBQForDeletedDataset(ctx); err != nil {
@@ -120,6 +144,47 @@ func (e *Ensurer) run(ctx context.Context) {
 	}
 }
 
+// ensureTablePatternAccesses grants the active accesses to datasources
+// covering the tables of a dataset that match a pattern. Access to these is
+// given on each of the matching tables, so the tables created after access
+// was granted are only covered once they are granted here.
+func (e *Ensurer) ensureTablePatternAccesses(ctx context.Context) error {
+	accesses, err := e.accessStorage.ListActiveAccessToTablePatternDatasources(ctx)
+	if err != nil {
+		return err
+	}
+
+	refs := map[uuid.UUID]*service.DatasourceRef{}
+
+	for _, a := range accesses {
+		ref, ok := refs[a.DatasetID]
+		if !ok {
+			ref, err = e.datasourceStorage.GetDatasourceRef(ctx, a.DatasetID)
+			if err != nil {
+				e.log.Error().Err(err).Msgf("getting datasource of dataset %v", a.DatasetID)
+				e.errs.WithLabelValues("GetDatasourceRef").Inc()
+				continue
+			}
+
+			refs[a.DatasetID] = ref
+		}
+
+		provider, ok := e.providers.Provider(ref.Type)
+		if !ok {
+			e.log.Error().Msgf("no datasource provider for type %v on dataset %v", ref.Type, a.DatasetID)
+			e.errs.WithLabelValues("DatasourceProvider").Inc()
+			continue
+		}
+
+		if err := provider.Grant(ctx, *ref, a.Subject); err != nil {
+			e.log.Error().Err(err).Msgf("granting %v access for %v on dataset %v", ref.Type, a.Subject, a.DatasetID)
+			e.errs.WithLabelValues("Grant").Inc()
+		}
+	}
+
+	return nil
+}
+
 func (e *Ensurer) ensureDeletePseudoViewBQForDeletedDataset(ctx context.Context) error {
 	pseudoDatasources, err := e.bigQueryStorage.GetPseudoDatasourcesToDelete(ctx)
 	if err != nil {
@@ -164,6 +229,11 @@ func (e *Ensurer) ensureDeleteJoinableViewBQForDeletedDataset(ctx context.Contex
 			e.log.Error().Err(err).Msgf("deleting joinable view with deleted pseudo-datasource %v %v.%v.%v", jvds.JoinableViewName, jvds.BqProjectID, jvds.BqDatasetID, jvds.BqTableID)
 			continue
 		}
+
+		if err := e.joinableViewService.SetJoinableViewDatasourceViewDeleted(ctx, jvds.JoinableViewID, jvds.DatasourceID); err != nil {
+			e.log.Error().Err(err).Msgf("setting joinable view for deleted pseudo-datasource deleted in db, view id: %v", jvds.JoinableViewID)
+			e.errs.WithLabelValues("SetJoinableViewDatasourceViewDeleted").Inc()
+		}
 	}
 
 	return nil
@@ -182,19 +252,11 @@ func (e *Ensurer) ensureJoinableViewAccesses(ctx context.Context) error {
 		return err
 	}
 
-OUTER:
+	shares := map[uuid.UUID][]string{}
+
 	for _, jv := range joinableViews {
 		if hasExpired(jv) {
-			if err := e.bigQueryAPI.DeleteJoinableDataset(ctx, jv.JoinableViewDataset); err != nil {
-				e.log.Error().Err(err).Msgf("deleting expired joinable view dataset %v", jv.JoinableViewDataset)
-				e.errs.WithLabelValues("DeleteExpiredDataset").Inc()
-				continue
-			}
-			if err := e.joinableViewService.SetJoinableViewDeleted(ctx, jv.JoinableViewID); err != nil {
-				e.log.Error().Err(err).Msgf("setting joinable view deleted in db, view id: %v", jv.JoinableViewID)
-				e.errs.WithLabelValues("SetJoinableViewDeleted").Inc()
-				continue
-			}
+			// Expired joinable views are deleted by the cleanup
 			continue
 		}
 
@@ -204,55 +266,85 @@ OUTER:
 			e.log.Error().Err(err).Msgf("getting owner group of dataset: %v", jv.PseudoViewID)
 			return err
 		}
-		userGroups, err := e.googleGroups.Groups(ctx, &jv.Owner)
+
+		accesses, err := e.accessStorage.ListActiveAccessToDataset(ctx, jv.PseudoViewID)
 		if err != nil {
+			e.log.Error().Err(err).Msgf("listing active access to dataset: %v", jv.PseudoViewID)
 			return err
 		}
 
-		for _, userGroup := range userGroups {
-			if userGroup.Email == datasetOwnerGroup {
-				if err := e.bigQueryAPI.Grant(ctx, e.centralDataProject, jv.JoinableViewDataset, joinableViewName, fmt.Sprintf("user:%v", jv.Owner)); err != nil {
-					e.log.Error().Err(err).Msgf("Granting IAM access for %v on %v.%v.%v", jv.Owner, e.centralDataProject, jv.JoinableViewDataset, joinableViewName)
-					e.errs.WithLabelValues("Grant").Inc()
-					continue
-				}
-				continue OUTER
-			}
-		}
-
-		accesses, err := e.accessStorage.ListActiveAccessToDataset(ctx, jv.PseudoViewID)
+		ownerHasAccess, err := e.userHasAccess(ctx, jv.Owner, datasetOwnerGroup, accesses)
 		if err != nil {
-			e.log.Error().Err(err).Msgf("listing active access to dataset: %v", jv.PseudoViewID)
 			return err
 		}
 
-		for _, a := range accesses {
-			subjectParts := strings.Split(a.Subject, ":")
-			if len(subjectParts) != 2 {
-				e.log.Error().Msgf("invalid subject format for %v, should be type:email", a.Subject)
-				continue
-			}
-			subjectWithoutType := subjectParts[1]
-			if subjectWithoutType == jv.Owner {
-				if err := e.bigQueryAPI.Grant(ctx, e.centralDataProject, jv.JoinableViewDataset, joinableViewName, fmt.Sprintf("user:%v", jv.Owner)); err != nil {
-					e.log.Error().Err(err).Msgf("Granting IAM access for %v on %v.%v.%v", jv.Owner, e.centralDataProject, jv.JoinableViewDataset, joinableViewName)
-					e.errs.WithLabelValues("Grant").Inc()
-					continue
-				}
-				continue OUTER
+		e.ensureJoinableViewAccess(ctx, jv, joinableViewName, fmt.Sprintf("user:%v", jv.Owner), ownerHasAccess)
+
+		groups, ok := shares[jv.JoinableViewID]
+		if !ok {
+			groups, err = e.joinableViewService.GetJoinableViewShares(ctx, jv.JoinableViewID)
+			if err != nil {
+				e.log.Error().Err(err).Msgf("getting shares of joinable view: %v", jv.JoinableViewID)
+				return err
 			}
+
+			shares[jv.JoinableViewID] = groups
 		}
 
-		if err := e.bigQueryAPI.Revoke(ctx, e.centralDataProject, jv.JoinableViewDataset, joinableViewName, fmt.Sprintf("user:%v", jv.Owner)); err != nil {
-			e.log.Error().Err(err).Msgf("Revoking IAM access for %v on %v.%v.%v", jv.Owner, e.centralDataProject, jv.JoinableViewDataset, joinableViewName)
-			e.errs.WithLabelValues("Revoke").Inc()
-			continue
+		for _, group := range groups {
+			subject := fmt.Sprintf("group:%v", group)
+			e.ensureJoinableViewAccess(ctx, jv, joinableViewName, subject, group == datasetOwnerGroup || service.HasSubject(accesses, subject))
 		}
 	}
 
 	return nil
 }
 
+// userHasAccess returns true if the user is a member of the group owning the dataset,
+// or has been granted access to the dataset
+func (e *Ensurer) userHasAccess(ctx context.Context, user, datasetOwnerGroup string, accesses []*service.Access) (bool, error) {
+	userGroups, err := e.googleGroups.Groups(ctx, &user)
+	if err != nil {
+		return false, err
+	}
+
+	for _, userGroup := range userGroups {
+		if userGroup.Email == datasetOwnerGroup {
+			return true, nil
+		}
+	}
+
+	for _, a := range accesses {
+		subjectParts := strings.Split(a.Subject, ":")
+		if len(subjectParts) != 2 {
+			e.log.Error().Msgf("invalid subject format for %v, should be type:email", a.Subject)
+			continue
+		}
+
+		if subjectParts[1] == user {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (e *Ensurer) ensureJoinableViewAccess(ctx context.Context, jv service.JoinableViewWithReference, joinableViewName, subject string, hasAccess bool) {
+	if hasAccess {
+		if err := e.bigQueryAPI.Grant(ctx, e.centralDataProject, jv.JoinableViewDataset, joinableViewName, subject); err != nil {
+			e.log.Error().Err(err).Msgf("Granting IAM access for %v on %v.%v.%v", subject, e.centralDataProject, jv.JoinableViewDataset, joinableViewName)
+			e.errs.WithLabelValues("Grant").Inc()
+		}
+
+		return
+	}
+
+	if err := e.bigQueryAPI.Revoke(ctx, e.centralDataProject, jv.JoinableViewDataset, joinableViewName, subject); err != nil {
+		e.log.Error().Err(err).Msgf("Revoking IAM access for %v on %v.%v.%v", subject, e.centralDataProject, jv.JoinableViewDataset, joinableViewName)
+		e.errs.WithLabelValues("Revoke").Inc()
+	}
+}
+
 func hasExpired(jv service.JoinableViewWithReference) bool {
 	if jv.Expires.Valid {
 		return jv.Expires.Time.Before(time.Now())
@@ -0,0 +1,211 @@
+package access_ensurer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core/api/gcp"
+	"github.com/navikt/nada-backend/pkg/syncers/access_ensurer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAccessStorage struct {
+	service.AccessStorage
+	mock.Mock
+}
+
+func (m *MockAccessStorage) GetUnrevokedExpiredAccess(ctx context.Context) ([]*service.Access, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*service.Access), args.Error(1)
+}
+
+func (m *MockAccessStorage) ListActiveAccessToTablePatternDatasources(ctx context.Context) ([]*service.Access, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*service.Access), args.Error(1)
+}
+
+type MockDatasourceStorage struct {
+	service.DatasourceStorage
+	mock.Mock
+}
+
+func (m *MockDatasourceStorage) GetDatasourceRef(ctx context.Context, datasetID uuid.UUID) (*service.DatasourceRef, error) {
+	args := m.Called(ctx, datasetID)
+	return args.Get(0).(*service.DatasourceRef), args.Error(1)
+}
+
+type MockBigQueryAPI struct {
+	service.BigQueryAPI
+	mock.Mock
+}
+
+func (m *MockBigQueryAPI) GetTables(ctx context.Context, projectID, datasetID string) ([]*service.BigQueryTable, error) {
+	args := m.Called(ctx, projectID, datasetID)
+	return args.Get(0).([]*service.BigQueryTable), args.Error(1)
+}
+
+func (m *MockBigQueryAPI) Grant(ctx context.Context, projectID, datasetID, tableID, member string) error {
+	args := m.Called(ctx, projectID, datasetID, tableID, member)
+	return args.Error(0)
+}
+
+type MockBigQueryStorage struct {
+	service.BigQueryStorage
+	mock.Mock
+}
+
+func (m *MockBigQueryStorage) GetPseudoDatasourcesToDelete(ctx context.Context) ([]*service.BigQuery, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*service.BigQuery), args.Error(1)
+}
+
+type MockJoinableViewsService struct {
+	service.JoinableViewsService
+	mock.Mock
+}
+
+func (m *MockJoinableViewsService) GetJoinableViewsToBeDeletedWithRefDatasource(ctx context.Context) ([]service.JoinableViewToBeDeletedWithRefDatasource, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]service.JoinableViewToBeDeletedWithRefDatasource), args.Error(1)
+}
+
+func (m *MockJoinableViewsService) GetJoinableViewsWithReference(ctx context.Context) ([]service.JoinableViewWithReference, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]service.JoinableViewWithReference), args.Error(1)
+}
+
+func (m *MockJoinableViewsService) CleanupJoinableViews(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func newJoinableViewsService() *MockJoinableViewsService {
+	joinableViewsService := new(MockJoinableViewsService)
+	joinableViewsService.On("GetJoinableViewsToBeDeletedWithRefDatasource", mock.Anything).Return([]service.JoinableViewToBeDeletedWithRefDatasource{}, nil)
+	joinableViewsService.On("GetJoinableViewsWithReference", mock.Anything).Return([]service.JoinableViewWithReference{}, nil)
+
+	return joinableViewsService
+}
+
+func newBigQueryStorage() *MockBigQueryStorage {
+	bigQueryStorage := new(MockBigQueryStorage)
+	bigQueryStorage.On("GetPseudoDatasourcesToDelete", mock.Anything).Return([]*service.BigQuery{}, nil)
+
+	return bigQueryStorage
+}
+
+func TestEnsurerGrantsAccessToNewTablesMatchingPattern(t *testing.T) {
+	datasetID := uuid.New()
+	subject := "group:team@nav.no"
+
+	accessStorage := new(MockAccessStorage)
+	accessStorage.On("GetUnrevokedExpiredAccess", mock.Anything).Return([]*service.Access{}, nil)
+	accessStorage.On("ListActiveAccessToTablePatternDatasources", mock.Anything).Return([]*service.Access{
+		{DatasetID: datasetID, Subject: subject},
+	}, nil)
+
+	datasourceStorage := new(MockDatasourceStorage)
+	datasourceStorage.On("GetDatasourceRef", mock.Anything, datasetID).Return(&service.DatasourceRef{
+		Type:         service.DatasourceTypeBigQuery,
+		DatasetID:    datasetID,
+		ProjectID:    "project",
+		Dataset:      "dataset",
+		Scope:        service.BigQueryScopeDataset,
+		TablePattern: "events_*",
+	}, nil)
+
+	done := make(chan struct{})
+
+	api := new(MockBigQueryAPI)
+	api.On("GetTables", mock.Anything, "project", "dataset").Return([]*service.BigQueryTable{
+		{Name: "events_2024"},
+		{Name: "other"},
+		{Name: "events_2025"},
+	}, nil)
+	api.On("Grant", mock.Anything, "project", "dataset", "events_2024", subject).Return(nil)
+	api.On("Grant", mock.Anything, "project", "dataset", "events_2025", subject).Return(nil).Run(func(mock.Arguments) {
+		close(done)
+	})
+
+	ensurer := access_ensurer.NewEnsurer(
+		nil,
+		"central-project",
+		false,
+		prometheus.NewCounterVec(prometheus.CounterOpts{Name: "errors"}, []string{"location"}),
+		accessStorage,
+		nil,
+		nil,
+		newBigQueryStorage(),
+		api,
+		nil,
+		newJoinableViewsService(),
+		datasourceStorage,
+		service.NewDatasourceProviders(gcp.NewBigQueryDatasourceProvider(api)),
+		zerolog.Nop(),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go ensurer.Run(ctx, time.Hour)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for access to be granted on the new table")
+	}
+
+	api.AssertExpectations(t)
+	api.AssertNotCalled(t, "Grant", mock.Anything, "project", "dataset", "other", subject)
+}
+
+func TestEnsurerEnsuresJoinableViewAccessesWithoutCleanup(t *testing.T) {
+	accessStorage := new(MockAccessStorage)
+	accessStorage.On("GetUnrevokedExpiredAccess", mock.Anything).Return([]*service.Access{}, nil)
+	accessStorage.On("ListActiveAccessToTablePatternDatasources", mock.Anything).Return([]*service.Access{}, nil)
+
+	bigQueryStorage := new(MockBigQueryStorage)
+	joinableViewsService := newJoinableViewsService()
+
+	done := make(chan struct{})
+
+	bigQueryStorage.On("GetPseudoDatasourcesToDelete", mock.Anything).Return([]*service.BigQuery{}, nil).Run(func(mock.Arguments) {
+		close(done)
+	})
+
+	ensurer := access_ensurer.NewEnsurer(
+		nil,
+		"central-project",
+		false,
+		prometheus.NewCounterVec(prometheus.CounterOpts{Name: "errors"}, []string{"location"}),
+		accessStorage,
+		nil,
+		nil,
+		bigQueryStorage,
+		nil,
+		nil,
+		joinableViewsService,
+		nil,
+		nil,
+		zerolog.Nop(),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go ensurer.Run(ctx, time.Hour)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the joinable views to be ensured")
+	}
+
+	joinableViewsService.AssertExpectations(t)
+	joinableViewsService.AssertNotCalled(t, "CleanupJoinableViews", mock.Anything)
+}
@@ -0,0 +1,50 @@
+package access_recertification
+
+import (
+	"context"
+	"time"
+
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/rs/zerolog"
+)
+
+// Syncer reminds the owners of pending recertification reviews, and closes
+// the campaigns that have passed their deadline
+type Syncer struct {
+	service service.RecertificationService
+	log     zerolog.Logger
+}
+
+func New(service service.RecertificationService, log zerolog.Logger) *Syncer {
+	return &Syncer{
+		service: service,
+		log:     log,
+	}
+}
+
+func (s *Syncer) Run(ctx context.Context, frequency time.Duration) {
+	s.log.Info().Msg("Starting access recertification syncer")
+
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	s.RunOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RunOnce(ctx)
+		}
+	}
+}
+
+func (s *Syncer) RunOnce(ctx context.Context) {
+	s.log.Info().Msg("Processing access recertification campaigns...")
+
+	err := s.service.ProcessCampaigns(ctx)
+	if err != nil {
+		s.log.Error().Err(err).Msg("processing access recertification campaigns")
+	}
+}
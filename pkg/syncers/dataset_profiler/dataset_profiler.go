@@ -0,0 +1,50 @@
+package dataset_profiler
+
+import (
+	"context"
+	"time"
+
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/rs/zerolog"
+)
+
+// Syncer profiles the columns of the registered datasources, within the
+// budget of bytes processed for each run
+type Syncer struct {
+	service service.ProfilingService
+	log     zerolog.Logger
+}
+
+func New(service service.ProfilingService, log zerolog.Logger) *Syncer {
+	return &Syncer{
+		service: service,
+		log:     log,
+	}
+}
+
+func (s *Syncer) Run(ctx context.Context, frequency time.Duration) {
+	s.log.Info().Msg("Starting dataset profiler")
+
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	s.RunOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RunOnce(ctx)
+		}
+	}
+}
+
+func (s *Syncer) RunOnce(ctx context.Context) {
+	s.log.Info().Msg("Profiling datasources...")
+
+	err := s.service.ProfileDatasources(ctx)
+	if err != nil {
+		s.log.Error().Err(err).Msg("profiling datasources")
+	}
+}
@@ -0,0 +1,50 @@
+package dataset_sunset
+
+import (
+	"context"
+	"time"
+
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/rs/zerolog"
+)
+
+// Syncer retires the dataproducts and datasets that have passed their
+// sunset date
+type Syncer struct {
+	service service.LifecycleService
+	log     zerolog.Logger
+}
+
+func New(service service.LifecycleService, log zerolog.Logger) *Syncer {
+	return &Syncer{
+		service: service,
+		log:     log,
+	}
+}
+
+func (s *Syncer) Run(ctx context.Context, frequency time.Duration) {
+	s.log.Info().Msg("Starting dataset sunset syncer")
+
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	s.RunOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RunOnce(ctx)
+		}
+	}
+}
+
+func (s *Syncer) RunOnce(ctx context.Context) {
+	s.log.Info().Msg("Retiring datasets past their sunset date...")
+
+	err := s.service.RetireSunsetDatasets(ctx)
+	if err != nil {
+		s.log.Error().Err(err).Msg("retiring datasets past their sunset date")
+	}
+}
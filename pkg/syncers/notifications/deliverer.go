@@ -0,0 +1,48 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/rs/zerolog"
+)
+
+// Deliverer sends the notifications queued for delivery on Slack and email,
+// so that the requests creating them do not wait for Slack or SMTP
+type Deliverer struct {
+	service service.NotificationService
+	log     zerolog.Logger
+}
+
+func NewDeliverer(service service.NotificationService, log zerolog.Logger) *Deliverer {
+	return &Deliverer{
+		service: service,
+		log:     log,
+	}
+}
+
+func (d *Deliverer) Run(ctx context.Context, frequency time.Duration) {
+	d.log.Info().Msg("Starting notification deliverer")
+
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	d.RunOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.RunOnce(ctx)
+		}
+	}
+}
+
+func (d *Deliverer) RunOnce(ctx context.Context) {
+	err := d.service.DeliverNotifications(ctx)
+	if err != nil {
+		d.log.Error().Err(err).Msg("delivering notifications")
+	}
+}
@@ -0,0 +1,55 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/rs/zerolog"
+)
+
+// Syncer notifies about access that expires soon, and sends the daily
+// digests of notifications
+type Syncer struct {
+	service service.NotificationService
+	log     zerolog.Logger
+}
+
+func New(service service.NotificationService, log zerolog.Logger) *Syncer {
+	return &Syncer{
+		service: service,
+		log:     log,
+	}
+}
+
+func (s *Syncer) Run(ctx context.Context, frequency time.Duration) {
+	s.log.Info().Msg("Starting notifications syncer")
+
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	s.RunOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RunOnce(ctx)
+		}
+	}
+}
+
+func (s *Syncer) RunOnce(ctx context.Context) {
+	s.log.Info().Msg("Notifying about expiring access and sending digests...")
+
+	err := s.service.NotifyExpiringAccess(ctx)
+	if err != nil {
+		s.log.Error().Err(err).Msg("notifying about expiring access")
+	}
+
+	err = s.service.SendDigests(ctx)
+	if err != nil {
+		s.log.Error().Err(err).Msg("sending notification digests")
+	}
+}
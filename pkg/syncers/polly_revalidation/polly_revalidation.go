@@ -0,0 +1,50 @@
+package polly_revalidation
+
+import (
+	"context"
+	"time"
+
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/rs/zerolog"
+)
+
+// Syncer re-fetches the purposes of the grants from the treatment catalogue,
+// and alerts the dataset owners when the legal basis is no longer valid
+type Syncer struct {
+	service service.PollyService
+	log     zerolog.Logger
+}
+
+func New(service service.PollyService, log zerolog.Logger) *Syncer {
+	return &Syncer{
+		service: service,
+		log:     log,
+	}
+}
+
+func (s *Syncer) Run(ctx context.Context, frequency time.Duration) {
+	s.log.Info().Msg("Starting polly revalidation syncer")
+
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	s.RunOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RunOnce(ctx)
+		}
+	}
+}
+
+func (s *Syncer) RunOnce(ctx context.Context) {
+	s.log.Info().Msg("Revalidating polly purposes...")
+
+	err := s.service.RevalidatePurposes(ctx)
+	if err != nil {
+		s.log.Error().Err(err).Msg("revalidating polly purposes")
+	}
+}
@@ -0,0 +1,50 @@
+package quality_checks
+
+import (
+	"context"
+	"time"
+
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/rs/zerolog"
+)
+
+// Syncer runs the data quality checks of the datasets, and stores the
+// result of each run
+type Syncer struct {
+	service service.QualityCheckService
+	log     zerolog.Logger
+}
+
+func New(service service.QualityCheckService, log zerolog.Logger) *Syncer {
+	return &Syncer{
+		service: service,
+		log:     log,
+	}
+}
+
+func (s *Syncer) Run(ctx context.Context, frequency time.Duration) {
+	s.log.Info().Msg("Starting quality checks runner")
+
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	s.RunOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RunOnce(ctx)
+		}
+	}
+}
+
+func (s *Syncer) RunOnce(ctx context.Context) {
+	s.log.Info().Msg("Running quality checks...")
+
+	err := s.service.RunQualityChecks(ctx)
+	if err != nil {
+		s.log.Error().Err(err).Msg("running quality checks")
+	}
+}
@@ -0,0 +1,50 @@
+package recycle_bin
+
+import (
+	"context"
+	"time"
+
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/rs/zerolog"
+)
+
+// Syncer purges the dataproducts, stories and insight products that have
+// been in the recycle bin for longer than the retention period
+type Syncer struct {
+	service service.RecycleBinService
+	log     zerolog.Logger
+}
+
+func New(service service.RecycleBinService, log zerolog.Logger) *Syncer {
+	return &Syncer{
+		service: service,
+		log:     log,
+	}
+}
+
+func (s *Syncer) Run(ctx context.Context, frequency time.Duration) {
+	s.log.Info().Msg("Starting recycle bin purger")
+
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	s.RunOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RunOnce(ctx)
+		}
+	}
+}
+
+func (s *Syncer) RunOnce(ctx context.Context) {
+	s.log.Info().Msg("Purging expired items from the recycle bin...")
+
+	err := s.service.PurgeDeletedItems(ctx)
+	if err != nil {
+		s.log.Error().Err(err).Msg("purging expired items from the recycle bin")
+	}
+}
@@ -0,0 +1,144 @@
+// Package xlsx writes spreadsheets in the Office Open XML format, with a
+// single worksheet of text cells, which is what is needed for exporting
+// reports to Excel.
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const (
+	contentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`
+
+	rootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+	workbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`
+
+	workbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+)
+
+// ContentType is the media type of the spreadsheets
+const ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// MaxSheetNameLength is the longest worksheet name Excel accepts
+const MaxSheetNameLength = 31
+
+// Write writes a workbook with one worksheet, where each row is written as
+// a row of text cells
+func Write(w io.Writer, sheet string, rows [][]string) error {
+	if len(sheet) == 0 || len(sheet) > MaxSheetNameLength {
+		return fmt.Errorf("sheet name must be between 1 and %d characters", MaxSheetNameLength)
+	}
+
+	zw := zip.NewWriter(w)
+
+	sheetName, err := escape(sheet)
+	if err != nil {
+		return err
+	}
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", rootRels},
+		{"xl/workbook.xml", fmt.Sprintf(workbook, sheetName)},
+		{"xl/_rels/workbook.xml.rels", workbookRels},
+	}
+
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return fmt.Errorf("creating %s: %w", f.name, err)
+		}
+
+		if _, err := io.WriteString(fw, f.content); err != nil {
+			return fmt.Errorf("writing %s: %w", f.name, err)
+		}
+	}
+
+	fw, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return fmt.Errorf("creating worksheet: %w", err)
+	}
+
+	if err := writeSheet(fw, rows); err != nil {
+		return fmt.Errorf("writing worksheet: %w", err)
+	}
+
+	return zw.Close()
+}
+
+func writeSheet(w io.Writer, rows [][]string) error {
+	var buf bytes.Buffer
+
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	buf.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	for i, row := range rows {
+		r := strconv.Itoa(i + 1)
+
+		buf.WriteString(`<row r="` + r + `">`)
+
+		for j, value := range row {
+			text, err := escape(value)
+			if err != nil {
+				return err
+			}
+
+			buf.WriteString(`<c r="` + ColumnName(j) + r + `" t="inlineStr"><is><t xml:space="preserve">`)
+			buf.WriteString(text)
+			buf.WriteString(`</t></is></c>`)
+		}
+
+		buf.WriteString(`</row>`)
+	}
+
+	buf.WriteString(`</sheetData></worksheet>`)
+
+	_, err := w.Write(buf.Bytes())
+
+	return err
+}
+
+// ColumnName returns the name of the zero indexed column, e.g., A, Z, AA
+func ColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+
+	return name
+}
+
+func escape(s string) (string, error) {
+	var buf bytes.Buffer
+
+	if err := xml.EscapeText(&buf, []byte(s)); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,97 @@
+package xlsx_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/navikt/nada-backend/pkg/xlsx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnName(t *testing.T) {
+	testCases := []struct {
+		name   string
+		index  int
+		expect string
+	}{
+		{name: "First column", index: 0, expect: "A"},
+		{name: "Last single letter column", index: 25, expect: "Z"},
+		{name: "First double letter column", index: 26, expect: "AA"},
+		{name: "Double letter column", index: 27, expect: "AB"},
+		{name: "Last double letter column", index: 701, expect: "ZZ"},
+		{name: "First triple letter column", index: 702, expect: "AAA"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, xlsx.ColumnName(tc.index))
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	testCases := []struct {
+		name      string
+		sheet     string
+		rows      [][]string
+		expect    []string
+		expectErr bool
+	}{
+		{
+			name:  "Writes the rows as inline strings",
+			sheet: "Rapport",
+			rows: [][]string{
+				{"Datasett", "Tilgang"},
+				{"fnr & navn", "<user:bob@nav.no>"},
+			},
+			expect: []string{
+				`<c r="A1" t="inlineStr"><is><t xml:space="preserve">Datasett</t></is></c>`,
+				`<c r="B2" t="inlineStr"><is><t xml:space="preserve">&lt;user:bob@nav.no&gt;</t></is></c>`,
+				`<c r="A2" t="inlineStr"><is><t xml:space="preserve">fnr &amp; navn</t></is></c>`,
+			},
+		},
+		{
+			name:      "Sheet name too long",
+			sheet:     "A sheet name that is too long for Excel",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := xlsx.Write(&buf, tc.sheet, tc.rows)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			require.NoError(t, err)
+
+			files := map[string]string{}
+			for _, f := range zr.File {
+				rc, err := f.Open()
+				require.NoError(t, err)
+
+				content, err := io.ReadAll(rc)
+				require.NoError(t, err)
+				rc.Close()
+
+				files[f.Name] = string(content)
+			}
+
+			assert.Contains(t, files, "[Content_Types].xml")
+			assert.Contains(t, files["xl/workbook.xml"], `<sheet name="`+tc.sheet+`"`)
+
+			for _, e := range tc.expect {
+				assert.Contains(t, files["xl/worksheets/sheet1.xml"], e)
+			}
+		})
+	}
+}
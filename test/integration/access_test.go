@@ -14,6 +14,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
 	"github.com/navikt/nada-backend/pkg/config/v2"
 	"github.com/navikt/nada-backend/pkg/sa"
 	serviceAccountEmulator "github.com/navikt/nada-backend/pkg/sa/emulator"
@@ -27,6 +28,7 @@ import (
 	"github.com/navikt/nada-backend/pkg/bq"
 	bigQueryEmulator "github.com/navikt/nada-backend/pkg/bq/emulator"
 	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/errs"
 	"github.com/navikt/nada-backend/pkg/service/core"
 	"github.com/navikt/nada-backend/pkg/service/core/api/gcp"
 	"github.com/navikt/nada-backend/pkg/service/core/api/http"
@@ -113,6 +115,14 @@ func TestAccess(t *testing.T) {
 		log,
 	)
 
+	policyService := core.NewPolicyService(
+		stores.PolicyStorage,
+		stores.DataProductsStorage,
+		stores.AccessStorage,
+		GroupEmailNada,
+		"group:"+GroupEmailAllUsers,
+	)
+
 	mbService := core.NewMetabaseService(
 		Project,
 		fakeMetabaseSA,
@@ -121,11 +131,14 @@ func TestAccess(t *testing.T) {
 		mbapi,
 		bqapi,
 		saapi,
+		service.NewDatasourceProviders(gcp.NewBigQueryDatasourceProvider(bqapi)),
 		stores.ThirdPartyMappingStorage,
 		stores.MetaBaseStorage,
 		stores.BigQueryStorage,
 		stores.DataProductsStorage,
 		stores.AccessStorage,
+		stores.DatasourceStorage,
+		policyService,
 		zlog,
 	)
 
@@ -142,6 +155,10 @@ func TestAccess(t *testing.T) {
 		stores.BigQueryStorage,
 		bqapi,
 		stores.NaisConsoleStorage,
+		stores.AccessStorage,
+		mbService,
+		stores.DatasourceStorage,
+		service.NewDatasourceProviders(gcp.NewBigQueryDatasourceProvider(bqapi)),
 		GroupEmailAllUsers,
 	)
 
@@ -185,19 +202,31 @@ func TestAccess(t *testing.T) {
 		fDatasetOwnerRoutes(datasetOwnerRouter)
 	}
 
+	notificationService := core.NewNotificationService(
+		"https://data.nav.no",
+		stores.NotificationStorage,
+		static.NewSlackAPI(log),
+		static.NewEmailAPI(log),
+		log,
+	)
+
+	accessService := core.NewAccessService(
+		"https://data.nav.no",
+		notificationService,
+		policyService,
+		stores.AccessApprovalRuleStorage,
+		stores.PollyStorage,
+		stores.AccessStorage,
+		stores.DataProductsStorage,
+		stores.BigQueryStorage,
+		stores.JoinableViewsStorage,
+		bqapi,
+		stores.DatasourceStorage,
+		service.NewDatasourceProviders(gcp.NewBigQueryDatasourceProvider(bqapi)),
+	)
+
 	{
-		slack := static.NewSlackAPI(log)
-		s := core.NewAccessService(
-			"https://data.nav.no",
-			slack,
-			stores.PollyStorage,
-			stores.AccessStorage,
-			stores.DataProductsStorage,
-			stores.BigQueryStorage,
-			stores.JoinableViewsStorage,
-			bqapi,
-		)
-		h := handlers.NewAccessHandler(s, mbService, Project)
+		h := handlers.NewAccessHandler(accessService, mbService, Project)
 		e := routes.NewAccessEndpoints(zlog, h)
 		fDatasetOwnerRoutes := routes.NewAccessRoutes(e, injectUser(UserOne))
 		fAccessRequesterRoutes := routes.NewAccessRoutes(e, injectUser(UserTwo))
@@ -206,6 +235,57 @@ func TestAccess(t *testing.T) {
 		fAccessRequesterRoutes(accessRequesterRouter)
 	}
 
+	{
+		s := core.NewAccessApprovalRuleService(stores.AccessApprovalRuleStorage, stores.DataProductsStorage)
+		h := handlers.NewAccessApprovalRulesHandler(s)
+		e := routes.NewAccessApprovalRulesEndpoints(zlog, h)
+		routes.NewAccessApprovalRulesRoutes(e, injectUser(UserOne))(datasetOwnerRouter)
+		routes.NewAccessApprovalRulesRoutes(e, injectUser(UserTwo))(accessRequesterRouter)
+	}
+
+	{
+		h := handlers.NewPoliciesHandler(policyService)
+		e := routes.NewPoliciesEndpoints(zlog, h)
+		routes.NewPoliciesRoutes(e, injectUser(UserOne))(datasetOwnerRouter)
+		routes.NewPoliciesRoutes(e, injectUser(UserTwo))(accessRequesterRouter)
+	}
+
+	{
+		s := core.NewRecertificationService(
+			stores.RecertificationStorage,
+			stores.DataProductsStorage,
+			stores.AccessStorage,
+			accessService,
+			notificationService,
+			GroupEmailNada,
+			Project,
+			log,
+		)
+		h := handlers.NewRecertificationHandler(s)
+		e := routes.NewRecertificationEndpoints(zlog, h)
+		routes.NewRecertificationRoutes(e, injectUser(UserOne))(datasetOwnerRouter)
+		routes.NewRecertificationRoutes(e, injectUser(UserTwo))(accessRequesterRouter)
+	}
+
+	pollyAPI := &pollyAPIFake{
+		processes: map[string]*service.PollyProcess{
+			"polly-ny": {
+				ExternalID: "polly-ny",
+				Name:       "Ny statistikk",
+				LegalBases: []string{"Folketrygdloven § 21-4"},
+			},
+		},
+	}
+
+	pollyService := core.NewPollyService(stores.PollyStorage, pollyAPI, notificationService, log)
+
+	{
+		h := handlers.NewPollyHandler(pollyService)
+		e := routes.NewPollyEndpoints(zlog, h)
+		routes.NewPollyRoutes(e, injectUser(UserOne))(datasetOwnerRouter)
+		routes.NewPollyRoutes(e, injectUser(UserTwo))(accessRequesterRouter)
+	}
+
 	datasetOwnerServer := httptest.NewServer(datasetOwnerRouter)
 	defer datasetOwnerServer.Close()
 
@@ -222,8 +302,8 @@ func TestAccess(t *testing.T) {
 			}, "/api/accessRequests/new").
 			HasStatusCode(http2.StatusNoContent)
 
-		expect := &service.AccessRequestsWrapper{
-			AccessRequests: []*service.AccessRequest{
+		expect := &service.Page[*service.AccessRequest]{
+			Items: []*service.AccessRequest{
 				{
 					DatasetID:   fuelData.ID,
 					Subject:     UserTwoEmail,
@@ -233,25 +313,25 @@ func TestAccess(t *testing.T) {
 				},
 			},
 		}
-		got := &service.AccessRequestsWrapper{}
+		got := &service.Page[*service.AccessRequest]{}
 
 		NewTester(t, datasetOwnerServer).Get("/api/accessRequests", "datasetId", fuelData.ID.String()).
 			HasStatusCode(http2.StatusOK).
 			Value(got)
 
-		require.Len(t, got.AccessRequests, 1)
-		diff := cmp.Diff(expect.AccessRequests[0], got.AccessRequests[0], cmpopts.IgnoreFields(service.AccessRequest{}, "ID", "Created"))
+		require.Len(t, got.Items, 1)
+		diff := cmp.Diff(expect.Items[0], got.Items[0], cmpopts.IgnoreFields(service.AccessRequest{}, "ID", "Created"))
 		assert.Empty(t, diff)
 	})
 
 	existingAR := &service.AccessRequest{}
 	t.Run("Approve dataset access request", func(t *testing.T) {
-		existingARs := &service.AccessRequestsWrapper{}
+		existingARs := &service.Page[*service.AccessRequest]{}
 		NewTester(t, datasetOwnerServer).Get("/api/accessRequests", "datasetId", fuelData.ID.String()).
 			HasStatusCode(http2.StatusOK).
 			Value(existingARs)
 
-		existingAR = existingARs.AccessRequests[0]
+		existingAR = existingARs.Items[0]
 
 		NewTester(t, datasetOwnerServer).Post(nil, fmt.Sprintf("/api/accessRequests/process/%v", existingAR.ID), "action", "approve").
 			HasStatusCode(http2.StatusNoContent)
@@ -279,21 +359,21 @@ func TestAccess(t *testing.T) {
 	})
 
 	t.Run("Delete dataset access request", func(t *testing.T) {
-		got := &service.UserInfo{}
-		NewTester(t, accessRequesterServer).Get("/api/userData").
+		got := &service.Page[*service.AccessRequest]{}
+		NewTester(t, accessRequesterServer).Get("/api/userData/accessRequests").
 			HasStatusCode(http2.StatusOK).
 			Value(got)
 
-		require.Len(t, got.AccessRequests, 1)
+		require.Len(t, got.Items, 1)
 
 		NewTester(t, accessRequesterServer).Delete(fmt.Sprintf("/api/accessRequests/%v", existingAR.ID)).
 			HasStatusCode(http2.StatusNoContent)
 
-		NewTester(t, accessRequesterServer).Get("/api/userData").
+		NewTester(t, accessRequesterServer).Get("/api/userData/accessRequests").
 			HasStatusCode(http2.StatusOK).
 			Value(got)
 
-		require.Len(t, got.AccessRequests, 0)
+		require.Len(t, got.Items, 0)
 	})
 
 	t.Run("Deny dataset access request", func(t *testing.T) {
@@ -307,18 +387,18 @@ func TestAccess(t *testing.T) {
 			}, "/api/accessRequests/new").
 			HasStatusCode(http2.StatusNoContent)
 
-		existingARs := &service.AccessRequestsWrapper{}
+		existingARs := &service.Page[*service.AccessRequest]{}
 		NewTester(t, datasetOwnerServer).Get("/api/accessRequests", "datasetId", fuelData.ID.String()).
 			HasStatusCode(http2.StatusOK).
 			Value(existingARs)
 
-		ar := existingARs.AccessRequests[0]
+		ar := existingARs.Items[0]
 
 		NewTester(t, datasetOwnerServer).Post(nil, fmt.Sprintf("/api/accessRequests/process/%v", ar.ID), "action", "deny", "reason", url.QueryEscape(denyReason)).
 			HasStatusCode(http2.StatusNoContent)
 
-		expect := &service.UserInfo{
-			AccessRequests: []service.AccessRequest{
+		expect := &service.Page[*service.AccessRequest]{
+			Items: []*service.AccessRequest{
 				{
 					ID:          ar.ID,
 					DatasetID:   ar.DatasetID,
@@ -332,23 +412,23 @@ func TestAccess(t *testing.T) {
 			},
 		}
 
-		got := &service.UserInfo{}
-		NewTester(t, accessRequesterServer).Get("/api/userData").
+		got := &service.Page[*service.AccessRequest]{}
+		NewTester(t, accessRequesterServer).Get("/api/userData/accessRequests").
 			HasStatusCode(http2.StatusOK).
 			Value(got)
 
-		require.Len(t, got.AccessRequests, 1)
-		diff := cmp.Diff(expect.AccessRequests[0], got.AccessRequests[0], cmpopts.IgnoreFields(service.AccessRequest{}, "Created", "Closed"))
+		require.Len(t, got.Items, 1)
+		diff := cmp.Diff(expect.Items[0], got.Items[0], cmpopts.IgnoreFields(service.AccessRequest{}, "Created", "Closed"))
 		assert.Empty(t, diff)
 
 		NewTester(t, accessRequesterServer).Delete(fmt.Sprintf("/api/accessRequests/%v", ar.ID)).
 			HasStatusCode(http2.StatusNoContent)
 
-		NewTester(t, accessRequesterServer).Get("/api/userData").
+		NewTester(t, accessRequesterServer).Get("/api/userData/accessRequests").
 			HasStatusCode(http2.StatusOK).
 			Value(got)
 
-		require.Len(t, got.AccessRequests, 0)
+		require.Len(t, got.Items, 0)
 	})
 
 	t.Run("Grant dataset access request for service account", func(t *testing.T) {
@@ -363,18 +443,18 @@ func TestAccess(t *testing.T) {
 			}, "/api/accessRequests/new").
 			HasStatusCode(http2.StatusNoContent)
 
-		existingARs := &service.AccessRequestsWrapper{}
+		existingARs := &service.Page[*service.AccessRequest]{}
 		NewTester(t, datasetOwnerServer).Get("/api/accessRequests", "datasetId", fuelData.ID.String()).
 			HasStatusCode(http2.StatusOK).
 			Value(existingARs)
 
-		ar := existingARs.AccessRequests[0]
+		ar := existingARs.Items[0]
 
 		NewTester(t, datasetOwnerServer).Post(nil, fmt.Sprintf("/api/accessRequests/process/%v", ar.ID), "action", "approve").
 			HasStatusCode(http2.StatusNoContent)
 
-		expect := &service.UserInfo{
-			AccessRequests: []service.AccessRequest{
+		expect := &service.Page[*service.AccessRequest]{
+			Items: []*service.AccessRequest{
 				{
 					ID:          ar.ID,
 					DatasetID:   ar.DatasetID,
@@ -385,31 +465,565 @@ func TestAccess(t *testing.T) {
 					Status:      service.AccessRequestStatusApproved,
 				},
 			},
-			Accessable: service.AccessibleDatasets{
-				ServiceAccountGranted: []*service.AccessibleDataset{
-					{
-						Subject: strToStrPtr("serviceAccount:" + serviceaccountName),
-						Dataset: service.Dataset{
-							ID:            fuelData.ID,
-							DataproductID: fuel.ID,
-						},
-					},
+		}
+
+		expectGranted := []*service.AccessibleDataset{
+			{
+				Subject: strToStrPtr("serviceAccount:" + serviceaccountName),
+				Dataset: service.Dataset{
+					ID:            fuelData.ID,
+					DataproductID: fuel.ID,
 				},
 			},
 		}
 
-		got := &service.UserInfo{}
-		NewTester(t, accessRequesterServer).Get("/api/userData").
+		got := &service.Page[*service.AccessRequest]{}
+		NewTester(t, accessRequesterServer).Get("/api/userData/accessRequests").
 			HasStatusCode(http2.StatusOK).
 			Value(got)
 
-		require.Len(t, got.AccessRequests, 1)
-		diff := cmp.Diff(expect.AccessRequests[0], got.AccessRequests[0], cmpopts.IgnoreFields(service.AccessRequest{}, "Created", "Closed"))
+		require.Len(t, got.Items, 1)
+		diff := cmp.Diff(expect.Items[0], got.Items[0], cmpopts.IgnoreFields(service.AccessRequest{}, "Created", "Closed"))
 		assert.Empty(t, diff)
 
-		require.Len(t, got.Accessable.ServiceAccountGranted, 1)
-		assert.Equal(t, *expect.Accessable.ServiceAccountGranted[0].Subject, *got.Accessable.ServiceAccountGranted[0].Subject)
-		assert.Equal(t, expect.Accessable.ServiceAccountGranted[0].DataproductID, got.Accessable.ServiceAccountGranted[0].DataproductID)
-		assert.Equal(t, expect.Accessable.ServiceAccountGranted[0].ID, got.Accessable.ServiceAccountGranted[0].ID)
+		granted := &service.Page[*service.AccessibleDataset]{}
+		NewTester(t, accessRequesterServer).Get("/api/userData/accessibleDatasets/serviceAccountGranted").
+			HasStatusCode(http2.StatusOK).
+			Value(granted)
+
+		require.Len(t, granted.Items, 1)
+		assert.Equal(t, *expectGranted[0].Subject, *granted.Items[0].Subject)
+		assert.Equal(t, expectGranted[0].DataproductID, granted.Items[0].DataproductID)
+		assert.Equal(t, expectGranted[0].ID, granted.Items[0].ID)
+	})
+
+	newRule := service.NewAccessApprovalRule{
+		DatasetID:     fuelData.ID,
+		SubjectType:   strToStrPtr(service.SubjectTypeGroup),
+		SubjectGroup:  strToStrPtr(GroupEmailNada),
+		MaxExpiryDays: intToIntPtr(30),
+	}
+
+	t.Run("Create access approval rule without being in the owner group", func(t *testing.T) {
+		NewTester(t, accessRequesterServer).Post(newRule, "/api/accessApprovalRules/new").
+			HasStatusCode(http2.StatusForbidden)
+	})
+
+	t.Run("Create access approval rule with unknown subject type", func(t *testing.T) {
+		input := newRule
+		input.SubjectType = strToStrPtr("robot")
+
+		NewTester(t, datasetOwnerServer).Post(input, "/api/accessApprovalRules/new").
+			HasStatusCode(http2.StatusBadRequest)
+	})
+
+	rule := &service.AccessApprovalRule{}
+	t.Run("Create access approval rule", func(t *testing.T) {
+		NewTester(t, datasetOwnerServer).Post(newRule, "/api/accessApprovalRules/new").
+			HasStatusCode(http2.StatusOK).
+			Value(rule)
+
+		got := &service.AccessApprovalRules{}
+		NewTester(t, datasetOwnerServer).Get("/api/accessApprovalRules", "datasetId", fuelData.ID.String()).
+			HasStatusCode(http2.StatusOK).
+			Value(got)
+
+		require.Len(t, got.Rules, 1)
+		assert.Equal(t, rule.ID, got.Rules[0].ID)
+		assert.Equal(t, UserOneEmail, got.Rules[0].CreatedBy)
+	})
+
+	accessRequestForGroup := func(t *testing.T, requester *httptest.Server, expires *time.Time) *service.AccessRequest {
+		t.Helper()
+
+		NewTester(t, requester).
+			Post(service.NewAccessRequestDTO{
+				DatasetID:   fuelData.ID,
+				Expires:     expires,
+				Subject:     strToStrPtr(GroupEmailNada),
+				SubjectType: strToStrPtr(service.SubjectTypeGroup),
+			}, "/api/accessRequests/new").
+			HasStatusCode(http2.StatusNoContent)
+
+		existingARs := &service.Page[*service.AccessRequest]{}
+		NewTester(t, datasetOwnerServer).Get("/api/accessRequests", "datasetId", fuelData.ID.String()).
+			HasStatusCode(http2.StatusOK).
+			Value(existingARs)
+
+		for _, ar := range existingARs.Items {
+			if ar.Subject == GroupEmailNada {
+				return ar
+			}
+		}
+
+		t.Fatalf("no access request for %s", GroupEmailNada)
+
+		return nil
+	}
+
+	t.Run("Access request not matching access approval rule", func(t *testing.T) {
+		ar := accessRequestForGroup(t, datasetOwnerServer, nil)
+
+		assert.Equal(t, service.AccessRequestStatusPending, ar.Status)
+		assert.Nil(t, ar.ApprovalRuleID)
+
+		NewTester(t, datasetOwnerServer).Delete(fmt.Sprintf("/api/accessRequests/%v", ar.ID)).
+			HasStatusCode(http2.StatusNoContent)
+	})
+
+	t.Run("Access request for a group the requester is not a member of", func(t *testing.T) {
+		expires := time.Now().Add(10 * 24 * time.Hour)
+		ar := accessRequestForGroup(t, accessRequesterServer, &expires)
+
+		assert.Equal(t, service.AccessRequestStatusPending, ar.Status)
+		assert.Nil(t, ar.ApprovalRuleID)
+
+		NewTester(t, datasetOwnerServer).Delete(fmt.Sprintf("/api/accessRequests/%v", ar.ID)).
+			HasStatusCode(http2.StatusNoContent)
+	})
+
+	t.Run("Access request approved by access approval rule", func(t *testing.T) {
+		expires := time.Now().Add(10 * 24 * time.Hour)
+		ar := accessRequestForGroup(t, datasetOwnerServer, &expires)
+
+		assert.Equal(t, service.AccessRequestStatusApproved, ar.Status)
+		require.NotNil(t, ar.ApprovalRuleID)
+		assert.Equal(t, rule.ID, *ar.ApprovalRuleID)
+		require.NotNil(t, ar.Granter)
+		assert.Equal(t, UserOneEmail, *ar.Granter)
+	})
+
+	t.Run("Delete access approval rule", func(t *testing.T) {
+		NewTester(t, accessRequesterServer).Delete(fmt.Sprintf("/api/accessApprovalRules/%v", rule.ID)).
+			HasStatusCode(http2.StatusForbidden)
+
+		NewTester(t, datasetOwnerServer).Delete(fmt.Sprintf("/api/accessApprovalRules/%v", rule.ID)).
+			HasStatusCode(http2.StatusNoContent)
+
+		got := &service.AccessApprovalRules{}
+		NewTester(t, datasetOwnerServer).Get("/api/accessApprovalRules", "datasetId", fuelData.ID.String()).
+			HasStatusCode(http2.StatusOK).
+			Value(got)
+
+		assert.Len(t, got.Rules, 0)
+	})
+
+	requirePurpose := service.NewPolicy{
+		DatasetID: &fuelData.ID,
+		Name:      "require-purpose",
+		Condition: `action == "request_access" && !has(request.purpose)`,
+		Reason:    "søknaden må ha et formål fra Polly",
+	}
+
+	t.Run("Get organisation policies includes built-in policies", func(t *testing.T) {
+		got := &service.Policies{}
+		NewTester(t, accessRequesterServer).Get("/api/policies").
+			HasStatusCode(http2.StatusOK).
+			Value(got)
+
+		require.GreaterOrEqual(t, len(got.Policies), 2)
+		assert.True(t, got.Policies[0].BuiltIn)
+	})
+
+	t.Run("Create organisation policy without being in the admin group", func(t *testing.T) {
+		input := requirePurpose
+		input.DatasetID = nil
+
+		NewTester(t, accessRequesterServer).Post(input, "/api/policies/new").
+			HasStatusCode(http2.StatusForbidden)
+	})
+
+	t.Run("Create policy with invalid condition", func(t *testing.T) {
+		input := requirePurpose
+		input.Condition = `action ==`
+
+		NewTester(t, datasetOwnerServer).Post(input, "/api/policies/new").
+			HasStatusCode(http2.StatusBadRequest)
+	})
+
+	requestInput := service.PolicyInput{
+		Action:      service.PolicyActionRequestAccess,
+		Subject:     UserTwoEmail,
+		SubjectType: service.SubjectTypeUser,
+		DatasetID:   fuelData.ID,
+	}
+
+	t.Run("Dry run policy without owning the dataset", func(t *testing.T) {
+		NewTester(t, accessRequesterServer).Post(service.PolicyDryRun{
+			Input:     requestInput,
+			Candidate: &requirePurpose,
+		}, "/api/policies/dryRun").
+			HasStatusCode(http2.StatusForbidden)
+	})
+
+	t.Run("Dry run policy candidate", func(t *testing.T) {
+		got := &service.PolicyDecision{}
+		NewTester(t, datasetOwnerServer).Post(service.PolicyDryRun{
+			Input:     requestInput,
+			Candidate: &requirePurpose,
+		}, "/api/policies/dryRun").
+			HasStatusCode(http2.StatusOK).
+			Value(got)
+
+		assert.False(t, got.Allow)
+		assert.Equal(t, []string{"require-purpose: søknaden må ha et formål fra Polly"}, got.Reasons)
+
+		input := requestInput
+		input.Purpose = strToStrPtr("Analyse av drivstofforbruk")
+
+		NewTester(t, datasetOwnerServer).Post(service.PolicyDryRun{
+			Input:     input,
+			Candidate: &requirePurpose,
+		}, "/api/policies/dryRun").
+			HasStatusCode(http2.StatusOK).
+			Value(got)
+
+		assert.True(t, got.Allow)
+		assert.Empty(t, got.Reasons)
+	})
+
+	policy := &service.Policy{}
+	t.Run("Access request denied by dataset policy", func(t *testing.T) {
+		NewTester(t, datasetOwnerServer).Post(requirePurpose, "/api/policies/new").
+			HasStatusCode(http2.StatusOK).
+			Value(policy)
+
+		NewTester(t, accessRequesterServer).
+			Post(service.NewAccessRequestDTO{
+				DatasetID:   fuelData.ID,
+				Subject:     strToStrPtr(UserTwoEmail),
+				SubjectType: strToStrPtr(service.SubjectTypeUser),
+			}, "/api/accessRequests/new").
+			HasStatusCode(http2.StatusForbidden)
+	})
+
+	t.Run("Delete dataset policy", func(t *testing.T) {
+		NewTester(t, accessRequesterServer).Delete(fmt.Sprintf("/api/policies/%v", policy.ID)).
+			HasStatusCode(http2.StatusForbidden)
+
+		NewTester(t, datasetOwnerServer).Delete(fmt.Sprintf("/api/policies/%v", policy.ID)).
+			HasStatusCode(http2.StatusNoContent)
+
+		got := &service.Policies{}
+		NewTester(t, datasetOwnerServer).Get("/api/policies", "datasetId", fuelData.ID.String()).
+			HasStatusCode(http2.StatusOK).
+			Value(got)
+
+		assert.Len(t, got.Policies, 0)
+	})
+
+	sensitive := NewDatasetBiofuelConsumptionRates(fuel.ID)
+	sensitive.Name = "Biofuel Consumers"
+	sensitive.Pii = service.PiiLevelSensitive
+	consumers, err := stores.DataProductsStorage.CreateDataset(ctx, sensitive, nil, UserOne)
+	require.NoError(t, err)
+
+	t.Run("Built-in policy denies sensitive dataset to all users", func(t *testing.T) {
+		got := &service.PolicyDecision{}
+		NewTester(t, datasetOwnerServer).Post(service.PolicyDryRun{
+			Input: service.PolicyInput{
+				Action:      service.PolicyActionGrantAccess,
+				Subject:     GroupEmailAllUsers,
+				SubjectType: service.SubjectTypeGroup,
+				DatasetID:   consumers.ID,
+			},
+		}, "/api/policies/dryRun").
+			HasStatusCode(http2.StatusOK).
+			Value(got)
+
+		assert.False(t, got.Allow)
+		require.Len(t, got.Reasons, 1)
+		assert.Contains(t, got.Reasons[0], "sensitive-all-users")
+
+		NewTester(t, datasetOwnerServer).
+			Post(service.NewAccessRequestDTO{
+				DatasetID:   consumers.ID,
+				Subject:     strToStrPtr(GroupEmailAllUsers),
+				SubjectType: strToStrPtr(service.SubjectTypeGroup),
+			}, "/api/accessRequests/new").
+			HasStatusCode(http2.StatusForbidden)
+	})
+
+	newCampaign := service.NewRecertificationCampaign{
+		Name:       "Kvartalsvis gjennomgang",
+		DatasetIDs: []uuid.UUID{fuelData.ID},
+		Deadline:   time.Now().Add(14 * 24 * time.Hour),
+	}
+
+	campaign := &service.RecertificationCampaign{}
+	t.Run("Create recertification campaign", func(t *testing.T) {
+		NewTester(t, accessRequesterServer).Post(newCampaign, "/api/recertifications/new").
+			HasStatusCode(http2.StatusForbidden)
+
+		NewTester(t, datasetOwnerServer).Post(newCampaign, "/api/recertifications/new").
+			HasStatusCode(http2.StatusOK).
+			Value(campaign)
+
+		assert.Equal(t, newCampaign.Name, campaign.Name)
+		assert.Equal(t, UserOneEmail, campaign.CreatedBy)
+		assert.Greater(t, campaign.Progress.Total, 0)
+		assert.Equal(t, campaign.Progress.Total, campaign.Progress.Pending)
+	})
+
+	review := &service.RecertificationReview{}
+	t.Run("Review access in recertification campaign", func(t *testing.T) {
+		got := &service.RecertificationCampaignWithReviews{}
+		NewTester(t, datasetOwnerServer).Get(fmt.Sprintf("/api/recertifications/%v", campaign.ID)).
+			HasStatusCode(http2.StatusOK).
+			Value(got)
+
+		require.NotEmpty(t, got.Reviews)
+		assert.Equal(t, fuelData.ID, got.Reviews[0].DatasetID)
+
+		input := service.RecertificationReviewInput{
+			Decision: service.RecertificationDecisionConfirmed,
+		}
+
+		NewTester(t, accessRequesterServer).Post(input, fmt.Sprintf("/api/recertifications/reviews/%v", got.Reviews[0].ID)).
+			HasStatusCode(http2.StatusForbidden)
+
+		NewTester(t, datasetOwnerServer).Post(input, fmt.Sprintf("/api/recertifications/reviews/%v", got.Reviews[0].ID)).
+			HasStatusCode(http2.StatusOK).
+			Value(review)
+
+		require.NotNil(t, review.Decision)
+		assert.Equal(t, service.RecertificationDecisionConfirmed, *review.Decision)
+		require.NotNil(t, review.ReviewedBy)
+		assert.Equal(t, UserOneEmail, *review.ReviewedBy)
+
+		NewTester(t, datasetOwnerServer).Post(input, fmt.Sprintf("/api/recertifications/reviews/%v", got.Reviews[0].ID)).
+			HasStatusCode(http2.StatusBadRequest)
+	})
+
+	t.Run("Export recertification campaign", func(t *testing.T) {
+		body := NewTester(t, datasetOwnerServer).Get(fmt.Sprintf("/api/recertifications/%v/export", campaign.ID), "format", "csv").
+			HasStatusCode(http2.StatusOK).
+			Body()
+
+		assert.Contains(t, body, "campaign,deadline,dataproduct,dataset")
+		assert.Contains(t, body, review.Subject+","+review.Owner)
+		assert.Contains(t, body, string(service.RecertificationDecisionConfirmed))
+
+		got := &service.RecertificationCampaigns{}
+		NewTester(t, datasetOwnerServer).Get("/api/recertifications").
+			HasStatusCode(http2.StatusOK).
+			Value(got)
+
+		require.Len(t, got.Campaigns, 1)
+		assert.Equal(t, 1, got.Campaigns[0].Progress.Confirmed)
+	})
+
+	groupAccess := &service.Access{}
+	t.Run("Get expiring access", func(t *testing.T) {
+		ds := &service.Dataset{}
+		NewTester(t, datasetOwnerServer).Get(fmt.Sprintf("/api/datasets/%v", fuelData.ID)).
+			HasStatusCode(http2.StatusOK).
+			Value(ds)
+
+		for _, a := range ds.Access {
+			if a.Subject == "group:"+GroupEmailNada && a.Revoked == nil {
+				groupAccess = a
+			}
+		}
+
+		require.NotNil(t, groupAccess.Expires)
+
+		got := &service.ExpiringAccesses{}
+		NewTester(t, datasetOwnerServer).Get("/api/accesses/expiring", "days", "30").
+			HasStatusCode(http2.StatusOK).
+			Value(got)
+
+		require.Len(t, got.Accesses, 1)
+		assert.Equal(t, groupAccess.ID, got.Accesses[0].AccessID)
+		assert.Nil(t, got.Accesses[0].RenewalRequestID)
+
+		NewTester(t, accessRequesterServer).Get("/api/accesses/expiring").
+			HasStatusCode(http2.StatusOK).
+			Value(got)
+
+		assert.Len(t, got.Accesses, 0)
+	})
+
+	renewal := &service.AccessRequest{}
+	t.Run("Renew access", func(t *testing.T) {
+		expires := groupAccess.Expires.Add(20 * 24 * time.Hour)
+		input := service.RenewAccessDTO{
+			Expires: &expires,
+		}
+
+		NewTester(t, accessRequesterServer).Post(input, fmt.Sprintf("/api/accesses/%v/renew", groupAccess.ID)).
+			HasStatusCode(http2.StatusForbidden)
+
+		NewTester(t, datasetOwnerServer).Post(input, fmt.Sprintf("/api/accesses/%v/renew", groupAccess.ID)).
+			HasStatusCode(http2.StatusOK).
+			Value(renewal)
+
+		assert.Equal(t, service.AccessRequestStatusPending, renewal.Status)
+		require.NotNil(t, renewal.RenewsAccessID)
+		assert.Equal(t, groupAccess.ID, *renewal.RenewsAccessID)
+
+		NewTester(t, datasetOwnerServer).Post(input, fmt.Sprintf("/api/accesses/%v/renew", groupAccess.ID)).
+			HasStatusCode(http2.StatusBadRequest)
+
+		got := &service.ExpiringAccesses{}
+		NewTester(t, datasetOwnerServer).Get("/api/accesses/expiring").
+			HasStatusCode(http2.StatusOK).
+			Value(got)
+
+		require.Len(t, got.Accesses, 1)
+		require.NotNil(t, got.Accesses[0].RenewalRequestID)
+		assert.Equal(t, renewal.ID, *got.Accesses[0].RenewalRequestID)
 	})
+
+	t.Run("Approve renewal extends the access", func(t *testing.T) {
+		NewTester(t, datasetOwnerServer).Post(nil, fmt.Sprintf("/api/accessRequests/process/%v", renewal.ID), "action", "approve").
+			HasStatusCode(http2.StatusNoContent)
+
+		ds := &service.Dataset{}
+		NewTester(t, datasetOwnerServer).Get(fmt.Sprintf("/api/datasets/%v", fuelData.ID)).
+			HasStatusCode(http2.StatusOK).
+			Value(ds)
+
+		var renewed []*service.Access
+		for _, a := range ds.Access {
+			if a.Subject == "group:"+GroupEmailNada && a.Revoked == nil {
+				renewed = append(renewed, a)
+			}
+		}
+
+		require.Len(t, renewed, 1)
+		assert.Equal(t, groupAccess.ID, renewed[0].ID)
+		require.NotNil(t, renewed[0].Expires)
+		assert.WithinDuration(t, *renewal.Expires, *renewed[0].Expires, time.Second)
+	})
+
+	purposeAccess := &service.Access{}
+	t.Run("Revalidate withdrawn purpose", func(t *testing.T) {
+		expires := time.Now().Add(30 * 24 * time.Hour)
+		NewTester(t, accessRequesterServer).
+			Post(service.NewAccessRequestDTO{
+				DatasetID:   fuelData.ID,
+				Subject:     strToStrPtr(UserTwoEmail),
+				SubjectType: strToStrPtr(service.SubjectTypeUser),
+				Expires:     &expires,
+				Polly: &service.PollyInput{
+					QueryPolly: service.QueryPolly{
+						ExternalID: "polly-statistikk",
+						Name:       "Statistikk",
+						URL:        "https://polly.nav.no/process/purpose/STATISTIKK/polly-statistikk",
+					},
+				},
+			}, "/api/accessRequests/new").
+			HasStatusCode(http2.StatusNoContent)
+
+		ars := &service.Page[*service.AccessRequest]{}
+		NewTester(t, datasetOwnerServer).Get("/api/accessRequests", "datasetId", fuelData.ID.String()).
+			HasStatusCode(http2.StatusOK).
+			Value(ars)
+
+		var ar *service.AccessRequest
+		for _, a := range ars.Items {
+			if a.Status == service.AccessRequestStatusPending && a.Polly != nil && a.Polly.ExternalID == "polly-statistikk" {
+				ar = a
+			}
+		}
+		require.NotNil(t, ar)
+
+		NewTester(t, datasetOwnerServer).Post(nil, fmt.Sprintf("/api/accessRequests/process/%v", ar.ID), "action", "approve").
+			HasStatusCode(http2.StatusNoContent)
+
+		ds := &service.Dataset{}
+		NewTester(t, datasetOwnerServer).Get(fmt.Sprintf("/api/datasets/%v", fuelData.ID)).
+			HasStatusCode(http2.StatusOK).
+			Value(ds)
+
+		for _, a := range ds.Access {
+			if a.AccessRequestID != nil && *a.AccessRequestID == ar.ID {
+				purposeAccess = a
+			}
+		}
+		require.NotEqual(t, uuid.Nil, purposeAccess.ID)
+
+		err := pollyService.RevalidatePurposes(ctx)
+		require.NoError(t, err)
+
+		got := &service.AccessesWithPollyPurpose{}
+		NewTester(t, datasetOwnerServer).Get("/api/polly/purposes/invalid").
+			HasStatusCode(http2.StatusOK).
+			Value(got)
+
+		require.Len(t, got.Accesses, 1)
+		assert.Equal(t, purposeAccess.ID, got.Accesses[0].AccessID)
+		assert.Equal(t, service.PollyPurposeStatusWithdrawn, got.Accesses[0].PurposeStatus)
+
+		NewTester(t, accessRequesterServer).Get("/api/polly/purposes/invalid").
+			HasStatusCode(http2.StatusOK).
+			Value(got)
+
+		assert.Len(t, got.Accesses, 0)
+	})
+
+	t.Run("Get purpose history", func(t *testing.T) {
+		err := pollyService.RevalidatePurposes(ctx)
+		require.NoError(t, err)
+
+		got := &service.PollyPurposeHistory{}
+		NewTester(t, datasetOwnerServer).Get("/api/polly/purposes/polly-statistikk/history").
+			HasStatusCode(http2.StatusOK).
+			Value(got)
+
+		require.NotNil(t, got.Purpose)
+		assert.Equal(t, service.PollyPurposeStatusWithdrawn, got.Purpose.Status)
+		require.Len(t, got.History, 1)
+		assert.Equal(t, service.PollyPurposeStatusWithdrawn, got.History[0].Status)
+
+		NewTester(t, datasetOwnerServer).Get("/api/polly/purposes/polly-ukjent/history").
+			HasStatusCode(http2.StatusNotFound)
+	})
+
+	t.Run("Renew access with invalid purpose requires a new purpose", func(t *testing.T) {
+		NewTester(t, datasetOwnerServer).Post(service.NewPolicy{
+			DatasetID: &fuelData.ID,
+			Name:      "require-valid-purpose",
+			Condition: `action == "request_access" && has(request.purposeStatus) && request.purposeStatus != "valid"`,
+			Reason:    "behandlingsgrunnlaget for formålet er ikke lenger gyldig",
+		}, "/api/policies/new").
+			HasStatusCode(http2.StatusOK)
+
+		NewTester(t, accessRequesterServer).Post(service.RenewAccessDTO{}, fmt.Sprintf("/api/accesses/%v/renew", purposeAccess.ID)).
+			HasStatusCode(http2.StatusForbidden)
+
+		got := &service.AccessRequest{}
+		NewTester(t, accessRequesterServer).Post(service.RenewAccessDTO{
+			Polly: &service.PollyInput{
+				QueryPolly: service.QueryPolly{
+					ExternalID: "polly-ny",
+					Name:       "Ny statistikk",
+					URL:        "https://polly.nav.no/process/purpose/STATISTIKK/polly-ny",
+				},
+			},
+		}, fmt.Sprintf("/api/accesses/%v/renew", purposeAccess.ID)).
+			HasStatusCode(http2.StatusOK).
+			Value(got)
+
+		require.NotNil(t, got.Polly)
+		assert.Equal(t, "polly-ny", got.Polly.ExternalID)
+	})
+}
+
+type pollyAPIFake struct {
+	processes map[string]*service.PollyProcess
+}
+
+func (p *pollyAPIFake) SearchPolly(_ context.Context, _ string) ([]*service.QueryPolly, error) {
+	return nil, nil
+}
+
+func (p *pollyAPIFake) GetPollyProcess(_ context.Context, externalID string) (*service.PollyProcess, error) {
+	process, ok := p.processes[externalID]
+	if !ok {
+		return nil, errs.E(errs.NotExist, errs.Op("pollyAPIFake.GetPollyProcess"), fmt.Errorf("treatment %s not found", externalID))
+	}
+
+	return process, nil
 }
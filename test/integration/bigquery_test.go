@@ -7,16 +7,20 @@ import (
 	"os"
 	"testing"
 
+	"github.com/fsouza/fake-gcs-server/fakestorage"
 	"github.com/goccy/bigquery-emulator/types"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/navikt/nada-backend/pkg/bq"
 	"github.com/navikt/nada-backend/pkg/bq/emulator"
 	"github.com/navikt/nada-backend/pkg/config/v2"
+	"github.com/navikt/nada-backend/pkg/cs"
+	csEmulator "github.com/navikt/nada-backend/pkg/cs/emulator"
 	"github.com/navikt/nada-backend/pkg/database"
 	"github.com/navikt/nada-backend/pkg/service"
 	"github.com/navikt/nada-backend/pkg/service/core"
 	"github.com/navikt/nada-backend/pkg/service/core/api/gcp"
+	"github.com/navikt/nada-backend/pkg/service/core/api/static"
 	"github.com/navikt/nada-backend/pkg/service/core/handlers"
 	"github.com/navikt/nada-backend/pkg/service/core/routes"
 	"github.com/navikt/nada-backend/pkg/service/core/storage"
@@ -61,6 +65,25 @@ func TestBigQuery(t *testing.T) {
 	em.TestServer()
 	bqClient := bq.NewClient(em.Endpoint(), false, zerolog.Nop())
 
+	gcsBucket := "test-bucket"
+	csEm := csEmulator.New(t, []fakestorage.Object{
+		{
+			ObjectAttrs: fakestorage.ObjectAttrs{
+				BucketName:  gcsBucket,
+				Name:        "exports/data.csv",
+				ContentType: "text/csv",
+			},
+			Content: []byte("id,name"),
+		},
+		{
+			ObjectAttrs: fakestorage.ObjectAttrs{
+				BucketName: gcsBucket,
+				Name:       "other/file.txt",
+			},
+		},
+	})
+	defer csEm.Cleanup()
+
 	stores := storage.NewStores(repo, config.Config{}, log)
 
 	zlog := zerolog.New(os.Stdout)
@@ -68,7 +91,28 @@ func TestBigQuery(t *testing.T) {
 
 	{
 		a := gcp.NewBigQueryAPI(gcpProject, gcpLocation, "pseudo-test-dataset", bqClient)
-		s := core.NewBigQueryService(stores.BigQueryStorage, a, stores.DataProductsStorage)
+		providers := service.NewDatasourceProviders(
+			gcp.NewBigQueryDatasourceProvider(a),
+			gcp.NewGCSDatasourceProvider(cs.NewBucketClient(csEm.Client())),
+		)
+		notificationService := core.NewNotificationService(
+			"https://data.nav.no",
+			stores.NotificationStorage,
+			static.NewSlackAPI(log),
+			static.NewEmailAPI(log),
+			log,
+		)
+		s := core.NewBigQueryService(
+			"https://data.nav.no",
+			stores.BigQueryStorage,
+			a,
+			stores.DataProductsStorage,
+			stores.DatasourceStorage,
+			stores.AccessStorage,
+			providers,
+			notificationService,
+			stores.ProfilingStorage,
+		)
 		h := handlers.NewBigQueryHandler(s)
 		e := routes.NewBigQueryEndpoints(zlog, h)
 		f := routes.NewBigQueryRoutes(e)
@@ -175,6 +219,7 @@ func TestBigQuery(t *testing.T) {
 			Dataset:   "test-dataset",
 			Table:     "test-table",
 			TableType: "TABLE",
+			Scope:     service.BigQueryScopeTable,
 			Schema: []*service.BigqueryColumn{
 				{
 					Name: "id",
@@ -201,5 +246,112 @@ func TestBigQuery(t *testing.T) {
 		assert.Empty(t, diff)
 	})
 
+	t.Run("Sync dataset scoped datasources", func(t *testing.T) {
+		user := &service.User{
+			Email: "nada@nav.no",
+		}
+
+		dp, err := stores.DataProductsStorage.CreateDataproduct(context.Background(), service.NewDataproduct{
+			Name:  "My Dataset Product",
+			Group: "nada@nav.no",
+		})
+		assert.NoError(t, err)
+
+		ds, err := stores.DataProductsStorage.CreateDataset(context.Background(), service.NewDataset{
+			DataproductID: dp.ID,
+			Name:          "My Whole Dataset",
+			Pii:           "none",
+			BigQuery: service.NewBigQuery{
+				ProjectID:    gcpProject,
+				Dataset:      "test-dataset",
+				Scope:        service.BigQueryScopeDataset,
+				TablePattern: "test-*",
+			},
+		}, nil, user)
+		assert.NoError(t, err)
+
+		NewTester(t, server).Post(nil, "/api/bigquery/tables/sync").
+			HasStatusCode(http.StatusNoContent)
+
+		source, err := stores.BigQueryStorage.GetBigqueryDatasource(context.Background(), ds.ID, false)
+		assert.NoError(t, err)
+		assert.Equal(t, service.BigQueryScopeDataset, source.Scope)
+		assert.Equal(t, "test-*", source.TablePattern)
+		assert.Equal(t, []string{"test-table"}, source.TableNames())
+		assert.Equal(t, []*service.BigqueryColumn{
+			{
+				Name: "test-table.id",
+				Type: "STRING",
+				Mode: "REQUIRED",
+			},
+			{
+				Name: "test-table.name",
+				Type: "STRING",
+				Mode: "NULLABLE",
+			},
+			{
+				Name: "test-table.description",
+				Type: "STRING",
+				Mode: "NULLABLE",
+			},
+		}, source.Schema)
+		assert.Len(t, source.Tables[0].Schema, 3)
+	})
+
+	t.Run("Sync gcs datasources", func(t *testing.T) {
+		user := &service.User{
+			Email: "nada@nav.no",
+		}
+
+		dp, err := stores.DataProductsStorage.CreateDataproduct(context.Background(), service.NewDataproduct{
+			Name:  "My Bucket Product",
+			Group: "nada@nav.no",
+		})
+		assert.NoError(t, err)
+
+		ds, err := stores.DataProductsStorage.CreateGCSDataset(context.Background(), service.NewDataset{
+			DataproductID: dp.ID,
+			Name:          "My Bucket",
+			Pii:           "none",
+			GCS: &service.NewGCS{
+				ProjectID: gcpProject,
+				Bucket:    gcsBucket,
+				Prefix:    "exports/",
+			},
+		}, service.DatasourceMetadata{}, user)
+		assert.NoError(t, err)
+
+		missing, err := stores.DataProductsStorage.CreateGCSDataset(context.Background(), service.NewDataset{
+			DataproductID: dp.ID,
+			Name:          "My Missing Bucket",
+			Pii:           "none",
+			GCS: &service.NewGCS{
+				ProjectID: gcpProject,
+				Bucket:    "missing-bucket",
+			},
+		}, service.DatasourceMetadata{}, user)
+		assert.NoError(t, err)
+
+		NewTester(t, server).Post(nil, "/api/bigquery/tables/sync").
+			HasStatusCode(http.StatusNoContent)
+
+		got, err := stores.DataProductsStorage.GetDataset(context.Background(), ds.ID)
+		assert.NoError(t, err)
+		assert.Nil(t, got.Datasource)
+		assert.NotNil(t, got.GCSDatasource)
+		assert.Equal(t, []*service.GCSObject{
+			{
+				Name:        "exports/data.csv",
+				ContentType: "text/csv",
+				Size:        7,
+			},
+		}, got.GCSDatasource.Objects)
+		assert.Nil(t, got.GCSDatasource.MissingSince)
+
+		got, err = stores.DataProductsStorage.GetDataset(context.Background(), missing.ID)
+		assert.NoError(t, err)
+		assert.NotNil(t, got.GCSDatasource.MissingSince)
+	})
+
 	// FIXME: Check sync with pseudo tables
 }
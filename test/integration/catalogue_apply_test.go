@@ -0,0 +1,353 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/navikt/nada-backend/pkg/bq"
+	bigQueryEmulator "github.com/navikt/nada-backend/pkg/bq/emulator"
+	"github.com/navikt/nada-backend/pkg/config/v2"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core"
+	"github.com/navikt/nada-backend/pkg/service/core/api/gcp"
+	"github.com/navikt/nada-backend/pkg/service/core/api/static"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/routes"
+	"github.com/navikt/nada-backend/pkg/service/core/storage"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const catalogueSpecYAML = `
+dataproducts:
+  - name: Biofuel Production
+    description: Turning seagrass into biofuels
+    teamID: 00000000-0000-0000-0000-000000000003
+    datasets:
+      - name: Biofuel Consumption Rates
+        description: Consumption rates of biofuels in the transportation sector
+        pii: none
+        keywords: [biofuel, rates]
+        bigquery:
+          projectID: test-project
+          dataset: biofuel
+          table: consumption_rates
+        access:
+          - subject: group:all-users@nav.no
+  - name: Biofuel Consumption
+    teamContact: "#biofuel"
+`
+
+func TestCatalogueApply(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(10*time.Minute))
+	defer cancel()
+
+	log := zerolog.New(os.Stdout)
+	c := NewContainers(t, log)
+	defer c.Cleanup()
+
+	pgCfg := c.RunPostgres(NewPostgresConfig())
+
+	repo, err := database.New(
+		pgCfg.ConnectionURL(),
+		10,
+		10,
+	)
+	assert.NoError(t, err)
+
+	bqe := bigQueryEmulator.New(log)
+	bqe.WithProject(Project, NewDatasetBiofuelConsumptionRatesSchema()...)
+	bqe.EnableMock(false, log, bigQueryEmulator.NewPolicyMock(log).Mocks()...)
+
+	bqHTTPAddr := fmt.Sprintf("127.0.0.1:%s", strconv.Itoa(GetFreePort(t)))
+	bqGRPCAddr := fmt.Sprintf("127.0.0.1:%s", strconv.Itoa(GetFreePort(t)))
+	go func() {
+		_ = bqe.Serve(ctx, bqHTTPAddr, bqGRPCAddr)
+	}()
+	bqClient := bq.NewClient("http://"+bqHTTPAddr, false, log)
+
+	stores := storage.NewStores(repo, config.Config{}, log)
+	bqapi := gcp.NewBigQueryAPI(Project, Location, PseudoDataSet, bqClient)
+	providers := service.NewDatasourceProviders(gcp.NewBigQueryDatasourceProvider(bqapi))
+
+	policyService := core.NewPolicyService(
+		stores.PolicyStorage,
+		stores.DataProductsStorage,
+		stores.AccessStorage,
+		GroupEmailNada,
+		"group:"+GroupEmailAllUsers,
+	)
+
+	// No datasets are added to Metabase, so the Metabase clients are never used
+	mbService := core.NewMetabaseService(
+		Project,
+		fakeMetabaseSA,
+		"nada-metabase@test.iam.gserviceaccount.com",
+		GroupEmailAllUsers,
+		nil,
+		bqapi,
+		nil,
+		providers,
+		stores.ThirdPartyMappingStorage,
+		stores.MetaBaseStorage,
+		stores.BigQueryStorage,
+		stores.DataProductsStorage,
+		stores.AccessStorage,
+		stores.DatasourceStorage,
+		policyService,
+		log,
+	)
+
+	dataproductService := core.NewDataProductsService(
+		stores.DataProductsStorage,
+		stores.BigQueryStorage,
+		bqapi,
+		stores.NaisConsoleStorage,
+		stores.AccessStorage,
+		mbService,
+		stores.DatasourceStorage,
+		providers,
+		GroupEmailAllUsers,
+	)
+
+	notificationService := core.NewNotificationService(
+		"https://data.nav.no",
+		stores.NotificationStorage,
+		static.NewSlackAPI(log),
+		static.NewEmailAPI(log),
+		log,
+	)
+
+	accessService := core.NewAccessService(
+		"https://data.nav.no",
+		notificationService,
+		policyService,
+		stores.AccessApprovalRuleStorage,
+		stores.PollyStorage,
+		stores.AccessStorage,
+		stores.DataProductsStorage,
+		stores.BigQueryStorage,
+		stores.JoinableViewsStorage,
+		bqapi,
+		stores.DatasourceStorage,
+		providers,
+	)
+
+	StorageCreateProductAreasAndTeams(t, stores.ProductAreaStorage)
+	StorageCreateNaisConsoleTeamsAndProjects(t, stores.NaisConsoleStorage, map[string]string{
+		NaisTeamNada: Project,
+	})
+
+	fuel, err := dataproductService.CreateDataproduct(ctx, UserOne, NewDataProductBiofuelProduction(GroupEmailNada, TeamSeagrassID))
+	require.NoError(t, err)
+
+	fuelData, err := dataproductService.CreateDataset(ctx, UserOne, NewDatasetBiofuelConsumptionRates(fuel.ID))
+	require.NoError(t, err)
+
+	err = stores.AccessStorage.GrantAccessToDatasetAndRenew(ctx, fuelData.ID, nil, "user:"+UserTwoEmail, UserTwoEmail, UserOneEmail)
+	require.NoError(t, err)
+
+	token, err := stores.TokenStorage.GetNadaToken(ctx, NaisTeamNada)
+	require.NoError(t, err)
+
+	r := TestRouter(log)
+
+	{
+		tokenService := core.NewTokenService(stores.TokenStorage)
+		storyHandler := handlers.NewStoryHandler("@nav.no", nil, tokenService, log)
+		s := core.NewCatalogueApplyService(Project, stores.DataProductsStorage, stores.BigQueryStorage, dataproductService, accessService)
+		h := handlers.NewCatalogueApplyHandler(s)
+		e := routes.NewCatalogueApplyEndpoints(log, h)
+		routes.NewCatalogueApplyRoutes(e, storyHandler.NadaTokenMiddleware)(r)
+	}
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	apply := func(t *testing.T, spec string, params ...string) TestRunnerStatus {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/api/catalogue/apply", strings.NewReader(spec))
+		require.NoError(t, err)
+
+		req.Header.Set("Content-Type", "application/yaml")
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		req.URL.RawQuery = strings.Join(params, "&")
+
+		return NewTester(t, server).Send(req)
+	}
+
+	fuelPath := "Biofuel Production/Biofuel Consumption Rates"
+
+	plan := &service.CataloguePlan{
+		Actions: []*service.CataloguePlanAction{
+			{
+				Action: service.CatalogueActionUpdate,
+				Kind:   service.CatalogueResourceDataproduct,
+				Path:   "Biofuel Production",
+				ID:     &fuel.ID,
+				Changes: []*service.CatalogueFieldChange{
+					{
+						Field: "description",
+						Old:   "Using seagrass as a feedstock to create renewable biofuels",
+						New:   "Turning seagrass into biofuels",
+					},
+				},
+				Status: service.CatalogueActionStatusPlanned,
+			},
+			{
+				Action: service.CatalogueActionUpdate,
+				Kind:   service.CatalogueResourceDataset,
+				Path:   fuelPath,
+				ID:     &fuelData.ID,
+				Changes: []*service.CatalogueFieldChange{
+					{
+						Field: "keywords",
+						Old:   "biofuel, consumption, rates",
+						New:   "biofuel, rates",
+					},
+				},
+				Status: service.CatalogueActionStatusPlanned,
+			},
+			{
+				Action:  service.CatalogueActionGrant,
+				Kind:    service.CatalogueResourceAccess,
+				Path:    fuelPath + "/group:" + GroupEmailAllUsers,
+				Changes: []*service.CatalogueFieldChange{},
+				Status:  service.CatalogueActionStatusPlanned,
+			},
+			{
+				Action:  service.CatalogueActionRevoke,
+				Kind:    service.CatalogueResourceAccess,
+				Path:    fuelPath + "/user:" + UserTwoEmail,
+				Changes: []*service.CatalogueFieldChange{},
+				Status:  service.CatalogueActionStatusPlanned,
+			},
+			{
+				Action: service.CatalogueActionCreate,
+				Kind:   service.CatalogueResourceDataproduct,
+				Path:   "Biofuel Consumption",
+				Changes: []*service.CatalogueFieldChange{
+					{
+						Field: "teamContact",
+						New:   "#biofuel",
+					},
+				},
+				Status: service.CatalogueActionStatusPlanned,
+			},
+		},
+	}
+
+	ignoreAccessID := cmpopts.IgnoreFields(service.CataloguePlanAction{}, "ID")
+
+	t.Run("Apply without token", func(t *testing.T) {
+		NewTester(t, server).
+			Post(service.CatalogueSpec{}, "/api/catalogue/apply").
+			HasStatusCode(http.StatusUnauthorized)
+	})
+
+	t.Run("Apply invalid spec", func(t *testing.T) {
+		apply(t, strings.Replace(catalogueSpecYAML, "pii: none", "pii: maybe", 1)).
+			HasStatusCode(http.StatusBadRequest)
+	})
+
+	t.Run("Plan", func(t *testing.T) {
+		apply(t, catalogueSpecYAML, "planOnly=true", "prune=true").
+			HasStatusCode(http.StatusOK).
+			Expect(plan, &service.CataloguePlan{}, ignoreAccessID)
+
+		dp, err := stores.DataProductsStorage.GetDataproduct(ctx, fuel.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Using seagrass as a feedstock to create renewable biofuels", *dp.Description)
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		applied := service.CataloguePlan{Applied: true}
+		for _, a := range plan.Actions {
+			action := *a
+			action.Status = service.CatalogueActionStatusApplied
+			applied.Actions = append(applied.Actions, &action)
+		}
+
+		apply(t, catalogueSpecYAML, "prune=true").
+			HasStatusCode(http.StatusOK).
+			Expect(&applied, &service.CataloguePlan{}, ignoreAccessID)
+
+		dp, err := stores.DataProductsStorage.GetDataproduct(ctx, fuel.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Turning seagrass into biofuels", *dp.Description)
+
+		ds, err := stores.DataProductsStorage.GetDataset(ctx, fuelData.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"biofuel", "rates"}, ds.Keywords)
+
+		active := map[string]bool{}
+		for _, a := range ds.Access {
+			active[a.Subject] = a.Revoked == nil
+		}
+		assert.Equal(t, map[string]bool{
+			"group:" + GroupEmailAllUsers: true,
+			"user:" + UserTwoEmail:        false,
+		}, active)
+	})
+
+	t.Run("Apply again", func(t *testing.T) {
+		apply(t, catalogueSpecYAML, "prune=true").
+			HasStatusCode(http.StatusOK).
+			Expect(&service.CataloguePlan{
+				Applied: true,
+				Actions: []*service.CataloguePlanAction{},
+			}, &service.CataloguePlan{})
+	})
+
+	t.Run("Apply failing part way", func(t *testing.T) {
+		spec := strings.Replace(catalogueSpecYAML, "Turning seagrass into biofuels", "Turning seagrass into renewable biofuels", 1)
+		spec = strings.Replace(spec, "- subject: group:all-users@nav.no\n", "- subject: group:all-users@nav.no\n            expires: 2000-01-01T00:00:00Z\n", 1)
+		spec += "  - name: Biofuel Storage\n"
+
+		got := &service.CataloguePlan{}
+
+		apply(t, spec).
+			HasStatusCode(http.StatusInternalServerError).
+			Value(got)
+
+		assert.False(t, got.Applied)
+		require.Len(t, got.Actions, 3)
+		assert.Equal(t, service.CatalogueActionStatusApplied, got.Actions[0].Status)
+		assert.Equal(t, service.CatalogueActionStatusFailed, got.Actions[1].Status)
+		assert.Equal(t, fuelPath+"/group:"+GroupEmailAllUsers, got.Actions[1].Path)
+		require.NotNil(t, got.Actions[1].Error)
+		assert.Contains(t, *got.Actions[1].Error, "expires is in the past")
+		assert.Equal(t, service.CatalogueActionStatusSkipped, got.Actions[2].Status)
+		assert.Equal(t, "Biofuel Storage", got.Actions[2].Path)
+
+		dp, err := stores.DataProductsStorage.GetDataproduct(ctx, fuel.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Turning seagrass into renewable biofuels", *dp.Description)
+	})
+
+	t.Run("Plan pruning everything", func(t *testing.T) {
+		got := &service.CataloguePlan{}
+
+		apply(t, "dataproducts: []", "planOnly=true", "prune=true").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		require.Len(t, got.Actions, 2)
+		for _, a := range got.Actions {
+			assert.Equal(t, service.CatalogueActionDelete, a.Action)
+			assert.Equal(t, service.CatalogueResourceDataproduct, a.Kind)
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/navikt/nada-backend/pkg/config/v2"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/routes"
+	"github.com/navikt/nada-backend/pkg/service/core/storage"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+type jsonLDDocument struct {
+	Context map[string]string `json:"@context"`
+	Graph   []map[string]any  `json:"@graph"`
+}
+
+func (d jsonLDDocument) resourcesOfType(typ string) []map[string]any {
+	var resources []map[string]any
+
+	for _, r := range d.Graph {
+		if r["@type"] == typ {
+			resources = append(resources, r)
+		}
+	}
+
+	return resources
+}
+
+func TestCatalogueExport(t *testing.T) {
+	log := zerolog.New(os.Stdout)
+	c := NewContainers(t, log)
+	defer c.Cleanup()
+
+	pgCfg := c.RunPostgres(NewPostgresConfig())
+
+	repo, err := database.New(
+		pgCfg.ConnectionURL(),
+		10,
+		10,
+	)
+	assert.NoError(t, err)
+
+	r := TestRouter(log)
+
+	stores := storage.NewStores(repo, config.Config{}, log)
+
+	StorageCreateProductAreasAndTeams(t, stores.ProductAreaStorage)
+	fuel := StorageCreateDataproduct(t, stores.DataProductsStorage, NewDataProductBiofuelProduction(GroupEmailNada, TeamSeagrassID))
+
+	user := &service.User{Email: UserOneEmail}
+
+	rates, err := stores.DataProductsStorage.CreateDataset(context.Background(), NewDatasetBiofuelConsumptionRates(fuel.ID), nil, user)
+	assert.NoError(t, err)
+
+	sensitive := NewDatasetBiofuelConsumptionRates(fuel.ID)
+	sensitive.Name = "Biofuel Consumers"
+	sensitive.Keywords = []string{"consumers"}
+	sensitive.Pii = service.PiiLevelSensitive
+	consumers, err := stores.DataProductsStorage.CreateDataset(context.Background(), sensitive, nil, user)
+	assert.NoError(t, err)
+
+	{
+		s := core.NewCatalogueExportService(stores.CatalogueExportStorage, "data.example.com", service.DCATCatalogue{
+			PublisherName: "Some Organisation",
+			PublisherURI:  "https://example.com/organisation",
+			LicenseURI:    "https://example.com/license",
+		})
+		h := handlers.NewCatalogueExportHandler(s)
+		e := routes.NewCatalogueExportEndpoints(log, h)
+		f := routes.NewCatalogueExportRoutes(e)
+		f(r)
+	}
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	t.Run("Export as JSON-LD", func(t *testing.T) {
+		got := jsonLDDocument{}
+
+		NewTester(t, server).Get("/api/dcat/catalog").
+			HasStatusCode(http.StatusOK).
+			Value(&got)
+
+		assert.Equal(t, "http://www.w3.org/ns/dcat#", got.Context["dcat"])
+		assert.Len(t, got.resourcesOfType("dcat:Catalog"), 1)
+
+		datasets := got.resourcesOfType("dcat:Dataset")
+		assert.Len(t, datasets, 1)
+		assert.Equal(t, map[string]any{"@value": "Biofuel Production", "@language": "nb"}, datasets[0]["dct:title"])
+		assert.Len(t, datasets[0]["dcat:keyword"], 4)
+		assert.Equal(t, map[string]any{
+			"@type":     "foaf:Organization",
+			"foaf:name": TeamSeagrassName,
+		}, datasets[0]["dct:publisher"])
+
+		distributions := got.resourcesOfType("dcat:Distribution")
+		assert.Len(t, distributions, 2)
+
+		for _, d := range distributions {
+			assert.Equal(t, map[string]any{"@id": "https://example.com/license"}, d["dct:license"])
+
+			if d["dct:identifier"] == consumers.ID.String() {
+				assert.Equal(t, "sensitive", d["nada:piiLevel"])
+			}
+		}
+	})
+
+	t.Run("Export as Turtle", func(t *testing.T) {
+		body := NewTester(t, server).
+			Headers(map[string]string{"Accept": "text/turtle"}).
+			Get("/api/dcat/catalog").
+			HasStatusCode(http.StatusOK).
+			Body()
+
+		assert.Contains(t, body, "@prefix dcat: <http://www.w3.org/ns/dcat#> .")
+		assert.Contains(t, body, "<https://data.example.com/api/dcat/catalog>\n    a dcat:Catalog ;")
+		assert.Contains(t, body, `dct:title "Biofuel Consumption Rates"@nb`)
+		assert.Contains(t, body, "<https://example.com/organisation>\n    a foaf:Agent ;")
+	})
+
+	t.Run("Export without sensitive datasets and fields", func(t *testing.T) {
+		got := jsonLDDocument{}
+
+		NewTester(t, server).Get("/api/dcat/catalog", "excludeSensitive", "true", "exclude", "contactPoint,pii").
+			HasStatusCode(http.StatusOK).
+			Value(&got)
+
+		distributions := got.resourcesOfType("dcat:Distribution")
+		assert.Len(t, distributions, 1)
+		assert.Equal(t, rates.ID.String(), distributions[0]["dct:identifier"])
+		assert.NotContains(t, distributions[0], "nada:piiLevel")
+		assert.NotContains(t, got.resourcesOfType("dcat:Dataset")[0], "dcat:contactPoint")
+	})
+
+	t.Run("Export modified since", func(t *testing.T) {
+		got := jsonLDDocument{}
+
+		NewTester(t, server).Get("/api/dcat/catalog", "modifiedSince", time.Now().UTC().Add(time.Hour).Format(time.RFC3339)).
+			HasStatusCode(http.StatusOK).
+			Value(&got)
+
+		assert.Len(t, got.resourcesOfType("dcat:Dataset"), 0)
+
+		NewTester(t, server).Get("/api/dcat/catalog", "modifiedSince", time.Now().UTC().Add(-time.Hour).Format(time.RFC3339)).
+			HasStatusCode(http.StatusOK).
+			Value(&got)
+
+		assert.Len(t, got.resourcesOfType("dcat:Dataset"), 1)
+	})
+
+	t.Run("Export with unknown field", func(t *testing.T) {
+		NewTester(t, server).Get("/api/dcat/catalog", "exclude", "owner").
+			HasStatusCode(http.StatusBadRequest)
+	})
+}
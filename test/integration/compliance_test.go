@@ -0,0 +1,177 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/config/v2"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/routes"
+	"github.com/navikt/nada-backend/pkg/service/core/storage"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComplianceReport(t *testing.T) {
+	log := zerolog.New(os.Stdout)
+	c := NewContainers(t, log)
+	defer c.Cleanup()
+
+	pgCfg := c.RunPostgres(NewPostgresConfig())
+
+	repo, err := database.New(
+		pgCfg.ConnectionURL(),
+		10,
+		10,
+	)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+
+	adminRouter := TestRouter(log)
+	userRouter := TestRouter(log)
+
+	stores := storage.NewStores(repo, config.Config{}, log)
+
+	StorageCreateProductAreasAndTeams(t, stores.ProductAreaStorage)
+	fuel := StorageCreateDataproduct(t, stores.DataProductsStorage, NewDataProductBiofuelProduction(GroupEmailNada, TeamSeagrassID))
+	reef := StorageCreateDataproduct(t, stores.DataProductsStorage, NewDataProductReefMonitoring(GroupEmailNada, TeamReefID))
+
+	user := &service.User{Email: UserOneEmail}
+
+	_, err = stores.DataProductsStorage.CreateDataset(ctx, NewDatasetBiofuelConsumptionRates(fuel.ID), nil, user)
+	require.NoError(t, err)
+
+	sensitive := NewDatasetBiofuelConsumptionRates(fuel.ID)
+	sensitive.Name = "Biofuel Consumers"
+	sensitive.Pii = service.PiiLevelSensitive
+	sensitive.BigQuery.PiiTags = strToStrPtr(`{"fnr": "PII_DirekteIdentifiserende"}`)
+	consumers, err := stores.DataProductsStorage.CreateDataset(ctx, sensitive, nil, user)
+	require.NoError(t, err)
+
+	anonymised := NewDatasetBiofuelConsumptionRates(reef.ID)
+	anonymised.Name = "Reef Divers"
+	anonymised.Pii = service.PiiLevelAnonymised
+	divers, err := stores.DataProductsStorage.CreateDataset(ctx, anonymised, nil, user)
+	require.NoError(t, err)
+
+	tagged := NewDatasetBiofuelConsumptionRates(reef.ID)
+	tagged.Name = "=Reef Sensors"
+	tagged.Pii = service.PiiLevelNone
+	tagged.BigQuery.PiiTags = strToStrPtr(`{"diver": "PII_IndirekteIdentifiserende", "depth": "PII_IngenPersonopplysning"}`)
+	sensors, err := stores.DataProductsStorage.CreateDataset(ctx, tagged, nil, user)
+	require.NoError(t, err)
+
+	expires := time.Now().Add(30 * 24 * time.Hour)
+	err = stores.AccessStorage.GrantAccessToDatasetAndRenew(ctx, consumers.ID, &expires, "user:"+UserTwoEmail, UserTwoEmail, UserOneEmail)
+	require.NoError(t, err)
+
+	{
+		s := core.NewComplianceService(stores.ComplianceStorage, GroupEmailNada)
+		h := handlers.NewComplianceHandler(s)
+		e := routes.NewComplianceEndpoints(log, h)
+		routes.NewComplianceRoutes(e, injectUser(UserOne))(adminRouter)
+		routes.NewComplianceRoutes(e, injectUser(UserTwo))(userRouter)
+	}
+
+	adminServer := httptest.NewServer(adminRouter)
+	defer adminServer.Close()
+
+	userServer := httptest.NewServer(userRouter)
+	defer userServer.Close()
+
+	t.Run("Get report", func(t *testing.T) {
+		got := &service.ComplianceReport{}
+		NewTester(t, adminServer).Get("/api/compliance/personal-data").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		require.Len(t, got.Datasets, 3)
+
+		var consumersReport, sensorsReport *service.PersonalDataset
+		for _, ds := range got.Datasets {
+			switch ds.DatasetID {
+			case consumers.ID:
+				consumersReport = ds
+			case sensors.ID:
+				sensorsReport = ds
+			}
+		}
+		require.NotNil(t, consumersReport)
+		require.NotNil(t, sensorsReport)
+
+		assert.False(t, consumersReport.PiiMismatch)
+		assert.True(t, sensorsReport.PiiMismatch)
+
+		assert.Equal(t, service.PiiLevelSensitive, consumersReport.Pii)
+		assert.Equal(t, map[string]string{"fnr": "PII_DirekteIdentifiserende"}, consumersReport.PiiTags)
+		require.NotNil(t, consumersReport.TeamName)
+		assert.Equal(t, TeamSeagrassName, *consumersReport.TeamName)
+		require.NotNil(t, consumersReport.ProductAreaName)
+		assert.Equal(t, ProductAreaOceanicName, *consumersReport.ProductAreaName)
+		require.Len(t, consumersReport.Accesses, 1)
+		assert.Equal(t, "user:"+UserTwoEmail, consumersReport.Accesses[0].Subject)
+		assert.WithinDuration(t, expires, *consumersReport.Accesses[0].Expires, time.Second)
+	})
+
+	t.Run("Get report filtered by product area and team", func(t *testing.T) {
+		got := &service.ComplianceReport{}
+		NewTester(t, adminServer).Get("/api/compliance/personal-data", "productAreaId", ProductAreaOceanicID.String()).
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		require.Len(t, got.Datasets, 1)
+		assert.Equal(t, consumers.ID, got.Datasets[0].DatasetID)
+
+		NewTester(t, adminServer).Get("/api/compliance/personal-data", "teamId", TeamReefID.String()).
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		require.Len(t, got.Datasets, 2)
+		assert.ElementsMatch(t, []uuid.UUID{divers.ID, sensors.ID}, []uuid.UUID{got.Datasets[0].DatasetID, got.Datasets[1].DatasetID})
+
+		NewTester(t, adminServer).Get("/api/compliance/personal-data", "teamId", "not-a-uuid").
+			HasStatusCode(http.StatusBadRequest)
+	})
+
+	t.Run("Export report", func(t *testing.T) {
+		body := NewTester(t, adminServer).Get("/api/compliance/personal-data/export").
+			HasStatusCode(http.StatusOK).
+			Body()
+
+		lines := strings.Split(strings.TrimSpace(body), "\n")
+		require.Len(t, lines, 4)
+		assert.True(t, strings.HasPrefix(lines[0], "product_area,team,dataproduct,dataset,"))
+		assert.Contains(t, body, ",'=Reef Sensors,")
+		assert.NotContains(t, body, ",=Reef Sensors,")
+		assert.Contains(t, body, "user:"+UserTwoEmail)
+		assert.Contains(t, body, "fnr=PII_DirekteIdentifiserende")
+
+		body = NewTester(t, adminServer).Get("/api/compliance/personal-data/export", "format", "xlsx").
+			HasStatusCode(http.StatusOK).
+			Body()
+
+		assert.True(t, strings.HasPrefix(body, "PK"))
+
+		NewTester(t, adminServer).Get("/api/compliance/personal-data/export", "format", "pdf").
+			HasStatusCode(http.StatusBadRequest)
+	})
+
+	t.Run("Get report without being in the admin group", func(t *testing.T) {
+		NewTester(t, userServer).Get("/api/compliance/personal-data").
+			HasStatusCode(http.StatusForbidden)
+
+		NewTester(t, userServer).Get("/api/compliance/personal-data/export").
+			HasStatusCode(http.StatusForbidden)
+	})
+}
@@ -0,0 +1,293 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/bq"
+	bigQueryEmulator "github.com/navikt/nada-backend/pkg/bq/emulator"
+	"github.com/navikt/nada-backend/pkg/config/v2"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core"
+	"github.com/navikt/nada-backend/pkg/service/core/api/gcp"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/routes"
+	"github.com/navikt/nada-backend/pkg/service/core/storage"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataproductTransfers(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(10*time.Minute))
+	defer cancel()
+
+	log := zerolog.New(os.Stdout)
+	c := NewContainers(t, log)
+	defer c.Cleanup()
+
+	pgCfg := c.RunPostgres(NewPostgresConfig())
+
+	repo, err := database.New(
+		pgCfg.ConnectionURL(),
+		10,
+		10,
+	)
+	assert.NoError(t, err)
+
+	bqe := bigQueryEmulator.New(log)
+	bqe.WithProject(Project, NewDatasetBiofuelConsumptionRatesSchema()...)
+	bqe.EnableMock(false, log, bigQueryEmulator.NewPolicyMock(log).Mocks()...)
+
+	bqHTTPAddr := fmt.Sprintf("127.0.0.1:%s", strconv.Itoa(GetFreePort(t)))
+	bqGRPCAddr := fmt.Sprintf("127.0.0.1:%s", strconv.Itoa(GetFreePort(t)))
+	go func() {
+		_ = bqe.Serve(ctx, bqHTTPAddr, bqGRPCAddr)
+	}()
+	bqClient := bq.NewClient("http://"+bqHTTPAddr, false, log)
+
+	stores := storage.NewStores(repo, config.Config{}, log)
+	bqapi := gcp.NewBigQueryAPI(Project, Location, PseudoDataSet, bqClient)
+
+	policyService := core.NewPolicyService(
+		stores.PolicyStorage,
+		stores.DataProductsStorage,
+		stores.AccessStorage,
+		GroupEmailNada,
+		"group:"+GroupEmailAllUsers,
+	)
+
+	// The dataset has never been added to Metabase, so the Metabase clients
+	// are never used when transferring it
+	mbService := core.NewMetabaseService(
+		Project,
+		fakeMetabaseSA,
+		"nada-metabase@test.iam.gserviceaccount.com",
+		GroupEmailAllUsers,
+		nil,
+		bqapi,
+		nil,
+		service.NewDatasourceProviders(gcp.NewBigQueryDatasourceProvider(bqapi)),
+		stores.ThirdPartyMappingStorage,
+		stores.MetaBaseStorage,
+		stores.BigQueryStorage,
+		stores.DataProductsStorage,
+		stores.AccessStorage,
+		stores.DatasourceStorage,
+		policyService,
+		log,
+	)
+
+	dataproductService := core.NewDataProductsService(
+		stores.DataProductsStorage,
+		stores.BigQueryStorage,
+		bqapi,
+		stores.NaisConsoleStorage,
+		stores.AccessStorage,
+		mbService,
+		stores.DatasourceStorage,
+		service.NewDatasourceProviders(gcp.NewBigQueryDatasourceProvider(bqapi)),
+		GroupEmailAllUsers,
+	)
+
+	StorageCreateProductAreasAndTeams(t, stores.ProductAreaStorage)
+	fuel, err := dataproductService.CreateDataproduct(ctx, UserOne, NewDataProductBiofuelProduction(GroupEmailNada, TeamSeagrassID))
+	require.NoError(t, err)
+
+	fuelData, err := dataproductService.CreateDataset(ctx, UserOne, NewDatasetBiofuelConsumptionRates(fuel.ID))
+	require.NoError(t, err)
+
+	fuelStory := StorageCreateStory(t, stores.StoryStorage, UserOneEmail, NewStoryBiofuelProduction(GroupEmailNada))
+	reefStory := StorageCreateStory(t, stores.StoryStorage, UserOneEmail, NewStoryReefMonitoring(GroupEmailReef))
+
+	err = stores.AccessStorage.GrantAccessToDatasetAndRenew(ctx, fuelData.ID, nil, "serviceAccount:nada@test-project.iam.gserviceaccount.com", GroupEmailNada, UserOneEmail)
+	require.NoError(t, err)
+
+	err = stores.AccessStorage.GrantAccessToDatasetAndRenew(ctx, fuelData.ID, nil, "group:"+GroupEmailNada, GroupEmailNada, UserOneEmail)
+	require.NoError(t, err)
+
+	accessRequest, err := stores.AccessStorage.CreateAccessRequestForDataset(ctx, fuelData.ID, uuid.NullUUID{}, "group:"+GroupEmailNada, "group:"+GroupEmailNada, nil)
+	require.NoError(t, err)
+
+	transferService := core.NewDataproductTransferService(
+		stores.DataproductTransferStorage,
+		stores.DataProductsStorage,
+		stores.StoryStorage,
+		mbService,
+		log,
+	)
+
+	reefUser := &service.User{
+		Name:  "Reef User",
+		Email: "reef.user@nav.no",
+		GoogleGroups: []service.Group{
+			{
+				Name:  GroupNameReef,
+				Email: GroupEmailReef,
+			},
+			{
+				Name:  GroupNameAllUsers,
+				Email: GroupEmailAllUsers,
+			},
+		},
+	}
+
+	zlog := zerolog.New(os.Stdout)
+	ownerRouter := TestRouter(zlog)
+	otherRouter := TestRouter(zlog)
+	receiverRouter := TestRouter(zlog)
+
+	{
+		h := handlers.NewDataproductTransferHandler(transferService)
+		e := routes.NewDataproductTransferEndpoints(zlog, h)
+		routes.NewDataproductTransferRoutes(e, injectUser(UserOne))(ownerRouter)
+		routes.NewDataproductTransferRoutes(e, injectUser(UserTwo))(otherRouter)
+		routes.NewDataproductTransferRoutes(e, injectUser(reefUser))(receiverRouter)
+	}
+
+	ownerServer := httptest.NewServer(ownerRouter)
+	defer ownerServer.Close()
+
+	otherServer := httptest.NewServer(otherRouter)
+	defer otherServer.Close()
+
+	receiverServer := httptest.NewServer(receiverRouter)
+	defer receiverServer.Close()
+
+	newTransfer := service.NewDataproductTransfer{
+		DataproductID: fuel.ID,
+		Group:         GroupEmailReef,
+		TeamContact:   strToStrPtr("#reef"),
+		TeamID:        &TeamReefID,
+		StoryIDs:      []uuid.UUID{fuelStory.ID},
+	}
+
+	t.Run("Request transfer without being in the owner group", func(t *testing.T) {
+		NewTester(t, otherServer).Post(newTransfer, "/api/dataproductTransfers/new").
+			HasStatusCode(http.StatusForbidden)
+	})
+
+	t.Run("Request transfer to the current owner group", func(t *testing.T) {
+		input := newTransfer
+		input.Group = GroupEmailNada
+
+		NewTester(t, ownerServer).Post(input, "/api/dataproductTransfers/new").
+			HasStatusCode(http.StatusBadRequest)
+	})
+
+	t.Run("Request transfer including a story owned by another group", func(t *testing.T) {
+		input := newTransfer
+		input.StoryIDs = []uuid.UUID{reefStory.ID}
+
+		NewTester(t, ownerServer).Post(input, "/api/dataproductTransfers/new").
+			HasStatusCode(http.StatusBadRequest)
+	})
+
+	cancelled := &service.DataproductTransfer{}
+
+	t.Run("Request and cancel transfer", func(t *testing.T) {
+		NewTester(t, ownerServer).Post(newTransfer, "/api/dataproductTransfers/new").
+			HasStatusCode(http.StatusOK).
+			Value(cancelled)
+
+		assert.Equal(t, service.DataproductTransferStatusPending, cancelled.Status)
+		assert.Equal(t, GroupEmailNada, cancelled.From.Group)
+		assert.Equal(t, GroupEmailReef, cancelled.To.Group)
+
+		NewTester(t, ownerServer).Post(newTransfer, "/api/dataproductTransfers/new").
+			HasStatusCode(http.StatusBadRequest)
+
+		NewTester(t, receiverServer).Post(nil, fmt.Sprintf("/api/dataproductTransfers/%s/cancel", cancelled.ID)).
+			HasStatusCode(http.StatusForbidden)
+
+		got := &service.DataproductTransfer{}
+		NewTester(t, ownerServer).Post(nil, fmt.Sprintf("/api/dataproductTransfers/%s/cancel", cancelled.ID)).
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		assert.Equal(t, service.DataproductTransferStatusCancelled, got.Status)
+		require.NotNil(t, got.ResolvedBy)
+		assert.Equal(t, UserOneEmail, *got.ResolvedBy)
+	})
+
+	transfer := &service.DataproductTransfer{}
+
+	t.Run("Receiving team sees pending transfer", func(t *testing.T) {
+		NewTester(t, ownerServer).Post(newTransfer, "/api/dataproductTransfers/new").
+			HasStatusCode(http.StatusOK).
+			Value(transfer)
+
+		var got []*service.DataproductTransfer
+		NewTester(t, receiverServer).Get("/api/dataproductTransfers/").
+			HasStatusCode(http.StatusOK).
+			Value(&got)
+
+		require.Len(t, got, 2)
+		assert.Equal(t, transfer.ID, got[0].ID)
+		assert.Equal(t, cancelled.ID, got[1].ID)
+	})
+
+	t.Run("Accept transfer without being in the receiving group", func(t *testing.T) {
+		NewTester(t, ownerServer).Post(nil, fmt.Sprintf("/api/dataproductTransfers/%s/accept", transfer.ID)).
+			HasStatusCode(http.StatusForbidden)
+	})
+
+	t.Run("Accept transfer", func(t *testing.T) {
+		got := &service.DataproductTransfer{}
+		NewTester(t, receiverServer).Post(nil, fmt.Sprintf("/api/dataproductTransfers/%s/accept", transfer.ID)).
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		assert.Equal(t, service.DataproductTransferStatusAccepted, got.Status)
+		require.Len(t, got.RetainedAccess, 1)
+		assert.Equal(t, "group:"+GroupEmailNada, got.RetainedAccess[0].Subject)
+		assert.Equal(t, fuelData.ID, got.RetainedAccess[0].DatasetID)
+
+		dp, err := stores.DataProductsStorage.GetDataproduct(ctx, fuel.ID)
+		require.NoError(t, err)
+		assert.Equal(t, GroupEmailReef, dp.Owner.Group)
+		assert.Equal(t, &TeamReefID, dp.Owner.TeamID)
+		assert.Equal(t, strToStrPtr("#reef"), dp.Owner.TeamContact)
+
+		story, err := stores.StoryStorage.GetStory(ctx, fuelStory.ID)
+		require.NoError(t, err)
+		assert.Equal(t, GroupEmailReef, story.Group)
+		assert.Equal(t, &TeamReefID, story.TeamID)
+
+		accesses, err := stores.AccessStorage.ListActiveAccessToDataset(ctx, fuelData.ID)
+		require.NoError(t, err)
+		require.Len(t, accesses, 2)
+		for _, a := range accesses {
+			assert.Equal(t, GroupEmailReef, a.Owner)
+		}
+
+		ar, err := stores.AccessStorage.GetAccessRequest(ctx, accessRequest.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "group:"+GroupEmailReef, ar.Owner)
+	})
+
+	t.Run("Accept transfer that is no longer pending", func(t *testing.T) {
+		NewTester(t, receiverServer).Post(nil, fmt.Sprintf("/api/dataproductTransfers/%s/accept", transfer.ID)).
+			HasStatusCode(http.StatusBadRequest)
+	})
+
+	t.Run("Transfer history for dataproduct", func(t *testing.T) {
+		var got []*service.DataproductTransfer
+		NewTester(t, otherServer).Get(fmt.Sprintf("/api/dataproductTransfers/dataproduct/%s", fuel.ID)).
+			HasStatusCode(http.StatusOK).
+			Value(&got)
+
+		require.Len(t, got, 2)
+		assert.Equal(t, service.DataproductTransferStatusAccepted, got[0].Status)
+		assert.Equal(t, service.DataproductTransferStatusCancelled, got[1].Status)
+	})
+}
@@ -0,0 +1,179 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/goccy/bigquery-emulator/types"
+	"github.com/navikt/nada-backend/pkg/bq"
+	"github.com/navikt/nada-backend/pkg/bq/emulator"
+	"github.com/navikt/nada-backend/pkg/config/v2"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core"
+	"github.com/navikt/nada-backend/pkg/service/core/api/gcp"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/routes"
+	"github.com/navikt/nada-backend/pkg/service/core/storage"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatasetPreview(t *testing.T) {
+	log := zerolog.New(os.Stdout)
+	c := NewContainers(t, log)
+	defer c.Cleanup()
+
+	pgCfg := c.RunPostgres(NewPostgresConfig())
+
+	repo, err := database.New(
+		pgCfg.ConnectionURL(),
+		10,
+		10,
+	)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+
+	fuelBqSchema := NewDatasetBiofuelConsumptionRatesSchema()
+	fuelBqSchema[0].Rows = types.Data{
+		{"id": "1", "fuel_type": "ethanol", "consumption_rate": "2.5", "unit": "l"},
+		{"id": "2", "fuel_type": "biodiesel", "consumption_rate": "1.5", "unit": "l"},
+	}
+
+	em := emulator.New(log)
+	em.WithProject(Project, fuelBqSchema...)
+	em.TestServer()
+	defer em.Cleanup()
+
+	bqClient := bq.NewClient(em.Endpoint(), false, zerolog.Nop())
+
+	stores := storage.NewStores(repo, config.Config{}, log)
+
+	StorageCreateProductAreasAndTeams(t, stores.ProductAreaStorage)
+	fuel := StorageCreateDataproduct(t, stores.DataProductsStorage, NewDataProductBiofuelProduction(GroupEmailNada, TeamSeagrassID))
+
+	user := &service.User{Email: UserOneEmail}
+
+	metadata := service.BigqueryMetadata{
+		TableType: service.RegularTable,
+		Schema: service.BigquerySchema{
+			Columns: []*service.BigqueryColumn{
+				{Name: "id", Type: "STRING", Mode: "REQUIRED"},
+				{Name: "fuel_type", Type: "STRING", Mode: "NULLABLE"},
+				{Name: "consumption_rate", Type: "STRING", Mode: "NULLABLE"},
+				{Name: "unit", Type: "STRING", Mode: "NULLABLE"},
+			},
+		},
+	}
+
+	open := NewDatasetBiofuelConsumptionRates(fuel.ID)
+	open.Metadata = metadata
+	rates, err := stores.DataProductsStorage.CreateDataset(ctx, open, nil, user)
+	require.NoError(t, err)
+
+	sensitive := NewDatasetBiofuelConsumptionRates(fuel.ID)
+	sensitive.Name = "Biofuel Consumers"
+	sensitive.Pii = service.PiiLevelSensitive
+	sensitive.Metadata = metadata
+	consumers, err := stores.DataProductsStorage.CreateDataset(ctx, sensitive, nil, user)
+	require.NoError(t, err)
+
+	expires := time.Now().Add(30 * 24 * time.Hour)
+	err = stores.AccessStorage.GrantAccessToDatasetAndRenew(ctx, consumers.ID, &expires, "user:"+UserTwoEmail, UserTwoEmail, UserOneEmail)
+	require.NoError(t, err)
+
+	enabled := true
+
+	ownerRouter := TestRouter(log)
+	userRouter := TestRouter(log)
+
+	{
+		s := core.NewDatasetPreviewService(
+			stores.DatasetPreviewStorage,
+			stores.DataProductsStorage,
+			stores.BigQueryStorage,
+			stores.AccessStorage,
+			gcp.NewBigQueryAPI(Project, Location, PseudoDataSet, bqClient),
+		)
+		h := handlers.NewDatasetPreviewHandler(s)
+		e := routes.NewDatasetPreviewEndpoints(log, h)
+		routes.NewDatasetPreviewRoutes(e, injectUser(UserOne))(ownerRouter)
+		routes.NewDatasetPreviewRoutes(e, injectUser(UserTwo))(userRouter)
+	}
+
+	ownerServer := httptest.NewServer(ownerRouter)
+	defer ownerServer.Close()
+
+	userServer := httptest.NewServer(userRouter)
+	defer userServer.Close()
+
+	t.Run("Get synthetic preview without access", func(t *testing.T) {
+		got := &service.DatasetPreview{}
+		NewTester(t, userServer).Get("/api/datasets/"+rates.ID.String()+"/preview", "limit", "3").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		assert.Equal(t, service.DatasetPreviewKindSynthetic, got.Kind)
+		assert.NotNil(t, got.Reason)
+		require.Len(t, got.Rows, 3)
+		assert.Equal(t, "fuel_type_1", got.Rows[0]["fuel_type"])
+		assert.Len(t, got.Schema, 4)
+	})
+
+	t.Run("Get sample as owner", func(t *testing.T) {
+		got := &service.DatasetPreview{}
+		NewTester(t, ownerServer).Get("/api/datasets/" + rates.ID.String() + "/preview").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		assert.Equal(t, service.DatasetPreviewKindSample, got.Kind)
+		assert.Nil(t, got.Reason)
+		require.Len(t, got.Rows, 2)
+		assert.Equal(t, "ethanol", got.Rows[0]["fuel_type"])
+	})
+
+	t.Run("Get synthetic preview of sensitive dataset with access", func(t *testing.T) {
+		got := &service.DatasetPreview{}
+		NewTester(t, userServer).Get("/api/datasets/" + consumers.ID.String() + "/preview").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		assert.Equal(t, service.DatasetPreviewKindSynthetic, got.Kind)
+		assert.Len(t, got.Rows, service.DatasetPreviewDefaultLimit)
+	})
+
+	t.Run("Enable previews without being owner", func(t *testing.T) {
+		NewTester(t, userServer).Put(service.UpdateDatasetPreviewSettings{Enabled: &enabled}, "/api/datasets/"+consumers.ID.String()+"/preview/settings").
+			HasStatusCode(http.StatusForbidden)
+	})
+
+	t.Run("Get sample of sensitive dataset with previews enabled", func(t *testing.T) {
+		settings := &service.DatasetPreviewSettings{}
+		NewTester(t, ownerServer).Put(service.UpdateDatasetPreviewSettings{Enabled: &enabled}, "/api/datasets/"+consumers.ID.String()+"/preview/settings").
+			HasStatusCode(http.StatusOK).
+			Value(settings)
+
+		assert.True(t, settings.Enabled)
+		assert.Equal(t, UserOneEmail, settings.UpdatedBy)
+
+		got := &service.DatasetPreview{}
+		NewTester(t, userServer).Get("/api/datasets/"+consumers.ID.String()+"/preview", "limit", "1").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		assert.Equal(t, service.DatasetPreviewKindSample, got.Kind)
+		require.Len(t, got.Rows, 1)
+		assert.Equal(t, "1", got.Rows[0]["id"])
+	})
+
+	t.Run("Get preview with invalid limit", func(t *testing.T) {
+		NewTester(t, ownerServer).Get("/api/datasets/"+rates.ID.String()+"/preview", "limit", "1000").
+			HasStatusCode(http.StatusBadRequest)
+	})
+}
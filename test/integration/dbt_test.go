@@ -0,0 +1,357 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/navikt/nada-backend/pkg/config/v2"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/routes"
+	"github.com/navikt/nada-backend/pkg/service/core/storage"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDbtManifest() *service.DbtManifest {
+	return &service.DbtManifest{
+		Metadata: service.DbtManifestMetadata{
+			DbtSchemaVersion: "https://schemas.getdbt.com/dbt/manifest/v12.json",
+			ProjectName:      "biofuel",
+		},
+		Nodes: map[string]*service.DbtNode{
+			"model.biofuel.consumption_rates": {
+				UniqueID:     "model.biofuel.consumption_rates",
+				ResourceType: service.DbtResourceTypeModel,
+				Name:         "consumption_rates",
+				Database:     Project,
+				Schema:       "biofuel",
+				Description:  "Consumption rates of biofuels, built with dbt",
+				Tags:         []string{"biofuel", "dbt"},
+				Columns: map[string]*service.DbtColumn{
+					"fuel_type": {Name: "fuel_type", Description: "The type of fuel"},
+					"unit":      {Name: "unit"},
+				},
+				Config: service.DbtNodeConfig{Materialized: "table"},
+			},
+			"model.biofuel.reef_sensors": {
+				UniqueID:     "model.biofuel.reef_sensors",
+				ResourceType: service.DbtResourceTypeModel,
+				Name:         "stg_reef_sensors",
+				Alias:        "reef_sensors",
+				Database:     Project,
+				Schema:       "reef",
+				Description:  "Readings from the reef sensors",
+				Config:       service.DbtNodeConfig{Materialized: "view"},
+			},
+			"model.biofuel.production_volumes": {
+				UniqueID:     "model.biofuel.production_volumes",
+				ResourceType: service.DbtResourceTypeModel,
+				Name:         "production_volumes",
+				Database:     Project,
+				Schema:       "biofuel",
+				Description:  "Production volumes of biofuels",
+				Config:       service.DbtNodeConfig{Materialized: "table"},
+			},
+			"model.biofuel.int_rates": {
+				UniqueID:     "model.biofuel.int_rates",
+				ResourceType: service.DbtResourceTypeModel,
+				Name:         "int_rates",
+				Database:     Project,
+				Schema:       "biofuel",
+				Config:       service.DbtNodeConfig{Materialized: "ephemeral"},
+			},
+			"test.biofuel.not_null_consumption_rates_id": {
+				UniqueID:     "test.biofuel.not_null_consumption_rates_id",
+				ResourceType: "test",
+				Name:         "not_null_consumption_rates_id",
+			},
+		},
+	}
+}
+
+// dbtFailingDataProductsService fails to create datasets, for importing
+// a manifest where some of the models fail
+type dbtFailingDataProductsService struct {
+	service.DataProductsService
+}
+
+func (s *dbtFailingDataProductsService) CreateDataset(context.Context, *service.User, service.NewDataset) (*service.Dataset, error) {
+	return nil, fmt.Errorf("creating dataset failed")
+}
+
+func TestDbtImport(t *testing.T) {
+	ctx := context.Background()
+
+	log := zerolog.New(os.Stdout)
+	c := NewContainers(t, log)
+	defer c.Cleanup()
+
+	pgCfg := c.RunPostgres(NewPostgresConfig())
+
+	repo, err := database.New(
+		pgCfg.ConnectionURL(),
+		10,
+		10,
+	)
+	assert.NoError(t, err)
+
+	r := TestRouter(log)
+
+	stores := storage.NewStores(repo, config.Config{}, log)
+
+	StorageCreateProductAreasAndTeams(t, stores.ProductAreaStorage)
+	StorageCreateNaisConsoleTeamsAndProjects(t, stores.NaisConsoleStorage, map[string]string{
+		NaisTeamNada:  Project,
+		GroupNameReef: "reef-project",
+	})
+
+	fuel := StorageCreateDataproduct(t, stores.DataProductsStorage, NewDataProductBiofuelProduction(GroupEmailNada, TeamSeagrassID))
+	barriers := StorageCreateDataproduct(t, stores.DataProductsStorage, NewDataProductProtectiveBarriers(GroupEmailReef, TeamReefID))
+
+	rates := NewDatasetBiofuelConsumptionRates(fuel.ID)
+	rates.Metadata = service.BigqueryMetadata{
+		Schema: service.BigquerySchema{
+			Columns: []*service.BigqueryColumn{
+				{Name: "id", Type: "STRING", Mode: "REQUIRED"},
+				{Name: "fuel_type", Type: "STRING", Mode: "NULLABLE"},
+				{Name: "unit", Type: "STRING", Mode: "NULLABLE", Description: "The unit of the rate"},
+			},
+		},
+	}
+
+	ratesDataset, err := stores.DataProductsStorage.CreateDataset(ctx, rates, nil, UserOne)
+	assert.NoError(t, err)
+
+	sensors, err := stores.DataProductsStorage.CreateDataset(ctx, service.NewDataset{
+		DataproductID: barriers.ID,
+		Name:          "Reef sensors",
+		Keywords:      []string{"reef"},
+		Pii:           service.PiiLevelNone,
+		BigQuery: service.NewBigQuery{
+			ProjectID: Project,
+			Dataset:   "reef",
+			Table:     "reef_sensors",
+		},
+	}, nil, &service.User{Email: GroupEmailReef})
+	assert.NoError(t, err)
+
+	token, err := stores.TokenStorage.GetNadaToken(ctx, NaisTeamNada)
+	assert.NoError(t, err)
+
+	{
+		tokenService := core.NewTokenService(stores.TokenStorage)
+		storyHandler := handlers.NewStoryHandler("@nav.no", nil, tokenService, log)
+		s := core.NewDbtService(stores.DbtStorage, stores.DataProductsStorage, stores.BigQueryStorage, &dbtFailingDataProductsService{})
+		h := handlers.NewDbtHandler(s)
+		e := routes.NewDbtEndpoints(log, h)
+		f := routes.NewDbtRoutes(e, storyHandler.NadaTokenMiddleware)
+		f(r)
+	}
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	auth := map[string]string{"Authorization": fmt.Sprintf("Bearer %s", token)}
+
+	updated := &service.DbtModelResult{
+		Model:     "model.biofuel.consumption_rates",
+		ProjectID: Project,
+		Dataset:   "biofuel",
+		Table:     "consumption_rates",
+		DatasetID: &ratesDataset.ID,
+		Action:    service.DbtImportActionUpdated,
+		Changes: []*service.DbtFieldChange{
+			{
+				Field: "description",
+				Old:   "Consumption rates of biofuels in the transportation sector",
+				New:   "Consumption rates of biofuels, built with dbt",
+			},
+			{
+				Field: "keywords",
+				Old:   "biofuel, consumption, rates",
+				New:   "biofuel, consumption, rates, dbt",
+			},
+			{
+				Field: "columns.fuel_type",
+				Old:   "",
+				New:   "The type of fuel",
+			},
+		},
+	}
+
+	skipped := &service.DbtModelResult{
+		Model:     "model.biofuel.reef_sensors",
+		ProjectID: Project,
+		Dataset:   "reef",
+		Table:     "reef_sensors",
+		DatasetID: &sensors.ID,
+		Action:    service.DbtImportActionSkipped,
+		Reason:    "dataset is owned by " + GroupEmailReef,
+		Changes:   []*service.DbtFieldChange{},
+	}
+
+	t.Run("Import manifest without token", func(t *testing.T) {
+		NewTester(t, server).
+			Post(newDbtManifest(), "/api/dbt/import").
+			HasStatusCode(http.StatusUnauthorized)
+	})
+
+	t.Run("Import manifest as dry run", func(t *testing.T) {
+		expect := &service.DbtImportReport{
+			DryRun: true,
+			Models: []*service.DbtModelResult{
+				updated,
+				{
+					Model:     "model.biofuel.production_volumes",
+					ProjectID: Project,
+					Dataset:   "biofuel",
+					Table:     "production_volumes",
+					Action:    service.DbtImportActionCreated,
+					Changes: []*service.DbtFieldChange{
+						{
+							Field: "description",
+							New:   "Production volumes of biofuels",
+						},
+					},
+				},
+				skipped,
+			},
+		}
+
+		got := &service.DbtImportReport{}
+
+		NewTester(t, server).
+			Headers(auth).
+			Post(newDbtManifest(), "/api/dbt/import",
+				"dryRun", "true",
+				"createInDataproduct", fuel.ID.String(),
+				"pii", string(service.PiiLevelNone),
+			).
+			HasStatusCode(http.StatusOK).
+			Expect(expect, got)
+
+		ds, err := stores.DataProductsStorage.GetDataset(ctx, ratesDataset.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, rates.Description, ds.Description)
+		assert.Equal(t, rates.Keywords, ds.Keywords)
+	})
+
+	t.Run("Import manifest creating datasets in dataproduct owned by another team", func(t *testing.T) {
+		NewTester(t, server).
+			Headers(auth).
+			Post(newDbtManifest(), "/api/dbt/import",
+				"dryRun", "true",
+				"createInDataproduct", barriers.ID.String(),
+				"pii", string(service.PiiLevelNone),
+			).
+			HasStatusCode(http.StatusForbidden)
+	})
+
+	t.Run("Import manifest creating datasets without pii level", func(t *testing.T) {
+		NewTester(t, server).
+			Headers(auth).
+			Post(newDbtManifest(), "/api/dbt/import",
+				"createInDataproduct", fuel.ID.String(),
+			).
+			HasStatusCode(http.StatusBadRequest)
+	})
+
+	t.Run("Import manifest", func(t *testing.T) {
+		expect := &service.DbtImportReport{
+			Models: []*service.DbtModelResult{
+				updated,
+				{
+					Model:     "model.biofuel.production_volumes",
+					ProjectID: Project,
+					Dataset:   "biofuel",
+					Table:     "production_volumes",
+					Action:    service.DbtImportActionUnmatched,
+					Reason:    "no dataset in the catalogue for the table",
+					Changes:   []*service.DbtFieldChange{},
+				},
+				skipped,
+			},
+		}
+
+		got := &service.DbtImportReport{}
+
+		NewTester(t, server).
+			Headers(auth).
+			Post(newDbtManifest(), "/api/dbt/import").
+			HasStatusCode(http.StatusOK).
+			Expect(expect, got)
+
+		ds, err := stores.DataProductsStorage.GetDataset(ctx, ratesDataset.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Consumption rates of biofuels, built with dbt", *ds.Description)
+		assert.Equal(t, []string{"biofuel", "consumption", "rates", "dbt"}, ds.Keywords)
+
+		bq, err := stores.BigQueryStorage.GetBigqueryDatasource(ctx, ratesDataset.ID, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []*service.BigqueryColumn{
+			{Name: "id", Type: "STRING", Mode: "REQUIRED"},
+			{Name: "fuel_type", Type: "STRING", Mode: "NULLABLE", Description: "The type of fuel"},
+			{Name: "unit", Type: "STRING", Mode: "NULLABLE", Description: "The unit of the rate"},
+		}, bq.Schema)
+	})
+
+	t.Run("Import manifest again", func(t *testing.T) {
+		got := &service.DbtImportReport{}
+
+		NewTester(t, server).
+			Headers(auth).
+			Post(newDbtManifest(), "/api/dbt/import").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		assert.Equal(t, service.DbtImportActionUnchanged, got.Models[0].Action)
+	})
+
+	t.Run("Import manifest failing for some of the models", func(t *testing.T) {
+		got := &service.DbtImportReport{}
+
+		NewTester(t, server).
+			Headers(auth).
+			Post(newDbtManifest(), "/api/dbt/import",
+				"createInDataproduct", fuel.ID.String(),
+				"pii", string(service.PiiLevelNone),
+			).
+			HasStatusCode(http.StatusInternalServerError).
+			Value(got)
+
+		assert.Len(t, got.Models, 3)
+		assert.Equal(t, service.DbtImportActionUnchanged, got.Models[0].Action)
+		assert.Equal(t, "model.biofuel.production_volumes", got.Models[1].Model)
+		assert.Equal(t, service.DbtImportActionFailed, got.Models[1].Action)
+		assert.Contains(t, got.Models[1].Reason, "creating dataset failed")
+		assert.Equal(t, skipped, got.Models[2])
+	})
+
+	t.Run("Column descriptions are kept when the schema is synced", func(t *testing.T) {
+		err := stores.BigQueryStorage.UpdateBigqueryDatasourceSchema(ctx, ratesDataset.ID, service.BigqueryMetadata{
+			Schema: service.BigquerySchema{
+				Columns: []*service.BigqueryColumn{
+					{Name: "id", Type: "STRING", Mode: "REQUIRED"},
+					{Name: "fuel_type", Type: "STRING", Mode: "NULLABLE"},
+					{Name: "unit", Type: "STRING", Mode: "NULLABLE"},
+				},
+			},
+		})
+		assert.NoError(t, err)
+
+		bq, err := stores.BigQueryStorage.GetBigqueryDatasource(ctx, ratesDataset.ID, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []*service.BigqueryColumn{
+			{Name: "id", Type: "STRING", Mode: "REQUIRED"},
+			{Name: "fuel_type", Type: "STRING", Mode: "NULLABLE", Description: "The type of fuel"},
+			{Name: "unit", Type: "STRING", Mode: "NULLABLE"},
+		}, bq.Schema)
+	})
+}
@@ -113,7 +113,8 @@ func TestInsightProduct(t *testing.T) {
 
 		got := &service.InsightProduct{}
 
-		NewTester(t, server).Put(insightProduct, "/api/insightProducts/"+ip.ID.String()).
+		NewTester(t, server).Headers(map[string]string{"If-Match": ip.ETag()}).
+			Put(insightProduct, "/api/insightProducts/"+ip.ID.String()).
 			HasStatusCode(http.StatusOK).
 			Value(got)
 
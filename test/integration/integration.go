@@ -515,6 +515,10 @@ func strToStrPtr(s string) *string {
 	return &s
 }
 
+func intToIntPtr(i int) *int {
+	return &i
+}
+
 func injectUser(user *service.User) func(handler http.Handler) http.Handler {
 	return func(handler http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
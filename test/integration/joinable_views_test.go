@@ -0,0 +1,239 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/goccy/bigquery-emulator/types"
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/bq"
+	bigQueryEmulator "github.com/navikt/nada-backend/pkg/bq/emulator"
+	"github.com/navikt/nada-backend/pkg/config/v2"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core"
+	"github.com/navikt/nada-backend/pkg/service/core/api/gcp"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/routes"
+	"github.com/navikt/nada-backend/pkg/service/core/storage"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func joinableViewsDataset(datasetID string) *bigQueryEmulator.Dataset {
+	return &bigQueryEmulator.Dataset{
+		DatasetID: datasetID,
+		TableID:   fmt.Sprintf("%s_%s", Project, "consumption_rates"),
+		Columns: []*types.Column{
+			bigQueryEmulator.ColumnRequired("_x_id"),
+		},
+	}
+}
+
+func TestJoinableViews(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(10*time.Minute))
+	defer cancel()
+
+	log := zerolog.New(os.Stdout)
+	c := NewContainers(t, log)
+	defer c.Cleanup()
+
+	pgCfg := c.RunPostgres(NewPostgresConfig())
+
+	repo, err := database.New(
+		pgCfg.ConnectionURL(),
+		10,
+		10,
+	)
+	assert.NoError(t, err)
+
+	const (
+		sharedViews   = "joinable_shared"
+		expiredViews  = "joinable_expired"
+		orphanedViews = "joinable_orphaned"
+	)
+
+	bqe := bigQueryEmulator.New(log)
+	bqe.WithProject(Project, append(
+		NewDatasetBiofuelConsumptionRatesSchema(),
+		joinableViewsDataset(sharedViews),
+		joinableViewsDataset(expiredViews),
+		joinableViewsDataset(orphanedViews),
+	)...)
+	bqe.EnableMock(false, log, bigQueryEmulator.NewPolicyMock(log).Mocks()...)
+
+	bqHTTPAddr := fmt.Sprintf("127.0.0.1:%s", strconv.Itoa(GetFreePort(t)))
+	bqGRPCAddr := fmt.Sprintf("127.0.0.1:%s", strconv.Itoa(GetFreePort(t)))
+	go func() {
+		_ = bqe.Serve(ctx, bqHTTPAddr, bqGRPCAddr)
+	}()
+	bqClient := bq.NewClient("http://"+bqHTTPAddr, false, log)
+
+	stores := storage.NewStores(repo, config.Config{}, log)
+	bqapi := gcp.NewBigQueryAPI(Project, Location, PseudoDataSet, bqClient)
+
+	dataproductService := core.NewDataProductsService(
+		stores.DataProductsStorage,
+		stores.BigQueryStorage,
+		bqapi,
+		stores.NaisConsoleStorage,
+		stores.AccessStorage,
+		nil,
+		stores.DatasourceStorage,
+		service.NewDatasourceProviders(gcp.NewBigQueryDatasourceProvider(bqapi)),
+		GroupEmailAllUsers,
+	)
+
+	StorageCreateProductAreasAndTeams(t, stores.ProductAreaStorage)
+	fuel, err := dataproductService.CreateDataproduct(ctx, UserOne, NewDataProductBiofuelProduction(GroupEmailNada, TeamSeagrassID))
+	require.NoError(t, err)
+
+	fuelData, err := dataproductService.CreateDataset(ctx, UserOne, NewDatasetBiofuelConsumptionRates(fuel.ID))
+	require.NoError(t, err)
+
+	orphanedInput := NewDatasetBiofuelConsumptionRates(fuel.ID)
+	orphanedInput.Name = "Biofuel Consumption Rates Copy"
+	orphanedData, err := dataproductService.CreateDataset(ctx, UserOne, orphanedInput)
+	require.NoError(t, err)
+
+	createJoinableViews := func(name string, datasetID uuid.UUID, expires time.Time) uuid.UUID {
+		datasource, err := stores.BigQueryStorage.GetBigqueryDatasource(ctx, datasetID, false)
+		require.NoError(t, err)
+
+		id, err := stores.JoinableViewsStorage.CreateJoinableViewsDB(ctx, name, UserOneEmail, &expires, []uuid.UUID{datasource.ID})
+		require.NoError(t, err)
+
+		return uuid.MustParse(id)
+	}
+
+	sharedID := createJoinableViews(sharedViews, fuelData.ID, time.Now().Add(24*time.Hour))
+	expiredID := createJoinableViews(expiredViews, fuelData.ID, time.Now().Add(-time.Hour))
+	orphanedID := createJoinableViews(orphanedViews, orphanedData.ID, time.Now().Add(24*time.Hour))
+
+	policyService := core.NewPolicyService(
+		stores.PolicyStorage,
+		stores.DataProductsStorage,
+		stores.AccessStorage,
+		GroupEmailNada,
+		"group:"+GroupEmailAllUsers,
+	)
+
+	joinableViewsService := core.NewJoinableViewsService(
+		stores.JoinableViewsStorage,
+		stores.AccessStorage,
+		stores.DataProductsStorage,
+		bqapi,
+		stores.BigQueryStorage,
+		Project,
+		policyService,
+	)
+
+	zlog := zerolog.New(os.Stdout)
+	ownerRouter := TestRouter(zlog)
+	otherRouter := TestRouter(zlog)
+
+	{
+		h := handlers.NewJoinableViewsHandler(joinableViewsService)
+		e := routes.NewJoinableViewsEndpoints(zlog, h)
+		routes.NewJoinableViewsRoutes(e, injectUser(UserOne))(ownerRouter)
+		routes.NewJoinableViewsRoutes(e, injectUser(UserTwo))(otherRouter)
+	}
+
+	ownerServer := httptest.NewServer(ownerRouter)
+	defer ownerServer.Close()
+
+	otherServer := httptest.NewServer(otherRouter)
+	defer otherServer.Close()
+
+	t.Run("Extend joinable views", func(t *testing.T) {
+		expires := time.Now().Add(48 * time.Hour).Truncate(time.Second).UTC()
+		got := &service.JoinableView{}
+
+		NewTester(t, ownerServer).Put(service.ExtendJoinableView{Expires: expires}, fmt.Sprintf("/api/pseudo/joinable/%s/expires", sharedID)).
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		require.NotNil(t, got.Expires)
+		assert.True(t, expires.Equal(*got.Expires))
+
+		jv, err := stores.JoinableViewsStorage.GetJoinableViewMetadata(ctx, sharedID)
+		require.NoError(t, err)
+		assert.True(t, expires.Equal(*jv.Expires))
+	})
+
+	t.Run("Extend joinable views with expiry in the past", func(t *testing.T) {
+		NewTester(t, ownerServer).Put(service.ExtendJoinableView{Expires: time.Now().Add(-time.Hour)}, fmt.Sprintf("/api/pseudo/joinable/%s/expires", sharedID)).
+			HasStatusCode(http.StatusBadRequest)
+	})
+
+	t.Run("Extend joinable views owned by someone else", func(t *testing.T) {
+		NewTester(t, otherServer).Put(service.ExtendJoinableView{Expires: time.Now().Add(time.Hour)}, fmt.Sprintf("/api/pseudo/joinable/%s/expires", sharedID)).
+			HasStatusCode(http.StatusForbidden)
+	})
+
+	t.Run("Share joinable views with group without access to the datasets", func(t *testing.T) {
+		NewTester(t, ownerServer).Post(service.ShareJoinableView{Group: GroupEmailReef}, fmt.Sprintf("/api/pseudo/joinable/%s/share", sharedID)).
+			HasStatusCode(http.StatusForbidden)
+	})
+
+	t.Run("Share joinable views with group owning the datasets", func(t *testing.T) {
+		NewTester(t, ownerServer).Post(service.ShareJoinableView{Group: GroupEmailNada}, fmt.Sprintf("/api/pseudo/joinable/%s/share", sharedID)).
+			HasStatusCode(http.StatusNoContent)
+
+		got := &service.JoinableViewWithDatasource{}
+		NewTester(t, ownerServer).Get(fmt.Sprintf("/api/pseudo/joinable/%s", sharedID)).
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		assert.Equal(t, []string{GroupEmailNada}, got.SharedWith)
+	})
+
+	t.Run("Delete joinable views owned by someone else", func(t *testing.T) {
+		NewTester(t, otherServer).Delete(fmt.Sprintf("/api/pseudo/joinable/%s", sharedID)).
+			HasStatusCode(http.StatusForbidden)
+	})
+
+	t.Run("Delete joinable views", func(t *testing.T) {
+		NewTester(t, ownerServer).Delete(fmt.Sprintf("/api/pseudo/joinable/%s", sharedID)).
+			HasStatusCode(http.StatusNoContent)
+
+		_, err := bqClient.GetDataset(ctx, Project, sharedViews)
+		assert.ErrorIs(t, err, bq.ErrNotExist)
+
+		jv, err := stores.JoinableViewsStorage.GetJoinableViewMetadata(ctx, sharedID)
+		require.NoError(t, err)
+		assert.NotNil(t, jv.Deleted)
+
+		NewTester(t, ownerServer).Delete(fmt.Sprintf("/api/pseudo/joinable/%s", sharedID)).
+			HasStatusCode(http.StatusNotFound)
+	})
+
+	t.Run("Cleanup deletes expired and orphaned joinable views", func(t *testing.T) {
+		err := stores.DataProductsStorage.DeleteDataset(ctx, orphanedData.ID)
+		require.NoError(t, err)
+
+		err = joinableViewsService.CleanupJoinableViews(ctx)
+		require.NoError(t, err)
+
+		for id, name := range map[uuid.UUID]string{expiredID: expiredViews, orphanedID: orphanedViews} {
+			_, err := bqClient.GetDataset(ctx, Project, name)
+			assert.ErrorIs(t, err, bq.ErrNotExist)
+
+			jv, err := stores.JoinableViewsStorage.GetJoinableViewMetadata(ctx, id)
+			require.NoError(t, err)
+			assert.NotNil(t, jv.Deleted)
+		}
+
+		views, err := stores.JoinableViewsStorage.GetJoinableViewsToBeCleanedUp(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, views)
+	})
+}
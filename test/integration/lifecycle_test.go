@@ -0,0 +1,265 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/navikt/nada-backend/pkg/bq"
+	bigQueryEmulator "github.com/navikt/nada-backend/pkg/bq/emulator"
+	"github.com/navikt/nada-backend/pkg/config/v2"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core"
+	"github.com/navikt/nada-backend/pkg/service/core/api/gcp"
+	"github.com/navikt/nada-backend/pkg/service/core/api/static"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/routes"
+	"github.com/navikt/nada-backend/pkg/service/core/storage"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycle(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(10*time.Minute))
+	defer cancel()
+
+	log := zerolog.New(os.Stdout)
+	c := NewContainers(t, log)
+	defer c.Cleanup()
+
+	pgCfg := c.RunPostgres(NewPostgresConfig())
+
+	repo, err := database.New(
+		pgCfg.ConnectionURL(),
+		10,
+		10,
+	)
+	assert.NoError(t, err)
+
+	bqe := bigQueryEmulator.New(log)
+	bqe.WithProject(Project, NewDatasetBiofuelConsumptionRatesSchema()...)
+	bqe.EnableMock(false, log, bigQueryEmulator.NewPolicyMock(log).Mocks()...)
+
+	bqHTTPAddr := fmt.Sprintf("127.0.0.1:%s", strconv.Itoa(GetFreePort(t)))
+	bqGRPCAddr := fmt.Sprintf("127.0.0.1:%s", strconv.Itoa(GetFreePort(t)))
+	go func() {
+		_ = bqe.Serve(ctx, bqHTTPAddr, bqGRPCAddr)
+	}()
+	bqClient := bq.NewClient("http://"+bqHTTPAddr, false, log)
+
+	stores := storage.NewStores(repo, config.Config{}, log)
+	bqapi := gcp.NewBigQueryAPI(Project, Location, PseudoDataSet, bqClient)
+	notificationService := core.NewNotificationService(
+		"https://data.nav.no",
+		stores.NotificationStorage,
+		static.NewSlackAPI(log),
+		static.NewEmailAPI(log),
+		log,
+	)
+
+	policyService := core.NewPolicyService(
+		stores.PolicyStorage,
+		stores.DataProductsStorage,
+		stores.AccessStorage,
+		GroupEmailNada,
+		"group:"+GroupEmailAllUsers,
+	)
+
+	// The dataset has never been added to Metabase, so the Metabase clients
+	// are never used when retiring it
+	mbService := core.NewMetabaseService(
+		Project,
+		fakeMetabaseSA,
+		"nada-metabase@test.iam.gserviceaccount.com",
+		GroupEmailAllUsers,
+		nil,
+		bqapi,
+		nil,
+		service.NewDatasourceProviders(gcp.NewBigQueryDatasourceProvider(bqapi)),
+		stores.ThirdPartyMappingStorage,
+		stores.MetaBaseStorage,
+		stores.BigQueryStorage,
+		stores.DataProductsStorage,
+		stores.AccessStorage,
+		stores.DatasourceStorage,
+		policyService,
+		log,
+	)
+
+	dataproductService := core.NewDataProductsService(
+		stores.DataProductsStorage,
+		stores.BigQueryStorage,
+		bqapi,
+		stores.NaisConsoleStorage,
+		stores.AccessStorage,
+		mbService,
+		stores.DatasourceStorage,
+		service.NewDatasourceProviders(gcp.NewBigQueryDatasourceProvider(bqapi)),
+		GroupEmailAllUsers,
+	)
+
+	StorageCreateProductAreasAndTeams(t, stores.ProductAreaStorage)
+	fuel, err := dataproductService.CreateDataproduct(ctx, UserOne, NewDataProductBiofuelProduction(GroupEmailNada, TeamSeagrassID))
+	require.NoError(t, err)
+
+	fuelData, err := dataproductService.CreateDataset(ctx, UserOne, NewDatasetBiofuelConsumptionRates(fuel.ID))
+	require.NoError(t, err)
+
+	err = stores.AccessStorage.GrantAccessToDatasetAndRenew(ctx, fuelData.ID, nil, "user:"+UserTwoEmail, UserTwoEmail, UserOneEmail)
+	require.NoError(t, err)
+
+	lifecycleService := core.NewLifecycleService(
+		"https://data.nav.no",
+		stores.LifecycleStorage,
+		stores.DataProductsStorage,
+		stores.AccessStorage,
+		stores.BigQueryStorage,
+		stores.DatasourceStorage,
+		service.NewDatasourceProviders(gcp.NewBigQueryDatasourceProvider(bqapi)),
+		mbService,
+		notificationService,
+		log,
+	)
+
+	zlog := zerolog.New(os.Stdout)
+	ownerRouter := TestRouter(zlog)
+	otherRouter := TestRouter(zlog)
+
+	{
+		h := handlers.NewLifecycleHandler(lifecycleService)
+		e := routes.NewLifecycleEndpoints(zlog, h)
+		routes.NewLifecycleRoutes(e, injectUser(UserOne))(ownerRouter)
+		routes.NewLifecycleRoutes(e, injectUser(UserTwo))(otherRouter)
+	}
+
+	{
+		h := handlers.NewDataProductsHandler(dataproductService)
+		e := routes.NewDataProductsEndpoints(zlog, h)
+		routes.NewDataProductsRoutes(e, injectUser(UserOne))(ownerRouter)
+	}
+
+	{
+		s := core.NewAccessService(
+			"https://data.nav.no",
+			notificationService,
+			policyService,
+			stores.AccessApprovalRuleStorage,
+			stores.PollyStorage,
+			stores.AccessStorage,
+			stores.DataProductsStorage,
+			stores.BigQueryStorage,
+			stores.JoinableViewsStorage,
+			bqapi,
+			stores.DatasourceStorage,
+			service.NewDatasourceProviders(gcp.NewBigQueryDatasourceProvider(bqapi)),
+		)
+		h := handlers.NewAccessHandler(s, mbService, Project)
+		e := routes.NewAccessEndpoints(zlog, h)
+		routes.NewAccessRoutes(e, injectUser(UserTwo))(otherRouter)
+	}
+
+	ownerServer := httptest.NewServer(ownerRouter)
+	defer ownerServer.Close()
+
+	otherServer := httptest.NewServer(otherRouter)
+	defer otherServer.Close()
+
+	sunset := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	deprecate := service.UpdateLifecycle{
+		Status: service.LifecycleStatusSunsetScheduled,
+		Sunset: &sunset,
+		Reason: strToStrPtr("replaced by a better dataset"),
+	}
+
+	t.Run("Deprecate dataset without being in the owner group", func(t *testing.T) {
+		NewTester(t, otherServer).Put(deprecate, fmt.Sprintf("/api/lifecycle/datasets/%s", fuelData.ID)).
+			HasStatusCode(http.StatusForbidden)
+	})
+
+	t.Run("Deprecate dataset without a sunset date", func(t *testing.T) {
+		input := deprecate
+		input.Sunset = nil
+
+		NewTester(t, ownerServer).Put(input, fmt.Sprintf("/api/lifecycle/datasets/%s", fuelData.ID)).
+			HasStatusCode(http.StatusBadRequest)
+	})
+
+	t.Run("Deprecate dataset replaced by itself", func(t *testing.T) {
+		input := deprecate
+		input.ReplacedBy = &fuelData.ID
+
+		NewTester(t, ownerServer).Put(input, fmt.Sprintf("/api/lifecycle/datasets/%s", fuelData.ID)).
+			HasStatusCode(http.StatusBadRequest)
+	})
+
+	t.Run("Deprecate dataset", func(t *testing.T) {
+		got := &service.Lifecycle{}
+		NewTester(t, ownerServer).Put(deprecate, fmt.Sprintf("/api/lifecycle/datasets/%s", fuelData.ID)).
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		assert.Equal(t, service.LifecycleStatusSunsetScheduled, got.Status)
+
+		ds := &service.Dataset{}
+		NewTester(t, ownerServer).Get(fmt.Sprintf("/api/datasets/%s", fuelData.ID)).
+			HasStatusCode(http.StatusOK).
+			Value(ds)
+
+		require.NotNil(t, ds.Lifecycle)
+		assert.Equal(t, service.LifecycleStatusSunsetScheduled, ds.Lifecycle.Status)
+		require.NotNil(t, ds.Lifecycle.Sunset)
+		assert.True(t, sunset.Equal(*ds.Lifecycle.Sunset))
+	})
+
+	t.Run("Request access to deprecated dataset", func(t *testing.T) {
+		NewTester(t, otherServer).
+			Post(service.NewAccessRequestDTO{
+				DatasetID:   fuelData.ID,
+				Subject:     strToStrPtr(UserTwoEmail),
+				SubjectType: strToStrPtr(service.SubjectTypeUser),
+			}, "/api/accessRequests/new").
+			HasStatusCode(http.StatusBadRequest)
+	})
+
+	t.Run("Retire dataset past its sunset date", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour)
+
+		err := stores.LifecycleStorage.SetDatasetLifecycle(ctx, fuelData.ID, service.Lifecycle{
+			Status: service.LifecycleStatusSunsetScheduled,
+			Sunset: &past,
+		})
+		require.NoError(t, err)
+
+		err = lifecycleService.RetireSunsetDatasets(ctx)
+		require.NoError(t, err)
+
+		ds, err := stores.DataProductsStorage.GetDataset(ctx, fuelData.ID)
+		require.NoError(t, err)
+		require.NotNil(t, ds.Lifecycle)
+		assert.Equal(t, service.LifecycleStatusRetired, ds.Lifecycle.Status)
+
+		accesses, err := stores.AccessStorage.ListActiveAccessToDataset(ctx, fuelData.ID)
+		require.NoError(t, err)
+		assert.Len(t, accesses, 0)
+
+		hits, err := stores.SearchStorage.Search(ctx, &service.SearchOptions{
+			Types: []string{"dataset"},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, hits)
+	})
+
+	t.Run("Update lifecycle of retired dataset", func(t *testing.T) {
+		NewTester(t, ownerServer).Put(deprecate, fmt.Sprintf("/api/lifecycle/datasets/%s", fuelData.ID)).
+			HasStatusCode(http.StatusBadRequest)
+	})
+}
@@ -111,6 +111,14 @@ func TestMetabase(t *testing.T) {
 		log,
 	)
 
+	policyService := core.NewPolicyService(
+		stores.PolicyStorage,
+		stores.DataProductsStorage,
+		stores.AccessStorage,
+		GroupEmailNada,
+		"group:"+GroupEmailAllUsers,
+	)
+
 	mbService := core.NewMetabaseService(
 		Project,
 		fakeMetabaseSA,
@@ -119,11 +127,14 @@ func TestMetabase(t *testing.T) {
 		mbapi,
 		bqapi,
 		saapi,
+		service.NewDatasourceProviders(gcp.NewBigQueryDatasourceProvider(bqapi)),
 		stores.ThirdPartyMappingStorage,
 		stores.MetaBaseStorage,
 		stores.BigQueryStorage,
 		stores.DataProductsStorage,
 		stores.AccessStorage,
+		stores.DatasourceStorage,
+		policyService,
 		zlog,
 	)
 
@@ -141,6 +152,10 @@ func TestMetabase(t *testing.T) {
 		stores.BigQueryStorage,
 		bqapi,
 		stores.NaisConsoleStorage,
+		stores.AccessStorage,
+		mbService,
+		stores.DatasourceStorage,
+		service.NewDatasourceProviders(gcp.NewBigQueryDatasourceProvider(bqapi)),
 		GroupEmailAllUsers,
 	)
 
@@ -160,16 +175,26 @@ func TestMetabase(t *testing.T) {
 	}
 
 	{
-		slack := static.NewSlackAPI(log)
+		notificationService := core.NewNotificationService(
+			"",
+			stores.NotificationStorage,
+			static.NewSlackAPI(log),
+			static.NewEmailAPI(log),
+			log,
+		)
 		s := core.NewAccessService(
 			"",
-			slack,
+			notificationService,
+			policyService,
+			stores.AccessApprovalRuleStorage,
 			stores.PollyStorage,
 			stores.AccessStorage,
 			stores.DataProductsStorage,
 			stores.BigQueryStorage,
 			stores.JoinableViewsStorage,
 			bqapi,
+			stores.DatasourceStorage,
+			service.NewDatasourceProviders(gcp.NewBigQueryDatasourceProvider(bqapi)),
 		)
 		h := handlers.NewAccessHandler(s, mbService, Project)
 		e := routes.NewAccessEndpoints(zlog, h)
@@ -0,0 +1,276 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/config/v2"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core"
+	"github.com/navikt/nada-backend/pkg/service/core/api/smtp"
+	"github.com/navikt/nada-backend/pkg/service/core/api/static"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/routes"
+	"github.com/navikt/nada-backend/pkg/service/core/storage"
+	smtpEmulator "github.com/navikt/nada-backend/pkg/smtp/emulator"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifications(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(10*time.Minute))
+	defer cancel()
+
+	log := zerolog.New(os.Stdout)
+	c := NewContainers(t, log)
+	defer c.Cleanup()
+
+	pgCfg := c.RunPostgres(NewPostgresConfig())
+
+	repo, err := database.New(
+		pgCfg.ConnectionURL(),
+		10,
+		10,
+	)
+	assert.NoError(t, err)
+
+	em := smtpEmulator.New(log)
+	host, port := em.Run()
+	defer em.Close()
+
+	stores := storage.NewStores(repo, config.Config{}, log)
+
+	notificationService := core.NewNotificationService(
+		"https://data.nav.no",
+		stores.NotificationStorage,
+		static.NewSlackAPI(log),
+		smtp.NewEmailAPI(host, port, "nada@nav.no", "", ""),
+		log,
+	)
+
+	zlog := zerolog.New(os.Stdout)
+	r := TestRouter(zlog)
+
+	{
+		h := handlers.NewNotificationsHandler(notificationService)
+		e := routes.NewNotificationsEndpoints(zlog, h)
+		routes.NewNotificationsRoutes(e, injectUser(UserOne))(r)
+	}
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	t.Run("Get default preferences", func(t *testing.T) {
+		got := &service.NotificationPreferences{}
+		NewTester(t, server).Get("/api/notifications/preferences").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		require.Len(t, got.Preferences, len(service.NotificationEventTypes))
+		for _, p := range got.Preferences {
+			assert.Equal(t, service.NotificationChannelInApp, p.Channel)
+			assert.False(t, p.Digest)
+		}
+	})
+
+	t.Run("Update preferences with unknown channel", func(t *testing.T) {
+		NewTester(t, server).Put(service.NotificationPreferences{
+			Preferences: []*service.NotificationPreference{
+				{EventType: service.NotificationEventAccessRequestApproved, Channel: "pigeon"},
+			},
+		}, "/api/notifications/preferences").
+			HasStatusCode(http.StatusBadRequest)
+	})
+
+	t.Run("Update preferences", func(t *testing.T) {
+		got := &service.NotificationPreferences{}
+		NewTester(t, server).Put(service.NotificationPreferences{
+			Preferences: []*service.NotificationPreference{
+				{EventType: service.NotificationEventAccessRequestApproved, Channel: service.NotificationChannelEmail},
+				{EventType: service.NotificationEventDatasetDeprecated, Channel: service.NotificationChannelEmail, Digest: true},
+				{EventType: service.NotificationEventSchemaChanged, Channel: service.NotificationChannelInApp},
+			},
+		}, "/api/notifications/preferences").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		byEventType := map[service.NotificationEventType]*service.NotificationPreference{}
+		for _, p := range got.Preferences {
+			byEventType[p.EventType] = p
+		}
+
+		assert.Equal(t, service.NotificationChannelEmail, byEventType[service.NotificationEventAccessRequestApproved].Channel)
+		assert.True(t, byEventType[service.NotificationEventDatasetDeprecated].Digest)
+		assert.Equal(t, service.NotificationChannelInApp, byEventType[service.NotificationEventSchemaChanged].Channel)
+		assert.Equal(t, service.NotificationChannelInApp, byEventType[service.NotificationEventAccessExpiring].Channel)
+	})
+
+	t.Run("Notify by email", func(t *testing.T) {
+		em.Reset()
+
+		err := notificationService.Notify(ctx, service.NewNotification{
+			EventType:  service.NotificationEventAccessRequestApproved,
+			Recipients: []string{"user:" + UserOneEmail},
+			Title:      "Søknad om tilgang godkjent",
+			Message:    "Søknaden om tilgang til datasettet er godkjent.",
+			Link:       strToStrPtr("https://data.nav.no/dataproduct/1"),
+		})
+		require.NoError(t, err)
+
+		// The email is queued, and only sent when the deliveries are run
+		assert.Len(t, em.Messages(), 0)
+
+		err = notificationService.DeliverNotifications(ctx)
+		require.NoError(t, err)
+
+		messages := em.Messages()
+		require.Len(t, messages, 1)
+		assert.Equal(t, []string{UserOneEmail}, messages[0].To)
+		assert.Equal(t, "Søknad om tilgang godkjent", messages[0].Subject)
+		assert.Equal(t, "Søknaden om tilgang til datasettet er godkjent.\nLink: https://data.nav.no/dataproduct/1", messages[0].Body)
+
+		err = notificationService.DeliverNotifications(ctx)
+		require.NoError(t, err)
+		assert.Len(t, em.Messages(), 1)
+	})
+
+	t.Run("Notify in app only", func(t *testing.T) {
+		em.Reset()
+
+		err := notificationService.Notify(ctx, service.NewNotification{
+			EventType:  service.NotificationEventSchemaChanged,
+			Recipients: []string{"user:" + UserOneEmail, "group:" + GroupEmailNada},
+			Title:      "Skjemaendring i datasett",
+			Message:    "Skjemaet til datasettet er endret.",
+		})
+		require.NoError(t, err)
+
+		err = notificationService.DeliverNotifications(ctx)
+		require.NoError(t, err)
+
+		assert.Len(t, em.Messages(), 0)
+	})
+
+	t.Run("Notify in digest", func(t *testing.T) {
+		em.Reset()
+
+		err := notificationService.Notify(ctx, service.NewNotification{
+			EventType:  service.NotificationEventDatasetDeprecated,
+			Recipients: []string{"user:" + UserOneEmail},
+			Title:      "Datasett utfaset",
+			Message:    "Datasettet er utfaset.",
+		})
+		require.NoError(t, err)
+
+		err = notificationService.SendDigests(ctx)
+		require.NoError(t, err)
+		assert.Len(t, em.Messages(), 0)
+
+		_, err = repo.GetDB().ExecContext(ctx, "UPDATE notifications SET created = $1 WHERE digest_channel IS NOT NULL", time.Now().Add(-25*time.Hour))
+		require.NoError(t, err)
+
+		err = notificationService.SendDigests(ctx)
+		require.NoError(t, err)
+
+		messages := em.Messages()
+		require.Len(t, messages, 1)
+		assert.Equal(t, "Du har 1 nye varsler på Datamarkedsplassen", messages[0].Subject)
+		assert.Contains(t, messages[0].Body, "Datasettet er utfaset.")
+
+		err = notificationService.SendDigests(ctx)
+		require.NoError(t, err)
+		assert.Len(t, em.Messages(), 1)
+	})
+
+	t.Run("Get inbox", func(t *testing.T) {
+		got := &service.Page[*service.Notification]{}
+		NewTester(t, server).Get("/api/notifications/", "limit", "2").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		require.Len(t, got.Items, 2)
+		assert.NotEmpty(t, got.NextCursor)
+
+		next := &service.Page[*service.Notification]{}
+		NewTester(t, server).Get("/api/notifications/", "limit", "2", "cursor", got.NextCursor).
+			HasStatusCode(http.StatusOK).
+			Value(next)
+
+		require.Len(t, next.Items, 2)
+		assert.Empty(t, next.NextCursor)
+
+		recipients := map[string]bool{}
+		for _, n := range append(got.Items, next.Items...) {
+			recipients[n.Recipient] = true
+			assert.Nil(t, n.Read)
+		}
+
+		assert.True(t, recipients[GroupEmailNada])
+	})
+
+	t.Run("Mark notifications read", func(t *testing.T) {
+		unread := &service.Page[*service.Notification]{}
+		NewTester(t, server).Get("/api/notifications/", "unread", "true").
+			HasStatusCode(http.StatusOK).
+			Value(unread)
+
+		require.Len(t, unread.Items, 4)
+
+		NewTester(t, server).Post(service.MarkNotificationsRead{
+			IDs: []uuid.UUID{unread.Items[0].ID},
+		}, "/api/notifications/read").
+			HasStatusCode(http.StatusNoContent)
+
+		got := &service.Page[*service.Notification]{}
+		NewTester(t, server).Get("/api/notifications/", "unread", "true").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		assert.Len(t, got.Items, 3)
+
+		NewTester(t, server).Post(nil, "/api/notifications/readAll").
+			HasStatusCode(http.StatusNoContent)
+
+		got = &service.Page[*service.Notification]{}
+		NewTester(t, server).Get("/api/notifications/", "unread", "true").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		assert.Len(t, got.Items, 0)
+	})
+
+	t.Run("Notify about expiring access once", func(t *testing.T) {
+		StorageCreateProductAreasAndTeams(t, stores.ProductAreaStorage)
+		fuel := StorageCreateDataproduct(t, stores.DataProductsStorage, NewDataProductBiofuelProduction(GroupEmailNada, TeamSeagrassID))
+
+		ds, err := stores.DataProductsStorage.CreateDataset(ctx, NewDatasetBiofuelConsumptionRates(fuel.ID), nil, UserOne)
+		require.NoError(t, err)
+
+		expires := time.Now().Add(72 * time.Hour)
+		err = stores.AccessStorage.GrantAccessToDatasetAndRenew(ctx, ds.ID, &expires, "user:"+UserOneEmail, UserOneEmail, UserOneEmail)
+		require.NoError(t, err)
+
+		err = notificationService.NotifyExpiringAccess(ctx)
+		require.NoError(t, err)
+
+		err = notificationService.NotifyExpiringAccess(ctx)
+		require.NoError(t, err)
+
+		got := &service.Page[*service.Notification]{}
+		NewTester(t, server).Get("/api/notifications/", "unread", "true").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		require.Len(t, got.Items, 1)
+		assert.Equal(t, service.NotificationEventAccessExpiring, got.Items[0].EventType)
+		assert.Contains(t, got.Items[0].Message, "Biofuel Consumption Rates")
+	})
+}
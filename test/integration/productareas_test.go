@@ -16,6 +16,7 @@ import (
 	"github.com/navikt/nada-backend/pkg/service/core/storage"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestProductArea(t *testing.T) {
@@ -160,4 +161,29 @@ func TestProductArea(t *testing.T) {
 			HasStatusCode(http.StatusOK).
 			Expect(expect, got)
 	})
+
+	t.Run("Get product area dataproducts for a team", func(t *testing.T) {
+		got := &service.Page[*service.Dataproduct]{}
+		NewTester(t, server).Get(fmt.Sprintf("/api/productareas/%s/dataproducts", ProductAreaOceanicID), "teamId", TeamSeagrassID.String(), "limit", "1").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		require.Len(t, got.Items, 1)
+		assert.Equal(t, feed.ID, got.Items[0].ID)
+		require.NotEmpty(t, got.NextCursor)
+
+		next := &service.Page[*service.Dataproduct]{}
+		NewTester(t, server).Get(fmt.Sprintf("/api/productareas/%s/dataproducts", ProductAreaOceanicID), "teamId", TeamSeagrassID.String(), "limit", "1", "cursor", got.NextCursor).
+			HasStatusCode(http.StatusOK).
+			Value(next)
+
+		require.Len(t, next.Items, 1)
+		assert.Equal(t, fuel.ID, next.Items[0].ID)
+		assert.Empty(t, next.NextCursor)
+	})
+
+	t.Run("Get product area dataproducts for a team in another product area should return 404", func(t *testing.T) {
+		NewTester(t, server).Get(fmt.Sprintf("/api/productareas/%s/dataproducts", ProductAreaOceanicID), "teamId", TeamReefID.String()).
+			HasStatusCode(http.StatusNotFound)
+	})
 }
@@ -0,0 +1,150 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/goccy/bigquery-emulator/types"
+	"github.com/navikt/nada-backend/pkg/bq"
+	"github.com/navikt/nada-backend/pkg/bq/emulator"
+	"github.com/navikt/nada-backend/pkg/config/v2"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core"
+	"github.com/navikt/nada-backend/pkg/service/core/api/gcp"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/routes"
+	"github.com/navikt/nada-backend/pkg/service/core/storage"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatasetProfiling(t *testing.T) {
+	log := zerolog.New(os.Stdout)
+	c := NewContainers(t, log)
+	defer c.Cleanup()
+
+	pgCfg := c.RunPostgres(NewPostgresConfig())
+
+	repo, err := database.New(
+		pgCfg.ConnectionURL(),
+		10,
+		10,
+	)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+
+	fuelBqSchema := NewDatasetBiofuelConsumptionRatesSchema()
+	fuelBqSchema[0].Rows = types.Data{
+		{"id": "1", "fuel_type": "ethanol", "consumption_rate": "2.5", "unit": "l"},
+		{"id": "2", "fuel_type": "biodiesel", "consumption_rate": "1.5", "unit": "l"},
+		{"id": "3", "fuel_type": "ethanol", "consumption_rate": nil, "unit": "l"},
+	}
+
+	em := emulator.New(log)
+	em.WithProject(Project, fuelBqSchema...)
+	em.TestServer()
+	defer em.Cleanup()
+
+	bqClient := bq.NewClient(em.Endpoint(), false, zerolog.Nop())
+
+	stores := storage.NewStores(repo, config.Config{}, log)
+
+	StorageCreateProductAreasAndTeams(t, stores.ProductAreaStorage)
+	fuel := StorageCreateDataproduct(t, stores.DataProductsStorage, NewDataProductBiofuelProduction(GroupEmailNada, TeamSeagrassID))
+
+	rates := NewDatasetBiofuelConsumptionRates(fuel.ID)
+	rates.BigQuery.PiiTags = strToStrPtr(`{"id": "PII_DirekteIdentifiserende"}`)
+	rates.Metadata = service.BigqueryMetadata{
+		TableType: service.RegularTable,
+		Schema: service.BigquerySchema{
+			Columns: []*service.BigqueryColumn{
+				{Name: "id", Type: "STRING", Mode: "REQUIRED"},
+				{Name: "fuel_type", Type: "STRING", Mode: "NULLABLE"},
+				{Name: "consumption_rate", Type: "STRING", Mode: "NULLABLE"},
+				{Name: "unit", Type: "STRING", Mode: "NULLABLE"},
+			},
+		},
+	}
+	ds, err := stores.DataProductsStorage.CreateDataset(ctx, rates, nil, &service.User{Email: UserOneEmail})
+	require.NoError(t, err)
+
+	r := TestRouter(log)
+
+	s := core.NewProfilingService(
+		stores.ProfilingStorage,
+		stores.DataProductsStorage,
+		stores.AccessStorage,
+		gcp.NewBigQueryAPI(Project, Location, PseudoDataSet, bqClient),
+		0,
+		log,
+	)
+
+	noAccessRouter := TestRouter(log)
+
+	{
+		h := handlers.NewProfilingHandler(s)
+		e := routes.NewProfilingEndpoints(log, h)
+		routes.NewProfilingRoutes(e, injectUser(UserOne))(r)
+		routes.NewProfilingRoutes(e, injectUser(UserTwo))(noAccessRouter)
+	}
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	noAccessServer := httptest.NewServer(noAccessRouter)
+	defer noAccessServer.Close()
+
+	t.Run("Profile datasources", func(t *testing.T) {
+		err := s.ProfileDatasources(ctx)
+		require.NoError(t, err)
+
+		got := &service.DatasetProfiles{}
+		NewTester(t, server).Get("/api/datasets/" + ds.ID.String() + "/profiles").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		require.Len(t, got.Profiles, 1)
+		assert.Equal(t, int64(3), got.Profiles[0].RowCount)
+
+		columns := map[string]*service.ColumnProfile{}
+		for _, c := range got.Profiles[0].Columns {
+			columns[c.Name] = c
+		}
+		require.Len(t, columns, 4)
+
+		assert.True(t, columns["id"].Masked)
+		assert.Nil(t, columns["id"].Min)
+		assert.Empty(t, columns["id"].TopValues)
+
+		assert.False(t, columns["fuel_type"].Masked)
+		require.NotNil(t, columns["fuel_type"].DistinctCount)
+		assert.Equal(t, int64(2), *columns["fuel_type"].DistinctCount)
+		require.NotEmpty(t, columns["fuel_type"].TopValues)
+		assert.Equal(t, "ethanol", *columns["fuel_type"].TopValues[0].Value)
+		assert.Equal(t, int64(2), columns["fuel_type"].TopValues[0].Count)
+
+		assert.InDelta(t, 1.0/3.0, columns["consumption_rate"].NullRate, 0.001)
+	})
+
+	t.Run("Get latest table profile", func(t *testing.T) {
+		profile, err := stores.ProfilingStorage.GetLatestTableProfile(ctx, Project, fuelBqSchema[0].DatasetID, fuelBqSchema[0].TableID)
+		require.NoError(t, err)
+		assert.Equal(t, ds.ID, profile.DatasetID)
+	})
+
+	t.Run("Get profiles without access to the dataset", func(t *testing.T) {
+		NewTester(t, noAccessServer).Get("/api/datasets/" + ds.ID.String() + "/profiles").
+			HasStatusCode(http.StatusForbidden)
+	})
+
+	t.Run("Get profiles with invalid limit", func(t *testing.T) {
+		NewTester(t, server).Get("/api/datasets/"+ds.ID.String()+"/profiles", "limit", "0").
+			HasStatusCode(http.StatusBadRequest)
+	})
+}
@@ -0,0 +1,269 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/goccy/bigquery-emulator/types"
+	"github.com/navikt/nada-backend/pkg/bq"
+	"github.com/navikt/nada-backend/pkg/bq/emulator"
+	"github.com/navikt/nada-backend/pkg/config/v2"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core"
+	"github.com/navikt/nada-backend/pkg/service/core/api/gcp"
+	"github.com/navikt/nada-backend/pkg/service/core/api/static"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/routes"
+	"github.com/navikt/nada-backend/pkg/service/core/storage"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQualityChecks(t *testing.T) {
+	log := zerolog.New(os.Stdout)
+	c := NewContainers(t, log)
+	defer c.Cleanup()
+
+	pgCfg := c.RunPostgres(NewPostgresConfig())
+
+	repo, err := database.New(
+		pgCfg.ConnectionURL(),
+		10,
+		10,
+	)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+
+	fuelBqSchema := NewDatasetBiofuelConsumptionRatesSchema()
+	fuelBqSchema[0].Rows = types.Data{
+		{"id": "1", "fuel_type": "ethanol", "consumption_rate": "2.5", "unit": "l"},
+		{"id": "2", "fuel_type": "biodiesel", "consumption_rate": "1.5", "unit": "l"},
+		{"id": "3", "fuel_type": "ethanol", "consumption_rate": nil, "unit": "l"},
+	}
+
+	em := emulator.New(log)
+	em.WithProject(Project, fuelBqSchema...)
+	em.TestServer()
+	defer em.Cleanup()
+
+	bqClient := bq.NewClient(em.Endpoint(), false, zerolog.Nop())
+
+	stores := storage.NewStores(repo, config.Config{}, log)
+
+	StorageCreateProductAreasAndTeams(t, stores.ProductAreaStorage)
+	fuel := StorageCreateDataproduct(t, stores.DataProductsStorage, NewDataProductBiofuelProduction(GroupEmailNada, TeamSeagrassID))
+
+	rates := NewDatasetBiofuelConsumptionRates(fuel.ID)
+	rates.Metadata = service.BigqueryMetadata{
+		TableType: service.RegularTable,
+		Schema: service.BigquerySchema{
+			Columns: []*service.BigqueryColumn{
+				{Name: "id", Type: "STRING", Mode: "REQUIRED"},
+				{Name: "fuel_type", Type: "STRING", Mode: "NULLABLE"},
+				{Name: "consumption_rate", Type: "STRING", Mode: "NULLABLE"},
+				{Name: "unit", Type: "STRING", Mode: "NULLABLE"},
+			},
+		},
+	}
+	ds, err := stores.DataProductsStorage.CreateDataset(ctx, rates, nil, &service.User{Email: UserOneEmail})
+	require.NoError(t, err)
+
+	notificationService := core.NewNotificationService(
+		"https://data.nav.no",
+		stores.NotificationStorage,
+		static.NewSlackAPI(log),
+		static.NewEmailAPI(log),
+		log,
+	)
+
+	s := core.NewQualityCheckService(
+		"https://data.nav.no",
+		stores.QualityCheckStorage,
+		stores.DataProductsStorage,
+		stores.AccessStorage,
+		gcp.NewBigQueryAPI(Project, Location, PseudoDataSet, bqClient),
+		notificationService,
+		log,
+	)
+
+	ownerRouter := TestRouter(log)
+	userRouter := TestRouter(log)
+
+	{
+		h := handlers.NewQualityCheckHandler(s)
+		e := routes.NewQualityCheckEndpoints(log, h)
+		routes.NewQualityCheckRoutes(e, injectUser(UserOne))(ownerRouter)
+		routes.NewQualityCheckRoutes(e, injectUser(UserTwo))(userRouter)
+	}
+
+	ownerServer := httptest.NewServer(ownerRouter)
+	defer ownerServer.Close()
+
+	userServer := httptest.NewServer(userRouter)
+	defer userServer.Close()
+
+	var threshold int64 = 3
+
+	newChecks := []service.NewQualityCheck{
+		{Name: "id is set", Kind: service.QualityCheckKindNotNull, Column: strToStrPtr("id")},
+		{Name: "fuel type is unique", Kind: service.QualityCheckKindUnique, Column: strToStrPtr("fuel_type")},
+		{Name: "has rows", Kind: service.QualityCheckKindRowCount, Threshold: &threshold},
+		{Name: "unit is litres", Kind: service.QualityCheckKindSQL, SQL: strToStrPtr("SELECT * FROM {{table}} WHERE unit != 'l'")},
+	}
+
+	checks := map[string]*service.QualityCheck{}
+
+	t.Run("Create quality checks", func(t *testing.T) {
+		for _, input := range newChecks {
+			got := &service.QualityCheck{}
+			NewTester(t, ownerServer).Post(input, "/api/datasets/"+ds.ID.String()+"/quality-checks").
+				HasStatusCode(http.StatusOK).
+				Value(got)
+
+			assert.Equal(t, input.Name, got.Name)
+			assert.Equal(t, UserOneEmail, got.CreatedBy)
+
+			checks[got.Name] = got
+		}
+	})
+
+	t.Run("Create quality check with existing name", func(t *testing.T) {
+		NewTester(t, ownerServer).Post(newChecks[0], "/api/datasets/"+ds.ID.String()+"/quality-checks").
+			HasStatusCode(http.StatusBadRequest)
+	})
+
+	t.Run("Create quality check without table placeholder", func(t *testing.T) {
+		input := service.NewQualityCheck{
+			Name: "other table",
+			Kind: service.QualityCheckKindSQL,
+			SQL:  strToStrPtr("SELECT 1"),
+		}
+
+		NewTester(t, ownerServer).Post(input, "/api/datasets/"+ds.ID.String()+"/quality-checks").
+			HasStatusCode(http.StatusBadRequest)
+	})
+
+	t.Run("Create quality check without being owner", func(t *testing.T) {
+		input := service.NewQualityCheck{
+			Name: "not owner",
+			Kind: service.QualityCheckKindRowCount,
+		}
+
+		NewTester(t, userServer).Post(input, "/api/datasets/"+ds.ID.String()+"/quality-checks").
+			HasStatusCode(http.StatusForbidden)
+	})
+
+	t.Run("Run quality checks", func(t *testing.T) {
+		err := s.RunQualityChecks(ctx)
+		require.NoError(t, err)
+
+		got := &service.QualityChecks{}
+		NewTester(t, userServer).Get("/api/datasets/" + ds.ID.String() + "/quality-checks").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		require.Len(t, got.Checks, len(newChecks))
+
+		statuses := map[string]service.QualityCheckStatus{}
+		measured := map[string]int64{}
+		for _, c := range got.Checks {
+			require.NotNil(t, c.LastRun)
+			statuses[c.Name] = c.LastRun.Status
+
+			if c.LastRun.Measured != nil {
+				measured[c.Name] = *c.LastRun.Measured
+			}
+		}
+
+		assert.Equal(t, service.QualityCheckStatusPassed, statuses["id is set"])
+		assert.Equal(t, service.QualityCheckStatusFailed, statuses["fuel type is unique"])
+		assert.Equal(t, int64(1), measured["fuel type is unique"])
+		assert.Equal(t, service.QualityCheckStatusPassed, statuses["has rows"])
+		assert.Equal(t, int64(3), measured["has rows"])
+		assert.Equal(t, service.QualityCheckStatusPassed, statuses["unit is litres"])
+	})
+
+	t.Run("Get quality status of dataset", func(t *testing.T) {
+		got, err := stores.DataProductsStorage.GetDataset(ctx, ds.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.QualityStatus)
+		assert.Equal(t, service.QualityCheckStatusFailed, got.QualityStatus.Status)
+	})
+
+	t.Run("Search on quality status", func(t *testing.T) {
+		count, err := stores.SearchStorage.SearchCount(ctx, &service.SearchOptions{
+			Types:           []string{"dataset"},
+			QualityStatuses: []string{string(service.QualityCheckStatusFailed)},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		count, err = stores.SearchStorage.SearchCount(ctx, &service.SearchOptions{
+			Types:           []string{"dataset"},
+			QualityStatuses: []string{string(service.QualityCheckStatusPassed)},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("Owners are notified about failed check", func(t *testing.T) {
+		page, err := notificationService.GetNotifications(ctx, UserOne, false, service.PageRequest{Limit: 10})
+		require.NoError(t, err)
+
+		var failed []*service.Notification
+		for _, n := range page.Items {
+			if n.EventType == service.NotificationEventQualityCheckFailed {
+				failed = append(failed, n)
+			}
+		}
+
+		require.Len(t, failed, 1)
+		assert.Contains(t, failed[0].Message, "fuel type is unique")
+	})
+
+	t.Run("Run quality check and get history", func(t *testing.T) {
+		id := checks["has rows"].ID.String()
+
+		run := &service.QualityCheckRun{}
+		NewTester(t, ownerServer).Post(nil, "/api/quality-checks/"+id+"/run").
+			HasStatusCode(http.StatusOK).
+			Value(run)
+
+		assert.Equal(t, service.QualityCheckStatusPassed, run.Status)
+
+		got := &service.QualityCheckRuns{}
+		NewTester(t, ownerServer).Get("/api/quality-checks/" + id + "/runs").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		assert.Len(t, got.Runs, 2)
+	})
+
+	t.Run("Get history without access to the dataset", func(t *testing.T) {
+		id := checks["has rows"].ID.String()
+
+		NewTester(t, userServer).Get("/api/quality-checks/" + id + "/runs").
+			HasStatusCode(http.StatusForbidden)
+	})
+
+	t.Run("Delete quality check", func(t *testing.T) {
+		id := checks["fuel type is unique"].ID.String()
+
+		NewTester(t, userServer).Delete("/api/quality-checks/" + id).
+			HasStatusCode(http.StatusForbidden)
+
+		NewTester(t, ownerServer).Delete("/api/quality-checks/" + id).
+			HasStatusCode(http.StatusNoContent)
+
+		got, err := stores.DataProductsStorage.GetDataset(ctx, ds.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.QualityStatus)
+		assert.Equal(t, service.QualityCheckStatusPassed, got.QualityStatus.Status)
+	})
+}
@@ -0,0 +1,230 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/navikt/nada-backend/pkg/bq"
+	bigQueryEmulator "github.com/navikt/nada-backend/pkg/bq/emulator"
+	"github.com/navikt/nada-backend/pkg/config/v2"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/errs"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core"
+	"github.com/navikt/nada-backend/pkg/service/core/api/gcp"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/routes"
+	"github.com/navikt/nada-backend/pkg/service/core/storage"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecycleBin(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(10*time.Minute))
+	defer cancel()
+
+	log := zerolog.New(os.Stdout)
+	c := NewContainers(t, log)
+	defer c.Cleanup()
+
+	pgCfg := c.RunPostgres(NewPostgresConfig())
+
+	repo, err := database.New(
+		pgCfg.ConnectionURL(),
+		10,
+		10,
+	)
+	assert.NoError(t, err)
+
+	bqe := bigQueryEmulator.New(log)
+	bqe.WithProject(Project, NewDatasetBiofuelConsumptionRatesSchema()...)
+	bqe.EnableMock(false, log, bigQueryEmulator.NewPolicyMock(log).Mocks()...)
+
+	bqHTTPAddr := fmt.Sprintf("127.0.0.1:%s", strconv.Itoa(GetFreePort(t)))
+	bqGRPCAddr := fmt.Sprintf("127.0.0.1:%s", strconv.Itoa(GetFreePort(t)))
+	go func() {
+		_ = bqe.Serve(ctx, bqHTTPAddr, bqGRPCAddr)
+	}()
+	bqClient := bq.NewClient("http://"+bqHTTPAddr, false, log)
+
+	stores := storage.NewStores(repo, config.Config{}, log)
+	bqapi := gcp.NewBigQueryAPI(Project, Location, PseudoDataSet, bqClient)
+
+	policyService := core.NewPolicyService(
+		stores.PolicyStorage,
+		stores.DataProductsStorage,
+		stores.AccessStorage,
+		GroupEmailNada,
+		"group:"+GroupEmailAllUsers,
+	)
+
+	// The dataset has never been added to Metabase, so the Metabase clients
+	// are never used when deleting and restoring it
+	mbService := core.NewMetabaseService(
+		Project,
+		fakeMetabaseSA,
+		"nada-metabase@test.iam.gserviceaccount.com",
+		GroupEmailAllUsers,
+		nil,
+		bqapi,
+		nil,
+		service.NewDatasourceProviders(gcp.NewBigQueryDatasourceProvider(bqapi)),
+		stores.ThirdPartyMappingStorage,
+		stores.MetaBaseStorage,
+		stores.BigQueryStorage,
+		stores.DataProductsStorage,
+		stores.AccessStorage,
+		stores.DatasourceStorage,
+		policyService,
+		log,
+	)
+
+	dataproductService := core.NewDataProductsService(
+		stores.DataProductsStorage,
+		stores.BigQueryStorage,
+		bqapi,
+		stores.NaisConsoleStorage,
+		stores.AccessStorage,
+		mbService,
+		stores.DatasourceStorage,
+		service.NewDatasourceProviders(gcp.NewBigQueryDatasourceProvider(bqapi)),
+		GroupEmailAllUsers,
+	)
+
+	insightProductService := core.NewInsightProductService(stores.InsightProductStorage)
+
+	// No stories are purged in this test, so the story API is never used
+	recycleBinService := core.NewRecycleBinService(
+		stores.RecycleBinStorage,
+		stores.DataProductsStorage,
+		stores.StoryStorage,
+		nil,
+		stores.InsightProductStorage,
+		stores.AccessStorage,
+		stores.DatasourceStorage,
+		service.NewDatasourceProviders(gcp.NewBigQueryDatasourceProvider(bqapi)),
+		mbService,
+		log,
+	)
+
+	StorageCreateProductAreasAndTeams(t, stores.ProductAreaStorage)
+	fuel, err := dataproductService.CreateDataproduct(ctx, UserOne, NewDataProductBiofuelProduction(GroupEmailNada, TeamSeagrassID))
+	require.NoError(t, err)
+
+	fuelData, err := dataproductService.CreateDataset(ctx, UserOne, NewDatasetBiofuelConsumptionRates(fuel.ID))
+	require.NoError(t, err)
+
+	err = stores.AccessStorage.GrantAccessToDatasetAndRenew(ctx, fuelData.ID, nil, "user:"+UserTwoEmail, UserTwoEmail, UserOneEmail)
+	require.NoError(t, err)
+
+	insight := StorageCreateInsightProduct(t, UserOneEmail, stores.InsightProductStorage, NewInsightProductBiofuelProduction(GroupEmailNada, TeamSeagrassID))
+
+	zlog := zerolog.New(os.Stdout)
+	ownerRouter := TestRouter(zlog)
+	otherRouter := TestRouter(zlog)
+
+	{
+		h := handlers.NewRecycleBinHandler(recycleBinService)
+		e := routes.NewRecycleBinEndpoints(zlog, h)
+		routes.NewRecycleBinRoutes(e, injectUser(UserOne))(ownerRouter)
+		routes.NewRecycleBinRoutes(e, injectUser(UserTwo))(otherRouter)
+	}
+
+	{
+		h := handlers.NewDataProductsHandler(dataproductService)
+		e := routes.NewDataProductsEndpoints(zlog, h)
+		routes.NewDataProductsRoutes(e, injectUser(UserOne))(ownerRouter)
+	}
+
+	ownerServer := httptest.NewServer(ownerRouter)
+	defer ownerServer.Close()
+
+	otherServer := httptest.NewServer(otherRouter)
+	defer otherServer.Close()
+
+	t.Run("Delete dataproduct moves it to the recycle bin", func(t *testing.T) {
+		NewTester(t, ownerServer).Delete(fmt.Sprintf("/api/dataproducts/%s", fuel.ID)).
+			HasStatusCode(http.StatusNoContent)
+
+		NewTester(t, ownerServer).Get(fmt.Sprintf("/api/dataproducts/%s", fuel.ID)).
+			HasStatusCode(http.StatusNotFound)
+
+		var got []*service.DeletedItem
+		NewTester(t, ownerServer).Get("/api/recycleBin/").
+			HasStatusCode(http.StatusOK).
+			Value(&got)
+
+		require.Len(t, got, 1)
+		assert.Equal(t, fuel.ID, got[0].ID)
+		assert.Equal(t, service.DeletedItemTypeDataproduct, got[0].Type)
+		assert.Equal(t, UserOneEmail, got[0].DeletedBy)
+		assert.Equal(t, got[0].Deleted.Add(service.RecycleBinRetention), got[0].PurgeAfter)
+
+		accesses, err := stores.AccessStorage.ListActiveAccessToDataset(ctx, fuelData.ID)
+		require.NoError(t, err)
+		assert.Len(t, accesses, 1)
+	})
+
+	t.Run("List recycle bin of another group", func(t *testing.T) {
+		NewTester(t, otherServer).Get("/api/recycleBin/", "group", GroupEmailNada).
+			HasStatusCode(http.StatusForbidden)
+
+		var got []*service.DeletedItem
+		NewTester(t, otherServer).Get("/api/recycleBin/").
+			HasStatusCode(http.StatusOK).
+			Value(&got)
+
+		assert.Len(t, got, 0)
+	})
+
+	t.Run("Restore dataproduct without being in the owner group", func(t *testing.T) {
+		NewTester(t, otherServer).Post(nil, fmt.Sprintf("/api/recycleBin/%s/restore", fuel.ID)).
+			HasStatusCode(http.StatusForbidden)
+	})
+
+	t.Run("Restore dataproduct", func(t *testing.T) {
+		got := &service.DeletedItem{}
+		NewTester(t, ownerServer).Post(nil, fmt.Sprintf("/api/recycleBin/%s/restore", fuel.ID)).
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		assert.Equal(t, fuel.ID, got.ID)
+
+		dp, err := stores.DataProductsStorage.GetDataproduct(ctx, fuel.ID)
+		require.NoError(t, err)
+		require.Len(t, dp.Datasets, 1)
+
+		NewTester(t, ownerServer).Post(nil, fmt.Sprintf("/api/recycleBin/%s/restore", fuel.ID)).
+			HasStatusCode(http.StatusNotFound)
+	})
+
+	t.Run("Purge items past the retention period", func(t *testing.T) {
+		_, err := insightProductService.DeleteInsightProduct(ctx, UserOne, insight.ID)
+		require.NoError(t, err)
+
+		_, err = dataproductService.DeleteDataproduct(ctx, UserOne, fuel.ID)
+		require.NoError(t, err)
+
+		_, err = repo.GetDB().ExecContext(ctx, "UPDATE insight_product SET deleted = $1 WHERE id = $2", time.Now().Add(-service.RecycleBinRetention-time.Hour), insight.ID)
+		require.NoError(t, err)
+
+		err = recycleBinService.PurgeDeletedItems(ctx)
+		require.NoError(t, err)
+
+		items, err := stores.RecycleBinStorage.GetDeletedItems(ctx, service.DeletedItemsFilter{})
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, fuel.ID, items[0].ID)
+
+		_, err = stores.InsightProductStorage.GetInsightProductWithTeamkatalogen(ctx, insight.ID)
+		assert.True(t, errs.KindIs(errs.NotExist, err))
+	})
+}
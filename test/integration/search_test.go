@@ -0,0 +1,202 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/navikt/nada-backend/pkg/config/v2"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/routes"
+	"github.com/navikt/nada-backend/pkg/service/core/storage"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+// searchResponse mirrors service.SearchResult, but leaves the results
+// undecoded since they are an interface type
+type searchResponse struct {
+	Results []json.RawMessage     `json:"results"`
+	Total   int                   `json:"total"`
+	Facets  *service.SearchFacets `json:"facets"`
+	Fuzzy   bool                  `json:"fuzzy"`
+}
+
+func TestSearch(t *testing.T) {
+	log := zerolog.New(os.Stdout)
+	c := NewContainers(t, log)
+	defer c.Cleanup()
+
+	pgCfg := c.RunPostgres(NewPostgresConfig())
+
+	repo, err := database.New(
+		pgCfg.ConnectionURL(),
+		10,
+		10,
+	)
+	assert.NoError(t, err)
+
+	r := TestRouter(log)
+
+	stores := storage.NewStores(repo, config.Config{}, log)
+
+	StorageCreateProductAreasAndTeams(t, stores.ProductAreaStorage)
+	biofuel := StorageCreateDataproduct(t, stores.DataProductsStorage, NewDataProductBiofuelProduction(GroupEmailNada, TeamSeagrassID))
+	StorageCreateDataproduct(t, stores.DataProductsStorage, NewDataProductAquacultureFeed(GroupEmailNada, TeamSeagrassID))
+	StorageCreateDataproduct(t, stores.DataProductsStorage, NewDataProductReefMonitoring(GroupEmailReef, TeamReefID))
+
+	_, err = stores.DataProductsStorage.CreateDataset(context.Background(), NewDatasetBiofuelConsumptionRates(biofuel.ID), nil, UserOne)
+	assert.NoError(t, err)
+
+	biofuelStory := NewStoryBiofuelProduction(GroupEmailNada)
+	biofuelStory.TeamID = &TeamSeagrassID
+	StorageCreateStory(t, stores.StoryStorage, UserOneEmail, biofuelStory)
+
+	aquacultureStory := NewStoryAquacultureFeed(GroupEmailNada)
+	aquacultureStory.TeamID = &TeamSeagrassID
+	StorageCreateStory(t, stores.StoryStorage, UserOneEmail, aquacultureStory)
+
+	{
+		s := core.NewSearchService(stores.SearchStorage, stores.StoryStorage, stores.DataProductsStorage)
+		h := handlers.NewSearchHandler(s)
+		e := routes.NewSearchEndpoints(log, h)
+		f := routes.NewSearchRoutes(e)
+		f(r)
+	}
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	t.Run("Search returns total and facets", func(t *testing.T) {
+		got := &searchResponse{}
+
+		NewTester(t, server).Get("/api/search/", "types", "dataproduct,story", "limit", "2").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		assert.Len(t, got.Results, 2)
+		assert.Equal(t, 5, got.Total)
+		assert.Equal(t, []*service.SearchFacetValue{
+			{Value: "dataproduct", Count: 3},
+			{Value: "story", Count: 2},
+		}, got.Facets.Types)
+		assert.Equal(t, []*service.SearchFacetValue{
+			{Value: TeamSeagrassID.String(), Label: TeamSeagrassName, Count: 4},
+			{Value: TeamReefID.String(), Label: TeamReefName, Count: 1},
+		}, got.Facets.Teams)
+		assert.Equal(t, []*service.SearchFacetValue{
+			{Value: ProductAreaOceanicID.String(), Label: ProductAreaOceanicName, Count: 4},
+			{Value: ProductAreaCostalID.String(), Label: ProductAreaCostalName, Count: 1},
+		}, got.Facets.ProductAreas)
+		assert.Equal(t, []*service.SearchFacetValue{
+			{Value: "seagrass", Count: 2},
+			{Value: "aquaculture", Count: 1},
+			{Value: "biofuel", Count: 1},
+			{Value: "feed", Count: 1},
+			{Value: "production", Count: 1},
+		}, got.Facets.Keywords)
+		assert.Empty(t, got.Facets.PiiLevels)
+	})
+
+	t.Run("Search facets are computed over the filtered result set", func(t *testing.T) {
+		got := &searchResponse{}
+
+		NewTester(t, server).Get("/api/search/", "types", "dataproduct,story", "productAreaIDs", ProductAreaCostalID.String()).
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		assert.Len(t, got.Results, 1)
+		assert.Equal(t, 1, got.Total)
+		assert.Equal(t, []*service.SearchFacetValue{
+			{Value: "dataproduct", Count: 1},
+		}, got.Facets.Types)
+		assert.Equal(t, []*service.SearchFacetValue{
+			{Value: TeamReefID.String(), Label: TeamReefName, Count: 1},
+		}, got.Facets.Teams)
+		assert.Empty(t, got.Facets.Keywords)
+	})
+	t.Run("Search returns dataset hits counted in the total", func(t *testing.T) {
+		got := &searchResponse{}
+
+		NewTester(t, server).Get("/api/search/", "types", "dataset").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		assert.Equal(t, 1, got.Total)
+		assert.Len(t, got.Results, 1)
+		assert.Equal(t, []*service.SearchFacetValue{
+			{Value: "dataset", Count: 1},
+		}, got.Facets.Types)
+	})
+
+	t.Run("Search with full text match is not fuzzy", func(t *testing.T) {
+		got := &searchResponse{}
+
+		NewTester(t, server).Get("/api/search/", "types", "dataproduct", "text", "aquaculture").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		assert.False(t, got.Fuzzy)
+		assert.Equal(t, 1, got.Total)
+	})
+
+	t.Run("Search with typo falls back to fuzzy matching", func(t *testing.T) {
+		got := &searchResponse{}
+
+		NewTester(t, server).Get("/api/search/", "types", "dataproduct", "text", "aquaculure").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		assert.True(t, got.Fuzzy)
+		assert.Equal(t, 1, got.Total)
+		assert.Len(t, got.Results, 1)
+	})
+
+	t.Run("Suggest names and keywords from prefix", func(t *testing.T) {
+		got := &service.SearchSuggestions{}
+
+		NewTester(t, server).Get("/api/search/suggest", "text", "bio").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		names := map[string]string{}
+		for _, n := range got.Names {
+			names[n.Type] = n.Name
+		}
+
+		assert.Equal(t, map[string]string{
+			"dataproduct": "Biofuel Production",
+			"dataset":     "Biofuel Consumption Rates",
+			"story":       "Biofuel Production",
+		}, names)
+		assert.Equal(t, []service.KeywordItem{
+			{Keyword: "biofuel", Count: 2},
+		}, got.Keywords)
+	})
+
+	t.Run("Suggest team names", func(t *testing.T) {
+		got := &service.SearchSuggestions{}
+
+		NewTester(t, server).Get("/api/search/suggest", "text", "sea", "limit", "5").
+			HasStatusCode(http.StatusOK).
+			Value(got)
+
+		assert.Len(t, got.Names, 1)
+		assert.Equal(t, TeamSeagrassName, got.Names[0].Name)
+		assert.Equal(t, "team", got.Names[0].Type)
+		assert.Equal(t, []service.KeywordItem{
+			{Keyword: "seagrass", Count: 2},
+		}, got.Keywords)
+	})
+
+	t.Run("Suggest with invalid limit", func(t *testing.T) {
+		NewTester(t, server).Get("/api/search/suggest", "text", "sea", "limit", "1000").
+			HasStatusCode(http.StatusBadRequest)
+	})
+}
@@ -0,0 +1,228 @@
+package integration
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/navikt/nada-backend/pkg/config/v2"
+	"github.com/navikt/nada-backend/pkg/database"
+	"github.com/navikt/nada-backend/pkg/service"
+	"github.com/navikt/nada-backend/pkg/service/core"
+	"github.com/navikt/nada-backend/pkg/service/core/api/static"
+	"github.com/navikt/nada-backend/pkg/service/core/handlers"
+	"github.com/navikt/nada-backend/pkg/service/core/routes"
+	"github.com/navikt/nada-backend/pkg/service/core/storage"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const slackSigningSecret = "fake_signing_secret"
+
+func TestSlackInteractions(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(10*time.Minute))
+	defer cancel()
+
+	log := zerolog.New(os.Stdout)
+	c := NewContainers(t, log)
+	defer c.Cleanup()
+
+	pgCfg := c.RunPostgres(NewPostgresConfig())
+
+	repo, err := database.New(
+		pgCfg.ConnectionURL(),
+		10,
+		10,
+	)
+	assert.NoError(t, err)
+
+	stores := storage.NewStores(repo, config.Config{}, log)
+	slackapi := static.NewSlackAPI(log)
+
+	notificationService := core.NewNotificationService(
+		"https://data.nav.no",
+		stores.NotificationStorage,
+		slackapi,
+		static.NewEmailAPI(log),
+		log,
+	)
+
+	policyService := core.NewPolicyService(
+		stores.PolicyStorage,
+		stores.DataProductsStorage,
+		stores.AccessStorage,
+		GroupEmailNada,
+		"group:"+GroupEmailAllUsers,
+	)
+
+	accessService := core.NewAccessService(
+		"https://data.nav.no",
+		notificationService,
+		policyService,
+		stores.AccessApprovalRuleStorage,
+		stores.PollyStorage,
+		stores.AccessStorage,
+		stores.DataProductsStorage,
+		stores.BigQueryStorage,
+		stores.JoinableViewsStorage,
+		nil,
+		stores.DatasourceStorage,
+		service.NewDatasourceProviders(),
+	)
+
+	// The static slack api uses the slack user id as the email of the user
+	slackService := core.NewSlackService(
+		slackapi,
+		static.NewGoogleGroupsAPI(map[string]service.Groups{
+			UserOneEmail: UserOne.GoogleGroups,
+			UserTwoEmail: UserTwo.GoogleGroups,
+		}),
+		accessService,
+		log,
+	)
+
+	zlog := zerolog.New(os.Stdout)
+	r := TestRouter(zlog)
+
+	{
+		h := handlers.NewSlackHandler(slackService, slackSigningSecret)
+		e := routes.NewSlackEndpoints(zlog, h)
+		routes.NewSlackRoutes(e)(r)
+	}
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	StorageCreateProductAreasAndTeams(t, stores.ProductAreaStorage)
+	fuel := StorageCreateDataproduct(t, stores.DataProductsStorage, NewDataProductBiofuelProduction(GroupEmailNada, TeamSeagrassID))
+
+	ds, err := stores.DataProductsStorage.CreateDataset(ctx, NewDatasetBiofuelConsumptionRates(fuel.ID), nil, UserOne)
+	require.NoError(t, err)
+
+	ar, err := stores.AccessStorage.CreateAccessRequestForDataset(ctx, ds.ID, uuid.NullUUID{}, "user:"+UserTwoEmail, "user:"+UserTwoEmail, nil)
+	require.NoError(t, err)
+
+	denyReason := "Mangler formål"
+
+	t.Run("Interaction with invalid signature", func(t *testing.T) {
+		body := url.Values{"payload": {denyReasonSubmission(t, ar.ID, UserOneEmail, denyReason)}}.Encode()
+
+		req := signedSlackRequest(t, server.URL, body)
+		req.Header.Set("X-Slack-Signature", "v0=deadbeef")
+
+		NewTester(t, server).Send(req).
+			HasStatusCode(http.StatusUnauthorized)
+	})
+
+	t.Run("Click deny button", func(t *testing.T) {
+		payload := Marshal(t, map[string]any{
+			"type":       "block_actions",
+			"trigger_id": "trigger",
+			"user":       map[string]any{"id": UserOneEmail},
+			"container":  map[string]any{"channel_id": "C123", "message_ts": "1234.5678"},
+			"actions": []map[string]any{
+				{
+					"action_id": string(service.SlackInteractionDenyAccessRequest),
+					"value":     ar.ID.String(),
+				},
+			},
+		})
+
+		body := url.Values{"payload": {string(payload)}}.Encode()
+
+		NewTester(t, server).Send(signedSlackRequest(t, server.URL, body)).
+			HasStatusCode(http.StatusOK)
+
+		got, err := stores.AccessStorage.GetAccessRequest(ctx, ar.ID)
+		require.NoError(t, err)
+		assert.Equal(t, service.AccessRequestStatusPending, got.Status)
+	})
+
+	t.Run("Deny access request without being in the owner group", func(t *testing.T) {
+		body := url.Values{"payload": {denyReasonSubmission(t, ar.ID, UserTwoEmail, denyReason)}}.Encode()
+
+		NewTester(t, server).Send(signedSlackRequest(t, server.URL, body)).
+			HasStatusCode(http.StatusOK)
+
+		got, err := stores.AccessStorage.GetAccessRequest(ctx, ar.ID)
+		require.NoError(t, err)
+		assert.Equal(t, service.AccessRequestStatusPending, got.Status)
+	})
+
+	t.Run("Deny access request with reason", func(t *testing.T) {
+		body := url.Values{"payload": {denyReasonSubmission(t, ar.ID, UserOneEmail, denyReason)}}.Encode()
+
+		NewTester(t, server).Send(signedSlackRequest(t, server.URL, body)).
+			HasStatusCode(http.StatusOK)
+
+		got, err := stores.AccessStorage.GetAccessRequest(ctx, ar.ID)
+		require.NoError(t, err)
+		assert.Equal(t, service.AccessRequestStatusDenied, got.Status)
+		require.NotNil(t, got.Reason)
+		assert.Equal(t, denyReason, *got.Reason)
+		require.NotNil(t, got.Granter)
+		assert.Equal(t, UserOneEmail, *got.Granter)
+	})
+}
+
+func denyReasonSubmission(t *testing.T, accessRequestID uuid.UUID, slackUserID, reason string) string {
+	t.Helper()
+
+	interaction := service.SlackInteraction{
+		AccessRequestID:  accessRequestID,
+		Channel:          "C123",
+		MessageTimestamp: "1234.5678",
+	}
+
+	payload := Marshal(t, map[string]any{
+		"type": "view_submission",
+		"user": map[string]any{"id": slackUserID},
+		"view": map[string]any{
+			"callback_id":      string(service.SlackInteractionDenyAccessRequestReason),
+			"private_metadata": interaction.Metadata(),
+			"state": map[string]any{
+				"values": map[string]any{
+					service.SlackDenyReasonInputID: map[string]any{
+						service.SlackDenyReasonInputID: map[string]any{
+							"type":  "plain_text_input",
+							"value": reason,
+						},
+					},
+				},
+			},
+		},
+	})
+
+	return string(payload)
+}
+
+func signedSlackRequest(t *testing.T, serverURL, body string) *http.Request {
+	t.Helper()
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(slackSigningSecret))
+	_, _ = mac.Write([]byte(fmt.Sprintf("v0:%s:%s", timestamp, body)))
+
+	req, err := http.NewRequest(http.MethodPost, serverURL+"/api/slack/interactions", strings.NewReader(body))
+	require.NoError(t, err)
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+
+	return req
+}
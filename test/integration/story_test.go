@@ -182,16 +182,38 @@ func TestStory(t *testing.T) {
 			Group:            story.Group,
 		}
 
+		etag := story.ETag()
 		story.Description = update.Description
 
 		got := &service.Story{}
 
 		NewTester(t, server).
+			Headers(map[string]string{"If-Match": etag}).
 			Put(update, "/api/stories/"+story.ID.String()).
 			HasStatusCode(http.StatusOK).
 			Expect(story, got, cmpopts.IgnoreFields(service.Story{}, "LastModified"))
 
 		story = got
+
+		update.Description = "This is an outdated description"
+
+		NewTester(t, server).
+			Headers(map[string]string{"If-Match": etag}).
+			Put(update, "/api/stories/"+story.ID.String()).
+			HasStatusCode(http.StatusPreconditionFailed)
+	})
+
+	t.Run("Update missing story with If-Match", func(t *testing.T) {
+		NewTester(t, server).
+			Headers(map[string]string{"If-Match": story.ETag()}).
+			Put(&service.UpdateStoryDto{Name: story.Name, Group: story.Group}, "/api/stories/"+uuid.New().String()).
+			HasStatusCode(http.StatusNotFound)
+	})
+
+	t.Run("Update story without If-Match", func(t *testing.T) {
+		NewTester(t, server).
+			Put(&service.UpdateStoryDto{Name: story.Name, Group: story.Group}, "/api/stories/"+story.ID.String()).
+			HasStatusCode(http.StatusPreconditionRequired)
 	})
 
 	t.Run("Get story with oauth", func(t *testing.T) {
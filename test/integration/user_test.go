@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"github.com/google/uuid"
 	"github.com/navikt/nada-backend/pkg/config/v2"
 	"github.com/navikt/nada-backend/pkg/database"
 	"github.com/navikt/nada-backend/pkg/service"
@@ -10,6 +11,7 @@ import (
 	"github.com/navikt/nada-backend/pkg/service/core/storage"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -75,9 +77,9 @@ func TestUserDataService(t *testing.T) {
 
 	server := httptest.NewServer(r)
 	defer server.Close()
-	// Would prefer to sort by dp.team_name, but it is always null
-	t.Run("User data products are sorted alphabetically by group_name and dp_name", func(t *testing.T) {
-		got := &service.UserInfo{}
+
+	t.Run("User data products are sorted alphabetically by name", func(t *testing.T) {
+		got := &service.Page[*service.Dataproduct]{}
 		expect := []service.Dataproduct{
 			{ID: feed.ID, Name: feed.Name, Owner: &service.DataproductOwner{Group: GroupEmailNada}},
 			{ID: fuel.ID, Name: fuel.Name, Owner: &service.DataproductOwner{Group: GroupEmailNada}},
@@ -85,27 +87,27 @@ func TestUserDataService(t *testing.T) {
 			{ID: reef.ID, Name: reef.Name, Owner: &service.DataproductOwner{Group: GroupEmailReef}},
 		}
 
-		NewTester(t, server).Get("/api/userData").
+		NewTester(t, server).Get("/api/userData/dataproducts").
 			HasStatusCode(http.StatusOK).Value(got)
 
-		if len(got.Dataproducts) != len(expect) {
-			t.Fatalf("got %d, expected %d", len(got.Dataproducts), len(expect))
+		if len(got.Items) != len(expect) {
+			t.Fatalf("got %d, expected %d", len(got.Items), len(expect))
 		}
-		for i := 0; i < len(got.Dataproducts); i++ {
-			if got.Dataproducts[i].ID != expect[i].ID {
-				t.Errorf("got %s, expected %s", got.Dataproducts[i].ID, expect[i].ID)
+		for i := 0; i < len(got.Items); i++ {
+			if got.Items[i].ID != expect[i].ID {
+				t.Errorf("got %s, expected %s", got.Items[i].ID, expect[i].ID)
 			}
-			if got.Dataproducts[i].Name != expect[i].Name {
-				t.Errorf("got %s, expected %s", got.Dataproducts[i].Name, expect[i].Name)
+			if got.Items[i].Name != expect[i].Name {
+				t.Errorf("got %s, expected %s", got.Items[i].Name, expect[i].Name)
 			}
-			if got.Dataproducts[i].Owner.Group != expect[i].Owner.Group {
-				t.Errorf("got %s, expected %s", got.Dataproducts[i].Owner.Group, expect[i].Owner.Group)
+			if got.Items[i].Owner.Group != expect[i].Owner.Group {
+				t.Errorf("got %s, expected %s", got.Items[i].Owner.Group, expect[i].Owner.Group)
 			}
 		}
 	})
-	// Would prefer to sort by team_name, but it is null in the view
-	t.Run("User insight products are sorted alphabetically by group and name", func(t *testing.T) {
-		got := &service.UserInfo{}
+
+	t.Run("User insight products are sorted alphabetically by name", func(t *testing.T) {
+		got := &service.Page[*service.InsightProduct]{}
 		expect := []service.InsightProduct{
 			{ID: feedInsights.ID, Name: feedInsights.Name, Group: GroupEmailNada},
 			{ID: fuelInsights.ID, Name: fuelInsights.Name, Group: GroupEmailNada},
@@ -113,27 +115,27 @@ func TestUserDataService(t *testing.T) {
 			{ID: reefInsights.ID, Name: reefInsights.Name, Group: GroupEmailReef},
 		}
 
-		NewTester(t, server).Get("/api/userData").
+		NewTester(t, server).Get("/api/userData/insightProducts").
 			HasStatusCode(http.StatusOK).Value(got)
 
-		if len(got.InsightProducts) != len(expect) {
-			t.Fatalf("got %d, expected %d", len(got.InsightProducts), len(expect))
+		if len(got.Items) != len(expect) {
+			t.Fatalf("got %d, expected %d", len(got.Items), len(expect))
 		}
-		for i := 0; i < len(got.InsightProducts); i++ {
-			if got.InsightProducts[i].ID != expect[i].ID {
-				t.Errorf("got %s, expected %s", got.InsightProducts[i].ID, expect[i].ID)
+		for i := 0; i < len(got.Items); i++ {
+			if got.Items[i].ID != expect[i].ID {
+				t.Errorf("got %s, expected %s", got.Items[i].ID, expect[i].ID)
 			}
-			if got.InsightProducts[i].Name != expect[i].Name {
-				t.Errorf("got %s, expected %s", got.InsightProducts[i].Name, expect[i].Name)
+			if got.Items[i].Name != expect[i].Name {
+				t.Errorf("got %s, expected %s", got.Items[i].Name, expect[i].Name)
 			}
-			if got.InsightProducts[i].Group != expect[i].Group {
-				t.Errorf("got %s, expected %s", got.InsightProducts[i].Group, expect[i].Group)
+			if got.Items[i].Group != expect[i].Group {
+				t.Errorf("got %s, expected %s", got.Items[i].Group, expect[i].Group)
 			}
 		}
 	})
 
-	t.Run("User stories are sorted alphabetically by group_name and name", func(t *testing.T) {
-		got := &service.UserInfo{}
+	t.Run("User stories are sorted alphabetically by name", func(t *testing.T) {
+		got := &service.Page[*service.Story]{}
 		expect := []service.Story{
 			{ID: feedStory.ID, Name: feedStory.Name, Group: GroupEmailNada},
 			{ID: fuelStory.ID, Name: fuelStory.Name, Group: GroupEmailNada},
@@ -141,22 +143,66 @@ func TestUserDataService(t *testing.T) {
 			{ID: reefStory.ID, Name: reefStory.Name, Group: GroupEmailReef},
 		}
 
-		NewTester(t, server).Get("/api/userData").
+		NewTester(t, server).Get("/api/userData/stories").
 			HasStatusCode(http.StatusOK).Value(got)
 
-		if len(got.Stories) != len(expect) {
-			t.Fatalf("got %d, expected %d", len(got.Stories), len(expect))
+		if len(got.Items) != len(expect) {
+			t.Fatalf("got %d, expected %d", len(got.Items), len(expect))
 		}
-		for i := 0; i < len(got.Stories); i++ {
-			if got.Stories[i].ID != expect[i].ID {
-				t.Errorf("got %s, expected %s", got.Stories[i].ID, expect[i].ID)
+		for i := 0; i < len(got.Items); i++ {
+			if got.Items[i].ID != expect[i].ID {
+				t.Errorf("got %s, expected %s", got.Items[i].ID, expect[i].ID)
 			}
-			if got.Stories[i].Name != expect[i].Name {
-				t.Errorf("got %s, expected %s", got.Stories[i].Name, expect[i].Name)
+			if got.Items[i].Name != expect[i].Name {
+				t.Errorf("got %s, expected %s", got.Items[i].Name, expect[i].Name)
 			}
-			if got.Stories[i].Group != expect[i].Group {
-				t.Errorf("got %s, expected %s", got.Stories[i].Group, expect[i].Group)
+			if got.Items[i].Group != expect[i].Group {
+				t.Errorf("got %s, expected %s", got.Items[i].Group, expect[i].Group)
 			}
 		}
 	})
+
+	t.Run("User data products are paginated", func(t *testing.T) {
+		first := &service.Page[*service.Dataproduct]{}
+		NewTester(t, server).Get("/api/userData/dataproducts", "limit", "3").
+			HasStatusCode(http.StatusOK).Value(first)
+
+		require.Len(t, first.Items, 3)
+		require.NotEmpty(t, first.NextCursor)
+		assert.Equal(t, feed.ID, first.Items[0].ID)
+
+		next := &service.Page[*service.Dataproduct]{}
+		NewTester(t, server).Get("/api/userData/dataproducts", "limit", "3", "cursor", first.NextCursor).
+			HasStatusCode(http.StatusOK).Value(next)
+
+		require.Len(t, next.Items, 1)
+		assert.Empty(t, next.NextCursor)
+		assert.Equal(t, reef.ID, next.Items[0].ID)
+	})
+
+	t.Run("User data products sorted by newest first", func(t *testing.T) {
+		got := &service.Page[*service.Dataproduct]{}
+		NewTester(t, server).Get("/api/userData/dataproducts", "sort", "created", "order", "desc").
+			HasStatusCode(http.StatusOK).Value(got)
+
+		var ids []uuid.UUID
+		for _, dp := range got.Items {
+			ids = append(ids, dp.ID)
+		}
+		assert.Equal(t, []uuid.UUID{reef.ID, feed.ID, barriers.ID, fuel.ID}, ids)
+	})
+
+	t.Run("Cursor for another sort order is rejected", func(t *testing.T) {
+		got := &service.Page[*service.Dataproduct]{}
+		NewTester(t, server).Get("/api/userData/dataproducts", "limit", "1").
+			HasStatusCode(http.StatusOK).Value(got)
+
+		NewTester(t, server).Get("/api/userData/dataproducts", "sort", "created", "cursor", got.NextCursor).
+			HasStatusCode(http.StatusBadRequest)
+	})
+
+	t.Run("Unknown sort field is rejected", func(t *testing.T) {
+		NewTester(t, server).Get("/api/userData/stories", "sort", "owner").
+			HasStatusCode(http.StatusBadRequest)
+	})
 }